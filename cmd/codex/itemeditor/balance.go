@@ -0,0 +1,173 @@
+package itemeditor
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Item balance report — within each item type, compare every item's price,
+// weight and (for weapons) average damage against the rest of its type and flag
+// the ones far from the mean, so a dagger priced like a greatsword stands out
+// during content entry. Not to be confused with HandleGetBalance, which is the
+// PixelLab account balance.
+
+// defaultOutlierThreshold is how many standard deviations from its type's mean
+// an item has to be to get flagged.
+const defaultOutlierThreshold = 2.0
+
+// BalanceOutlier is one flagged measurement of one item.
+type BalanceOutlier struct {
+	File      string  `json:"file"`
+	Name      string  `json:"name"`
+	Field     string  `json:"field"`     // "value", "weight" or "damage" (average roll)
+	Amount    float64 `json:"amount"`    // the item's own figure
+	Mean      float64 `json:"mean"`      // its type's mean
+	StdDev    float64 `json:"stddev"`    // its type's standard deviation
+	Deviation float64 `json:"deviation"` // (amount - mean) / stddev
+}
+
+// BalanceReport groups the flagged items by item type.
+type BalanceReport struct {
+	Threshold float64                     `json:"threshold"`
+	Types     map[string][]BalanceOutlier `json:"types"`
+}
+
+// HandleGetItemBalance reports price/weight/damage outliers per item type.
+// ?threshold= overrides the default of 2 standard deviations.
+func (e *Editor) HandleGetItemBalance(w http.ResponseWriter, r *http.Request) {
+	threshold := defaultOutlierThreshold
+	if raw := r.URL.Query().Get("threshold"); raw != "" {
+		t, err := strconv.ParseFloat(raw, 64)
+		if err != nil || t <= 0 {
+			http.Error(w, fmt.Sprintf("Invalid threshold: %q", raw), http.StatusBadRequest)
+			return
+		}
+		threshold = t
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(BuildBalanceReport(e.Items, threshold))
+}
+
+// BuildBalanceReport flags every item whose value, weight or average damage is
+// more than threshold standard deviations from the mean of its type. Items
+// without a type, and types where a figure doesn't vary, are skipped. With the
+// population deviation no item of an n-item type can sit more than √(n-1) out,
+// so small types only flag at lower thresholds.
+func BuildBalanceReport(items map[string]*Item, threshold float64) BalanceReport {
+	report := BalanceReport{Threshold: threshold, Types: map[string][]BalanceOutlier{}}
+
+	byType := map[string][]string{}
+	for filename, item := range items {
+		if item.Type != "" {
+			byType[item.Type] = append(byType[item.Type], filename)
+		}
+	}
+
+	measures := []struct {
+		field string
+		of    func(*Item) (float64, bool)
+	}{
+		{"value", func(item *Item) (float64, bool) { return float64(item.Value), true }},
+		{"weight", func(item *Item) (float64, bool) { return item.Weight, true }},
+		{"damage", func(item *Item) (float64, bool) { return averageDamage(item.Damage) }},
+	}
+
+	for itemType, filenames := range byType {
+		slices.Sort(filenames)
+		var flagged []BalanceOutlier
+		for _, m := range measures {
+			var files []string
+			var amounts []float64
+			for _, filename := range filenames {
+				if amount, ok := m.of(items[filename]); ok {
+					files = append(files, filename)
+					amounts = append(amounts, amount)
+				}
+			}
+			mean, stddev := meanStdDev(amounts)
+			if stddev == 0 {
+				continue
+			}
+			for i, amount := range amounts {
+				deviation := (amount - mean) / stddev
+				if math.Abs(deviation) > threshold {
+					flagged = append(flagged, BalanceOutlier{
+						File:      fmt.Sprintf("%s.json", files[i]),
+						Name:      items[files[i]].Name,
+						Field:     m.field,
+						Amount:    amount,
+						Mean:      mean,
+						StdDev:    stddev,
+						Deviation: deviation,
+					})
+				}
+			}
+		}
+		if len(flagged) > 0 {
+			// Worst first.
+			slices.SortStableFunc(flagged, func(a, b BalanceOutlier) int {
+				return cmp.Compare(math.Abs(b.Deviation), math.Abs(a.Deviation))
+			})
+			report.Types[itemType] = flagged
+		}
+	}
+
+	return report
+}
+
+// meanStdDev returns the mean and population standard deviation of xs.
+func meanStdDev(xs []float64) (mean, stddev float64) {
+	if len(xs) == 0 {
+		return 0, 0
+	}
+	for _, x := range xs {
+		mean += x
+	}
+	mean /= float64(len(xs))
+	for _, x := range xs {
+		stddev += (x - mean) * (x - mean)
+	}
+	return mean, math.Sqrt(stddev / float64(len(xs)))
+}
+
+// averageDamage returns the mean roll of a damage expression — "1d8", "2d6+1"
+// or a flat "1". Items without damage (anything but weapons) report false.
+func averageDamage(damage interface{}) (float64, bool) {
+	s, ok := damage.(string)
+	if !ok {
+		return 0, false
+	}
+	s = strings.ToLower(strings.ReplaceAll(s, " ", ""))
+	if s == "" {
+		return 0, false
+	}
+	mod := 0
+	if i := strings.IndexAny(s, "+-"); i > 0 {
+		m, err := strconv.Atoi(s[i+1:])
+		if err != nil {
+			return 0, false
+		}
+		if s[i] == '-' {
+			m = -m
+		}
+		mod, s = m, s[:i]
+	}
+	count, sides, found := strings.Cut(s, "d")
+	if !found {
+		flat, err := strconv.Atoi(s)
+		return float64(flat + mod), err == nil
+	}
+	n, err1 := strconv.Atoi(count)
+	d, err2 := strconv.Atoi(sides)
+	if err1 != nil || err2 != nil || n < 1 || d < 1 {
+		return 0, false
+	}
+	return float64(n)*float64(d+1)/2 + float64(mod), true
+}
@@ -0,0 +1,178 @@
+package itemeditor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"pubkey-quest/cmd/codex/validation"
+)
+
+// Bulk tag edits — add or remove tags on every item a filter selects, e.g.
+// rolling "two-handed" out across the existing heavy weapons. The preview lists
+// what each file would gain and lose; apply rewrites those files.
+
+// TagEditRequest is the body of the bulk tag endpoints.
+type TagEditRequest struct {
+	Filter     ItemFilter `json:"filter"`
+	AddTags    []string   `json:"addTags"`
+	RemoveTags []string   `json:"removeTags"`
+}
+
+// TagChange is what one item file gains and loses.
+type TagChange struct {
+	File    string   `json:"file"`
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+}
+
+// TagEditPreview lists the files a bulk tag edit will rewrite. Matching items
+// that already have the requested tags are counted but not listed.
+type TagEditPreview struct {
+	Matched int         `json:"matched"`
+	Changes []TagChange `json:"changes"`
+}
+
+// HandleTagEditPreview shows which item files a bulk tag edit would change
+func (e *Editor) HandleTagEditPreview(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeTagEdit(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(e.previewTagEdit(req))
+}
+
+// HandleTagEditApply applies a bulk tag edit to every matching item file
+func (e *Editor) HandleTagEditApply(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeTagEdit(w, r)
+	if !ok {
+		return
+	}
+
+	preview := e.previewTagEdit(req)
+	for _, change := range preview.Changes {
+		if err := rewriteItemTags(change); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// Reload items
+	e.LoadItems()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":  "success",
+		"changes": preview.Changes,
+	})
+}
+
+// decodeTagEdit reads and checks a bulk tag edit, answering 400 when it's unusable.
+func decodeTagEdit(w http.ResponseWriter, r *http.Request) (TagEditRequest, bool) {
+	var req TagEditRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return req, false
+	}
+	if err := req.validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return req, false
+	}
+	return req, true
+}
+
+// validate refuses edits that would touch the whole catalog or do nothing.
+func (req TagEditRequest) validate() error {
+	if req.Filter.IsEmpty() {
+		return fmt.Errorf("a filter (type, tag, rarity or q) is required")
+	}
+	if len(req.AddTags) == 0 && len(req.RemoveTags) == 0 {
+		return fmt.Errorf("nothing to do: addTags and removeTags are both empty")
+	}
+	for _, tag := range append(slices.Clone(req.AddTags), req.RemoveTags...) {
+		if tag == "" {
+			return fmt.Errorf("empty tag")
+		}
+	}
+	for _, tag := range req.AddTags {
+		if slices.Contains(req.RemoveTags, tag) {
+			return fmt.Errorf("tag %q is both added and removed", tag)
+		}
+	}
+	return nil
+}
+
+// previewTagEdit works out the change to each matching item.
+func (e *Editor) previewTagEdit(req TagEditRequest) TagEditPreview {
+	matched := e.matchingItems(req.Filter)
+	preview := TagEditPreview{Matched: len(matched), Changes: []TagChange{}}
+
+	for _, filename := range matched {
+		tags := e.Items[filename].Tags
+		change := TagChange{File: fmt.Sprintf("%s.json", filename)}
+		for _, tag := range req.AddTags {
+			if !slices.Contains(tags, tag) && !slices.Contains(change.Added, tag) {
+				change.Added = append(change.Added, tag)
+			}
+		}
+		for _, tag := range req.RemoveTags {
+			if slices.Contains(tags, tag) && !slices.Contains(change.Removed, tag) {
+				change.Removed = append(change.Removed, tag)
+			}
+		}
+		if len(change.Added) > 0 || len(change.Removed) > 0 {
+			preview.Changes = append(preview.Changes, change)
+		}
+	}
+
+	return preview
+}
+
+// rewriteItemTags applies one change to its item file. The file is edited as a
+// raw map, so properties the Item struct doesn't model survive, and written back
+// in the standard property order.
+func rewriteItemTags(change TagChange) error {
+	path := filepath.Join("game-data/items", change.File)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var item map[string]interface{}
+	if err := json.Unmarshal(data, &item); err != nil {
+		return fmt.Errorf("error parsing %s: %v", change.File, err)
+	}
+
+	existing, _ := item["tags"].([]interface{})
+	tags := []interface{}{}
+	for _, tag := range existing {
+		if name, ok := tag.(string); ok && slices.Contains(change.Removed, name) {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	for _, tag := range change.Added {
+		tags = append(tags, tag)
+	}
+	if len(tags) == 0 {
+		delete(item, "tags")
+	} else {
+		item["tags"] = tags
+	}
+
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(validation.OrderItemProperties(item)); err != nil {
+		return fmt.Errorf("failed to encode %s: %v", change.File, err)
+	}
+
+	return os.WriteFile(path, out.Bytes(), 0644)
+}
@@ -47,6 +47,7 @@ type Item struct {
 type Editor struct {
 	Items          map[string]*Item
 	PixelLabClient *pixellab.Client
+	ImageJobs      *pixellab.Queue
 	Config         interface{} // Will be *config.Config, using interface to avoid import cycle
 }
 
@@ -10,6 +10,8 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -28,10 +30,108 @@ func (e *Editor) HandleItemEditor(w http.ResponseWriter, r *http.Request) {
 	http.ServeFile(w, r, "cmd/codex/html/item-editor.html")
 }
 
-// HandleGetItems returns all items as JSON
+// ItemListResponse is one filtered page of the catalog from HandleGetItems.
+type ItemListResponse struct {
+	Items  map[string]*Item `json:"items"`  // filename → item, this page only
+	Total  int              `json:"total"`  // items matching the filters, before paging
+	Offset int              `json:"offset"` // index of the first item on this page
+	Limit  int              `json:"limit"`  // page size; 0 means no limit
+}
+
+// ItemFilter selects catalog items. Empty fields match anything; type and rarity
+// compare case-insensitively, the tag exactly, and Query is a case-insensitive
+// substring of the name or description.
+type ItemFilter struct {
+	Type   string `json:"type,omitempty"`
+	Tag    string `json:"tag,omitempty"`
+	Rarity string `json:"rarity,omitempty"`
+	Query  string `json:"q,omitempty"`
+}
+
+// IsEmpty reports whether the filter would match every item.
+func (f ItemFilter) IsEmpty() bool {
+	return f == ItemFilter{}
+}
+
+// Matches reports whether item passes every set field of the filter.
+func (f ItemFilter) Matches(item *Item) bool {
+	if f.Type != "" && !strings.EqualFold(item.Type, f.Type) {
+		return false
+	}
+	if f.Rarity != "" && !strings.EqualFold(item.Rarity, f.Rarity) {
+		return false
+	}
+	if f.Tag != "" && !slices.Contains(item.Tags, f.Tag) {
+		return false
+	}
+	if f.Query != "" {
+		needle := strings.ToLower(f.Query)
+		return strings.Contains(strings.ToLower(item.Name), needle) ||
+			strings.Contains(strings.ToLower(item.Description), needle)
+	}
+	return true
+}
+
+// matchingItems returns the filenames of the items passing filter, sorted.
+func (e *Editor) matchingItems(filter ItemFilter) []string {
+	var matched []string
+	for filename, item := range e.Items {
+		if filter.Matches(item) {
+			matched = append(matched, filename)
+		}
+	}
+	slices.Sort(matched)
+	return matched
+}
+
+// itemListParams are the query parameters that switch HandleGetItems from the
+// plain catalog to a filtered ItemListResponse.
+var itemListParams = []string{"type", "tag", "rarity", "q", "limit", "offset"}
+
+// HandleGetItems returns all items as JSON. With any of ?type=, ?tag=, ?rarity=,
+// ?q= (name/description substring), ?limit= or ?offset= it returns an
+// ItemListResponse instead: the matching items in filename order, one page of
+// them, and the total number that matched.
 func (e *Editor) HandleGetItems(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if !slices.ContainsFunc(itemListParams, query.Has) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(e.Items)
+		return
+	}
+
+	var offset, limit int
+	for name, dst := range map[string]*int{"offset": &offset, "limit": &limit} {
+		raw := query.Get(name)
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			http.Error(w, fmt.Sprintf("Invalid %s: %q", name, raw), http.StatusBadRequest)
+			return
+		}
+		*dst = n
+	}
+
+	matched := e.matchingItems(ItemFilter{
+		Type:   query.Get("type"),
+		Tag:    query.Get("tag"),
+		Rarity: query.Get("rarity"),
+		Query:  query.Get("q"),
+	})
+
+	page := matched[min(offset, len(matched)):]
+	if limit > 0 && len(page) > limit {
+		page = page[:limit]
+	}
+	resp := ItemListResponse{Items: make(map[string]*Item, len(page)), Total: len(matched), Offset: offset, Limit: limit}
+	for _, filename := range page {
+		resp.Items[filename] = e.Items[filename]
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(e.Items)
+	json.NewEncoder(w).Encode(resp)
 }
 
 // HandleGetItem returns a specific item
@@ -267,9 +367,10 @@ func (e *Editor) HandleGetBalance(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(balance)
 }
 
-// HandleGenerateImage generates an image for an item
+// HandleGenerateImage queues image generation for an item and returns the job
+// ID straight away; poll HandleImageStatus for the candidates.
 func (e *Editor) HandleGenerateImage(w http.ResponseWriter, r *http.Request) {
-	if e.PixelLabClient == nil {
+	if e.PixelLabClient == nil || e.ImageJobs == nil {
 		http.Error(w, "PixelLab client not initialized", http.StatusServiceUnavailable)
 		return
 	}
@@ -285,6 +386,7 @@ func (e *Editor) HandleGenerateImage(w http.ResponseWriter, r *http.Request) {
 
 	var req struct {
 		Model string `json:"model"`
+		Count int    `json:"count"` // candidates to generate (default 1, max maxGenerateCandidates)
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Invalid JSON", http.StatusBadRequest)
@@ -294,52 +396,263 @@ func (e *Editor) HandleGenerateImage(w http.ResponseWriter, r *http.Request) {
 	if req.Model == "" {
 		req.Model = "bitforge"
 	}
+	if req.Count < 1 {
+		req.Count = 1
+	}
+	if req.Count > maxGenerateCandidates {
+		req.Count = maxGenerateCandidates
+	}
 
-	log.Printf("🎨 Generating image for %s using %s...", item.Name, req.Model)
-
-	prompt := pixellab.GeneratePrompt(item.Name, item.Description, item.Rarity)
-	negativePrompt := pixellab.NegativePrompt()
-
-	result, err := e.PixelLabClient.GenerateImage(prompt, negativePrompt, req.Model)
+	job, err := e.queueGeneration(item, req.Model, req.Count)
 	if err != nil {
-		log.Printf("❌ Error generating image: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
 		return
 	}
 
-	// Save image with timestamp to history folder
-	timestamp := time.Now().Format("20060102_150405")
-	historyDir := filepath.Join("www/res/img/items/_history", item.ID)
-	if err := os.MkdirAll(historyDir, 0755); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	log.Printf("🎨 Queued %d image(s) for %s using %s (%s)", req.Count, item.Name, req.Model, job.ID)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"jobId":   job.ID,
+		"status":  job.Status,
+	})
+}
+
+// HandleGenerateMissingImages queues generation for every item without a sprite
+// at www/res/img/items/<id>.png — the images the validator warns about. Items
+// with nothing to build a prompt from, or with a job already waiting, are
+// skipped. The optional body takes the same model/count as HandleGenerateImage.
+func (e *Editor) HandleGenerateMissingImages(w http.ResponseWriter, r *http.Request) {
+	if e.PixelLabClient == nil || e.ImageJobs == nil {
+		http.Error(w, "PixelLab client not initialized", http.StatusServiceUnavailable)
 		return
 	}
 
-	historyFile := filepath.Join(historyDir, fmt.Sprintf("%s_%s.png", timestamp, req.Model))
-	imageData, err := base64.StdEncoding.DecodeString(result.Image.Base64)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+	var req struct {
+		Model string `json:"model"`
+		Count int    `json:"count"`
 	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid JSON", http.StatusBadRequest)
+			return
+		}
+	}
+	if req.Model == "" {
+		req.Model = "bitforge"
+	}
+	req.Count = min(max(req.Count, 1), maxGenerateCandidates)
 
-	if err := os.WriteFile(historyFile, imageData, 0644); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+	filenames := make([]string, 0, len(e.Items))
+	for filename := range e.Items {
+		filenames = append(filenames, filename)
 	}
+	slices.Sort(filenames)
 
-	log.Printf("✅ Image generated successfully ($%.4f) - saved to history", result.Usage.USD)
+	queued := []map[string]string{}
+	skipped := []map[string]string{}
+	for _, filename := range filenames {
+		item := e.Items[filename]
+		if _, err := os.Stat(filepath.Join(itemsImgDir, filename+".png")); !os.IsNotExist(err) {
+			continue
+		}
+		reason := ""
+		if item.Name == "" && len(item.Description) <= 10 {
+			reason = "no name or description to prompt from"
+		} else if job, ok := e.ImageJobs.Latest(item.ID); ok && (job.Status == pixellab.JobPending || job.Status == pixellab.JobRunning) {
+			reason = fmt.Sprintf("already %s as %s", job.Status, job.ID)
+		}
+		if reason != "" {
+			skipped = append(skipped, map[string]string{"file": filename, "reason": reason})
+			continue
+		}
+
+		job, err := e.queueGeneration(item, req.Model, req.Count)
+		if err != nil {
+			// The queue is full; everything after this would fail the same way.
+			skipped = append(skipped, map[string]string{"file": filename, "reason": err.Error()})
+			break
+		}
+		queued = append(queued, map[string]string{"file": filename, "jobId": job.ID})
+	}
+
+	log.Printf("🎨 Queued image generation for %d item(s) missing sprites (%d skipped)", len(queued), len(skipped))
 
 	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success":   true,
-		"cost":      result.Usage.USD,
-		"imagePath": historyFile,
-		"imageData": result.Image.Base64,
-		"prompt":    prompt,
+		"success": true,
+		"queued":  queued,
+		"skipped": skipped,
 	})
 }
 
-// HandleGetImage checks if an image exists for an item
+// queueGeneration enqueues a generation job for item under its ID.
+func (e *Editor) queueGeneration(item *Item, model string, count int) (pixellab.Job, error) {
+	// The job works from a copy: the item may be edited while it waits.
+	snapshot := *item
+	return e.ImageJobs.Enqueue(item.ID, func() (interface{}, error) {
+		return e.generateCandidates(&snapshot, model, count)
+	})
+}
+
+// HandleImageStatus reports an item's generation job: pending, running, done
+// (with the candidates) or failed (with the error). ?job= picks a job; without
+// it the item's newest job is reported.
+func (e *Editor) HandleImageStatus(w http.ResponseWriter, r *http.Request) {
+	job, ok := e.imageJob(w, r, r.URL.Query().Get("job"))
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(job)
+}
+
+// HandleRetryImage re-queues a failed generation job ({"jobId": ...}, or the
+// item's newest job when omitted).
+func (e *Editor) HandleRetryImage(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		JobID string `json:"jobId"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid JSON", http.StatusBadRequest)
+			return
+		}
+	}
+
+	job, ok := e.imageJob(w, r, req.JobID)
+	if !ok {
+		return
+	}
+	job, err := e.ImageJobs.Retry(job.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	log.Printf("🔁 Retrying image generation %s for %s", job.ID, job.Key)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(job)
+}
+
+// imageJob looks up jobID (or, when empty, the newest job) for the item named in
+// the route, answering with an error when there isn't one.
+func (e *Editor) imageJob(w http.ResponseWriter, r *http.Request, jobID string) (pixellab.Job, bool) {
+	if e.ImageJobs == nil {
+		http.Error(w, "PixelLab client not initialized", http.StatusServiceUnavailable)
+		return pixellab.Job{}, false
+	}
+
+	item, exists := e.Items[mux.Vars(r)["filename"]]
+	if !exists {
+		http.Error(w, "Item not found", http.StatusNotFound)
+		return pixellab.Job{}, false
+	}
+
+	job, found := e.ImageJobs.Latest(item.ID)
+	if jobID != "" {
+		job, found = e.ImageJobs.Get(jobID)
+	}
+	if !found || job.Key != item.ID {
+		http.Error(w, "No generation job for this item", http.StatusNotFound)
+		return pixellab.Job{}, false
+	}
+	return job, true
+}
+
+// generateCandidates runs one generation job: count images from PixelLab, each
+// saved to the item's history folder and staged as a pending candidate so
+// HandleAcceptImage can pick one by index. Candidates from earlier jobs stay
+// pending until accepted or discarded. A job that produces some images but not
+// all still succeeds, with the shortfall in "error".
+func (e *Editor) generateCandidates(item *Item, model string, count int) (map[string]interface{}, error) {
+	log.Printf("🎨 Generating %d image(s) for %s using %s...", count, item.Name, model)
+
+	prompt := pixellab.GeneratePrompt(item.Name, item.Description, item.Rarity)
+	negativePrompt := pixellab.NegativePrompt()
+
+	timestamp := time.Now().Format("20060102_150405")
+	historyDir := filepath.Join("www/res/img/items/_history", item.ID)
+	candidateDir := filepath.Join(itemsImgDir, "_candidates", item.ID)
+	for _, dir := range []string{historyDir, candidateDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		cost      float64
+		generated []string              // candidate names, in generation order
+		images    = map[string]string{} // candidate name → base64 PNG
+		genErr    error
+	)
+	for i := 1; i <= count; i++ {
+		result, err := e.PixelLabClient.GenerateImage(prompt, negativePrompt, model)
+		if err != nil {
+			log.Printf("❌ Error generating image %d/%d: %v", i, count, err)
+			genErr = err
+			break
+		}
+		imageData, err := base64.StdEncoding.DecodeString(result.Image.Base64)
+		if err != nil {
+			genErr = err
+			break
+		}
+
+		name := fmt.Sprintf("%s_%s_%d.png", timestamp, model, i)
+		if err := os.WriteFile(filepath.Join(historyDir, name), imageData, 0644); err != nil {
+			genErr = err
+			break
+		}
+		if err := os.WriteFile(filepath.Join(candidateDir, name), imageData, 0644); err != nil {
+			genErr = err
+			break
+		}
+		cost += result.Usage.USD
+		generated = append(generated, name)
+		images[name] = result.Image.Base64
+	}
+	if len(generated) == 0 {
+		return nil, genErr
+	}
+
+	log.Printf("✅ %d image(s) generated successfully ($%.4f) - saved to history and staged as candidates", len(generated), cost)
+
+	pending := pendingCandidates(item.ID)
+	candidates := []map[string]interface{}{}
+	for idx, name := range pending {
+		if data, ok := images[name]; ok {
+			candidates = append(candidates, map[string]interface{}{
+				"index":     idx,
+				"name":      name,
+				"url":       candidateURL(item.ID, name),
+				"imageData": data,
+			})
+		}
+	}
+
+	result := map[string]interface{}{
+		"cost":       cost,
+		"imagePath":  filepath.Join(historyDir, generated[0]),
+		"imageData":  images[generated[0]],
+		"prompt":     prompt,
+		"candidates": candidates,
+		"pending":    len(pending),
+	}
+	if genErr != nil {
+		result["error"] = fmt.Sprintf("generated %d of %d: %v", len(generated), count, genErr)
+	}
+	return result, nil
+}
+
+// HandleGetImage checks if an image exists for an item. With ?candidate=N it
+// returns pending candidate N instead (see HandleGenerateImage).
 func (e *Editor) HandleGetImage(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	filename := vars["filename"]
@@ -350,12 +663,37 @@ func (e *Editor) HandleGetImage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	pending := pendingCandidates(item.ID)
+
+	if raw := r.URL.Query().Get("candidate"); raw != "" {
+		idx, err := strconv.Atoi(raw)
+		if err != nil || idx < 0 || idx >= len(pending) {
+			http.Error(w, fmt.Sprintf("No pending candidate %s (%d pending)", raw, len(pending)), http.StatusNotFound)
+			return
+		}
+		data, err := os.ReadFile(filepath.Join(itemsImgDir, "_candidates", item.ID, pending[idx]))
+		if err != nil {
+			http.Error(w, "Candidate not found", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"exists":    true,
+			"index":     idx,
+			"name":      pending[idx],
+			"url":       candidateURL(item.ID, pending[idx]),
+			"imageData": base64.StdEncoding.EncodeToString(data),
+		})
+		return
+	}
+
 	// Check if image exists
 	imagePath := filepath.Join("www/res/img/items", item.ID+".png")
 	if _, err := os.Stat(imagePath); os.IsNotExist(err) {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
-			"exists": false,
+			"exists":     false,
+			"candidates": pending,
 		})
 		return
 	}
@@ -376,6 +714,7 @@ func (e *Editor) HandleGetImage(w http.ResponseWriter, r *http.Request) {
 		"exists":       true,
 		"path":         imagePath,
 		"historyFiles": historyFiles,
+		"candidates":   pending,
 	})
 }
 
@@ -418,7 +757,8 @@ func (e *Editor) HandleGetEffectTypes(w http.ResponseWriter, r *http.Request) {
 	w.Write(data)
 }
 
-// HandleAcceptImage accepts a generated image
+// HandleAcceptImage accepts a generated image: either the posted imageData, or
+// pending candidate `candidate` (an index into HandleGetImage's candidates).
 func (e *Editor) HandleAcceptImage(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	filename := vars["filename"]
@@ -431,17 +771,37 @@ func (e *Editor) HandleAcceptImage(w http.ResponseWriter, r *http.Request) {
 
 	var req struct {
 		ImageData string `json:"imageData"`
+		Candidate *int   `json:"candidate"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Invalid JSON", http.StatusBadRequest)
 		return
 	}
 
-	// Decode the base64 image data
-	imageData, err := base64.StdEncoding.DecodeString(req.ImageData)
-	if err != nil {
-		http.Error(w, "Invalid image data", http.StatusBadRequest)
-		return
+	var imageData []byte
+	if req.Candidate != nil {
+		pending := pendingCandidates(item.ID)
+		idx := *req.Candidate
+		if idx < 0 || idx >= len(pending) {
+			http.Error(w, fmt.Sprintf("No pending candidate %d (%d pending)", idx, len(pending)), http.StatusNotFound)
+			return
+		}
+		data, err := os.ReadFile(filepath.Join(itemsImgDir, "_candidates", item.ID, pending[idx]))
+		if err != nil {
+			http.Error(w, "Candidate not found", http.StatusNotFound)
+			return
+		}
+		imageData = data
+		backupSprite(item.ID)
+		log.Printf("✅ Accepting candidate %d (%s) for %s", idx, pending[idx], item.ID)
+	} else {
+		// Decode the base64 image data
+		data, err := base64.StdEncoding.DecodeString(req.ImageData)
+		if err != nil {
+			http.Error(w, "Invalid image data", http.StatusBadRequest)
+			return
+		}
+		imageData = data
 	}
 
 	// Save to main items directory
@@ -478,6 +838,32 @@ func backupSprite(itemID string) {
 	}
 }
 
+// maxGenerateCandidates caps how many images one generate call may request —
+// each is a paid PixelLab call.
+const maxGenerateCandidates = 4
+
+// pendingCandidates returns the names of the PNGs staged under
+// _candidates/<id>/, in filename order. A candidate's index is its position
+// here.
+func pendingCandidates(itemID string) []string {
+	names := []string{}
+	entries, err := os.ReadDir(filepath.Join(itemsImgDir, "_candidates", itemID))
+	if err != nil {
+		return names
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(strings.ToLower(entry.Name()), ".png") {
+			names = append(names, entry.Name())
+		}
+	}
+	return names
+}
+
+// candidateURL is where the codex serves a staged candidate.
+func candidateURL(itemID, name string) string {
+	return "/www/res/img/items/_candidates/" + itemID + "/" + name
+}
+
 // candidatePath resolves a candidate filename to a path inside the item's
 // candidate dir, rejecting empty names, path traversal, and non-PNGs.
 func candidatePath(itemID, name string) (string, bool) {
@@ -543,21 +929,13 @@ func (e *Editor) HandleListCandidates(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	type candidate struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
+		Index int    `json:"index"`
+		Name  string `json:"name"`
+		URL   string `json:"url"`
 	}
 	candidates := []candidate{}
-	dir := filepath.Join(itemsImgDir, "_candidates", item.ID)
-	if entries, err := os.ReadDir(dir); err == nil {
-		for _, entry := range entries {
-			if entry.IsDir() || !strings.HasSuffix(strings.ToLower(entry.Name()), ".png") {
-				continue
-			}
-			candidates = append(candidates, candidate{
-				Name: entry.Name(),
-				URL:  "/www/res/img/items/_candidates/" + item.ID + "/" + entry.Name(),
-			})
-		}
+	for idx, name := range pendingCandidates(item.ID) {
+		candidates = append(candidates, candidate{Index: idx, Name: name, URL: candidateURL(item.ID, name)})
 	}
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"html/template"
@@ -30,9 +31,13 @@ var cfg *config.Config
 // Version is set at build time via ldflags
 var Version = "dev"
 
+// imageGenerationWorkers caps how many PixelLab generations run at once.
+const imageGenerationWorkers = 2
+
 func main() {
 	// Command-line flags
 	migrateFlag := flag.Bool("migrate", false, "Run database migration and exit")
+	incrementalFlag := flag.Bool("incremental", false, "When used with --migrate, only re-migrate files changed since the last run")
 	validateFlag := flag.Bool("validate", false, "Run game data validation and exit")
 	checkSchemaFlag := flag.Bool("check-schema", false, "Run POI/encounter/quest draft schema check and exit")
 	checkConnectionsFlag := flag.Bool("check-connections", false, "Validate city↔environment world connectivity and exit")
@@ -40,6 +45,7 @@ func main() {
 	fixSchemaFlag := flag.Bool("fix-schema", false, "Apply legacy one-shot transforms to draft JSON (deprecated) and exit")
 	cleanupEffectsFlag := flag.Bool("cleanup-effects", false, "Migrate effects to new schema and exit")
 	dryRunFlag := flag.Bool("dry-run", false, "When used with cleanup flags, preview changes without modifying files")
+	undoCleanupFlag := flag.Bool("undo-cleanup", false, "Restore the files the last cleanup run overwrote and exit")
 	versionFlag := flag.Bool("version", false, "Print version and exit")
 	generateImagesFlag := flag.String("generate-images", "", "Generate sprite candidates from a batch spec JSON and exit (dev tool)")
 	configFlag := flag.String("config", "", "Path to config file (default: ./codex-config.yml)")
@@ -195,10 +201,27 @@ func main() {
 			fmt.Println("   Run without --dry-run to apply changes.")
 		} else {
 			fmt.Println("\n✅ Cleanup completed!")
+			if result.RunID != "" {
+				fmt.Printf("   Originals backed up (run %s) - undo with --undo-cleanup\n", result.RunID)
+			}
 		}
 		os.Exit(0)
 	}
 
+	// Handle undo-cleanup flag (no config required)
+	if *undoCleanupFlag {
+		result, err := validation.RestoreLastCleanup()
+		if err != nil {
+			fmt.Printf("❌ Undo failed: %v\n", err)
+			os.Exit(1)
+		}
+		for _, f := range result.Files {
+			fmt.Println(f)
+		}
+		fmt.Printf("✅ Restored %d file(s) from cleanup run %s\n", len(result.Files), result.RunID)
+		os.Exit(0)
+	}
+
 	// Handle validate flag (no config required)
 	if *validateFlag {
 		fmt.Println("🔍 Running game data validation...")
@@ -241,7 +264,11 @@ func main() {
 		fmt.Println("🔄 Running database migration...")
 		dbPath := "./www/game.db"
 
-		err := migration.Migrate(dbPath, func(status migration.Status) {
+		migrate := migration.Migrate
+		if *incrementalFlag {
+			migrate = migration.MigrateIncremental
+		}
+		err := migrate(dbPath, func(status migration.Status) {
 			if status.Progress > 0 {
 				fmt.Printf("  %s (%d/%d)\n", status.Message, status.Progress, status.Total)
 			} else {
@@ -279,6 +306,7 @@ func main() {
 	// Initialize PixelLab if API key is configured
 	if cfg.PixelLab.APIKey != "" {
 		editor.PixelLabClient = pixellab.NewClient(cfg.PixelLab.APIKey)
+		editor.ImageJobs = pixellab.NewQueue(imageGenerationWorkers)
 		log.Printf("✅ PixelLab client initialized")
 	}
 
@@ -355,6 +383,7 @@ func main() {
 	// Item editor routes
 	r.HandleFunc("/tools/item-editor", editor.HandleItemEditor).Methods("GET")
 	r.HandleFunc("/api/items", editor.HandleGetItems).Methods("GET")
+	r.HandleFunc("/api/items/generate-missing-images", editor.HandleGenerateMissingImages).Methods("POST")
 	r.HandleFunc("/api/items/{filename}", editor.HandleGetItem).Methods("GET")
 	r.HandleFunc("/api/items/{filename}", editor.HandleSaveItem).Methods("PUT")
 	r.HandleFunc("/api/items/{filename}", editor.HandleDeleteItem).Methods("DELETE")
@@ -363,9 +392,14 @@ func main() {
 	r.HandleFunc("/api/tags", editor.HandleGetTags).Methods("GET")
 	r.HandleFunc("/api/refactor/preview", editor.HandleRefactorPreview).Methods("POST")
 	r.HandleFunc("/api/refactor/apply", editor.HandleRefactorApply).Methods("POST")
+	r.HandleFunc("/api/refactor/tags/preview", editor.HandleTagEditPreview).Methods("POST")
+	r.HandleFunc("/api/refactor/tags/apply", editor.HandleTagEditApply).Methods("POST")
 	r.HandleFunc("/api/balance", editor.HandleGetBalance).Methods("GET")
+	r.HandleFunc("/api/item-balance", editor.HandleGetItemBalance).Methods("GET")
 	r.HandleFunc("/api/items/{filename}/generate-image", editor.HandleGenerateImage).Methods("POST")
 	r.HandleFunc("/api/items/{filename}/image", editor.HandleGetImage).Methods("GET")
+	r.HandleFunc("/api/items/{filename}/image-status", editor.HandleImageStatus).Methods("GET")
+	r.HandleFunc("/api/items/{filename}/image-retry", editor.HandleRetryImage).Methods("POST")
 	r.HandleFunc("/api/items/{filename}/accept-image", editor.HandleAcceptImage).Methods("POST")
 	// Sprite upload + candidate gallery (upload-only editor path; PixelLab is separate)
 	r.HandleFunc("/api/items/{filename}/upload-image", editor.HandleUploadImage).Methods("POST")
@@ -382,8 +416,14 @@ func main() {
 	r.HandleFunc("/tools/validation", handleValidationTool).Methods("GET")
 	r.HandleFunc("/api/validation/run", handleValidationRun).Methods("POST")
 	r.HandleFunc("/api/validation/cleanup", handleCleanupRun).Methods("POST")
+	r.HandleFunc("/api/validation/cleanup/effects", handleCleanupEffects).Methods("POST")
+	r.HandleFunc("/api/validation/cleanup/starting-gear", handleCleanupStartingGear).Methods("POST")
+	r.HandleFunc("/api/validation/cleanup/undo", handleCleanupUndo).Methods("POST")
 	r.HandleFunc("/api/validation/item/{itemId}", handleValidateOneItem).Methods("GET")
+	r.HandleFunc("/api/validation/category/{category}", handleValidateCategory).Methods("GET")
 	r.HandleFunc("/api/validation/schema", handleValidationSchema).Methods("POST")
+	r.HandleFunc("/api/schema/items", handleItemJSONSchema).Methods("GET")
+	r.HandleFunc("/api/schema/effects", handleEffectJSONSchema).Methods("GET")
 
 	// Staging routes
 	r.HandleFunc("/api/staging/init", staging.HandleStagingInit).Methods("POST")
@@ -493,6 +533,21 @@ func handleValidationRun(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(result)
 }
 
+func handleValidateCategory(w http.ResponseWriter, r *http.Request) {
+	result, err := validation.ValidateCategory(mux.Vars(r)["category"])
+	if errors.Is(err, validation.ErrUnknownCategory) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
 func handleValidateOneItem(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	itemID := vars["itemId"]
@@ -533,11 +588,81 @@ func handleValidationSchema(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(res)
 }
 
+// handleItemJSONSchema serves the JSON Schema for item files, built from the
+// item validator's rules.
+func handleItemJSONSchema(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/schema+json")
+	json.NewEncoder(w).Encode(validation.ItemJSONSchema())
+}
+
+// handleEffectJSONSchema serves the JSON Schema for effect files, built from
+// the effect validator's rules and the effect-type registry.
+func handleEffectJSONSchema(w http.ResponseWriter, r *http.Request) {
+	schema, err := validation.LoadEffectJSONSchema()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/schema+json")
+	json.NewEncoder(w).Encode(schema)
+}
+
+// handleCleanupRun cleans up every item file; dry_run=true previews the
+// changes without writing, safe=true applies only the fixes that can't be
+// wrong and reports the rest as skipped.
 func handleCleanupRun(w http.ResponseWriter, r *http.Request) {
 	// Check for dry_run parameter
 	dryRun := r.URL.Query().Get("dry_run") == "true"
+	safe := r.URL.Query().Get("safe") == "true"
 
-	result, err := validation.CleanupAllItems(dryRun)
+	result, err := validation.CleanupAllItems(dryRun, safe)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// handleCleanupEffects migrates effect files to the current schema, the HTTP
+// twin of --cleanup-effects; dry_run=true previews the changes without writing.
+func handleCleanupEffects(w http.ResponseWriter, r *http.Request) {
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+
+	result, err := validation.CleanupEffects(dryRun)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// handleCleanupStartingGear reorders the starting-gear fields into their
+// canonical order; dry_run=true previews the changes without writing.
+func handleCleanupStartingGear(w http.ResponseWriter, r *http.Request) {
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+
+	result, err := validation.CleanupStartingGear(dryRun)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// handleCleanupUndo restores the files overwritten by the most recent cleanup
+// run (items, effects or starting gear), the HTTP twin of --undo-cleanup.
+func handleCleanupUndo(w http.ResponseWriter, r *http.Request) {
+	result, err := validation.RestoreLastCleanup()
+	if errors.Is(err, validation.ErrNoCleanupRun) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -0,0 +1,204 @@
+package migration
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+// incremental is set for the duration of a MigrateIncremental run. A full
+// migration clears each table and reinserts every file; an incremental one
+// only rewrites rows whose source file changed since the last run (by content
+// hash) and deletes rows whose source file is gone.
+var incremental bool
+
+// MigrateIncremental performs the migration incrementally: unchanged files are
+// skipped, changed and new files are upserted, and rows whose source file no
+// longer exists are deleted. Falls back to inserting everything on a database
+// that has no recorded hashes yet.
+func MigrateIncremental(dbPath string, callback StatusCallback) error {
+	incremental = true
+	defer func() { incremental = false }()
+	return Migrate(dbPath, callback)
+}
+
+// fileHash is the recorded state of one source file.
+type fileHash struct {
+	rowID string
+	hash  string
+}
+
+// tableSync tracks one content table through a migration pass: which source
+// files were seen, the rows they produced, and their hashes. The whole pass runs
+// in one transaction, so a failure part way leaves the table as it was rather
+// than half-migrated.
+type tableSync struct {
+	table     string
+	tx        *sql.Tx
+	previous  map[string]fileHash // path → state at the last migration
+	current   map[string]fileHash // path → state now
+	rows      map[string]bool     // row IDs that have a source file
+	changed   int
+	unchanged int
+}
+
+// beginTable starts a migration pass over table in a new transaction. A full
+// migration clears the table; an incremental one loads the hashes recorded last
+// time. The caller defers rollback, which is a no-op once finish has committed.
+func beginTable(table string) (*tableSync, error) {
+	tx, err := database.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin %s transaction: %v", table, err)
+	}
+	s := &tableSync{
+		table:    table,
+		tx:       tx,
+		previous: make(map[string]fileHash),
+		current:  make(map[string]fileHash),
+		rows:     make(map[string]bool),
+	}
+
+	if !incremental {
+		if _, err := tx.Exec(fmt.Sprintf("DELETE FROM %s", table)); err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to clear %s table: %v", table, err)
+		}
+		return s, nil
+	}
+
+	if err := s.loadHashes(); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	return s, nil
+}
+
+// loadHashes reads the hashes the last migration recorded for the table.
+func (s *tableSync) loadHashes() error {
+	rows, err := s.tx.Query(`SELECT path, row_id, hash FROM migration_hashes WHERE table_name = ?`, s.table)
+	if err != nil {
+		return fmt.Errorf("failed to load hashes for %s: %v", s.table, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var path string
+		var h fileHash
+		if err := rows.Scan(&path, &h.rowID, &h.hash); err != nil {
+			return err
+		}
+		s.previous[path] = h
+	}
+	return rows.Err()
+}
+
+// rollback abandons the pass, leaving the table as it was before beginTable.
+func (s *tableSync) rollback() {
+	s.tx.Rollback()
+}
+
+// file migrates one source file with migrate, which upserts its row inside the
+// pass's transaction and returns the row ID. In incremental mode a file whose
+// content hash matches the last migration is skipped.
+func (s *tableSync) file(path string, migrate func(*sql.Tx, string) (string, error)) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return s.apply(path, contentHash(data), func() (string, error) {
+		return migrate(s.tx, path)
+	})
+}
+
+// apply records a source file already read and hashed, running insert (which
+// upserts its row and returns the row ID) unless incremental mode finds the
+// file unchanged since the last migration.
+func (s *tableSync) apply(path, hash string, insert func() (string, error)) error {
+	if prev, ok := s.previous[path]; ok && incremental && prev.hash == hash {
+		s.current[path] = prev
+		s.rows[prev.rowID] = true
+		s.unchanged++
+		return nil
+	}
+
+	id, err := insert()
+	if err != nil {
+		return err
+	}
+	s.current[path] = fileHash{rowID: id, hash: hash}
+	s.rows[id] = true
+	s.changed++
+	return nil
+}
+
+// contentHash is the hex SHA-256 of a source file's content.
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// finish records the hashes from this pass and, in incremental mode, deletes
+// the rows no source file produced this time, then commits the pass.
+func (s *tableSync) finish() error {
+	removed := 0
+	if incremental {
+		ids, err := s.tx.Query(fmt.Sprintf("SELECT id FROM %s", s.table))
+		if err != nil {
+			return fmt.Errorf("failed to list %s rows: %v", s.table, err)
+		}
+		var stale []string
+		for ids.Next() {
+			var id string
+			if err := ids.Scan(&id); err != nil {
+				ids.Close()
+				return err
+			}
+			if !s.rows[id] {
+				stale = append(stale, id)
+			}
+		}
+		ids.Close()
+
+		for _, id := range stale {
+			if _, err := s.tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE id = ?", s.table), id); err != nil {
+				return fmt.Errorf("failed to delete %s row %s: %v", s.table, id, err)
+			}
+		}
+		removed = len(stale)
+	}
+
+	if _, err := s.tx.Exec(`DELETE FROM migration_hashes WHERE table_name = ?`, s.table); err != nil {
+		return fmt.Errorf("failed to clear hashes for %s: %v", s.table, err)
+	}
+	for path, h := range s.current {
+		if _, err := s.tx.Exec(`INSERT INTO migration_hashes (table_name, path, row_id, hash) VALUES (?, ?, ?, ?)`,
+			s.table, path, h.rowID, h.hash); err != nil {
+			return fmt.Errorf("failed to record hash for %s: %v", path, err)
+		}
+	}
+	if err := s.tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit %s: %v", s.table, err)
+	}
+
+	if incremental {
+		log.Printf("  %s: %d changed, %d unchanged, %d removed", s.table, s.changed, s.unchanged, removed)
+	}
+	return nil
+}
+
+// upsert builds an INSERT for table that updates every other column when the
+// id already exists.
+func upsert(table string, columns ...string) string {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+	var updates []string
+	for _, c := range columns {
+		if c != "id" {
+			updates = append(updates, fmt.Sprintf("%s = excluded.%s", c, c))
+		}
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON CONFLICT(id) DO UPDATE SET %s",
+		table, strings.Join(columns, ", "), placeholders, strings.Join(updates, ", "))
+}
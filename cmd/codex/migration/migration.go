@@ -8,6 +8,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 
 	_ "modernc.org/sqlite"
@@ -29,7 +30,11 @@ type StatusCallback func(status Status)
 
 // Migrate performs the full database migration from JSON files
 func Migrate(dbPath string, callback StatusCallback) error {
-	log.Println("🔄 Starting database migration...")
+	if incremental {
+		log.Println("🔄 Starting incremental database migration...")
+	} else {
+		log.Println("🔄 Starting database migration...")
+	}
 
 	if callback != nil {
 		callback(Status{Step: "init", Message: "Initializing database connection"})
@@ -336,6 +341,16 @@ func createTables() error {
 			properties TEXT,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
 		)`,
+
+		// Content hash of every migrated source file and the row it produced,
+		// so an incremental migration can skip the files that haven't changed.
+		`CREATE TABLE IF NOT EXISTS migration_hashes (
+			table_name TEXT NOT NULL,
+			path TEXT NOT NULL,
+			row_id TEXT NOT NULL,
+			hash TEXT NOT NULL,
+			PRIMARY KEY (table_name, path)
+		)`,
 	}
 
 	for _, table := range tables {
@@ -461,6 +476,7 @@ func migrateCharacterData() error {
 		"base-hp.json":              "character_base_hp",
 		"generation-weights.json":   "generation_weights",
 		"introductions.json":        "introductions",
+		"playable.json":             "playable",
 		"starting-gear.json":        "starting_gear",
 		"starting-gold.json":        "starting_gold",
 		"starting-locations.json":   "starting_locations",
@@ -477,72 +493,118 @@ func migrateCharacterData() error {
 	return nil
 }
 
-// migrateItems migrates all item JSON files
+// migrateItems migrates all item JSON files. A pool of workers reads and parses
+// the files; the rows are inserted as they come in through one prepared
+// statement in the category's transaction, which stays on this goroutine along
+// with the progress callback.
 func migrateItems(callback StatusCallback) error {
 	log.Println("Migrating items...")
 
 	itemsPath := "game-data/items"
 
-	// Clear existing items
-	if _, err := database.Exec("DELETE FROM items"); err != nil {
-		return fmt.Errorf("failed to clear items table: %v", err)
+	sync, err := beginTable("items")
+	if err != nil {
+		return err
 	}
+	defer sync.rollback()
 
-	// Count total items first
-	totalItems := 0
-	filepath.WalkDir(itemsPath, func(path string, d fs.DirEntry, err error) error {
+	// Collect the files first (the count is the progress total)
+	var paths []string
+	err = filepath.WalkDir(itemsPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
 		if !d.IsDir() && strings.HasSuffix(path, ".json") {
-			totalItems++
+			paths = append(paths, path)
 		}
 		return nil
 	})
+	if err != nil {
+		return fmt.Errorf("failed to walk items directory: %v", err)
+	}
+	totalItems := len(paths)
+
+	stmt, err := sync.tx.Prepare(upsert("items", "id", "name", "description", "item_type", "properties", "tags", "rarity"))
+	if err != nil {
+		return fmt.Errorf("failed to prepare item insert: %v", err)
+	}
+	defer stmt.Close()
+
+	// Buffered for every file, so the workers never block on a consumer that
+	// has bailed out on an error
+	jobs := make(chan string, totalItems)
+	results := make(chan itemFile, totalItems)
+	for _, path := range paths {
+		jobs <- path
+	}
+	close(jobs)
+	for w := 0; w < runtime.NumCPU(); w++ {
+		go func() {
+			for path := range jobs {
+				results <- readItemFile(path)
+			}
+		}()
+	}
 
 	count := 0
-	err := filepath.WalkDir(itemsPath, func(path string, d fs.DirEntry, err error) error {
+	for range paths {
+		f := <-results
+		if f.err != nil {
+			return fmt.Errorf("failed to migrate item file %s: %v", f.path, f.err)
+		}
+		err := sync.apply(f.path, f.hash, func() (string, error) {
+			_, err := stmt.Exec(f.row...)
+			return f.id, err
+		})
 		if err != nil {
-			return err
+			return fmt.Errorf("failed to migrate item file %s: %v", f.path, err)
 		}
-
-		if !d.IsDir() && strings.HasSuffix(path, ".json") {
-			if err := migrateItemFile(path); err != nil {
-				log.Printf("Warning: failed to migrate item file %s: %v", path, err)
-			} else {
-				count++
-				if callback != nil && count%10 == 0 {
-					callback(Status{
-						Step:     "items",
-						Progress: count,
-						Total:    totalItems,
-						Message:  fmt.Sprintf("Migrated %d/%d items", count, totalItems),
-					})
-				}
-			}
+		count++
+		if callback != nil && count%10 == 0 {
+			callback(Status{
+				Step:     "items",
+				Progress: count,
+				Total:    totalItems,
+				Message:  fmt.Sprintf("Migrated %d/%d items", count, totalItems),
+			})
 		}
-		return nil
-	})
+	}
 
-	if err != nil {
-		return fmt.Errorf("failed to walk items directory: %v", err)
+	if err := sync.finish(); err != nil {
+		return err
 	}
 
 	log.Printf("Migrated %d items", count)
 	return nil
 }
 
-// migrateItemFile migrates a single item JSON file
-func migrateItemFile(filePath string) error {
+// itemFile is one item file as read and parsed by a migration worker.
+type itemFile struct {
+	path string
+	hash string
+	id   string
+	row  []interface{} // the items upsert args, in column order
+	err  error
+}
+
+// readItemFile reads and parses a single item JSON file into its row
+func readItemFile(filePath string) itemFile {
+	f := itemFile{path: filePath}
 	data, err := os.ReadFile(filePath)
 	if err != nil {
-		return err
+		f.err = err
+		return f
 	}
+	f.hash = contentHash(data)
 
 	var item map[string]interface{}
 	if err := json.Unmarshal(data, &item); err != nil {
-		return err
+		f.err = err
+		return f
 	}
 
 	// Extract base filename as ID
-	id := strings.TrimSuffix(filepath.Base(filePath), ".json")
+	f.id = strings.TrimSuffix(filepath.Base(filePath), ".json")
 
 	// Convert item data to required fields
 	name, _ := item["name"].(string)
@@ -556,9 +618,8 @@ func migrateItemFile(filePath string) error {
 	// Serialize all properties as JSON for the properties field
 	propertiesJSON, _ := json.Marshal(item)
 
-	stmt := `INSERT INTO items (id, name, description, item_type, properties, tags, rarity) VALUES (?, ?, ?, ?, ?, ?, ?)`
-	_, err = database.Exec(stmt, id, name, description, itemType, string(propertiesJSON), string(tagsJSON), rarity)
-	return err
+	f.row = []interface{}{f.id, name, description, itemType, string(propertiesJSON), string(tagsJSON), rarity}
+	return f
 }
 
 // migrateSpells migrates all spell JSON files
@@ -567,10 +628,11 @@ func migrateSpells(callback StatusCallback) error {
 
 	spellsPath := "game-data/magic/spells"
 
-	// Clear existing spells
-	if _, err := database.Exec("DELETE FROM spells"); err != nil {
-		return fmt.Errorf("failed to clear spells table: %v", err)
+	sync, err := beginTable("spells")
+	if err != nil {
+		return err
 	}
+	defer sync.rollback()
 
 	// Count total spells first
 	totalSpells := 0
@@ -582,24 +644,23 @@ func migrateSpells(callback StatusCallback) error {
 	})
 
 	count := 0
-	err := filepath.WalkDir(spellsPath, func(path string, d fs.DirEntry, err error) error {
+	err = filepath.WalkDir(spellsPath, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
 
 		if !d.IsDir() && strings.HasSuffix(path, ".json") {
-			if err := migrateSpellFile(path); err != nil {
-				log.Printf("Warning: failed to migrate spell file %s: %v", path, err)
-			} else {
-				count++
-				if callback != nil && count%10 == 0 {
-					callback(Status{
-						Step:     "spells",
-						Progress: count,
-						Total:    totalSpells,
-						Message:  fmt.Sprintf("Migrated %d/%d spells", count, totalSpells),
-					})
-				}
+			if err := sync.file(path, migrateSpellFile); err != nil {
+				return fmt.Errorf("failed to migrate spell file %s: %v", path, err)
+			}
+			count++
+			if callback != nil && count%10 == 0 {
+				callback(Status{
+					Step:     "spells",
+					Progress: count,
+					Total:    totalSpells,
+					Message:  fmt.Sprintf("Migrated %d/%d spells", count, totalSpells),
+				})
 			}
 		}
 		return nil
@@ -609,20 +670,24 @@ func migrateSpells(callback StatusCallback) error {
 		return fmt.Errorf("failed to walk spells directory: %v", err)
 	}
 
+	if err := sync.finish(); err != nil {
+		return err
+	}
+
 	log.Printf("Migrated %d spells", count)
 	return nil
 }
 
 // migrateSpellFile migrates a single spell JSON file
-func migrateSpellFile(filePath string) error {
+func migrateSpellFile(tx *sql.Tx, filePath string) (string, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	var spell map[string]interface{}
 	if err := json.Unmarshal(data, &spell); err != nil {
-		return err
+		return "", err
 	}
 
 	// Extract base filename as ID
@@ -650,10 +715,9 @@ func migrateSpellFile(filePath string) error {
 	// Serialize all properties as JSON for the properties field
 	propertiesJSON, _ := json.Marshal(spell)
 
-	stmt := `INSERT INTO spells (id, name, description, level, school, damage, mana_cost, classes, concentration, tags, properties)
-	         VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
-	_, err = database.Exec(stmt, id, name, description, int(level), school, damage, manaCost, string(classesJSON), concentrationVal, string(tagsJSON), string(propertiesJSON))
-	return err
+	stmt := upsert("spells", "id", "name", "description", "level", "school", "damage", "mana_cost", "classes", "concentration", "tags", "properties")
+	_, err = tx.Exec(stmt, id, name, description, int(level), school, damage, manaCost, string(classesJSON), concentrationVal, string(tagsJSON), string(propertiesJSON))
+	return id, err
 }
 
 // migrateContentData migrates monsters, locations, and other content
@@ -682,13 +746,14 @@ func migrateContentData(callback StatusCallback) error {
 func migrateMonsters(callback StatusCallback) error {
 	monstersPath := "game-data/monsters"
 
-	// Clear existing monsters
-	if _, err := database.Exec("DELETE FROM monsters"); err != nil {
-		return fmt.Errorf("failed to clear monsters table: %v", err)
+	sync, err := beginTable("monsters")
+	if err != nil {
+		return err
 	}
+	defer sync.rollback()
 
 	count := 0
-	err := filepath.WalkDir(monstersPath, func(path string, d fs.DirEntry, err error) error {
+	err = filepath.WalkDir(monstersPath, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -699,11 +764,10 @@ func migrateMonsters(callback StatusCallback) error {
 		}
 
 		if !d.IsDir() && strings.HasSuffix(path, ".json") {
-			if err := migrateMonsterFile(path); err != nil {
-				log.Printf("Warning: failed to migrate monster file %s: %v", path, err)
-			} else {
-				count++
+			if err := sync.file(path, migrateMonsterFile); err != nil {
+				return fmt.Errorf("failed to migrate monster file %s: %v", path, err)
 			}
+			count++
 		}
 		return nil
 	})
@@ -712,20 +776,24 @@ func migrateMonsters(callback StatusCallback) error {
 		return fmt.Errorf("failed to walk monsters directory: %v", err)
 	}
 
+	if err := sync.finish(); err != nil {
+		return err
+	}
+
 	log.Printf("Migrated %d monsters", count)
 	return nil
 }
 
 // migrateMonsterFile migrates a single monster JSON file
-func migrateMonsterFile(filePath string) error {
+func migrateMonsterFile(tx *sql.Tx, filePath string) (string, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	var monster map[string]interface{}
 	if err := json.Unmarshal(data, &monster); err != nil {
-		return err
+		return "", err
 	}
 
 	// Extract base filename as ID
@@ -743,19 +811,20 @@ func migrateMonsterFile(filePath string) error {
 	}
 	actionsJSON, _ := json.Marshal(actions)
 
-	stmt := `INSERT INTO monsters (id, name, challenge_rating, stats, actions) VALUES (?, ?, ?, ?, ?)`
-	_, err = database.Exec(stmt, id, name, challengeRating, string(statsJSON), string(actionsJSON))
-	return err
+	stmt := upsert("monsters", "id", "name", "challenge_rating", "stats", "actions")
+	_, err = tx.Exec(stmt, id, name, challengeRating, string(statsJSON), string(actionsJSON))
+	return id, err
 }
 
 // migrateLocations migrates location data
 func migrateLocations(callback StatusCallback) error {
 	locationsPath := "game-data/locations"
 
-	// Clear existing locations
-	if _, err := database.Exec("DELETE FROM locations"); err != nil {
-		return fmt.Errorf("failed to clear locations table: %v", err)
+	sync, err := beginTable("locations")
+	if err != nil {
+		return err
 	}
+	defer sync.rollback()
 
 	count := 0
 
@@ -770,34 +839,37 @@ func migrateLocations(callback StatusCallback) error {
 
 			if !d.IsDir() && strings.HasSuffix(path, ".json") {
 				locationType := subDir[:len(subDir)-1] // Remove 's' from cities/environments
-				if err := migrateLocationFile(path, locationType); err != nil {
-					log.Printf("Warning: failed to migrate location file %s: %v", path, err)
-				} else {
-					count++
+				if err := sync.file(path, func(tx *sql.Tx, path string) (string, error) { return migrateLocationFile(tx, path, locationType) }); err != nil {
+					return fmt.Errorf("failed to migrate location file %s: %v", path, err)
 				}
+				count++
 			}
 			return nil
 		})
 
 		if err != nil {
-			log.Printf("Warning: failed to walk %s directory: %v", subDir, err)
+			return fmt.Errorf("failed to walk %s directory: %v", subDir, err)
 		}
 	}
 
+	if err := sync.finish(); err != nil {
+		return err
+	}
+
 	log.Printf("Migrated %d locations", count)
 	return nil
 }
 
 // migrateLocationFile migrates a single location JSON file
-func migrateLocationFile(filePath, locationType string) error {
+func migrateLocationFile(tx *sql.Tx, filePath, locationType string) (string, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	var location map[string]interface{}
 	if err := json.Unmarshal(data, &location); err != nil {
-		return err
+		return "", err
 	}
 
 	// Extract base filename as ID
@@ -812,25 +884,25 @@ func migrateLocationFile(filePath, locationType string) error {
 	propertiesJSON, _ := json.Marshal(location)
 	connectionsJSON, _ := json.Marshal(location["connections"])
 
-	stmt := `INSERT INTO locations (id, name, location_type, description, image, music, properties, connections)
-			 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
-	_, err = database.Exec(stmt, id, name, locationType, description, image, music, string(propertiesJSON), string(connectionsJSON))
-	return err
+	stmt := upsert("locations", "id", "name", "location_type", "description", "image", "music", "properties", "connections")
+	_, err = tx.Exec(stmt, id, name, locationType, description, image, music, string(propertiesJSON), string(connectionsJSON))
+	return id, err
 }
 
 // migrateNPCs migrates all NPC JSON files from all location subdirectories
 func migrateNPCs(callback StatusCallback) error {
 	npcsPath := "game-data/npcs"
 
-	// Clear existing NPCs
-	if _, err := database.Exec("DELETE FROM npcs"); err != nil {
-		return fmt.Errorf("failed to clear npcs table: %v", err)
+	sync, err := beginTable("npcs")
+	if err != nil {
+		return err
 	}
+	defer sync.rollback()
 
 	count := 0
 
 	// Walk through all subdirectories (kingdom, millhaven, etc.)
-	err := filepath.WalkDir(npcsPath, func(path string, d fs.DirEntry, err error) error {
+	err = filepath.WalkDir(npcsPath, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -840,11 +912,10 @@ func migrateNPCs(callback StatusCallback) error {
 			relPath, _ := filepath.Rel(npcsPath, path)
 			locationFolder := filepath.Dir(relPath)
 
-			if err := migrateNPCFile(path, locationFolder); err != nil {
-				log.Printf("Warning: failed to migrate NPC file %s: %v", path, err)
-			} else {
-				count++
+			if err := sync.file(path, func(tx *sql.Tx, path string) (string, error) { return migrateNPCFile(tx, path, locationFolder) }); err != nil {
+				return fmt.Errorf("failed to migrate NPC file %s: %v", path, err)
 			}
+			count++
 		}
 		return nil
 	})
@@ -853,20 +924,24 @@ func migrateNPCs(callback StatusCallback) error {
 		return fmt.Errorf("failed to walk NPCs directory: %v", err)
 	}
 
+	if err := sync.finish(); err != nil {
+		return err
+	}
+
 	log.Printf("Migrated %d NPCs", count)
 	return nil
 }
 
 // migrateNPCFile migrates a single NPC JSON file
-func migrateNPCFile(filePath, locationFromPath string) error {
+func migrateNPCFile(tx *sql.Tx, filePath, locationFromPath string) (string, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	var npc map[string]interface{}
 	if err := json.Unmarshal(data, &npc); err != nil {
-		return err
+		return "", err
 	}
 
 	// Extract base filename as ID
@@ -885,33 +960,32 @@ func migrateNPCFile(filePath, locationFromPath string) error {
 	// Serialize all properties as JSON
 	propertiesJSON, _ := json.Marshal(npc)
 
-	stmt := `INSERT INTO npcs (id, name, title, race, location, building, description, properties)
-	         VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
-	_, err = database.Exec(stmt, id, name, title, race, location, building, description, string(propertiesJSON))
-	return err
+	stmt := upsert("npcs", "id", "name", "title", "race", "location", "building", "description", "properties")
+	_, err = tx.Exec(stmt, id, name, title, race, location, building, description, string(propertiesJSON))
+	return id, err
 }
 
 // migrateEffects migrates all effect JSON files
 func migrateEffects(callback StatusCallback) error {
 	effectsPath := "game-data/effects"
 
-	// Clear existing effects
-	if _, err := database.Exec("DELETE FROM effects"); err != nil {
-		return fmt.Errorf("failed to clear effects table: %v", err)
+	sync, err := beginTable("effects")
+	if err != nil {
+		return err
 	}
+	defer sync.rollback()
 
 	count := 0
-	err := filepath.WalkDir(effectsPath, func(path string, d fs.DirEntry, err error) error {
+	err = filepath.WalkDir(effectsPath, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
 
 		if !d.IsDir() && strings.HasSuffix(path, ".json") {
-			if err := migrateEffectFile(path); err != nil {
-				log.Printf("Warning: failed to migrate effect file %s: %v", path, err)
-			} else {
-				count++
+			if err := sync.file(path, migrateEffectFile); err != nil {
+				return fmt.Errorf("failed to migrate effect file %s: %v", path, err)
 			}
+			count++
 		}
 		return nil
 	})
@@ -920,20 +994,24 @@ func migrateEffects(callback StatusCallback) error {
 		return fmt.Errorf("failed to walk effects directory: %v", err)
 	}
 
+	if err := sync.finish(); err != nil {
+		return err
+	}
+
 	log.Printf("Migrated %d effects", count)
 	return nil
 }
 
 // migrateEffectFile migrates a single effect JSON file
-func migrateEffectFile(filePath string) error {
+func migrateEffectFile(tx *sql.Tx, filePath string) (string, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	var effect map[string]interface{}
 	if err := json.Unmarshal(data, &effect); err != nil {
-		return err
+		return "", err
 	}
 
 	// Extract base filename as ID
@@ -947,34 +1025,34 @@ func migrateEffectFile(filePath string) error {
 	// Serialize all properties as JSON
 	propertiesJSON, _ := json.Marshal(effect)
 
-	stmt := `INSERT INTO effects (id, name, description, source_type, properties) VALUES (?, ?, ?, ?, ?)`
-	_, err = database.Exec(stmt, id, name, description, sourceType, string(propertiesJSON))
-	return err
+	stmt := upsert("effects", "id", "name", "description", "source_type", "properties")
+	_, err = tx.Exec(stmt, id, name, description, sourceType, string(propertiesJSON))
+	return id, err
 }
 
 // migrateAbilities migrates all ability JSON files from class subdirectories
 func migrateAbilities(callback StatusCallback) error {
 	abilitiesPath := "game-data/systems/abilities"
 
-	// Clear existing abilities
-	if _, err := database.Exec("DELETE FROM abilities"); err != nil {
-		return fmt.Errorf("failed to clear abilities table: %v", err)
+	sync, err := beginTable("abilities")
+	if err != nil {
+		return err
 	}
+	defer sync.rollback()
 
 	count := 0
 
 	// Walk through class subdirectories (fighter, barbarian, monk, rogue)
-	err := filepath.WalkDir(abilitiesPath, func(path string, d fs.DirEntry, err error) error {
+	err = filepath.WalkDir(abilitiesPath, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
 
 		if !d.IsDir() && strings.HasSuffix(path, ".json") {
-			if err := migrateAbilityFile(path); err != nil {
-				log.Printf("Warning: failed to migrate ability file %s: %v", path, err)
-			} else {
-				count++
+			if err := sync.file(path, migrateAbilityFile); err != nil {
+				return fmt.Errorf("failed to migrate ability file %s: %v", path, err)
 			}
+			count++
 		}
 		return nil
 	})
@@ -983,20 +1061,24 @@ func migrateAbilities(callback StatusCallback) error {
 		return fmt.Errorf("failed to walk abilities directory: %v", err)
 	}
 
+	if err := sync.finish(); err != nil {
+		return err
+	}
+
 	log.Printf("Migrated %d abilities", count)
 	return nil
 }
 
 // migrateAbilityFile migrates a single ability JSON file
-func migrateAbilityFile(filePath string) error {
+func migrateAbilityFile(tx *sql.Tx, filePath string) (string, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	var ability map[string]interface{}
 	if err := json.Unmarshal(data, &ability); err != nil {
-		return err
+		return "", err
 	}
 
 	// Extract base filename as ID
@@ -1016,31 +1098,31 @@ func migrateAbilityFile(filePath string) error {
 	// Serialize all properties as JSON (includes scaling_tiers, effects_applied, etc.)
 	propertiesJSON, _ := json.Marshal(ability)
 
-	stmt := `INSERT INTO abilities (id, name, class, unlock_level, resource_cost, resource_type, cooldown, description, properties)
-	         VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
-	_, err = database.Exec(stmt, id, name, class, unlockLevel, resourceCost, resourceType, cooldown, description, string(propertiesJSON))
-	return err
+	stmt := upsert("abilities", "id", "name", "class", "unlock_level", "resource_cost", "resource_type", "cooldown", "description", "properties")
+	_, err = tx.Exec(stmt, id, name, class, unlockLevel, resourceCost, resourceType, cooldown, description, string(propertiesJSON))
+	return id, err
 }
 
 // migrateFeats loads selectable feats from the flat game-data/systems/feats/ dir.
 func migrateFeats(callback StatusCallback) error {
 	featsPath := "game-data/systems/feats"
 
-	if _, err := database.Exec("DELETE FROM feats"); err != nil {
-		return fmt.Errorf("failed to clear feats table: %v", err)
+	sync, err := beginTable("feats")
+	if err != nil {
+		return err
 	}
+	defer sync.rollback()
 
 	count := 0
-	err := filepath.WalkDir(featsPath, func(path string, d fs.DirEntry, err error) error {
+	err = filepath.WalkDir(featsPath, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
 		if !d.IsDir() && strings.HasSuffix(path, ".json") {
-			if err := migrateFeatFile(path); err != nil {
-				log.Printf("Warning: failed to migrate feat file %s: %v", path, err)
-			} else {
-				count++
+			if err := sync.file(path, migrateFeatFile); err != nil {
+				return fmt.Errorf("failed to migrate feat file %s: %v", path, err)
 			}
+			count++
 		}
 		return nil
 	})
@@ -1048,20 +1130,24 @@ func migrateFeats(callback StatusCallback) error {
 		return fmt.Errorf("failed to walk feats directory: %v", err)
 	}
 
+	if err := sync.finish(); err != nil {
+		return err
+	}
+
 	log.Printf("Migrated %d feats", count)
 	return nil
 }
 
 // migrateFeatFile migrates a single feat JSON file. The whole JSON is stored in the
 // properties column (stat_grant, hp_per_level, effects, prerequisite).
-func migrateFeatFile(filePath string) error {
+func migrateFeatFile(tx *sql.Tx, filePath string) (string, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
-		return err
+		return "", err
 	}
 	var feat map[string]interface{}
 	if err := json.Unmarshal(data, &feat); err != nil {
-		return err
+		return "", err
 	}
 	id, _ := feat["id"].(string)
 	if id == "" {
@@ -1071,9 +1157,9 @@ func migrateFeatFile(filePath string) error {
 	description, _ := feat["description"].(string)
 	propertiesJSON, _ := json.Marshal(feat)
 
-	stmt := `INSERT INTO feats (id, name, description, properties) VALUES (?, ?, ?, ?)`
-	_, err = database.Exec(stmt, id, name, description, string(propertiesJSON))
-	return err
+	stmt := upsert("feats", "id", "name", "description", "properties")
+	_, err = tx.Exec(stmt, id, name, description, string(propertiesJSON))
+	return id, err
 }
 
 // migrateNarrativeContent loads the M3 quest / POI / encounter content. These
@@ -1098,12 +1184,14 @@ func migrateNarrativeContent(callback StatusCallback) error {
 func migrateQuests() error {
 	questsPath := "game-data/quests-drafts"
 
-	if _, err := database.Exec("DELETE FROM quests"); err != nil {
-		return fmt.Errorf("failed to clear quests table: %v", err)
+	sync, err := beginTable("quests")
+	if err != nil {
+		return err
 	}
+	defer sync.rollback()
 
 	count := 0
-	err := filepath.WalkDir(questsPath, func(path string, d fs.DirEntry, err error) error {
+	err = filepath.WalkDir(questsPath, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -1113,31 +1201,34 @@ func migrateQuests() error {
 		if filepath.Base(path) == "template.json" {
 			return nil
 		}
-		if err := migrateQuestFile(path); err != nil {
-			log.Printf("Warning: failed to migrate quest file %s: %v", path, err)
-		} else {
-			count++
+		if err := sync.file(path, migrateQuestFile); err != nil {
+			return fmt.Errorf("failed to migrate quest file %s: %v", path, err)
 		}
+		count++
 		return nil
 	})
 	if err != nil {
 		return fmt.Errorf("failed to walk quests directory: %v", err)
 	}
 
+	if err := sync.finish(); err != nil {
+		return err
+	}
+
 	log.Printf("Migrated %d quests", count)
 	return nil
 }
 
 // migrateQuestFile migrates a single quest JSON file.
-func migrateQuestFile(filePath string) error {
+func migrateQuestFile(tx *sql.Tx, filePath string) (string, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	var quest map[string]interface{}
 	if err := json.Unmarshal(data, &quest); err != nil {
-		return err
+		return "", err
 	}
 
 	// Quests reference each other by id (prerequisites); prefer the in-file id.
@@ -1156,53 +1247,57 @@ func migrateQuestFile(filePath string) error {
 
 	propertiesJSON, _ := json.Marshal(quest)
 
-	stmt := `INSERT INTO quests (id, name, category, difficulty, total_qp, is_randomized, properties)
-	         VALUES (?, ?, ?, ?, ?, ?, ?)`
-	_, err = database.Exec(stmt, id, name, category, difficulty, int(totalQP), isRandomized, string(propertiesJSON))
-	return err
+	stmt := upsert("quests", "id", "name", "category", "difficulty", "total_qp", "is_randomized", "properties")
+	_, err = tx.Exec(stmt, id, name, category, difficulty, int(totalQP), isRandomized, string(propertiesJSON))
+	return id, err
 }
 
 // migratePOIs loads every POI draft.
 func migratePOIs() error {
 	poisPath := "game-data/locations/poi-draft"
 
-	if _, err := database.Exec("DELETE FROM pois"); err != nil {
-		return fmt.Errorf("failed to clear pois table: %v", err)
+	sync, err := beginTable("pois")
+	if err != nil {
+		return err
 	}
+	defer sync.rollback()
 
 	count := 0
-	err := filepath.WalkDir(poisPath, func(path string, d fs.DirEntry, err error) error {
+	err = filepath.WalkDir(poisPath, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
 		if d.IsDir() || !strings.HasSuffix(path, ".json") {
 			return nil
 		}
-		if err := migratePOIFile(path); err != nil {
-			log.Printf("Warning: failed to migrate POI file %s: %v", path, err)
-		} else {
-			count++
+		if err := sync.file(path, migratePOIFile); err != nil {
+			return fmt.Errorf("failed to migrate POI file %s: %v", path, err)
 		}
+		count++
 		return nil
 	})
 	if err != nil {
 		return fmt.Errorf("failed to walk POI directory: %v", err)
 	}
 
+	if err := sync.finish(); err != nil {
+		return err
+	}
+
 	log.Printf("Migrated %d POIs", count)
 	return nil
 }
 
 // migratePOIFile migrates a single POI JSON file.
-func migratePOIFile(filePath string) error {
+func migratePOIFile(tx *sql.Tx, filePath string) (string, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	var poi map[string]interface{}
 	if err := json.Unmarshal(data, &poi); err != nil {
-		return err
+		return "", err
 	}
 
 	id := strings.TrimSuffix(filepath.Base(filePath), ".json")
@@ -1216,53 +1311,57 @@ func migratePOIFile(filePath string) error {
 
 	propertiesJSON, _ := json.Marshal(poi)
 
-	stmt := `INSERT INTO pois (id, name, category, parent_environment, position, properties)
-	         VALUES (?, ?, ?, ?, ?, ?)`
-	_, err = database.Exec(stmt, id, name, category, parentEnv, position, string(propertiesJSON))
-	return err
+	stmt := upsert("pois", "id", "name", "category", "parent_environment", "position", "properties")
+	_, err = tx.Exec(stmt, id, name, category, parentEnv, position, string(propertiesJSON))
+	return id, err
 }
 
 // migrateEncounters loads every encounter draft.
 func migrateEncounters() error {
 	encountersPath := "game-data/systems/encounters-draft"
 
-	if _, err := database.Exec("DELETE FROM encounters"); err != nil {
-		return fmt.Errorf("failed to clear encounters table: %v", err)
+	sync, err := beginTable("encounters")
+	if err != nil {
+		return err
 	}
+	defer sync.rollback()
 
 	count := 0
-	err := filepath.WalkDir(encountersPath, func(path string, d fs.DirEntry, err error) error {
+	err = filepath.WalkDir(encountersPath, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
 		if d.IsDir() || !strings.HasSuffix(path, ".json") {
 			return nil
 		}
-		if err := migrateEncounterFile(path); err != nil {
-			log.Printf("Warning: failed to migrate encounter file %s: %v", path, err)
-		} else {
-			count++
+		if err := sync.file(path, migrateEncounterFile); err != nil {
+			return fmt.Errorf("failed to migrate encounter file %s: %v", path, err)
 		}
+		count++
 		return nil
 	})
 	if err != nil {
 		return fmt.Errorf("failed to walk encounters directory: %v", err)
 	}
 
+	if err := sync.finish(); err != nil {
+		return err
+	}
+
 	log.Printf("Migrated %d encounters", count)
 	return nil
 }
 
 // migrateEncounterFile migrates a single encounter JSON file.
-func migrateEncounterFile(filePath string) error {
+func migrateEncounterFile(tx *sql.Tx, filePath string) (string, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	var enc map[string]interface{}
 	if err := json.Unmarshal(data, &enc); err != nil {
-		return err
+		return "", err
 	}
 
 	id := strings.TrimSuffix(filepath.Base(filePath), ".json")
@@ -1280,10 +1379,9 @@ func migrateEncounterFile(filePath string) error {
 
 	propertiesJSON, _ := json.Marshal(enc)
 
-	stmt := `INSERT INTO encounters (id, name, trigger, chance, repeatable, cooldown_minutes, properties)
-	         VALUES (?, ?, ?, ?, ?, ?, ?)`
-	_, err = database.Exec(stmt, id, name, trigger, chance, repeatable, int(cooldown), string(propertiesJSON))
-	return err
+	stmt := upsert("encounters", "id", "name", "trigger", "chance", "repeatable", "cooldown_minutes", "properties")
+	_, err = tx.Exec(stmt, id, name, trigger, chance, repeatable, int(cooldown), string(propertiesJSON))
+	return id, err
 }
 
 // migrateGenericJSON migrates a generic JSON file to a dynamically created table
@@ -1304,21 +1402,23 @@ func migrateGenericJSON(filePath, tableName string) error {
 		return fmt.Errorf("failed to create table %s: %v", tableName, err)
 	}
 
-	// Clear existing data
-	if _, err := database.Exec(fmt.Sprintf("DELETE FROM %s", tableName)); err != nil {
-		return fmt.Errorf("failed to clear table %s: %v", tableName, err)
+	sync, err := beginTable(tableName)
+	if err != nil {
+		return err
 	}
+	defer sync.rollback()
 
 	// Insert data
-	id := strings.TrimSuffix(filepath.Base(filePath), ".json")
-	stmt := fmt.Sprintf(`INSERT INTO %s (id, data) VALUES (?, ?)`, tableName)
-	_, err = database.Exec(stmt, id, string(data))
-
+	err = sync.file(filePath, func(tx *sql.Tx, path string) (string, error) {
+		id := strings.TrimSuffix(filepath.Base(path), ".json")
+		_, err := tx.Exec(upsert(tableName, "id", "data"), id, string(data))
+		return id, err
+	})
 	if err != nil {
 		return fmt.Errorf("failed to insert into %s: %v", tableName, err)
 	}
 
-	return nil
+	return sync.finish()
 }
 
 // migrateSystemData migrates system configuration files
@@ -1358,15 +1458,18 @@ func migrateSystemData() error {
 func migrateSystemsFiles() error {
 	log.Println("Migrating system config files...")
 
-	// Clear existing systems
-	if _, err := database.Exec("DELETE FROM systems"); err != nil {
-		return fmt.Errorf("failed to clear systems table: %v", err)
+	sync, err := beginTable("systems")
+	if err != nil {
+		return err
 	}
+	defer sync.rollback()
 
 	systemFiles := []string{
+		"combat.json",
 		"encumbrance.json",
 		"effects.json",
 		"inventory.json",
+		"saving.json",
 		"skills.json",
 		"travel-config.json",
 	}
@@ -1386,20 +1489,26 @@ func migrateSystemsFiles() error {
 		systemID := strings.TrimSuffix(filename, ".json")
 
 		// Insert into systems table
-		_, err = database.Exec(`
-			INSERT OR REPLACE INTO systems (id, properties)
-			VALUES (?, ?)
-		`, systemID, string(data))
+		err = sync.file(filePath, func(tx *sql.Tx, _ string) (string, error) {
+			_, err := tx.Exec(`
+				INSERT OR REPLACE INTO systems (id, properties)
+				VALUES (?, ?)
+			`, systemID, string(data))
+			return systemID, err
+		})
 
 		if err != nil {
-			log.Printf("❌ Failed to migrate %s: %v", filename, err)
-			continue
+			return fmt.Errorf("failed to migrate %s: %v", filename, err)
 		}
 
 		log.Printf("✅ Migrated system config: %s", systemID)
 		count++
 	}
 
+	if err := sync.finish(); err != nil {
+		return err
+	}
+
 	log.Printf("Migrated %d system config files", count)
 	return nil
 }
@@ -0,0 +1,172 @@
+package pixellab
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Generation jobs — a PixelLab call takes seconds per image, so the editor
+// queues generation work and answers with a job ID instead of holding the
+// request open. A fixed set of workers drains the queue, which caps how many
+// calls run against the API at once.
+
+// Job states, in the order a job moves through them.
+const (
+	JobPending = "pending"
+	JobRunning = "running"
+	JobDone    = "done"
+	JobFailed  = "failed"
+)
+
+// maxQueuedJobs is how many jobs may wait for a worker before Enqueue refuses more.
+const maxQueuedJobs = 256
+
+// Job is a snapshot of one queued piece of generation work.
+type Job struct {
+	ID        string      `json:"id"`
+	Key       string      `json:"key"` // what the job is for, e.g. the item ID
+	Status    string      `json:"status"`
+	Attempts  int         `json:"attempts"`
+	Error     string      `json:"error,omitempty"`
+	Result    interface{} `json:"result,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// queuedJob is a Job plus the work it runs.
+type queuedJob struct {
+	Job
+	run func() (interface{}, error)
+}
+
+// Queue runs generation jobs on a limited number of workers.
+type Queue struct {
+	mu     sync.Mutex
+	jobs   map[string]*queuedJob
+	latest map[string]string // key → ID of its newest job
+	work   chan *queuedJob
+	nextID int
+}
+
+// NewQueue starts a queue whose jobs run at most workers at a time.
+func NewQueue(workers int) *Queue {
+	if workers < 1 {
+		workers = 1
+	}
+	q := &Queue{
+		jobs:   make(map[string]*queuedJob),
+		latest: make(map[string]string),
+		work:   make(chan *queuedJob, maxQueuedJobs),
+	}
+	for i := 0; i < workers; i++ {
+		go q.worker()
+	}
+	return q
+}
+
+// Enqueue adds a job for key and returns its snapshot. run does the work; what
+// it returns becomes the job's Result, or its Error if it fails.
+func (q *Queue) Enqueue(key string, run func() (interface{}, error)) (Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.nextID++
+	now := time.Now()
+	job := &queuedJob{
+		Job: Job{
+			ID:        fmt.Sprintf("job-%d", q.nextID),
+			Key:       key,
+			Status:    JobPending,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		run: run,
+	}
+	if err := q.submit(job); err != nil {
+		return Job{}, err
+	}
+	q.jobs[job.ID] = job
+	q.latest[key] = job.ID
+	return job.Job, nil
+}
+
+// Retry puts a failed job back in the queue.
+func (q *Queue) Retry(id string) (Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	job, ok := q.jobs[id]
+	if !ok {
+		return Job{}, fmt.Errorf("no job %s", id)
+	}
+	if job.Status != JobFailed {
+		return Job{}, fmt.Errorf("job %s is %s, only failed jobs can be retried", id, job.Status)
+	}
+	if err := q.submit(job); err != nil {
+		return Job{}, err
+	}
+	job.Status = JobPending
+	job.Error = ""
+	job.UpdatedAt = time.Now()
+	return job.Job, nil
+}
+
+// Get returns a snapshot of job id.
+func (q *Queue) Get(id string) (Job, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	job, ok := q.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return job.Job, true
+}
+
+// Latest returns a snapshot of the newest job queued for key.
+func (q *Queue) Latest(key string) (Job, bool) {
+	q.mu.Lock()
+	id, ok := q.latest[key]
+	q.mu.Unlock()
+	if !ok {
+		return Job{}, false
+	}
+	return q.Get(id)
+}
+
+// submit hands a job to the workers without blocking. Callers hold q.mu.
+func (q *Queue) submit(job *queuedJob) error {
+	select {
+	case q.work <- job:
+		return nil
+	default:
+		return fmt.Errorf("generation queue is full (%d waiting)", maxQueuedJobs)
+	}
+}
+
+// worker runs queued jobs one at a time until the process exits.
+func (q *Queue) worker() {
+	for job := range q.work {
+		q.mu.Lock()
+		job.Status = JobRunning
+		job.Attempts++
+		job.UpdatedAt = time.Now()
+		q.mu.Unlock()
+
+		result, err := job.run()
+
+		q.mu.Lock()
+		if err != nil {
+			log.Printf("❌ Generation job %s (%s) failed: %v", job.ID, job.Key, err)
+			job.Status = JobFailed
+			job.Error = err.Error()
+		} else {
+			job.Status = JobDone
+			job.Result = result
+		}
+		job.UpdatedAt = time.Now()
+		q.mu.Unlock()
+	}
+}
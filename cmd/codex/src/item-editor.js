@@ -309,6 +309,7 @@ function populateForm(item) {
     // Combat
     document.getElementById('itemAC').value = item.ac || '';
     document.getElementById('itemDamage').value = item.damage || '';
+    document.getElementById('itemDamageVersatile').value = item.damage_versatile || item['damage-versatile'] || '';
     document.getElementById('damageType').value = item['damage-type'] || item.damage_type || '';
 
     // Ranged
@@ -894,6 +895,7 @@ async function saveItem() {
 
     if (!isArmor) delete item.ac;
     if (!isWeapon && !isRanged) { delete item.damage; delete item.damage_type; delete item['damage-type']; }
+    delete item.damage_versatile; delete item['damage-versatile'];
     if (!isRanged) { delete item.ammunition; delete item.range; delete item.range_long; delete item['range-long']; }
 
     const ac = document.getElementById('itemAC').value.trim();
@@ -902,6 +904,9 @@ async function saveItem() {
     const damage = document.getElementById('itemDamage').value.trim();
     if (damage) item.damage = damage;
 
+    const damageVersatile = document.getElementById('itemDamageVersatile').value.trim();
+    if (damageVersatile) item.damage_versatile = damageVersatile;
+
     const damageType = document.getElementById('damageType').value;
     if (damageType) item.damage_type = damageType;
 
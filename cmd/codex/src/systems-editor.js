@@ -1886,6 +1886,14 @@ async function saveEffectTypes() {
         } else {
             showStatus('✅ Effect types saved successfully', 'success');
         }
+
+        // A removed/renamed type orphans the effects whose modifiers use it.
+        const broken = result.broken_effects || [];
+        if (broken.length > 0) {
+            const files = [...new Set(broken.map(issue => issue.file))];
+            console.warn('⚠️ Effects referencing unknown stats:', broken);
+            showStatus(`⚠️ This change broke ${files.length} effect(s): ${files.join(', ')}`, 'warning');
+        }
     } catch (error) {
         console.error('❌ Save failed:', error);
         showStatus(`❌ ${error.message}`, 'error');
@@ -52,7 +52,10 @@ async function runValidation() {
     }
 }
 
-async function runCleanup(dryRun) {
+// target picks the cleanup: '' for items, 'effects' or 'starting-gear'.
+// safe (items only) applies just the fixes that can't be wrong and lists the
+// rest as skipped.
+async function runCleanup(dryRun, target = '', safe = false) {
     const results = document.getElementById('results');
     results.style.display = 'block';
 
@@ -62,7 +65,11 @@ async function runCleanup(dryRun) {
     cleanupResultsDiv.innerHTML = '<div style="text-align: center; padding: 20px;">Processing cleanup...</div>';
 
     try {
-        const url = '/api/validation/cleanup' + (dryRun ? '?dry_run=true' : '');
+        const params = new URLSearchParams();
+        if (dryRun) params.set('dry_run', 'true');
+        if (safe) params.set('safe', 'true');
+        const query = params.toString();
+        const url = '/api/validation/cleanup' + (target ? '/' + target : '') + (query ? '?' + query : '');
         const response = await fetch(url, { method: 'POST' });
         const data = await response.json();
 
@@ -84,8 +91,17 @@ async function runCleanup(dryRun) {
             const header = document.createElement('div');
             header.className = 'codex-section win95-inset pixel-clip mb-10';
             header.style = 'background: #50fa7b; color: #000; padding: 12px; font-weight: bold;';
-            header.textContent = `✅ CLEANUP COMPLETE - ${data.files_modified} files modified`;
+            header.textContent = `✅ ${safe ? 'SAFE FIXES' : 'CLEANUP'} COMPLETE - ${data.files_modified} files modified`;
             cleanupResultsDiv.appendChild(header);
+
+            if (data.run_id) {
+                const undo = document.createElement('button');
+                undo.className = 'codex-btn pixel-clip-sm mb-10';
+                undo.style = 'background: #ff5555; color: #000;';
+                undo.textContent = '↩️ UNDO THIS CLEANUP';
+                undo.onclick = undoCleanup;
+                cleanupResultsDiv.appendChild(undo);
+            }
         }
 
         if (data.changes.length === 0) {
@@ -139,11 +155,54 @@ async function runCleanup(dryRun) {
                 cleanupResultsDiv.appendChild(fileDiv);
             });
         }
+
+        if (data.skipped && data.skipped.length > 0) {
+            const skippedDiv = document.createElement('div');
+            skippedDiv.className = 'codex-section win95-inset pixel-clip mb-10';
+            skippedDiv.style.padding = '15px';
+
+            const skippedHeader = document.createElement('div');
+            skippedHeader.style = 'font-weight: bold; margin-bottom: 10px; color: #f1fa8c;';
+            skippedHeader.textContent = `⚠️ ${data.skipped.length} change(s) skipped - fix these by hand`;
+            skippedDiv.appendChild(skippedHeader);
+
+            data.skipped.forEach(change => {
+                const changeDiv = document.createElement('div');
+                changeDiv.style = 'margin-left: 15px; margin-bottom: 5px; font-size: 14px;';
+                changeDiv.textContent = `${change.file}: ${change.message}`;
+                skippedDiv.appendChild(changeDiv);
+            });
+
+            cleanupResultsDiv.appendChild(skippedDiv);
+        }
     } catch (error) {
         cleanupResultsDiv.innerHTML = '<div style="color: #ff5555; padding: 20px;">Error running cleanup: ' + error.message + '</div>';
     }
 }
 
+// undoCleanup restores the files the most recent cleanup run overwrote.
+async function undoCleanup() {
+    if (!confirm('Restore every file the last cleanup changed?')) return;
+
+    const cleanupResultsDiv = document.getElementById('cleanup-results');
+    try {
+        const response = await fetch('/api/validation/cleanup/undo', { method: 'POST' });
+        if (!response.ok) {
+            throw new Error(await response.text());
+        }
+        const data = await response.json();
+
+        cleanupResultsDiv.innerHTML = '';
+        const header = document.createElement('div');
+        header.className = 'codex-section win95-inset pixel-clip mb-10';
+        header.style = 'background: #8be9fd; color: #000; padding: 12px; font-weight: bold;';
+        header.textContent = `↩️ CLEANUP UNDONE - ${data.files.length} files restored`;
+        cleanupResultsDiv.appendChild(header);
+    } catch (error) {
+        cleanupResultsDiv.innerHTML = '<div style="color: #ff5555; padding: 20px;">Error undoing cleanup: ' + error.message + '</div>';
+    }
+}
+
 async function runSchemaCheck() {
     const results = document.getElementById('results');
     results.style.display = 'block';
@@ -204,6 +263,7 @@ async function runSchemaCheck() {
 // Expose functions to window for onclick handlers
 window.runValidation = runValidation;
 window.runCleanup = runCleanup;
+window.undoCleanup = undoCleanup;
 window.runSchemaCheck = runSchemaCheck;
 
 console.log('🎯 CODEX Validation loaded');
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"pubkey-quest/cmd/codex/config"
+	"pubkey-quest/cmd/codex/validation"
 )
 
 type Editor struct {
@@ -145,7 +146,11 @@ func (e *Editor) DeleteEffect(effectID string) error {
 	return nil
 }
 
-func (e *Editor) ValidateEffect(effect Effect) error {
+// ValidateEffect checks an effect before it is saved. raw is the request body
+// decoded as a plain map, so rules that depend on a field being present (visible
+// decodes to false when omitted) see what the client actually sent. The
+// source_type rules are the same ones the full validation pass applies.
+func (e *Editor) ValidateEffect(effect Effect, raw map[string]interface{}) error {
 	// Check that all effect.Modifiers[].Stat references valid EffectTypeDefinition
 	for _, modifier := range effect.Modifiers {
 		if _, exists := e.EffectTypes.EffectTypes[modifier.Stat]; !exists {
@@ -164,5 +169,14 @@ func (e *Editor) ValidateEffect(effect Effect) error {
 		return fmt.Errorf("effect ID cannot contain spaces or path separators")
 	}
 
+	if issues := validation.CheckEffectSourceRules(raw); len(issues) > 0 {
+		return fmt.Errorf("%s", issues[0].Message)
+	}
+	if issues := validation.CheckEffectCondition(raw); len(issues) > 0 {
+		return fmt.Errorf("%s", issues[0].Message)
+	}
+	if issues := validation.CheckEffectConcentration(raw); len(issues) > 0 {
+		return fmt.Errorf("%s", issues[0].Message)
+	}
 	return nil
 }
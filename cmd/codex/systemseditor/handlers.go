@@ -3,12 +3,15 @@ package systemseditor
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"log"
 	"net/http"
 	"os"
 	"strings"
 	"time"
 
 	"pubkey-quest/cmd/codex/staging"
+	"pubkey-quest/cmd/codex/validation"
 	"github.com/gorilla/mux"
 )
 
@@ -50,13 +53,31 @@ func (e *Editor) HandleGetEffectTypes(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(e.EffectTypes)
 }
 
+// decodeEffectRequest reads an effect from the request body both as the typed
+// Effect and as a raw map, so validation can tell an omitted field from a zero one.
+func decodeEffectRequest(r *http.Request) (Effect, map[string]interface{}, error) {
+	var effect Effect
+	var raw map[string]interface{}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return effect, nil, err
+	}
+	if err := json.Unmarshal(body, &effect); err != nil {
+		return effect, nil, err
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return effect, nil, err
+	}
+	return effect, raw, nil
+}
+
 // Save individual effect
 func (e *Editor) HandleSaveEffect(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	effectID := vars["id"]
 
-	var effect Effect
-	if err := json.NewDecoder(r.Body).Decode(&effect); err != nil {
+	effect, raw, err := decodeEffectRequest(r)
+	if err != nil {
 		http.Error(w, fmt.Sprintf("Invalid JSON: %v", err), http.StatusBadRequest)
 		return
 	}
@@ -65,7 +86,7 @@ func (e *Editor) HandleSaveEffect(w http.ResponseWriter, r *http.Request) {
 	effect.ID = effectID
 
 	// Validate effect references valid effect types
-	if err := e.ValidateEffect(effect); err != nil {
+	if err := e.ValidateEffect(effect, raw); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
@@ -125,14 +146,14 @@ func (e *Editor) HandleSaveEffect(w http.ResponseWriter, r *http.Request) {
 
 // Create new effect
 func (e *Editor) HandleCreateEffect(w http.ResponseWriter, r *http.Request) {
-	var effect Effect
-	if err := json.NewDecoder(r.Body).Decode(&effect); err != nil {
+	effect, raw, err := decodeEffectRequest(r)
+	if err != nil {
 		http.Error(w, fmt.Sprintf("Invalid JSON: %v", err), http.StatusBadRequest)
 		return
 	}
 
 	// Validate
-	if err := e.ValidateEffect(effect); err != nil {
+	if err := e.ValidateEffect(effect, raw); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
@@ -256,6 +277,11 @@ func (e *Editor) HandleSaveEffectTypes(w http.ResponseWriter, r *http.Request) {
 	gitPath := strings.ReplaceAll(filePath, "\\", "/")
 	newContent, _ := json.MarshalIndent(types, "", "  ")
 
+	// Re-check every effect against the edited registry: removing or renaming
+	// an effect type orphans the modifiers that use it. The save still goes
+	// through; the editor warns with the list.
+	broken := orphanedEffects(newContent)
+
 	if mode == staging.ModeDirect {
 		if err := e.SaveEffectTypes(types); err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -264,8 +290,9 @@ func (e *Editor) HandleSaveEffectTypes(w http.ResponseWriter, r *http.Request) {
 		e.EffectTypes = types
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
-			"status": "saved",
-			"mode":   "direct",
+			"status":         "saved",
+			"mode":           "direct",
+			"broken_effects": broken,
 		})
 	} else {
 		session := staging.Manager.GetSession(sessionID)
@@ -287,13 +314,30 @@ func (e *Editor) HandleSaveEffectTypes(w http.ResponseWriter, r *http.Request) {
 		e.EffectTypes = types
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
-			"status":  "staged",
-			"mode":    "staging",
-			"changes": len(session.Changes),
+			"status":         "staged",
+			"mode":           "staging",
+			"changes":        len(session.Changes),
+			"broken_effects": broken,
 		})
 	}
 }
 
+// orphanedEffects validates every effect file against an effect-type registry
+// and returns the modifiers whose stat the registry doesn't define. Never nil,
+// so the client can always read .length.
+func orphanedEffects(registryJSON []byte) []validation.Issue {
+	issues, err := validation.ValidateEffectsForRegistry(registryJSON)
+	if err != nil {
+		log.Printf("⚠️ Effect re-validation after registry edit failed: %v", err)
+		return []validation.Issue{}
+	}
+	broken := validation.UnknownStatIssues(issues)
+	if broken == nil {
+		broken = []validation.Issue{}
+	}
+	return broken
+}
+
 // Save skills config
 func (e *Editor) HandleSaveSkills(w http.ResponseWriter, r *http.Request) {
 	var newData json.RawMessage
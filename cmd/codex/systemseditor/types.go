@@ -12,7 +12,7 @@ type EffectTypeDefinition struct {
 	ID             string `json:"id"`
 	Property       string `json:"property"`
 	Description    string `json:"description"`
-	Category       string `json:"category"`        // "stat", "resource", "capacity"
+	Category       string `json:"category"`        // "stat", "resource", "capacity", "combat"
 	AllowsPeriodic bool   `json:"allows_periodic"` // Whether this type can have periodic modifiers
 }
 
@@ -0,0 +1,114 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// Cleanup backups — every cleanup run that overwrites files first copies the
+// originals into a directory of its own under CleanupBackupDir, mirroring their
+// paths. RestoreLastCleanup puts the newest run's files back and drops the run,
+// so repeated undos walk back through earlier runs.
+
+// CleanupBackupDir is where cleanup runs keep the files they overwrote.
+const CleanupBackupDir = "data/cleanup-backups"
+
+// ErrNoCleanupRun is returned by RestoreLastCleanup when there is nothing to undo.
+var ErrNoCleanupRun = errors.New("no cleanup run to undo")
+
+// cleanupRun collects the originals of the files one cleanup run overwrites.
+// Its directory is only created once something is backed up, so a run that
+// changes nothing leaves nothing to undo.
+type cleanupRun struct {
+	ID    string
+	files int
+}
+
+// newCleanupRun starts a run named for the current time (sortable, so the
+// newest run is the last directory name).
+func newCleanupRun() *cleanupRun {
+	return &cleanupRun{ID: time.Now().Format("20060102-150405.000000")}
+}
+
+// backup saves original as the pre-cleanup copy of path. Call it before
+// overwriting the file; if it fails, don't overwrite.
+func (run *cleanupRun) backup(path string, original []byte) error {
+	dst := filepath.Join(CleanupBackupDir, run.ID, path)
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return fmt.Errorf("backing up %s: %w", path, err)
+	}
+	if err := os.WriteFile(dst, original, 0644); err != nil {
+		return fmt.Errorf("backing up %s: %w", path, err)
+	}
+	run.files++
+	return nil
+}
+
+// runID is the ID to report for the run: empty when it backed nothing up.
+func (run *cleanupRun) runID() string {
+	if run.files == 0 {
+		return ""
+	}
+	return run.ID
+}
+
+// RestoreResult lists the files an undo put back.
+type RestoreResult struct {
+	RunID string   `json:"run_id"`
+	Files []string `json:"files"`
+}
+
+// RestoreLastCleanup restores every file the most recent cleanup run overwrote
+// and removes that run's backups.
+func RestoreLastCleanup() (*RestoreResult, error) {
+	entries, err := os.ReadDir(CleanupBackupDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	var runs []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			runs = append(runs, entry.Name())
+		}
+	}
+	if len(runs) == 0 {
+		return nil, ErrNoCleanupRun
+	}
+	slices.Sort(runs)
+
+	result := &RestoreResult{RunID: runs[len(runs)-1], Files: []string{}}
+	runDir := filepath.Join(CleanupBackupDir, result.RunID)
+	err = filepath.WalkDir(runDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		original, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		target, err := filepath.Rel(runDir, path)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, original, 0644); err != nil {
+			return fmt.Errorf("restoring %s: %w", target, err)
+		}
+		result.Files = append(result.Files, target)
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	if err := os.RemoveAll(runDir); err != nil {
+		return result, err
+	}
+	log.Printf("Undid cleanup run %s: %d file(s) restored", result.RunID, len(result.Files))
+	return result, nil
+}
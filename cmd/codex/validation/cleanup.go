@@ -1,13 +1,18 @@
 package validation
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io/fs"
 	"log"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+
+	"pubkey-quest/types"
 )
 
 // CleanupResult holds the results of a cleanup operation
@@ -15,6 +20,8 @@ type CleanupResult struct {
 	FilesProcessed int      `json:"files_processed"`
 	FilesModified  int      `json:"files_modified"`
 	Changes        []Change `json:"changes"`
+	RunID          string   `json:"run_id,omitempty"`  // backup run holding the overwritten originals (see RestoreLastCleanup)
+	Skipped        []Change `json:"skipped,omitempty"` // changes a safe-mode run left for a person to make
 }
 
 // Change represents a change made to a file
@@ -25,13 +32,17 @@ type Change struct {
 	Message string `json:"message"`
 }
 
-// CleanupAllItems runs cleanup on all item files
-func CleanupAllItems(dryRun bool) (*CleanupResult, error) {
+// CleanupAllItems runs cleanup on all item files. With safe set it only
+// applies the fixes that can't be wrong (property-name normalization, removing
+// null fields, reordering) and lists every default it would have guessed,
+// including the ones marked MANUAL FIX REQUIRED, under Skipped instead.
+func CleanupAllItems(dryRun, safe bool) (*CleanupResult, error) {
 	result := &CleanupResult{
 		Changes: []Change{},
 	}
 
 	itemsPath := "game-data/items"
+	run := newCleanupRun()
 
 	err := filepath.WalkDir(itemsPath, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
@@ -39,12 +50,13 @@ func CleanupAllItems(dryRun bool) (*CleanupResult, error) {
 		}
 
 		if !d.IsDir() && strings.HasSuffix(path, ".json") {
-			changes, modified := cleanupItemFile(path, dryRun)
+			changes, skipped, modified := cleanupItemFile(path, dryRun, safe, run)
 			result.FilesProcessed++
 			if modified {
 				result.FilesModified++
 			}
 			result.Changes = append(result.Changes, changes...)
+			result.Skipped = append(result.Skipped, skipped...)
 		}
 		return nil
 	})
@@ -53,26 +65,31 @@ func CleanupAllItems(dryRun bool) (*CleanupResult, error) {
 		return nil, err
 	}
 
+	result.RunID = run.runID()
 	log.Printf("Cleanup complete: %d files processed, %d modified", result.FilesProcessed, result.FilesModified)
 	return result, nil
 }
 
-// cleanupItemFile cleans up a single item file
-func cleanupItemFile(filePath string, dryRun bool) ([]Change, bool) {
+// cleanupItemFile cleans up a single item file, backing up the original into
+// run before overwriting it. In safe mode it only renames properties, drops
+// null fields and reorders; the defaults it would have filled in come back as
+// skipped changes.
+func cleanupItemFile(filePath string, dryRun, safe bool, run *cleanupRun) ([]Change, []Change, bool) {
 	changes := []Change{}
+	skipped := []Change{}
 	filename := filepath.Base(filePath)
 	idFromFilename := strings.TrimSuffix(filename, ".json")
 
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		log.Printf("Error reading %s: %v", filename, err)
-		return changes, false
+		return changes, skipped, false
 	}
 
 	var item map[string]interface{}
 	if err := json.Unmarshal(data, &item); err != nil {
 		log.Printf("Error parsing %s: %v", filename, err)
-		return changes, false
+		return changes, skipped, false
 	}
 
 	modified := false
@@ -85,62 +102,10 @@ func cleanupItemFile(filePath string, dryRun bool) ([]Change, bool) {
 		modified = true
 	}
 
-	// 1. ADD MISSING REQUIRED FIELDS
-	requiredDefaults := map[string]interface{}{
-		"id":             idFromFilename,
-		"name":           "NEEDS NAME",
-		"description":    "NEEDS DESCRIPTION",
-		"rarity":         "common",
-		"value":          0,
-		"weight":         1.0,
-		"stack":          1,
-		"type":           "Adventuring Gear",
-		"image":          fmt.Sprintf("/res/img/items/%s.png", idFromFilename),
-		"tags":           []interface{}{},
-		"notes":          []interface{}{},
-	}
-
-	for field, defaultValue := range requiredDefaults {
-		if _, exists := item[field]; !exists {
-			item[field] = defaultValue
-			changes = append(changes, Change{
-				File:    filename,
-				Type:    "added",
-				Field:   field,
-				Message: fmt.Sprintf("Added missing required field '%s'", field),
-			})
-			modified = true
-		} else if field == "description" {
-			// Check for empty strings
-			if val, ok := item[field].(string); ok && val == "" {
-				item[field] = defaultValue
-				changes = append(changes, Change{
-					File:    filename,
-					Type:    "fixed",
-					Field:   field,
-					Message: fmt.Sprintf("Fixed empty '%s' field", field),
-				})
-				modified = true
-			}
-		}
-	}
+	// 1. GET TAGS AND TYPE FOR CONDITIONAL CLEANUP
+	tags, itemType := itemTagsAndType(item)
 
-	// 2. GET TAGS AND TYPE FOR CONDITIONAL CLEANUP
-	tags := []string{}
-	if tagsArray, ok := item["tags"].([]interface{}); ok {
-		for _, tag := range tagsArray {
-			if tagStr, ok := tag.(string); ok {
-				tags = append(tags, tagStr)
-			}
-		}
-	}
-
-	itemType := ""
-	if t, ok := item["type"].(string); ok {
-		itemType = strings.ToLower(t)
-	}
-
-	// 3. REMOVE UNNECESSARY NULL/EMPTY FIELDS
+	// 2. REMOVE UNNECESSARY NULL/EMPTY FIELDS
 	fieldsToCheckForRemoval := []string{"ac", "damage", "heal", "ammunition", "range", "range-long", "damage-type"}
 
 	for _, field := range fieldsToCheckForRemoval {
@@ -183,6 +148,100 @@ func cleanupItemFile(filePath string, dryRun bool) ([]Change, bool) {
 		}
 	}
 
+	// 3. FILL IN MISSING AND TYPE-SPECIFIC FIELDS
+	// These are guesses, some of them flagged for a manual fix, so safe mode
+	// works them out on a scratch copy and only reports them as skipped.
+	if safe {
+		guessed, _ := fillItemDefaults(maps.Clone(item), idFromFilename, filename)
+		skipped = append(skipped, guessed...)
+	} else {
+		guessed, guessModified := fillItemDefaults(item, idFromFilename, filename)
+		changes = append(changes, guessed...)
+		modified = modified || guessModified
+	}
+
+	// 4. STANDARDIZE PROPERTY ORDER
+	orderedItem := OrderItemProperties(item)
+
+	// 5. WRITE BACK TO FILE (if not dry run and modified)
+	if modified && !dryRun {
+		// Marshal with indentation
+		output, err := json.MarshalIndent(orderedItem, "", "  ")
+		if err != nil {
+			log.Printf("Error marshaling %s: %v", filename, err)
+			return changes, skipped, false
+		}
+
+		if err := run.backup(filePath, data); err != nil {
+			log.Printf("Error backing up %s, left unchanged: %v", filename, err)
+			return changes, skipped, false
+		}
+
+		// Write to file
+		if err := os.WriteFile(filePath, output, 0644); err != nil {
+			log.Printf("Error writing %s: %v", filename, err)
+			return changes, skipped, false
+		}
+
+		changes = append(changes, Change{
+			File:    filename,
+			Type:    "reordered",
+			Message: "Standardized property ordering",
+		})
+	}
+
+	return changes, skipped, modified
+}
+
+// fillItemDefaults adds the fields item is missing for its tags and type,
+// with default values that may need a person to correct them.
+func fillItemDefaults(item map[string]interface{}, idFromFilename, filename string) ([]Change, bool) {
+	changes := []Change{}
+	modified := false
+
+	// 1. ADD MISSING REQUIRED FIELDS
+	requiredDefaults := map[string]interface{}{
+		"id":             idFromFilename,
+		"name":           "NEEDS NAME",
+		"description":    "NEEDS DESCRIPTION",
+		"rarity":         "common",
+		"value":          0,
+		"weight":         1.0,
+		"stack":          1,
+		"type":           "Adventuring Gear",
+		"image":          fmt.Sprintf("/res/img/items/%s.png", idFromFilename),
+		"tags":           []interface{}{},
+		"notes":          []interface{}{},
+	}
+
+	for field, defaultValue := range requiredDefaults {
+		if _, exists := item[field]; !exists {
+			item[field] = defaultValue
+			changes = append(changes, Change{
+				File:    filename,
+				Type:    "added",
+				Field:   field,
+				Message: fmt.Sprintf("Added missing required field '%s'", field),
+			})
+			modified = true
+		} else if field == "description" {
+			// Check for empty strings
+			if val, ok := item[field].(string); ok && val == "" {
+				item[field] = defaultValue
+				changes = append(changes, Change{
+					File:    filename,
+					Type:    "fixed",
+					Field:   field,
+					Message: fmt.Sprintf("Fixed empty '%s' field", field),
+				})
+				modified = true
+			}
+		}
+	}
+
+	// 2. GET TAGS AND TYPE FOR CONDITIONAL CLEANUP
+	tags, itemType := itemTagsAndType(item)
+
 	// 4. ADD CONDITIONAL FIELDS
 
 	// If item has gear_slot, ensure it has equipment tag
@@ -373,7 +432,7 @@ func cleanupItemFile(filePath string, dryRun bool) ([]Change, bool) {
 			modified = true
 		}
 		if _, exists := item["damage-type"]; !exists {
-			item["damage-type"] = "slashing"
+			item["damage-type"] = types.DamageSlashing
 			changes = append(changes, Change{
 				File:    filename,
 				Type:    "added",
@@ -417,7 +476,7 @@ func cleanupItemFile(filePath string, dryRun bool) ([]Change, bool) {
 			modified = true
 		}
 		if _, exists := item["damage-type"]; !exists {
-			item["damage-type"] = "piercing"
+			item["damage-type"] = types.DamagePiercing
 			changes = append(changes, Change{
 				File:    filename,
 				Type:    "added",
@@ -428,38 +487,30 @@ func cleanupItemFile(filePath string, dryRun bool) ([]Change, bool) {
 		}
 	}
 
-	// 6. STANDARDIZE PROPERTY ORDER
-	orderedItem := orderItemProperties(item)
-
-	// 7. WRITE BACK TO FILE (if not dry run and modified)
-	if modified && !dryRun {
-		// Marshal with indentation
-		output, err := json.MarshalIndent(orderedItem, "", "  ")
-		if err != nil {
-			log.Printf("Error marshaling %s: %v", filename, err)
-			return changes, false
-		}
+	return changes, modified
+}
 
-		// Write to file
-		if err := os.WriteFile(filePath, output, 0644); err != nil {
-			log.Printf("Error writing %s: %v", filename, err)
-			return changes, false
+// itemTagsAndType returns the item's tags and its lowercased type.
+func itemTagsAndType(item map[string]interface{}) ([]string, string) {
+	tags := []string{}
+	if tagsArray, ok := item["tags"].([]interface{}); ok {
+		for _, tag := range tagsArray {
+			if tagStr, ok := tag.(string); ok {
+				tags = append(tags, tagStr)
+			}
 		}
+	}
 
-		changes = append(changes, Change{
-			File:    filename,
-			Type:    "reordered",
-			Message: "Standardized property ordering",
-		})
+	itemType := ""
+	if t, ok := item["type"].(string); ok {
+		itemType = strings.ToLower(t)
 	}
 
-	return changes, modified
+	return tags, itemType
 }
 
-// orderItemProperties returns a new map with properties in the standard order
-func orderItemProperties(item map[string]interface{}) map[string]interface{} {
-	ordered := make(map[string]interface{})
-
+// OrderItemProperties returns the item's properties in the standard order
+func OrderItemProperties(item map[string]interface{}) OrderedProperties {
 	// Define the standard order
 	propertyOrder := []string{
 		"id",
@@ -478,6 +529,7 @@ func orderItemProperties(item map[string]interface{}) map[string]interface{} {
 		"ac",
 		"damage",
 		"damage-type",
+		"damage_versatile",
 		"heal",
 		"ammunition",
 		"range",
@@ -489,23 +541,63 @@ func orderItemProperties(item map[string]interface{}) map[string]interface{} {
 		"img",
 	}
 
-	// Add properties in order if they exist
-	for _, key := range propertyOrder {
-		if val, exists := item[key]; exists {
-			ordered[key] = val
+	return orderProperties(item, propertyOrder)
+}
+
+// OrderedProperties is a JSON object that keeps its keys in the given order
+// when marshalled; a plain map would always come out alphabetical.
+type OrderedProperties struct {
+	Keys   []string
+	Values map[string]interface{}
+}
+
+// orderProperties lays out m with the keys of standard first, in that order,
+// followed by any other keys alphabetically so repeated runs write identical
+// files.
+func orderProperties(m map[string]interface{}, standard []string) OrderedProperties {
+	ordered := OrderedProperties{Values: m}
+	for _, key := range standard {
+		if _, exists := m[key]; exists {
+			ordered.Keys = append(ordered.Keys, key)
 		}
 	}
 
-	// Add any remaining properties that aren't in the standard order
-	for key, val := range item {
-		if _, exists := ordered[key]; !exists {
-			ordered[key] = val
+	var rest []string
+	for key := range m {
+		if !slices.Contains(standard, key) {
+			rest = append(rest, key)
 		}
 	}
-
+	slices.Sort(rest)
+	ordered.Keys = append(ordered.Keys, rest...)
 	return ordered
 }
 
+// MarshalJSON writes the properties in Keys order. Values are encoded without
+// HTML escaping; json.Marshal still escapes the result, an Encoder with
+// SetEscapeHTML(false) leaves it alone.
+func (o OrderedProperties) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+
+	buf.WriteByte('{')
+	for i, key := range o.Keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := enc.Encode(key); err != nil {
+			return nil, err
+		}
+		buf.WriteByte(':')
+		if err := enc.Encode(o.Values[key]); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
 // interfaceSlice converts []string to []interface{} for JSON encoding
 func interfaceSlice(strings []string) []interface{} {
 	result := make([]interface{}, len(strings))
@@ -730,9 +822,14 @@ func CleanupStartingGear(dryRun bool) (*CleanupResult, error) {
 				return result, fmt.Errorf("failed to marshal cleaned data: %w", err)
 			}
 
+			run := newCleanupRun()
+			if err := run.backup(filePath, data); err != nil {
+				return result, err
+			}
 			if err := os.WriteFile(filePath, cleanedData, 0644); err != nil {
 				return result, fmt.Errorf("failed to write cleaned file: %w", err)
 			}
+			result.RunID = run.runID()
 		}
 	}
 
@@ -747,6 +844,7 @@ func CleanupEffects(dryRun bool) (*CleanupResult, error) {
 	}
 
 	effectsPath := "game-data/effects"
+	run := newCleanupRun()
 
 	err := filepath.WalkDir(effectsPath, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
@@ -754,7 +852,7 @@ func CleanupEffects(dryRun bool) (*CleanupResult, error) {
 		}
 
 		if !d.IsDir() && strings.HasSuffix(path, ".json") {
-			changes, modified := cleanupEffectFile(path, dryRun)
+			changes, modified := cleanupEffectFile(path, dryRun, run)
 			result.FilesProcessed++
 			if modified {
 				result.FilesModified++
@@ -768,11 +866,12 @@ func CleanupEffects(dryRun bool) (*CleanupResult, error) {
 		return nil, err
 	}
 
+	result.RunID = run.runID()
 	log.Printf("Effects cleanup complete: %d files processed, %d modified", result.FilesProcessed, result.FilesModified)
 	return result, nil
 }
 
-func cleanupEffectFile(filePath string, dryRun bool) ([]Change, bool) {
+func cleanupEffectFile(filePath string, dryRun bool, run *cleanupRun) ([]Change, bool) {
 	changes := []Change{}
 	filename := filepath.Base(filePath)
 
@@ -954,6 +1053,10 @@ func cleanupEffectFile(filePath string, dryRun bool) ([]Change, bool) {
 			return changes, false
 		}
 
+		if err := run.backup(filePath, data); err != nil {
+			log.Printf("Error backing up %s, left unchanged: %v", filename, err)
+			return changes, false
+		}
 		if err := os.WriteFile(filePath, output, 0644); err != nil {
 			log.Printf("Error writing %s: %v", filename, err)
 			return changes, false
@@ -1048,10 +1151,8 @@ func inferRemoval(effect map[string]interface{}) map[string]interface{} {
 	return removal
 }
 
-// orderEffectProperties returns a new map with properties in the standard order
-func orderEffectProperties(effect map[string]interface{}) map[string]interface{} {
-	ordered := make(map[string]interface{})
-
+// orderEffectProperties returns the effect's properties in the standard order
+func orderEffectProperties(effect map[string]interface{}) OrderedProperties {
 	// Define the standard order
 	propertyOrder := []string{
 		"id",
@@ -1065,19 +1166,5 @@ func orderEffectProperties(effect map[string]interface{}) map[string]interface{}
 		"visible",
 	}
 
-	// Add properties in order if they exist
-	for _, key := range propertyOrder {
-		if val, exists := effect[key]; exists {
-			ordered[key] = val
-		}
-	}
-
-	// Add any remaining properties that aren't in the standard order
-	for key, val := range effect {
-		if _, exists := ordered[key]; !exists {
-			ordered[key] = val
-		}
-	}
-
-	return ordered
+	return orderProperties(effect, propertyOrder)
 }
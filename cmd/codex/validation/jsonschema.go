@@ -0,0 +1,262 @@
+package validation
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"pubkey-quest/types"
+)
+
+// JSON Schema export — lets editors outside the codex check item and effect
+// files in their own tooling. The documents are built from the same rule sets
+// validateItemFile and validateEffectFile check against, so they can't drift
+// apart. A schema only says what a file must look like: rules that look across
+// files (pack contents and focus provides naming real items, effect references)
+// and the validator's warnings stay in the codex.
+
+// jsonSchemaDraft is the dialect the exported schemas declare.
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// ItemJSONSchema returns the JSON Schema for an item file.
+func ItemJSONSchema() map[string]interface{} {
+	var conditions []interface{}
+	for _, rule := range itemTagRules {
+		then := map[string]interface{}{"required": rule.Fields}
+		if len(rule.Tags) > 0 {
+			var tags []interface{}
+			for _, tag := range rule.Tags {
+				tags = append(tags, map[string]interface{}{"contains": map[string]interface{}{"const": tag}})
+			}
+			then["properties"] = map[string]interface{}{"tags": map[string]interface{}{"allOf": tags}}
+		}
+		conditions = append(conditions, map[string]interface{}{
+			"if":   tagged(rule.Tag),
+			"then": then,
+		})
+	}
+	// A bag sets the backpack size, so it has to be a container that says how big.
+	conditions = append(conditions, map[string]interface{}{
+		"if": map[string]interface{}{
+			"properties": map[string]interface{}{"gear_slot": map[string]interface{}{"const": "bag"}},
+			"required":   []string{"gear_slot"},
+		},
+		"then": map[string]interface{}{
+			"required":   []string{"container_slots"},
+			"properties": map[string]interface{}{"tags": map[string]interface{}{"contains": map[string]interface{}{"const": "container"}}},
+		},
+	})
+	// Only equipment has a gear slot.
+	conditions = append(conditions, map[string]interface{}{
+		"if":   map[string]interface{}{"required": []string{"gear_slot"}},
+		"then": tagged("equipment"),
+	})
+
+	return map[string]interface{}{
+		"$schema":  jsonSchemaDraft,
+		"title":    "Pubkey Quest item",
+		"type":     "object",
+		"required": itemRequiredFields,
+		"properties": map[string]interface{}{
+			"id":              map[string]interface{}{"type": "string", "description": "Must match the file name"},
+			"name":            map[string]interface{}{"type": "string"},
+			"description":     map[string]interface{}{"type": "string", "minLength": 1},
+			"rarity":          map[string]interface{}{"type": "string", "enum": itemRarities},
+			"value":           map[string]interface{}{"type": "number", "minimum": 0},
+			"weight":          map[string]interface{}{"type": "number", "minimum": 0},
+			"stack":           map[string]interface{}{"type": "number", "minimum": 1},
+			"type":            map[string]interface{}{"type": "string"},
+			"image":           map[string]interface{}{"type": "string"},
+			"tags":            map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+			"notes":           map[string]interface{}{"type": "array"},
+			"gear_slot":       map[string]interface{}{"type": "string", "enum": itemGearSlots},
+			"container_slots": map[string]interface{}{"type": "number", "minimum": 1},
+			"allowed_types": map[string]interface{}{
+				"oneOf": []interface{}{
+					map[string]interface{}{"type": "string", "minLength": 1},
+					map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+				},
+			},
+			"contents": map[string]interface{}{
+				"type":        "array",
+				"description": "[item_id, quantity] pairs",
+				"items": map[string]interface{}{
+					"type": "array",
+					"prefixItems": []interface{}{
+						map[string]interface{}{"type": "string"},
+						map[string]interface{}{"type": "number", "minimum": 1},
+					},
+					"minItems": 2,
+					"maxItems": 2,
+				},
+			},
+			"provides":           map[string]interface{}{"type": "string", "minLength": 1},
+			"damage_resistances": damageTypeArray(),
+			"damage_immunities":  damageTypeArray(),
+		},
+		"allOf": conditions,
+	}
+}
+
+// EffectJSONSchema returns the JSON Schema for an effect file. Modifier stats
+// are limited to the effect types in registryJSON (effects.json content).
+func EffectJSONSchema(registryJSON []byte) (map[string]interface{}, error) {
+	effectTypes, err := parseEffectTypes(registryJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load effect types: %w", err)
+	}
+	stats := make([]string, 0, len(effectTypes))
+	for stat := range effectTypes {
+		stats = append(stats, stat)
+	}
+	slices.Sort(stats)
+
+	sourceIs := func(sourceType string) map[string]interface{} {
+		return map[string]interface{}{
+			"properties": map[string]interface{}{"source_type": map[string]interface{}{"const": sourceType}},
+			"required":   []string{"source_type"},
+		}
+	}
+	visible := func(v bool) map[string]interface{} {
+		return map[string]interface{}{"properties": map[string]interface{}{"visible": map[string]interface{}{"const": v}}}
+	}
+
+	modifier := map[string]interface{}{
+		"type":     "object",
+		"required": []string{"stat", "type"},
+		"properties": map[string]interface{}{
+			"stat":          map[string]interface{}{"type": "string", "enum": stats},
+			"type":          map[string]interface{}{"type": "string", "enum": effectModifierTypes},
+			"tick_interval": map[string]interface{}{"type": "number", "exclusiveMinimum": 0},
+			"delay":         map[string]interface{}{"type": "number", "minimum": 0},
+			"damage_type":   map[string]interface{}{"type": "string", "enum": damageTypes()},
+		},
+		"allOf": []interface{}{
+			map[string]interface{}{
+				"if": map[string]interface{}{
+					"properties": map[string]interface{}{"type": map[string]interface{}{"const": "periodic"}},
+					"required":   []string{"type"},
+				},
+				"then": map[string]interface{}{"required": []string{"tick_interval"}},
+			},
+			map[string]interface{}{
+				"if": map[string]interface{}{
+					"properties": map[string]interface{}{"stat": map[string]interface{}{"enum": []string{"damage_resistance", "damage_immunity"}}},
+					"required":   []string{"stat"},
+				},
+				"then": map[string]interface{}{"required": []string{"damage_type"}},
+			},
+		},
+	}
+
+	return map[string]interface{}{
+		"$schema":  jsonSchemaDraft,
+		"title":    "Pubkey Quest effect",
+		"type":     "object",
+		"required": append(slices.Clone(effectRequiredFields), "category", "removal", "visible", "source_type"),
+		"properties": map[string]interface{}{
+			"id":            map[string]interface{}{"type": "string"},
+			"name":          map[string]interface{}{"type": "string"},
+			"description":   map[string]interface{}{"type": "string"},
+			"category":      map[string]interface{}{"type": "string", "enum": effectCategories},
+			"source_type":   map[string]interface{}{"type": "string", "enum": effectSourceTypes},
+			"visible":       map[string]interface{}{"type": "boolean"},
+			"removal":       map[string]interface{}{"type": "object"},
+			"message":       map[string]interface{}{"type": "string"},
+			"concentration": map[string]interface{}{"type": "boolean"},
+			"modifiers":     map[string]interface{}{"type": "array", "items": modifier},
+			"condition":     map[string]interface{}{"type": "string", "enum": effectConditions},
+			"saving_throw": map[string]interface{}{
+				"type":     "object",
+				"required": []string{"stat", "dc"},
+				"properties": map[string]interface{}{
+					"stat": map[string]interface{}{"type": "string", "enum": abilityScores()},
+					"dc":   map[string]interface{}{"type": "integer", "minimum": 1},
+				},
+			},
+			"system_check": map[string]interface{}{
+				"type":     "object",
+				"required": []string{"stat", "operator", "value"},
+				"properties": map[string]interface{}{
+					"stat":     map[string]interface{}{"type": "string", "enum": systemCheckStats},
+					"operator": map[string]interface{}{"type": "string", "enum": systemCheckOperators},
+					"value":    map[string]interface{}{"type": "number"},
+				},
+			},
+		},
+		"allOf": []interface{}{
+			// Without modifiers an effect has to impose a condition.
+			map[string]interface{}{
+				"anyOf": []interface{}{
+					map[string]interface{}{
+						"required":   []string{"modifiers"},
+						"properties": map[string]interface{}{"modifiers": map[string]interface{}{"minItems": 1}},
+					},
+					map[string]interface{}{
+						"required":   []string{"condition"},
+						"properties": map[string]interface{}{"condition": map[string]interface{}{"minLength": 1}},
+					},
+				},
+			},
+			// A saving throw ends a condition, so it needs one.
+			map[string]interface{}{
+				"if":   map[string]interface{}{"required": []string{"saving_throw"}},
+				"then": map[string]interface{}{"required": []string{"condition"}},
+			},
+			map[string]interface{}{"if": sourceIs("system_status"), "then": map[string]interface{}{"required": []string{"system_check"}}},
+			map[string]interface{}{"if": sourceIs("system_ticker"), "then": visible(false)},
+			map[string]interface{}{"if": sourceIs("system_status"), "then": visible(true)},
+			map[string]interface{}{"if": sourceIs("applied"), "then": visible(true)},
+			// Only an applied effect can be held by concentration.
+			map[string]interface{}{
+				"if": map[string]interface{}{
+					"properties": map[string]interface{}{"concentration": map[string]interface{}{"const": true}},
+					"required":   []string{"concentration"},
+				},
+				"then": sourceIs("applied"),
+			},
+		},
+	}, nil
+}
+
+// LoadEffectJSONSchema builds the effect schema against the effect-type
+// registry on disk.
+func LoadEffectJSONSchema() (map[string]interface{}, error) {
+	data, err := os.ReadFile("game-data/systems/effects.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load effect types: %w", err)
+	}
+	return EffectJSONSchema(data)
+}
+
+// tagged matches an item whose tags include tag.
+func tagged(tag string) map[string]interface{} {
+	return map[string]interface{}{
+		"properties": map[string]interface{}{"tags": map[string]interface{}{"contains": map[string]interface{}{"const": tag}}},
+		"required":   []string{"tags"},
+	}
+}
+
+// damageTypeArray is a list of damage types, as gear resistances are written.
+func damageTypeArray() map[string]interface{} {
+	return map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string", "enum": damageTypes()}}
+}
+
+// damageTypes returns the known damage types in order.
+func damageTypes() []string {
+	return slices.Clone(types.DamageTypes)
+}
+
+// abilityScores returns the six ability scores in order.
+func abilityScores() []string {
+	return sortedNames(schemaValidStats)
+}
+
+func sortedNames(set map[string]bool) []string {
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,282 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"pubkey-quest/types"
+)
+
+// PlayablePath is the registry of playable races and classes.
+const PlayablePath = "game-data/systems/new-character/playable.json"
+
+var playableIDPattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// LoadPlayableRegistry reads the playable races/classes registry.
+func LoadPlayableRegistry(path string) (*types.PlayableRegistry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var registry types.PlayableRegistry
+	if err := json.Unmarshal(data, &registry); err != nil {
+		return nil, err
+	}
+	return &registry, nil
+}
+
+// CheckPlayableRegistry validates the registry itself: both lists are
+// non-empty, every entry has a lowercase id and a name, and neither ids nor
+// names repeat within a list.
+func CheckPlayableRegistry(registry *types.PlayableRegistry) []Issue {
+	var issues []Issue
+	check := func(list string, options []types.PlayableOption) {
+		if len(options) == 0 {
+			issues = append(issues, playableIssue("error", list, fmt.Sprintf("'%s' must list at least one entry", list)))
+			return
+		}
+		ids := map[string]bool{}
+		names := map[string]bool{}
+		for i, option := range options {
+			field := fmt.Sprintf("%s[%d]", list, i)
+			switch {
+			case option.ID == "":
+				issues = append(issues, playableIssue("error", field+".id", "Missing required field: id"))
+			case !playableIDPattern.MatchString(option.ID):
+				issues = append(issues, playableIssue("error", field+".id", fmt.Sprintf("id '%s' must be lowercase letters, digits and hyphens", option.ID)))
+			case ids[option.ID]:
+				issues = append(issues, playableIssue("error", field+".id", fmt.Sprintf("Duplicate id '%s'", option.ID)))
+			}
+			ids[option.ID] = true
+
+			if option.Name == "" {
+				issues = append(issues, playableIssue("error", field+".name", "Missing required field: name"))
+			} else if names[strings.ToLower(option.Name)] {
+				issues = append(issues, playableIssue("error", field+".name", fmt.Sprintf("Duplicate name '%s'", option.Name)))
+			}
+			names[strings.ToLower(option.Name)] = true
+		}
+	}
+	check("races", registry.Races)
+	check("classes", registry.Classes)
+	return issues
+}
+
+func playableIssue(severity, field, message string) Issue {
+	return Issue{Type: severity, Category: "playable", File: filepath.Base(PlayablePath), Field: field, Message: message}
+}
+
+// playableSet resolves race or class references by id or name, either case,
+// since per-class data keys on ids ("wizard") and display tables on names
+// ("Wizard").
+type playableSet map[string]string // lowercased id or name → id
+
+func newPlayableSet(options []types.PlayableOption) playableSet {
+	set := playableSet{}
+	for _, option := range options {
+		set[strings.ToLower(option.ID)] = option.ID
+		set[strings.ToLower(option.Name)] = option.ID
+	}
+	return set
+}
+
+func (s playableSet) resolve(ref string) (string, bool) {
+	id, ok := s[strings.ToLower(ref)]
+	return id, ok
+}
+
+// CheckPlayableReferences checks the race/class references of one table
+// against the registry. refs maps a field path to the race or class it names;
+// every one must be registered, and when complete is set every registered
+// option must be covered (a class without starting gear can't be created).
+func CheckPlayableReferences(file, kind string, options []types.PlayableOption, refs map[string]string, complete bool) []Issue {
+	var issues []Issue
+	set := newPlayableSet(options)
+	covered := map[string]bool{}
+
+	fields := make([]string, 0, len(refs))
+	for field := range refs {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		ref := refs[field]
+		id, ok := set.resolve(ref)
+		if !ok {
+			issues = append(issues, Issue{
+				Type:     "error",
+				Category: "playable",
+				File:     file,
+				Field:    field,
+				Message:  fmt.Sprintf("'%s' is not a playable %s (see %s)", ref, kind, filepath.Base(PlayablePath)),
+			})
+			continue
+		}
+		covered[id] = true
+	}
+
+	if complete {
+		for _, option := range options {
+			if !covered[option.ID] {
+				issues = append(issues, Issue{
+					Type:     "error",
+					Category: "playable",
+					File:     file,
+					Message:  fmt.Sprintf("No entry for playable %s '%s'", kind, option.Name),
+				})
+			}
+		}
+	}
+	return issues
+}
+
+// ValidatePlayable validates the registry and every new-character table and
+// class ability against it, so they all agree on which races and classes exist.
+// The file count covers the registry and the tables only it scans — the starting
+// gear and ability files are counted by their own validators.
+func ValidatePlayable() ([]Issue, int, error) {
+	registry, err := LoadPlayableRegistry(PlayablePath)
+	if err != nil {
+		return []Issue{playableIssue("error", "", fmt.Sprintf("Failed to load registry: %v", err))}, 1, nil
+	}
+	issues := CheckPlayableRegistry(registry)
+	files := 1
+
+	newCharacter := "game-data/systems/new-character"
+	readJSON := func(name string, into interface{}) bool {
+		if name != "starting-gear.json" {
+			files++
+		}
+		data, err := os.ReadFile(filepath.Join(newCharacter, name))
+		if err != nil || json.Unmarshal(data, into) != nil {
+			issues = append(issues, Issue{Type: "error", Category: "playable", File: name, Message: "Failed to read or parse file"})
+			return false
+		}
+		return true
+	}
+	classRefs := func(file string, refs map[string]string, complete bool) {
+		issues = append(issues, CheckPlayableReferences(file, "class", registry.Classes, refs, complete)...)
+	}
+	raceRefs := func(file string, refs map[string]string, complete bool) {
+		issues = append(issues, CheckPlayableReferences(file, "race", registry.Races, refs, complete)...)
+	}
+
+	// starting-gear.json: one entry per class
+	var gear []struct {
+		Class string `json:"class"`
+	}
+	if readJSON("starting-gear.json", &gear) {
+		refs := map[string]string{}
+		for i, entry := range gear {
+			refs[fmt.Sprintf("[%d].class", i)] = entry.Class
+		}
+		classRefs("starting-gear.json", refs, true)
+	}
+
+	// starting-spells.json: keyed by caster class
+	var spells map[string]json.RawMessage
+	if readJSON("starting-spells.json", &spells) {
+		refs := map[string]string{}
+		for class := range spells {
+			refs[class] = class
+		}
+		classRefs("starting-spells.json", refs, false)
+	}
+
+	// base-hp.json: every class needs a hit die
+	var baseHP struct {
+		BaseHP map[string]json.RawMessage `json:"base-hp"`
+	}
+	if readJSON("base-hp.json", &baseHP) {
+		refs := map[string]string{}
+		for class := range baseHP.BaseHP {
+			refs["base-hp."+class] = class
+		}
+		classRefs("base-hp.json", refs, true)
+	}
+
+	// introductions.json: equipment intro groups name classes
+	var intros struct {
+		EquipmentIntros map[string]struct {
+			Classes []string `json:"classes"`
+		} `json:"equipment_intros"`
+	}
+	if readJSON("introductions.json", &intros) {
+		refs := map[string]string{}
+		for group, intro := range intros.EquipmentIntros {
+			for i, class := range intro.Classes {
+				refs[fmt.Sprintf("equipment_intros.%s.classes[%d]", group, i)] = class
+			}
+		}
+		classRefs("introductions.json", refs, true)
+	}
+
+	// generation-weights.json: races, class weights per race, backgrounds per class
+	var weights struct {
+		Races                    []string                  `json:"Races"`
+		ClassWeightsByRace       map[string]map[string]int `json:"classWeightsByRace"`
+		BackgroundWeightsByClass map[string]map[string]int `json:"BackgroundWeightsByClass"`
+	}
+	if readJSON("generation-weights.json", &weights) {
+		races := map[string]string{}
+		for i, race := range weights.Races {
+			races[fmt.Sprintf("Races[%d]", i)] = race
+		}
+		for race := range weights.ClassWeightsByRace {
+			races["classWeightsByRace."+race] = race
+		}
+		raceRefs("generation-weights.json", races, true)
+
+		classes := map[string]string{}
+		for race, byClass := range weights.ClassWeightsByRace {
+			for class := range byClass {
+				classes[fmt.Sprintf("classWeightsByRace.%s.%s", race, class)] = class
+			}
+		}
+		for class := range weights.BackgroundWeightsByClass {
+			classes["BackgroundWeightsByClass."+class] = class
+		}
+		classRefs("generation-weights.json", classes, true)
+	}
+
+	// starting-locations.json: a home city per race
+	var locations struct {
+		RacialStartingCities map[string]json.RawMessage `json:"racial_starting_cities"`
+	}
+	if readJSON("starting-locations.json", &locations) {
+		refs := map[string]string{}
+		for race := range locations.RacialStartingCities {
+			refs["racial_starting_cities."+race] = race
+		}
+		raceRefs("starting-locations.json", refs, true)
+	}
+
+	// Class abilities: one directory per class, each ability naming its class
+	abilitiesPath := "game-data/systems/abilities"
+	if entries, err := os.ReadDir(abilitiesPath); err == nil {
+		refs := map[string]string{}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			refs[entry.Name()+"/"] = entry.Name()
+			files, _ := filepath.Glob(filepath.Join(abilitiesPath, entry.Name(), "*.json"))
+			for _, file := range files {
+				var ability struct {
+					Class string `json:"class"`
+				}
+				if data, err := os.ReadFile(file); err == nil && json.Unmarshal(data, &ability) == nil && ability.Class != "" {
+					refs[entry.Name()+"/"+filepath.Base(file)+".class"] = ability.Class
+				}
+			}
+		}
+		classRefs("abilities", refs, false)
+	}
+
+	return issues, files, nil
+}
@@ -2,11 +2,17 @@ package validation
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/fs"
+	"math"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+
+	"pubkey-quest/types"
 )
 
 // Issue represents a validation issue found in game data
@@ -26,86 +32,100 @@ type Result struct {
 
 // Stats holds statistics about validation
 type Stats struct {
-	TotalFiles   int `json:"total_files"`
+	TotalFiles   int `json:"total_files"` // distinct game-data files scanned
 	ErrorCount   int `json:"error_count"`
 	WarningCount int `json:"warning_count"`
 	InfoCount    int `json:"info_count"`
 }
 
-// ValidateAll runs all validation checks on game data
+// validator runs one category's checks and reports how many files it scanned.
+type validator func() ([]Issue, int, error)
+
+// categories lists every validation category in the order ValidateAll runs
+// them. The names match the Category the validator stamps on its issues.
+var categories = []struct {
+	name string
+	run  validator
+}{
+	{"items", ValidateItems},
+	{"monsters", ValidateMonsters},
+	{"locations", ValidateLocations},
+	{"npcs", ValidateNPCs},
+	{"playable", ValidatePlayable}, // playable races/classes and the tables that reference them
+	{"starting-gear", ValidateStartingGear},
+	{"effects", ValidateEffects},
+	{"spells", ValidateSpells},
+	{"abilities", ValidateAbilities},  // class abilities
+	{"systems", ValidateCombatSystem}, // combat system tunables
+}
+
+// ErrUnknownCategory is returned by ValidateCategory for a category it has no
+// validator for.
+var ErrUnknownCategory = errors.New("unknown validation category")
+
+// ValidateAll runs all validation checks on game data. Each validator reports
+// how many files it scanned; Stats.TotalFiles sums them.
 func ValidateAll() (*Result, error) {
 	result := &Result{
 		Issues: []Issue{},
 	}
-
-	// Validate items
-	if itemIssues, err := ValidateItems(); err != nil {
-		return nil, err
-	} else {
-		result.Issues = append(result.Issues, itemIssues...)
-	}
-
-	// Validate monsters
-	if monsterIssues, err := ValidateMonsters(); err != nil {
-		return nil, err
-	} else {
-		result.Issues = append(result.Issues, monsterIssues...)
-	}
-
-	// Validate locations
-	if locationIssues, err := ValidateLocations(); err != nil {
-		return nil, err
-	} else {
-		result.Issues = append(result.Issues, locationIssues...)
-	}
-
-	// Validate NPCs
-	if npcIssues, err := ValidateNPCs(); err != nil {
-		return nil, err
-	} else {
-		result.Issues = append(result.Issues, npcIssues...)
-	}
-
-	// Validate starting gear
-	if gearIssues, err := ValidateStartingGear(); err != nil {
-		return nil, err
-	} else {
-		result.Issues = append(result.Issues, gearIssues...)
+	for _, c := range categories {
+		if err := result.add(c.run); err != nil {
+			return nil, err
+		}
 	}
+	result.countSeverities()
+	return result, nil
+}
 
-	// Validate effects
-	if effectIssues, err := ValidateEffects(); err != nil {
-		return nil, err
-	} else {
-		result.Issues = append(result.Issues, effectIssues...)
+// ValidateCategory runs a single category's checks (e.g. "effects" after an
+// effect edit) and returns the same Result shape as ValidateAll, scoped to it.
+func ValidateCategory(category string) (*Result, error) {
+	for _, c := range categories {
+		if c.name != category {
+			continue
+		}
+		result := &Result{
+			Issues: []Issue{},
+		}
+		if err := result.add(c.run); err != nil {
+			return nil, err
+		}
+		result.countSeverities()
+		return result, nil
 	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownCategory, category)
+}
 
-	// Validate spells
-	if spellIssues, err := ValidateSpells(); err != nil {
-		return nil, err
-	} else {
-		result.Issues = append(result.Issues, spellIssues...)
+// add runs one validator, folding its issues and file count into the result.
+func (r *Result) add(run validator) error {
+	issues, files, err := run()
+	if err != nil {
+		return err
 	}
+	r.Issues = append(r.Issues, issues...)
+	r.Stats.TotalFiles += files
+	return nil
+}
 
-	// Calculate stats
-	for _, issue := range result.Issues {
-		result.Stats.TotalFiles++
+// countSeverities tallies the issues by severity
+func (r *Result) countSeverities() {
+	for _, issue := range r.Issues {
 		switch issue.Type {
 		case "error":
-			result.Stats.ErrorCount++
+			r.Stats.ErrorCount++
 		case "warning":
-			result.Stats.WarningCount++
+			r.Stats.WarningCount++
 		case "info":
-			result.Stats.InfoCount++
+			r.Stats.InfoCount++
 		}
 	}
-
-	return result, nil
 }
 
 // ValidateItems validates all item files
-func ValidateItems() ([]Issue, error) {
+func ValidateItems() ([]Issue, int, error) {
 	issues := []Issue{}
+	files := 0
 	itemsPath := "game-data/items"
 
 	// First, build a set of all valid item IDs for reference checking
@@ -117,6 +137,8 @@ func ValidateItems() ([]Issue, error) {
 		}
 		return nil
 	})
+	validEffectIDs := loadEffectIDs()
+	lightEffectIDs := loadLightEffectIDs()
 
 	// Now validate each item
 	err := filepath.WalkDir(itemsPath, func(path string, d fs.DirEntry, err error) error {
@@ -125,16 +147,37 @@ func ValidateItems() ([]Issue, error) {
 		}
 
 		if !d.IsDir() && strings.HasSuffix(path, ".json") {
-			itemIssues := validateItemFile(path, validItemIDs)
+			itemIssues := validateItemFile(path, validItemIDs, validEffectIDs, lightEffectIDs)
 			issues = append(issues, itemIssues...)
+			files++
 		}
 		return nil
 	})
 
-	return issues, err
+	return issues, files, err
 }
 
-func validateItemFile(filePath string, validItemIDs map[string]bool) []Issue {
+// Item rules shared by validateItemFile and ItemJSONSchema.
+var (
+	itemRequiredFields = []string{"id", "name", "description", "rarity", "value", "weight", "stack", "type", "image", "tags", "notes"}
+	itemRarities       = []string{"common", "uncommon", "rare", "legendary", "mythical"}
+	itemGearSlots      = []string{"hands", "mainhand", "offhand", "chest", "head", "legs", "gloves", "boots", "neck", "ring", "ammo", "bag"}
+)
+
+// itemTagRules lists, per tag, the properties an item carrying it must have and
+// the other tags it must carry alongside — the tag checks validateItemFile makes.
+var itemTagRules = []struct {
+	Tag    string
+	Fields []string
+	Tags   []string
+}{
+	{Tag: "equipment", Fields: []string{"gear_slot"}},
+	{Tag: "container", Fields: []string{"container_slots", "allowed_types"}},
+	{Tag: "pack", Fields: []string{"contents"}},
+	{Tag: "focus", Fields: []string{"provides"}, Tags: []string{"equipment"}},
+}
+
+func validateItemFile(filePath string, validItemIDs, validEffectIDs, lightEffectIDs map[string]bool) []Issue {
 	issues := []Issue{}
 	filename := filepath.Base(filePath)
 	idFromFilename := strings.TrimSuffix(filename, ".json")
@@ -162,8 +205,7 @@ func validateItemFile(filePath string, validItemIDs map[string]bool) []Issue {
 	}
 
 	// Check ALL required fields
-	requiredFields := []string{"id", "name", "description", "rarity", "value", "weight", "stack", "type", "image", "tags", "notes"}
-	for _, field := range requiredFields {
+	for _, field := range itemRequiredFields {
 		if _, exists := item[field]; !exists {
 			issues = append(issues, Issue{
 				Type:     "error",
@@ -241,12 +283,8 @@ func validateItemFile(filePath string, validItemIDs map[string]bool) []Issue {
 	}
 
 	// Check rarity is valid
-	validRarities := map[string]bool{
-		"common": true, "uncommon": true, "rare": true,
-		"legendary": true, "mythical": true,
-	}
 	if rarity, ok := item["rarity"].(string); ok {
-		if !validRarities[strings.ToLower(rarity)] {
+		if !containsID(itemRarities, strings.ToLower(rarity)) {
 			issues = append(issues, Issue{
 				Type:     "warning",
 				Category: "items",
@@ -280,20 +318,13 @@ func validateItemFile(filePath string, validItemIDs map[string]bool) []Issue {
 				Message:  "Items with 'equipment' tag must have 'gear_slot' property",
 			})
 		} else if gearSlotStr, ok := gearSlot.(string); ok {
-			validGearSlots := map[string]bool{
-				"hands": true, "mainhand": true, "offhand": true,
-				"chest": true, "head": true, "legs": true,
-				"gloves": true, "boots": true,
-				"neck": true, "ring": true,
-				"ammo": true, "bag": true,
-			}
-			if !validGearSlots[gearSlotStr] {
+			if !containsID(itemGearSlots, gearSlotStr) {
 				issues = append(issues, Issue{
 					Type:     "error",
 					Category: "items",
 					File:     filename,
 					Field:    "gear_slot",
-					Message:  fmt.Sprintf("Invalid gear_slot '%s'. Must be one of: hands, mainhand, offhand, chest, head, legs, gloves, boots, neck, ring, ammo, bag", gearSlotStr),
+					Message:  fmt.Sprintf("Invalid gear_slot '%s'. Must be one of: %s", gearSlotStr, strings.Join(itemGearSlots, ", ")),
 				})
 			}
 		}
@@ -323,6 +354,80 @@ func validateItemFile(filePath string, validItemIDs map[string]bool) []Issue {
 		}
 	}
 
+	// apply_effect must name a real effect, and its chance must be reachable
+	for _, issue := range CheckItemEffectReferences(item, validEffectIDs) {
+		issue.File = filename
+		issues = append(issues, issue)
+	}
+	for _, issue := range CheckConsumableEffectChances(item) {
+		issue.File = filename
+		issues = append(issues, issue)
+	}
+
+	// Finesse weapons must be light enough to wield with agility
+	for _, issue := range CheckFinesseWeapon(tags) {
+		issue.File = filename
+		issues = append(issues, issue)
+	}
+
+	// A two-handed weapon can't also be light
+	for _, issue := range CheckTwoHandedWeapon(tags) {
+		issue.File = filename
+		issues = append(issues, issue)
+	}
+
+	// Gear takes a slot per piece; consumables are meant to pile up
+	for _, issue := range CheckItemStack(item, tags) {
+		issue.File = filename
+		issues = append(issues, issue)
+	}
+
+	// Versatile dice belong to versatile melee weapons
+	for _, issue := range CheckVersatileWeapon(item, tags) {
+		issue.File = filename
+		issues = append(issues, issue)
+	}
+
+	// Light sources must actually give light when used or equipped
+	for _, issue := range CheckLightSource(item, tags, lightEffectIDs) {
+		issue.File = filename
+		issues = append(issues, issue)
+	}
+
+	// Gear resistances/immunities must name real damage types
+	for _, issue := range CheckItemDamageDefenses(item) {
+		issue.File = filename
+		issues = append(issues, issue)
+	}
+
+	// Revive items must bring the player back up off 0 HP
+	for _, issue := range CheckReviveItem(item, tags) {
+		issue.File = filename
+		issues = append(issues, issue)
+	}
+
+	// Bags set the player's backpack size from container_slots, so a bag must
+	// be a container that declares one
+	if gearSlot, _ := item["gear_slot"].(string); gearSlot == "bag" {
+		if !contains(tags, "container") {
+			issues = append(issues, Issue{
+				Type:     "error",
+				Category: "items",
+				File:     filename,
+				Field:    "tags",
+				Message:  "Items with gear_slot 'bag' must have the 'container' tag",
+			})
+		} else if _, exists := item["container_slots"]; !exists {
+			issues = append(issues, Issue{
+				Type:     "error",
+				Category: "items",
+				File:     filename,
+				Field:    "container_slots",
+				Message:  "Items with gear_slot 'bag' must define 'container_slots' (it sets backpack size)",
+			})
+		}
+	}
+
 	// Container tag requires container_slots and allowed_types
 	if contains(tags, "container") {
 		if _, exists := item["container_slots"]; !exists {
@@ -688,180 +793,1092 @@ func checkUnnecessaryField(item map[string]interface{}, field, filename, context
 	}
 }
 
-// ValidateOneItem validates a single item by its ID (filename without .json)
-func ValidateOneItem(itemID string) ([]Issue, error) {
+// CheckFinesseWeapon validates the "finesse" tag, which lets a weapon attack
+// and deal damage with the better of STR and DEX. Only weapons can be finesse,
+// and a finesse weapon must be light or medium — "heavy" contradicts it.
+func CheckFinesseWeapon(tags []string) []Issue {
 	issues := []Issue{}
-	itemsPath := "game-data/items"
-
-	// Build valid item IDs set for reference checking
-	validItemIDs := make(map[string]bool)
-	filepath.WalkDir(itemsPath, func(path string, d fs.DirEntry, err error) error {
-		if err == nil && !d.IsDir() && strings.HasSuffix(path, ".json") {
-			filename := strings.TrimSuffix(filepath.Base(path), ".json")
-			validItemIDs[filename] = true
-		}
-		return nil
-	})
-
-	filePath := filepath.Join(itemsPath, itemID+".json")
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("item file not found: %s", itemID)
+	if !contains(tags, "finesse") {
+		return issues
 	}
-
-	issues = validateItemFile(filePath, validItemIDs)
-	return issues, nil
+	if !contains(tags, "weapon") {
+		issues = append(issues, Issue{
+			Type:     "warning",
+			Category: "items",
+			Field:    "tags",
+			Message:  "'finesse' only applies to weapons - add the 'weapon' tag or remove 'finesse'",
+		})
+	}
+	if contains(tags, "heavy") {
+		issues = append(issues, Issue{
+			Type:     "warning",
+			Category: "items",
+			Field:    "tags",
+			Message:  "Weapon is both 'finesse' and 'heavy' - finesse weapons must be light or medium",
+		})
+	}
+	return issues
 }
 
-// ValidateMonsters validates all monster files (skips wip/ and draft/ subdirectories)
-func ValidateMonsters() ([]Issue, error) {
+// CheckTwoHandedWeapon validates the "two-handed" tag. A two-handed weapon takes
+// both hands, so it can never be "light" — light is what qualifies a weapon for
+// two-weapon fighting, which needs a free offhand.
+func CheckTwoHandedWeapon(tags []string) []Issue {
 	issues := []Issue{}
-	monstersPath := "game-data/monsters"
-
-	// Build valid item IDs for loot table reference checking
-	validItemIDs := make(map[string]bool)
-	filepath.WalkDir("game-data/items", func(path string, d fs.DirEntry, err error) error {
-		if err == nil && !d.IsDir() && strings.HasSuffix(path, ".json") {
-			validItemIDs[strings.TrimSuffix(filepath.Base(path), ".json")] = true
-		}
-		return nil
-	})
-
-	err := filepath.WalkDir(monstersPath, func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-
-		// Skip subdirectories like wip/ and draft/
-		if d.IsDir() && path != monstersPath {
-			return filepath.SkipDir
-		}
-
-		if !d.IsDir() && strings.HasSuffix(path, ".json") {
-			monsterIssues := validateMonsterFile(path, validItemIDs)
-			issues = append(issues, monsterIssues...)
-		}
-		return nil
-	})
-
-	return issues, err
+	if contains(tags, "two-handed") && contains(tags, "light") {
+		issues = append(issues, Issue{
+			Type:     "error",
+			Category: "items",
+			Field:    "tags",
+			Message:  "Weapon is both 'two-handed' and 'light' - the tags are mutually exclusive",
+		})
+	}
+	return issues
 }
 
-func validateMonsterFile(filePath string, validItemIDs map[string]bool) []Issue {
+// CheckItemStack checks stack, the most of an item one inventory slot holds,
+// against what the item is. Equipment has to stay at 1 or two swords merge into
+// one slot; ammunition and thrown weapons are the exception, they're spent a
+// piece at a time. A consumable that can't stack is allowed but usually a slip.
+func CheckItemStack(item map[string]interface{}, tags []string) []Issue {
 	issues := []Issue{}
-	filename := filepath.Base(filePath)
+	stack, ok := item["stack"].(float64)
+	if !ok {
+		return issues
+	}
 
-	data, err := os.ReadFile(filePath)
-	if err != nil {
+	gearSlot, _ := item["gear_slot"].(string)
+	stackable := gearSlot == "ammo" || contains(tags, "thrown")
+	if contains(tags, "equipment") && !stackable && stack != 1 {
 		issues = append(issues, Issue{
 			Type:     "error",
-			Category: "monsters",
-			File:     filename,
-			Message:  fmt.Sprintf("Failed to read file: %v", err),
+			Category: "items",
+			Field:    "stack",
+			Message:  fmt.Sprintf("Equipment must have stack 1 (got %v) - only ammunition and thrown weapons stack", stack),
 		})
-		return issues
 	}
-
-	var monster map[string]interface{}
-	if err := json.Unmarshal(data, &monster); err != nil {
+	if contains(tags, "consumable") && stack == 1 {
 		issues = append(issues, Issue{
-			Type:     "error",
-			Category: "monsters",
-			File:     filename,
-			Message:  fmt.Sprintf("Invalid JSON: %v", err),
+			Type:     "warning",
+			Category: "items",
+			Field:    "stack",
+			Message:  "Consumable has stack 1, so each one takes its own slot - is that intended?",
 		})
-		return issues
 	}
+	return issues
+}
 
-	// --- Required top-level string fields ---
-	requiredStrings := []string{"id", "name", "type", "size", "alignment", "hp_dice"}
-	for _, field := range requiredStrings {
-		v, exists := monster[field]
-		if !exists || v == nil || v == "" {
-			issues = append(issues, Issue{
-				Type:     "error",
-				Category: "monsters",
-				File:     filename,
-				Field:    field,
-				Message:  fmt.Sprintf("Missing required field: %s", field),
-			})
-		}
+// CheckVersatileWeapon validates damage_versatile, the dice a "versatile" weapon
+// deals when wielded with the offhand empty: only a melee weapon tagged
+// versatile has them, they have to be a valid dice roll, and a versatile weapon
+// without them never gets its two-handed damage.
+func CheckVersatileWeapon(item map[string]interface{}, tags []string) []Issue {
+	issues := []Issue{}
+	add := func(typ, field, msg string) {
+		issues = append(issues, Issue{Type: typ, Category: "items", Field: field, Message: msg})
 	}
 
-	// --- Required numeric fields ---
-	if cr, exists := monster["challenge_rating"]; !exists || cr == nil {
-		issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "challenge_rating", Message: "Missing required field: challenge_rating"})
+	field := "damage_versatile"
+	dice, exists := item[field]
+	if !exists {
+		field = "damage-versatile"
+		dice, exists = item[field]
 	}
-	if xp, exists := monster["xp"]; !exists || xp == nil {
-		issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "xp", Message: "Missing required field: xp"})
-	} else if xpNum, ok := xp.(float64); ok && xpNum < 0 {
-		issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "xp", Message: "xp must be >= 0"})
+	damage, _ := item["damage"].(string)
+	legacy := strings.Contains(damage, ",")
+
+	if !exists {
+		if legacy {
+			add("warning", "damage", fmt.Sprintf("Versatile dice in 'damage' ('%s') - move the two-handed dice to 'damage_versatile'", damage))
+		} else if contains(tags, "versatile") {
+			add("warning", "damage_versatile", "Weapon is tagged 'versatile' but has no 'damage_versatile' dice - it never deals more damage two-handed")
+		}
+		return issues
 	}
-	if ac, exists := monster["armor_class"]; !exists || ac == nil {
-		issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "armor_class", Message: "Missing required field: armor_class"})
-	} else if acNum, ok := ac.(float64); ok && (acNum < 1 || acNum > 30) {
-		issues = append(issues, Issue{Type: "warning", Category: "monsters", File: filename, Field: "armor_class", Message: fmt.Sprintf("armor_class %v is outside expected range 1-30", acNum)})
+
+	itemType, _ := item["type"].(string)
+	if !strings.Contains(strings.ToLower(itemType), "melee") {
+		add("error", field, fmt.Sprintf("'%s' only applies to melee weapons (type is '%s')", field, itemType))
 	}
-	if hp, exists := monster["hit_points"]; !exists || hp == nil {
-		issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "hit_points", Message: "Missing required field: hit_points"})
-	} else if hpNum, ok := hp.(float64); ok && hpNum <= 0 {
-		issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "hit_points", Message: "hit_points must be > 0"})
+	if !contains(tags, "versatile") {
+		add("error", field, fmt.Sprintf("Item has '%s' but isn't tagged 'versatile'", field))
 	}
-
-	// --- kill_bonus_xp: required for CR >= 3 ---
-	if cr, ok := monster["challenge_rating"].(float64); ok && cr >= 3 {
-		if _, exists := monster["kill_bonus_xp"]; !exists {
-			issues = append(issues, Issue{Type: "warning", Category: "monsters", File: filename, Field: "kill_bonus_xp", Message: fmt.Sprintf("CR %.4g monster should have kill_bonus_xp (~25%% of xp)", cr)})
-		}
+	if s, ok := dice.(string); !ok || !validDiceRoll(s) {
+		add("error", field, fmt.Sprintf("'%s' must be a dice roll like '1d10', got %v", field, dice))
 	}
+	return issues
+}
 
-	// --- environment: required array ---
-	if env, exists := monster["environment"]; !exists || env == nil {
-		issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "environment", Message: "Missing required field: environment (array)"})
-	} else if envArr, ok := env.([]interface{}); !ok {
-		issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "environment", Message: "environment must be an array"})
-	} else if len(envArr) == 0 {
-		issues = append(issues, Issue{Type: "warning", Category: "monsters", File: filename, Field: "environment", Message: "environment array is empty — monster won't appear in any zone"})
+// CheckReviveItem validates a "revive" item, the one kind of consumable usable
+// while dying: it has to be a consumable and has to restore HP every time, from
+// a 'heal' roll that can't come up 0 or an inline hp effect with a positive
+// value. A revive that heals nothing would leave the player at 0 HP.
+func CheckReviveItem(item map[string]interface{}, tags []string) []Issue {
+	issues := []Issue{}
+	if !contains(tags, "revive") {
+		return issues
+	}
+	add := func(field, msg string) {
+		issues = append(issues, Issue{Type: "error", Category: "items", Field: field, Message: msg})
 	}
 
-	// --- speed object ---
-	if speedRaw, exists := monster["speed"]; !exists || speedRaw == nil {
-		issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "speed", Message: "Missing required field: speed"})
-	} else if speed, ok := speedRaw.(map[string]interface{}); !ok {
-		issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "speed", Message: "speed must be an object"})
-	} else {
-		for _, key := range []string{"walk", "fly", "swim", "climb"} {
-			if _, exists := speed[key]; !exists {
-				issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "speed." + key, Message: fmt.Sprintf("speed missing required key: %s", key)})
-			}
+	if !contains(tags, "consumable") {
+		add("tags", "Item is tagged 'revive' but not 'consumable' - it can't be used in combat")
+	}
+	heals := false
+	if raw, exists := item["heal"]; exists {
+		heal, _ := raw.(string)
+		if low, ok := minHealRoll(heal); !ok {
+			add("heal", fmt.Sprintf("'heal' must be a dice roll like '2d4 + 2', got %v", raw))
+		} else if low < 1 {
+			add("heal", fmt.Sprintf("'heal' on a revive item must always restore HP ('%s' can roll %d)", heal, low))
+		} else {
+			heals = true
 		}
 	}
-
-	// --- stats object with all 6 ability scores ---
-	if statsRaw, exists := monster["stats"]; !exists || statsRaw == nil {
-		issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "stats", Message: "Missing required field: stats"})
-	} else if stats, ok := statsRaw.(map[string]interface{}); !ok {
-		issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "stats", Message: "stats must be an object"})
-	} else {
-		for _, ability := range []string{"strength", "dexterity", "constitution", "intelligence", "wisdom", "charisma"} {
-			if v, exists := stats[ability]; !exists || v == nil {
-				issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "stats." + ability, Message: fmt.Sprintf("stats missing ability score: %s", ability)})
-			} else if score, ok := v.(float64); ok && (score < 1 || score > 30) {
-				issues = append(issues, Issue{Type: "warning", Category: "monsters", File: filename, Field: "stats." + ability, Message: fmt.Sprintf("stats.%s value %v is outside expected range 1-30", ability, score)})
-			}
+	effects, _ := item["effects"].([]interface{})
+	for i, raw := range effects {
+		effect, _ := raw.(map[string]interface{})
+		if t, _ := effect["type"].(string); t != "hp" && t != "health" {
+			continue
+		}
+		if v, _ := effect["value"].(float64); v > 0 {
+			heals = true
+		} else {
+			add(fmt.Sprintf("effects[%d].value", i), fmt.Sprintf("A revive item's hp effect must be positive, got %v", effect["value"]))
 		}
 	}
+	if !heals && len(issues) == 0 {
+		add("heal", "Item is tagged 'revive' but restores no HP - give it a 'heal' roll or a positive hp effect")
+	}
+	return issues
+}
 
-	// --- Required empty arrays (errors if missing) ---
-	for _, field := range []string{"damage_resistances", "damage_immunities", "damage_vulnerabilities", "condition_immunities", "special_abilities", "bonus_actions", "reactions", "legendary_actions"} {
-		if v, exists := monster[field]; !exists || v == nil {
-			issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: field, Message: fmt.Sprintf("Missing required field: %s (must be array, can be empty)", field)})
-		} else if _, ok := v.([]interface{}); !ok {
-			issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: field, Message: fmt.Sprintf("%s must be an array", field)})
+// minHealRoll returns the lowest result of a heal roll the way item use rolls
+// it: NdM with an optional flat modifier ("2d4 + 2", "1d8-1").
+func minHealRoll(s string) (int, bool) {
+	s = strings.ReplaceAll(strings.TrimSpace(s), " ", "")
+	mod := 0
+	if i := strings.IndexAny(s, "+-"); i > 0 {
+		m, err := strconv.Atoi(s[i+1:])
+		if err != nil {
+			return 0, false
+		}
+		if s[i] == '-' {
+			m = -m
 		}
+		mod, s = m, s[:i]
 	}
+	if !validDice(s) {
+		return 0, false
+	}
+	count, _ := strconv.Atoi(strings.SplitN(strings.ToLower(s), "d", 2)[0])
+	return count + mod, true
+}
 
-	// --- senses object with passive_perception ---
-	if sensesRaw, exists := monster["senses"]; !exists || sensesRaw == nil {
+// CheckLightSource validates a "light-source" item: it has to give light one of
+// the two ways combat recognises — used (a consumable whose apply_effect is a
+// light effect, i.e. one of lightEffectIDs) or equipped in the offhand.
+func CheckLightSource(item map[string]interface{}, tags []string, lightEffectIDs map[string]bool) []Issue {
+	issues := []Issue{}
+	if !contains(tags, "light-source") {
+		return issues
+	}
+	if gearSlot, _ := item["gear_slot"].(string); gearSlot != "" {
+		if gearSlot != "offhand" {
+			issues = append(issues, Issue{
+				Type:     "error",
+				Category: "items",
+				Field:    "gear_slot",
+				Message:  fmt.Sprintf("Equippable light sources only give light from the offhand (gear_slot is '%s')", gearSlot),
+			})
+		}
+		return issues
+	}
+
+	lit := false
+	effects, _ := item["effects"].([]interface{})
+	for _, raw := range effects {
+		effect, _ := raw.(map[string]interface{})
+		if id, _ := effect["apply_effect"].(string); lightEffectIDs[id] {
+			lit = true
+		}
+	}
+	switch {
+	case !lit:
+		issues = append(issues, Issue{
+			Type:     "error",
+			Category: "items",
+			Field:    "effects",
+			Message:  "Light-source items must apply a light effect (an effect with a 'light' modifier) or be equippable in the offhand",
+		})
+	case !contains(tags, "consumable"):
+		issues = append(issues, Issue{
+			Type:     "error",
+			Category: "items",
+			Field:    "tags",
+			Message:  "Light-source items lit by use must have the 'consumable' tag",
+		})
+	}
+	return issues
+}
+
+// loadLightEffectIDs returns the effects in game-data/effects that give light:
+// those with a positive "light" modifier.
+func loadLightEffectIDs() map[string]bool {
+	ids := make(map[string]bool)
+	filepath.WalkDir("game-data/effects", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".json") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		var effect struct {
+			Modifiers []struct {
+				Stat  string  `json:"stat"`
+				Value float64 `json:"value"`
+			} `json:"modifiers"`
+		}
+		if json.Unmarshal(data, &effect) != nil {
+			return nil
+		}
+		for _, m := range effect.Modifiers {
+			if m.Stat == "light" && m.Value > 0 {
+				ids[strings.TrimSuffix(filepath.Base(path), ".json")] = true
+			}
+		}
+		return nil
+	})
+	return ids
+}
+
+// loadEffectIDs returns the IDs of every effect file in game-data/effects.
+func loadEffectIDs() map[string]bool {
+	ids := make(map[string]bool)
+	filepath.WalkDir("game-data/effects", func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() && strings.HasSuffix(path, ".json") {
+			ids[strings.TrimSuffix(filepath.Base(path), ".json")] = true
+		}
+		return nil
+	})
+	return ids
+}
+
+// CheckItemEffectReferences checks that every named apply_effect in an item's
+// effects resolves to an effect file — ApplyItemEffects only logs a dangling one,
+// so a typo silently turns the consumable into a no-op.
+func CheckItemEffectReferences(item map[string]interface{}, validEffectIDs map[string]bool) []Issue {
+	var issues []Issue
+	effects, _ := item["effects"].([]interface{})
+	for i, raw := range effects {
+		effect, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		ref, exists := effect["apply_effect"]
+		if !exists {
+			continue
+		}
+		if id, ok := ref.(string); !ok || !validEffectIDs[id] {
+			issues = append(issues, Issue{
+				Type:     "error",
+				Category: "items",
+				Field:    fmt.Sprintf("effects[%d].apply_effect", i),
+				Message:  fmt.Sprintf("Effect '%v' not found in game-data/effects", ref),
+			})
+		}
+	}
+	return issues
+}
+
+// unlikelyConsumableOdds is the chance (0-1) below which a consumable whose
+// effects are all probabilistic is flagged as usually doing nothing.
+const unlikelyConsumableOdds = 0.5
+
+// CheckConsumableEffectChances validates the `chance` on an item's effects the
+// way ApplyItemEffects rolls them: only named apply_effect entries roll a chance
+// (default 100) as a whole percentage, so a chance below 1 can never fire, a
+// chance on an inline effect is ignored, and a consumable whose every effect is a
+// long shot usually does nothing. Once any effect rolls, a named effect left
+// without a chance is flagged: it quietly applies every time.
+func CheckConsumableEffectChances(item map[string]interface{}) []Issue {
+	issues := []Issue{}
+	effects, ok := item["effects"].([]interface{})
+	if !ok || len(effects) == 0 {
+		return issues
+	}
+
+	probabilistic := false
+	for _, raw := range effects {
+		effect, _ := raw.(map[string]interface{})
+		if _, named := effect["apply_effect"].(string); !named {
+			continue
+		}
+		if c, ok := effect["chance"].(float64); ok && c < 100 {
+			probabilistic = true
+		}
+	}
+
+	nothingOdds := 1.0 // probability that no effect applies
+	for i, raw := range effects {
+		effect, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		field := fmt.Sprintf("effects[%d].chance", i)
+		chanceRaw, hasChance := effect["chance"]
+		if _, named := effect["apply_effect"].(string); !named {
+			nothingOdds = 0 // inline effects always apply
+			if hasChance {
+				issues = append(issues, Issue{
+					Type:     "warning",
+					Category: "items",
+					Field:    field,
+					Message:  "'chance' only applies to named 'apply_effect' effects - inline effects always apply",
+				})
+			}
+			continue
+		}
+
+		chance := 100.0
+		if !hasChance && probabilistic {
+			issues = append(issues, Issue{
+				Type:     "warning",
+				Category: "items",
+				Field:    field,
+				Message:  fmt.Sprintf("Effect '%v' has no chance alongside rolled effects - it always applies; set 'chance': 100 if that's intended", effect["apply_effect"]),
+			})
+		}
+		if hasChance {
+			c, isNum := chanceRaw.(float64)
+			if !isNum {
+				issues = append(issues, Issue{
+					Type:     "error",
+					Category: "items",
+					Field:    field,
+					Message:  fmt.Sprintf("'chance' must be a number (got %v)", chanceRaw),
+				})
+				continue
+			}
+			chance = c
+		}
+		switch {
+		case chance < 1:
+			issues = append(issues, Issue{
+				Type:     "error",
+				Category: "items",
+				Field:    field,
+				Message:  fmt.Sprintf("Effect '%v' has chance %v and can never apply", effect["apply_effect"], chanceRaw),
+			})
+		case chance != math.Trunc(chance):
+			issues = append(issues, Issue{
+				Type:     "error",
+				Category: "items",
+				Field:    field,
+				Message:  fmt.Sprintf("chance %v must be a whole percentage 1-100", chanceRaw),
+			})
+		case chance > 100:
+			issues = append(issues, Issue{
+				Type:     "warning",
+				Category: "items",
+				Field:    field,
+				Message:  fmt.Sprintf("chance %v is over 100 - it always applies, use 100", chanceRaw),
+			})
+		}
+		nothingOdds *= 1 - math.Min(math.Max(math.Trunc(chance), 0), 100)/100 // rolled as a whole percent
+	}
+
+	if anyOdds := 1 - nothingOdds; anyOdds > 0 && anyOdds < unlikelyConsumableOdds {
+		issues = append(issues, Issue{
+			Type:     "warning",
+			Category: "items",
+			Field:    "effects",
+			Message:  fmt.Sprintf("Only a %.0f%% chance that any effect applies - this consumable usually does nothing", anyOdds*100),
+		})
+	}
+	return issues
+}
+
+// ValidateOneItem validates a single item by its ID (filename without .json)
+func ValidateOneItem(itemID string) ([]Issue, error) {
+	issues := []Issue{}
+	itemsPath := "game-data/items"
+
+	// Build valid item IDs set for reference checking
+	validItemIDs := make(map[string]bool)
+	filepath.WalkDir(itemsPath, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() && strings.HasSuffix(path, ".json") {
+			filename := strings.TrimSuffix(filepath.Base(path), ".json")
+			validItemIDs[filename] = true
+		}
+		return nil
+	})
+
+	filePath := filepath.Join(itemsPath, itemID+".json")
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("item file not found: %s", itemID)
+	}
+
+	issues = validateItemFile(filePath, validItemIDs, loadEffectIDs(), loadLightEffectIDs())
+	return issues, nil
+}
+
+// ValidateMonsters validates all monster files (skips wip/ and draft/ subdirectories)
+func ValidateMonsters() ([]Issue, int, error) {
+	issues := []Issue{}
+	files := 0
+	monstersPath := "game-data/monsters"
+
+	// Build valid item IDs (and their rarities) for loot table reference checking
+	validItemIDs := make(map[string]bool)
+	itemRarities := make(map[string]string)
+	filepath.WalkDir("game-data/items", func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() && strings.HasSuffix(path, ".json") {
+			id := strings.TrimSuffix(filepath.Base(path), ".json")
+			validItemIDs[id] = true
+			if data, readErr := os.ReadFile(path); readErr == nil {
+				var item struct {
+					Rarity string `json:"rarity"`
+				}
+				if json.Unmarshal(data, &item) == nil {
+					itemRarities[id] = strings.ToLower(item.Rarity)
+				}
+			}
+		}
+		return nil
+	})
+
+	err := filepath.WalkDir(monstersPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Skip subdirectories like wip/ and draft/
+		if d.IsDir() && path != monstersPath {
+			return filepath.SkipDir
+		}
+
+		if !d.IsDir() && strings.HasSuffix(path, ".json") {
+			monsterIssues := validateMonsterFile(path, validItemIDs)
+			issues = append(issues, monsterIssues...)
+			files++
+			if data, readErr := os.ReadFile(path); readErr == nil {
+				var monster struct {
+					LootTable types.LootTable `json:"loot_table"`
+				}
+				if json.Unmarshal(data, &monster) == nil {
+					issues = append(issues, CheckLootRarities(filepath.Base(path), monster.LootTable, itemRarities)...)
+				}
+			}
+		}
+		return nil
+	})
+
+	return issues, files, err
+}
+
+// monsterAttackBonusRange bounds a believable monster to-hit bonus.
+const (
+	minMonsterAttackBonus = -5
+	maxMonsterAttackBonus = 20
+)
+
+// CheckMonsterAttacks makes sure a monster has something to do on its turn:
+// at least one melee_attack or ranged_attack with a sane attack_bonus and
+// parseable NdM hit dice. Non-attack actions (multiattack, special) and
+// damageless rider attacks (dice "0" with a hit special) don't count — a
+// special is only used while it's ready and in range, so a monster without a
+// damaging attack to fall back on stands idle. Each attack with bad dice or
+// to-hit is reported too.
+func CheckMonsterAttacks(actions []types.MonsterAction) []Issue {
+	issues := []Issue{}
+	usable := 0
+	for i, action := range actions {
+		if action.Type != "melee_attack" && action.Type != "ranged_attack" {
+			continue
+		}
+		ok := true
+		if action.AttackBonus < minMonsterAttackBonus || action.AttackBonus > maxMonsterAttackBonus {
+			ok = false
+			issues = append(issues, Issue{
+				Type:     "error",
+				Category: "monsters",
+				Field:    fmt.Sprintf("actions[%d].attack_bonus", i),
+				Message:  fmt.Sprintf("attack_bonus %+d is outside %+d..%+d", action.AttackBonus, minMonsterAttackBonus, maxMonsterAttackBonus),
+			})
+		}
+		if !validDice(action.Hit.Dice) {
+			ok = false
+			// "0" is a damageless rider attack (a web that restrains): legal, but it
+			// doesn't count as the monster's attack. A missing field is reported elsewhere.
+			riderOnly := action.Hit.Dice == "0" && action.Hit.Special != nil
+			if action.Hit.Dice != "" && !riderOnly {
+				issues = append(issues, Issue{
+					Type:     "error",
+					Category: "monsters",
+					Field:    fmt.Sprintf("actions[%d].hit.dice", i),
+					Message:  fmt.Sprintf("hit dice '%s' must look like NdM (e.g. 2d6)", action.Hit.Dice),
+				})
+			}
+		}
+		if ok && !types.IsDamageType(action.Hit.Type) {
+			// Player resistances key off the hit type, so a damaging attack needs one.
+			issues = append(issues, Issue{
+				Type:     "error",
+				Category: "monsters",
+				Field:    fmt.Sprintf("actions[%d].hit.type", i),
+				Message:  fmt.Sprintf("hit type '%s' is not a damage type: %s", action.Hit.Type, damageTypeList()),
+			})
+		}
+		if ok {
+			usable++
+		}
+	}
+	if usable == 0 {
+		issues = append(issues, Issue{
+			Type:     "error",
+			Category: "monsters",
+			Field:    "actions",
+			Message:  "Monster has no usable attack (melee_attack or ranged_attack with valid to-hit and dice) — it would stand idle in combat",
+		})
+	}
+	return issues
+}
+
+// monsterSpecialEffects are the conditions a special's area_save mechanic can
+// inflict. Mirrors the combat engine's monster effect → condition map.
+var monsterSpecialEffects = map[string]bool{
+	"knocked_prone": true, "prone": true, "paralyzed": true, "restrained": true,
+	"poisoned": true, "blinded": true, "frightened": true, "stunned": true,
+	"charmed": true, "charmed_and_move_toward": true,
+}
+
+// CheckMonsterActionBlock validates the non-attack entries of a monster's
+// actions. A multiattack needs an attack count of at least 2 (only one
+// multiattack per monster), and any sequence must list that many of the
+// monster's own attacks. A special needs a recharge of "none",
+// "once_per_combat" or "recharge_N_6" and an area_save mechanic with an
+// ability, DC, range and valid damage dice; a special the engine can't resolve
+// is only a warning, since the monster just never uses it.
+func CheckMonsterActionBlock(actions []types.MonsterAction) []Issue {
+	issues := []Issue{}
+	add := func(kind, field, format string, args ...interface{}) {
+		issues = append(issues, Issue{Type: kind, Category: "monsters", Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+	attacks := map[string]bool{}
+	for _, action := range actions {
+		if action.Type == "melee_attack" || action.Type == "ranged_attack" {
+			attacks[action.Name] = true
+		}
+	}
+
+	multiattacks := 0
+	for i, action := range actions {
+		switch action.Type {
+		case "multiattack":
+			multiattacks++
+			if multiattacks > 1 {
+				add("error", fmt.Sprintf("actions[%d]", i), "a monster can have only one multiattack")
+			}
+			if action.Attacks < 2 {
+				add("error", fmt.Sprintf("actions[%d].attacks", i), "multiattack needs attacks of at least 2, got %d", action.Attacks)
+			}
+			if len(action.Sequence) > 0 && len(action.Sequence) != action.Attacks {
+				add("error", fmt.Sprintf("actions[%d].sequence", i), "sequence lists %d attacks but attacks is %d", len(action.Sequence), action.Attacks)
+			}
+			for j, name := range action.Sequence {
+				if !attacks[name] {
+					add("error", fmt.Sprintf("actions[%d].sequence[%d]", i, j), "'%s' is not one of this monster's attacks", name)
+				}
+			}
+
+		case "special":
+			if !validMonsterRecharge(action.Recharge) {
+				add("error", fmt.Sprintf("actions[%d].recharge", i), "recharge '%s' must be none, once_per_combat or recharge_N_6", action.Recharge)
+			}
+			mech := action.Mechanic
+			if mech == nil || mech.Type != "area_save" {
+				add("warning", fmt.Sprintf("actions[%d].mechanic", i), "special has no area_save mechanic — the monster will never use it")
+				continue
+			}
+			field := fmt.Sprintf("actions[%d].mechanic", i)
+			if !schemaValidStats[mech.Ability] {
+				add("error", field+".ability", "ability '%s' is not an ability score", mech.Ability)
+			}
+			if mech.DC < 1 || mech.DC > 30 {
+				add("error", field+".dc", "dc %d is outside 1..30", mech.DC)
+			}
+			if mech.Range < 1 {
+				add("error", field+".range", "range must be at least 1, got %d", mech.Range)
+			}
+			if mech.OnFail != "" && mech.OnFail != "drop_to_zero" {
+				add("error", field+".on_fail", "on_fail '%s' must be drop_to_zero", mech.OnFail)
+			}
+			for _, roll := range []struct{ name, dice, dtype string }{
+				{"dice", mech.Dice, mech.DamageType},
+				{"on_success_dice", mech.OnSuccessDice, mech.OnSuccessType},
+			} {
+				if roll.dice == "" {
+					continue
+				}
+				if !validDice(roll.dice) {
+					add("error", field+"."+roll.name, "dice '%s' must look like NdM (e.g. 2d6)", roll.dice)
+				}
+				if !types.IsDamageType(roll.dtype) {
+					add("error", field+"."+roll.name, "damage type '%s' is not a damage type: %s", roll.dtype, damageTypeList())
+				}
+			}
+			if mech.Effect != "" && !monsterSpecialEffects[mech.Effect] {
+				add("warning", field+".effect", "effect '%s' isn't a condition the engine applies", mech.Effect)
+			}
+			if mech.Dice == "" && mech.OnFail == "" && mech.Effect == "" {
+				add("error", field, "area_save does nothing on a failed save: set dice, effect or on_fail")
+			}
+		}
+	}
+	return issues
+}
+
+// validMonsterRecharge reports whether a special's recharge is one the combat
+// engine understands ("" reads as "none").
+func validMonsterRecharge(recharge string) bool {
+	switch recharge {
+	case "", "none", "once_per_combat":
+		return true
+	}
+	rest, found := strings.CutPrefix(recharge, "recharge_")
+	if !found {
+		return false
+	}
+	n, err := strconv.Atoi(strings.TrimSuffix(rest, "_6"))
+	return err == nil && n >= 2 && n <= 6
+}
+
+func damageTypeList() string {
+	return strings.Join(types.DamageTypes, ", ")
+}
+
+// CheckItemDamageDefenses validates the damage_resistances / damage_immunities
+// lists gear can carry: each entry has to be a known damage type.
+func CheckItemDamageDefenses(item map[string]interface{}) []Issue {
+	var issues []Issue
+	for _, field := range []string{"damage_resistances", "damage_immunities"} {
+		raw, exists := item[field]
+		if !exists {
+			continue
+		}
+		list, ok := raw.([]interface{})
+		if !ok {
+			issues = append(issues, Issue{
+				Type:     "error",
+				Category: "items",
+				Field:    field,
+				Message:  fmt.Sprintf("'%s' must be a list of damage types", field),
+			})
+			continue
+		}
+		for i, v := range list {
+			if t, _ := v.(string); !types.IsDamageType(t) {
+				issues = append(issues, Issue{
+					Type:     "error",
+					Category: "items",
+					Field:    fmt.Sprintf("%s[%d]", field, i),
+					Message:  fmt.Sprintf("'%v' is not a damage type: %s", v, damageTypeList()),
+				})
+			}
+		}
+	}
+	return issues
+}
+
+// monsterDamageModifierFields are the lists ResolveDamageToMonster reads, keyed
+// by the bare names an author might write instead (which the server ignores).
+var monsterDamageModifierFields = map[string]string{
+	"resistances":     "damage_resistances",
+	"immunities":      "damage_immunities",
+	"vulnerabilities": "damage_vulnerabilities",
+}
+
+// CheckMonsterDamageModifiers validates a monster's damage_resistances,
+// damage_immunities and damage_vulnerabilities: every entry has to be one of
+// the damage types weapons, spells and attacks deal, or the modifier silently
+// never applies. A near miss ("slash") names the type it probably meant. The
+// bare field names (resistances, …) are flagged too, since the server only
+// reads the damage_ ones.
+func CheckMonsterDamageModifiers(monster map[string]interface{}) []Issue {
+	var issues []Issue
+	for _, bare := range []string{"resistances", "immunities", "vulnerabilities"} {
+		if _, exists := monster[bare]; exists {
+			issues = append(issues, Issue{
+				Type:     "error",
+				Category: "monsters",
+				Field:    bare,
+				Message:  fmt.Sprintf("'%s' is never read — use '%s'", bare, monsterDamageModifierFields[bare]),
+			})
+		}
+	}
+	for _, field := range []string{"damage_resistances", "damage_immunities", "damage_vulnerabilities"} {
+		list, ok := monster[field].([]interface{})
+		if !ok {
+			continue // missing or not an array: reported with the required arrays
+		}
+		for i, v := range list {
+			t, _ := v.(string)
+			if types.IsDamageType(t) {
+				continue
+			}
+			issue := Issue{
+				Type:     "error",
+				Category: "monsters",
+				Field:    fmt.Sprintf("%s[%d]", field, i),
+				Message:  fmt.Sprintf("'%v' is not a damage type: %s", v, damageTypeList()),
+			}
+			if lower := strings.ToLower(strings.TrimSpace(t)); types.IsDamageType(lower) {
+				issue.Type = "warning"
+				issue.Message = fmt.Sprintf("'%s' should be written '%s'", t, lower)
+			} else if guess := closestDamageType(lower); guess != "" {
+				issue.Message = fmt.Sprintf("'%v' is not a damage type — did you mean '%s'?", v, guess)
+			}
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// closestDamageType returns the damage type s looks like a short form of
+// ("slash" → "slashing"), or "" when there's no single match.
+func closestDamageType(s string) string {
+	if len(s) < 3 {
+		return ""
+	}
+	match := ""
+	for _, name := range types.DamageTypes {
+		if strings.HasPrefix(name, s) || strings.HasPrefix(s, name) {
+			if match != "" {
+				return ""
+			}
+			match = name
+		}
+	}
+	return match
+}
+
+// validDice reports whether s is an NdM dice expression with N, M ≥ 1.
+func validDice(s string) bool {
+	parts := strings.SplitN(strings.ToLower(strings.TrimSpace(s)), "d", 2)
+	if len(parts) != 2 {
+		return false
+	}
+	count, err1 := strconv.Atoi(parts[0])
+	sides, err2 := strconv.Atoi(parts[1])
+	return err1 == nil && err2 == nil && count >= 1 && sides >= 1
+}
+
+// CheckSpellClasses checks the names in a spell's class list: each has to be a
+// string, and one that isn't a playable class is flagged since no character can
+// learn the spell through it. A nil classes slice skips the playable check.
+func CheckSpellClasses(spell map[string]interface{}, classes []types.PlayableOption) []Issue {
+	var issues []Issue
+	list, _ := spell["classes"].([]interface{})
+	set := newPlayableSet(classes)
+	for i, c := range list {
+		field := fmt.Sprintf("classes[%d]", i)
+		name, ok := c.(string)
+		if !ok || name == "" {
+			issues = append(issues, Issue{
+				Type:     "error",
+				Category: "spells",
+				Field:    field,
+				Message:  "Class names must be non-empty strings",
+			})
+			continue
+		}
+		if _, known := set.resolve(name); classes != nil && !known {
+			issues = append(issues, Issue{
+				Type:     "warning",
+				Category: "spells",
+				Field:    field,
+				Message:  fmt.Sprintf("'%s' is not a playable class (see %s)", name, filepath.Base(PlayablePath)),
+			})
+		}
+	}
+	return issues
+}
+
+// CheckSpellRolls checks a spell's optional damage and heal rolls parse.
+func CheckSpellRolls(spell map[string]interface{}) []Issue {
+	var issues []Issue
+	for _, field := range []string{"damage", "heal"} {
+		raw, exists := spell[field]
+		if !exists || raw == nil {
+			continue
+		}
+		if roll, ok := raw.(string); !ok || !validDiceRoll(roll) {
+			issues = append(issues, Issue{
+				Type:     "error",
+				Category: "spells",
+				Field:    field,
+				Message:  fmt.Sprintf("'%s' must be a dice roll like '2d6' or '1d4+1', or a flat number (got %v)", field, raw),
+			})
+		}
+	}
+	return issues
+}
+
+// validDiceRoll reports whether s is a dice roll as spells write them: an NdM
+// expression with an optional flat modifier ("3d4+3", "1d8 + 3"), or a flat
+// number ("5").
+func validDiceRoll(s string) bool {
+	s = strings.ReplaceAll(strings.TrimSpace(s), " ", "")
+	if n, err := strconv.Atoi(s); err == nil {
+		return n >= 0
+	}
+	if i := strings.IndexAny(s, "+-"); i > 0 {
+		if _, err := strconv.Atoi(s[i+1:]); err != nil {
+			return false
+		}
+		s = s[:i]
+	}
+	return validDice(s)
+}
+
+// lootRarityRank orders rarities from most to least common. Loot tier names
+// use the same scale plus "very_rare". Mirrors the server's combat.RarityRank.
+var lootRarityRank = map[string]int{
+	"common": 0, "uncommon": 1, "rare": 2, "very_rare": 3,
+	"legendary": 4, "mythical": 5, "mythic": 5,
+}
+
+// CheckLootRarities flags loot entries whose item is rarer than the tier that
+// drops it — a legendary blade in a "common" tier would drop as often as rat
+// tails. Tiers whose name isn't a rarity are skipped; itemRarities maps item
+// ID to its lowercased rarity.
+func CheckLootRarities(filename string, table types.LootTable, itemRarities map[string]string) []Issue {
+	var issues []Issue
+	for i, tier := range table.Tiers {
+		tierRank, ok := lootRarityRank[strings.ToLower(tier.Name)]
+		if !ok {
+			continue
+		}
+		for j, entry := range tier.Entries {
+			rarity, known := itemRarities[entry.Item]
+			if !known {
+				continue
+			}
+			if itemRank, ok := lootRarityRank[rarity]; ok && itemRank > tierRank {
+				issues = append(issues, Issue{
+					Type:     "warning",
+					Category: "monsters",
+					File:     filename,
+					Field:    fmt.Sprintf("loot_table.tiers[%d].entries[%d].item", i, j),
+					Message:  fmt.Sprintf("%s item '%s' is in the %s tier; move it to a %s or rarer tier", rarity, entry.Item, tier.Name, rarity),
+				})
+			}
+		}
+	}
+	return issues
+}
+
+// CheckMonsterStatBlock checks the values combat reads off a monster, beyond
+// their presence: challenge_rating is a non-negative number, armor_class a
+// positive whole number, hp_dice a dice roll (e.g. 2d8+2), every ability score a
+// number from 1 to 30, and every loot item a real item ("nothing" is the empty
+// roll). A monster that can never drop anything gets a warning. validItemIDs
+// empty skips the item check. Issues come back without File set.
+func CheckMonsterStatBlock(monster map[string]interface{}, validItemIDs map[string]bool) []Issue {
+	var issues []Issue
+	add := func(typ, field, msg string) {
+		issues = append(issues, Issue{Type: typ, Category: "monsters", Field: field, Message: msg})
+	}
+
+	if cr, exists := monster["challenge_rating"]; exists && cr != nil {
+		if n, ok := cr.(float64); !ok || n < 0 {
+			add("error", "challenge_rating", fmt.Sprintf("challenge_rating %v must be a non-negative number", cr))
+		}
+	}
+	if ac, exists := monster["armor_class"]; exists && ac != nil {
+		if n, ok := ac.(float64); !ok || n < 1 || n != math.Trunc(n) {
+			add("error", "armor_class", fmt.Sprintf("armor_class %v must be a positive whole number", ac))
+		} else if n > 30 {
+			add("warning", "armor_class", fmt.Sprintf("armor_class %v is outside expected range 1-30", n))
+		}
+	}
+	if hpDice, exists := monster["hp_dice"]; exists && hpDice != nil && hpDice != "" {
+		if d, ok := hpDice.(string); !ok || !validDiceRoll(d) {
+			add("error", "hp_dice", fmt.Sprintf("hp_dice '%v' must be a dice roll like 2d8+2", hpDice))
+		}
+	}
+	if stats, ok := monster["stats"].(map[string]interface{}); ok {
+		for _, ability := range []string{"strength", "dexterity", "constitution", "intelligence", "wisdom", "charisma"} {
+			v, exists := stats[ability]
+			if !exists || v == nil {
+				continue
+			}
+			if score, ok := v.(float64); !ok || score < 1 || score > 30 {
+				add("error", "stats."+ability, fmt.Sprintf("stats.%s value %v must be a number from 1 to 30", ability, v))
+			}
+		}
+	}
+
+	lt, ok := monster["loot_table"].(map[string]interface{})
+	if !ok {
+		return issues
+	}
+	checkItem := func(field string, raw interface{}) bool {
+		entry, _ := raw.(map[string]interface{})
+		itemID, _ := entry["item"].(string)
+		if itemID == "" || itemID == "nothing" {
+			return false
+		}
+		if len(validItemIDs) > 0 && !validItemIDs[itemID] {
+			add("error", field, fmt.Sprintf("loot item '%s' not found in game-data/items/", itemID))
+		}
+		return true
+	}
+	drops := false
+	guaranteed, _ := lt["guaranteed"].([]interface{})
+	for i, drop := range guaranteed {
+		if checkItem(fmt.Sprintf("loot_table.guaranteed[%d].item", i), drop) {
+			drops = true
+		}
+	}
+	rolls, _ := lt["rolls"].(float64)
+	tiers, _ := lt["tiers"].([]interface{})
+	for i, tierRaw := range tiers {
+		tier, _ := tierRaw.(map[string]interface{})
+		entries, _ := tier["entries"].([]interface{})
+		for j, entry := range entries {
+			if checkItem(fmt.Sprintf("loot_table.tiers[%d].entries[%d].item", i, j), entry) && rolls > 0 {
+				drops = true
+			}
+		}
+	}
+	if !drops {
+		add("warning", "loot_table", "loot_table can never drop anything — the monster leaves no loot")
+	}
+	return issues
+}
+
+func validateMonsterFile(filePath string, validItemIDs map[string]bool) []Issue {
+	issues := []Issue{}
+	filename := filepath.Base(filePath)
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		issues = append(issues, Issue{
+			Type:     "error",
+			Category: "monsters",
+			File:     filename,
+			Message:  fmt.Sprintf("Failed to read file: %v", err),
+		})
+		return issues
+	}
+
+	var monster map[string]interface{}
+	if err := json.Unmarshal(data, &monster); err != nil {
+		issues = append(issues, Issue{
+			Type:     "error",
+			Category: "monsters",
+			File:     filename,
+			Message:  fmt.Sprintf("Invalid JSON: %v", err),
+		})
+		return issues
+	}
+
+	// --- Required top-level string fields ---
+	requiredStrings := []string{"id", "name", "type", "size", "alignment", "hp_dice"}
+	for _, field := range requiredStrings {
+		v, exists := monster[field]
+		if !exists || v == nil || v == "" {
+			issues = append(issues, Issue{
+				Type:     "error",
+				Category: "monsters",
+				File:     filename,
+				Field:    field,
+				Message:  fmt.Sprintf("Missing required field: %s", field),
+			})
+		}
+	}
+
+	// --- Required numeric fields ---
+	if cr, exists := monster["challenge_rating"]; !exists || cr == nil {
+		issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "challenge_rating", Message: "Missing required field: challenge_rating"})
+	}
+	if xp, exists := monster["xp"]; !exists || xp == nil {
+		issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "xp", Message: "Missing required field: xp"})
+	} else if xpNum, ok := xp.(float64); ok && xpNum < 0 {
+		issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "xp", Message: "xp must be >= 0"})
+	}
+	if ac, exists := monster["armor_class"]; !exists || ac == nil {
+		issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "armor_class", Message: "Missing required field: armor_class"})
+	}
+	if hp, exists := monster["hit_points"]; !exists || hp == nil {
+		issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "hit_points", Message: "Missing required field: hit_points"})
+	} else if hpNum, ok := hp.(float64); ok && hpNum <= 0 {
+		issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "hit_points", Message: "hit_points must be > 0"})
+	}
+
+	// --- kill_bonus_xp: required for CR >= 3 ---
+	if cr, ok := monster["challenge_rating"].(float64); ok && cr >= 3 {
+		if _, exists := monster["kill_bonus_xp"]; !exists {
+			issues = append(issues, Issue{Type: "warning", Category: "monsters", File: filename, Field: "kill_bonus_xp", Message: fmt.Sprintf("CR %.4g monster should have kill_bonus_xp (~25%% of xp)", cr)})
+		}
+	}
+
+	// --- environment: required array ---
+	if env, exists := monster["environment"]; !exists || env == nil {
+		issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "environment", Message: "Missing required field: environment (array)"})
+	} else if envArr, ok := env.([]interface{}); !ok {
+		issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "environment", Message: "environment must be an array"})
+	} else if len(envArr) == 0 {
+		issues = append(issues, Issue{Type: "warning", Category: "monsters", File: filename, Field: "environment", Message: "environment array is empty — monster won't appear in any zone"})
+	}
+
+	// --- speed object ---
+	if speedRaw, exists := monster["speed"]; !exists || speedRaw == nil {
+		issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "speed", Message: "Missing required field: speed"})
+	} else if speed, ok := speedRaw.(map[string]interface{}); !ok {
+		issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "speed", Message: "speed must be an object"})
+	} else {
+		for _, key := range []string{"walk", "fly", "swim", "climb"} {
+			if _, exists := speed[key]; !exists {
+				issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "speed." + key, Message: fmt.Sprintf("speed missing required key: %s", key)})
+			}
+		}
+	}
+
+	// --- stats object with all 6 ability scores ---
+	if statsRaw, exists := monster["stats"]; !exists || statsRaw == nil {
+		issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "stats", Message: "Missing required field: stats"})
+	} else if stats, ok := statsRaw.(map[string]interface{}); !ok {
+		issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "stats", Message: "stats must be an object"})
+	} else {
+		for _, ability := range []string{"strength", "dexterity", "constitution", "intelligence", "wisdom", "charisma"} {
+			if v, exists := stats[ability]; !exists || v == nil {
+				issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "stats." + ability, Message: fmt.Sprintf("stats missing ability score: %s", ability)})
+			}
+		}
+	}
+
+	// --- Required empty arrays (errors if missing) ---
+	for _, field := range []string{"damage_resistances", "damage_immunities", "damage_vulnerabilities", "condition_immunities", "special_abilities", "bonus_actions", "reactions", "legendary_actions"} {
+		if v, exists := monster[field]; !exists || v == nil {
+			issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: field, Message: fmt.Sprintf("Missing required field: %s (must be array, can be empty)", field)})
+		} else if _, ok := v.([]interface{}); !ok {
+			issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: field, Message: fmt.Sprintf("%s must be an array", field)})
+		}
+	}
+
+	// --- damage modifiers name real damage types ---
+	for _, issue := range CheckMonsterDamageModifiers(monster) {
+		issue.File = filename
+		issues = append(issues, issue)
+	}
+
+	// --- senses object with passive_perception ---
+	if sensesRaw, exists := monster["senses"]; !exists || sensesRaw == nil {
 		issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "senses", Message: "Missing required field: senses"})
 	} else if senses, ok := sensesRaw.(map[string]interface{}); !ok {
 		issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "senses", Message: "senses must be an object"})
@@ -880,8 +1897,19 @@ func validateMonsterFile(filePath string, validItemIDs map[string]bool) []Issue
 	} else if actions, ok := actionsRaw.([]interface{}); !ok {
 		issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: "actions", Message: "actions must be an array"})
 	} else {
-		if len(actions) == 0 {
-			issues = append(issues, Issue{Type: "warning", Category: "monsters", File: filename, Field: "actions", Message: "actions array is empty — monster cannot attack"})
+		// Every monster needs an attack the AI can actually use
+		var typed struct {
+			Actions []types.MonsterAction `json:"actions"`
+		}
+		if raw, err := json.Marshal(map[string]interface{}{"actions": actions}); err == nil && json.Unmarshal(raw, &typed) == nil {
+			for _, issue := range CheckMonsterAttacks(typed.Actions) {
+				issue.File = filename
+				issues = append(issues, issue)
+			}
+			for _, issue := range CheckMonsterActionBlock(typed.Actions) {
+				issue.File = filename
+				issues = append(issues, issue)
+			}
 		}
 		// Validate each action
 		for i, actionRaw := range actions {
@@ -987,11 +2015,8 @@ func validateMonsterFile(filePath string, validItemIDs map[string]bool) []Issue
 						if !ok {
 							continue
 						}
-						itemID, _ := entry["item"].(string)
-						if itemID == "" {
+						if itemID, _ := entry["item"].(string); itemID == "" {
 							issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: fmt.Sprintf("loot_table.tiers[%d].entries[%d].item", i, j), Message: "loot entry missing required field: item"})
-						} else if itemID != "nothing" && len(validItemIDs) > 0 && !validItemIDs[itemID] {
-							issues = append(issues, Issue{Type: "warning", Category: "monsters", File: filename, Field: fmt.Sprintf("loot_table.tiers[%d].entries[%d].item", i, j), Message: fmt.Sprintf("loot item '%s' not found in game-data/items/", itemID)})
 						}
 						if _, exists := entry["weight"]; !exists {
 							issues = append(issues, Issue{Type: "error", Category: "monsters", File: filename, Field: fmt.Sprintf("loot_table.tiers[%d].entries[%d].weight", i, j), Message: "loot entry missing required field: weight"})
@@ -1000,27 +2025,32 @@ func validateMonsterFile(filePath string, validItemIDs map[string]bool) []Issue
 				}
 			}
 		}
-		// Validate guaranteed drops reference valid items
-		if guaranteed, ok := lt["guaranteed"].([]interface{}); ok {
-			for i, dropRaw := range guaranteed {
-				if drop, ok := dropRaw.(map[string]interface{}); ok {
-					itemID, _ := drop["item"].(string)
-					if itemID != "" && itemID != "nothing" && len(validItemIDs) > 0 && !validItemIDs[itemID] {
-						issues = append(issues, Issue{Type: "warning", Category: "monsters", File: filename, Field: fmt.Sprintf("loot_table.guaranteed[%d].item", i), Message: fmt.Sprintf("guaranteed loot item '%s' not found in game-data/items/", itemID)})
-					}
-				}
-			}
-		}
+	}
+
+	// Values combat reads: CR, AC, HP dice, ability scores, loot item references
+	for _, issue := range CheckMonsterStatBlock(monster, validItemIDs) {
+		issue.File = filename
+		issues = append(issues, issue)
 	}
 
 	return issues
 }
 
 // ValidateLocations validates all location files
-func ValidateLocations() ([]Issue, error) {
+func ValidateLocations() ([]Issue, int, error) {
 	issues := []Issue{}
+	files := 0
 	locationsPath := "game-data/locations"
 
+	// Build valid effect IDs for environment/building effect references, and
+	// the monster IDs environment encounter tables may name
+	validEffectIDs := loadEffectIDs()
+	validMonsterIDs := loadMonsterIDs()
+
+	// Every parsed location by filename, for the connection graph check that
+	// needs the full set of location IDs
+	graph := make(map[string]map[string]interface{})
+
 	// Check cities and environments
 	subDirs := []string{"cities", "environments"}
 	for _, subDir := range subDirs {
@@ -1031,21 +2061,113 @@ func ValidateLocations() ([]Issue, error) {
 			}
 
 			if !d.IsDir() && strings.HasSuffix(path, ".json") {
-				locationIssues := validateLocationFile(path)
+				locationIssues := validateLocationFile(path, validEffectIDs, validMonsterIDs)
 				issues = append(issues, locationIssues...)
+				files++
+
+				var location map[string]interface{}
+				if data, readErr := os.ReadFile(path); readErr == nil && json.Unmarshal(data, &location) == nil {
+					graph[filepath.Base(path)] = location
+				}
 			}
 			return nil
 		})
 
 		if err != nil {
-			return issues, err
+			return issues, files, err
 		}
 	}
 
-	return issues, nil
+	issues = append(issues, CheckLocationGraph(graph)...)
+
+	return issues, files, nil
+}
+
+// locationLink is one travel link in the location graph: from a city district's
+// connections or an environment's connects list.
+type locationLink struct {
+	file, field, from, to string
+}
+
+// CheckLocationGraph checks the travel links between locations, keyed by
+// filename. The nodes are city districts (their connections map, direction →
+// target) and environments (their connects list). Every link must name an
+// existing district or environment, or the player is stranded on a dead end; a
+// link the target doesn't return is a warning, since travel back would need a
+// different route.
+func CheckLocationGraph(locations map[string]map[string]interface{}) []Issue {
+	files := make([]string, 0, len(locations))
+	for f := range locations {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+
+	var links []locationLink
+	linked := make(map[string]map[string]bool) // node → targets it links to
+	node := func(id string) {
+		if linked[id] == nil {
+			linked[id] = make(map[string]bool)
+		}
+	}
+	for _, file := range files {
+		location := locations[file]
+		if districts, ok := location["districts"].(map[string]interface{}); ok {
+			keys := make([]string, 0, len(districts))
+			for k := range districts {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				district, _ := districts[key].(map[string]interface{})
+				id, _ := district["id"].(string)
+				if id == "" {
+					continue
+				}
+				node(id)
+				connections, _ := district["connections"].(map[string]interface{})
+				dirs := make([]string, 0, len(connections))
+				for dir := range connections {
+					dirs = append(dirs, dir)
+				}
+				sort.Strings(dirs)
+				for _, dir := range dirs {
+					to, _ := connections[dir].(string)
+					links = append(links, locationLink{file, fmt.Sprintf("districts.%s.connections.%s", key, dir), id, to})
+				}
+			}
+			continue
+		}
+		id, _ := location["id"].(string)
+		if id == "" {
+			continue
+		}
+		node(id)
+		connects, _ := location["connects"].([]interface{})
+		for i, c := range connects {
+			to, _ := c.(string)
+			links = append(links, locationLink{file, fmt.Sprintf("connects[%d]", i), id, to})
+		}
+	}
+	for _, l := range links {
+		linked[l.from][l.to] = true
+	}
+
+	var issues []Issue
+	for _, l := range links {
+		if _, exists := linked[l.to]; !exists {
+			issues = append(issues, Issue{Type: "error", Category: "locations", File: l.file, Field: l.field,
+				Message: fmt.Sprintf("Connection target '%s' is not a known district or environment", l.to)})
+			continue
+		}
+		if !linked[l.to][l.from] {
+			issues = append(issues, Issue{Type: "warning", Category: "locations", File: l.file, Field: l.field,
+				Message: fmt.Sprintf("One-way connection: %s links to %s, but %s doesn't link back", l.from, l.to, l.to)})
+		}
+	}
+	return issues
 }
 
-func validateLocationFile(filePath string) []Issue {
+func validateLocationFile(filePath string, validEffectIDs, validMonsterIDs map[string]bool) []Issue {
 	issues := []Issue{}
 	filename := filepath.Base(filePath)
 
@@ -1085,13 +2207,152 @@ func validateLocationFile(filePath string) []Issue {
 		}
 	}
 
+	// Ambient light must be a known level
+	if light, exists := location["light"]; exists {
+		if level, _ := light.(string); !validLightLevels[level] {
+			issues = append(issues, Issue{
+				Type:     "error",
+				Category: "locations",
+				File:     filename,
+				Field:    "light",
+				Message:  fmt.Sprintf("Invalid light '%v'. Must be one of: bright, dim, dark", light),
+			})
+		}
+	}
+
+	// Travel encounters: a known danger rating and a table of real monsters
+	for _, issue := range CheckLocationEncounters(location, validMonsterIDs) {
+		issue.File = filename
+		issues = append(issues, issue)
+	}
+
+	// Environment- and building-scoped effects must reference real effects
+	checkLocationEffects(location["effects"], "effects", filename, validEffectIDs, &issues)
+	if districts, ok := location["districts"].(map[string]interface{}); ok {
+		for districtID, dd := range districts {
+			district, _ := dd.(map[string]interface{})
+			buildings, _ := district["buildings"].([]interface{})
+			for _, bd := range buildings {
+				b, ok := bd.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				field := fmt.Sprintf("districts.%s.buildings.%v.effects", districtID, b["id"])
+				checkLocationEffects(b["effects"], field, filename, validEffectIDs, &issues)
+			}
+		}
+	}
+
+	return issues
+}
+
+// validTravelDifficulties are the danger ratings an environment may declare;
+// they scale how often travel encounters fire there (see the server's
+// encounter.Danger).
+var validTravelDifficulties = []string{"easy", "moderate", "hard", "very_hard"}
+
+// CheckLocationEncounters checks an environment's travel_difficulty and its
+// optional "encounters" table: a list of {"monster", "weight"} entries naming
+// migrated monsters with positive whole weights. Only environments roll travel
+// encounters, so a table anywhere else is an error. Issues come back without
+// File set.
+func CheckLocationEncounters(location map[string]interface{}, validMonsterIDs map[string]bool) []Issue {
+	var issues []Issue
+	add := func(field, msg string) {
+		issues = append(issues, Issue{Type: "error", Category: "locations", Field: field, Message: msg})
+	}
+
+	if raw, exists := location["travel_difficulty"]; exists {
+		if d, _ := raw.(string); !containsID(validTravelDifficulties, d) {
+			add("travel_difficulty", fmt.Sprintf("Invalid travel_difficulty '%v'. Must be one of: %s", raw, strings.Join(validTravelDifficulties, ", ")))
+		}
+	}
+
+	raw, exists := location["encounters"]
+	if !exists {
+		return issues
+	}
+	if locType, _ := location["type"].(string); locType != "environment" {
+		add("encounters", "encounters only apply to environments (type \"environment\")")
+		return issues
+	}
+	table, ok := raw.([]interface{})
+	if !ok {
+		add("encounters", "encounters must be an array of {monster, weight} entries")
+		return issues
+	}
+	for i, e := range table {
+		field := fmt.Sprintf("encounters[%d]", i)
+		entry, ok := e.(map[string]interface{})
+		if !ok {
+			add(field, "Entry must be an object")
+			continue
+		}
+		if monster, _ := entry["monster"].(string); !validMonsterIDs[monster] {
+			add(field+".monster", fmt.Sprintf("Monster '%v' not found in game-data/monsters", entry["monster"]))
+		}
+		if w, exists := entry["weight"]; exists {
+			if f, ok := w.(float64); !ok || f != math.Trunc(f) || f < 1 {
+				add(field+".weight", fmt.Sprintf("weight %v must be a whole number ≥ 1", w))
+			}
+		}
+	}
 	return issues
 }
 
+// loadMonsterIDs returns the IDs of the monsters the migration loads (the top
+// level of game-data/monsters; wip/ and draft/ are skipped).
+func loadMonsterIDs() map[string]bool {
+	ids := make(map[string]bool)
+	entries, _ := os.ReadDir("game-data/monsters")
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".json") {
+			ids[strings.TrimSuffix(e.Name(), ".json")] = true
+		}
+	}
+	return ids
+}
+
+// validLightLevels are the ambient light levels a location may declare (see
+// the server's combat light levels).
+var validLightLevels = map[string]bool{"bright": true, "dim": true, "dark": true}
+
+// checkLocationEffects validates a location or building "effects" list.
+func checkLocationEffects(raw interface{}, field, filename string, validEffectIDs map[string]bool, issues *[]Issue) {
+	if raw == nil {
+		return
+	}
+	list, ok := raw.([]interface{})
+	if !ok {
+		*issues = append(*issues, Issue{
+			Type:     "error",
+			Category: "locations",
+			File:     filename,
+			Field:    field,
+			Message:  "effects must be an array of effect IDs",
+		})
+		return
+	}
+	for _, e := range list {
+		effectID, ok := e.(string)
+		if !ok || !validEffectIDs[effectID] {
+			*issues = append(*issues, Issue{
+				Type:     "error",
+				Category: "locations",
+				File:     filename,
+				Field:    field,
+				Message:  fmt.Sprintf("Effect '%v' not found in game-data/effects", e),
+			})
+		}
+	}
+}
+
 // ValidateNPCs validates all NPC files
-func ValidateNPCs() ([]Issue, error) {
+func ValidateNPCs() ([]Issue, int, error) {
 	issues := []Issue{}
+	files := 0
 	npcsPath := "game-data/npcs"
+	places := LoadSchedulePlaces("game-data/locations/cities")
 
 	err := filepath.WalkDir(npcsPath, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
@@ -1099,16 +2360,17 @@ func ValidateNPCs() ([]Issue, error) {
 		}
 
 		if !d.IsDir() && strings.HasSuffix(path, ".json") {
-			npcIssues := validateNPCFile(path)
+			npcIssues := validateNPCFile(path, places)
 			issues = append(issues, npcIssues...)
+			files++
 		}
 		return nil
 	})
 
-	return issues, err
+	return issues, files, err
 }
 
-func validateNPCFile(filePath string) []Issue {
+func validateNPCFile(filePath string, places map[string]map[string]bool) []Issue {
 	issues := []Issue{}
 	filename := filepath.Base(filePath)
 
@@ -1148,12 +2410,155 @@ func validateNPCFile(filePath string) []Issue {
 		}
 	}
 
-	return issues
+	if schedule, exists := npc["schedule"]; exists {
+		for _, issue := range CheckNPCSchedule(schedule, places) {
+			issue.File = filename
+			issues = append(issues, issue)
+		}
+	}
+
+	return issues
+}
+
+// LoadSchedulePlaces collects the places an NPC schedule slot can name from the
+// city files in dir: every district ID (mapped to nil) and every building ID
+// (mapped to its room IDs).
+func LoadSchedulePlaces(dir string) map[string]map[string]bool {
+	places := make(map[string]map[string]bool)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return places
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		var city struct {
+			Districts map[string]struct {
+				ID        string `json:"id"`
+				Buildings []struct {
+					ID    string `json:"id"`
+					Rooms []struct {
+						ID string `json:"id"`
+					} `json:"rooms"`
+				} `json:"buildings"`
+			} `json:"districts"`
+		}
+		if json.Unmarshal(data, &city) != nil {
+			continue
+		}
+		for _, district := range city.Districts {
+			if district.ID != "" {
+				places[district.ID] = nil
+			}
+			for _, b := range district.Buildings {
+				rooms := make(map[string]bool, len(b.Rooms))
+				for _, r := range b.Rooms {
+					rooms[r.ID] = true
+				}
+				places[b.ID] = rooms
+			}
+		}
+	}
+	return places
+}
+
+// CheckNPCSchedule checks an NPC's schedule: a list of slots, each a
+// [start, end) window in minutes from midnight (end < start wraps past
+// midnight) at a known building or district, and a room the building has.
+// Slots must not overlap — the server takes the first match, so the later slot
+// would never apply. Hours no slot covers are a warning: the server falls back
+// to the first slot there. Issues come back without File set.
+func CheckNPCSchedule(schedule interface{}, places map[string]map[string]bool) []Issue {
+	var issues []Issue
+	add := func(kind, field, msg string) {
+		issues = append(issues, Issue{Type: kind, Category: "npcs", Field: field, Message: msg})
+	}
+
+	slots, ok := schedule.([]interface{})
+	if !ok {
+		add("error", "schedule", "schedule must be a list of time slots")
+		return issues
+	}
+	if len(slots) == 0 {
+		return issues
+	}
+
+	owner := make([]int, 1440) // minute → slot index + 1
+	for i, raw := range slots {
+		field := fmt.Sprintf("schedule[%d]", i)
+		slot, ok := raw.(map[string]interface{})
+		if !ok {
+			add("error", field, "schedule slot must be an object")
+			continue
+		}
+
+		start, startOK := slot["start"].(float64)
+		end, endOK := slot["end"].(float64)
+		switch {
+		case !startOK || !endOK:
+			add("error", field, "schedule slot needs numeric start and end (minutes from midnight)")
+		case start != float64(int(start)) || end != float64(int(end)):
+			add("error", field, fmt.Sprintf("start and end must be whole minutes, got %v–%v", start, end))
+		case start < 0 || start > 1439 || end < 0 || end > 1440:
+			add("error", field, fmt.Sprintf("start must be 0–1439 and end 0–1440, got %v–%v", start, end))
+		case start == end:
+			add("error", field, fmt.Sprintf("Slot %s–%s is empty", clockTime(int(start)), clockTime(int(end))))
+		default:
+			length := int(end) - int(start)
+			if length < 0 {
+				length += 1440
+			}
+			for k := 0; k < length; k++ {
+				m := (int(start) + k) % 1440
+				if other := owner[m]; other != 0 {
+					add("error", field, fmt.Sprintf("Overlaps schedule[%d] at %s", other-1, clockTime(m)))
+					break
+				}
+				owner[m] = i + 1
+			}
+		}
+
+		location, _ := slot["location"].(string)
+		if location == "" {
+			add("error", field+".location", "schedule slot needs a location (building or district ID)")
+			continue
+		}
+		rooms, known := places[location]
+		if !known {
+			add("error", field+".location", fmt.Sprintf("Unknown location '%s': not a building or district in any city", location))
+			continue
+		}
+		if room, _ := slot["room"].(string); room != "" && !rooms[room] {
+			add("error", field+".room", fmt.Sprintf("Room '%s' not found in building '%s'", room, location))
+		}
+	}
+
+	for m := 0; m < 1440; m++ {
+		if owner[m] != 0 {
+			continue
+		}
+		gap := m
+		for m < 1440 && owner[m] == 0 {
+			m++
+		}
+		add("warning", "schedule", fmt.Sprintf("No slot covers %s–%s; the NPC falls back to its first slot", clockTime(gap), clockTime(m)))
+	}
+
+	return issues
+}
+
+// clockTime formats minutes from midnight as HH:MM.
+func clockTime(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60%24, minutes%60)
 }
 
 // ValidateStartingGear validates the starting gear configuration file
-// ValidateStartingGear validates the starting gear configuration file
-func ValidateStartingGear() ([]Issue, error) {
+func ValidateStartingGear() ([]Issue, int, error) {
 	issues := []Issue{}
 	filePath := "game-data/systems/new-character/starting-gear.json"
 
@@ -1193,7 +2598,7 @@ func ValidateStartingGear() ([]Issue, error) {
 			File:     "starting-gear.json",
 			Message:  fmt.Sprintf("Failed to read file: %v", err),
 		})
-		return issues, err
+		return issues, 0, err
 	}
 
 	var gearData []map[string]interface{}
@@ -1204,7 +2609,7 @@ func ValidateStartingGear() ([]Issue, error) {
 			File:     "starting-gear.json",
 			Message:  fmt.Sprintf("Invalid JSON: %v", err),
 		})
-		return issues, err
+		return issues, 1, err
 	}
 
 	// Validate each class entry
@@ -1384,7 +2789,7 @@ func ValidateStartingGear() ([]Issue, error) {
 		}
 	}
 
-	return issues, nil
+	return issues, 1, nil
 }
 // Helper function to validate an equipment choice
 func validateEquipmentChoice(choice map[string]interface{}, className string, choiceIdx int, validItemIDs map[string]bool, issues *[]Issue) {
@@ -1658,16 +3063,157 @@ func validateMultiSlotOption(opt map[string]interface{}, className string, choic
 	}
 }
 
+// ValidateCombatSystem validates game-data/systems/combat.json's tunable
+// blocks. The server falls back to defaults on a bad critical_hits block, so
+// errors here are the only place a typo surfaces.
+func ValidateCombatSystem() ([]Issue, int, error) {
+	data, err := os.ReadFile("game-data/systems/combat.json")
+	if err != nil {
+		return nil, 0, err
+	}
+	var config struct {
+		CombatSystem map[string]interface{} `json:"combat_system"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return []Issue{{Type: "error", Category: "systems", File: "combat.json", Message: fmt.Sprintf("Failed to parse JSON: %v", err)}}, 1, nil
+	}
+	var issues []Issue
+	// Both blocks are optional — the server falls back to its defaults.
+	if crit, ok := config.CombatSystem["critical_hits"].(map[string]interface{}); ok {
+		issues = append(issues, CheckCritRules(crit)...)
+	}
+	if raw, exists := config.CombatSystem["ammo_recovery"]; exists {
+		issues = append(issues, CheckAmmoRecovery(raw)...)
+	}
+	return issues, 1, nil
+}
+
+// CheckAmmoRecovery validates a combat_system.ammo_recovery block: victory_rate
+// and flee_rate are shares between 0 and 1, drop_unrecovered a boolean. Mirrors
+// the server's combat.ValidateAmmoRecovery.
+func CheckAmmoRecovery(raw interface{}) []Issue {
+	var issues []Issue
+	add := func(field, msg string) {
+		issues = append(issues, Issue{Type: "error", Category: "systems", File: "combat.json", Field: "ammo_recovery" + field, Message: msg})
+	}
+	block, ok := raw.(map[string]interface{})
+	if !ok {
+		add("", "ammo_recovery must be an object")
+		return issues
+	}
+	for _, key := range []string{"victory_rate", "flee_rate"} {
+		v, exists := block[key]
+		if !exists {
+			continue
+		}
+		if f, ok := v.(float64); !ok || f < 0 || f > 1 {
+			add("."+key, fmt.Sprintf("%s %v must be a number between 0 and 1", key, v))
+		}
+	}
+	if v, exists := block["drop_unrecovered"]; exists {
+		if _, ok := v.(bool); !ok {
+			add(".drop_unrecovered", fmt.Sprintf("drop_unrecovered %v must be true or false", v))
+		}
+	}
+	return issues
+}
+
+// CheckCritRules validates a combat_system.critical_hits block: damage_rule is
+// "double_dice" or "max_plus_roll", crit_range and min_crit_range sit on the
+// d20 (2–20, min ≤ crit), and each class_crit_ranges entry names a class, a
+// level ≥ 1 and a range between min_crit_range and 20. Mirrors the server's
+// combat.ValidateCritRules.
+func CheckCritRules(crit map[string]interface{}) []Issue {
+	var issues []Issue
+	add := func(field, msg string) {
+		issues = append(issues, Issue{Type: "error", Category: "systems", File: "combat.json", Field: "critical_hits." + field, Message: msg})
+	}
+	intField := func(m map[string]interface{}, key string, def int) (int, bool) {
+		v, exists := m[key]
+		if !exists {
+			return def, true
+		}
+		f, ok := v.(float64)
+		if !ok || f != math.Trunc(f) {
+			return 0, false
+		}
+		return int(f), true
+	}
+
+	if rule, _ := crit["damage_rule"].(string); rule != "double_dice" && rule != "max_plus_roll" {
+		add("damage_rule", fmt.Sprintf("damage_rule %v must be 'double_dice' or 'max_plus_roll'", crit["damage_rule"]))
+	}
+	critRange, ok := intField(crit, "crit_range", 20)
+	if !ok || critRange < 2 || critRange > 20 {
+		add("crit_range", fmt.Sprintf("crit_range %v must be a whole number between 2 and 20", crit["crit_range"]))
+		critRange = 20
+	}
+	minRange, ok := intField(crit, "min_crit_range", 18)
+	if !ok || minRange < 2 || minRange > critRange {
+		add("min_crit_range", fmt.Sprintf("min_crit_range %v must be a whole number between 2 and crit_range (%d)", crit["min_crit_range"], critRange))
+		minRange = 2
+	}
+
+	if raw, exists := crit["class_crit_ranges"]; exists {
+		list, ok := raw.([]interface{})
+		if !ok {
+			add("class_crit_ranges", "class_crit_ranges must be an array")
+			return issues
+		}
+		for i, entry := range list {
+			field := fmt.Sprintf("class_crit_ranges[%d]", i)
+			m, ok := entry.(map[string]interface{})
+			if !ok {
+				add(field, "Entry must be an object")
+				continue
+			}
+			if class, _ := m["class"].(string); class == "" {
+				add(field+".class", "class is required")
+			}
+			if level, ok := intField(m, "min_level", 0); !ok || level < 1 {
+				add(field+".min_level", fmt.Sprintf("min_level %v must be a whole number ≥ 1", m["min_level"]))
+			}
+			if r, ok := intField(m, "crit_range", 0); !ok || r < minRange || r > 20 {
+				add(field+".crit_range", fmt.Sprintf("crit_range %v must be a whole number between min_crit_range (%d) and 20", m["crit_range"], minRange))
+			}
+		}
+	}
+	return issues
+}
+
 // ValidateEffects validates all effect files against the new schema
-func ValidateEffects() ([]Issue, error) {
+func ValidateEffects() ([]Issue, int, error) {
+	// Load effect types for validation
+	data, err := os.ReadFile("game-data/systems/effects.json")
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to load effect types: %w", err)
+	}
+	issues, files, err := validateEffectsForRegistry(data)
+	if err != nil {
+		return nil, 0, err
+	}
+	return issues, files + 1, nil // plus the registry itself
+}
+
+// ValidateEffectsForRegistry validates all effect files against the given
+// effect-type registry (effects.json content) instead of the one on disk, so
+// the systems editor can check a registry edit before it is written or while
+// it only exists as a staged change.
+func ValidateEffectsForRegistry(registryJSON []byte) ([]Issue, error) {
+	issues, _, err := validateEffectsForRegistry(registryJSON)
+	return issues, err
+}
+
+func validateEffectsForRegistry(registryJSON []byte) ([]Issue, int, error) {
 	issues := []Issue{}
+	files := 0
 	effectsPath := "game-data/effects"
 
-	// Load effect types for validation
-	effectTypes, err := loadEffectTypes("game-data/systems/effects.json")
+	effectTypes, err := parseEffectTypes(registryJSON)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load effect types: %w", err)
+		return nil, 0, fmt.Errorf("failed to load effect types: %w", err)
 	}
+	conflicts := map[string][]string{}
 
 	// Find all effect files
 	err = filepath.WalkDir(effectsPath, func(path string, d fs.DirEntry, err error) error {
@@ -1678,23 +3224,106 @@ func ValidateEffects() ([]Issue, error) {
 		if !d.IsDir() && strings.HasSuffix(path, ".json") {
 			effectIssues := validateEffectFile(path, effectTypes)
 			issues = append(issues, effectIssues...)
+			files++
+
+			if data, err := os.ReadFile(path); err == nil {
+				var effect types.EffectData
+				if json.Unmarshal(data, &effect) == nil && effect.ID != "" {
+					conflicts[effect.ID] = effect.Conflicts
+				}
+			}
 		}
 		return nil
 	})
 
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return issues, nil
+	issues = append(issues, CheckEffectConflicts(conflicts)...)
+
+	return issues, files, nil
 }
 
-func loadEffectTypes(path string) (map[string]effectTypeInfo, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
+// CheckEffectConflicts validates the conflict table: every effect an effect
+// lists under "conflicts" must exist, can't be itself, and has to list it back
+// — applying either one removes the other, so a one-sided entry would make the
+// outcome depend on which was applied first. conflicts maps effect ID to its
+// declared conflicts.
+func CheckEffectConflicts(conflicts map[string][]string) []Issue {
+	var issues []Issue
+	ids := make([]string, 0, len(conflicts))
+	for id := range conflicts {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		for i, other := range conflicts[id] {
+			field := fmt.Sprintf("conflicts[%d]", i)
+			switch {
+			case other == id:
+				issues = append(issues, Issue{
+					Type:     "error",
+					Category: "effects",
+					File:     id + ".json",
+					Field:    field,
+					Message:  "Effect can't conflict with itself",
+				})
+			case !hasEffect(conflicts, other):
+				issues = append(issues, Issue{
+					Type:     "error",
+					Category: "effects",
+					File:     id + ".json",
+					Field:    field,
+					Message:  fmt.Sprintf("Conflicting effect '%s' does not exist", other),
+				})
+			case !containsID(conflicts[other], id):
+				issues = append(issues, Issue{
+					Type:     "error",
+					Category: "effects",
+					File:     id + ".json",
+					Field:    field,
+					Message:  fmt.Sprintf("Conflict is one-sided: '%s' doesn't list '%s' in its conflicts", other, id),
+				})
+			}
+		}
+	}
+	return issues
+}
+
+func hasEffect(conflicts map[string][]string, id string) bool {
+	_, ok := conflicts[id]
+	return ok
+}
+
+func containsID(ids []string, id string) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+// UnknownStatIssues filters issues down to effect modifiers that reference a
+// stat missing from the effect-type registry — the effects a registry edit
+// orphaned.
+func UnknownStatIssues(issues []Issue) []Issue {
+	var out []Issue
+	for _, issue := range issues {
+		if issue.Category == "effects" && strings.HasPrefix(issue.Message, unknownStatMessage) {
+			out = append(out, issue)
+		}
 	}
+	return out
+}
+
+// unknownStatMessage prefixes the issue raised for a modifier whose stat isn't
+// in the effect-type registry (see UnknownStatIssues).
+const unknownStatMessage = "Unknown stat type"
 
+func parseEffectTypes(data []byte) (map[string]effectTypeInfo, error) {
 	var wrapper struct {
 		EffectTypes map[string]effectTypeInfo `json:"effect_types"`
 	}
@@ -1713,6 +3342,18 @@ type effectTypeInfo struct {
 	AllowsPeriodic bool   `json:"allows_periodic"`
 }
 
+// Effect rules shared by validateEffectFile, CheckEffectSourceRules and
+// EffectJSONSchema. category, removal, visible and source_type are required
+// too, but each gets its own message.
+var (
+	effectRequiredFields = []string{"id", "name", "description"}
+	effectCategories     = []string{"buff", "debuff", "status"}
+	effectSourceTypes    = []string{"system_ticker", "system_status", "applied"}
+	effectModifierTypes  = []string{"instant", "constant", "periodic"}
+	systemCheckStats     = []string{"hunger", "fatigue", "weight_percent", "hp_percent", "mana_percent"}
+	systemCheckOperators = []string{"==", "!=", "<", "<=", ">", ">="}
+)
+
 func validateEffectFile(filePath string, effectTypes map[string]effectTypeInfo) []Issue {
 	issues := []Issue{}
 	filename := filepath.Base(filePath)
@@ -1731,251 +3372,88 @@ func validateEffectFile(filePath string, effectTypes map[string]effectTypeInfo)
 	var effect map[string]interface{}
 	if err := json.Unmarshal(data, &effect); err != nil {
 		issues = append(issues, Issue{
-			Type:     "error",
-			Category: "effects",
-			File:     filename,
-			Message:  fmt.Sprintf("Failed to parse JSON: %v", err),
-		})
-		return issues
-	}
-
-	// Rule 1: Required fields
-	requiredFields := []string{"id", "name", "description"}
-	for _, field := range requiredFields {
-		if _, exists := effect[field]; !exists {
-			issues = append(issues, Issue{
-				Type:     "error",
-				Category: "effects",
-				File:     filename,
-				Field:    field,
-				Message:  fmt.Sprintf("Required field '%s' is missing", field),
-			})
-		}
-	}
-
-	// Check for new structure vs old structure
-	hasModifiers := false
-	if modifiers, ok := effect["modifiers"].([]interface{}); ok && len(modifiers) > 0 {
-		hasModifiers = true
-	}
-
-	hasOldStructure := false
-	if effects, ok := effect["effects"].([]interface{}); ok && len(effects) > 0 {
-		hasOldStructure = true
-	}
-
-	// Check for new required fields
-	if !hasModifiers {
-		issues = append(issues, Issue{
-			Type:     "error",
-			Category: "effects",
-			File:     filename,
-			Field:    "modifiers",
-			Message:  "Required field 'modifiers' is missing or empty (use 'modifiers' instead of 'effects')",
-		})
-	}
-
-	if _, exists := effect["source_type"]; !exists {
-		issues = append(issues, Issue{
-			Type:     "error",
-			Category: "effects",
-			File:     filename,
-			Field:    "source_type",
-			Message:  "Required field 'source_type' is missing (must be 'system_ticker', 'system_status', or 'applied')",
-		})
-	}
-
-	if _, exists := effect["category"]; !exists {
-		issues = append(issues, Issue{
-			Type:     "error",
-			Category: "effects",
-			File:     filename,
-			Field:    "category",
-			Message:  "Required field 'category' is missing (must be 'buff', 'debuff', or 'status')",
-		})
-	}
-
-	if _, exists := effect["removal"]; !exists {
-		issues = append(issues, Issue{
-			Type:     "error",
-			Category: "effects",
-			File:     filename,
-			Field:    "removal",
-			Message:  "Required field 'removal' is missing",
-		})
-	}
-
-	if _, exists := effect["visible"]; !exists {
-		issues = append(issues, Issue{
-			Type:     "error",
-			Category: "effects",
-			File:     filename,
-			Field:    "visible",
-			Message:  "Required field 'visible' is missing",
-		})
-	}
-
-	// Rule 2: source_type validation
-	if sourceType, ok := effect["source_type"].(string); ok {
-		validSourceTypes := map[string]bool{
-			"system_ticker": true,
-			"system_status": true,
-			"applied":       true,
-		}
-		if !validSourceTypes[sourceType] {
-			issues = append(issues, Issue{
-				Type:     "error",
-				Category: "effects",
-				File:     filename,
-				Field:    "source_type",
-				Message:  fmt.Sprintf("Invalid source_type '%s' (must be 'system_ticker', 'system_status', or 'applied')", sourceType),
-			})
-		}
-
-		// NEW RULE: system_status effects must have system_check
-		if sourceType == "system_status" {
-			if systemCheck, ok := effect["system_check"].(map[string]interface{}); !ok {
-				issues = append(issues, Issue{
-					Type:     "error",
-					Category: "effects",
-					File:     filename,
-					Field:    "system_check",
-					Message:  "system_status effects must have 'system_check' defined",
-				})
-			} else {
-				// Validate system_check fields
-				validStats := map[string]bool{"hunger": true, "fatigue": true, "weight_percent": true, "hp_percent": true, "mana_percent": true}
-				validOperators := map[string]bool{"==": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true}
-
-				if stat, ok := systemCheck["stat"].(string); !ok || stat == "" {
-					issues = append(issues, Issue{
-						Type:     "error",
-						Category: "effects",
-						File:     filename,
-						Field:    "system_check.stat",
-						Message:  "system_check must have 'stat' field",
-					})
-				} else if !validStats[stat] {
-					issues = append(issues, Issue{
-						Type:     "error",
-						Category: "effects",
-						File:     filename,
-						Field:    "system_check.stat",
-						Message:  fmt.Sprintf("Invalid stat '%s' (must be: hunger, fatigue, weight_percent, hp_percent, mana_percent)", stat),
-					})
-				} else {
-					// Validate value range based on stat
-					if value, ok := systemCheck["value"].(float64); ok {
-						intValue := int(value)
-						switch stat {
-						case "hunger":
-							if intValue < 0 || intValue > 3 {
-								issues = append(issues, Issue{
-									Type:     "error",
-									Category: "effects",
-									File:     filename,
-									Field:    "system_check.value",
-									Message:  "hunger value must be 0-3",
-								})
-							}
-						case "fatigue":
-							if intValue < 0 || intValue > 10 {
-								issues = append(issues, Issue{
-									Type:     "error",
-									Category: "effects",
-									File:     filename,
-									Field:    "system_check.value",
-									Message:  "fatigue value must be 0-10",
-								})
-							}
-						case "weight_percent":
-							if intValue < 0 || intValue > 300 {
-								issues = append(issues, Issue{
-									Type:     "error",
-									Category: "effects",
-									File:     filename,
-									Field:    "system_check.value",
-									Message:  "weight_percent value must be 0-300",
-								})
-							}
-						case "hp_percent", "mana_percent":
-							if intValue < 0 || intValue > 100 {
-								issues = append(issues, Issue{
-									Type:     "error",
-									Category: "effects",
-									File:     filename,
-									Field:    "system_check.value",
-									Message:  fmt.Sprintf("%s value must be 0-100", stat),
-								})
-							}
-						}
-					}
-				}
-
-				if operator, ok := systemCheck["operator"].(string); !ok || operator == "" {
-					issues = append(issues, Issue{
-						Type:     "error",
-						Category: "effects",
-						File:     filename,
-						Field:    "system_check.operator",
-						Message:  "system_check must have 'operator' field",
-					})
-				} else if !validOperators[operator] {
-					issues = append(issues, Issue{
-						Type:     "error",
-						Category: "effects",
-						File:     filename,
-						Field:    "system_check.operator",
-						Message:  fmt.Sprintf("Invalid operator '%s' (must be: ==, !=, <, <=, >, >=)", operator),
-					})
-				}
-
-				if _, ok := systemCheck["value"]; !ok {
-					issues = append(issues, Issue{
-						Type:     "error",
-						Category: "effects",
-						File:     filename,
-						Field:    "system_check.value",
-						Message:  "system_check must have 'value' field",
-					})
-				}
-			}
-		}
-
-		// Rule 4 & 5: visible field validation
-		if visible, ok := effect["visible"].(bool); ok {
-			// system_ticker must always be hidden (they fire silently in the background)
-			if sourceType == "system_ticker" && visible {
-				issues = append(issues, Issue{
-					Type:     "error",
-					Category: "effects",
-					File:     filename,
-					Field:    "visible",
-					Message:  "system_ticker effects must have visible=false",
-				})
-			}
-			// system_status must always be visible (players need to see encumbrance/hunger state)
-			if sourceType == "system_status" && !visible {
-				issues = append(issues, Issue{
-					Type:     "error",
-					Category: "effects",
-					File:     filename,
-					Field:    "visible",
-					Message:  "system_status effects must have visible=true",
-				})
-			}
-			// applied effects: value can be true or false, no constraint
-		} else if _, exists := effect["visible"]; !exists {
-			// visible field is missing entirely — require it to be explicitly set
+			Type:     "error",
+			Category: "effects",
+			File:     filename,
+			Message:  fmt.Sprintf("Failed to parse JSON: %v", err),
+		})
+		return issues
+	}
+
+	// Rule 1: Required fields
+	for _, field := range effectRequiredFields {
+		if _, exists := effect[field]; !exists {
 			issues = append(issues, Issue{
 				Type:     "error",
 				Category: "effects",
 				File:     filename,
-				Field:    "visible",
-				Message:  "Required field 'visible' is missing (must be true or false)",
+				Field:    field,
+				Message:  fmt.Sprintf("Required field '%s' is missing", field),
 			})
 		}
+	}
+
+	// Check for new structure vs old structure
+	hasModifiers := false
+	if modifiers, ok := effect["modifiers"].([]interface{}); ok && len(modifiers) > 0 {
+		hasModifiers = true
+	}
+
+	hasOldStructure := false
+	if effects, ok := effect["effects"].([]interface{}); ok && len(effects) > 0 {
+		hasOldStructure = true
+	}
+
+	// Check for new required fields (an effect that only imposes a condition
+	// needs no modifiers)
+	if condition, _ := effect["condition"].(string); !hasModifiers && condition == "" {
+		issues = append(issues, Issue{
+			Type:     "error",
+			Category: "effects",
+			File:     filename,
+			Field:    "modifiers",
+			Message:  "Required field 'modifiers' is missing or empty (use 'modifiers' instead of 'effects')",
+		})
+	}
+
+	if _, exists := effect["category"]; !exists {
+		issues = append(issues, Issue{
+			Type:     "error",
+			Category: "effects",
+			File:     filename,
+			Field:    "category",
+			Message:  "Required field 'category' is missing (must be 'buff', 'debuff', or 'status')",
+		})
+	}
+
+	if _, exists := effect["removal"]; !exists {
+		issues = append(issues, Issue{
+			Type:     "error",
+			Category: "effects",
+			File:     filename,
+			Field:    "removal",
+			Message:  "Required field 'removal' is missing",
+		})
+	}
+
+	// Rules 2-5: source_type, system_check, and visible consistency — shared
+	// with the systems editor so a save is rejected for the same reasons.
+	for _, issue := range CheckEffectSourceRules(effect) {
+		issue.File = filename
+		issues = append(issues, issue)
+	}
 
+	for _, issue := range CheckEffectCondition(effect) {
+		issue.File = filename
+		issues = append(issues, issue)
+	}
+
+	for _, issue := range CheckEffectConcentration(effect) {
+		issue.File = filename
+		issues = append(issues, issue)
+	}
+
+	if sourceType, ok := effect["source_type"].(string); ok {
 		// Rule 10: Applied effects should have message
 		if sourceType == "applied" {
 			if message, ok := effect["message"].(string); !ok || strings.TrimSpace(message) == "" {
@@ -1992,12 +3470,7 @@ func validateEffectFile(filePath string, effectTypes map[string]effectTypeInfo)
 
 	// Rule 3: category validation
 	if category, ok := effect["category"].(string); ok {
-		validCategories := map[string]bool{
-			"buff":   true,
-			"debuff": true,
-			"status": true,
-		}
-		if !validCategories[category] {
+		if !containsID(effectCategories, category) {
 			issues = append(issues, Issue{
 				Type:     "error",
 				Category: "effects",
@@ -2045,7 +3518,7 @@ func validateEffectFile(filePath string, effectTypes map[string]effectTypeInfo)
 					Category: "effects",
 					File:     filename,
 					Field:    fmt.Sprintf("modifiers[%d].stat", i),
-					Message:  fmt.Sprintf("Unknown stat type '%s'", stat),
+					Message:  fmt.Sprintf("%s '%s'", unknownStatMessage, stat),
 				})
 				continue
 			}
@@ -2063,12 +3536,7 @@ func validateEffectFile(filePath string, effectTypes map[string]effectTypeInfo)
 				continue
 			}
 
-			validModTypes := map[string]bool{
-				"instant":  true,
-				"constant": true,
-				"periodic": true,
-			}
-			if !validModTypes[modType] {
+			if !containsID(effectModifierTypes, modType) {
 				issues = append(issues, Issue{
 					Type:     "error",
 					Category: "effects",
@@ -2080,8 +3548,8 @@ func validateEffectFile(filePath string, effectTypes map[string]effectTypeInfo)
 
 			// Rule 7: Validate modifier type based on category
 			switch effectType.Category {
-			case "stat", "capacity":
-				// Stats and capacities should ONLY use constant type
+			case "stat", "capacity", "combat":
+				// Stats, capacities and combat modifiers should ONLY use constant type
 				if modType != "constant" {
 					issues = append(issues, Issue{
 						Type:     "error",
@@ -2139,6 +3607,19 @@ func validateEffectFile(filePath string, effectTypes map[string]effectTypeInfo)
 				periodicCount[stat]++
 			}
 
+			// Resistance/immunity modifiers defend against one damage type
+			if stat == "damage_resistance" || stat == "damage_immunity" {
+				if damageType, _ := modMap["damage_type"].(string); !types.IsDamageType(damageType) {
+					issues = append(issues, Issue{
+						Type:     "error",
+						Category: "effects",
+						File:     filename,
+						Field:    fmt.Sprintf("modifiers[%d].damage_type", i),
+						Message:  fmt.Sprintf("'%s' modifier needs a valid damage_type (got '%s'): %s", stat, damageType, damageTypeList()),
+					})
+				}
+			}
+
 			// Rule 13: Delay only makes sense for applied effects
 			if delay, ok := modMap["delay"].(float64); ok && delay > 0 {
 				if sourceType, ok := effect["source_type"].(string); ok && sourceType != "applied" {
@@ -2211,29 +3692,274 @@ func validateEffectFile(filePath string, effectTypes map[string]effectTypeInfo)
 	return issues
 }
 
+// effectConditions is the set of conditions an effect may impose — the
+// conditions combat knows how to resolve (combat/conditions.go).
+var effectConditions = []string{
+	"blinded", "burning", "charmed", "frightened", "grappled", "outlined", "paralyzed",
+	"poisoned", "prone", "restrained", "stunned", "unconscious",
+}
+
+// CheckEffectCondition checks a raw effect's optional condition and
+// saving_throw: the condition must be one combat knows, and a saving throw
+// needs a condition to end, an ability to roll, and a positive DC. Issues come
+// back without File set. Used by both the full validation pass and the systems
+// editor's save path.
+func CheckEffectCondition(effect map[string]interface{}) []Issue {
+	var issues []Issue
+	add := func(field, msg string) {
+		issues = append(issues, Issue{Type: "error", Category: "effects", Field: field, Message: msg})
+	}
+
+	raw, hasCondition := effect["condition"]
+	condition, _ := raw.(string)
+	if hasCondition && !containsID(effectConditions, condition) {
+		add("condition", fmt.Sprintf("Unknown condition '%v' (must be one of: %s)", raw, strings.Join(effectConditions, ", ")))
+	}
+
+	rawSave, hasSave := effect["saving_throw"]
+	if !hasSave || rawSave == nil {
+		return issues
+	}
+	save, ok := rawSave.(map[string]interface{})
+	if !ok {
+		add("saving_throw", "saving_throw must be an object with 'stat' and 'dc'")
+		return issues
+	}
+	if condition == "" {
+		add("saving_throw", "saving_throw needs a 'condition' to end")
+	}
+	validStats := map[string]bool{"strength": true, "dexterity": true, "constitution": true, "intelligence": true, "wisdom": true, "charisma": true}
+	if stat, _ := save["stat"].(string); !validStats[stat] {
+		add("saving_throw.stat", fmt.Sprintf("Invalid saving throw stat '%v' (must be an ability score)", save["stat"]))
+	}
+	if dc, ok := save["dc"].(float64); !ok || dc != math.Trunc(dc) || dc < 1 {
+		add("saving_throw.dc", fmt.Sprintf("saving_throw.dc %v must be a whole number ≥ 1", save["dc"]))
+	}
+	return issues
+}
+
+// CheckEffectConcentration checks a raw effect's optional concentration flag:
+// it must be a boolean, and only an applied effect can be concentrated on (the
+// system tickers and statuses aren't something the player holds up). Issues
+// come back without File set. Used by both the full validation pass and the
+// systems editor's save path.
+func CheckEffectConcentration(effect map[string]interface{}) []Issue {
+	raw, exists := effect["concentration"]
+	if !exists {
+		return nil
+	}
+	concentration, ok := raw.(bool)
+	if !ok {
+		return []Issue{{Type: "error", Category: "effects", Field: "concentration",
+			Message: fmt.Sprintf("concentration must be true or false, got %v", raw)}}
+	}
+	if sourceType, _ := effect["source_type"].(string); concentration && sourceType != "applied" {
+		return []Issue{{Type: "error", Category: "effects", Field: "concentration",
+			Message: fmt.Sprintf("Only applied effects can require concentration (source_type is '%s')", sourceType)}}
+	}
+	return nil
+}
+
+// CheckEffectSourceRules checks the source_type-dependent rules for a raw effect
+// (as decoded from JSON): source_type is valid, system_status effects carry a
+// well-formed system_check, and visible is present and matches the source_type.
+// Issues come back without File set. Used by both the full validation pass and
+// the systems editor's save path.
+func CheckEffectSourceRules(effect map[string]interface{}) []Issue {
+	issues := []Issue{}
+
+	if _, exists := effect["visible"]; !exists {
+		issues = append(issues, Issue{
+			Type:     "error",
+			Category: "effects",
+			Field:    "visible",
+			Message:  "Required field 'visible' is missing",
+		})
+	}
+
+	sourceType, ok := effect["source_type"].(string)
+	if !ok {
+		issues = append(issues, Issue{
+			Type:     "error",
+			Category: "effects",
+			Field:    "source_type",
+			Message:  "Required field 'source_type' is missing (must be 'system_ticker', 'system_status', or 'applied')",
+		})
+		return issues
+	}
+
+	if !containsID(effectSourceTypes, sourceType) {
+		issues = append(issues, Issue{
+			Type:     "error",
+			Category: "effects",
+			Field:    "source_type",
+			Message:  fmt.Sprintf("Invalid source_type '%s' (must be 'system_ticker', 'system_status', or 'applied')", sourceType),
+		})
+	}
+
+	// NEW RULE: system_status effects must have system_check
+	if sourceType == "system_status" {
+		if systemCheck, ok := effect["system_check"].(map[string]interface{}); !ok {
+			issues = append(issues, Issue{
+				Type:     "error",
+				Category: "effects",
+				Field:    "system_check",
+				Message:  "system_status effects must have 'system_check' defined",
+			})
+		} else {
+			// Validate system_check fields
+			if stat, ok := systemCheck["stat"].(string); !ok || stat == "" {
+				issues = append(issues, Issue{
+					Type:     "error",
+					Category: "effects",
+					Field:    "system_check.stat",
+					Message:  "system_check must have 'stat' field",
+				})
+			} else if !containsID(systemCheckStats, stat) {
+				issues = append(issues, Issue{
+					Type:     "error",
+					Category: "effects",
+					Field:    "system_check.stat",
+					Message:  fmt.Sprintf("Invalid stat '%s' (must be: hunger, fatigue, weight_percent, hp_percent, mana_percent)", stat),
+				})
+			} else {
+				// Validate value range based on stat
+				if value, ok := systemCheck["value"].(float64); ok {
+					intValue := int(value)
+					switch stat {
+					case "hunger":
+						if intValue < 0 || intValue > 3 {
+							issues = append(issues, Issue{
+								Type:     "error",
+								Category: "effects",
+								Field:    "system_check.value",
+								Message:  "hunger value must be 0-3",
+							})
+						}
+					case "fatigue":
+						if intValue < 0 || intValue > 10 {
+							issues = append(issues, Issue{
+								Type:     "error",
+								Category: "effects",
+								Field:    "system_check.value",
+								Message:  "fatigue value must be 0-10",
+							})
+						}
+					case "weight_percent":
+						if intValue < 0 || intValue > 300 {
+							issues = append(issues, Issue{
+								Type:     "error",
+								Category: "effects",
+								Field:    "system_check.value",
+								Message:  "weight_percent value must be 0-300",
+							})
+						}
+					case "hp_percent", "mana_percent":
+						if intValue < 0 || intValue > 100 {
+							issues = append(issues, Issue{
+								Type:     "error",
+								Category: "effects",
+								Field:    "system_check.value",
+								Message:  fmt.Sprintf("%s value must be 0-100", stat),
+							})
+						}
+					}
+				}
+			}
+
+			if operator, ok := systemCheck["operator"].(string); !ok || operator == "" {
+				issues = append(issues, Issue{
+					Type:     "error",
+					Category: "effects",
+					Field:    "system_check.operator",
+					Message:  "system_check must have 'operator' field",
+				})
+			} else if !containsID(systemCheckOperators, operator) {
+				issues = append(issues, Issue{
+					Type:     "error",
+					Category: "effects",
+					Field:    "system_check.operator",
+					Message:  fmt.Sprintf("Invalid operator '%s' (must be: ==, !=, <, <=, >, >=)", operator),
+				})
+			}
+
+			if _, ok := systemCheck["value"]; !ok {
+				issues = append(issues, Issue{
+					Type:     "error",
+					Category: "effects",
+					Field:    "system_check.value",
+					Message:  "system_check must have 'value' field",
+				})
+			}
+		}
+	}
+
+	// Rule 4 & 5: visible field validation
+	if visible, ok := effect["visible"].(bool); ok {
+		// system_ticker must always be hidden (they fire silently in the background)
+		if sourceType == "system_ticker" && visible {
+			issues = append(issues, Issue{
+				Type:     "error",
+				Category: "effects",
+				Field:    "visible",
+				Message:  "system_ticker effects must have visible=false",
+			})
+		}
+		// system_status must always be visible (players need to see encumbrance/hunger state)
+		if sourceType == "system_status" && !visible {
+			issues = append(issues, Issue{
+				Type:     "error",
+				Category: "effects",
+				Field:    "visible",
+				Message:  "system_status effects must have visible=true",
+			})
+		}
+		// applied effects must be visible (the player would never see an invisible one)
+		if sourceType == "applied" && !visible {
+			issues = append(issues, Issue{
+				Type:     "error",
+				Category: "effects",
+				Field:    "visible",
+				Message:  "applied effects must have visible=true",
+			})
+		}
+	}
+
+	return issues
+}
+
 // ============================================================================
 // Spell Validation
 // ============================================================================
 
 // ValidateSpells validates all spell JSON files
-func ValidateSpells() ([]Issue, error) {
+func ValidateSpells() ([]Issue, int, error) {
 	issues := []Issue{}
+	files := 0
 	spellsPath := "game-data/magic/spells"
 
+	// Spell class lists are checked against the playable classes; without the
+	// registry (reported by ValidatePlayable) the names go unchecked.
+	var classes []types.PlayableOption
+	if registry, err := LoadPlayableRegistry(PlayablePath); err == nil {
+		classes = registry.Classes
+	}
+
 	err := filepath.WalkDir(spellsPath, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
 		if !d.IsDir() && strings.HasSuffix(path, ".json") {
-			issues = append(issues, validateSpellFile(path)...)
+			issues = append(issues, validateSpellFile(path, classes)...)
+			files++
 		}
 		return nil
 	})
 
-	return issues, err
+	return issues, files, err
 }
 
-func validateSpellFile(filePath string) []Issue {
+func validateSpellFile(filePath string, classes []types.PlayableOption) []Issue {
 	issues := []Issue{}
 	filename := filepath.Base(filePath)
 	idFromFilename := strings.TrimSuffix(filename, ".json")
@@ -2338,6 +4064,16 @@ func validateSpellFile(filePath string) []Issue {
 			Message: "Field 'classes' must not be empty",
 		})
 	}
+	for _, issue := range CheckSpellClasses(spell, classes) {
+		issue.File = filename
+		issues = append(issues, issue)
+	}
+
+	// --- damage / heal: optional dice rolls ---
+	for _, issue := range CheckSpellRolls(spell) {
+		issue.File = filename
+		issues = append(issues, issue)
+	}
 
 	// --- tags: required non-empty array ---
 	var tags []string
@@ -2373,8 +4109,8 @@ func validateSpellFile(filePath string) []Issue {
 	if school, ok := spell["school"].(string); ok && school != "" {
 		if !validSchools[strings.ToLower(school)] {
 			issues = append(issues, Issue{
-				Type: "warning", Category: "spells", File: filename, Field: "school",
-				Message: fmt.Sprintf("Non-standard school '%s' (expected one of: abjuration, conjuration, divination, enchantment, evocation, illusion, necromancy, transmutation)", school),
+				Type: "error", Category: "spells", File: filename, Field: "school",
+				Message: fmt.Sprintf("Unknown school '%s' (expected one of: abjuration, conjuration, divination, enchantment, evocation, illusion, necromancy, transmutation)", school),
 			})
 		}
 	}
@@ -2506,3 +4242,115 @@ func validateSpellFile(filePath string) []Issue {
 
 	return issues
 }
+
+// ============================================================================
+// Ability Validation
+// ============================================================================
+
+// ValidateAbilities validates the martial class abilities, one directory per
+// class under game-data/systems/abilities.
+func ValidateAbilities() ([]Issue, int, error) {
+	issues := []Issue{}
+	files := 0
+	abilitiesPath := "game-data/systems/abilities"
+
+	// Build valid effect IDs for the tiers' effects_applied references
+	validEffectIDs := loadEffectIDs()
+
+	err := filepath.WalkDir(abilitiesPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(path, ".json") {
+			issues = append(issues, validateAbilityFile(path, validEffectIDs)...)
+			files++
+		}
+		return nil
+	})
+
+	return issues, files, err
+}
+
+func validateAbilityFile(filePath string, validEffectIDs map[string]bool) []Issue {
+	filename := filepath.Base(filePath)
+	folder := filepath.Base(filepath.Dir(filePath))
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return []Issue{{Type: "error", Category: "abilities", File: filename, Message: fmt.Sprintf("Failed to read file: %v", err)}}
+	}
+	var ability map[string]interface{}
+	if err := json.Unmarshal(data, &ability); err != nil {
+		return []Issue{{Type: "error", Category: "abilities", File: filename, Message: fmt.Sprintf("Invalid JSON: %v", err)}}
+	}
+
+	var issues []Issue
+	if id, ok := ability["id"].(string); ok && id != strings.TrimSuffix(filename, ".json") {
+		issues = append(issues, Issue{
+			Type: "error", Category: "abilities", File: filename, Field: "id",
+			Message: fmt.Sprintf("Ability 'id' field '%s' doesn't match filename '%s'", id, filename),
+		})
+	}
+	for _, issue := range CheckAbility(ability, folder, validEffectIDs) {
+		issue.File = filename
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// CheckAbility validates one ability definition: required fields, the class
+// matching the folder it lives in (the migration keys abilities by folder), a
+// 1–20 unlock level, non-negative costs and cooldown rounds, and the effect IDs
+// its scaling tiers apply. Combat resolves abilities through Go handlers, so a
+// tier effect that doesn't exist yet is a warning, listed once per ability.
+func CheckAbility(ability map[string]interface{}, folder string, validEffectIDs map[string]bool) []Issue {
+	var issues []Issue
+	add := func(severity, field, message string) {
+		issues = append(issues, Issue{Type: severity, Category: "abilities", Field: field, Message: message})
+	}
+
+	for _, field := range []string{"id", "name", "class"} {
+		if s, ok := ability[field].(string); !ok || s == "" {
+			add("error", field, fmt.Sprintf("Missing required field: %s", field))
+		}
+	}
+	if class, ok := ability["class"].(string); ok && class != "" && !strings.EqualFold(class, folder) {
+		add("error", "class", fmt.Sprintf("Class '%s' doesn't match the '%s' folder the ability lives in", class, folder))
+	}
+
+	if raw, exists := ability["unlock_level"]; !exists {
+		add("error", "unlock_level", "Missing required field: unlock_level")
+	} else if lvl, ok := raw.(float64); !ok || lvl != float64(int(lvl)) || lvl < 1 || lvl > 20 {
+		add("error", "unlock_level", fmt.Sprintf("'unlock_level' must be an integer 1–20 (got %v)", raw))
+	}
+
+	for _, field := range []string{"resource_cost", "cooldown_rounds"} {
+		if raw, exists := ability[field]; exists {
+			if n, ok := raw.(float64); !ok || n != float64(int(n)) || n < 0 {
+				add("error", field, fmt.Sprintf("'%s' must be a non-negative integer (got %v)", field, raw))
+			}
+		}
+	}
+
+	var missing []string
+	tiers, _ := ability["scaling_tiers"].([]interface{})
+	for i, t := range tiers {
+		tier, _ := t.(map[string]interface{})
+		if cost, exists := tier["override_cost"]; exists {
+			if n, ok := cost.(float64); !ok || n < 0 {
+				add("error", fmt.Sprintf("scaling_tiers[%d].override_cost", i), fmt.Sprintf("'override_cost' must be non-negative (got %v)", cost))
+			}
+		}
+		effects, _ := tier["effects_applied"].([]interface{})
+		for _, e := range effects {
+			if id, ok := e.(string); !ok || !validEffectIDs[id] {
+				missing = append(missing, fmt.Sprintf("%v", e))
+			}
+		}
+	}
+	if len(missing) > 0 {
+		add("warning", "scaling_tiers.effects_applied", fmt.Sprintf("Effect(s) not found in game-data/effects: %s", strings.Join(missing, ", ")))
+	}
+
+	return issues
+}
@@ -1,6 +1,8 @@
 package character
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -43,28 +45,14 @@ func CharacterHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Load weight data from DuckDB (same as weights API endpoint)
-	weightDataMap, err := GetWeightsFromDB()
+	weightData, err := loadWeightData()
 	if err != nil {
 		http.Error(w, "Error loading weight data: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	// Convert map to WeightData struct
-	weightDataJSON, err := json.Marshal(weightDataMap)
-	if err != nil {
-		http.Error(w, "Error marshaling weight data", http.StatusInternalServerError)
-		return
-	}
-
-	var weightData types.WeightData
-	if err := json.Unmarshal(weightDataJSON, &weightData); err != nil {
-		http.Error(w, "Error unmarshaling weight data", http.StatusInternalServerError)
-		return
-	}
-
 	// Generate character using the loaded weight data
-	generatedChar := gamecharacter.GenerateCharacter(pubKey, &weightData)
+	generatedChar := gamecharacter.GenerateCharacter(pubKey, weightData)
 
 	registry, err := utils.ReadRegistry()
 	if err != nil {
@@ -92,3 +80,69 @@ func CharacterHandler(w http.ResponseWriter, r *http.Request) {
 		"character": generatedChar,
 	})
 }
+
+// SeededCharacterResponse is a character rolled from a seed
+// swagger:model SeededCharacterResponse
+type SeededCharacterResponse struct {
+	Seed      string          `json:"seed" example:"a1b2c3d4e5f60718"`
+	Character types.Character `json:"character"`
+}
+
+// GenerateSeededHandler godoc
+// @Summary      Generate character from a seed
+// @Description  Rolls race, class, background, alignment and stats from the generation weights. The same seed always produces the same character; omit it to get a fresh seed back to share or reroll with.
+// @Tags         Character
+// @Produce      json
+// @Param        seed  query     string  false  "Seed to reproduce a roll"
+// @Success      200   {object}  SeededCharacterResponse
+// @Failure      500   {string}  string  "Server error"
+// @Router       /character/generate [get]
+func GenerateSeededHandler(w http.ResponseWriter, r *http.Request) {
+	seed := r.URL.Query().Get("seed")
+	if seed == "" {
+		buf := make([]byte, 8)
+		if _, err := rand.Read(buf); err != nil {
+			http.Error(w, "Error generating seed", http.StatusInternalServerError)
+			return
+		}
+		seed = hex.EncodeToString(buf)
+	}
+
+	weightData, err := loadWeightData()
+	if err != nil {
+		http.Error(w, "Error loading weight data: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(SeededCharacterResponse{
+		Seed:      seed,
+		Character: gamecharacter.GenerateCharacterFromSeed(seed, weightData),
+	})
+}
+
+// loadWeightData loads the generation weights from the database and checks
+// they're usable before anything is rolled from them.
+func loadWeightData() (*types.WeightData, error) {
+	// Load weight data from DuckDB (same as weights API endpoint)
+	weightDataMap, err := GetWeightsFromDB()
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert map to WeightData struct
+	weightDataJSON, err := json.Marshal(weightDataMap)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling weight data: %v", err)
+	}
+
+	var weightData types.WeightData
+	if err := json.Unmarshal(weightDataJSON, &weightData); err != nil {
+		return nil, fmt.Errorf("unmarshaling weight data: %v", err)
+	}
+
+	if err := gamecharacter.ValidateWeights(&weightData); err != nil {
+		return nil, fmt.Errorf("invalid generation weights: %v", err)
+	}
+	return &weightData, nil
+}
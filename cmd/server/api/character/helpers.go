@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	gamedata "pubkey-quest/cmd/server/api/data"
 	"pubkey-quest/types"
 )
 
@@ -742,25 +743,11 @@ func loadKnownSpells(database *sql.DB, class string) ([]string, error) {
 // ============================================================================
 
 func getStartingCityForRace(database *sql.DB, race string) (string, error) {
-	var dataJSON string
-	err := database.QueryRow("SELECT data FROM starting_locations WHERE id = 'starting-locations'").Scan(&dataJSON)
+	city, err := gamedata.LoadStartingCity(database, race)
 	if err != nil {
 		return "millhaven", err
 	}
-
-	var racialCities struct {
-		RacialStartingCities map[string]string `json:"racial_starting_cities"`
-	}
-
-	if err := json.Unmarshal([]byte(dataJSON), &racialCities); err != nil {
-		return "millhaven", err
-	}
-
-	if city, ok := racialCities.RacialStartingCities[race]; ok {
-		return city, nil
-	}
-
-	return "millhaven", nil
+	return city, nil
 }
 
 func getMusicTrackForLocation(database *sql.DB, locationID string) string {
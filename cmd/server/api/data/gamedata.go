@@ -602,6 +602,32 @@ func LoadSpellByID(database *sql.DB, id string) (map[string]interface{}, error)
 	return spell, nil
 }
 
+// LoadStartingCity returns the race's starting city from starting-locations.json
+// (racial_starting_cities). It's an error when the race has none.
+func LoadStartingCity(database *sql.DB, race string) (string, error) {
+	if database == nil {
+		return "", fmt.Errorf("database not available")
+	}
+	var dataJSON string
+	err := database.QueryRow("SELECT data FROM starting_locations WHERE id = 'starting-locations'").Scan(&dataJSON)
+	if err != nil {
+		return "", fmt.Errorf("failed to query starting locations: %v", err)
+	}
+
+	var startingLocations struct {
+		RacialStartingCities map[string]string `json:"racial_starting_cities"`
+	}
+	if err := json.Unmarshal([]byte(dataJSON), &startingLocations); err != nil {
+		return "", fmt.Errorf("failed to parse starting locations: %v", err)
+	}
+
+	city := startingLocations.RacialStartingCities[race]
+	if city == "" {
+		return "", fmt.Errorf("no starting city for race %q", race)
+	}
+	return city, nil
+}
+
 func LoadAllMusicTracks(database *sql.DB) ([]MusicTrack, error) {
 	var dataJSON string
 	err := database.QueryRow("SELECT data FROM music_tracks WHERE id = 'music'").Scan(&dataJSON)
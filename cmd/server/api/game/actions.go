@@ -2,6 +2,7 @@ package game
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math/rand"
@@ -45,6 +46,12 @@ type GameActionRequest struct {
 	Npub   string     `json:"npub" example:"npub1..."`
 	SaveID string     `json:"save_id" example:"save_1234567890"`
 	Action GameAction `json:"action"`
+	// ActionID is optional; a request repeating a recently applied id gets the
+	// original response back without the action running again.
+	ActionID string `json:"action_id,omitempty" example:"k3x9q2-7f1a"`
+	// Revision is the save revision the client last saw (state.revision in the
+	// previous response). An action against an older revision is refused with 409.
+	Revision int `json:"revision" example:"42"`
 }
 
 // GameActionHandler godoc
@@ -58,6 +65,7 @@ type GameActionRequest struct {
 // @Failure      400      {string}  string  "Invalid request"
 // @Failure      404      {string}  string  "Session not found"
 // @Failure      405      {string}  string  "Method not allowed"
+// @Failure      409      {object}  types.GameActionResponse  "Game changed elsewhere (stale revision)"
 // @Router       /game/action [post]
 func GameActionHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
@@ -65,11 +73,7 @@ func GameActionHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var request struct {
-		Npub   string     `json:"npub"`
-		SaveID string     `json:"save_id"`
-		Action GameAction `json:"action"`
-	}
+	var request GameActionRequest
 
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
 		log.Printf("❌ Failed to decode action request: %v", err)
@@ -96,6 +100,27 @@ func GameActionHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// One request at a time per save, from the replay check until the response
+	// is recorded: two retries of one action_id can't both get past the check.
+	session.LockActions()
+	defer session.UnlockActions()
+
+	// A retry of an action that already went through (same action_id) gets the
+	// first response replayed — the potion is only drunk once.
+	if cached, ok := session.AppliedResponse(request.ActionID); ok {
+		log.Printf("🔁 Duplicate action %s (%s), replaying its response", request.ActionID, request.Action.Type)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(cached)
+		return
+	}
+
+	// Checked after the replay above: a retried action is stale by design.
+	if request.Revision != session.SaveData.Revision {
+		log.Printf("⚠️ Stale action %s for %s: revision %d, session at %d", request.Action.Type, request.SaveID, request.Revision, session.SaveData.Revision)
+		writeRevisionConflict(w, session.SaveData.Revision)
+		return
+	}
+
 	// Track last action time for player actions (not tick-type actions)
 	if request.Action.Type != "update_time" {
 		session.LastActionTime = time.Now().Unix()
@@ -108,9 +133,10 @@ func GameActionHandler(w http.ResponseWriter, r *http.Request) {
 		log.Printf("❌ Action failed: %v", err)
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(GameActionResponse{
-			Success: false,
-			Error:   err.Error(),
-			Message: fmt.Sprintf("Failed to process action: %v", err),
+			Success:   false,
+			Error:     err.Error(),
+			Message:   fmt.Sprintf("Failed to process action: %v", err),
+			ErrorCode: types.ErrorCodeOf(err),
 		})
 		return
 	}
@@ -124,10 +150,20 @@ func GameActionHandler(w http.ResponseWriter, r *http.Request) {
 	// so save.HP only reaches 0 out of combat — so catch a lethal HP here and run
 	// the shared death flow. Skipped while a fight is active.
 	if session.ActiveCombat == nil && session.SaveData.HP <= 0 {
-		kept := ApplyDeath(&session.SaveData)
+		kept, permadeath := ApplyDeath(session)
 		if response.Data == nil {
 			response.Data = make(map[string]interface{})
 		}
+		if permadeath {
+			// The save is gone: no state, no session to write back.
+			response.Data["death"] = map[string]any{"outcome": "defeat", "permadeath": true}
+			response.Message = hardcoreDeathMessage
+			response.Color = "red"
+			response.State = nil
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(response)
+			return
+		}
 		response.Data["death"] = map[string]any{"outcome": "defeat", "location": session.SaveData.Location, "loot_kept": kept}
 		response.Message = fmt.Sprintf("You have fallen. You wake in %s, stripped of your belongings — but your experience endures.", session.SaveData.Location)
 	}
@@ -166,28 +202,84 @@ func GameActionHandler(w http.ResponseWriter, r *http.Request) {
 	response.Data["enriched_effects"] = effects.EnrichActiveEffects(session.SaveData.ActiveEffects, &session.SaveData)
 	response.Data["total_weight"] = status.CalculateTotalWeight(&session.SaveData)
 	response.Data["weight_capacity"] = status.CalculateWeightCapacity(&session.SaveData)
+	response.Data["encumbrance"] = status.GetEncumbranceTier(&session.SaveData)
 	// Server-authoritative ground items at the player's current spot (drives the GROUND modal).
-	response.Data["ground"] = session.Ground.List(world.GroundKey(&session.SaveData))
+	response.Data["ground"] = world.GroundHere(&session.SaveData)
 
+	body, err := json.Marshal(response)
+	if err != nil {
+		log.Printf("❌ Failed to encode action response: %v", err)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+	session.RecordAppliedAction(request.ActionID, body)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// writeRevisionConflict refuses a request made against an out-of-date save
+// revision, telling the client the revision the session is actually at.
+func writeRevisionConflict(w http.ResponseWriter, current int) {
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	w.WriteHeader(http.StatusConflict)
+	json.NewEncoder(w).Encode(GameActionResponse{
+		Success:   false,
+		Error:     "revision_conflict",
+		Message:   "Your game is open elsewhere. Reload to continue from the latest state.",
+		Data:      map[string]interface{}{"revision": current},
+		ErrorCode: types.ErrCodeRevisionConflict,
+	})
+}
+
+// lockSaveChange is the revision check for handlers outside /game/action that
+// change the save (combat, POI, shop): it takes the session's action lock and
+// refuses a request made against another revision with 409, releasing the lock.
+// On success the caller holds the lock until it has committed the change with
+// commitSaveChange, so those handlers and the actions queue behind each other.
+func lockSaveChange(w http.ResponseWriter, sess *session.GameSession, revision int) bool {
+	sess.LockActions()
+	if revision != sess.SaveData.Revision {
+		log.Printf("⚠️ Stale request for %s: revision %d, session at %d", sess.SaveID, revision, sess.SaveData.Revision)
+		sess.UnlockActions()
+		writeRevisionConflict(w, sess.SaveData.Revision)
+		return false
+	}
+	return true
+}
+
+// commitSaveChange bumps the revision of a save changed in place under
+// lockSaveChange and returns the new revision for the response. It returns 0
+// once the session is gone (a hardcore death deleted the save).
+func commitSaveChange(npub, saveID string, sess *session.GameSession) int {
+	sessionMgr := session.GetSessionManager()
+	if _, err := sessionMgr.GetSession(npub, saveID); err != nil {
+		return 0
+	}
+	if err := sessionMgr.UpdateSession(npub, saveID, sess.SaveData); err != nil {
+		log.Printf("❌ Failed to update session %s: %v", saveID, err)
+	}
+	return sess.SaveData.Revision
 }
 
 // processGameAction routes to specific action handlers
 // combatBlockedActions are out-of-combat game actions that must not run during a
 // fight (M5 interaction matrix): rummaging the pack, re-arming, banking, resting,
 // travelling, chatting, or casting/using through the out-of-combat paths (combat
-// has its own /api/combat/{cast,use-item,action,end-turn}). The world tick
+// has its own /api/combat/{cast,use-item,equip,action,end-turn}). The world tick
 // (update_time) and debug add_item are intentionally exempt.
 var combatBlockedActions = map[string]bool{
-	"equip_item": true, "unequip_item": true, "drop_item": true,
+	"equip_item": true, "unequip_item": true, "drop_item": true, "open_pack": true,
 	"remove_from_inventory": true, "pickup_item": true, "move_item": true,
-	"stack_item": true, "split_item": true, "add_to_container": true,
+	"stack_item": true, "split_item": true, "sort_inventory": true, "add_to_container": true,
 	"remove_from_container": true, "use_item": true, "cast_spell": true,
-	"vault_deposit": true, "vault_withdraw": true, "register_vault": true,
+	"buy_item": true, "sell_item": true,
+	"vault_deposit": true, "vault_withdraw": true, "vault_store_all": true,
+	"vault_take_all": true, "register_vault": true,
 	"open_vault": true, "rest": true, "enter_building": true, "exit_building": true,
 	"move_to_room": true, "move": true, "talk_to_npc": true,
 	"npc_dialogue_choice": true, "rent_room": true, "advance_time": true,
+	"save_game": true,
 }
 
 func processGameAction(session *GameSession, action GameAction) (*GameActionResponse, error) {
@@ -196,14 +288,16 @@ func processGameAction(session *GameSession, action GameAction) (*GameActionResp
 	// Can't do out-of-combat things mid-fight (interaction matrix).
 	if session.ActiveCombat != nil && combatBlockedActions[action.Type] {
 		return &GameActionResponse{
-			Success: false,
-			Message: "You can't do that during combat.",
+			Success:   false,
+			Message:   "You can't do that during combat.",
+			ErrorCode: types.ErrCodeInCombat,
 		}, nil
 	}
 
 	// Snapshot time before action (for travel progress calculation)
 	oldTimeOfDay := state.TimeOfDay
 	oldCurrentDay := state.CurrentDay
+	eventsBefore := snapshotForEvents(state)
 
 	response, err := processActionSwitch(session, state, action)
 
@@ -231,12 +325,22 @@ func processGameAction(session *GameSession, action GameAction) (*GameActionResp
 					response.Data["dest_district"] = travelUpdate.DestDistrict
 					response.Data["newly_discovered"] = travelUpdate.NewlyDiscovered
 					response.Data["music_unlocked"] = travelUpdate.MusicUnlocked
+					response.Data["effects_removed"] = travelUpdate.EffectsRemoved
+					response.AddEvent(types.EventArrived, fmt.Sprintf("You arrive at %s.", travelUpdate.DestCityName), map[string]interface{}{
+						"city":             travelUpdate.DestCity,
+						"city_name":        travelUpdate.DestCityName,
+						"district":         travelUpdate.DestDistrict,
+						"newly_discovered": travelUpdate.NewlyDiscovered,
+					})
 				} else {
 					// Still out in the wild — roll for a biome monster encounter,
 					// for an authored travel encounter, and for discovering any POI
-					// we just travelled past.
-					maybeRollTravelEncounter(session, state, minutesElapsed, response)
-					maybeFireEncounter(session, "travel", []string{state.Location}, response)
+					// we just travelled past. Waiting and sleeping rolled their
+					// encounters step by step already (restInterrupt).
+					if !steppedRestActions[action.Type] {
+						maybeRollTravelEncounter(session, state, minutesElapsed, response)
+						maybeFireEncounter(session, "travel", []string{state.Location}, response)
+					}
 					maybeDiscoverPOIs(state, oldProgress, response)
 				}
 			}
@@ -257,9 +361,50 @@ func processGameAction(session *GameSession, action GameAction) (*GameActionResp
 		}
 	}
 
+	if err == nil && response != nil {
+		appendStateEvents(response, eventsBefore, state)
+	}
+
 	return response, err
 }
 
+// steppedRestActions pass their time in steps and roll travel encounters on
+// each one through restInterrupt, so the post-action roll skips them.
+var steppedRestActions = map[string]bool{"wait": true, "sleep": true}
+
+// restInterrupt is the gametime.WaitInterrupt for waiting and sleeping: out in
+// the wild each step rolls a biome monster and an authored travel encounter
+// over the step's minutes, and either one firing ends the rest. Whatever fires
+// lands on rolled so the handler can pass the combat payload and events on.
+func restInterrupt(sess *GameSession, rolled *GameActionResponse) gametime.WaitInterrupt {
+	return func(state *types.SaveFile, minutes int) string {
+		if travel.GetEnvironmentData(state.Location) == nil {
+			return ""
+		}
+		maybeRollTravelEncounter(sess, state, minutes, rolled)
+		if sess.ActiveCombat != nil {
+			return "you're set upon"
+		}
+		maybeFireEncounter(sess, "travel", []string{state.Location}, rolled)
+		if len(rolled.Events) > 0 {
+			return "something finds you"
+		}
+		return ""
+	}
+}
+
+// withRolledEncounter copies what restInterrupt fired (combat payload, events)
+// onto the handler's response.
+func withRolledEncounter(resp, rolled *GameActionResponse) {
+	for k, v := range rolled.Data {
+		if resp.Data == nil {
+			resp.Data = make(map[string]interface{})
+		}
+		resp.Data[k] = v
+	}
+	resp.Events = append(resp.Events, rolled.Events...)
+}
+
 // maybeRollTravelEncounter rolls a biome monster encounter on a travel tick.
 // On a hit it starts combat server-side, stores it on the session, and flags
 // the result on the response so the client drops into the combat UI. The biome
@@ -276,12 +421,12 @@ func maybeRollTravelEncounter(sess *GameSession, state *SaveFile, minutesElapsed
 	if sess.LastEncounterTime > 0 && nowAbs-sess.LastEncounterTime < encounter.CooldownMinutes {
 		return
 	}
-	biome, err := serverdb.GetEnvironmentType(state.Location)
-	if err != nil || biome == "" {
+	env, err := serverdb.GetEnvironmentEncounters(state.Location)
+	if err != nil {
 		return
 	}
-	refs, err := serverdb.GetMonstersByBiome(biome)
-	if err != nil || len(refs) == 0 {
+	candidates := travelEncounterCandidates(env)
+	if len(candidates) == 0 {
 		return
 	}
 	advancement, err := loadAdvancement()
@@ -289,21 +434,21 @@ func maybeRollTravelEncounter(sess *GameSession, state *SaveFile, minutesElapsed
 		return
 	}
 
-	candidates := make([]encounter.Candidate, 0, len(refs))
-	for _, r := range refs {
-		candidates = append(candidates, encounter.Candidate{ID: r.ID, Name: r.Name, CR: r.CR})
-	}
 	level := character.GetLevelFromXP(state.Experience, advancement)
 	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
 
-	monster, ok := encounter.Roll(candidates, level, minutesElapsed, rng, sess.LastEncounterMonster)
+	danger := encounter.Danger(env.Difficulty)
+	if gametime.IsNight(state.TimeOfDay) {
+		danger *= encounter.NightDanger
+	}
+	monster, ok := encounter.RollWithDanger(candidates, level, minutesElapsed, danger, rng, sess.LastEncounterMonster)
 	if !ok {
 		return
 	}
 	sess.LastEncounterTime = nowAbs
 	sess.LastEncounterMonster = monster.ID
 
-	cs, err := combat.StartCombat(serverdb.GetDB(), state, sess.Npub, monster.ID, state.Location, advancement)
+	cs, err := combat.StartCombat(serverdb.GetDB(), state, sess.Npub, []string{monster.ID}, state.Location, advancement)
 	if err != nil {
 		log.Printf("⚠️ travel encounter: StartCombat failed: %v", err)
 		return
@@ -319,7 +464,39 @@ func maybeRollTravelEncounter(sess *GameSession, state *SaveFile, minutesElapsed
 	cs.MonsterSpawnPos = nil
 	response.Data["combat_started"] = true
 	response.Data["combat"] = combatPayload
-	log.Printf("⚔️  Travel encounter: %s (CR %.2f) in biome %q at level %d", monster.ID, monster.CR, biome, level)
+	response.AddEvent(types.EventEncounter, fmt.Sprintf("%s attacks!", cs.Monsters[0].Name), map[string]interface{}{
+		"kind": "combat", "id": monster.ID, "name": cs.Monsters[0].Name,
+	})
+	log.Printf("⚔️  Travel encounter: %s (CR %.2f) in %s at level %d", monster.ID, monster.CR, state.Location, level)
+}
+
+// travelEncounterCandidates is the monster pool for an environment: its
+// authored "encounters" table when it has one, otherwise every monster tagged
+// with its biome, evenly weighted.
+func travelEncounterCandidates(env *serverdb.EnvironmentEncounters) []encounter.Candidate {
+	var candidates []encounter.Candidate
+	if len(env.Table) > 0 {
+		for _, entry := range env.Table {
+			ref, err := serverdb.GetMonsterRef(entry.Monster)
+			if err != nil {
+				log.Printf("⚠️ travel encounter: %v", err)
+				continue
+			}
+			candidates = append(candidates, encounter.Candidate{ID: ref.ID, Name: ref.Name, CR: ref.CR, Weight: entry.Weight})
+		}
+		return candidates
+	}
+	if env.Biome == "" {
+		return nil
+	}
+	refs, err := serverdb.GetMonstersByBiome(env.Biome)
+	if err != nil {
+		return nil
+	}
+	for _, r := range refs {
+		candidates = append(candidates, encounter.Candidate{ID: r.ID, Name: r.Name, CR: r.CR})
+	}
+	return candidates
 }
 
 // maybeDiscoverPOIs rolls discovery for any POI the player just travelled past
@@ -402,19 +579,25 @@ func processActionSwitch(session *GameSession, state *SaveFile, action GameActio
 	case "use_item":
 		return handleUseItemAction(state, action.Params)
 	case "equip_item":
-		return inventory.HandleEquipItemAction(state, action.Params)
+		return handleEquipItemAction(state, action.Params)
 	case "unequip_item":
 		return inventory.HandleUnequipItemAction(state, action.Params)
 	case "drop_item":
-		return handleDropItemAction(session, state, action.Params)
+		return handleDropItemAction(state, action.Params)
+	case "open_pack":
+		return handleOpenPackAction(state, action.Params)
 	case "remove_from_inventory":
 		return handleRemoveFromInventoryAction(state, action.Params)
 	case "pickup_item":
-		return handlePickupItemAction(session, state, action.Params)
+		return handlePickupItemAction(state, action.Params)
+	case "set_loot_filter":
+		return handleSetLootFilterAction(state, action.Params)
 	case "cast_spell":
 		return handleCastSpellAction(state, action.Params)
+	case "end_concentration":
+		return handleEndConcentrationAction(session)
 	case "rest":
-		return handleRestAction(state, action.Params)
+		return handleRestAction(session, action.Params)
 	case "advance_time":
 		return handleAdvanceTimeAction(state, action.Params)
 	case "update_time":
@@ -423,12 +606,22 @@ func processActionSwitch(session *GameSession, state *SaveFile, action GameActio
 		return handleVaultDepositAction(state, action.Params)
 	case "vault_withdraw":
 		return handleVaultWithdrawAction(state, action.Params)
+	case "vault_store_all":
+		return handleVaultStoreAllAction(state, action.Params)
+	case "vault_take_all":
+		return handleVaultTakeAllAction(state, action.Params)
 	case "move_item":
 		return handleMoveItemAction(state, action.Params)
 	case "stack_item":
 		return handleStackItemAction(state, action.Params)
 	case "split_item":
 		return handleSplitItemAction(state, action.Params)
+	case "sort_inventory":
+		return handleSortInventoryAction(state, action.Params)
+	case "buy_item":
+		return handleBuyItemAction(session, action.Params)
+	case "sell_item":
+		return handleSellItemAction(session, action.Params)
 	case "add_item":
 		return handleAddItemAction(state, action.Params)
 	case "add_to_container":
@@ -469,6 +662,8 @@ func processActionSwitch(session *GameSession, state *SaveFile, action GameActio
 		return handleTurnBackAction(state, action.Params)
 	case "reset_idle_timer":
 		return handleResetIdleTimerAction(session)
+	case "save_game":
+		return handleSaveGameAction(session)
 	default:
 		return nil, fmt.Errorf("unknown action type: %s", action.Type)
 	}
@@ -489,9 +684,14 @@ func updateEncumbranceIfNeeded(state *SaveFile, actionType string, response *Gam
 		"pickup_item":           true,
 		"vault_deposit":         true,
 		"vault_withdraw":        true,
+		"vault_store_all":       true,
+		"vault_take_all":        true,
 		"move_item":             true,
 		"stack_item":            true,
 		"split_item":            true,
+		"sort_inventory":        true,
+		"buy_item":              true,
+		"sell_item":             true,
 		"add_item":              true,
 		"add_to_container":      true,
 		"remove_from_container": true,
@@ -534,6 +734,80 @@ func handleMoveAction(state *SaveFile, params map[string]any) (*GameActionRespon
 	return nil, err
 }
 
+// handleEquipItemAction equips an item, warning (without refusing) when it's
+// armor the player's class isn't trained in. An equip refused for lack of a
+// free slot comes back unsuccessful with the occupied slots.
+func handleEquipItemAction(state *SaveFile, params map[string]any) (*GameActionResponse, error) {
+	resp, err := inventory.HandleEquipItemAction(state, params)
+	var full *inventory.SlotsFullError
+	if errors.As(err, &full) {
+		// Refused, not failed: say which slots are in the way.
+		return &GameActionResponse{
+			Success:   false,
+			Error:     full.Error(),
+			Message:   full.Error(),
+			Color:     "red",
+			Data:      map[string]interface{}{"occupied_slots": full.Occupied},
+			ErrorCode: types.ErrCodeSlotsFull,
+		}, nil
+	}
+	if err != nil || resp == nil {
+		return resp, err
+	}
+	itemID, _ := params["item_id"].(string)
+	item, loadErr := data.LoadItemByID(serverdb.GetDB(), itemID)
+	if loadErr != nil {
+		return resp, nil
+	}
+	if category := combat.ArmorCategory(item); !combat.IsProficientWithArmor(state.Class, category) {
+		warning := fmt.Sprintf("As a %s you aren't trained in %s armor — your attacks and Strength/Dexterity saves have disadvantage while you wear it.",
+			state.Class, category)
+		if category == combat.ArmorShields {
+			warning = fmt.Sprintf("As a %s you aren't trained with shields — your attacks and Strength/Dexterity saves have disadvantage while you carry one.", state.Class)
+		}
+		resp.Message += ". " + warning
+		resp.Color = "yellow"
+		if resp.Data == nil {
+			resp.Data = map[string]interface{}{}
+		}
+		resp.Data["proficiency_warning"] = warning
+	}
+	return resp, nil
+}
+
+// handleSetLootFilterAction replaces the save's loot filter. Params:
+// manual_pickup (bool), min_rarity (string), min_value (number), ignore
+// (item IDs). An all-default filter clears it — everything is picked up.
+func handleSetLootFilterAction(state *SaveFile, params map[string]any) (*GameActionResponse, error) {
+	var filter types.LootFilter
+	filter.ManualPickup, _ = params["manual_pickup"].(bool)
+	filter.MinRarity, _ = params["min_rarity"].(string)
+	if v, ok := params["min_value"].(float64); ok {
+		filter.MinValue = int(v)
+	}
+	if ignore, ok := params["ignore"].([]interface{}); ok {
+		for _, raw := range ignore {
+			if id, _ := raw.(string); id != "" {
+				filter.Ignore = append(filter.Ignore, id)
+			}
+		}
+	}
+	if err := combat.ValidateLootFilter(filter); err != nil {
+		return nil, err
+	}
+
+	if !filter.ManualPickup && filter.MinRarity == "" && filter.MinValue == 0 && len(filter.Ignore) == 0 {
+		state.LootFilter = nil
+		return &GameActionResponse{Success: true, Message: "Loot filter cleared — all loot will be picked up."}, nil
+	}
+	state.LootFilter = &filter
+	return &GameActionResponse{
+		Success: true,
+		Message: "Loot filter updated.",
+		Data:    map[string]interface{}{"loot_filter": state.LootFilter},
+	}, nil
+}
+
 // handleUseItemAction uses a consumable item
 func handleUseItemAction(state *SaveFile, params map[string]any) (*GameActionResponse, error) {
 	// A spell scroll casts its spell (out of combat: heal/buff/utility only —
@@ -599,10 +873,10 @@ func castFromScrollOutOfCombat(state *SaveFile, spellID string) (string, error)
 
 // Equipment handlers are now in equipment.go
 
-// handleDropItemAction drops an item from inventory ONTO THE GROUND (session-scoped
-// ground store) — it is no longer destroyed. The dropped quantity is added to the
-// per-location ground pile so it can be picked back up.
-func handleDropItemAction(session *GameSession, state *SaveFile, params map[string]any) (*GameActionResponse, error) {
+// handleDropItemAction drops an item from inventory ONTO THE GROUND — it is no
+// longer destroyed. The dropped quantity is added to the save's per-location
+// ground pile so it can be picked back up, even after a reload.
+func handleDropItemAction(state *SaveFile, params map[string]any) (*GameActionResponse, error) {
 	paramsIface := make(map[string]interface{}, len(params))
 	for k, v := range params {
 		paramsIface[k] = v
@@ -613,7 +887,7 @@ func handleDropItemAction(session *GameSession, state *SaveFile, params map[stri
 	}
 	if resp != nil && resp.Success && dropped > 0 {
 		itemID, _ := params["item_id"].(string)
-		session.Ground.Add(world.GroundKey(state), itemID, dropped)
+		world.DropOnGround(state, itemID, dropped)
 	}
 	if resp != nil {
 		return &GameActionResponse{Success: resp.Success, Message: resp.Message}, nil
@@ -621,6 +895,30 @@ func handleDropItemAction(session *GameSession, state *SaveFile, params map[stri
 	return nil, nil
 }
 
+// handleOpenPackAction opens a pack into the inventory. Contents that don't fit
+// land on the ground here, same as a drop, so nothing from the pack is lost.
+func handleOpenPackAction(state *SaveFile, params map[string]any) (*GameActionResponse, error) {
+	paramsIface := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		paramsIface[k] = v
+	}
+	resp, grants, err := inventory.HandleOpenPackAction(state, paramsIface)
+	if err != nil {
+		return nil, err
+	}
+	if resp != nil && resp.Success {
+		for _, g := range grants {
+			if g.Overflow > 0 {
+				world.DropOnGround(state, g.Item, g.Overflow)
+			}
+		}
+	}
+	if resp != nil {
+		return &GameActionResponse{Success: resp.Success, Message: resp.Message, Color: resp.Color, Data: resp.Data}, nil
+	}
+	return nil, nil
+}
+
 // handleRemoveFromInventoryAction removes an item from inventory (for sell staging)
 func handleRemoveFromInventoryAction(state *SaveFile, params map[string]any) (*GameActionResponse, error) {
 	paramsIface := make(map[string]interface{}, len(params))
@@ -635,23 +933,22 @@ func handleRemoveFromInventoryAction(state *SaveFile, params map[string]any) (*G
 }
 
 // handlePickupItemAction picks an item up off the ground (server-authoritative):
-// it must actually be in the per-location ground store, then it's added back to the
-// inventory. Anything that won't fit stays on the ground, so nothing is ever lost.
-func handlePickupItemAction(session *GameSession, state *SaveFile, params map[string]any) (*GameActionResponse, error) {
+// it must actually be on the ground where the player stands, then it's added back
+// to the inventory. Anything that won't fit stays on the ground, so nothing is
+// ever lost; a full inventory leaves the pile untouched.
+func handlePickupItemAction(state *SaveFile, params map[string]any) (*GameActionResponse, error) {
 	itemID, ok := params["item_id"].(string)
 	if !ok {
 		return nil, fmt.Errorf("missing or invalid item_id parameter")
 	}
 
-	key := world.GroundKey(state)
-
 	// How much to pick up: an explicit quantity, else the whole pile of that item.
 	want := -1
 	if q, ok := params["quantity"].(float64); ok {
 		want = int(q)
 	}
 	if want <= 0 {
-		for _, d := range session.Ground.List(key) {
+		for _, d := range world.GroundHere(state) {
 			if d.Item == itemID {
 				want = d.Quantity
 				break
@@ -659,23 +956,37 @@ func handlePickupItemAction(session *GameSession, state *SaveFile, params map[st
 		}
 	}
 
-	taken := session.Ground.Take(key, itemID, want)
+	// Under the "block" pickup policy only what fits the carrying capacity comes
+	// off the ground; the rest stays where it lies.
+	tooHeavy := 0
+	if want > 0 && status.WeightPolicy("pickup") == "block" {
+		fit := status.CarryableQuantity(state, itemID, want)
+		if fit == 0 {
+			return nil, fmt.Errorf("%s is too heavy to carry (%.0f/%.0f lbs)", itemID,
+				status.CalculateTotalWeight(state), status.CalculateWeightCapacity(state))
+		}
+		tooHeavy, want = want-fit, fit
+	}
+
+	taken := world.TakeFromGround(state, itemID, want)
 	if taken <= 0 {
 		return nil, fmt.Errorf("%s is not on the ground here", itemID)
 	}
 
 	added, err := inventory.AddItemToInventory(state, itemID, taken)
 	if err != nil {
-		session.Ground.Add(key, itemID, taken) // roll back — never lose it
+		world.DropOnGround(state, itemID, taken) // roll back — never lose it
 		return nil, err
 	}
 	if added < taken {
-		session.Ground.Add(key, itemID, taken-added) // inventory full — leave the rest
+		world.DropOnGround(state, itemID, taken-added) // inventory full — leave the rest
 	}
 
 	msg := fmt.Sprintf("Picked up %s", itemID)
 	if added < taken {
 		msg = fmt.Sprintf("Picked up %d %s (inventory full — %d left on the ground)", added, itemID, taken-added)
+	} else if tooHeavy > 0 {
+		msg = fmt.Sprintf("Picked up %d %s (too heavy — %d left on the ground)", added, itemID, tooHeavy)
 	}
 	return &GameActionResponse{Success: true, Message: msg}, nil
 }
@@ -686,8 +997,9 @@ func handlePickupItemAction(session *GameSession, state *SaveFile, params map[st
 // shapes need an enemy, so the engine rejects them with a clear message. The
 // shared casting engine (spells.Cast) validates known + prepared + mana +
 // components and applies mana/component/effect costs; here we apply any healing
-// to the resting save HP. A failed cast returns Success=false with the reason
-// rather than a hard error so the UI can surface it inline.
+// to the resting save HP and report the mana spent (the updated mana reaches the
+// client through the character delta). A failed cast returns Success=false with
+// the reason rather than a hard error so the UI can surface it inline.
 func handleCastSpellAction(state *SaveFile, params map[string]any) (*GameActionResponse, error) {
 	spellID, ok := params["spell_id"].(string)
 	if !ok || spellID == "" {
@@ -723,23 +1035,62 @@ func handleCastSpellAction(state *SaveFile, params map[string]any) (*GameActionR
 	if msg == "" {
 		msg = fmt.Sprintf("You cast %s.", res.SpellName)
 	}
+	if res.ManaSpent > 0 {
+		msg += fmt.Sprintf(" (%d mana spent, %d/%d left)", res.ManaSpent, state.Mana, state.MaxMana)
+	}
 
 	return &GameActionResponse{Success: true, Message: msg}, nil
 }
 
-// handleRestAction rests to restore HP/Mana
-func handleRestAction(state *SaveFile, params map[string]any) (*GameActionResponse, error) {
+// handleEndConcentrationAction lets go of the concentration effect the player
+// is holding, freeing them to start another. Allowed mid-fight: dropping
+// concentration costs nothing.
+func handleEndConcentrationAction(session *GameSession) (*GameActionResponse, error) {
+	name := ""
+	if cs := session.ActiveCombat; cs != nil && cs.Concentration != nil {
+		name = cs.Concentration.SpellName
+		if cs.Concentration.EffectID != "" {
+			effects.RemoveEffect(&session.SaveData, cs.Concentration.EffectID)
+		}
+		cs.Concentration = nil
+	}
+	if held := effects.EndConcentration(&session.SaveData); held != "" {
+		name = held
+	}
+	if name == "" {
+		return &GameActionResponse{Success: false, Message: "You aren't concentrating on anything."}, nil
+	}
+	return &GameActionResponse{Success: true, Message: fmt.Sprintf("You stop concentrating on %s.", name)}, nil
+}
+
+// handleRestAction rests for a number of minutes, restoring HP/Mana over time
+func handleRestAction(session *GameSession, params map[string]any) (*GameActionResponse, error) {
 	paramsIface := make(map[string]interface{}, len(params))
 	for k, v := range params {
 		paramsIface[k] = v
 	}
-	resp, err := npc.HandleRestAction(state, paramsIface)
+	resp, err := gametime.HandleRestAction(&session.SaveData, paramsIface, session, data.GetNPCIDsAtLocation)
+
+	// Resolve any spell prep tasks that finished during the rest
+	prepMsgs := spells.ResolvePrepTimers(session)
+
 	if resp != nil {
 		msg := resp.Message
-		if cleared := effects.RemoveEffectsByAction(state, "rest"); len(cleared) > 0 {
-			msg += fmt.Sprintf("\nRest clears: %s.", strings.Join(cleared, ", "))
+		if resp.Success {
+			if cleared := effects.RemoveEffectsByAction(&session.SaveData, "rest"); len(cleared) > 0 {
+				msg += fmt.Sprintf("\nRest clears: %s.", strings.Join(cleared, ", "))
+			}
 		}
-		return &GameActionResponse{Success: resp.Success, Message: msg}, err
+		for _, pm := range prepMsgs {
+			msg += "\n\n🔮 " + pm
+		}
+		return &GameActionResponse{
+			Success: resp.Success,
+			Message: msg,
+			Color:   resp.Color,
+			Delta:   resp.Delta,
+			Data:    resp.Data,
+		}, err
 	}
 	return nil, err
 }
@@ -791,24 +1142,60 @@ func handleUpdateTimeAction(state *SaveFile, params map[string]any) (*GameAction
 	return nil, err
 }
 
-// handleVaultDepositAction deposits items into vault (uses existing move_item action for vault transfers)
-func handleVaultDepositAction(_ *SaveFile, _ map[string]any) (*GameActionResponse, error) {
-	// Vaults work like containers - use the container system
-	// This is handled by frontend calling move_item or add_to_container with vault as destination
-	return &GameActionResponse{
-		Success: true,
-		Message: "Item deposited to vault",
-	}, nil
+// handleVaultDepositAction stores an inventory item in the first open slot of
+// the building's vault
+func handleVaultDepositAction(state *SaveFile, params map[string]any) (*GameActionResponse, error) {
+	paramsIface := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		paramsIface[k] = v
+	}
+	resp, err := inventory.HandleVaultDepositAction(state, paramsIface)
+	if resp != nil {
+		return &GameActionResponse{Success: resp.Success, Message: resp.Message, Error: resp.Error, Color: resp.Color, Delta: resp.Delta}, err
+	}
+	return nil, err
 }
 
-// handleVaultWithdrawAction withdraws items from vault (uses existing move_item action for vault transfers)
-func handleVaultWithdrawAction(_ *SaveFile, _ map[string]any) (*GameActionResponse, error) {
-	// Vaults work like containers - use the container system
-	// This is handled by frontend calling move_item or remove_from_container with vault as source
-	return &GameActionResponse{
-		Success: true,
-		Message: "Item withdrawn from vault",
-	}, nil
+// handleVaultWithdrawAction takes a vault item into the first open backpack
+// slot, then general slot
+func handleVaultWithdrawAction(state *SaveFile, params map[string]any) (*GameActionResponse, error) {
+	paramsIface := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		paramsIface[k] = v
+	}
+	resp, err := inventory.HandleVaultWithdrawAction(state, paramsIface)
+	if resp != nil {
+		return &GameActionResponse{Success: resp.Success, Message: resp.Message, Error: resp.Error, Color: resp.Color, Delta: resp.Delta}, err
+	}
+	return nil, err
+}
+
+// handleVaultStoreAllAction stores every stack in the backpack (or general
+// slots) in the vault until it's full
+func handleVaultStoreAllAction(state *SaveFile, params map[string]any) (*GameActionResponse, error) {
+	paramsIface := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		paramsIface[k] = v
+	}
+	resp, err := inventory.HandleVaultStoreAllAction(state, paramsIface)
+	if resp != nil {
+		return &GameActionResponse{Success: resp.Success, Message: resp.Message, Error: resp.Error, Color: resp.Color, Delta: resp.Delta, Data: resp.Data}, err
+	}
+	return nil, err
+}
+
+// handleVaultTakeAllAction takes every stack out of the vault until the
+// player runs out of room
+func handleVaultTakeAllAction(state *SaveFile, params map[string]any) (*GameActionResponse, error) {
+	paramsIface := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		paramsIface[k] = v
+	}
+	resp, err := inventory.HandleVaultTakeAllAction(state, paramsIface)
+	if resp != nil {
+		return &GameActionResponse{Success: resp.Success, Message: resp.Message, Error: resp.Error, Color: resp.Color, Delta: resp.Delta, Data: resp.Data}, err
+	}
+	return nil, err
 }
 
 // handleMoveItemAction moves/swaps items between inventory slots
@@ -837,6 +1224,20 @@ func handleStackItemAction(state *SaveFile, params map[string]any) (*GameActionR
 	return nil, err
 }
 
+// handleSortInventoryAction merges, compacts and optionally sorts the general
+// slots and backpack
+func handleSortInventoryAction(state *SaveFile, params map[string]any) (*GameActionResponse, error) {
+	paramsIface := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		paramsIface[k] = v
+	}
+	resp, err := inventory.HandleSortInventoryAction(state, paramsIface)
+	if resp != nil {
+		return &GameActionResponse{Success: resp.Success, Message: resp.Message, Color: resp.Color}, err
+	}
+	return nil, err
+}
+
 // handleSplitItemAction splits a stack into two stacks
 func handleSplitItemAction(state *SaveFile, params map[string]any) (*GameActionResponse, error) {
 	paramsIface := make(map[string]interface{}, len(params))
@@ -858,7 +1259,7 @@ func handleAddItemAction(state *SaveFile, params map[string]any) (*GameActionRes
 	}
 	resp, err := inventory.HandleAddItemAction(state, paramsIface)
 	if resp != nil {
-		return &GameActionResponse{Success: resp.Success, Message: resp.Message}, err
+		return &GameActionResponse{Success: resp.Success, Message: resp.Message, Error: resp.Error, Color: resp.Color}, err
 	}
 	return nil, err
 }
@@ -908,10 +1309,12 @@ func GetGameStateHandler(w http.ResponseWriter, r *http.Request) {
 	weightCapacity := status.CalculateWeightCapacity(&session.SaveData)
 
 	// Level is derived from XP (never stored — hydration rule). Send it so the UI's
-	// level number and XP bar track the right interval instead of defaulting to 1.
-	level := 1
+	// level number and XP bar track the right interval instead of defaulting to 1,
+	// along with the XP still to go and the proficiency bonus for that level.
+	level, xpToNext := 1, 0
 	if adv, err := loadAdvancement(); err == nil {
 		level = character.GetLevelFromXP(session.SaveData.Experience, adv)
+		xpToNext = character.XPToNextLevel(session.SaveData.Experience, adv)
 	}
 
 	// Equipment-derived combat stats (AC / attack / damage / ranged + ammo) for
@@ -925,12 +1328,15 @@ func GetGameStateHandler(w http.ResponseWriter, r *http.Request) {
 			// Include all SaveData fields
 			"d":                     session.SaveData.D,
 			"created_at":            session.SaveData.CreatedAt,
+			"revision":              session.SaveData.Revision,
 			"race":                  session.SaveData.Race,
 			"class":                 session.SaveData.Class,
 			"background":            session.SaveData.Background,
 			"alignment":             session.SaveData.Alignment,
 			"level":                 level,
 			"experience":            session.SaveData.Experience,
+			"xp_to_next_level":      xpToNext,
+			"proficiency_bonus":     character.ProficiencyBonus(level),
 			"hp":                    session.SaveData.HP,
 			"max_hp":                session.SaveData.MaxHP,
 			"mana":                  session.SaveData.Mana,
@@ -947,21 +1353,31 @@ func GetGameStateHandler(w http.ResponseWriter, r *http.Request) {
 			"current_day":           session.SaveData.CurrentDay,
 			"time_of_day":           session.SaveData.TimeOfDay,
 			"inventory":             session.SaveData.Inventory,
+			"gold":                  gameutil.GetGoldQuantity(&session.SaveData),
 			"vaults":                session.SaveData.Vaults,
 			"known_spells":          session.SaveData.KnownSpells,
 			"spell_slots":           session.SaveData.SpellSlots,
 			"locations_discovered":  session.SaveData.LocationsDiscovered,
 			"music_tracks_unlocked": session.SaveData.MusicTracksUnlocked,
 			"active_effects":        effects.EnrichActiveEffects(session.SaveData.ActiveEffects, &session.SaveData),
+			"active_quests":         activeQuestViews(&session.SaveData),
+			"quests_completed":      session.SaveData.QuestsCompleted,
 
 			// Add calculated values (NOT persisted - calculated at runtime)
-			"total_weight":    totalWeight,
-			"weight_capacity": weightCapacity,
-			"equipped_stats":  equippedStats,
+			"total_weight":     totalWeight,
+			"weight_capacity":  weightCapacity,
+			"encumbrance":      status.GetEncumbranceTier(&session.SaveData),
+			"equipped_stats":   equippedStats,
+			"gear_slot_layout": inventory.GearSlotLayout(),
+
+			// Spells, level-unlocked abilities, discovered locations and music in
+			// one render-ready section (names/levels resolved from game data).
+			"learned": buildLearnedContent(&session.SaveData, level),
 
 			// Rentals live on the save now (survive reload); shows are session-only.
 			// "rented_rooms" kept as a compat alias until the P4 room UI rework.
 			"rentals":         session.SaveData.Rentals,
+			"loot_filter":     session.SaveData.LootFilter,
 			"rented_rooms":    session.SaveData.Rentals,
 			"booked_shows":    session.BookedShows,
 			"performed_shows": session.PerformedShows,
@@ -980,7 +1396,7 @@ func handleEnterBuildingAction(state *SaveFile, params map[string]any) (*GameAct
 	}
 	resp, err := movement.HandleEnterBuildingAction(state, paramsIface)
 	if resp != nil {
-		return &GameActionResponse{Success: resp.Success, Message: resp.Message, Color: resp.Color}, err
+		return &GameActionResponse{Success: resp.Success, Message: resp.Message, Color: resp.Color, Data: resp.Data}, err
 	}
 	return nil, err
 }
@@ -1006,7 +1422,7 @@ func handleExitBuildingAction(state *SaveFile, params map[string]any) (*GameActi
 	}
 	resp, err := movement.HandleExitBuildingAction(state, paramsIface)
 	if resp != nil {
-		return &GameActionResponse{Success: resp.Success, Message: resp.Message, Color: resp.Color}, err
+		return &GameActionResponse{Success: resp.Success, Message: resp.Message, Color: resp.Color, Data: resp.Data}, err
 	}
 	return nil, err
 }
@@ -1045,7 +1461,7 @@ func handleNPCDialogueChoiceAction(session *GameSession, params map[string]any)
 	for k, v := range params {
 		paramsIface[k] = v
 	}
-	resp, err := npc.HandleNPCDialogueChoiceActionWithSession(&session.SaveData, paramsIface, session)
+	resp, err := npc.HandleNPCDialogueChoiceActionWithSession(&session.SaveData, paramsIface, session, dialogueQuests{})
 	if resp != nil {
 		return &GameActionResponse{
 			Success: resp.Success,
@@ -1090,7 +1506,7 @@ func handleOpenVaultAction(state *SaveFile, _ map[string]any) (*GameActionRespon
 		Success: true,
 		Message: "Vault opened",
 		Delta: map[string]any{
-			"vault": vaultData,
+			"vault": vault.VaultDelta(vaultData),
 		},
 	}, nil
 }
@@ -1103,7 +1519,7 @@ func handleAddToContainerAction(state *SaveFile, params map[string]any) (*GameAc
 	}
 	resp, err := inventory.HandleAddToContainerAction(state, paramsIface)
 	if resp != nil {
-		return &GameActionResponse{Success: resp.Success, Message: resp.Message, Color: resp.Color}, err
+		return &GameActionResponse{Success: resp.Success, Message: resp.Message, Error: resp.Error, Color: resp.Color}, err
 	}
 	return nil, err
 }
@@ -1149,12 +1565,14 @@ func handleRentRoomAction(session *GameSession, params map[string]any) (*GameAct
 func handleSleepAction(session *GameSession, _ map[string]any) (*GameActionResponse, error) {
 	var resp *GameActionResponse
 	var err error
+	rolled := &GameActionResponse{}
 	if session.SaveData.Building != "" {
-		resp, err = npc.HandleSleepAction(&session.SaveData, session, data.GetNPCIDsAtLocation)
+		resp, err = npc.HandleSleepAction(&session.SaveData, session, data.GetNPCIDsAtLocation, restInterrupt(session, rolled))
 	} else {
-		resp, err = npc.HandleWildernessSleepAction(&session.SaveData, session, data.GetNPCIDsAtLocation)
+		resp, err = npc.HandleWildernessSleepAction(&session.SaveData, session, data.GetNPCIDsAtLocation, restInterrupt(session, rolled))
 	}
 	if resp != nil {
+		withRolledEncounter(resp, rolled)
 		msg := resp.Message
 		// Sleeping is a rest, so it clears both "sleep"- and "rest"-removal effects.
 		cleared := append(effects.RemoveEffectsByAction(&session.SaveData, "sleep"),
@@ -1168,6 +1586,7 @@ func handleSleepAction(session *GameSession, _ map[string]any) (*GameActionRespo
 			Color:   resp.Color,
 			Delta:   resp.Delta,
 			Data:    resp.Data,
+			Events:  resp.Events,
 		}, err
 	}
 	return nil, err
@@ -1180,12 +1599,14 @@ func handleWaitAction(session *GameSession, params map[string]any) (*GameActionR
 	for k, v := range params {
 		paramsIface[k] = v
 	}
-	resp, err := gametime.HandleWaitAction(&session.SaveData, paramsIface, session, data.GetNPCIDsAtLocation)
+	rolled := &GameActionResponse{}
+	resp, err := gametime.HandleWaitAction(&session.SaveData, paramsIface, session, data.GetNPCIDsAtLocation, restInterrupt(session, rolled))
 
 	// Resolve any spell prep tasks that finished during the wait
 	prepMsgs := spells.ResolvePrepTimers(session)
 
 	if resp != nil {
+		withRolledEncounter(resp, rolled)
 		msg := resp.Message
 		for _, pm := range prepMsgs {
 			msg += "\n\n🔮 " + pm
@@ -1196,6 +1617,7 @@ func handleWaitAction(session *GameSession, params map[string]any) (*GameActionR
 			Color:   resp.Color,
 			Delta:   resp.Delta,
 			Data:    resp.Data,
+			Events:  resp.Events,
 		}, err
 	}
 	return nil, err
@@ -1267,6 +1689,29 @@ func handleResetIdleTimerAction(session *GameSession) (*GameActionResponse, erro
 	return nil, err
 }
 
+// handleSaveGameAction commits the session to its save file, subject to the
+// save policy: under "safe-only" it's refused (with the reason) unless the
+// player is in a city or a rented room. Crash journaling is unaffected.
+func handleSaveGameAction(sess *GameSession) (*GameActionResponse, error) {
+	if reason := session.SaveBlockedReason(&sess.SaveData); reason != "" {
+		return &GameActionResponse{
+			Success: false,
+			Message: reason,
+			Color:   "yellow",
+			Data:    map[string]interface{}{"save_blocked": true},
+		}, nil
+	}
+	if err := session.CommitSave(sess.Npub, sess.SaveID, &sess.SaveData); err != nil {
+		return nil, fmt.Errorf("failed to write save file: %v", err)
+	}
+	log.Printf("✅ Session saved to disk: %s:%s", sess.Npub, sess.SaveID)
+	return &GameActionResponse{
+		Success: true,
+		Message: "Game saved.",
+		Color:   "green",
+	}, nil
+}
+
 // handleBookShowAction books a performance at a tavern
 func handleBookShowAction(session *GameSession, params map[string]any) (*GameActionResponse, error) {
 	paramsIface := make(map[string]interface{}, len(params))
@@ -12,37 +12,48 @@ import (
 	serverdb "pubkey-quest/cmd/server/db"
 	"pubkey-quest/cmd/server/game/character"
 	"pubkey-quest/cmd/server/game/combat"
+	"pubkey-quest/cmd/server/game/effects"
 	gaminventory "pubkey-quest/cmd/server/game/inventory"
 	"pubkey-quest/cmd/server/game/poi"
+	"pubkey-quest/cmd/server/game/status"
 	"pubkey-quest/cmd/server/session"
+	"pubkey-quest/cmd/server/world"
 	"pubkey-quest/types"
 )
 
 // ─── Request / Response models ───────────────────────────────────────────────
 
 // CombatStartRequest is the body sent to POST /combat/start.
+// monster_ids lists every monster in the encounter (repeat an ID for a pack);
+// monster_id is the single-monster shorthand.
 // swagger:model CombatStartRequest
 type CombatStartRequest struct {
-	Npub          string `json:"npub"           example:"npub1..."`
-	SaveID        string `json:"save_id"        example:"save_1234567890"`
-	MonsterID     string `json:"monster_id"     example:"goblin"`
-	EnvironmentID string `json:"environment_id" example:"forest"`
+	Npub          string   `json:"npub"           example:"npub1..."`
+	SaveID        string   `json:"save_id"        example:"save_1234567890"`
+	MonsterID     string   `json:"monster_id"     example:"goblin"`
+	MonsterIDs    []string `json:"monster_ids,omitempty"`
+	EnvironmentID string   `json:"environment_id" example:"forest"`
+	Revision      int      `json:"revision"       example:"42"`
 }
 
 // CombatMoveRequest is the body sent to POST /combat/move.
 // x, y: target grid cell coordinates (0-indexed, must be within grid bounds).
+// target_id: optional monster instance ID to measure range against after moving.
 // swagger:model CombatMoveRequest
 type CombatMoveRequest struct {
-	Npub   string `json:"npub"    example:"npub1..."`
-	SaveID string `json:"save_id" example:"save_1234567890"`
-	X      int    `json:"x"       example:"3"`
-	Y      int    `json:"y"       example:"3"`
+	Npub     string `json:"npub"      example:"npub1..."`
+	SaveID   string `json:"save_id"   example:"save_1234567890"`
+	X        int    `json:"x"         example:"3"`
+	Y        int    `json:"y"         example:"3"`
+	TargetID string `json:"target_id" example:"goblin"`
+	Revision int    `json:"revision"  example:"42"`
 }
 
 // CombatActionRequest is the body sent to POST /combat/action.
 // weapon_slot must be "mainHand", "offHand", or "unarmed".
 // hand: "main" (default) or "off" to use the off-hand weapon as a bonus action.
 // thrown: true to throw a melee weapon with the "thrown" tag as a ranged attack.
+// target_id: optional monster instance ID to attack (default: current target).
 // swagger:model CombatActionRequest
 type CombatActionRequest struct {
 	Npub       string `json:"npub"        example:"npub1..."`
@@ -50,13 +61,19 @@ type CombatActionRequest struct {
 	WeaponSlot string `json:"weapon_slot" example:"mainHand"`
 	Hand       string `json:"hand"        example:"main"`
 	Thrown     bool   `json:"thrown"      example:"false"`
+	TargetID   string `json:"target_id"   example:"goblin"`
+	Revision   int    `json:"revision"    example:"42"`
 }
 
 // CombatBaseRequest is reused by death-save and end-combat.
+// Every combat request carries the save revision the client last saw (revision
+// in the previous response); one made against an older revision is refused
+// with 409, as on /game/action.
 // swagger:model CombatBaseRequest
 type CombatBaseRequest struct {
-	Npub   string `json:"npub"    example:"npub1..."`
-	SaveID string `json:"save_id" example:"save_1234567890"`
+	Npub     string `json:"npub"     example:"npub1..."`
+	SaveID   string `json:"save_id"  example:"save_1234567890"`
+	Revision int    `json:"revision" example:"42"`
 }
 
 // CombatPlayerView is the player's combat state returned in responses.
@@ -76,6 +93,8 @@ type CombatPlayerView struct {
 	Resource      *CombatResourceView `json:"resource,omitempty"`
 	RageTurnsLeft int                 `json:"rage_turns_left,omitempty"`
 	AbilitiesUsed []string            `json:"abilities_used,omitempty"` // once-per-combat abilities already spent
+	// AbilityCooldowns maps an ability id to the rounds left before it can be used again.
+	AbilityCooldowns map[string]int `json:"ability_cooldowns,omitempty"`
 }
 
 // CombatResourceView is the visible martial ability resource pool.
@@ -91,13 +110,16 @@ type CombatResourceView struct {
 // Full stat blocks are never sent to the client.
 // swagger:model CombatMonsterView
 type CombatMonsterView struct {
-	InstanceID string `json:"instance_id" example:"goblin"`
-	Name       string `json:"name"        example:"Goblin"`
+	InstanceID string `json:"instance_id" example:"goblin-2"`
+	TemplateID string `json:"template_id" example:"goblin"`
+	Name       string `json:"name"        example:"Goblin 2"`
 	CurrentHP  int    `json:"current_hp"  example:"5"`
 	MaxHP      int    `json:"max_hp"      example:"7"`
 	ArmorClass int    `json:"armor_class" example:"15"`
 	IsAlive    bool   `json:"is_alive"    example:"true"`
 	Conditions []string `json:"conditions"`
+	Pos        types.Position `json:"pos"`
+	Range      int            `json:"range"       example:"2"`
 }
 
 // CombatGridView describes the 2D combat grid dimensions.
@@ -114,6 +136,7 @@ type CombatStateResponse struct {
 	Phase                string                  `json:"phase"                  example:"active"`
 	Round                int                     `json:"round"                  example:"1"`
 	Range                int                     `json:"range"                  example:"2"`
+	TargetID             string                  `json:"target_id,omitempty"    example:"goblin"`
 	Grid                 CombatGridView          `json:"grid"`
 	PlayerPos            types.Position          `json:"player_pos"`
 	MonsterPos           types.Position          `json:"monster_pos"`
@@ -121,7 +144,9 @@ type CombatStateResponse struct {
 	MovementSpent        int                     `json:"movement_spent"         example:"0"`
 	ActionUsed           bool                    `json:"action_used"            example:"false"`
 	BonusActionUsed      bool                    `json:"bonus_action_used"      example:"false"`
+	InteractionUsed      bool                    `json:"interaction_used"       example:"false"`
 	Disengaged           bool                    `json:"disengaged"             example:"false"`
+	Aiming               bool                    `json:"aiming"                 example:"false"`
 	ReactionUsed         bool                    `json:"reaction_used"          example:"false"`
 	MonsterMeleeReach    int                     `json:"monster_melee_reach"    example:"1"`
 	PlayerMeleeReach     int                     `json:"player_melee_reach"     example:"1"`
@@ -131,12 +156,18 @@ type CombatStateResponse struct {
 	Initiative           []types.InitiativeEntry `json:"initiative"`
 	Log                  []string                `json:"log"`
 	NewLog               []string                `json:"new_log,omitempty"`
+	Events               []types.CombatEvent     `json:"events,omitempty"`
 	XPEarned             int                     `json:"xp_earned"              example:"12"`
 	LootRolled           []types.LootDrop        `json:"loot_rolled,omitempty"`
 	LevelUpPending       bool                    `json:"level_up_pending"       example:"false"`
 	BonusAttackAvailable bool                    `json:"bonus_attack_available" example:"false"`
 	AmmoRemaining        int                     `json:"ammo_remaining"         example:"19"`
 	Difficulty           string                  `json:"difficulty,omitempty"   example:"tough"`
+	// LastAttack is the presentation hint for the attack this response resolved
+	// (weapon category, damage type, ranged/thrown) — only on /combat/action.
+	LastAttack *types.AttackPresentation `json:"last_attack,omitempty"`
+	// Revision is the save revision after this request, for the next one to echo.
+	Revision int `json:"revision" example:"43"`
 }
 
 // CombatEndResponse is returned when the player calls POST /combat/end.
@@ -147,12 +178,24 @@ type CombatEndResponse struct {
 	XPApplied   int                  `json:"xp_applied"            example:"47"`
 	LootAdded   []types.LootDrop     `json:"loot_added,omitempty"`
 	LootDropped []types.LootDrop     `json:"loot_dropped,omitempty"`
+	LootSkipped []types.LootDrop     `json:"loot_skipped,omitempty"` // left on the ground by the save's loot filter
+	LootHeavy   []types.LootDrop     `json:"loot_heavy,omitempty"`   // left on the ground as too heavy to carry
 	Message     string               `json:"message"               example:"You defeated the Goblin and gained 47 XP."`
 	LevelUp     *types.LevelUpResult `json:"level_up,omitempty"`
 	// POIResumed is the next POI node when this fight happened inside a POI walk
 	// (a monster node). The client reopens the exploration overlay on it. Nil for
 	// ordinary fights and on defeat.
 	POIResumed *poi.StepResult `json:"poi_resumed,omitempty"`
+	// Events is the same typed event stream action responses carry (level_up).
+	Events []types.GameEvent `json:"events,omitempty"`
+	// Permadeath is set on a hardcore defeat: the save has been deleted.
+	Permadeath bool `json:"permadeath,omitempty"`
+	// AmmoRecovered is how many fired rounds went back in the ammo slot;
+	// AmmoOnGround how many were left where the fight happened.
+	AmmoRecovered int `json:"ammo_recovered,omitempty" example:"3"`
+	AmmoOnGround  int `json:"ammo_on_ground,omitempty" example:"2"`
+	// Revision is the save revision after the results were applied (0 on permadeath).
+	Revision int `json:"revision" example:"43"`
 }
 
 // ─── Helpers ─────────────────────────────────────────────────────────────────
@@ -184,6 +227,8 @@ func conditionNames(conds []types.CombatCondition) []string {
 	return out
 }
 
+// buildStateResponse snapshots the fight for the client. It drains the session's
+// pending combat events, so each one is reported on exactly one response.
 func buildStateResponse(cs *types.CombatSession, save *types.SaveFile, newLog []string) CombatStateResponse {
 	player := CombatPlayerView{}
 	if len(cs.Party) > 0 {
@@ -199,6 +244,7 @@ func buildStateResponse(cs *types.CombatSession, save *types.SaveFile, newLog []
 			Conditions:         conditionNames(state.Conditions),
 			RageTurnsLeft:      state.RageTurnsLeft,
 			AbilitiesUsed:      state.AbilitiesUsed,
+			AbilityCooldowns:   state.AbilityCooldowns,
 		}
 		if state.Resource != nil {
 			player.Resource = &CombatResourceView{
@@ -221,15 +267,32 @@ func buildStateResponse(cs *types.CombatSession, save *types.SaveFile, newLog []
 	for _, m := range cs.Monsters {
 		monsters = append(monsters, CombatMonsterView{
 			InstanceID: m.InstanceID,
+			TemplateID: m.TemplateID,
 			Name:       m.Name,
 			CurrentHP:  m.CurrentHP,
 			MaxHP:      m.MaxHP,
 			ArmorClass: m.ArmorClass,
 			IsAlive:    m.IsAlive,
 			Conditions: conditionNames(m.Conditions),
+			Pos:        m.Pos,
+			Range:      combat.ChebyshevExported(cs.PlayerPos, m.Pos),
 		})
 	}
 
+	// Top-level range/monster_pos/monster_melee_reach stay pinned to the first
+	// monster so the grid sprite never jumps when the player switches targets;
+	// per-monster positions and ranges live in monsters[], the target in target_id.
+	var targetID string
+	if t := combat.TargetMonster(cs); t != nil {
+		targetID = t.InstanceID
+	}
+	var primaryPos types.Position
+	monsterReach := 0
+	if len(cs.Monsters) > 0 {
+		primaryPos = cs.Monsters[0].Pos
+		monsterReach = combat.MonsterMeleeReach(&cs.Monsters[0])
+	}
+
 	bonusAvail := false
 	ammoLeft := 0
 	if save != nil {
@@ -237,39 +300,44 @@ func buildStateResponse(cs *types.CombatSession, save *types.SaveFile, newLog []
 		ammoLeft = getAmmoRemaining(save.Inventory)
 	}
 
-	movBudget, movSpent, actionUsed, bonusUsed, disengaged, reactionUsed := 0, 0, false, false, false, false
+	movBudget, movSpent, actionUsed, bonusUsed, interactionUsed, disengaged, reactionUsed, aiming := 0, 0, false, false, false, false, false, false
 	if len(cs.Party) > 0 {
 		s := cs.Party[0].CombatState
 		movBudget = s.MovementBudget
 		movSpent = s.MovementSpent
 		actionUsed = s.ActionUsed
 		bonusUsed = s.BonusActionUsed
+		interactionUsed = s.InteractionUsed
 		disengaged = s.Disengaged
 		reactionUsed = s.ReactionUsed
+		aiming = s.Aiming
 	}
 
-	monsterReach := 0
-	if len(cs.Monsters) > 0 {
-		monsterReach = combat.MonsterMeleeReach(&cs.Monsters[0])
-	}
-	playerReach := 0
+	playerReach, revision := 0, 0
 	if save != nil {
 		playerReach = combat.PlayerMeleeReachForSave(serverdb.GetDB(), save)
+		revision = save.Revision
 	}
 
+	events := cs.Events
+	cs.Events = nil
+
 	return CombatStateResponse{
 		Success:              true,
 		Phase:                cs.Phase,
 		Round:                cs.Round,
-		Range:                combat.ChebyshevExported(cs.PlayerPos, cs.MonsterPos),
+		Range:                combat.ChebyshevExported(cs.PlayerPos, primaryPos),
+		TargetID:             targetID,
 		Grid:                 CombatGridView{Width: cs.GridWidth, Height: cs.GridHeight},
 		PlayerPos:            cs.PlayerPos,
-		MonsterPos:           cs.MonsterPos,
+		MonsterPos:           primaryPos,
 		MovementBudget:       movBudget,
 		MovementSpent:        movSpent,
 		ActionUsed:           actionUsed,
 		BonusActionUsed:      bonusUsed,
+		InteractionUsed:      interactionUsed,
 		Disengaged:           disengaged,
+		Aiming:               aiming,
 		ReactionUsed:         reactionUsed,
 		MonsterMeleeReach:    monsterReach,
 		PlayerMeleeReach:     playerReach,
@@ -279,18 +347,22 @@ func buildStateResponse(cs *types.CombatSession, save *types.SaveFile, newLog []
 		Initiative:           cs.Initiative,
 		Log:                  cs.Log,
 		NewLog:               newLog,
+		Events:               events,
 		XPEarned:             cs.XPEarnedThisFight,
 		LootRolled:           cs.LootRolled,
 		LevelUpPending:       cs.LevelUpPending,
 		BonusAttackAvailable: bonusAvail,
 		AmmoRemaining:        ammoLeft,
 		Difficulty:           cs.Difficulty,
+		LastAttack:           cs.LastAttack,
+		Revision:             revision,
 	}
 }
 
 // checkBonusAttackAvailable returns true when the player currently meets the
 // conditions for a two-weapon fighting bonus action: both hands hold light
-// weapons, and the bonus action has not yet been used this turn.
+// weapons (never a two-handed one), and the bonus action has not yet been used
+// this turn.
 func checkBonusAttackAvailable(cs *types.CombatSession, save *types.SaveFile) bool {
 	if len(cs.Party) == 0 || cs.Party[0].CombatState.BonusActionUsed {
 		return false
@@ -317,6 +389,8 @@ func checkBonusAttackAvailable(cs *types.CombatSession, save *types.SaveFile) bo
 
 	return itemHasTag(mainItem["tags"], "light") &&
 		itemHasTag(offItem["tags"], "light") &&
+		!itemHasTag(mainItem["tags"], "two-handed") &&
+		!itemHasTag(offItem["tags"], "two-handed") &&
 		!itemHasTag(mainItem["tags"], "loading")
 }
 
@@ -336,51 +410,109 @@ func getAmmoRemaining(inventory map[string]interface{}) int {
 	return 0
 }
 
-// addAmmoToSlot restores recovered ammunition to the ammo gear slot after victory.
-// If the slot is empty (ammo type unknown), no ammo is restored.
-func addAmmoToSlot(save *types.SaveFile, qty int) {
-	if qty <= 0 {
-		return
+// addAmmoToSlot puts up to qty recovered rounds of itemID back in the ammo gear
+// slot — into a quiver's contents when one is equipped (topping up the matching
+// stack, then empty slots), else onto the raw stack of the same ammo, or into
+// an empty ammo slot — capped at the item's stack limit. Returns how many fit.
+func addAmmoToSlot(save *types.SaveFile, itemID string, qty int) int {
+	if qty <= 0 || itemID == "" {
+		return 0
 	}
 	gearSlots, ok := save.Inventory["gear_slots"].(map[string]interface{})
 	if !ok {
-		return
+		return 0
+	}
+
+	// Cap at the item's stack limit
+	stackLimit := 0
+	if item, err := gamedata.LoadItemByID(serverdb.GetDB(), itemID); err == nil {
+		if limit, ok := item["stack"].(float64); ok && limit > 0 {
+			stackLimit = int(limit)
+		}
+	}
+	fill := func(slotMap map[string]interface{}, want int) int {
+		existing := int(slotFloat(slotMap, "quantity"))
+		room := want
+		if stackLimit > 0 && existing+room > stackLimit {
+			room = stackLimit - existing
+		}
+		if room <= 0 {
+			return 0
+		}
+		slotMap["item"] = itemID
+		slotMap["quantity"] = existing + room
+		return room
 	}
 
-	var ammoMap map[string]interface{}
-	var ammoKey string
 	for _, key := range []string{"ammunition", "ammo"} {
-		if slotData, exists := gearSlots[key]; exists && slotData != nil {
-			if slotMap, ok := slotData.(map[string]interface{}); ok {
-				if itemID, _ := slotMap["item"].(string); itemID != "" {
-					ammoMap = slotMap
-					ammoKey = key
-					break
+		slotData, exists := gearSlots[key]
+		if !exists {
+			continue
+		}
+		slotMap, _ := slotData.(map[string]interface{})
+		if slotMap == nil {
+			slotMap = map[string]interface{}{}
+		}
+		slotItem, _ := slotMap["item"].(string)
+
+		if contents, ok := slotMap["contents"].([]interface{}); ok {
+			added := 0
+			for _, pass := range []string{itemID, ""} {
+				for _, c := range contents {
+					round, ok := c.(map[string]interface{})
+					if !ok || added >= qty {
+						continue
+					}
+					if id, _ := round["item"].(string); id == pass {
+						added += fill(round, qty-added)
+					}
 				}
 			}
+			return added
 		}
+		if slotItem != itemID && slotItem != "" {
+			continue
+		}
+		added := fill(slotMap, qty)
+		gearSlots[key] = slotMap
+		return added
 	}
+	return 0
+}
 
-	if ammoMap == nil {
-		return // Cannot restore ammo when slot has no item type
+// recoverAmmo hands back the share of this fight's rounds the recovery rate
+// allows (into the ammo slot, see addAmmoToSlot). With drop_unrecovered set,
+// everything else fired — and anything that didn't fit — is left on the ground
+// where the fight happened. Returns the rounds recovered and the rounds left
+// on the ground.
+func recoverAmmo(save *types.SaveFile, cs *types.CombatSession, rate float64, dropUnrecovered bool) (recovered, grounded int) {
+	back, rest := combat.SplitAmmoRecovery(cs.AmmoUsedByItem, rate)
+	for _, share := range back {
+		added := addAmmoToSlot(save, share.Item, share.Quantity)
+		recovered += added
+		if added < share.Quantity {
+			rest = append(rest, combat.AmmoShare{Item: share.Item, Quantity: share.Quantity - added})
+		}
 	}
-
-	itemID, _ := ammoMap["item"].(string)
-	existing := int(slotFloat(ammoMap, "quantity"))
-	newQty := existing + qty
-
-	// Cap at the item's stack limit
-	item, err := gamedata.LoadItemByID(serverdb.GetDB(), itemID)
-	if err == nil {
-		if stackLimit, ok := item["stack"].(float64); ok && stackLimit > 0 {
-			if newQty > int(stackLimit) {
-				newQty = int(stackLimit)
-			}
+	if dropUnrecovered {
+		for _, share := range rest {
+			world.DropOnGround(save, share.Item, share.Quantity)
+			grounded += share.Quantity
 		}
 	}
+	return recovered, grounded
+}
 
-	ammoMap["quantity"] = newQty
-	gearSlots[ammoKey] = ammoMap
+// ammoRecoveryMessage describes a recovery for the end-of-combat message.
+func ammoRecoveryMessage(recovered, grounded int) string {
+	msg := ""
+	if recovered > 0 {
+		msg += fmt.Sprintf(" You recover %d round(s) of ammunition.", recovered)
+	}
+	if grounded > 0 {
+		msg += fmt.Sprintf(" %d round(s) are left on the ground.", grounded)
+	}
+	return msg
 }
 
 // itemHasTag checks whether a raw tag list ([]interface{}) contains a tag string.
@@ -407,16 +539,15 @@ func getEquippedIDMulti(inventory map[string]interface{}, keys ...string) string
 	return ""
 }
 
-// decodeBaseRequest decodes npub + save_id from the request body.
-func decodeBaseRequest(r *http.Request) (npub, saveID string, err error) {
-	var req CombatBaseRequest
+// decodeBaseRequest decodes npub + save_id (and the revision) from the request body.
+func decodeBaseRequest(r *http.Request) (req CombatBaseRequest, err error) {
 	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
-		return "", "", fmt.Errorf("invalid request body")
+		return req, fmt.Errorf("invalid request body")
 	}
 	if req.Npub == "" || req.SaveID == "" {
-		return "", "", fmt.Errorf("missing npub or save_id")
+		return req, fmt.Errorf("missing npub or save_id")
 	}
-	return req.Npub, req.SaveID, nil
+	return req, nil
 }
 
 // writeJSON writes a JSON response with the given status code.
@@ -435,7 +566,7 @@ func writeCombatError(w http.ResponseWriter, status int, msg string) {
 
 // StartCombatHandler godoc
 // @Summary      Start a combat encounter
-// @Description  Initialises a new combat session for the given monster and environment.
+// @Description  Initialises a new combat session for the given monster(s) and environment.
 //
 //	Combat state lives in server memory only and is never written to the save file.
 //	Returns the full initial combat state including initiative order and any opening
@@ -462,16 +593,28 @@ func StartCombatHandler(w http.ResponseWriter, r *http.Request) {
 		writeCombatError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
-	if req.Npub == "" || req.SaveID == "" || req.MonsterID == "" {
+	monsterIDs := req.MonsterIDs
+	if len(monsterIDs) == 0 && req.MonsterID != "" {
+		monsterIDs = []string{req.MonsterID}
+	}
+	if req.Npub == "" || req.SaveID == "" || len(monsterIDs) == 0 {
 		writeCombatError(w, http.StatusBadRequest, "Missing npub, save_id, or monster_id")
 		return
 	}
+	if len(monsterIDs) > combat.MaxEncounterMonsters {
+		writeCombatError(w, http.StatusBadRequest, fmt.Sprintf("At most %d monsters per encounter", combat.MaxEncounterMonsters))
+		return
+	}
 
 	sess, err := session.GetSessionManager().GetSession(req.Npub, req.SaveID)
 	if err != nil {
 		writeCombatError(w, http.StatusNotFound, "Session not found")
 		return
 	}
+	if !lockSaveChange(w, sess, req.Revision) {
+		return
+	}
+	defer sess.UnlockActions()
 	if sess.ActiveCombat != nil {
 		writeCombatError(w, http.StatusBadRequest, "Combat already in progress")
 		return
@@ -484,7 +627,7 @@ func StartCombatHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	cs, err := combat.StartCombat(serverdb.GetDB(), &sess.SaveData, req.Npub, req.MonsterID, req.EnvironmentID, advancement)
+	cs, err := combat.StartCombat(serverdb.GetDB(), &sess.SaveData, req.Npub, monsterIDs, req.EnvironmentID, advancement)
 	if err != nil {
 		log.Printf("❌ StartCombat: %v", err)
 		writeCombatError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to start combat: %v", err))
@@ -492,8 +635,9 @@ func StartCombatHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	sess.ActiveCombat = cs
-	log.Printf("⚔️  Combat started: npub=%s monster=%s env=%s", req.Npub, req.MonsterID, req.EnvironmentID)
+	log.Printf("⚔️  Combat started: npub=%s monsters=%s env=%s", req.Npub, strings.Join(monsterIDs, ","), req.EnvironmentID)
 
+	commitSaveChange(req.Npub, req.SaveID, sess)
 	resp := buildStateResponse(cs, &sess.SaveData, cs.Log)
 	// Spawn position is only meaningful on the very first response — clear it
 	// so subsequent state queries / rounds don't re-trigger the opening animation.
@@ -588,6 +732,10 @@ func CombatActionHandler(w http.ResponseWriter, r *http.Request) {
 		writeCombatError(w, http.StatusNotFound, err.Error())
 		return
 	}
+	if !lockSaveChange(w, sess, req.Revision) {
+		return
+	}
+	defer sess.UnlockActions()
 
 	advancement, err := loadAdvancement()
 	if err != nil {
@@ -598,7 +746,7 @@ func CombatActionHandler(w http.ResponseWriter, r *http.Request) {
 
 	cs := sess.ActiveCombat
 	roundLog, err := combat.ProcessPlayerAttack(
-		serverdb.GetDB(), cs, &sess.SaveData,
+		serverdb.GetDB(), cs, &sess.SaveData, req.TargetID,
 		req.WeaponSlot, req.Hand, req.Thrown, advancement,
 	)
 	if err != nil {
@@ -608,10 +756,12 @@ func CombatActionHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	cs.Log = append(cs.Log, roundLog...)
-	cs.Round++
 	roundLog = append(roundLog, maybeAutoEndTurn(cs, &sess.SaveData)...)
 
-	writeCombatJSON(w, http.StatusOK, buildStateResponse(cs, &sess.SaveData, roundLog))
+	commitSaveChange(req.Npub, req.SaveID, sess)
+	resp := buildStateResponse(cs, &sess.SaveData, roundLog)
+	cs.LastAttack = nil // reported once
+	writeCombatJSON(w, http.StatusOK, resp)
 }
 
 // ─── CombatCastHandler (M4 Phase B) ───────────────────────────────────────────
@@ -619,9 +769,11 @@ func CombatActionHandler(w http.ResponseWriter, r *http.Request) {
 // CombatCastRequest is the body sent to POST /combat/cast.
 // swagger:model CombatCastRequest
 type CombatCastRequest struct {
-	Npub    string `json:"npub"     example:"npub1..."`
-	SaveID  string `json:"save_id"  example:"save_1234567890"`
-	SpellID string `json:"spell_id" example:"fire-bolt"`
+	Npub     string `json:"npub"      example:"npub1..."`
+	SaveID   string `json:"save_id"   example:"save_1234567890"`
+	SpellID  string `json:"spell_id"  example:"fire-bolt"`
+	TargetID string `json:"target_id" example:"goblin"`
+	Revision int    `json:"revision"  example:"42"`
 }
 
 // CombatCastHandler resolves the player casting a prepared spell at the monster.
@@ -649,6 +801,10 @@ func CombatCastHandler(w http.ResponseWriter, r *http.Request) {
 		writeCombatError(w, http.StatusNotFound, err.Error())
 		return
 	}
+	if !lockSaveChange(w, sess, req.Revision) {
+		return
+	}
+	defer sess.UnlockActions()
 
 	advancement, err := loadAdvancement()
 	if err != nil {
@@ -658,16 +814,16 @@ func CombatCastHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	cs := sess.ActiveCombat
-	roundLog, err := combat.ProcessPlayerCast(serverdb.GetDB(), cs, &sess.SaveData, req.SpellID, advancement)
+	roundLog, err := combat.ProcessPlayerCast(serverdb.GetDB(), cs, &sess.SaveData, req.SpellID, req.TargetID, advancement)
 	if err != nil {
 		writeCombatError(w, http.StatusBadRequest, fmt.Sprintf("Cast error: %v", err))
 		return
 	}
 
 	cs.Log = append(cs.Log, roundLog...)
-	cs.Round++
 	roundLog = append(roundLog, maybeAutoEndTurn(cs, &sess.SaveData)...)
 
+	commitSaveChange(req.Npub, req.SaveID, sess)
 	writeCombatJSON(w, http.StatusOK, buildStateResponse(cs, &sess.SaveData, roundLog))
 }
 
@@ -676,14 +832,16 @@ func CombatCastHandler(w http.ResponseWriter, r *http.Request) {
 // CombatUseItemRequest is the body sent to POST /combat/use-item.
 // swagger:model CombatUseItemRequest
 type CombatUseItemRequest struct {
-	Npub   string `json:"npub"    example:"npub1..."`
-	SaveID string `json:"save_id" example:"save_1234567890"`
-	ItemID string `json:"item_id" example:"healing"`
+	Npub     string `json:"npub"     example:"npub1..."`
+	SaveID   string `json:"save_id"  example:"save_1234567890"`
+	ItemID   string `json:"item_id"  example:"healing"`
+	Revision int    `json:"revision" example:"42"`
 }
 
 // CombatUseItemHandler drinks a potion / uses a consumable during the fight and
 // auto-ends the turn if nothing meaningful remains. Healing lands on the combat
-// HP pool (see combat.ProcessPlayerUseItem).
+// HP pool (see combat.ProcessPlayerUseItem). During death saves it takes a
+// "revive" item instead of the roll, bringing the player back up.
 func CombatUseItemHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		writeCombatError(w, http.StatusMethodNotAllowed, "Method not allowed")
@@ -705,6 +863,10 @@ func CombatUseItemHandler(w http.ResponseWriter, r *http.Request) {
 		writeCombatError(w, http.StatusNotFound, err.Error())
 		return
 	}
+	if !lockSaveChange(w, sess, req.Revision) {
+		return
+	}
+	defer sess.UnlockActions()
 
 	cs := sess.ActiveCombat
 	roundLog, err := combat.ProcessPlayerUseItem(serverdb.GetDB(), cs, &sess.SaveData, req.ItemID)
@@ -714,9 +876,64 @@ func CombatUseItemHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	cs.Log = append(cs.Log, roundLog...)
-	cs.Round++
 	roundLog = append(roundLog, maybeAutoEndTurn(cs, &sess.SaveData)...)
 
+	commitSaveChange(req.Npub, req.SaveID, sess)
+	writeCombatJSON(w, http.StatusOK, buildStateResponse(cs, &sess.SaveData, roundLog))
+}
+
+// ─── CombatEquipHandler ───────────────────────────────────────────────────────
+
+// CombatEquipRequest is the body sent to POST /combat/equip.
+// swagger:model CombatEquipRequest
+type CombatEquipRequest struct {
+	Npub          string `json:"npub"           example:"npub1..."`
+	SaveID        string `json:"save_id"        example:"save_1234567890"`
+	ItemID        string `json:"item_id"        example:"longsword"`
+	EquipmentSlot string `json:"equipment_slot" example:"mainhand"` // optional; "" lets the inventory pick the hand
+	Revision      int    `json:"revision"       example:"42"`
+}
+
+// CombatEquipHandler swaps a weapon from a general slot into the player's hands
+// — the free object interaction the first time each turn, the action after that
+// (see combat.ProcessPlayerEquip) — and auto-ends the turn if nothing remains.
+func CombatEquipHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeCombatError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req CombatEquipRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeCombatError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Npub == "" || req.SaveID == "" || req.ItemID == "" {
+		writeCombatError(w, http.StatusBadRequest, "Missing npub, save_id, or item_id")
+		return
+	}
+
+	sess, err := getSessionAndCombat(req.Npub, req.SaveID)
+	if err != nil {
+		writeCombatError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if !lockSaveChange(w, sess, req.Revision) {
+		return
+	}
+	defer sess.UnlockActions()
+
+	cs := sess.ActiveCombat
+	roundLog, err := combat.ProcessPlayerEquip(serverdb.GetDB(), cs, &sess.SaveData, req.ItemID, req.EquipmentSlot)
+	if err != nil {
+		writeCombatError(w, http.StatusBadRequest, fmt.Sprintf("Equip error: %v", err))
+		return
+	}
+
+	cs.Log = append(cs.Log, roundLog...)
+	roundLog = append(roundLog, maybeAutoEndTurn(cs, &sess.SaveData)...)
+
+	commitSaveChange(req.Npub, req.SaveID, sess)
 	writeCombatJSON(w, http.StatusOK, buildStateResponse(cs, &sess.SaveData, roundLog))
 }
 
@@ -728,6 +945,7 @@ type CombatAbilityRequest struct {
 	Npub      string `json:"npub"       example:"npub1..."`
 	SaveID    string `json:"save_id"    example:"save_1234567890"`
 	AbilityID string `json:"ability_id" example:"enter-rage"`
+	Revision  int    `json:"revision"   example:"42"`
 }
 
 // CombatAbilityHandler resolves a martial class ability (Rage, Second Wind, Flurry,
@@ -755,6 +973,10 @@ func CombatAbilityHandler(w http.ResponseWriter, r *http.Request) {
 		writeCombatError(w, http.StatusNotFound, err.Error())
 		return
 	}
+	if !lockSaveChange(w, sess, req.Revision) {
+		return
+	}
+	defer sess.UnlockActions()
 
 	advancement, err := loadAdvancement()
 	if err != nil {
@@ -771,9 +993,9 @@ func CombatAbilityHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	cs.Log = append(cs.Log, roundLog...)
-	cs.Round++
 	roundLog = append(roundLog, maybeAutoEndTurn(cs, &sess.SaveData)...)
 
+	commitSaveChange(req.Npub, req.SaveID, sess)
 	writeCombatJSON(w, http.StatusOK, buildStateResponse(cs, &sess.SaveData, roundLog))
 }
 
@@ -803,9 +1025,13 @@ func CombatMoveHandler(w http.ResponseWriter, r *http.Request) {
 		writeCombatError(w, http.StatusNotFound, err.Error())
 		return
 	}
+	if !lockSaveChange(w, sess, req.Revision) {
+		return
+	}
+	defer sess.UnlockActions()
 
 	cs := sess.ActiveCombat
-	moveLog, err := combat.ProcessPlayerMove(serverdb.GetDB(), cs, &sess.SaveData, req.X, req.Y)
+	moveLog, err := combat.ProcessPlayerMove(serverdb.GetDB(), cs, &sess.SaveData, req.TargetID, req.X, req.Y)
 	if err != nil {
 		log.Printf("❌ CombatMove: %v", err)
 		writeCombatError(w, http.StatusBadRequest, fmt.Sprintf("Combat error: %v", err))
@@ -815,6 +1041,7 @@ func CombatMoveHandler(w http.ResponseWriter, r *http.Request) {
 	cs.Log = append(cs.Log, moveLog...)
 	moveLog = append(moveLog, maybeAutoEndTurn(cs, &sess.SaveData)...)
 
+	commitSaveChange(req.Npub, req.SaveID, sess)
 	writeCombatJSON(w, http.StatusOK, buildStateResponse(cs, &sess.SaveData, moveLog))
 }
 
@@ -843,6 +1070,10 @@ func CombatEndTurnHandler(w http.ResponseWriter, r *http.Request) {
 		writeCombatError(w, http.StatusNotFound, err.Error())
 		return
 	}
+	if !lockSaveChange(w, sess, req.Revision) {
+		return
+	}
+	defer sess.UnlockActions()
 
 	cs := sess.ActiveCombat
 	roundLog, err := combat.ProcessEndTurn(serverdb.GetDB(), cs, &sess.SaveData)
@@ -852,8 +1083,8 @@ func CombatEndTurnHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	cs.Log = append(cs.Log, roundLog...)
-	cs.Round++
 
+	commitSaveChange(req.Npub, req.SaveID, sess)
 	writeCombatJSON(w, http.StatusOK, buildStateResponse(cs, &sess.SaveData, roundLog))
 }
 
@@ -883,6 +1114,10 @@ func CombatHoldHandler(w http.ResponseWriter, r *http.Request) {
 		writeCombatError(w, http.StatusNotFound, err.Error())
 		return
 	}
+	if !lockSaveChange(w, sess, req.Revision) {
+		return
+	}
+	defer sess.UnlockActions()
 
 	cs := sess.ActiveCombat
 	roundLog, err := combat.ProcessPlayerHold(cs)
@@ -894,6 +1129,47 @@ func CombatHoldHandler(w http.ResponseWriter, r *http.Request) {
 	cs.Log = append(cs.Log, roundLog...)
 	roundLog = append(roundLog, maybeAutoEndTurn(cs, &sess.SaveData)...)
 
+	commitSaveChange(req.Npub, req.SaveID, sess)
+	writeCombatJSON(w, http.StatusOK, buildStateResponse(cs, &sess.SaveData, roundLog))
+}
+
+// CombatAimHandler spends the player's bonus action and the turn's movement to
+// steady a ranged weapon: the next ranged attack rolls with advantage. The turn
+// stays open so the player can take the shot.
+func CombatAimHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeCombatError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	var req CombatBaseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeCombatError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Npub == "" || req.SaveID == "" {
+		writeCombatError(w, http.StatusBadRequest, "Missing npub or save_id")
+		return
+	}
+
+	sess, err := getSessionAndCombat(req.Npub, req.SaveID)
+	if err != nil {
+		writeCombatError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if !lockSaveChange(w, sess, req.Revision) {
+		return
+	}
+	defer sess.UnlockActions()
+
+	cs := sess.ActiveCombat
+	roundLog, err := combat.ProcessPlayerAim(serverdb.GetDB(), cs, &sess.SaveData)
+	if err != nil {
+		writeCombatError(w, http.StatusBadRequest, fmt.Sprintf("Combat error: %v", err))
+		return
+	}
+
+	cs.Log = append(cs.Log, roundLog...)
+	commitSaveChange(req.Npub, req.SaveID, sess)
 	writeCombatJSON(w, http.StatusOK, buildStateResponse(cs, &sess.SaveData, roundLog))
 }
 
@@ -919,6 +1195,10 @@ func CombatDisengageHandler(w http.ResponseWriter, r *http.Request) {
 		writeCombatError(w, http.StatusNotFound, err.Error())
 		return
 	}
+	if !lockSaveChange(w, sess, req.Revision) {
+		return
+	}
+	defer sess.UnlockActions()
 
 	cs := sess.ActiveCombat
 	roundLog, err := combat.ProcessPlayerDisengage(cs)
@@ -930,12 +1210,13 @@ func CombatDisengageHandler(w http.ResponseWriter, r *http.Request) {
 	cs.Log = append(cs.Log, roundLog...)
 	roundLog = append(roundLog, maybeAutoEndTurn(cs, &sess.SaveData)...)
 
+	commitSaveChange(req.Npub, req.SaveID, sess)
 	writeCombatJSON(w, http.StatusOK, buildStateResponse(cs, &sess.SaveData, roundLog))
 }
 
 // shouldAutoEndTurn reports whether the player has no meaningful moves left.
 // True when: action used AND movement fully spent AND (bonus already used OR
-// bonus attack not available). The end-turn handler callers use this to decide
+// neither a bonus attack nor a bonus-action item is available). The end-turn handler callers use this to decide
 // whether to run ProcessEndTurn before serialising the response.
 func shouldAutoEndTurn(cs *types.CombatSession, save *types.SaveFile) bool {
 	if cs.Phase != "active" || len(cs.Party) == 0 {
@@ -951,7 +1232,7 @@ func shouldAutoEndTurn(cs *types.CombatSession, save *types.SaveFile) bool {
 	if state.BonusActionUsed {
 		return true
 	}
-	return !checkBonusAttackAvailable(cs, save)
+	return !checkBonusAttackAvailable(cs, save) && !combat.QuickItemAvailable(serverdb.GetDB(), cs, save)
 }
 
 // maybeAutoEndTurn runs the monster response if the player is out of meaningful
@@ -973,8 +1254,8 @@ func maybeAutoEndTurn(cs *types.CombatSession, save *types.SaveFile) []string {
 // ─── CombatFleeHandler ────────────────────────────────────────────────────────
 
 // CombatFleeHandler attempts a flee roll using the formula from §18 of the combat plan.
-// Requires range ≥ 3. On success the combat ends (phase → "loot", empty loot).
-// On failure the monster still responds and combat continues.
+// Requires range ≥ 3. On success the combat ends (phase → "fled": no XP, no loot).
+// On failure the monsters respond at once and combat continues.
 func CombatFleeHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		writeCombatError(w, http.StatusMethodNotAllowed, "Method not allowed")
@@ -995,17 +1276,21 @@ func CombatFleeHandler(w http.ResponseWriter, r *http.Request) {
 		writeCombatError(w, http.StatusNotFound, err.Error())
 		return
 	}
+	if !lockSaveChange(w, sess, req.Revision) {
+		return
+	}
+	defer sess.UnlockActions()
 
 	cs := sess.ActiveCombat
-	roundLog, err := combat.ProcessPlayerFlee(cs, &sess.SaveData)
+	roundLog, err := combat.ProcessPlayerFlee(serverdb.GetDB(), cs, &sess.SaveData)
 	if err != nil {
 		writeCombatError(w, http.StatusBadRequest, fmt.Sprintf("Combat error: %v", err))
 		return
 	}
 
 	cs.Log = append(cs.Log, roundLog...)
-	roundLog = append(roundLog, maybeAutoEndTurn(cs, &sess.SaveData)...)
 
+	commitSaveChange(req.Npub, req.SaveID, sess)
 	writeCombatJSON(w, http.StatusOK, buildStateResponse(cs, &sess.SaveData, roundLog))
 }
 
@@ -1034,17 +1319,22 @@ func CombatDeathSaveHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	npub, saveID, err := decodeBaseRequest(r)
+	req, err := decodeBaseRequest(r)
 	if err != nil {
 		writeCombatError(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	npub, saveID := req.Npub, req.SaveID
 
 	sess, err := getSessionAndCombat(npub, saveID)
 	if err != nil {
 		writeCombatError(w, http.StatusNotFound, err.Error())
 		return
 	}
+	if !lockSaveChange(w, sess, req.Revision) {
+		return
+	}
+	defer sess.UnlockActions()
 
 	cs := sess.ActiveCombat
 	if cs.Phase != "death_saves" {
@@ -1055,8 +1345,8 @@ func CombatDeathSaveHandler(w http.ResponseWriter, r *http.Request) {
 
 	roundLog := combat.ProcessDeathSave(cs, &sess.SaveData)
 	cs.Log = append(cs.Log, roundLog...)
-	cs.Round++
 
+	commitSaveChange(req.Npub, req.SaveID, sess)
 	writeCombatJSON(w, http.StatusOK, buildStateResponse(cs, &sess.SaveData, roundLog))
 }
 
@@ -1067,7 +1357,7 @@ func CombatDeathSaveHandler(w http.ResponseWriter, r *http.Request) {
 // @Description  Resolves the outcome of the combat encounter and applies changes to the
 //
 //	player's save data in session memory. Must be called after combat reaches
-//	a terminal phase ("loot", "victory", or "defeat").
+//	a terminal phase ("loot", "victory", "fled", or "defeat").
 //
 //	Victory: Applies earned XP to session, adds loot to inventory, and updates
 //	the player's HP to reflect damage taken during combat. If level_up_pending
@@ -1079,7 +1369,9 @@ func CombatDeathSaveHandler(w http.ResponseWriter, r *http.Request) {
 //	and mana to full, and returns the player to their starting location. XP and
 //	level are preserved. The frontend should show the death screen.
 //
-//	In both cases the active combat is cleared from session memory when this
+//	Fled: Updates the player's HP only — fleeing forfeits the fight's XP and loot.
+//
+//	In every case the active combat is cleared from session memory when this
 //	endpoint returns successfully.
 //
 // @Tags         Combat
@@ -1097,41 +1389,58 @@ func CombatEndHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	npub, saveID, err := decodeBaseRequest(r)
+	req, err := decodeBaseRequest(r)
 	if err != nil {
 		writeCombatError(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	npub, saveID := req.Npub, req.SaveID
 
 	sess, err := getSessionAndCombat(npub, saveID)
 	if err != nil {
 		writeCombatError(w, http.StatusNotFound, err.Error())
 		return
 	}
+	if !lockSaveChange(w, sess, req.Revision) {
+		return
+	}
+	defer sess.UnlockActions()
 
 	cs := sess.ActiveCombat
-	terminalPhases := map[string]bool{"loot": true, "victory": true, "defeat": true}
+	terminalPhases := map[string]bool{"loot": true, "victory": true, "defeat": true, "fled": true}
 	if !terminalPhases[cs.Phase] {
 		writeCombatError(w, http.StatusBadRequest,
 			fmt.Sprintf("Cannot end combat: phase is %q — combat must reach a terminal phase first", cs.Phase))
 		return
 	}
 
+	// Journal the fight before the outcome moves the player (a defeat respawns
+	// them elsewhere).
+	record := combat.RecordEncounter(&sess.SaveData, cs)
+
 	var resp CombatEndResponse
 
-	if cs.Phase == "defeat" {
+	switch cs.Phase {
+	case "defeat":
 		resp = applyDefeatOutcome(sess, cs)
-	} else {
+	case "fled":
+		resp = applyFledOutcome(sess, cs)
+	default:
 		resp = applyVictoryOutcome(sess, cs)
 	}
 
+	// Keep the log too for /combat/history — it goes with the combat session
+	// otherwise.
+	sess.RecentFights = combat.RememberFight(sess.RecentFights, record, cs.Log)
+
 	sess.ActiveCombat = nil
 
 	// If this fight happened inside a POI walk, bridge back: defeat ends the walk
-	// (the player is teleported home), victory resumes it at the node past the
-	// monster so the client can reopen the exploration overlay.
+	// (the player is teleported home) and so does fleeing (the player ran out of
+	// it); victory resumes it at the node past the monster so the client can
+	// reopen the exploration overlay.
 	if sess.ActivePOI != nil {
-		if resp.Outcome == "defeat" {
+		if resp.Outcome == "defeat" || resp.Outcome == "fled" {
 			sess.ActivePOI = nil
 		} else {
 			resp.POIResumed = resumePOIAfterVictory(sess)
@@ -1145,11 +1454,113 @@ func CombatEndHandler(w http.ResponseWriter, r *http.Request) {
 	// inventory diff against a pre-loot baseline.
 	sess.InitializeSnapshot()
 
+	resp.Revision = commitSaveChange(npub, saveID, sess)
 	log.Printf("✅ Combat ended: npub=%s outcome=%s xp=%d", npub, resp.Outcome, resp.XPApplied)
 
 	writeCombatJSON(w, http.StatusOK, resp)
 }
 
+// ─── CombatHistoryHandler ─────────────────────────────────────────────────────
+
+// CombatHistoryResponse is returned by GET /game/combat-history.
+type CombatHistoryResponse struct {
+	Success    bool                    `json:"success"      example:"true"`
+	Encounters []types.EncounterRecord `json:"encounters"`
+}
+
+// CombatHistoryHandler godoc
+// @Summary      Get the encounter history
+// @Description  Returns the save's finished fights, oldest first: monsters fought,
+//
+//	outcome ("victory", "escaped", "defeat"), rounds, XP and loot. Capped at
+//	the most recent combat.MaxEncounterHistory encounters.
+//
+// @Tags         Combat
+// @Produce      json
+// @Param        npub     query     string                 true  "Nostr public key"
+// @Param        save_id  query     string                 true  "Save ID"
+// @Success      200      {object}  CombatHistoryResponse        "Encounter history"
+// @Failure      400      {string}  string                       "Missing parameters"
+// @Failure      404      {string}  string                       "Session not found"
+// @Failure      405      {string}  string                       "Method not allowed"
+// @Router       /game/combat-history [get]
+func CombatHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeCombatError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	npub := r.URL.Query().Get("npub")
+	saveID := r.URL.Query().Get("save_id")
+	if npub == "" || saveID == "" {
+		writeCombatError(w, http.StatusBadRequest, "Missing npub or save_id")
+		return
+	}
+
+	sess, err := session.GetSessionManager().GetSession(npub, saveID)
+	if err != nil {
+		writeCombatError(w, http.StatusNotFound, "session not found")
+		return
+	}
+
+	encounters := sess.SaveData.CombatHistory
+	if encounters == nil {
+		encounters = []types.EncounterRecord{}
+	}
+	writeCombatJSON(w, http.StatusOK, CombatHistoryResponse{Success: true, Encounters: encounters})
+}
+
+// ─── CombatLogHistoryHandler ──────────────────────────────────────────────────
+
+// CombatLogHistoryResponse is returned by GET /combat/history.
+type CombatLogHistoryResponse struct {
+	Success bool             `json:"success" example:"true"`
+	Fights  []types.FightLog `json:"fights"`
+}
+
+// CombatLogHistoryHandler godoc
+// @Summary      Get recent fights with their combat logs
+// @Description  Returns the session's last combat.MaxRecentFights finished fights,
+//
+//	newest first: the encounter record (monsters, outcome, rounds, XP, loot)
+//	and the full round-by-round log. Session-only — the log is gone once the
+//	session unloads; /game/combat-history keeps the compact records.
+//
+// @Tags         Combat
+// @Produce      json
+// @Param        npub     query     string                    true  "Nostr public key"
+// @Param        save_id  query     string                    true  "Save ID"
+// @Success      200      {object}  CombatLogHistoryResponse        "Recent fights"
+// @Failure      400      {string}  string                          "Missing parameters"
+// @Failure      404      {string}  string                          "Session not found"
+// @Failure      405      {string}  string                          "Method not allowed"
+// @Router       /combat/history [get]
+func CombatLogHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeCombatError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	npub := r.URL.Query().Get("npub")
+	saveID := r.URL.Query().Get("save_id")
+	if npub == "" || saveID == "" {
+		writeCombatError(w, http.StatusBadRequest, "Missing npub or save_id")
+		return
+	}
+
+	sess, err := session.GetSessionManager().GetSession(npub, saveID)
+	if err != nil {
+		writeCombatError(w, http.StatusNotFound, "session not found")
+		return
+	}
+
+	fights := make([]types.FightLog, 0, len(sess.RecentFights))
+	for i := len(sess.RecentFights) - 1; i >= 0; i-- {
+		fights = append(fights, sess.RecentFights[i])
+	}
+	writeCombatJSON(w, http.StatusOK, CombatLogHistoryResponse{Success: true, Fights: fights})
+}
+
 // applyVictoryOutcome applies XP + loot to the session and returns the response.
 func applyVictoryOutcome(sess *session.GameSession, cs *types.CombatSession) CombatEndResponse {
 	save := &sess.SaveData
@@ -1172,16 +1583,28 @@ func applyVictoryOutcome(sess *session.GameSession, cs *types.CombatSession) Com
 		save.Experience += cs.XPEarnedThisFight
 	}
 
-	// Recover 50% of ammo used this combat (floor)
-	if cs.AmmoUsedThisCombat > 0 {
-		recovered := cs.AmmoUsedThisCombat / 2
-		if recovered > 0 {
-			addAmmoToSlot(save, recovered)
+	// Recover a share of the ammo fired this combat (combat.ammo_recovery)
+	ammoRules := combat.LoadAmmoRecovery(serverdb.GetDB())
+	ammoRecovered, ammoGrounded := recoverAmmo(save, cs, ammoRules.VictoryRate, ammoRules.DropUnrecovered)
+
+	// Add loot to inventory — except what the save's loot filter turns down,
+	// which is left on the ground where the fight happened.
+	wanted, skipped := combat.FilterLoot(serverdb.GetDB(), save.LootFilter, cs.LootRolled)
+	for _, drop := range skipped {
+		world.DropOnGround(save, drop.Item, drop.Quantity)
+	}
+	// Under the "block" loot policy what would take the player over their
+	// carrying capacity stays on the ground too; otherwise it is carried and the
+	// encumbrance penalties catch up straight away.
+	var heavy []types.LootDrop
+	if status.WeightPolicy("loot") == "block" {
+		wanted, heavy = weighLoot(save, wanted)
+		for _, drop := range heavy {
+			world.DropOnGround(save, drop.Item, drop.Quantity)
 		}
 	}
-
-	// Add loot to inventory
-	placed, overflow := addLootToInventory(save.Inventory, cs.LootRolled)
+	placed, overflow := addLootToInventory(save.Inventory, wanted)
+	status.HandleEncumbranceChange(save)
 
 	msg := fmt.Sprintf("You are victorious! +%d XP.", cs.XPEarnedThisFight)
 	if len(placed) > 0 {
@@ -1190,6 +1613,13 @@ func applyVictoryOutcome(sess *session.GameSession, cs *types.CombatSession) Com
 	if len(overflow) > 0 {
 		msg += fmt.Sprintf(" %d item type(s) had no space and were lost.", len(overflow))
 	}
+	if len(skipped) > 0 {
+		msg += fmt.Sprintf(" %d item type(s) left on the ground by your loot filter.", len(skipped))
+	}
+	if len(heavy) > 0 {
+		msg += fmt.Sprintf(" %d item type(s) too heavy to carry were left on the ground.", len(heavy))
+	}
+	msg += ammoRecoveryMessage(ammoRecovered, ammoGrounded)
 
 	resp := CombatEndResponse{
 		Success:     true,
@@ -1197,11 +1627,21 @@ func applyVictoryOutcome(sess *session.GameSession, cs *types.CombatSession) Com
 		XPApplied:   cs.XPEarnedThisFight,
 		LootAdded:   placed,
 		LootDropped: overflow,
+		LootSkipped: skipped,
+		LootHeavy:   heavy,
 		Message:     msg,
+
+		AmmoRecovered: ammoRecovered,
+		AmmoOnGround:  ammoGrounded,
 	}
 	if levelUp.Leveled {
 		resp.LevelUp = &levelUp
 		resp.Message += fmt.Sprintf(" You reached level %d!", levelUp.NewLevel)
+		resp.Events = append(resp.Events, types.GameEvent{
+			Type:    types.EventLevelUp,
+			Message: fmt.Sprintf("You reached level %d!", levelUp.NewLevel),
+			Data:    map[string]interface{}{"old_level": levelUp.OldLevel, "new_level": levelUp.NewLevel},
+		})
 	}
 	return resp
 }
@@ -1215,7 +1655,15 @@ func applyDefeatOutcome(sess *session.GameSession, cs *types.CombatSession) Comb
 	save.Experience += cs.XPEarnedThisFight
 
 	// Strip inventory + restore vitals + return home (shared with non-combat deaths).
-	kept := ApplyDeath(save)
+	kept, permadeath := ApplyDeath(sess)
+	if permadeath {
+		return CombatEndResponse{
+			Success:    true,
+			Outcome:    "defeat",
+			Message:    hardcoreDeathMessage,
+			Permadeath: true,
+		}
+	}
 
 	msg := fmt.Sprintf(
 		"You have fallen. You wake in %s, stripped of your belongings. XP and level are preserved.",
@@ -1231,12 +1679,53 @@ func applyDefeatOutcome(sess *session.GameSession, cs *types.CombatSession) Comb
 	}
 }
 
-// ApplyDeath runs the on-death consequences on the save alone (no combat session):
-// keep the 3 most valuable items, restore vitals to full, and return the player to
-// their racial starting city. Shared by combat defeat and out-of-combat deaths
-// (POI/environment damage, starvation) so death behaves identically everywhere.
-func ApplyDeath(save *types.SaveFile) []types.LootDrop {
-	kept := stripInventoryForDeath(save.Inventory)
+// applyFledOutcome carries the player's combat HP back to the save. Fleeing
+// forfeits the fight: no XP and no loot, and only the flee share of the ammo
+// fired is grabbed on the way out.
+func applyFledOutcome(sess *session.GameSession, cs *types.CombatSession) CombatEndResponse {
+	save := &sess.SaveData
+	if len(cs.Party) > 0 {
+		save.HP = cs.Party[0].CombatState.CurrentHP
+		if save.HP < 1 {
+			save.HP = 1
+		}
+	}
+	ammoRules := combat.LoadAmmoRecovery(serverdb.GetDB())
+	ammoRecovered, ammoGrounded := recoverAmmo(save, cs, ammoRules.FleeRate, ammoRules.DropUnrecovered)
+	return CombatEndResponse{
+		Success:       true,
+		Outcome:       "fled",
+		Message:       "You got away. Nothing gained, but you live to fight another day." + ammoRecoveryMessage(ammoRecovered, ammoGrounded),
+		AmmoRecovered: ammoRecovered,
+		AmmoOnGround:  ammoGrounded,
+	}
+}
+
+// hardcoreDeathMessage tells the player their hardcore run is over.
+const hardcoreDeathMessage = "You have fallen, and in hardcore there is no waking. Your save is gone."
+
+// ApplyDeath runs the on-death consequences on the session's save (no combat
+// session): the configured death penalty (LoadDeathPenalty — by default keep the
+// 3 most valuable items), vitals restored to full, and a return to their racial
+// starting city. Shared by combat defeat and out-of-combat deaths (POI/environment
+// damage, starvation) so death behaves identically everywhere.
+//
+// In hardcore mode the save is deleted instead and permadeath is true: the
+// session is unloaded, so callers must not write it back. If the delete fails
+// the session is still loaded, and the standard penalty applies in its place.
+func ApplyDeath(sess *session.GameSession) (kept []types.LootDrop, permadeath bool) {
+	penalty := LoadDeathPenalty()
+	if penalty.Hardcore {
+		err := session.DeleteSave(sess.Npub, sess.SaveID)
+		if err == nil {
+			log.Printf("💀 Hardcore death: deleted save %s for %s", sess.SaveID, sess.Npub)
+			return nil, true
+		}
+		log.Printf("❌ Hardcore death: failed to delete save %s for %s, applying the standard penalty: %v", sess.SaveID, sess.Npub, err)
+	}
+
+	save := &sess.SaveData
+	kept = applyDeathPenalty(save, penalty)
 	save.HP = save.MaxHP
 	save.Mana = save.MaxMana
 	save.Location = deathReturnLocation(save)
@@ -1244,12 +1733,19 @@ func ApplyDeath(save *types.SaveFile) []types.LootDrop {
 	save.Building = ""
 	save.TravelProgress = 0
 	save.TravelStopped = false
-	return kept
+	effects.ClearStaleScopedEffects(save)
+	return kept, false
 }
 
-// deathReturnLocation returns the player's racial starting city.
-// Falls back to the first discovered location, then to "kingdom".
+// deathReturnLocation returns the player's racial starting city
+// (starting-locations.json). Only when that lookup fails does it fall back to
+// the first discovered location, then to "kingdom".
 func deathReturnLocation(save *types.SaveFile) string {
+	city, err := gamedata.LoadStartingCity(serverdb.GetDB(), save.Race)
+	if err == nil {
+		return city
+	}
+	log.Printf("⚠️ Death return: %v — falling back", err)
 	if len(save.LocationsDiscovered) > 0 {
 		return save.LocationsDiscovered[0]
 	}
@@ -1266,10 +1762,20 @@ func deathReturnLocation(save *types.SaveFile) string {
 //
 // Stack limits are read from the items DB (item["stack"]). If absent or 1, the
 // item is treated as unstackable — extras spill into a fresh slot rather than
-// piling onto the same entry. Anything that still doesn't fit ends up in overflow.
+// piling onto the same entry. New stacks only go into slots within the current
+// capacity (general slots are padded out to it; backpack slots left past a
+// smaller bag's capacity stay closed). Anything that still doesn't fit ends up
+// in overflow.
 func addLootToInventory(inventory map[string]interface{}, loot []types.LootDrop) (placed, overflow []types.LootDrop) {
 	generalSlots, _ := inventory["general_slots"].([]interface{})
+	generalCapacity := gaminventory.GeneralSlotCount()
+	if generalSlots != nil {
+		for i := len(generalSlots); i < generalCapacity; i++ {
+			generalSlots = append(generalSlots, map[string]interface{}{"item": nil, "quantity": 0, "slot": i})
+		}
+	}
 	bagSlots := getBagContents(inventory)
+	bagCapacity := gaminventory.BackpackSlotCount(inventory)
 
 	for _, drop := range loot {
 		stackLimit := lookupItemStackLimit(drop.Item)
@@ -1285,19 +1791,19 @@ func addLootToInventory(inventory map[string]interface{}, loot []types.LootDrop)
 		}
 		// 3) Empty general_slots entries
 		if remaining > 0 && generalSlots != nil {
-			remaining = placeInEmpty(generalSlots, drop.Item, remaining, stackLimit)
+			remaining = placeInEmpty(generalSlots, generalCapacity, drop.Item, remaining, stackLimit)
 		}
 		// 4) Empty bag entries
 		if remaining > 0 && bagSlots != nil {
-			remaining = placeInEmpty(bagSlots, drop.Item, remaining, stackLimit)
+			remaining = placeInEmpty(bagSlots, bagCapacity, drop.Item, remaining, stackLimit)
 		}
 
 		placedQty := drop.Quantity - remaining
 		if placedQty > 0 {
-			placed = append(placed, types.LootDrop{Item: drop.Item, Quantity: placedQty})
+			placed = append(placed, types.LootDrop{Item: drop.Item, Quantity: placedQty, Rarity: drop.Rarity})
 		}
 		if remaining > 0 {
-			overflow = append(overflow, types.LootDrop{Item: drop.Item, Quantity: remaining})
+			overflow = append(overflow, types.LootDrop{Item: drop.Item, Quantity: remaining, Rarity: drop.Rarity})
 		}
 	}
 
@@ -1310,6 +1816,32 @@ func addLootToInventory(inventory map[string]interface{}, loot []types.LootDrop)
 	return placed, overflow
 }
 
+// weighLoot splits loot into what fits under the player's remaining carrying
+// capacity, filled in drop order, and what is too heavy to take.
+func weighLoot(save *types.SaveFile, loot []types.LootDrop) (carried, heavy []types.LootDrop) {
+	room := status.CalculateWeightCapacity(save) - status.CalculateTotalWeight(save)
+	for _, drop := range loot {
+		fit := drop.Quantity
+		if weight := status.GetItemWeight(drop.Item); weight > 0 {
+			fit = 0
+			if room > 0 {
+				fit = int(room / weight)
+			}
+			if fit > drop.Quantity {
+				fit = drop.Quantity
+			}
+			room -= float64(fit) * weight
+		}
+		if fit > 0 {
+			carried = append(carried, types.LootDrop{Item: drop.Item, Quantity: fit, Rarity: drop.Rarity})
+		}
+		if fit < drop.Quantity {
+			heavy = append(heavy, types.LootDrop{Item: drop.Item, Quantity: drop.Quantity - fit, Rarity: drop.Rarity})
+		}
+	}
+	return carried, heavy
+}
+
 // getBagContents returns the backpack's contents slice, or nil if no bag is equipped.
 func getBagContents(inventory map[string]interface{}) []interface{} {
 	gearSlots, ok := inventory["gear_slots"].(map[string]interface{})
@@ -1385,11 +1917,11 @@ func stackOntoMatching(slots []interface{}, itemID string, qty, stackLimit int)
 	return qty
 }
 
-// placeInEmpty drops the remaining quantity into empty slots, respecting stack
-// limits. Returns the leftover quantity that didn't fit.
-func placeInEmpty(slots []interface{}, itemID string, qty, stackLimit int) int {
+// placeInEmpty drops the remaining quantity into empty slots among the first
+// capacity, respecting stack limits. Returns the leftover quantity that didn't fit.
+func placeInEmpty(slots []interface{}, capacity int, itemID string, qty, stackLimit int) int {
 	for i, slot := range slots {
-		if qty == 0 {
+		if qty == 0 || i >= capacity {
 			break
 		}
 		if slot != nil {
@@ -1406,6 +1938,7 @@ func placeInEmpty(slots []interface{}, itemID string, qty, stackLimit int) int {
 		slots[i] = map[string]interface{}{
 			"item":     itemID,
 			"quantity": take,
+			"slot":     i,
 		}
 		qty -= take
 	}
@@ -1413,17 +1946,35 @@ func placeInEmpty(slots []interface{}, itemID string, qty, stackLimit int) int {
 }
 
 // stripInventoryForDeath flattens all inventory into individual units, keeps the
-// 3 most valuable (by item cost), clears everything else, and returns the kept items.
-func stripInventoryForDeath(inventory map[string]interface{}) []types.LootDrop {
+// keep most valuable (by item cost), clears everything else, and returns the kept
+// items. With loseGold false all gold is kept too, on top of those; otherwise
+// gold is just another item.
+func stripInventoryForDeath(inventory map[string]interface{}, keep int, loseGold bool) []types.LootDrop {
 	units := collectItemUnits(inventory)
 
+	gold := 0
+	if !loseGold {
+		var ranked []itemUnit
+		for _, u := range units {
+			if u.itemID == "gold-piece" {
+				gold++
+			} else {
+				ranked = append(ranked, u)
+			}
+		}
+		units = ranked
+	}
+
 	sort.Slice(units, func(i, j int) bool {
 		return units[i].cost > units[j].cost
 	})
 
-	top3 := mergeUnitsIntoDrops(units, 3)
+	kept := mergeUnitsIntoDrops(units, keep)
+	if gold > 0 {
+		kept = append(kept, types.LootDrop{Item: "gold-piece", Quantity: gold})
+	}
 	clearEntireInventory(inventory)
-	placeItemsInGeneralSlots(inventory, top3)
+	placeItemsInGeneralSlots(inventory, kept)
 
 	// Diagnostic: pin whether "only a backpack on death" is a strip bug (units
 	// collected but not kept) or an already-empty inventory (loot lost earlier).
@@ -1434,9 +1985,9 @@ func stripInventoryForDeath(inventory map[string]interface{}) []types.LootDrop {
 		}
 		sample = append(sample, fmt.Sprintf("%s(%.0f)", u.itemID, u.cost))
 	}
-	log.Printf("💀 death strip: %d units collected %v → kept top %d %+v", len(units), sample, len(top3), top3)
+	log.Printf("💀 death strip: %d units collected %v → kept %d %+v", len(units), sample, len(kept), kept)
 
-	return top3
+	return kept
 }
 
 // itemUnit is an individual item instance with its looked-up cost.
@@ -1586,7 +2137,7 @@ func placeItemsInGeneralSlots(inventory map[string]interface{}, drops []types.Lo
 	}
 	for _, drop := range drops {
 		limit := lookupItemStackLimit(drop.Item)
-		placeInEmpty(slots, drop.Item, drop.Quantity, limit) // spills overflow across slots
+		placeInEmpty(slots, len(slots), drop.Item, drop.Quantity, limit) // spills overflow across slots
 	}
 	inventory["general_slots"] = slots
 }
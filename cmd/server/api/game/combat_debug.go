@@ -23,6 +23,7 @@ type debugCombatRequest struct {
 	Npub      string `json:"npub"`
 	SaveID    string `json:"save_id"`
 	MonsterID string `json:"monster_id"` // optional; empty = random pick
+	Revision  int    `json:"revision"`
 }
 
 // DebugCombatStartHandler godoc
@@ -60,6 +61,10 @@ func DebugCombatStartHandler(w http.ResponseWriter, r *http.Request) {
 		writeCombatError(w, http.StatusNotFound, "Session not found")
 		return
 	}
+	if !lockSaveChange(w, sess, req.Revision) {
+		return
+	}
+	defer sess.UnlockActions()
 
 	if sess.ActiveCombat != nil {
 		writeCombatError(w, http.StatusConflict, "Combat already active")
@@ -85,7 +90,7 @@ func DebugCombatStartHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	cs, err := combat.StartCombat(serverdb.GetDB(), &sess.SaveData, npub, monsterID, "", advancement)
+	cs, err := combat.StartCombat(serverdb.GetDB(), &sess.SaveData, npub, []string{monsterID}, "", advancement)
 	if err != nil {
 		log.Printf("❌ DebugCombatStart: %v", err)
 		writeCombatError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to start combat: %v", err))
@@ -95,5 +100,6 @@ func DebugCombatStartHandler(w http.ResponseWriter, r *http.Request) {
 	sess.ActiveCombat = cs
 	log.Printf("⚔️  Debug combat started: npub=%s monster=%s", npub, monsterID)
 
+	commitSaveChange(npub, saveID, sess)
 	writeCombatJSON(w, http.StatusOK, buildStateResponse(cs, &sess.SaveData, cs.Log))
 }
@@ -0,0 +1,63 @@
+package game
+
+import (
+	"log"
+
+	"pubkey-quest/cmd/server/game/gameutil"
+	"pubkey-quest/cmd/server/utils"
+	"pubkey-quest/types"
+)
+
+// Death penalty — what dying costs is deployment config (game.death in
+// config.yml). Left unset it's the standard penalty: the 3 most valuable items
+// survive and everything else, gold included, is lost. keep_items and
+// lose_gold soften or harden that (-1 and false lose nothing at all), and
+// hardcore mode deletes the save instead.
+
+// DefaultDeathKeepItems is how many of the most valuable items survive a death
+// when game.death.keep_items is unset.
+const DefaultDeathKeepItems = 3
+
+// DeathPenalty is the resolved game.death config.
+type DeathPenalty struct {
+	Hardcore  bool // the save is deleted on death
+	KeepItems int  // most valuable items kept; -1 keeps everything
+	LoseGold  bool // false keeps all gold on top of the kept items
+}
+
+// LoadDeathPenalty resolves game.death from the loaded config, filling unset
+// fields with the standard penalty.
+func LoadDeathPenalty() DeathPenalty {
+	cfg := utils.AppConfig.Game.Death
+	penalty := DeathPenalty{KeepItems: DefaultDeathKeepItems, LoseGold: true}
+	switch cfg.Mode {
+	case "", "standard":
+	case "hardcore":
+		penalty.Hardcore = true
+	default:
+		log.Printf("⚠️ Unknown death mode %q, using standard", cfg.Mode)
+	}
+	if cfg.KeepItems != nil {
+		penalty.KeepItems = max(*cfg.KeepItems, -1)
+	}
+	if cfg.LoseGold != nil {
+		penalty.LoseGold = *cfg.LoseGold
+	}
+	return penalty
+}
+
+// applyDeathPenalty takes what the penalty says a death costs from the save's
+// inventory and returns the items kept (nil when the inventory is left alone).
+func applyDeathPenalty(save *types.SaveFile, penalty DeathPenalty) []types.LootDrop {
+	if penalty.KeepItems < 0 {
+		// Everything is kept where it is; at most the gold goes.
+		if penalty.LoseGold {
+			if gold := gameutil.GetGoldQuantity(save); gold > 0 {
+				gameutil.DeductGold(save, gold)
+				log.Printf("💀 death: lost %d gold, kept everything else", gold)
+			}
+		}
+		return nil
+	}
+	return stripInventoryForDeath(save.Inventory, penalty.KeepItems, penalty.LoseGold)
+}
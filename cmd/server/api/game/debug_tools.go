@@ -61,9 +61,10 @@ func debugGuard(w http.ResponseWriter, r *http.Request, debugMode bool, body any
 // POST /api/debug/grant-xp  { npub, save_id, amount }
 func DebugGrantXPHandler(w http.ResponseWriter, r *http.Request, debugMode bool) {
 	var req struct {
-		Npub   string `json:"npub"`
-		SaveID string `json:"save_id"`
-		Amount int    `json:"amount"`
+		Npub     string `json:"npub"`
+		SaveID   string `json:"save_id"`
+		Amount   int    `json:"amount"`
+		Revision int    `json:"revision"`
 	}
 	if !debugGuard(w, r, debugMode, &req) {
 		return
@@ -77,6 +78,10 @@ func DebugGrantXPHandler(w http.ResponseWriter, r *http.Request, debugMode bool)
 	if sess == nil {
 		return
 	}
+	if !lockSaveChange(w, sess, req.Revision) {
+		return
+	}
+	defer sess.UnlockActions()
 
 	adv, err := loadAdvancement()
 	if err != nil {
@@ -88,9 +93,10 @@ func DebugGrantXPHandler(w http.ResponseWriter, r *http.Request, debugMode bool)
 	log.Printf("🐛 Debug: granted %d XP to %s (leveled=%v)", req.Amount, req.Npub, result.Leveled)
 
 	resp := map[string]any{
-		"success": true,
-		"message": fmt.Sprintf("Granted %d XP", req.Amount),
-		"data":    map[string]any{"level_up": result},
+		"success":  true,
+		"message":  fmt.Sprintf("Granted %d XP", req.Amount),
+		"data":     map[string]any{"level_up": result},
+		"revision": commitSaveChange(req.Npub, req.SaveID, sess),
 	}
 	if d := sess.UpdateSnapshotAndCalculateDelta(); d != nil && !d.IsEmpty() {
 		resp["delta"] = d.ToMap()
@@ -109,6 +115,7 @@ func DebugTeleportHandler(w http.ResponseWriter, r *http.Request, debugMode bool
 		SaveID   string `json:"save_id"`
 		Location string `json:"location"`
 		District string `json:"district"`
+		Revision int    `json:"revision"`
 	}
 	if !debugGuard(w, r, debugMode, &req) {
 		return
@@ -126,6 +133,10 @@ func DebugTeleportHandler(w http.ResponseWriter, r *http.Request, debugMode bool
 	if sess == nil {
 		return
 	}
+	if !lockSaveChange(w, sess, req.Revision) {
+		return
+	}
+	defer sess.UnlockActions()
 
 	save := &sess.SaveData
 	save.Location = req.Location
@@ -144,6 +155,7 @@ func DebugTeleportHandler(w http.ResponseWriter, r *http.Request, debugMode bool
 		"message":  fmt.Sprintf("Teleported to %s", req.Location),
 		"location": req.Location,
 		"district": district,
+		"revision": commitSaveChange(req.Npub, req.SaveID, sess),
 	})
 }
 
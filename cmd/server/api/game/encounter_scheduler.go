@@ -118,5 +118,8 @@ func fireEncounter(sess *session.GameSession, enc types.EncounterData, response
 		response.Data["encounter_name"] = enc.Name
 		response.Data["poi_step"] = res
 	}
+	response.AddEvent(types.EventEncounter, enc.Name, map[string]interface{}{
+		"kind": "vignette", "id": enc.ID, "name": enc.Name,
+	})
 	log.Printf("✨ Encounter fired: %s (%s)", enc.ID, enc.Trigger)
 }
@@ -0,0 +1,58 @@
+package game
+
+import (
+	"fmt"
+
+	"pubkey-quest/cmd/server/game/character"
+	"pubkey-quest/cmd/server/game/effects"
+	"pubkey-quest/types"
+)
+
+// Action events — GameActionResponse.Events.
+//
+// Events that fall out of state changes (an effect running out, a level gained
+// from any XP source) are derived by diffing the save around the action, so no
+// individual handler has to remember to report them. Events with their own
+// moment (arrival, an encounter firing) are pushed where they happen.
+
+// eventSnapshot is the part of the save compared before and after an action.
+type eventSnapshot struct {
+	day, minute  int
+	experience   int
+	timedEffects map[string]float64 // effect ID → shortest duration remaining
+}
+
+func snapshotForEvents(state *types.SaveFile) eventSnapshot {
+	return eventSnapshot{
+		day:          state.CurrentDay,
+		minute:       state.TimeOfDay,
+		experience:   state.Experience,
+		timedEffects: effects.TimedEffectDurations(state.ActiveEffects),
+	}
+}
+
+// appendStateEvents adds the events implied by how the save changed since
+// before: timed effects whose duration ran out in the time that passed, and a
+// level-up if the player's level went up.
+func appendStateEvents(response *GameActionResponse, before eventSnapshot, state *types.SaveFile) {
+	elapsed := (state.CurrentDay-before.day)*1440 + state.TimeOfDay - before.minute
+	for _, effectID := range effects.ExpiredEffectIDs(before.timedEffects, state.ActiveEffects, elapsed) {
+		name := effectID
+		if data, err := effects.LoadEffectData(effectID); err == nil && data != nil && data.Name != "" {
+			name = data.Name
+		}
+		response.AddEvent(types.EventEffectExpired, fmt.Sprintf("%s has worn off.", name),
+			map[string]interface{}{"effect_id": effectID, "name": name})
+	}
+
+	if state.Experience > before.experience {
+		if adv, err := loadAdvancement(); err == nil {
+			oldLevel := character.GetLevelFromXP(before.experience, adv)
+			newLevel := character.GetLevelFromXP(state.Experience, adv)
+			if newLevel > oldLevel {
+				response.AddEvent(types.EventLevelUp, fmt.Sprintf("You reached level %d!", newLevel),
+					map[string]interface{}{"old_level": oldLevel, "new_level": newLevel})
+			}
+		}
+	}
+}
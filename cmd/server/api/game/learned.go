@@ -0,0 +1,101 @@
+package game
+
+import (
+	"log"
+	"strings"
+	"sync"
+
+	"pubkey-quest/cmd/server/api/data"
+	serverdb "pubkey-quest/cmd/server/db"
+	"pubkey-quest/cmd/server/game/character"
+	"pubkey-quest/types"
+)
+
+// buildLearnedContent loads the game-data catalog for the character's learned
+// content and assembles the state response's "learned" section. A catalog that
+// fails to load just leaves those entries named by ID.
+func buildLearnedContent(save *types.SaveFile, level int) character.LearnedContent {
+	return character.BuildLearnedContent(save, level, loadLearnedCatalog(save.Class))
+}
+
+// learnedCatalogs caches each class's catalog once it has loaded in full, since
+// the state fetch asks for it on every poll. Like the other cached game data,
+// edits to spells, locations, music or abilities show up after a restart.
+var (
+	learnedCatalogMu sync.RWMutex
+	learnedCatalogs  = map[string]character.LearnedCatalog{}
+)
+
+// loadLearnedCatalog returns the class's catalog, from the cache when it has
+// one. A catalog with a failed load isn't cached, so the next fetch retries.
+func loadLearnedCatalog(class string) character.LearnedCatalog {
+	learnedCatalogMu.RLock()
+	catalog, ok := learnedCatalogs[class]
+	learnedCatalogMu.RUnlock()
+	if ok {
+		return catalog
+	}
+
+	catalog, complete := readLearnedCatalog(class)
+	if complete {
+		learnedCatalogMu.Lock()
+		learnedCatalogs[class] = catalog
+		learnedCatalogMu.Unlock()
+	}
+	return catalog
+}
+
+// readLearnedCatalog loads the catalog from the database, reporting whether
+// every part of it loaded.
+func readLearnedCatalog(class string) (character.LearnedCatalog, bool) {
+	catalog := character.LearnedCatalog{
+		Spells:    map[string]character.LearnedEntry{},
+		Locations: map[string]character.LearnedEntry{},
+		Music:     map[string]character.LearnedEntry{},
+	}
+	database := serverdb.GetDB()
+	if database == nil {
+		return catalog, false
+	}
+	complete := true
+
+	if spells, err := data.LoadAllSpells(database); err != nil {
+		log.Printf("⚠️ learned: spells load failed: %v", err)
+		complete = false
+	} else {
+		for _, s := range spells {
+			catalog.Spells[s.ID] = character.LearnedEntry{ID: s.ID, Name: s.Name, Level: s.Level, Detail: s.School}
+		}
+	}
+
+	if locations, err := data.LoadAllLocations(database); err != nil {
+		log.Printf("⚠️ learned: locations load failed: %v", err)
+		complete = false
+	} else {
+		for _, l := range locations {
+			catalog.Locations[l.ID] = character.LearnedEntry{ID: l.ID, Name: l.Name, Detail: l.LocationType}
+		}
+	}
+
+	if tracks, err := data.LoadAllMusicTracks(database); err != nil {
+		log.Printf("⚠️ learned: music load failed: %v", err)
+		complete = false
+	} else {
+		for _, t := range tracks {
+			catalog.Music[t.Title] = character.LearnedEntry{ID: t.Title, Name: t.Title, Detail: t.File}
+		}
+	}
+
+	// Martial class abilities; casters have none.
+	if abilities, err := data.LoadAbilitiesForClass(strings.ToLower(class)); err != nil {
+		log.Printf("⚠️ learned: abilities load failed for %s: %v", class, err)
+		complete = false
+	} else {
+		for _, ab := range abilities {
+			catalog.ClassAbilities = append(catalog.ClassAbilities, character.LearnedEntry{
+				ID: ab.ID, Name: ab.Name, Level: ab.UnlockLevel, Detail: ab.ResourceType,
+			})
+		}
+	}
+	return catalog, complete
+}
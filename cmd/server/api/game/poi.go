@@ -72,11 +72,16 @@ func stepPOI(sess *session.GameSession, nodeID string, data map[string]any) (poi
 	// with the player dead. Trigger the shared death flow and end the walk here —
 	// the single choke point for all POI damage.
 	if state.HP <= 0 {
-		kept := ApplyDeath(state)
+		kept, permadeath := ApplyDeath(sess)
 		sess.ActivePOI = nil
 		res.Terminal = true
 		res.Combat = ""
 		res.Next = ""
+		if permadeath {
+			res.Outcome = append(res.Outcome, hardcoreDeathMessage)
+			data["death"] = map[string]any{"outcome": "defeat", "permadeath": true}
+			return res, nil
+		}
 		res.Outcome = append(res.Outcome, fmt.Sprintf(
 			"You have fallen. You wake in %s, stripped of your belongings — but your experience endures.",
 			state.Location,
@@ -123,7 +128,7 @@ func bridgePOICombat(sess *session.GameSession, monsterID string) error {
 		return err
 	}
 	state := &sess.SaveData
-	cs, err := combat.StartCombat(serverdb.GetDB(), state, sess.Npub, monsterID, state.Location, advancement)
+	cs, err := combat.StartCombat(serverdb.GetDB(), state, sess.Npub, []string{monsterID}, state.Location, advancement)
 	if err != nil {
 		return err
 	}
@@ -174,16 +179,17 @@ func resumePOIAfterVictory(sess *session.GameSession) *poi.StepResult {
 // ─── Handlers ─────────────────────────────────────────────────────────────────
 
 // POIEnterHandler begins a walk of a discovered POI at its start node.
-// POST /api/poi/enter {npub, save_id, poi_id}
+// POST /api/poi/enter {npub, save_id, poi_id, revision}
 func POIEnterHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		writePOIError(w, http.StatusMethodNotAllowed, "method not allowed")
 		return
 	}
 	var req struct {
-		Npub   string `json:"npub"`
-		SaveID string `json:"save_id"`
-		POIID  string `json:"poi_id"`
+		Npub     string `json:"npub"`
+		SaveID   string `json:"save_id"`
+		POIID    string `json:"poi_id"`
+		Revision int    `json:"revision"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writePOIError(w, http.StatusBadRequest, "invalid request body")
@@ -198,6 +204,10 @@ func POIEnterHandler(w http.ResponseWriter, r *http.Request) {
 		writePOIError(w, http.StatusNotFound, "session not found")
 		return
 	}
+	if !lockSaveChange(w, sess, req.Revision) {
+		return
+	}
+	defer sess.UnlockActions()
 	if !alreadyDiscovered(&sess.SaveData, req.POIID) {
 		writePOIError(w, http.StatusBadRequest, "POI not discovered")
 		return
@@ -217,21 +227,23 @@ func POIEnterHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	data["step"] = res
-	writePOIJSON(w, http.StatusOK, map[string]any{"success": true, "data": data})
+	revision := commitSaveChange(req.Npub, req.SaveID, sess)
+	writePOIJSON(w, http.StatusOK, map[string]any{"success": true, "data": data, "revision": revision})
 }
 
 // POIAdvanceHandler resolves the next node the player chose. The requested node
 // must be on the current node's anti-skip allowlist.
-// POST /api/poi/advance {npub, save_id, next}
+// POST /api/poi/advance {npub, save_id, next, revision}
 func POIAdvanceHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		writePOIError(w, http.StatusMethodNotAllowed, "method not allowed")
 		return
 	}
 	var req struct {
-		Npub   string `json:"npub"`
-		SaveID string `json:"save_id"`
-		Next   string `json:"next"`
+		Npub     string `json:"npub"`
+		SaveID   string `json:"save_id"`
+		Next     string `json:"next"`
+		Revision int    `json:"revision"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writePOIError(w, http.StatusBadRequest, "invalid request body")
@@ -246,6 +258,10 @@ func POIAdvanceHandler(w http.ResponseWriter, r *http.Request) {
 		writePOIError(w, http.StatusNotFound, "session not found")
 		return
 	}
+	if !lockSaveChange(w, sess, req.Revision) {
+		return
+	}
+	defer sess.UnlockActions()
 	if sess.ActivePOI == nil {
 		writePOIError(w, http.StatusBadRequest, "no active POI")
 		return
@@ -261,7 +277,8 @@ func POIAdvanceHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	data["step"] = res
-	writePOIJSON(w, http.StatusOK, map[string]any{"success": true, "data": data})
+	revision := commitSaveChange(req.Npub, req.SaveID, sess)
+	writePOIJSON(w, http.StatusOK, map[string]any{"success": true, "data": data, "revision": revision})
 }
 
 // POIListHandler returns the POIs the player has discovered in their current
@@ -29,20 +29,22 @@ type AbilityPointsResponse struct {
 
 // SpendAbilityPointRequest spends one banked point into an ability.
 type SpendAbilityPointRequest struct {
-	Npub    string `json:"npub"`
-	SaveID  string `json:"save_id"`
-	Ability string `json:"ability"` // full name or 3-letter abbrev, any case (e.g. "Strength" / "str")
+	Npub     string `json:"npub"`
+	SaveID   string `json:"save_id"`
+	Ability  string `json:"ability"` // full name or 3-letter abbrev, any case (e.g. "Strength" / "str")
+	Revision int    `json:"revision"`
 }
 
 // SpendAbilityPointResponse is returned after a successful allocation.
 type SpendAbilityPointResponse struct {
-	Success bool           `json:"success"`
-	Ability string         `json:"ability"`
-	Unspent int            `json:"unspent"`
-	Cap     int            `json:"cap"`
-	Scores  map[string]int `json:"scores"`
-	MaxHP   int            `json:"max_hp"`
-	MaxMana int            `json:"max_mana"`
+	Success  bool           `json:"success"`
+	Ability  string         `json:"ability"`
+	Unspent  int            `json:"unspent"`
+	Cap      int            `json:"cap"`
+	Scores   map[string]int `json:"scores"`
+	MaxHP    int            `json:"max_hp"`
+	MaxMana  int            `json:"max_mana"`
+	Revision int            `json:"revision"`
 }
 
 // ============================================================================
@@ -129,6 +131,10 @@ func SpendAbilityPointHandler(w http.ResponseWriter, r *http.Request) {
 	if sess == nil {
 		return
 	}
+	if !lockSaveChange(w, sess, req.Revision) {
+		return
+	}
+	defer sess.UnlockActions()
 	if sess.ActiveCombat != nil {
 		http.Error(w, "Cannot allocate ability points during combat", http.StatusConflict)
 		return
@@ -150,15 +156,110 @@ func SpendAbilityPointHandler(w http.ResponseWriter, r *http.Request) {
 	log.Printf("✨ Ability point spent: %s → %d (unspent %d) for %s",
 		canon, character.AbilityScores(save)[canon], character.UnspentAbilityPoints(save, adv), req.Npub)
 
+	revision := commitSaveChange(req.Npub, req.SaveID, sess)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(SpendAbilityPointResponse{
-		Success: true,
-		Ability: canon,
-		Unspent: character.UnspentAbilityPoints(save, adv),
-		Cap:     character.AbilityScoreMax,
-		Scores:  character.AbilityScores(save),
-		MaxHP:   save.MaxHP,
-		MaxMana: save.MaxMana,
+		Success:  true,
+		Ability:  canon,
+		Unspent:  character.UnspentAbilityPoints(save, adv),
+		Cap:      character.AbilityScoreMax,
+		Scores:   character.AbilityScores(save),
+		MaxHP:    save.MaxHP,
+		MaxMana:  save.MaxMana,
+		Revision: revision,
+	})
+}
+
+// LevelUpRequest applies any pending level-up for a session's character.
+type LevelUpRequest struct {
+	Npub     string `json:"npub"`
+	SaveID   string `json:"save_id"`
+	Revision int    `json:"revision"`
+}
+
+// LevelUpResponse reports what the level-up gave, for the level-up screen.
+type LevelUpResponse struct {
+	Success  bool                   `json:"success"`
+	Gains    character.LevelUpGains `json:"gains"`
+	Unspent  int                    `json:"unspent"` // ability points now available to allocate
+	Revision int                    `json:"revision"`
+}
+
+// LevelUpHandler applies the rewards for every level the character's XP has
+// reached since the last level-up was applied: re-derived Max HP / Mana, the new
+// level's spell slots (spell-slots.json), and the class abilities that unlocked.
+// At an already-applied level it returns gains.applied=false and changes nothing.
+// Blocked during active combat.
+//
+// LevelUpHandler godoc
+// @Summary      Apply a level-up
+// @Description  Applies pending level-up rewards (HP/Mana, spell slots, ability unlocks); idempotent
+// @Tags         Progression
+// @Accept       json
+// @Produce      json
+// @Param        request  body      LevelUpRequest  true  "Session to level up"
+// @Success      200      {object}  LevelUpResponse
+// @Failure      404      {string}  string  "Session not found"
+// @Failure      409      {string}  string  "In active combat"
+// @Router       /api/progression/level-up [post]
+func LevelUpHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req LevelUpRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Npub == "" || req.SaveID == "" {
+		http.Error(w, "Missing required fields: npub, save_id", http.StatusBadRequest)
+		return
+	}
+
+	sess := getSessionAndValidate(w, req.Npub, req.SaveID)
+	if sess == nil {
+		return
+	}
+	if !lockSaveChange(w, sess, req.Revision) {
+		return
+	}
+	defer sess.UnlockActions()
+	if sess.ActiveCombat != nil {
+		http.Error(w, "Cannot level up during combat", http.StatusConflict)
+		return
+	}
+
+	adv, err := character.LoadAdvancement(serverdb.GetDB())
+	if err != nil {
+		http.Error(w, "Failed to load advancement data", http.StatusInternalServerError)
+		return
+	}
+	save := sess.GetSaveData()
+
+	var spellSlots map[int]map[string]int
+	if character.IsCaster(save.Class) {
+		if slots, err := loadClassSpellSlots(save.Class); err != nil {
+			log.Printf("⚠️ level-up: spell slots load failed for %s: %v", save.Class, err)
+		} else {
+			spellSlots = slots
+		}
+	}
+
+	gains := character.ApplyLevelUp(save, adv, spellSlots, loadGuideAbilities(save.Class))
+	if gains.Applied {
+		log.Printf("⬆️ Level-up applied for %s: %d→%d (+%d HP, +%d mana, slots %v, abilities %v)",
+			req.Npub, gains.FromLevel, gains.Level, gains.HPGained, gains.ManaGained, gains.SpellSlots, gains.Abilities)
+	}
+
+	revision := commitSaveChange(req.Npub, req.SaveID, sess)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(LevelUpResponse{
+		Success:  true,
+		Gains:    gains,
+		Unspent:  character.UnspentAbilityPoints(save, adv),
+		Revision: revision,
 	})
 }
 
@@ -225,17 +326,7 @@ func GetLevelGuideHandler(w http.ResponseWriter, r *http.Request) {
 
 	levels := character.BuildLevelGuide(save, adv, guideAbilities, spellSlots)
 	currentLevel := character.GetLevelFromXP(save.Experience, adv)
-
-	xpToNext := 0
-	for _, e := range adv {
-		if e.Level == currentLevel+1 {
-			xpToNext = e.ExperiencePoints - save.Experience
-			break
-		}
-	}
-	if xpToNext < 0 {
-		xpToNext = 0
-	}
+	xpToNext := character.XPToNextLevel(save.Experience, adv)
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(LevelGuideResponse{
@@ -378,10 +469,11 @@ func GetFeatsHandler(w http.ResponseWriter, r *http.Request) {
 
 // ChooseFeatRequest picks a feat, with a stat choice for half-feats.
 type ChooseFeatRequest struct {
-	Npub   string `json:"npub"`
-	SaveID string `json:"save_id"`
-	FeatID string `json:"feat_id"`
-	Choice string `json:"choice,omitempty"` // stat for half-feats (e.g. "constitution")
+	Npub     string `json:"npub"`
+	SaveID   string `json:"save_id"`
+	FeatID   string `json:"feat_id"`
+	Choice   string `json:"choice,omitempty"` // stat for half-feats (e.g. "constitution")
+	Revision int    `json:"revision"`
 }
 
 // ChooseFeatHandler godoc
@@ -412,6 +504,10 @@ func ChooseFeatHandler(w http.ResponseWriter, r *http.Request) {
 	if sess == nil {
 		return
 	}
+	if !lockSaveChange(w, sess, req.Revision) {
+		return
+	}
+	defer sess.UnlockActions()
 	if sess.ActiveCombat != nil {
 		http.Error(w, "Cannot choose a feat during combat", http.StatusConflict)
 		return
@@ -435,6 +531,7 @@ func ChooseFeatHandler(w http.ResponseWriter, r *http.Request) {
 	log.Printf("✨ Feat taken: %s (choice=%q) for %s — %d slots left, %d unspent points",
 		feat.Name, req.Choice, req.Npub, character.FeatSlotsAvailable(save, adv), character.UnspentAbilityPoints(save, adv))
 
+	revision := commitSaveChange(req.Npub, req.SaveID, sess)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"success":         true,
@@ -444,5 +541,6 @@ func ChooseFeatHandler(w http.ResponseWriter, r *http.Request) {
 		"unspent":         character.UnspentAbilityPoints(save, adv),
 		"scores":          character.AbilityScores(save),
 		"max_hp":          save.MaxHP,
+		"revision":        revision,
 	})
 }
@@ -2,6 +2,7 @@ package game
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
@@ -138,8 +139,32 @@ type questLogView struct {
 }
 
 func buildQuestLog(save *types.SaveFile, ctx requirement.Context) questLogView {
-	view := questLogView{QuestPoints: quest.QuestPoints(save, serverdb.GetQuestByID)}
+	view := questLogView{QuestPoints: quest.QuestPoints(save, serverdb.GetQuestByID), Active: activeQuestViews(save)}
 
+	for _, id := range save.QuestsCompleted {
+		name := id
+		if qd, err := serverdb.GetQuestByID(id); err == nil && qd != nil {
+			name = qd.Name
+		}
+		view.Completed = append(view.Completed, namedQuestView{ID: id, Name: name})
+	}
+
+	all, _ := serverdb.GetAllQuests()
+	for _, qd := range quest.Available(all, save, ctx) {
+		view.Available = append(view.Available, namedQuestView{
+			ID: qd.ID, Name: qd.Name, Category: string(qd.Category),
+			Difficulty: qd.Difficulty, Description: qd.Description,
+			StartHint: qd.StartCondition.StartHint, Rewards: questRewardView(&qd),
+		})
+	}
+	return view
+}
+
+// activeQuestViews renders the in-progress quests with their current stage's
+// objective progress — the quest log's active list, also sent with the game
+// state. A turn-in stage's fetch objectives count what the player is carrying.
+func activeQuestViews(save *types.SaveFile) []activeQuestView {
+	var out []activeQuestView
 	for _, qp := range save.QuestsActive {
 		qd, err := serverdb.GetQuestByID(qp.QuestID)
 		if err != nil || qd == nil {
@@ -161,31 +186,39 @@ func buildQuestLog(save *types.SaveFile, ctx requirement.Context) questLogView {
 				if j < len(qp.ObjectiveCounts) {
 					count = qp.ObjectiveCounts[j]
 				}
+				if stage.TurnIn && obj.Type == types.ObjectiveFetch {
+					count = gameutil.CountItem(save, obj.Target)
+					if count > target {
+						count = target
+					}
+				}
 				av.Objectives = append(av.Objectives, objectiveView{
 					Description: obj.Description, Count: count, Target: target, Done: count >= target,
 				})
 			}
 		}
-		view.Active = append(view.Active, av)
+		out = append(out, av)
 	}
+	return out
+}
 
-	for _, id := range save.QuestsCompleted {
-		name := id
-		if qd, err := serverdb.GetQuestByID(id); err == nil && qd != nil {
-			name = qd.Name
-		}
-		view.Completed = append(view.Completed, namedQuestView{ID: id, Name: name})
+// dialogueQuests carries out the quest actions NPC dialogue can take.
+type dialogueQuests struct{}
+
+func (dialogueQuests) StartQuest(state *types.SaveFile, questID string) error {
+	qd, err := serverdb.GetQuestByID(questID)
+	if err != nil || qd == nil {
+		return fmt.Errorf("quest not found: %s", questID)
 	}
+	return quest.Accept(*qd, state, buildQuestContext(state))
+}
 
-	all, _ := serverdb.GetAllQuests()
-	for _, qd := range quest.Available(all, save, ctx) {
-		view.Available = append(view.Available, namedQuestView{
-			ID: qd.ID, Name: qd.Name, Category: string(qd.Category),
-			Difficulty: qd.Difficulty, Description: qd.Description,
-			StartHint: qd.StartCondition.StartHint, Rewards: questRewardView(&qd),
-		})
+func (dialogueQuests) TurnInQuest(state *types.SaveFile, questID string) error {
+	advancement, err := loadAdvancement()
+	if err != nil {
+		return err
 	}
-	return view
+	return quest.TurnIn(state, questID, serverdb.GetQuestByID, advancement)
 }
 
 // injectQuestOffers adds the quests this NPC gives — those whose start
@@ -244,9 +277,10 @@ func injectQuestOffers(resp *types.GameActionResponse, npcID string, state *type
 // ─── handlers ─────────────────────────────────────────────────────────────────
 
 type questActionRequest struct {
-	Npub    string `json:"npub"`
-	SaveID  string `json:"save_id"`
-	QuestID string `json:"quest_id"`
+	Npub     string `json:"npub"`
+	SaveID   string `json:"save_id"`
+	QuestID  string `json:"quest_id"`
+	Revision int    `json:"revision"`
 }
 
 // QuestLogHandler returns the player's quest log: active quests with objective
@@ -276,6 +310,7 @@ func QuestAcceptHandler(w http.ResponseWriter, r *http.Request) {
 	if !ok {
 		return
 	}
+	defer sess.UnlockActions()
 	qd, err := serverdb.GetQuestByID(req.QuestID)
 	if err != nil || qd == nil {
 		writeQuestError(w, http.StatusNotFound, "quest not found")
@@ -286,10 +321,12 @@ func QuestAcceptHandler(w http.ResponseWriter, r *http.Request) {
 		writeQuestError(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	revision := commitSaveChange(req.Npub, req.SaveID, sess)
 	writeQuestJSON(w, http.StatusOK, map[string]interface{}{
-		"success": true,
-		"message": "Quest accepted: " + qd.Name,
-		"data":    buildQuestLog(&sess.SaveData, ctx),
+		"success":  true,
+		"message":  "Quest accepted: " + qd.Name,
+		"data":     buildQuestLog(&sess.SaveData, ctx),
+		"revision": revision,
 	})
 }
 
@@ -299,19 +336,24 @@ func QuestAbandonHandler(w http.ResponseWriter, r *http.Request) {
 	if !ok {
 		return
 	}
+	defer sess.UnlockActions()
 	if err := quest.Abandon(&sess.SaveData, req.QuestID); err != nil {
 		writeQuestError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 	ctx := buildQuestContext(&sess.SaveData)
+	revision := commitSaveChange(req.Npub, req.SaveID, sess)
 	writeQuestJSON(w, http.StatusOK, map[string]interface{}{
-		"success": true,
-		"message": "Quest abandoned",
-		"data":    buildQuestLog(&sess.SaveData, ctx),
+		"success":  true,
+		"message":  "Quest abandoned",
+		"data":     buildQuestLog(&sess.SaveData, ctx),
+		"revision": revision,
 	})
 }
 
-// questActionSession parses a POST quest action and resolves its session.
+// questActionSession parses a POST quest action and resolves its session. On
+// success the session's action lock is held (see lockSaveChange); the caller
+// releases it.
 func questActionSession(w http.ResponseWriter, r *http.Request) (questActionRequest, *session.GameSession, bool) {
 	var req questActionRequest
 	if r.Method != http.MethodPost {
@@ -331,6 +373,9 @@ func questActionSession(w http.ResponseWriter, r *http.Request) (questActionRequ
 		writeQuestError(w, http.StatusNotFound, "session not found")
 		return req, nil, false
 	}
+	if !lockSaveChange(w, sess, req.Revision) {
+		return req, nil, false
+	}
 	return req, sess, true
 }
 
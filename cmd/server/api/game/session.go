@@ -215,6 +215,7 @@ func GetSessionHandler(w http.ResponseWriter, r *http.Request) {
 		// Add calculated values (NOT persisted - calculated at runtime)
 		"total_weight":    totalWeight,
 		"weight_capacity": weightCapacity,
+		"encumbrance":     status.GetEncumbranceTier(&sess.SaveData),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -231,7 +232,7 @@ type UpdateSessionRequest struct {
 
 // UpdateSessionHandler godoc
 // @Summary      Update session
-// @Description  Update in-memory game state with new data
+// @Description  Update in-memory game state with new data. save_data.revision must match the session's current revision, or the update is refused with 409.
 // @Tags         Session
 // @Accept       json
 // @Produce      json
@@ -239,6 +240,7 @@ type UpdateSessionRequest struct {
 // @Success      200      {object}  map[string]interface{}
 // @Failure      400      {string}  string  "Invalid request"
 // @Failure      405      {string}  string  "Method not allowed"
+// @Failure      409      {object}  types.GameActionResponse  "save_data.revision is out of date"
 // @Failure      500      {string}  string  "Failed to update session"
 // @Router       /session/update [post]
 func UpdateSessionHandler(w http.ResponseWriter, r *http.Request) {
@@ -280,8 +282,20 @@ func UpdateSessionHandler(w http.ResponseWriter, r *http.Request) {
 	saveData.InternalNpub = request.Npub
 	saveData.InternalID = request.SaveID
 
+	sessionMgr := session.GetSessionManager()
+	sess, err := sessionMgr.GetSession(request.Npub, request.SaveID)
+	if err != nil {
+		log.Printf("❌ Failed to update session: %v", err)
+		http.Error(w, fmt.Sprintf("Failed to update session: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if !lockSaveChange(w, sess, saveData.Revision) {
+		return
+	}
+	defer sess.UnlockActions()
+
 	// Update session in memory
-	if err := session.GetSessionManager().UpdateSession(request.Npub, request.SaveID, saveData); err != nil {
+	if err := sessionMgr.UpdateSession(request.Npub, request.SaveID, saveData); err != nil {
 		log.Printf("❌ Failed to update session: %v", err)
 		http.Error(w, fmt.Sprintf("Failed to update session: %v", err), http.StatusInternalServerError)
 		return
@@ -289,14 +303,15 @@ func UpdateSessionHandler(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]any{
-		"success": true,
-		"message": "Session updated successfully",
+		"success":  true,
+		"message":  "Session updated successfully",
+		"revision": sess.SaveData.Revision,
 	})
 }
 
 // SaveSessionHandler godoc
 // @Summary      Save session
-// @Description  Write in-memory session state to disk
+// @Description  Write in-memory session state to disk. Under a "safe-only" save policy (systems/saving.json) the save is refused with success=false outside a city or rented room.
 // @Tags         Session
 // @Accept       json
 // @Produce      json
@@ -336,17 +351,27 @@ func SaveSessionHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Write to disk using existing save logic
-	savePath := session.GetSavePath(request.Npub, request.SaveID)
-	if err := session.WriteSaveFile(savePath, &sess.SaveData); err != nil {
+	// Under a "safe-only" save policy a deliberate save is refused outside safe
+	// places. The refusal is a normal response so the client can show the reason.
+	if reason := session.SaveBlockedReason(&sess.SaveData); reason != "" {
+		log.Printf("🚫 Save refused for %s:%s: %s", request.Npub, request.SaveID, reason)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"success": false,
+			"message": reason,
+			"save_id": request.SaveID,
+		})
+		return
+	}
+
+	// Write to disk and drop the crash journal — the deliberate save is now the
+	// authoritative state.
+	if err := session.CommitSave(request.Npub, request.SaveID, &sess.SaveData); err != nil {
 		log.Printf("❌ Failed to write save file: %v", err)
 		http.Error(w, "Failed to write save file", http.StatusInternalServerError)
 		return
 	}
 
-	// The deliberate save is now the authoritative state — drop the crash journal.
-	session.RemoveJournal(request.Npub, request.SaveID)
-
 	log.Printf("✅ Session saved to disk: %s:%s", request.Npub, request.SaveID)
 
 	w.Header().Set("Content-Type", "application/json")
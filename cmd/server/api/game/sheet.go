@@ -0,0 +1,220 @@
+package game
+
+import (
+	"database/sql"
+	"net/http"
+	"sort"
+
+	gamedata "pubkey-quest/cmd/server/api/data"
+	serverdb "pubkey-quest/cmd/server/db"
+	"pubkey-quest/cmd/server/game/character"
+	"pubkey-quest/cmd/server/game/combat"
+	"pubkey-quest/cmd/server/game/effects"
+	"pubkey-quest/cmd/server/game/status"
+	"pubkey-quest/cmd/server/session"
+	"pubkey-quest/types"
+)
+
+// ─── Character sheet ─────────────────────────────────────────────────────────
+//
+// GET /api/game/sheet is a focused projection of the save for the character
+// sheet UI: the persistent character plus the numbers derived from it, without
+// the runtime blob /api/game/state carries (travel, rentals, shows, …). Every
+// derived number comes from the same helper the game itself uses, so the sheet
+// and play always agree.
+
+// sheetAbilities is the display order of the six ability scores.
+var sheetAbilities = []string{"strength", "dexterity", "constitution", "intelligence", "wisdom", "charisma"}
+
+// sheetSlotOrder is the display order of gear slots; any other slot follows,
+// alphabetically.
+var sheetSlotOrder = []string{
+	"mainhand", "offhand", "head", "chest", "cloak", "legs", "gloves", "boots",
+	"necklace", "ring1", "ring2", "ammo", "bag",
+}
+
+// SheetAbility is one ability score. Score includes active effects; Base is
+// the save's own score.
+type SheetAbility struct {
+	Ability  string `json:"ability"`
+	Score    int    `json:"score"`
+	Base     int    `json:"base"`
+	Modifier int    `json:"modifier"`
+}
+
+// SheetGear is one equipped item with its display name resolved.
+type SheetGear struct {
+	Slot     string `json:"slot"`
+	ItemID   string `json:"item_id"`
+	Name     string `json:"name"`
+	Quantity int    `json:"quantity"`
+}
+
+// CharacterSheet is the character-sheet projection of a save.
+// swagger:model CharacterSheet
+type CharacterSheet struct {
+	Race       string `json:"race"`
+	Class      string `json:"class"`
+	Background string `json:"background"`
+	Alignment  string `json:"alignment"`
+
+	Level            int `json:"level"` // derived from experience
+	Experience       int `json:"experience"`
+	ProficiencyBonus int `json:"proficiency_bonus"`
+
+	Abilities  []SheetAbility `json:"abilities"`
+	ArmorClass int            `json:"armor_class"`
+	HP         int            `json:"hp"`
+	MaxHP      int            `json:"max_hp"`
+	Mana       int            `json:"mana"`
+	MaxMana    int            `json:"max_mana"`
+
+	CarryWeight   float64 `json:"carry_weight"`
+	CarryCapacity float64 `json:"carry_capacity"`
+
+	// WeaponProficiencies lists the class's weapon categories ("simple",
+	// "martial") and individual weapon IDs.
+	WeaponProficiencies []string    `json:"weapon_proficiencies"`
+	ArmorProficiencies  []string    `json:"armor_proficiencies"` // "light", "medium", "heavy", "shields"
+	Equipment           []SheetGear `json:"equipment"`
+}
+
+// CharacterSheetResponse is the body returned by GET /api/game/sheet.
+// swagger:model CharacterSheetResponse
+type CharacterSheetResponse struct {
+	Success bool            `json:"success"`
+	Sheet   *CharacterSheet `json:"sheet,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+// BuildCharacterSheet projects a save into its character sheet.
+func BuildCharacterSheet(db *sql.DB, save *types.SaveFile, advancement []types.AdvancementEntry) CharacterSheet {
+	level := character.GetLevelFromXP(save.Experience, advancement)
+	effStats := effects.EffectiveStats(save)
+
+	sheet := CharacterSheet{
+		Race:                save.Race,
+		Class:               save.Class,
+		Background:          save.Background,
+		Alignment:           save.Alignment,
+		Level:               level,
+		Experience:          save.Experience,
+		ProficiencyBonus:    character.ProficiencyBonus(level),
+		ArmorClass:          combat.CalculatePlayerAC(db, save.Inventory, effStats),
+		HP:                  save.HP,
+		MaxHP:               save.MaxHP,
+		Mana:                save.Mana,
+		MaxMana:             save.MaxMana,
+		CarryWeight:         status.CalculateTotalWeight(save),
+		CarryCapacity:       status.CalculateWeightCapacity(save),
+		WeaponProficiencies: combat.ClassWeaponProficiencies(save.Class),
+		ArmorProficiencies:  combat.ClassArmorProficiencies(save.Class),
+		Equipment:           sheetEquipment(db, save.Inventory),
+	}
+	for _, ability := range sheetAbilities {
+		score := combat.GetStatFromMap(effStats, ability)
+		sheet.Abilities = append(sheet.Abilities, SheetAbility{
+			Ability:  ability,
+			Score:    score,
+			Base:     combat.GetStatFromMap(save.Stats, ability),
+			Modifier: combat.StatMod(score),
+		})
+	}
+	return sheet
+}
+
+// sheetEquipment lists the occupied gear slots in display order.
+func sheetEquipment(db *sql.DB, inventory map[string]interface{}) []SheetGear {
+	gear := []SheetGear{}
+	gearSlots, _ := inventory["gear_slots"].(map[string]interface{})
+	if len(gearSlots) == 0 {
+		return gear
+	}
+
+	rank := make(map[string]int, len(sheetSlotOrder))
+	for i, slot := range sheetSlotOrder {
+		rank[slot] = i
+	}
+	slots := make([]string, 0, len(gearSlots))
+	for slot := range gearSlots {
+		slots = append(slots, slot)
+	}
+	sort.Slice(slots, func(i, j int) bool {
+		ri, iKnown := rank[slots[i]]
+		rj, jKnown := rank[slots[j]]
+		switch {
+		case iKnown && jKnown:
+			return ri < rj
+		case iKnown != jKnown:
+			return iKnown
+		default:
+			return slots[i] < slots[j]
+		}
+	})
+
+	for _, slot := range slots {
+		slotMap, _ := gearSlots[slot].(map[string]interface{})
+		itemID, _ := slotMap["item"].(string)
+		if itemID == "" {
+			continue
+		}
+		name := itemID
+		if item, err := gamedata.LoadItemByID(db, itemID); err == nil {
+			if n, _ := item["name"].(string); n != "" {
+				name = n
+			}
+		}
+		quantity := 1
+		switch q := slotMap["quantity"].(type) {
+		case float64:
+			quantity = int(q)
+		case int:
+			quantity = q
+		}
+		gear = append(gear, SheetGear{Slot: slot, ItemID: itemID, Name: name, Quantity: quantity})
+	}
+	return gear
+}
+
+// CharacterSheetHandler godoc
+// @Summary      Get the character sheet
+// @Description  Returns a focused character-sheet projection of the save: level, ability scores and modifiers, AC, HP/mana, carry weight, proficiencies and equipped gear.
+// @Tags         Game
+// @Produce      json
+// @Param        npub     query     string                  true  "Nostr public key"
+// @Param        save_id  query     string                  true  "Save ID"
+// @Success      200      {object}  CharacterSheetResponse        "Character sheet"
+// @Failure      400      {object}  CharacterSheetResponse        "Missing parameters"
+// @Failure      404      {object}  CharacterSheetResponse        "Session not found"
+// @Router       /game/sheet [get]
+func CharacterSheetHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeCombatJSON(w, http.StatusMethodNotAllowed, CharacterSheetResponse{Error: "Method not allowed"})
+		return
+	}
+
+	npub := r.URL.Query().Get("npub")
+	saveID := r.URL.Query().Get("save_id")
+	if npub == "" || saveID == "" {
+		writeCombatJSON(w, http.StatusBadRequest, CharacterSheetResponse{Error: "Missing npub or save_id"})
+		return
+	}
+
+	sessionMgr := session.GetSessionManager()
+	sess, err := sessionMgr.GetSession(npub, saveID)
+	if err != nil {
+		if sess, err = sessionMgr.LoadSession(npub, saveID); err != nil {
+			writeCombatJSON(w, http.StatusNotFound, CharacterSheetResponse{Error: "Session not found"})
+			return
+		}
+	}
+
+	advancement, err := loadAdvancement()
+	if err != nil {
+		writeCombatJSON(w, http.StatusInternalServerError, CharacterSheetResponse{Error: "Failed to load advancement table"})
+		return
+	}
+
+	sheet := BuildCharacterSheet(serverdb.GetDB(), &sess.SaveData, advancement)
+	writeCombatJSON(w, http.StatusOK, CharacterSheetResponse{Success: true, Sheet: &sheet})
+}
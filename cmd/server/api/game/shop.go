@@ -7,7 +7,6 @@ import (
 	"net/http"
 	"strings"
 
-	"pubkey-quest/cmd/server/api/character"
 	"pubkey-quest/cmd/server/db"
 	"pubkey-quest/cmd/server/game/effects"
 	"pubkey-quest/cmd/server/game/gameutil"
@@ -84,6 +83,7 @@ type ShopTransactionResponse struct {
 	NewGold     int    `json:"new_gold" example:"85"`
 	ItemsBought int    `json:"items_bought,omitempty" example:"1"`
 	ItemsSold   int    `json:"items_sold,omitempty" example:"1"`
+	Revision    int    `json:"revision" example:"43"`
 	Error       string `json:"error,omitempty"`
 }
 
@@ -102,6 +102,7 @@ type ShopTransactionResponse struct {
 // @Failure      400          {object}  map[string]interface{}  "Invalid request"
 // @Failure      404          {object}  map[string]interface{}  "Merchant or session not found"
 // @Failure      405          {string}  string                  "Method not allowed"
+// @Failure      409          {object}  types.GameActionResponse "Game changed elsewhere (stale revision, POST)"
 // @Router       /shop/{merchant_id} [get]
 // @Router       /shop/buy [post]
 // @Router       /shop/sell [post]
@@ -292,6 +293,10 @@ func handleBuyFromShop(w http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
+	if !lockSaveChange(w, session, transaction.Revision) {
+		return
+	}
+	defer session.UnlockActions()
 
 	save := &session.SaveData
 
@@ -333,32 +338,8 @@ func handleBuyFromShop(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get merchant state to check current stock
-	initialInventory := make([]world.MerchantInventoryItem, 0)
-	for _, invItem := range shopConfig.Inventory {
-		initialInventory = append(initialInventory, world.MerchantInventoryItem{
-			ItemID:       invItem.ItemID,
-			CurrentStock: invItem.Stock,
-			MaxStock:     invItem.MaxStock,
-		})
-	}
-
-	itemRestockInterval := 10
-	if shopConfig.ItemRestockInterval > 0 {
-		itemRestockInterval = shopConfig.ItemRestockInterval
-	}
-
-	goldRestockInterval := 30
-	if shopConfig.GoldRestockInterval > 0 {
-		goldRestockInterval = shopConfig.GoldRestockInterval
-	}
-
-	goldRegenInterval := 10
-	if shopConfig.GoldRegenInterval != "" {
-		goldRegenInterval = parseIntervalToMinutes(shopConfig.GoldRegenInterval)
-	}
-
 	merchantManager := world.GetMerchantManager()
-	merchantState, _ := merchantManager.GetMerchantState(transaction.Npub, transaction.MerchantID, shopConfig.StartingGold, shopConfig.GoldRegenRate, initialInventory, itemRestockInterval, goldRestockInterval, goldRegenInterval)
+	merchantState := merchantStateFor(transaction.Npub, transaction.MerchantID, shopConfig)
 
 	// Check current stock from merchant state
 	currentStock := 0
@@ -427,7 +408,7 @@ func handleBuyFromShop(w http.ResponseWriter, r *http.Request) {
 	actualCost := buyPrice * itemsAdded
 
 	// Deduct gold for items that were added
-	if !gameutil.DeductGold(save, actualCost) {
+	if !gameutil.SpendGold(save, actualCost) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]any{
@@ -472,6 +453,7 @@ func handleBuyFromShop(w http.ResponseWriter, r *http.Request) {
 		"gold_spent":   actualCost,
 		"new_gold":     playerGold - actualCost,
 		"items_bought": itemsAdded,
+		"revision":     session.SaveData.Revision,
 	})
 }
 
@@ -504,6 +486,10 @@ func handleSellToShop(w http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
+	if !lockSaveChange(w, session, transaction.Revision) {
+		return
+	}
+	defer session.UnlockActions()
 
 	save := &session.SaveData
 
@@ -577,32 +563,8 @@ func handleSellToShop(w http.ResponseWriter, r *http.Request) {
 		item.Value, shopConfig.ShopType, playerCharisma, sellPrice)
 
 	// Get merchant state to check current gold
-	initialInventory := make([]world.MerchantInventoryItem, 0)
-	for _, invItem := range shopConfig.Inventory {
-		initialInventory = append(initialInventory, world.MerchantInventoryItem{
-			ItemID:       invItem.ItemID,
-			CurrentStock: invItem.Stock,
-			MaxStock:     invItem.MaxStock,
-		})
-	}
-
-	itemRestockInterval := 10
-	if shopConfig.ItemRestockInterval > 0 {
-		itemRestockInterval = shopConfig.ItemRestockInterval
-	}
-
-	goldRestockInterval := 30
-	if shopConfig.GoldRestockInterval > 0 {
-		goldRestockInterval = shopConfig.GoldRestockInterval
-	}
-
-	goldRegenInterval := 10
-	if shopConfig.GoldRegenInterval != "" {
-		goldRegenInterval = parseIntervalToMinutes(shopConfig.GoldRegenInterval)
-	}
-
 	merchantManager := world.GetMerchantManager()
-	merchantState, _ := merchantManager.GetMerchantState(transaction.Npub, transaction.MerchantID, shopConfig.StartingGold, shopConfig.GoldRegenRate, initialInventory, itemRestockInterval, goldRestockInterval, goldRegenInterval)
+	merchantState := merchantStateFor(transaction.Npub, transaction.MerchantID, shopConfig)
 
 	// Check merchant gold from state
 	merchantGold := merchantState.CurrentGold
@@ -620,9 +582,9 @@ func handleSellToShop(w http.ResponseWriter, r *http.Request) {
 	// Frontend removes items via remove_from_inventory action, so we don't remove them here
 	log.Printf("ℹ️ Items already removed from inventory during sell staging")
 
-	// Add gold to player (using existing helper function)
-	if err := character.AddGoldToInventory(save.Inventory, totalValue); err != nil {
-		log.Printf("❌ Error adding gold to inventory: %v", err)
+	// Add gold to player
+	if !gameutil.AddGold(save, totalValue) {
+		log.Printf("❌ Error adding gold to inventory: no room")
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]any{
@@ -661,9 +623,139 @@ func handleSellToShop(w http.ResponseWriter, r *http.Request) {
 		"gold_earned": totalValue,
 		"new_gold":    playerGold + totalValue,
 		"items_sold":  transaction.Quantity,
+		"revision":    session.SaveData.Revision,
 	})
 }
 
+// loadShopConfig reads a merchant NPC's shop config.
+func loadShopConfig(merchantID string) (types.ShopConfig, error) {
+	var shopConfig types.ShopConfig
+	npcData, err := db.GetNPCByID(merchantID)
+	if err != nil {
+		return shopConfig, fmt.Errorf("merchant not found: %s", merchantID)
+	}
+	configJSON, _ := json.Marshal(npcData.ShopConfig)
+	if err := json.Unmarshal(configJSON, &shopConfig); err != nil {
+		return shopConfig, fmt.Errorf("invalid shop configuration for %s", merchantID)
+	}
+	return shopConfig, nil
+}
+
+// merchantStateFor returns the player's live state (stock and gold) for a
+// merchant, seeded from the shop config on the first visit.
+func merchantStateFor(npub, merchantID string, shopConfig types.ShopConfig) *world.MerchantState {
+	initialInventory := make([]world.MerchantInventoryItem, 0)
+	for _, invItem := range shopConfig.Inventory {
+		initialInventory = append(initialInventory, world.MerchantInventoryItem{
+			ItemID:       invItem.ItemID,
+			CurrentStock: invItem.Stock,
+			MaxStock:     invItem.MaxStock,
+		})
+	}
+
+	itemRestockInterval := 10
+	if shopConfig.ItemRestockInterval > 0 {
+		itemRestockInterval = shopConfig.ItemRestockInterval
+	}
+
+	goldRestockInterval := 30
+	if shopConfig.GoldRestockInterval > 0 {
+		goldRestockInterval = shopConfig.GoldRestockInterval
+	}
+
+	goldRegenInterval := 10
+	if shopConfig.GoldRegenInterval != "" {
+		goldRegenInterval = parseIntervalToMinutes(shopConfig.GoldRegenInterval)
+	}
+
+	merchantManager := world.GetMerchantManager()
+	merchantState, _ := merchantManager.GetMerchantState(npub, merchantID, shopConfig.StartingGold, shopConfig.GoldRegenRate, initialInventory, itemRestockInterval, goldRestockInterval, goldRegenInterval)
+	return merchantState
+}
+
+// handleBuyItemAction buys item_id × quantity from merchant_id through the game
+// action pipeline: shop.Buy checks stock, gold and inventory room, and the
+// merchant's stock and gold are updated with what actually changed hands.
+func handleBuyItemAction(session *GameSession, params map[string]any) (*GameActionResponse, error) {
+	merchantID, _ := params["merchant_id"].(string)
+	itemID, _ := params["item_id"].(string)
+	if merchantID == "" || itemID == "" {
+		return nil, fmt.Errorf("missing merchant_id or item_id parameter")
+	}
+	quantity := 1
+	if q, ok := params["quantity"].(float64); ok {
+		quantity = int(q)
+	}
+
+	shopConfig, err := loadShopConfig(merchantID)
+	if err != nil {
+		return nil, err
+	}
+	merchantState := merchantStateFor(session.Npub, merchantID, shopConfig)
+	stock := 0
+	if stateItem, exists := merchantState.Inventory[itemID]; exists {
+		stock = stateItem.CurrentStock
+	}
+
+	trade, err := shop.Buy(&session.SaveData, shopConfig, itemID, quantity, stock)
+	if err != nil {
+		return &GameActionResponse{Success: false, Error: err.Error(), ErrorCode: types.ErrorCodeOf(err), Color: "red"}, nil
+	}
+	world.GetMerchantManager().UpdateMerchantInventory(session.Npub, merchantID, itemID, -trade.Quantity, trade.Gold)
+
+	message := fmt.Sprintf("Bought %dx %s for %dg", trade.Quantity, trade.ItemName, trade.Gold)
+	if trade.Quantity < quantity {
+		message += fmt.Sprintf(" (inventory full - %d didn't fit)", quantity-trade.Quantity)
+	}
+	return &GameActionResponse{
+		Success: true,
+		Message: message,
+		Color:   "green",
+		Data:    map[string]interface{}{"gold_spent": trade.Gold, "items_bought": trade.Quantity},
+	}, nil
+}
+
+// handleSellItemAction sells item_id × quantity to merchant_id straight out of
+// the player's inventory (from_slot_type general/inventory with from_slot, or
+// equipment with gear_slot), so the server — not the client's sell staging —
+// decides what leaves the inventory.
+func handleSellItemAction(session *GameSession, params map[string]any) (*GameActionResponse, error) {
+	merchantID, _ := params["merchant_id"].(string)
+	itemID, _ := params["item_id"].(string)
+	if merchantID == "" || itemID == "" {
+		return nil, fmt.Errorf("missing merchant_id or item_id parameter")
+	}
+	quantity := 1
+	if q, ok := params["quantity"].(float64); ok {
+		quantity = int(q)
+	}
+	from := shop.SellSource{}
+	from.SlotType, _ = params["from_slot_type"].(string)
+	from.GearSlot, _ = params["gear_slot"].(string)
+	if slot, ok := params["from_slot"].(float64); ok {
+		from.Slot = int(slot)
+	}
+
+	shopConfig, err := loadShopConfig(merchantID)
+	if err != nil {
+		return nil, err
+	}
+	merchantState := merchantStateFor(session.Npub, merchantID, shopConfig)
+
+	trade, err := shop.Sell(&session.SaveData, shopConfig, itemID, quantity, from, merchantState.CurrentGold)
+	if err != nil {
+		return &GameActionResponse{Success: false, Error: err.Error(), ErrorCode: types.ErrorCodeOf(err), Color: "red"}, nil
+	}
+	world.GetMerchantManager().UpdateMerchantInventory(session.Npub, merchantID, itemID, trade.Quantity, -trade.Gold)
+
+	return &GameActionResponse{
+		Success: true,
+		Message: fmt.Sprintf("Sold %dx %s for %dg", trade.Quantity, trade.ItemName, trade.Gold),
+		Color:   "green",
+		Data:    map[string]interface{}{"gold_earned": trade.Gold, "items_sold": trade.Quantity},
+	}, nil
+}
+
 // addItemToInventory delegates to game/inventory package
 func addItemToInventory(save *SaveFile, itemID string, quantity int) (int, error) {
 	return inventory.AddItemToInventory(save, itemID, quantity)
@@ -23,6 +23,7 @@ type SpellPrepRequest struct {
 	SpellID   string `json:"spell_id"`
 	SlotLevel string `json:"slot_level"` // "cantrips", "level_1", etc.
 	SlotIndex int    `json:"slot_index"`
+	Revision  int    `json:"revision"`
 }
 
 // SpellPrepResponse is returned by POST /api/spells/prepare.
@@ -34,6 +35,7 @@ type SpellPrepResponse struct {
 	SlotIndex      int    `json:"slot_index,omitempty"`
 	ReadyAtAbsolute int   `json:"ready_at_absolute,omitempty"`
 	ReadyInMinutes int    `json:"ready_in_minutes,omitempty"`
+	Revision        int    `json:"revision"`
 }
 
 // PrepQueueItem is a single task in the queue as returned to the client.
@@ -58,6 +60,7 @@ type SpellSlotRequest struct {
 	SaveID    string `json:"save_id"`
 	SlotLevel string `json:"slot_level"`
 	SlotIndex int    `json:"slot_index"`
+	Revision  int    `json:"revision"`
 }
 
 // ============================================================================
@@ -159,6 +162,10 @@ func PrepareSpellHandler(w http.ResponseWriter, r *http.Request) {
 	if sess == nil {
 		return
 	}
+	if !lockSaveChange(w, sess, req.Revision) {
+		return
+	}
+	defer sess.UnlockActions()
 
 	// Validate spell is known
 	knownFound := false
@@ -202,6 +209,7 @@ func PrepareSpellHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		log.Printf("🔮 Cantrip placed: %s → %s[%d] for %s", req.SpellID, req.SlotLevel, req.SlotIndex, req.Npub)
+		revision := commitSaveChange(req.Npub, req.SaveID, sess)
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(SpellPrepResponse{
 			Success:        true,
@@ -210,6 +218,7 @@ func PrepareSpellHandler(w http.ResponseWriter, r *http.Request) {
 			SlotIndex:      req.SlotIndex,
 			ReadyAtAbsolute: gameSpells.AbsoluteMinutes(sess.SaveData.CurrentDay, sess.SaveData.TimeOfDay),
 			ReadyInMinutes: 0,
+			Revision:        revision,
 		})
 		return
 	}
@@ -235,6 +244,7 @@ func PrepareSpellHandler(w http.ResponseWriter, r *http.Request) {
 	log.Printf("🔮 Spell prep queued: %s → %s[%d], ready in %d min (at %d) for %s",
 		req.SpellID, req.SlotLevel, req.SlotIndex, prepMins, readyAt, req.Npub)
 
+	revision := commitSaveChange(req.Npub, req.SaveID, sess)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(SpellPrepResponse{
 		Success:        true,
@@ -243,6 +253,7 @@ func PrepareSpellHandler(w http.ResponseWriter, r *http.Request) {
 		SlotIndex:      req.SlotIndex,
 		ReadyAtAbsolute: readyAt,
 		ReadyInMinutes: prepMins,
+		Revision:        revision,
 	})
 }
 
@@ -331,6 +342,10 @@ func CancelPrepHandler(w http.ResponseWriter, r *http.Request) {
 	if sess == nil {
 		return
 	}
+	if !lockSaveChange(w, sess, req.Revision) {
+		return
+	}
+	defer sess.UnlockActions()
 
 	idx := gameSpells.FindPrepTask(sess.PrepQueue, req.SlotLevel, req.SlotIndex)
 	if idx < 0 {
@@ -343,10 +358,12 @@ func CancelPrepHandler(w http.ResponseWriter, r *http.Request) {
 
 	log.Printf("🔮 Spell prep cancelled: %s from %s[%d] for %s", cancelledID, req.SlotLevel, req.SlotIndex, req.Npub)
 
+	revision := commitSaveChange(req.Npub, req.SaveID, sess)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"success":           true,
 		"cancelled_spell_id": cancelledID,
+		"revision":           revision,
 	})
 }
 
@@ -381,6 +398,10 @@ func UnslotSpellHandler(w http.ResponseWriter, r *http.Request) {
 	if sess == nil {
 		return
 	}
+	if !lockSaveChange(w, sess, req.Revision) {
+		return
+	}
+	defer sess.UnlockActions()
 
 	slots := sess.SaveData.SpellSlots
 	if !gameSpells.HasSlot(slots, req.SlotLevel, req.SlotIndex) {
@@ -398,10 +419,12 @@ func UnslotSpellHandler(w http.ResponseWriter, r *http.Request) {
 
 	log.Printf("🔮 Spell unslotted: %s[%d] for %s", req.SlotLevel, req.SlotIndex, req.Npub)
 
+	revision := commitSaveChange(req.Npub, req.SaveID, sess)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"success":    true,
 		"slot_level": req.SlotLevel,
 		"slot_index": req.SlotIndex,
+		"revision":   revision,
 	})
 }
@@ -163,6 +163,15 @@ func registerCharacterRoutes(mux *http.ServeMux) {
 	// @Router /api/character [get]
 	mux.HandleFunc("/api/character", character.CharacterHandler)
 
+	// @Summary Generate character from a seed
+	// @Description Deterministic roll from an optional seed (omit for a fresh one)
+	// @Tags Character
+	// @Produce json
+	// @Param seed query string false "Seed to reproduce a roll"
+	// @Success 200 {object} character.SeededCharacterResponse
+	// @Router /api/character/generate [get]
+	mux.HandleFunc("/api/character/generate", character.GenerateSeededHandler)
+
 	// @Summary Create character save
 	// @Description Creates a new character and save file
 	// @Tags Character
@@ -348,6 +357,26 @@ func registerGameRoutes(mux *http.ServeMux) {
 	// @Router /api/game/state [get]
 	mux.HandleFunc("/api/game/state", game.GetGameStateHandler)
 
+	// @Summary Get encounter history
+	// @Description Returns the save's journal of finished fights (monsters, outcome, rounds, XP, loot)
+	// @Tags Combat
+	// @Produce json
+	// @Param npub query string true "Nostr public key"
+	// @Param save_id query string true "Save ID"
+	// @Success 200 {object} game.CombatHistoryResponse
+	// @Router /api/game/combat-history [get]
+	mux.HandleFunc("/api/game/combat-history", game.CombatHistoryHandler)
+
+	// @Summary Get character sheet
+	// @Description Returns a character-sheet projection of the save (level, abilities, AC, HP/mana, carry weight, proficiencies, equipped gear)
+	// @Tags Game
+	// @Produce json
+	// @Param npub query string true "Nostr public key"
+	// @Param save_id query string true "Save ID"
+	// @Success 200 {object} game.CharacterSheetResponse
+	// @Router /api/game/sheet [get]
+	mux.HandleFunc("/api/game/sheet", game.CharacterSheetHandler)
+
 	registerCombatRoutes(mux)
 	registerPOIRoutes(mux)
 }
@@ -424,12 +453,16 @@ func registerCombatRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/api/combat/cast", game.CombatCastHandler)
 	// @Router       /api/combat/use-item [post]
 	mux.HandleFunc("/api/combat/use-item", game.CombatUseItemHandler)
+	// @Router       /api/combat/equip [post]
+	mux.HandleFunc("/api/combat/equip", game.CombatEquipHandler)
 	// @Router       /api/combat/ability [post]
 	mux.HandleFunc("/api/combat/ability", game.CombatAbilityHandler)
 	// @Router       /api/combat/hold [post]
 	mux.HandleFunc("/api/combat/hold", game.CombatHoldHandler)
 	// @Router       /api/combat/disengage [post]
 	mux.HandleFunc("/api/combat/disengage", game.CombatDisengageHandler)
+	// @Router       /api/combat/aim [post]
+	mux.HandleFunc("/api/combat/aim", game.CombatAimHandler)
 	// @Router       /api/combat/flee [post]
 	mux.HandleFunc("/api/combat/flee", game.CombatFleeHandler)
 	// @Router       /api/combat/end-turn [post]
@@ -451,8 +484,9 @@ func registerCombatRoutes(mux *http.ServeMux) {
 
 	// @Summary      End combat and apply results
 	// @Description  Resolves the outcome and applies changes to session memory. Must be called
-	//               after a terminal phase ("loot", "victory", or "defeat"). Victory: applies XP,
-	//               adds loot, updates HP. Defeat: keeps top 3 items by cost, restores HP/mana,
+	//               after a terminal phase ("loot", "victory", "fled", or "defeat"). Victory: applies XP,
+	//               adds loot, updates HP. Fled: updates HP only. Defeat: applies the game.death penalty
+	//               (default: keeps top 3 items by cost; hardcore deletes the save), restores HP/mana,
 	//               returns player to starting location. Clears active combat on success.
 	// @Tags         Combat
 	// @Accept       json
@@ -463,6 +497,19 @@ func registerCombatRoutes(mux *http.ServeMux) {
 	// @Failure      404      {string}  string  "Session or combat not found"
 	// @Router       /api/combat/end [post]
 	mux.HandleFunc("/api/combat/end", game.CombatEndHandler)
+
+	// @Summary      Get recent fights with their combat logs
+	// @Description  Returns the session's last few finished fights, newest first: the
+	//               encounter record and the full round-by-round combat log. Session-only.
+	// @Tags         Combat
+	// @Produce      json
+	// @Param        npub     query     string  true  "Nostr public key"
+	// @Param        save_id  query     string  true  "Save ID"
+	// @Success      200      {object}  game.CombatLogHistoryResponse
+	// @Failure      400      {string}  string  "Missing parameters"
+	// @Failure      404      {string}  string  "Session not found"
+	// @Router       /api/combat/history [get]
+	mux.HandleFunc("/api/combat/history", game.CombatLogHistoryHandler)
 }
 
 // ============================================================================
@@ -567,6 +614,16 @@ func registerProgressionRoutes(mux *http.ServeMux) {
 	// @Success      200      {object}  game.SpendAbilityPointResponse
 	// @Router       /api/progression/spend-point [post]
 	mux.HandleFunc("/api/progression/spend-point", game.SpendAbilityPointHandler)
+
+	// @Summary      Apply a level-up
+	// @Description  Applies pending level-up rewards (HP/Mana, spell slots, ability unlocks); idempotent
+	// @Tags         Progression
+	// @Accept       json
+	// @Produce      json
+	// @Param        request  body      game.LevelUpRequest  true  "Session to level up"
+	// @Success      200      {object}  game.LevelUpResponse
+	// @Router       /api/progression/level-up [post]
+	mux.HandleFunc("/api/progression/level-up", game.LevelUpHandler)
 	// @Router       /api/progression/feats [get]
 	mux.HandleFunc("/api/progression/feats", game.GetFeatsHandler)
 	// @Router       /api/progression/choose-feat [post]
@@ -10,6 +10,7 @@ import (
 	"pubkey-quest/cmd/server/cache"
 	"pubkey-quest/cmd/server/db"
 	"pubkey-quest/cmd/server/game/character"
+	"pubkey-quest/cmd/server/game/combat"
 	"pubkey-quest/cmd/server/game/discovery"
 	"pubkey-quest/cmd/server/game/events"
 	"pubkey-quest/cmd/server/game/quest"
@@ -29,6 +30,12 @@ func Init() {
 
 	cache.InitProfileCache(24 * time.Hour)
 
+	// The class proficiency tables live in code; flag any entry the item data
+	// doesn't back up (a renamed weapon, a typo'd armor category).
+	for _, problem := range combat.CheckProficiencyTables(db.GetDB()) {
+		log.Printf("⚠️ proficiency table: %s", problem)
+	}
+
 	// Wire the event-recorder consumers: the quest objective tracker advances
 	// active quests from gameplay events, and the discovery reward grants XP for
 	// reaching new places. Both need the advancement table for level-ups.
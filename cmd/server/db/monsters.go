@@ -64,3 +64,43 @@ func GetEnvironmentType(envID string) (string, error) {
 	}
 	return props.EnvironmentType, nil
 }
+
+// EncounterEntry is one row of an environment's authored encounter table: a
+// monster that can turn up there and its relative weight.
+type EncounterEntry struct {
+	Monster string `json:"monster"`
+	Weight  int    `json:"weight"`
+}
+
+// EnvironmentEncounters is what the travel encounter roll needs from an
+// environment: its biome, its danger rating (travel_difficulty) and, when the
+// location JSON authors one, its "encounters" table.
+type EnvironmentEncounters struct {
+	Biome      string           `json:"environment_type"`
+	Difficulty string           `json:"travel_difficulty"`
+	Table      []EncounterEntry `json:"encounters"`
+}
+
+// GetEnvironmentEncounters loads an environment's encounter settings.
+func GetEnvironmentEncounters(envID string) (*EnvironmentEncounters, error) {
+	var propsJSON string
+	err := db.QueryRow(`SELECT properties FROM locations WHERE id = ?`, envID).Scan(&propsJSON)
+	if err != nil {
+		return nil, fmt.Errorf("environment not found: %s", envID)
+	}
+	var enc EnvironmentEncounters
+	if err := parseJSON(propsJSON, &enc); err != nil {
+		return nil, fmt.Errorf("failed to parse environment %s: %v", envID, err)
+	}
+	return &enc, nil
+}
+
+// GetMonsterRef returns the encounter reference for one monster.
+func GetMonsterRef(id string) (MonsterRef, error) {
+	ref := MonsterRef{ID: id}
+	err := db.QueryRow(`SELECT name, challenge_rating FROM monsters WHERE id = ?`, id).Scan(&ref.Name, &ref.CR)
+	if err != nil {
+		return MonsterRef{}, fmt.Errorf("monster not found: %s", id)
+	}
+	return ref, nil
+}
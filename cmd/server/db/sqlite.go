@@ -8,6 +8,8 @@ import (
 	"os"
 	"path/filepath"
 
+	"pubkey-quest/types"
+
 	_ "modernc.org/sqlite"
 )
 
@@ -264,6 +266,23 @@ type GenerationWeights struct {
 	AlignmentWeights         []int                     `json:"AlignmentWeights"`
 }
 
+// GetPlayableRegistry retrieves the playable races and classes from the database
+func GetPlayableRegistry() (*types.PlayableRegistry, error) {
+	var dataJSON string
+
+	err := db.QueryRow(`SELECT data FROM playable WHERE id = 'playable' LIMIT 1`).Scan(&dataJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query playable registry: %v", err)
+	}
+
+	var registry types.PlayableRegistry
+	if err := json.Unmarshal([]byte(dataJSON), &registry); err != nil {
+		return nil, fmt.Errorf("failed to parse playable registry JSON: %v", err)
+	}
+
+	return &registry, nil
+}
+
 // GetGenerationWeights retrieves character generation weights from the database
 func GetGenerationWeights() (*GenerationWeights, error) {
 	var dataJSON string
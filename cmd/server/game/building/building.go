@@ -203,3 +203,21 @@ func GetAllBuildingStatesForDistrict(db *sql.DB, locationID, districtID string,
 
 	return result, nil
 }
+
+// GetBuildingEffects returns the effect IDs a building applies while the player
+// is inside (building.effects[] in the city JSON). Most buildings have none.
+func GetBuildingEffects(db *sql.DB, locationID, buildingID string) ([]string, error) {
+	b, err := findBuilding(db, locationID, buildingID)
+	if err != nil {
+		return nil, err
+	}
+	var effectIDs []string
+	if raw, ok := b["effects"].([]interface{}); ok {
+		for _, e := range raw {
+			if id, ok := e.(string); ok && id != "" {
+				effectIDs = append(effectIDs, id)
+			}
+		}
+	}
+	return effectIDs, nil
+}
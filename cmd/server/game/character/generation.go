@@ -296,3 +296,77 @@ func GenerateCharacter(hexKey string, weightData *types.WeightData) types.Charac
 	}
 }
 
+
+// SeedKey turns a free-form seed ("reroll with this seed") into the 32-byte hex
+// key the generators are keyed on. The "seed:" prefix keeps seeds from ever
+// colliding with a real pubkey's roll.
+func SeedKey(seed string) string {
+	hash := sha256.Sum256([]byte("seed:" + seed))
+	return hex.EncodeToString(hash[:])
+}
+
+// GenerateCharacterFromSeed generates a character from an arbitrary seed string.
+// The same seed and weights always produce the same character.
+func GenerateCharacterFromSeed(seed string, weightData *types.WeightData) types.Character {
+	return GenerateCharacter(SeedKey(seed), weightData)
+}
+
+// ValidateWeights checks that the generation weights are usable: every option
+// list lines up with its weights, every weight table has a positive total, and
+// every race/class the generator can land on has a follow-up table.
+func ValidateWeights(weightData *types.WeightData) error {
+	if weightData == nil {
+		return fmt.Errorf("generation weights not loaded")
+	}
+	if err := checkWeightList("race", weightData.Races, weightData.RaceWeights); err != nil {
+		return err
+	}
+	if err := checkWeightList("alignment", weightData.Alignments, weightData.AlignmentWeights); err != nil {
+		return err
+	}
+	for _, race := range weightData.Races {
+		if err := checkWeightTable("class weights for race "+race, weightData.ClassWeightsByRace[race]); err != nil {
+			return err
+		}
+		for class := range weightData.ClassWeightsByRace[race] {
+			if err := checkWeightTable("background weights for class "+class, weightData.BackgroundWeightsByClass[class]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func checkWeightList(name string, options []string, weights []int) error {
+	if len(options) == 0 {
+		return fmt.Errorf("no %s options", name)
+	}
+	if len(options) != len(weights) {
+		return fmt.Errorf("%d %s options but %d weights", len(options), name, len(weights))
+	}
+	total := 0
+	for _, w := range weights {
+		if w < 0 {
+			return fmt.Errorf("negative %s weight", name)
+		}
+		total += w
+	}
+	if total <= 0 {
+		return fmt.Errorf("%s weights sum to zero", name)
+	}
+	return nil
+}
+
+func checkWeightTable(name string, table map[string]int) error {
+	total := 0
+	for _, w := range table {
+		if w < 0 {
+			return fmt.Errorf("negative value in %s", name)
+		}
+		total += w
+	}
+	if total <= 0 {
+		return fmt.Errorf("missing or empty %s", name)
+	}
+	return nil
+}
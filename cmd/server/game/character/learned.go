@@ -0,0 +1,76 @@
+package character
+
+import (
+	"pubkey-quest/types"
+)
+
+// Learned content ("character knowledge" panel).
+//
+// BuildLearnedContent gathers everything the character has learned or
+// unlocked — known spells, class abilities unlocked by level, discovered
+// locations and unlocked music — into one render-ready view. Like
+// BuildLevelGuide it is pure: the handler loads the game-data catalog and
+// passes it in. Spells, locations and music come from the save's lists;
+// abilities derive from class + level and are never stored.
+
+// LearnedEntry is one learned thing with enough metadata to render directly.
+type LearnedEntry struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Level  int    `json:"level,omitempty"`  // spell level (0 = cantrip) or ability unlock level
+	Detail string `json:"detail,omitempty"` // spell school, ability resource, location type, or track file
+}
+
+// LearnedContent is the state response's "learned" section.
+type LearnedContent struct {
+	Spells    []LearnedEntry `json:"spells"`
+	Abilities []LearnedEntry `json:"abilities"`
+	Locations []LearnedEntry `json:"locations"`
+	Music     []LearnedEntry `json:"music"`
+}
+
+// LearnedCatalog is the game data BuildLearnedContent looks entries up in.
+// Spells, Locations and Music are keyed by the ID the save stores (music by
+// track title); ClassAbilities is the character's class abilities with Level
+// set to the unlock level.
+type LearnedCatalog struct {
+	Spells         map[string]LearnedEntry
+	Locations      map[string]LearnedEntry
+	Music          map[string]LearnedEntry
+	ClassAbilities []LearnedEntry
+}
+
+// BuildLearnedContent assembles the learned-content view for a character at a
+// level. Save entries missing from the catalog still appear, named by their
+// ID, so nothing the player has learned silently disappears.
+func BuildLearnedContent(save *types.SaveFile, level int, catalog LearnedCatalog) LearnedContent {
+	content := LearnedContent{
+		Spells:    lookupLearned(save.KnownSpells, catalog.Spells),
+		Abilities: []LearnedEntry{},
+		Locations: lookupLearned(save.LocationsDiscovered, catalog.Locations),
+		Music:     lookupLearned(save.MusicTracksUnlocked, catalog.Music),
+	}
+	for _, ability := range catalog.ClassAbilities {
+		if ability.Level <= level {
+			content.Abilities = append(content.Abilities, ability)
+		}
+	}
+	return content
+}
+
+func lookupLearned(ids []string, catalog map[string]LearnedEntry) []LearnedEntry {
+	entries := make([]LearnedEntry, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		entry, ok := catalog[id]
+		if !ok {
+			entry = LearnedEntry{ID: id, Name: id}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
@@ -0,0 +1,97 @@
+package character
+
+import (
+	"sort"
+
+	"pubkey-quest/types"
+)
+
+// LevelUpGains is what applying a level-up gave the character, for the level-up
+// screen. Applied is false when there was nothing to apply (the level-up for
+// this level was already applied, or XP hasn't crossed a threshold).
+type LevelUpGains struct {
+	Applied    bool           `json:"applied"`
+	FromLevel  int            `json:"from_level"`
+	Level      int            `json:"level"`
+	MaxHP      int            `json:"max_hp"`
+	MaxMana    int            `json:"max_mana"`
+	HPGained   int            `json:"hp_gained"`
+	ManaGained int            `json:"mana_gained"`
+	SpellSlots map[string]int `json:"spell_slots,omitempty"` // slots added, per slot level ("cantrips", "level_1", …)
+	Abilities  []string       `json:"abilities,omitempty"`   // class abilities unlocked by the new levels
+}
+
+// ApplyLevelUp applies the level-up rewards for every level the character's XP
+// has reached beyond save.LevelApplied: it re-derives Max HP/Mana (Hydrate),
+// grows the save's spell slots to the counts spell-slots.json gives the new
+// level, and reports the class abilities that unlocked on the way. Abilities
+// derive from level and are never stored; only LevelApplied is, which makes the
+// call idempotent — applying again at the same level changes nothing.
+//
+// slotTable is the class's per-level slot counts (nil for non-casters) and
+// abilities its level-gated abilities, both loaded by the caller.
+func ApplyLevelUp(save *types.SaveFile, advancement []types.AdvancementEntry, slotTable map[int]map[string]int, abilities []GuideAbilityUnlock) LevelUpGains {
+	level := GetLevelFromXP(save.Experience, advancement)
+	from := save.LevelApplied
+	if from < 1 {
+		from = 1
+	}
+	gains := LevelUpGains{FromLevel: from, Level: level, MaxHP: save.MaxHP, MaxMana: save.MaxMana}
+	if level <= from {
+		return gains
+	}
+
+	Hydrate(save, advancement)
+	gains.Applied = true
+	gains.MaxHP, gains.MaxMana = save.MaxHP, save.MaxMana
+	gains.HPGained = DeriveMaxHP(save.Class, level, save.Stats) - DeriveMaxHP(save.Class, from, save.Stats)
+	gains.ManaGained = DeriveMaxMana(save.Class, level, save.Stats) - DeriveMaxMana(save.Class, from, save.Stats)
+	gains.SpellSlots = growSpellSlots(save, slotTable[level])
+	for _, ab := range abilities {
+		if ab.UnlockLevel > from && ab.UnlockLevel <= level {
+			gains.Abilities = append(gains.Abilities, ab.Name)
+		}
+	}
+	save.LevelApplied = level
+	return gains
+}
+
+// growSpellSlots adds empty slots until each slot level has at least the given
+// count, keeping the slots (and spells prepared in them) the save already has.
+// It returns how many slots it added per slot level, or nil when none.
+func growSpellSlots(save *types.SaveFile, counts map[string]int) map[string]int {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var added map[string]int
+	for _, slotLevel := range keys {
+		var slots []interface{}
+		switch existing := save.SpellSlots[slotLevel].(type) {
+		case []interface{}:
+			slots = existing
+		case []map[string]interface{}:
+			for _, s := range existing {
+				slots = append(slots, s)
+			}
+		}
+		have := len(slots)
+		for i := have; i < counts[slotLevel]; i++ {
+			slots = append(slots, map[string]interface{}{"slot": i, "spell": nil, "quantity": 0})
+		}
+		if len(slots) == have {
+			continue
+		}
+		if save.SpellSlots == nil {
+			save.SpellSlots = make(map[string]interface{})
+		}
+		save.SpellSlots[slotLevel] = slots
+		if added == nil {
+			added = make(map[string]int)
+		}
+		added[slotLevel] = len(slots) - have
+	}
+	return added
+}
@@ -40,6 +40,18 @@ func GetLevelFromXP(xp int, advancement []types.AdvancementEntry) int {
 	return level
 }
 
+// XPToNextLevel returns the XP still needed to reach the level after the one xp
+// earns, or 0 at the top of the advancement table.
+func XPToNextLevel(xp int, advancement []types.AdvancementEntry) int {
+	next := GetLevelFromXP(xp, advancement) + 1
+	for _, entry := range advancement {
+		if entry.Level == next {
+			return max(entry.ExperiencePoints-xp, 0)
+		}
+	}
+	return 0
+}
+
 // GetXPMultiplierForLevel returns the XP multiplier applied at the given level.
 func GetXPMultiplierForLevel(level int, advancement []types.AdvancementEntry) float64 {
 	for _, entry := range advancement {
@@ -78,13 +78,21 @@ func regenResource(p *types.ResourcePool, amount int) {
 }
 
 // tickPlayerAbilities runs at the end of the player's turn: per-turn resource
-// regen, and the rage duration countdown (rage clears when it hits 0). Returns
-// log lines for anything the player should see.
+// regen, ability cooldowns counting down a round, and the rage duration
+// countdown (rage clears when it hits 0). Returns log lines for anything the
+// player should see.
 func tickPlayerAbilities(state *types.PlayerCombatState) []string {
 	var log []string
 	if state.Resource != nil {
 		regenResource(state.Resource, state.Resource.PerTurn)
 	}
+	for id, rounds := range state.AbilityCooldowns {
+		if rounds <= 1 {
+			delete(state.AbilityCooldowns, id)
+		} else {
+			state.AbilityCooldowns[id] = rounds - 1
+		}
+	}
 	if state.RageTurnsLeft > 0 {
 		state.RageTurnsLeft--
 		if state.RageTurnsLeft == 0 {
@@ -108,10 +116,16 @@ func consumePlayerAction(state *types.PlayerCombatState) {
 }
 
 // applyPlayerDamageRiders folds the ability-driven damage riders into a weapon
-// hit: barbarian rage's % bonus and the rogue's readied Sneak Attack dice (once,
-// consumed on the first hit). Returns the modified damage and log lines.
+// hit: a readied Power Strike's multiplier, barbarian rage's % bonus and the
+// rogue's readied Sneak Attack dice (the strike and the sneak dice are consumed on
+// the first hit). Returns the modified damage and log lines.
 func applyPlayerDamageRiders(state *types.PlayerCombatState, dmg int, isCrit bool) (int, []string) {
 	var log []string
+	if state.PendingDamagePct > 0 {
+		dmg = dmg * state.PendingDamagePct / 100
+		log = append(log, fmt.Sprintf("  💥 Power Strike — %d%% damage.", state.PendingDamagePct))
+		state.PendingDamagePct = 0
+	}
 	if state.RageTurnsLeft > 0 && state.RageDamageBonusPct > 0 {
 		bonus := dmg * state.RageDamageBonusPct / 100
 		if bonus > 0 {
@@ -143,7 +157,10 @@ type abilityDef struct {
 	UnlockLevel  int
 	ResourceCost int
 	Cooldown     string
-	Tiers        []abilityTier
+	// CooldownRounds is how many rounds must pass before the ability can be
+	// used again (0 = no round cooldown).
+	CooldownRounds int
+	Tiers          []abilityTier
 }
 
 // loadAbility reads one ability (with its scaling tiers) from the abilities table.
@@ -162,9 +179,11 @@ func loadAbility(db *sql.DB, id string) (*abilityDef, error) {
 	}
 	if props != "" {
 		var full struct {
-			Tiers []abilityTier `json:"scaling_tiers"`
+			CooldownRounds int           `json:"cooldown_rounds"`
+			Tiers          []abilityTier `json:"scaling_tiers"`
 		}
 		if json.Unmarshal([]byte(props), &full) == nil {
+			a.CooldownRounds = full.CooldownRounds
 			a.Tiers = full.Tiers
 		}
 	}
@@ -221,10 +240,10 @@ var abilityMechanics = map[string]abilityMechanic{
 		return []string{fmt.Sprintf("  You fly into a rage — +%d%% damage, -%d%% damage taken for %d turns.", dmg, resist, turns)}
 	}},
 	"intimidating-roar": {action: "action", apply: func(cs *types.CombatSession, state *types.PlayerCombatState, save *types.SaveFile, level, tierIdx int) []string {
-		if len(cs.Monsters) == 0 || !cs.Monsters[0].IsAlive {
+		m := TargetMonster(cs)
+		if m == nil || !m.IsAlive {
 			return []string{"  You roar, but there's nothing left to frighten."}
 		}
-		m := &cs.Monsters[0]
 		dc := 8 + proficiencyBonus(level) + StatMod(GetStatFromMap(effectiveStats(save), "strength"))
 		total := monsterSaveTotal(m, "wisdom")
 		if total >= dc {
@@ -256,6 +275,11 @@ var abilityMechanics = map[string]abilityMechanic{
 		}
 		return log
 	}},
+	"power-strike": {action: "bonus", apply: func(cs *types.CombatSession, state *types.PlayerCombatState, save *types.SaveFile, level, tierIdx int) []string {
+		pct := []int{150, 200, 250, 300}[clampIdx(tierIdx, 4)]
+		state.PendingDamagePct = pct
+		return []string{fmt.Sprintf("  You wind up a power strike — your next hit deals %d%% damage.", pct)}
+	}},
 	"action-surge": {action: "", apply: func(cs *types.CombatSession, state *types.PlayerCombatState, save *types.SaveFile, level, tierIdx int) []string {
 		extra := []int{1, 1, 2, 2}[clampIdx(tierIdx, 4)]
 		state.ExtraActions += extra
@@ -287,8 +311,9 @@ var abilityMechanics = map[string]abilityMechanic{
 }
 
 // ProcessPlayerAbility resolves the player activating a class ability during combat.
-// Validates class / unlock level / resource / cooldown / action economy, applies the
-// ability's mechanic, then spends the resource. Does NOT run the monster turn — the
+// Validates class / unlock level / resource / cooldown (once per fight, or a
+// number of rounds) / action economy, applies the ability's mechanic, then spends
+// the resource and starts any round cooldown. Does NOT run the monster turn — the
 // caller ends the turn (surge/flurry leave the action open on purpose).
 func ProcessPlayerAbility(db *sql.DB, cs *types.CombatSession, save *types.SaveFile, abilityID string, advancement []types.AdvancementEntry) ([]string, error) {
 	if cs.Phase != "active" {
@@ -298,8 +323,8 @@ func ProcessPlayerAbility(db *sql.DB, cs *types.CombatSession, save *types.SaveF
 		return nil, fmt.Errorf("no player in combat")
 	}
 	state := &cs.Party[0].CombatState
-	if IsIncapacitated(state.Conditions) {
-		return nil, fmt.Errorf("you are %s and can't act", incapacitatingConditionName(state.Conditions))
+	if conds := playerConditions(cs, save); IsIncapacitated(conds) {
+		return nil, fmt.Errorf("you are %s and can't act", incapacitatingConditionName(conds))
 	}
 
 	a, err := loadAbility(db, abilityID)
@@ -318,6 +343,9 @@ func ProcessPlayerAbility(db *sql.DB, cs *types.CombatSession, save *types.SaveF
 	if a.Cooldown == "once_per_combat" && containsString(state.AbilitiesUsed, a.ID) {
 		return nil, fmt.Errorf("%s can only be used once per fight", a.Name)
 	}
+	if left := state.AbilityCooldowns[a.ID]; left > 0 {
+		return nil, fmt.Errorf("%s is on cooldown for %d more round(s)", a.Name, left)
+	}
 
 	mech, ok := abilityMechanics[a.ID]
 	if !ok {
@@ -357,6 +385,12 @@ func ProcessPlayerAbility(db *sql.DB, cs *types.CombatSession, save *types.SaveF
 	if a.Cooldown == "once_per_combat" {
 		state.AbilitiesUsed = append(state.AbilitiesUsed, a.ID)
 	}
+	if a.CooldownRounds > 0 {
+		if state.AbilityCooldowns == nil {
+			state.AbilityCooldowns = make(map[string]int)
+		}
+		state.AbilityCooldowns[a.ID] = a.CooldownRounds
+	}
 	switch mech.action {
 	case "action":
 		consumePlayerAction(state)
@@ -95,6 +95,12 @@ func TestApplyPlayerDamageRiders(t *testing.T) {
 		t.Errorf("second hit should have no sneak rider: got %d, want 5", got)
 	}
 
+	// A readied Power Strike multiplies the hit once.
+	st = types.PlayerCombatState{PendingDamagePct: 200}
+	if got, _ := applyPlayerDamageRiders(&st, 7, false); got != 14 || st.PendingDamagePct != 0 {
+		t.Errorf("power strike 200%% of 7: got %d (pending %d), want 14 and cleared", got, st.PendingDamagePct)
+	}
+
 	// No riders → damage passes through unchanged.
 	st = types.PlayerCombatState{}
 	if got, _ := applyPlayerDamageRiders(&st, 8, true); got != 8 {
@@ -120,6 +126,16 @@ func TestTickPlayerAbilities(t *testing.T) {
 		t.Error("expected a 'rage subsides' log line")
 	}
 
+	// Ability cooldowns count down a round and drop out at 0.
+	st = types.PlayerCombatState{AbilityCooldowns: map[string]int{"power-strike": 2, "shadow-step": 1}}
+	tickPlayerAbilities(&st)
+	if st.AbilityCooldowns["power-strike"] != 1 {
+		t.Errorf("cooldown should tick to 1, got %d", st.AbilityCooldowns["power-strike"])
+	}
+	if _, ok := st.AbilityCooldowns["shadow-step"]; ok {
+		t.Error("an expired cooldown should be removed")
+	}
+
 	// Rage with turns to spare only decrements.
 	st = types.PlayerCombatState{RageTurnsLeft: 3, RageDamageBonusPct: 50}
 	tickPlayerAbilities(&st)
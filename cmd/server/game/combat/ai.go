@@ -2,6 +2,7 @@ package combat
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"pubkey-quest/types"
@@ -11,10 +12,17 @@ import (
 type MonsterDecision struct {
 	Move        int    // Intent: -1 = move closer, 0 = stay, +1 = move farther (away)
 	TargetRange int    // Range the monster is trying to reach during movement
-	Action      string // "attack", "retreat", "escape", "none"
+	Action      string // "attack", "special", "retreat", "escape", "none"
 	ActionIndex int    // Index into monster.Data.Actions to use
 }
 
+// MonsterDamage is the damage one of a monster's hits deals the player. A turn
+// can land several (multiattack); the caller applies them in order.
+type MonsterDamage struct {
+	Amount int
+	Type   string
+}
+
 // fleeMinPlayerRange is the minimum Chebyshev distance to the player required
 // before a wounded monster will try to flee. If the player is adjacent/nearby,
 // the monster is cornered and fights instead.
@@ -72,11 +80,11 @@ func DecideMonsterAction(cs *types.CombatSession, monster *types.MonsterInstance
 	if aggression == "" {
 		aggression = "aggressive"
 	}
-	r := currentRange(cs)
+	r := rangeTo(cs, monster)
 	wantsToFlee := hpFraction <= monster.Data.Behavior.FleeThreshold && aggression != "berserker"
 	if wantsToFlee && r >= fleeMinPlayerRange {
 		// Already at an edge → escape this turn.
-		if atGridEdge(cs.MonsterPos, cs.GridWidth, cs.GridHeight) {
+		if atGridEdge(monster.Pos, cs.GridWidth, cs.GridHeight) {
 			return MonsterDecision{Move: 0, Action: "escape"}
 		}
 		// Otherwise break toward the edge (away from the player). TargetRange=6 lets
@@ -95,8 +103,12 @@ func DecideMonsterAction(cs *types.CombatSession, monster *types.MonsterInstance
 		move = 1
 	}
 
-	// If already at preferred range and an attack is usable, take it without moving.
+	// If already at preferred range and a special or attack is usable, take it
+	// without moving.
 	if move == 0 {
+		if idx := selectSpecialAction(cs, monster, r); idx >= 0 {
+			return MonsterDecision{Move: 0, TargetRange: preferred, Action: "special", ActionIndex: idx}
+		}
 		if idx := selectBestAction(monster.Data.Actions, r); idx >= 0 {
 			return MonsterDecision{Move: 0, TargetRange: preferred, Action: "attack", ActionIndex: idx}
 		}
@@ -106,8 +118,9 @@ func DecideMonsterAction(cs *types.CombatSession, monster *types.MonsterInstance
 	return MonsterDecision{Move: move, TargetRange: preferred, Action: "none"}
 }
 
-// RefreshAttackDecision picks the best attack for the actual range after movement.
-// Upgrades a "none" decision to "attack" when an action is now usable at the post-move range.
+// RefreshAttackDecision picks the best action for the actual range after movement.
+// Upgrades a "none" decision to "special" or "attack" when an action is now usable
+// at the post-move range.
 // Upgrades a "retreat" decision to "escape" when the monster reached an edge with the
 // player still at least fleeMinPlayerRange cells away.
 func RefreshAttackDecision(cs *types.CombatSession, monster *types.MonsterInstance, decision MonsterDecision) MonsterDecision {
@@ -115,13 +128,19 @@ func RefreshAttackDecision(cs *types.CombatSession, monster *types.MonsterInstan
 	case "escape":
 		return decision
 	case "retreat":
-		if currentRange(cs) >= fleeMinPlayerRange &&
-			atGridEdge(cs.MonsterPos, cs.GridWidth, cs.GridHeight) {
+		if rangeTo(cs, monster) >= fleeMinPlayerRange &&
+			atGridEdge(monster.Pos, cs.GridWidth, cs.GridHeight) {
 			decision.Action = "escape"
 		}
 		return decision
 	}
-	idx := selectBestAction(monster.Data.Actions, currentRange(cs))
+	r := rangeTo(cs, monster)
+	if idx := selectSpecialAction(cs, monster, r); idx >= 0 {
+		decision.Action = "special"
+		decision.ActionIndex = idx
+		return decision
+	}
+	idx := selectBestAction(monster.Data.Actions, r)
 	if idx >= 0 {
 		decision.Action = "attack"
 		decision.ActionIndex = idx
@@ -131,34 +150,167 @@ func RefreshAttackDecision(cs *types.CombatSession, monster *types.MonsterInstan
 	return decision
 }
 
-// selectBestAction returns the index of the first action usable at the given range.
-// Returns -1 if no action is usable.
+// selectBestAction returns the index of the first attack usable at the given range.
+// Returns -1 if no attack is usable.
 func selectBestAction(actions []types.MonsterAction, currentRange int) int {
 	for i, action := range actions {
-		switch action.Type {
-		case "melee_attack":
-			reach := 1 // Default melee = adjacent (Range 0–1)
-			if action.Reach != nil && *action.Reach > 0 {
-				reach = *action.Reach
-			}
-			if currentRange <= reach {
-				return i
-			}
-		case "ranged_attack":
-			maxRange := 3 // Fallback
-			if action.RangeLong != nil {
-				maxRange = *action.RangeLong
-			} else if action.Range != nil {
-				maxRange = *action.Range
+		if attackUsableAt(action, currentRange) {
+			return i
+		}
+	}
+	return -1
+}
+
+// attackUsableAt reports whether the action is an attack that reaches the given
+// range. Multiattack and special entries are never attacks themselves.
+func attackUsableAt(action types.MonsterAction, currentRange int) bool {
+	switch action.Type {
+	case "melee_attack":
+		reach := 1 // Default melee = adjacent (Range 0–1)
+		if action.Reach != nil && *action.Reach > 0 {
+			reach = *action.Reach
+		}
+		return currentRange <= reach
+	case "ranged_attack":
+		maxRange := 3 // Fallback
+		if action.RangeLong != nil {
+			maxRange = *action.RangeLong
+		} else if action.Range != nil {
+			maxRange = *action.Range
+		}
+		return currentRange <= maxRange
+	}
+	return false
+}
+
+// attackSequence returns the attacks (indexes into actions) the monster makes
+// this turn. Without a multiattack entry that's just the chosen attack. With one,
+// it's the multiattack's sequence — a named attack that can't reach is swapped
+// for the chosen one — or, with no sequence, its attack count cycling through the
+// attacks usable at this range, starting from the chosen one.
+func attackSequence(actions []types.MonsterAction, chosen, currentRange int) []int {
+	multi := -1
+	for i, a := range actions {
+		if a.Type == "multiattack" && a.Attacks > 1 {
+			multi = i
+			break
+		}
+	}
+	if multi < 0 {
+		return []int{chosen}
+	}
+
+	if names := actions[multi].Sequence; len(names) > 0 {
+		seq := make([]int, 0, len(names))
+		for _, name := range names {
+			idx := chosen
+			for i, a := range actions {
+				if strings.EqualFold(a.Name, name) && attackUsableAt(a, currentRange) {
+					idx = i
+					break
+				}
 			}
-			if currentRange <= maxRange {
-				return i
+			seq = append(seq, idx)
+		}
+		return seq
+	}
+
+	usable := []int{chosen}
+	for i, a := range actions {
+		if i != chosen && attackUsableAt(a, currentRange) {
+			usable = append(usable, i)
+		}
+	}
+	seq := make([]int, 0, actions[multi].Attacks)
+	for k := 0; k < actions[multi].Attacks; k++ {
+		seq = append(seq, usable[k%len(usable)])
+	}
+	return seq
+}
+
+// selectSpecialAction returns the index of a special the monster uses this turn
+// in place of attacking, or -1. A special is ready when the player is within its
+// mechanic's range and it isn't spent. One that can be used freely (recharge
+// "none") is held back while the player already suffers its condition. None is
+// wasted on an unconscious player — the monster attacks them instead.
+func selectSpecialAction(cs *types.CombatSession, monster *types.MonsterInstance, currentRange int) int {
+	if len(cs.Party) == 0 || cs.Party[0].CombatState.IsUnconscious {
+		return -1
+	}
+	for i, action := range monster.Data.Actions {
+		mech := action.Mechanic
+		if action.Type != "special" || mech == nil || mech.Type != "area_save" {
+			continue
+		}
+		if currentRange > mech.Range || actionSpent(monster, action.Name) {
+			continue
+		}
+		if action.Recharge == "none" || action.Recharge == "" {
+			cond := monsterEffectCondition[strings.ToLower(mech.Effect)]
+			if cond != "" && HasCondition(cs.Party[0].CombatState.Conditions, cond) {
+				continue
 			}
 		}
+		return i
 	}
 	return -1
 }
 
+// actionSpent reports whether the named special is used up.
+func actionSpent(monster *types.MonsterInstance, name string) bool {
+	for _, spent := range monster.SpentActions {
+		if spent == name {
+			return true
+		}
+	}
+	return false
+}
+
+// spendAction marks the named special used up until it recharges.
+func spendAction(monster *types.MonsterInstance, name string) {
+	if !actionSpent(monster, name) {
+		monster.SpentActions = append(monster.SpentActions, name)
+	}
+}
+
+// rechargeThreshold parses a "recharge_N_6" (or "recharge_N") value into the
+// lowest d6 roll that recharges the special. ok is false for any other value.
+func rechargeThreshold(recharge string) (int, bool) {
+	rest, found := strings.CutPrefix(recharge, "recharge_")
+	if !found {
+		return 0, false
+	}
+	n, err := strconv.Atoi(strings.TrimSuffix(rest, "_6"))
+	if err != nil || n < 2 || n > 6 {
+		return 0, false
+	}
+	return n, true
+}
+
+// RechargeMonsterActions rolls a d6 at the start of the monster's turn for each
+// spent "recharge_N_6" special; on N or higher it's ready again. Specials spent
+// for the rest of the fight stay spent. Returns log entries.
+func RechargeMonsterActions(monster *types.MonsterInstance) []string {
+	var log []string
+	kept := monster.SpentActions[:0]
+	for _, name := range monster.SpentActions {
+		threshold := 0
+		for _, a := range monster.Data.Actions {
+			if a.Name == name {
+				threshold, _ = rechargeThreshold(a.Recharge)
+				break
+			}
+		}
+		if threshold > 0 && RollD(6) >= threshold {
+			log = append(log, fmt.Sprintf("  %s's %s recharges!", monster.Name, name))
+			continue
+		}
+		kept = append(kept, name)
+	}
+	monster.SpentActions = kept
+	return log
+}
+
 // MonsterMeleeReach returns the max reach among the monster's melee actions.
 // Returns 0 if the monster has no melee actions.
 func MonsterMeleeReach(monster *types.MonsterInstance) int {
@@ -200,10 +352,11 @@ func ApplyMonsterMove(cs *types.CombatSession, monster *types.MonsterInstance, d
 	preferred := decision.TargetRange
 	var oaLog []string
 	oaFired := false
+	from := monster.Pos
 
 	moved := 0
 	for i := 0; i < maxSteps; i++ {
-		r := chebyshev(cs.PlayerPos, cs.MonsterPos)
+		r := rangeTo(cs, monster)
 		if decision.Move == -1 && r <= preferred {
 			break
 		}
@@ -211,18 +364,18 @@ func ApplyMonsterMove(cs *types.CombatSession, monster *types.MonsterInstance, d
 			break
 		}
 
-		newPos := stepMonster(cs.MonsterPos, cs.PlayerPos, decision.Move, cs.GridWidth, cs.GridHeight)
-		if newPos == cs.MonsterPos {
+		newPos := stepMonster(monster.Pos, cs.PlayerPos, decision.Move, cs.GridWidth, cs.GridHeight)
+		if newPos == monster.Pos {
 			break
 		}
-		if newPos == cs.PlayerPos {
+		if newPos == cs.PlayerPos || monsterAt(cs, newPos) != nil {
 			break
 		}
 		prevR := r
-		cs.MonsterPos = newPos
+		monster.Pos = newPos
 		moved++
 
-		newR := chebyshev(cs.PlayerPos, cs.MonsterPos)
+		newR := rangeTo(cs, monster)
 
 		// Opportunity attack check: monster left the player's melee reach.
 		if !oaFired && oaTrigger != nil && playerMeleeReach > 0 &&
@@ -241,11 +394,12 @@ func ApplyMonsterMove(cs *types.CombatSession, monster *types.MonsterInstance, d
 	if moved == 0 {
 		return oaLog
 	}
+	emitMove(cs, monster.InstanceID, from, monster.Pos, rangeTo(cs, monster))
 	dir := "toward you"
 	if decision.Move > 0 {
 		dir = "away from you"
 	}
-	out := []string{fmt.Sprintf("  %s moves %s. (range: %d)", monster.Name, dir, currentRange(cs))}
+	out := []string{fmt.Sprintf("  %s moves %s. (range: %d)", monster.Name, dir, rangeTo(cs, monster))}
 	return append(out, oaLog...)
 }
 
@@ -287,19 +441,20 @@ func clampInt(v, min, max int) int {
 	return v
 }
 
-// ApplyMonsterAction executes the monster's chosen action (attack/flee/none).
-// Movement must already have been applied. Returns damage dealt and log entries.
+// ApplyMonsterAction executes the monster's chosen action (attack/special/flee/none).
+// Movement must already have been applied. Returns the damage each hit dealt, in
+// order, and log entries. An attack with a multiattack entry makes several attacks.
 //
 // useReflex: when true, the player makes a reflex save (d20+reflexDEXMod vs DC 12)
 // before damage resolves — on success the attack misses entirely. Pass false normally.
-func ApplyMonsterAction(cs *types.CombatSession, monster *types.MonsterInstance, decision MonsterDecision, playerAC int, useReflex bool, reflexDEXMod int, save *types.SaveFile) (damageDealt int, logEntries []string) {
+func ApplyMonsterAction(cs *types.CombatSession, monster *types.MonsterInstance, decision MonsterDecision, playerAC int, useReflex bool, reflexDEXMod int, save *types.SaveFile) (damage []MonsterDamage, logEntries []string) {
 	// Stunned / paralyzed / unconscious monsters lose their action entirely.
 	if IsIncapacitated(monster.Conditions) {
-		return 0, []string{fmt.Sprintf("  %s is %s and can't act.", monster.Name, incapacitatingConditionName(monster.Conditions))}
+		return nil, []string{fmt.Sprintf("  %s is %s and can't act.", monster.Name, incapacitatingConditionName(monster.Conditions))}
 	}
-	// A charmed monster can't bring itself to attack the one who charmed it.
-	if decision.Action == "attack" && HasCondition(monster.Conditions, "charmed") {
-		return 0, []string{fmt.Sprintf("  %s is charmed and won't attack you.", monster.Name)}
+	// A charmed monster can't bring itself to harm the one who charmed it.
+	if (decision.Action == "attack" || decision.Action == "special") && HasCondition(monster.Conditions, "charmed") {
+		return nil, []string{fmt.Sprintf("  %s is charmed and won't attack you.", monster.Name)}
 	}
 	switch decision.Action {
 	case "retreat":
@@ -307,80 +462,179 @@ func ApplyMonsterAction(cs *types.CombatSession, monster *types.MonsterInstance,
 		logEntries = append(logEntries, fmt.Sprintf("  %s is wounded and tries to flee!", monster.Name))
 
 	case "escape":
-		// Monster reached the edge with the player too far to stop it — combat ends with no kill.
+		// Monster reached the edge with the player too far to stop it — it leaves
+		// the fight without a kill, which ends once no monster is left standing.
 		logEntries = append(logEntries, fmt.Sprintf("  %s escapes off the edge of the battlefield!", monster.Name))
-		cs.Phase = "loot"
-		cs.LootRolled = nil
+		monster.IsAlive = false
+		monster.Escaped = true
+		if livingMonsters(cs) == 0 {
+			cs.Phase = "loot"
+		}
 
 	case "none":
 		logEntries = append(logEntries, fmt.Sprintf("  %s has no action available.", monster.Name))
 
 	case "attack":
-		action := monster.Data.Actions[decision.ActionIndex]
-
-		// If the player is dodging this turn, the monster attacks at disadvantage.
-		monsterAdvantage := 0
-		var playerConds []types.CombatCondition
-		if len(cs.Party) > 0 {
-			if cs.Party[0].CombatState.Dodging {
-				monsterAdvantage = -1
-			}
-			playerConds = cs.Party[0].CombatState.Conditions
-		}
-		// Conditions: the monster's own (poisoned/frightened/…) impose disadvantage;
-		// the player's (prone/restrained/…) grant the monster advantage.
-		monsterAdvantage += ConditionAttackAdvantage(monster.Conditions, playerConds)
-		result := ResolveAttackRoll(action.AttackBonus, playerAC, monsterAdvantage)
-
-		logEntries = append(logEntries,
-			fmt.Sprintf(
-				"  %s attacks with %s: rolled %d%s",
-				monster.Name, action.Name,
-				result.Roll, formatModifier(action.AttackBonus),
-			),
-			outcomeLine(result),
-		)
-
-		if result.IsHit {
-			// Reflex save: player hasn't chosen their stance, so they may dodge on instinct.
-			if useReflex {
-				reflexRoll := RollD20() + reflexDEXMod
-				logEntries = append(logEntries, fmt.Sprintf(
-					"  You react on instinct — reflex save: rolled %d (DC 12).", reflexRoll,
-				))
-				if reflexRoll >= 12 {
-					logEntries = append(logEntries, "  You twist away just in time — the attack misses!")
-					break // attack negated
-				}
-				logEntries = append(logEntries, "  Not quick enough to fully evade!")
+		seq := attackSequence(monster.Data.Actions, decision.ActionIndex, rangeTo(cs, monster))
+		if len(seq) > 1 {
+			logEntries = append(logEntries, fmt.Sprintf("  %s makes %d attacks!", monster.Name, len(seq)))
+		}
+		for _, idx := range seq {
+			hit, lines := resolveMonsterAttack(cs, monster, monster.Data.Actions[idx], playerAC, useReflex, reflexDEXMod, save)
+			logEntries = append(logEntries, lines...)
+			if hit.Amount > 0 {
+				damage = append(damage, hit)
 			}
+		}
 
-			dmg := ResolveDamageToPlayer(action.Hit.Dice, action.Hit.Mod, result.IsCrit)
-			damageDealt = dmg
-			critStr := ""
-			if result.IsCrit {
-				critStr = " CRITICAL HIT!"
-			}
-			logEntries = append(logEntries, fmt.Sprintf(
-				"  %s deals %d %s damage.%s",
-				monster.Name, dmg, action.Hit.Type, critStr,
-			))
-			// On-hit condition rider: the player saves or gains the condition.
-			logEntries = append(logEntries, applyMonsterConditionRider(cs, save, action)...)
+	case "special":
+		return resolveMonsterSpecial(cs, monster, monster.Data.Actions[decision.ActionIndex], save)
+	}
+	return damage, logEntries
+}
+
+// resolveMonsterAttack rolls one of the monster's attacks against the player and,
+// on a hit, its damage and on-hit condition rider. The damage is returned for the
+// caller to apply (zero on a miss).
+func resolveMonsterAttack(cs *types.CombatSession, monster *types.MonsterInstance, action types.MonsterAction, playerAC int, useReflex bool, reflexDEXMod int, save *types.SaveFile) (MonsterDamage, []string) {
+	// If the player is dodging this turn, the monster attacks at disadvantage.
+	monsterAdvantage := 0
+	if len(cs.Party) > 0 && cs.Party[0].CombatState.Dodging {
+		monsterAdvantage = -1
+	}
+	// Conditions: the monster's own (poisoned/frightened/…) impose disadvantage;
+	// the player's (prone/restrained/…, or imposed by an effect) grant the
+	// monster advantage.
+	monsterAdvantage += ConditionAttackAdvantage(monster.Conditions, playerConditions(cs, save))
+	result := ResolveAttackRoll(action.AttackBonus, playerAC, monsterAdvantage)
+
+	logEntries := []string{
+		fmt.Sprintf(
+			"  %s attacks with %s: rolled %d%s",
+			monster.Name, action.Name,
+			result.Roll, formatModifier(action.AttackBonus),
+		),
+		outcomeLine(result),
+	}
+	emitAttack(cs, monster.InstanceID, playerActor, result, rangeTo(cs, monster))
+	if !result.IsHit {
+		return MonsterDamage{}, logEntries
+	}
+
+	// Reflex save: player hasn't chosen their stance, so they may dodge on instinct.
+	if useReflex {
+		reflexRoll := RollD20() + reflexDEXMod
+		logEntries = append(logEntries, fmt.Sprintf(
+			"  You react on instinct — reflex save: rolled %d (DC 12).", reflexRoll,
+		))
+		if reflexRoll >= 12 {
+			emitEvent(cs, types.CombatEvent{Type: "miss", Actor: monster.InstanceID, Target: playerActor})
+			return MonsterDamage{}, append(logEntries, "  You twist away just in time — the attack misses!")
+		}
+		logEntries = append(logEntries, "  Not quick enough to fully evade!")
+	}
+
+	dmg := ResolveDamageToPlayer(action.Hit.Dice, action.Hit.Mod, result.IsCrit)
+	critStr := ""
+	if result.IsCrit {
+		critStr = " CRITICAL HIT!"
+	}
+	logEntries = append(logEntries, fmt.Sprintf(
+		"  %s deals %d %s damage.%s",
+		monster.Name, dmg, action.Hit.Type, critStr,
+	))
+	emitDamage(cs, monster.InstanceID, playerActor, dmg, action.Hit.Type)
+	// On-hit condition rider: the player saves or gains the condition.
+	logEntries = append(logEntries, applyMonsterConditionRider(cs, save, action)...)
+	return MonsterDamage{Amount: dmg, Type: action.Hit.Type}, logEntries
+}
+
+// resolveMonsterSpecial resolves a special action's area_save mechanic: the
+// player saves against its DC. On a failure they take its damage and/or gain its
+// condition (re-saving at the end of each of their turns), or drop straight to 0
+// HP for "drop_to_zero"; on a success they take the on-success damage, if any.
+// The special is then spent per its recharge, and a player who saves against one
+// that can be used freely is immune to it for the rest of the fight.
+func resolveMonsterSpecial(cs *types.CombatSession, monster *types.MonsterInstance, action types.MonsterAction, save *types.SaveFile) (damage []MonsterDamage, logEntries []string) {
+	logEntries = append(logEntries, fmt.Sprintf("  %s uses %s!", monster.Name, action.Name))
+	if action.Recharge != "none" && action.Recharge != "" {
+		spendAction(monster, action.Name)
+	}
+	mech := action.Mechanic
+	if mech == nil || save == nil || len(cs.Party) == 0 {
+		return nil, logEntries
+	}
+
+	stat := strings.ToLower(mech.Ability)
+	if stat == "" {
+		stat = "constitution"
+	}
+	dc := mech.DC
+	if dc <= 0 {
+		dc = 11
+	}
+	total := playerSaveTotal(cs, save, stat)
+
+	if total >= dc {
+		logEntries = append(logEntries, fmt.Sprintf("  You resist it (%s save %d vs DC %d).", stat, total, dc))
+		if action.Recharge == "none" || action.Recharge == "" {
+			spendAction(monster, action.Name)
 		}
+		if mech.OnSuccessDice != "" {
+			dmg := ResolveDamageToPlayer(mech.OnSuccessDice, mech.OnSuccessMod, false)
+			damage = append(damage, MonsterDamage{Amount: dmg, Type: mech.OnSuccessType})
+			logEntries = append(logEntries, fmt.Sprintf("  %s deals %d %s damage.", monster.Name, dmg, mech.OnSuccessType))
+			emitDamage(cs, monster.InstanceID, playerActor, dmg, mech.OnSuccessType)
+		}
+		return damage, logEntries
+	}
+
+	logEntries = append(logEntries, fmt.Sprintf("  ✘ You fail the %s save (%d vs DC %d)!", stat, total, dc))
+	if mech.OnFail == "drop_to_zero" {
+		state := &cs.Party[0].CombatState
+		state.CurrentHP = 0
+		state.IsUnconscious = true
+		cs.Phase = "death_saves"
+		return nil, append(logEntries, "  You fall unconscious. Make death saving throws.")
+	}
+	if mech.Dice != "" {
+		dmg := ResolveDamageToPlayer(mech.Dice, mech.Mod, false)
+		damage = append(damage, MonsterDamage{Amount: dmg, Type: mech.DamageType})
+		logEntries = append(logEntries, fmt.Sprintf("  %s deals %d %s damage.", monster.Name, dmg, mech.DamageType))
+		emitDamage(cs, monster.InstanceID, playerActor, dmg, mech.DamageType)
+	}
+	if cond := monsterEffectCondition[strings.ToLower(mech.Effect)]; cond != "" {
+		ApplyCondition(&cs.Party[0].CombatState.Conditions, types.CombatCondition{
+			Name: cond, DurationRounds: mechanicRounds(mech.Duration), SaveDC: dc, SaveStat: stat,
+		})
+		logEntries = append(logEntries, fmt.Sprintf("  ✘ You are %s!", cond))
+	}
+	return damage, logEntries
+}
+
+// mechanicRounds converts a special's authored duration into rounds: a minute is
+// 10 rounds, "concentration_or_save" lasts until saved against (-1), and
+// anything else defaults to 3.
+func mechanicRounds(duration string) int {
+	switch duration {
+	case "1_minute":
+		return 10
+	case "concentration_or_save":
+		return -1
 	}
-	return damageDealt, logEntries
+	return 3
 }
 
-// ExecuteMonsterTurn runs the monster's full turn (move + action).
-// Returns damage dealt and all log entries. No opportunity attacks are resolved
+// ExecuteMonsterTurn runs the monster's full turn (recharge + move + action).
+// Returns the damage of each hit, and all log entries. No opportunity attacks are resolved
 // here (opening/death-save turns — player either hasn't started or is down).
-func ExecuteMonsterTurn(cs *types.CombatSession, monster *types.MonsterInstance, playerAC int, useReflex bool, reflexDEXMod int, save *types.SaveFile) (damageDealt int, logEntries []string) {
+func ExecuteMonsterTurn(cs *types.CombatSession, monster *types.MonsterInstance, playerAC int, useReflex bool, reflexDEXMod int, save *types.SaveFile) (damage []MonsterDamage, logEntries []string) {
+	logEntries = RechargeMonsterActions(monster)
 	decision := DecideMonsterAction(cs, monster)
 	logEntries = append(logEntries, ApplyMonsterMove(cs, monster, decision, 0, nil)...)
 	decision = RefreshAttackDecision(cs, monster, decision)
-	dmg, actionLog := ApplyMonsterAction(cs, monster, decision, playerAC, useReflex, reflexDEXMod, save)
-	return dmg, append(logEntries, actionLog...)
+	damage, actionLog := ApplyMonsterAction(cs, monster, decision, playerAC, useReflex, reflexDEXMod, save)
+	return damage, append(logEntries, actionLog...)
 }
 
 // formatModifier turns an integer into "+N" or "-N" string.
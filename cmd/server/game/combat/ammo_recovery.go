@@ -0,0 +1,129 @@
+package combat
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"sync"
+)
+
+// ─── Ammo recovery ───────────────────────────────────────────────────────────
+//
+// Rounds fired in a fight can be picked back up when it ends. How many is
+// tunable from combat_system.ammo_recovery in game-data/systems/combat.json:
+//
+//   - victory_rate: the share of each ammo type recovered after a win (0.5).
+//   - flee_rate: the share grabbed on the way out when the player flees (0.25).
+//   - drop_unrecovered: leave the rest on the ground where the fight happened,
+//     so the player can come back for them, instead of losing them.
+
+// AmmoRecovery is the parsed ammo_recovery block.
+type AmmoRecovery struct {
+	VictoryRate     float64 `json:"victory_rate"`
+	FleeRate        float64 `json:"flee_rate"`
+	DropUnrecovered bool    `json:"drop_unrecovered"`
+}
+
+// DefaultAmmoRecovery is used when combat.json has no (or an invalid)
+// ammo_recovery block: half back on a win, a quarter when fleeing, and the
+// rest lost.
+func DefaultAmmoRecovery() AmmoRecovery {
+	return AmmoRecovery{VictoryRate: 0.5, FleeRate: 0.25}
+}
+
+var (
+	ammoRecoveryMu     sync.RWMutex
+	ammoRecovery       = DefaultAmmoRecovery()
+	ammoRecoveryLoaded bool
+)
+
+// SetAmmoRecovery replaces the active ammo recovery rules (tests, systems
+// editor reloads).
+func SetAmmoRecovery(rules AmmoRecovery) {
+	ammoRecoveryMu.Lock()
+	ammoRecovery = rules
+	ammoRecoveryLoaded = true
+	ammoRecoveryMu.Unlock()
+}
+
+// LoadAmmoRecovery reads combat_system.ammo_recovery from the systems table
+// once and caches it. Missing config keeps the defaults; invalid config is
+// logged and ignored.
+func LoadAmmoRecovery(db *sql.DB) AmmoRecovery {
+	ammoRecoveryMu.RLock()
+	loaded, rules := ammoRecoveryLoaded, ammoRecovery
+	ammoRecoveryMu.RUnlock()
+	if loaded || db == nil {
+		return rules
+	}
+
+	rules = DefaultAmmoRecovery()
+	var propsJSON string
+	if err := db.QueryRow("SELECT properties FROM systems WHERE id = 'combat'").Scan(&propsJSON); err == nil {
+		var config struct {
+			CombatSystem struct {
+				AmmoRecovery json.RawMessage `json:"ammo_recovery"`
+			} `json:"combat_system"`
+		}
+		if err := json.Unmarshal([]byte(propsJSON), &config); err != nil {
+			log.Printf("⚠️ Failed to parse combat system config: %v", err)
+		} else if len(config.CombatSystem.AmmoRecovery) > 0 {
+			// Omitted keys keep their defaults.
+			configured := DefaultAmmoRecovery()
+			if err := json.Unmarshal(config.CombatSystem.AmmoRecovery, &configured); err != nil {
+				log.Printf("⚠️ Failed to parse ammo_recovery config, using defaults: %v", err)
+			} else if err := ValidateAmmoRecovery(configured); err != nil {
+				log.Printf("⚠️ Invalid ammo_recovery config, using defaults: %v", err)
+			} else {
+				rules = configured
+			}
+		}
+	}
+	SetAmmoRecovery(rules)
+	return rules
+}
+
+// ValidateAmmoRecovery checks both rates are shares between 0 and 1.
+func ValidateAmmoRecovery(rules AmmoRecovery) error {
+	if rules.VictoryRate < 0 || rules.VictoryRate > 1 {
+		return fmt.Errorf("victory_rate %v must be between 0 and 1", rules.VictoryRate)
+	}
+	if rules.FleeRate < 0 || rules.FleeRate > 1 {
+		return fmt.Errorf("flee_rate %v must be between 0 and 1", rules.FleeRate)
+	}
+	return nil
+}
+
+// AmmoShare is one ammo type's part of a recovery.
+type AmmoShare struct {
+	Item     string `json:"item"`
+	Quantity int    `json:"quantity"`
+}
+
+// SplitAmmoRecovery divides the rounds fired per ammo item into what is
+// recovered at rate (rounded down) and what isn't, in item order.
+func SplitAmmoRecovery(used map[string]int, rate float64) (recovered, unrecovered []AmmoShare) {
+	items := make([]string, 0, len(used))
+	for item := range used {
+		items = append(items, item)
+	}
+	sort.Strings(items)
+
+	for _, item := range items {
+		fired := used[item]
+		if fired <= 0 {
+			continue
+		}
+		back := int(math.Floor(float64(fired)*rate + 1e-9)) // 0.29×100 is 28.999…
+		if back > 0 {
+			recovered = append(recovered, AmmoShare{Item: item, Quantity: back})
+		}
+		if fired > back {
+			unrecovered = append(unrecovered, AmmoShare{Item: item, Quantity: fired - back})
+		}
+	}
+	return recovered, unrecovered
+}
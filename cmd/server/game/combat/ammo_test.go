@@ -43,8 +43,8 @@ func TestConsumeAmmoFromQuiver(t *testing.T) {
 	if round["item"] != "arrows" || slotQty(round, "quantity") != 2 {
 		t.Errorf("quiver arrows = %v x%d, want arrows x2", round["item"], slotQty(round, "quantity"))
 	}
-	if cs.AmmoUsedThisCombat != 1 {
-		t.Errorf("AmmoUsedThisCombat = %d, want 1", cs.AmmoUsedThisCombat)
+	if cs.AmmoUsedThisCombat != 1 || cs.AmmoUsedByItem["arrows"] != 1 {
+		t.Errorf("ammo used = %d (by item %v), want 1 arrows", cs.AmmoUsedThisCombat, cs.AmmoUsedByItem)
 	}
 }
 
@@ -86,3 +86,37 @@ func TestAmmoMatchesWeaponLoose(t *testing.T) {
 		}
 	}
 }
+
+// Recovery is per ammo type and rounds down; the rest is what could be left on
+// the ground.
+func TestSplitAmmoRecovery(t *testing.T) {
+	used := map[string]int{"arrows": 5, "crossbow-bolts": 1, "sling-bullet": 0}
+
+	back, rest := SplitAmmoRecovery(used, 0.5)
+	if len(back) != 1 || back[0] != (AmmoShare{Item: "arrows", Quantity: 2}) {
+		t.Errorf("recovered = %v, want [arrows x2]", back)
+	}
+	want := []AmmoShare{{Item: "arrows", Quantity: 3}, {Item: "crossbow-bolts", Quantity: 1}}
+	if len(rest) != len(want) || rest[0] != want[0] || rest[1] != want[1] {
+		t.Errorf("unrecovered = %v, want %v", rest, want)
+	}
+
+	if back, _ := SplitAmmoRecovery(map[string]int{"arrows": 100}, 0.29); len(back) != 1 || back[0].Quantity != 29 {
+		t.Errorf("29%% of 100 = %v, want 29", back)
+	}
+	if back, rest := SplitAmmoRecovery(used, 0); len(back) != 0 || len(rest) != 2 {
+		t.Errorf("rate 0: recovered %v, unrecovered %v", back, rest)
+	}
+}
+
+func TestValidateAmmoRecovery(t *testing.T) {
+	if err := ValidateAmmoRecovery(DefaultAmmoRecovery()); err != nil {
+		t.Errorf("defaults invalid: %v", err)
+	}
+	if err := ValidateAmmoRecovery(AmmoRecovery{VictoryRate: 1.5}); err == nil {
+		t.Error("victory_rate above 1 should be invalid")
+	}
+	if err := ValidateAmmoRecovery(AmmoRecovery{VictoryRate: 0.5, FleeRate: -0.1}); err == nil {
+		t.Error("negative flee_rate should be invalid")
+	}
+}
@@ -0,0 +1,213 @@
+package combat
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	gamedata "pubkey-quest/cmd/server/api/data"
+	gaminventory "pubkey-quest/cmd/server/game/inventory"
+	"pubkey-quest/types"
+)
+
+// ─── Armor proficiency ───────────────────────────────────────────────────────
+//
+// Armor still gives its AC to anyone who straps it on, but wearing armor your
+// class isn't trained in hampers you: attack rolls and Strength/Dexterity saves
+// have disadvantage while it's on. Equipping it succeeds with a warning.
+
+// Armor proficiency categories. Leg pieces share their chest piece's category.
+const (
+	ArmorLight   = "light"
+	ArmorMedium  = "medium"
+	ArmorHeavy   = "heavy"
+	ArmorShields = "shields"
+)
+
+// classArmorProficiencies maps lowercased class names to the armor categories
+// they're trained in.
+var classArmorProficiencies = map[string][]string{
+	"barbarian": {ArmorLight, ArmorMedium, ArmorShields},
+	"fighter":   {ArmorLight, ArmorMedium, ArmorHeavy, ArmorShields},
+	"paladin":   {ArmorLight, ArmorMedium, ArmorHeavy, ArmorShields},
+	"ranger":    {ArmorLight, ArmorMedium, ArmorShields},
+	"rogue":     {ArmorLight},
+	"bard":      {ArmorLight},
+	"cleric":    {ArmorLight, ArmorMedium, ArmorShields},
+	"druid":     {ArmorLight, ArmorMedium, ArmorShields},
+	"monk":      {},
+	"sorcerer":  {},
+	"warlock":   {ArmorLight},
+	"wizard":    {},
+}
+
+// ClassArmorProficiencies returns the armor categories the class is trained in.
+func ClassArmorProficiencies(class string) []string {
+	return append([]string{}, classArmorProficiencies[strings.ToLower(class)]...)
+}
+
+// ArmorCategory returns the proficiency category an item falls under — "light",
+// "medium", "heavy" (from its "type") or "shields" (tagged "shield") — or ""
+// when it isn't armor.
+func ArmorCategory(item map[string]interface{}) string {
+	if hasTag(item["tags"], "shield") {
+		return ArmorShields
+	}
+	itemType, _ := item["type"].(string)
+	switch strings.ToLower(itemType) {
+	case "light armor":
+		return ArmorLight
+	case "medium armor":
+		return ArmorMedium
+	case "heavy armor":
+		return ArmorHeavy
+	}
+	return ""
+}
+
+// IsProficientWithArmor reports whether the class is trained in an armor
+// category. Non-armor ("") needs no training.
+func IsProficientWithArmor(class, category string) bool {
+	if category == "" {
+		return true
+	}
+	for _, prof := range classArmorProficiencies[strings.ToLower(class)] {
+		if prof == category {
+			return true
+		}
+	}
+	return false
+}
+
+// NonProficientArmor returns the IDs of equipped armor the player's class isn't
+// trained in, in acSlots order.
+func NonProficientArmor(db *sql.DB, save *types.SaveFile) []string {
+	if db == nil || save == nil {
+		return nil
+	}
+	var ids []string
+	for _, slot := range acSlots {
+		itemID := gaminventory.GetEquippedItemID(save.Inventory, slot)
+		if itemID == "" {
+			continue
+		}
+		item, err := gamedata.LoadItemByID(db, itemID)
+		if err != nil {
+			continue
+		}
+		if !IsProficientWithArmor(save.Class, ArmorCategory(item)) {
+			ids = append(ids, itemID)
+		}
+	}
+	return ids
+}
+
+// refreshArmorProficiency records on the player's combat state whether their
+// armor hampers them, so gear swapped mid-fight counts.
+func refreshArmorProficiency(db *sql.DB, cs *types.CombatSession, save *types.SaveFile) {
+	if len(cs.Party) == 0 {
+		return
+	}
+	cs.Party[0].CombatState.ArmorHampered = len(NonProficientArmor(db, save)) > 0
+}
+
+// armorHampersSave reports whether a saving throw on stat has disadvantage
+// from unfamiliar armor (Strength and Dexterity saves only).
+func armorHampersSave(cs *types.CombatSession, stat string) bool {
+	if cs == nil || len(cs.Party) == 0 || !cs.Party[0].CombatState.ArmorHampered {
+		return false
+	}
+	switch strings.ToLower(stat) {
+	case "strength", "str", "dexterity", "dex":
+		return true
+	}
+	return false
+}
+
+// CheckProficiencyTables checks the class proficiency tables against the item
+// data and the playable registry: every armor category must be a known one,
+// every individually named weapon must exist and be a weapon, and the tables
+// must cover exactly the playable classes. Returns one line per problem.
+func CheckProficiencyTables(db *sql.DB) []string {
+	var problems []string
+	for _, class := range sortedClasses(classWeaponProficiencies) {
+		for _, prof := range classWeaponProficiencies[class] {
+			if prof == "simple" || prof == "martial" {
+				continue
+			}
+			item, err := gamedata.LoadItemByID(db, prof)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: weapon proficiency %q is not an item", class, prof))
+				continue
+			}
+			if itemType, _ := item["type"].(string); !strings.Contains(strings.ToLower(itemType), "weapon") {
+				problems = append(problems, fmt.Sprintf("%s: weapon proficiency %q is a %q, not a weapon", class, prof, itemType))
+			}
+		}
+	}
+	for _, class := range sortedClasses(classArmorProficiencies) {
+		for _, prof := range classArmorProficiencies[class] {
+			switch prof {
+			case ArmorLight, ArmorMedium, ArmorHeavy, ArmorShields:
+			default:
+				problems = append(problems, fmt.Sprintf("%s: unknown armor proficiency %q", class, prof))
+			}
+		}
+	}
+
+	classes, err := playableClasses(db)
+	if err != nil {
+		return append(problems, fmt.Sprintf("playable classes: %v", err))
+	}
+	for _, class := range classes {
+		if _, ok := classWeaponProficiencies[class]; !ok {
+			problems = append(problems, fmt.Sprintf("%s: no weapon proficiency entry", class))
+		}
+		if _, ok := classArmorProficiencies[class]; !ok {
+			problems = append(problems, fmt.Sprintf("%s: no armor proficiency entry", class))
+		}
+	}
+	playable := make(map[string]bool, len(classes))
+	for _, class := range classes {
+		playable[class] = true
+	}
+	for kind, table := range map[string]map[string][]string{"weapon": classWeaponProficiencies, "armor": classArmorProficiencies} {
+		for _, class := range sortedClasses(table) {
+			if !playable[class] {
+				problems = append(problems, fmt.Sprintf("%s: %s proficiency entry for a class that isn't playable", class, kind))
+			}
+		}
+	}
+	return problems
+}
+
+// playableClasses returns the class IDs from the playable registry.
+func playableClasses(db *sql.DB) ([]string, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	var dataJSON string
+	if err := db.QueryRow(`SELECT data FROM playable WHERE id = 'playable'`).Scan(&dataJSON); err != nil {
+		return nil, err
+	}
+	var registry types.PlayableRegistry
+	if err := json.Unmarshal([]byte(dataJSON), &registry); err != nil {
+		return nil, err
+	}
+	classes := make([]string, 0, len(registry.Classes))
+	for _, class := range registry.Classes {
+		classes = append(classes, strings.ToLower(class.ID))
+	}
+	return classes, nil
+}
+
+func sortedClasses(table map[string][]string) []string {
+	classes := make([]string, 0, len(table))
+	for class := range table {
+		classes = append(classes, class)
+	}
+	sort.Strings(classes)
+	return classes
+}
@@ -3,6 +3,8 @@ package combat
 import (
 	"strconv"
 	"strings"
+
+	"pubkey-quest/types"
 )
 
 // parseRangeInt converts a JSON range value (string or number) to int.
@@ -71,14 +73,20 @@ var classWeaponProficiencies = map[string][]string{
 	"fighter":   {"simple", "martial"},
 	"paladin":   {"simple", "martial"},
 	"ranger":    {"simple", "martial"},
-	"rogue":     {"simple", "hand-crossbow", "longsword", "rapier", "shortsword"},
-	"bard":      {"simple", "hand-crossbow", "longsword", "rapier", "shortsword"},
+	"rogue":     {"simple", "crossbow-hand", "longsword", "rapier", "shortsword"},
+	"bard":      {"simple", "crossbow-hand", "longsword", "rapier", "shortsword"},
 	"cleric":    {"simple"},
 	"druid":     {"simple"},
 	"monk":      {"simple", "shortsword"},
-	"sorcerer":  {"dagger", "dart", "sling", "quarterstaff", "light-crossbow"},
-	"warlock":   {"dagger", "dart", "sling", "quarterstaff", "light-crossbow"},
-	"wizard":    {"dagger", "dart", "sling", "quarterstaff", "light-crossbow"},
+	"sorcerer":  {"dagger", "dart", "sling", "quarterstaff", "crossbow-light"},
+	"warlock":   {"dagger", "dart", "sling", "quarterstaff", "crossbow-light"},
+	"wizard":    {"dagger", "dart", "sling", "quarterstaff", "crossbow-light"},
+}
+
+// ClassWeaponProficiencies returns the class's weapon proficiencies: the
+// categories ("simple", "martial") and individual weapon IDs it's trained in.
+func ClassWeaponProficiencies(class string) []string {
+	return append([]string{}, classWeaponProficiencies[strings.ToLower(class)]...)
 }
 
 // IsProficientWith returns true if the class is proficient with the given weapon.
@@ -117,14 +125,21 @@ type AttackResult struct {
 	Roll      int  // Raw d20 result
 	Total     int  // Roll + all modifiers
 	Modifier  int  // Sum of modifiers applied
-	IsCrit    bool // Natural 20 — always hits, double dice
+	IsCrit    bool // Natural roll in the crit range — always hits, crit damage
 	IsCritMiss bool // Natural 1 — always misses
 	IsHit     bool // Total >= target AC (or crit)
 }
 
-// ResolveAttackRoll performs a d20 attack roll.
+// ResolveAttackRoll performs a d20 attack roll that crits on a natural 20.
 // advantage: >0 = advantage, <0 = disadvantage, 0 = normal.
 func ResolveAttackRoll(attackBonus, targetAC, advantage int) AttackResult {
+	return ResolveAttackRollWithCritRange(attackBonus, targetAC, advantage, 20)
+}
+
+// ResolveAttackRollWithCritRange is ResolveAttackRoll for an attacker with an
+// expanded crit range: any natural roll >= critRange crits (19 → crits on
+// 19–20). A natural 1 still always misses.
+func ResolveAttackRollWithCritRange(attackBonus, targetAC, advantage, critRange int) AttackResult {
 	var roll int
 	switch {
 	case advantage > 0:
@@ -135,8 +150,11 @@ func ResolveAttackRoll(attackBonus, targetAC, advantage int) AttackResult {
 		roll = RollD20()
 	}
 
-	isCrit := roll == 20
+	if critRange < 2 || critRange > 20 {
+		critRange = 20
+	}
 	isCritMiss := roll == 1
+	isCrit := !isCritMiss && roll >= critRange
 	total := roll + attackBonus
 	isHit := isCrit || (!isCritMiss && total >= targetAC)
 
@@ -164,32 +182,32 @@ func hasTag(tags interface{}, tag string) bool {
 	return false
 }
 
+// WeaponAbility returns the ability a weapon attacks and deals damage with:
+// a finesse weapon uses whichever of STR and DEX has the better modifier (STR
+// on a tie), other ranged weapons use DEX and everything else STR. Thrown
+// attacks are the exception and always use DEX (see resolveAttackBonus).
+func WeaponAbility(item map[string]interface{}, stats map[string]interface{}) string {
+	if hasTag(item["tags"], "finesse") {
+		if StatMod(GetStatFromMap(stats, "dexterity")) > StatMod(GetStatFromMap(stats, "strength")) {
+			return "dexterity"
+		}
+		return "strength"
+	}
+	weaponType, _ := item["type"].(string)
+	if strings.Contains(strings.ToLower(weaponType), "ranged") {
+		return "dexterity"
+	}
+	return "strength"
+}
+
 // WeaponAttackBonus computes the full attack bonus for a player attacking with an item.
 // item is the full item map from the database properties column.
 // stats is the player's stats map from the save file.
 func WeaponAttackBonus(item map[string]interface{}, stats map[string]interface{}, class string, level int) int {
 	weaponType, _ := item["type"].(string)
 	weaponID, _ := item["id"].(string)
-	tags := item["tags"]
 
-	strMod := StatMod(GetStatFromMap(stats, "strength"))
-	dexMod := StatMod(GetStatFromMap(stats, "dexterity"))
-
-	var abilityMod int
-	isRanged := strings.Contains(strings.ToLower(weaponType), "ranged")
-
-	if hasTag(tags, "finesse") {
-		// Use whichever is higher
-		if dexMod > strMod {
-			abilityMod = dexMod
-		} else {
-			abilityMod = strMod
-		}
-	} else if isRanged {
-		abilityMod = dexMod
-	} else {
-		abilityMod = strMod
-	}
+	abilityMod := StatMod(GetStatFromMap(stats, WeaponAbility(item, stats)))
 
 	prof := 0
 	if IsProficientWith(class, weaponType, weaponID) {
@@ -201,44 +219,45 @@ func WeaponAttackBonus(item map[string]interface{}, stats map[string]interface{}
 
 // WeaponDamageBonus returns the ability modifier added to weapon damage rolls.
 func WeaponDamageBonus(item map[string]interface{}, stats map[string]interface{}) int {
-	weaponType, _ := item["type"].(string)
-	tags := item["tags"]
-
-	strMod := StatMod(GetStatFromMap(stats, "strength"))
-	dexMod := StatMod(GetStatFromMap(stats, "dexterity"))
-
-	isRanged := strings.Contains(strings.ToLower(weaponType), "ranged")
-
-	if hasTag(tags, "finesse") {
-		if dexMod > strMod {
-			return dexMod
-		}
-		return strMod
-	}
-	if isRanged {
-		return dexMod
-	}
-	return strMod
+	return StatMod(GetStatFromMap(stats, WeaponAbility(item, stats)))
 }
 
-// WeaponDamageDice returns the damage dice string for an item, choosing 2H for versatile weapons
-// when the offhand is empty.
+// WeaponDamageDice returns the damage dice string for an item. A "versatile" weapon deals
+// its damage_versatile dice when wielded with the offhand empty, and a two-handed weapon is
+// always wielded in both hands so always uses its two-handed dice; otherwise it's the
+// one-handed damage dice.
 func WeaponDamageDice(item map[string]interface{}, offhandEmpty bool) string {
 	raw, _ := item["damage"].(string)
 	if raw == "" {
 		return "1d4" // Fallback for unusual items
 	}
 
+	// Legacy items carry both in damage as "1d8,1d10"
+	oneH, twoH := ParseVersatileDice(raw)
+	if v := WeaponVersatileDice(item); v != "" {
+		twoH = v
+	}
+
 	tags := item["tags"]
-	if hasTag(tags, "versatile") && offhandEmpty {
-		_, twoH := ParseVersatileDice(raw)
+	if hasTag(tags, "two-handed") || (hasTag(tags, "versatile") && offhandEmpty) {
 		return twoH
 	}
-
-	oneH, _ := ParseVersatileDice(raw)
 	return oneH
 }
 
+// WeaponVersatileDice returns an item's damage_versatile dice, the damage it deals
+// wielded in both hands, or "" when it has none. The hyphen form is a fallback, as
+// with damage_type.
+func WeaponVersatileDice(item map[string]interface{}) string {
+	if v, ok := item["damage_versatile"].(string); ok && v != "" {
+		return v
+	}
+	if v, ok := item["damage-versatile"].(string); ok && v != "" {
+		return v
+	}
+	return ""
+}
+
 // WeaponDamageType returns the damage type string from an item. Items use the
 // underscore key `damage_type`; the hyphen form is a legacy fallback.
 func WeaponDamageType(item map[string]interface{}) string {
@@ -248,7 +267,7 @@ func WeaponDamageType(item map[string]interface{}) string {
 	if dt, ok := item["damage-type"].(string); ok && dt != "" {
 		return dt
 	}
-	return "bludgeoning"
+	return types.DamageBludgeoning
 }
 
 // UnarmedAttackBonus returns the attack bonus for an unarmed strike.
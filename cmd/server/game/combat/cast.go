@@ -18,26 +18,30 @@ import (
 // ProcessPlayerCast is the combat-side wrapper around the shared casting engine
 // (spells.Cast). It mirrors ProcessPlayerAttack: validate the action economy,
 // run the data-driven engine against the target monster, then own the combat
-// consequences the engine deliberately leaves to the caller — applying damage to
-// the monster, awarding damage XP, resolving a kill, healing the combat HP pool,
+// consequences the engine deliberately leaves to the caller — checking the
+// spell's range, spreading an area spell over every enemy it reaches, applying
+// damage to the monster, awarding damage XP, resolving a kill, healing the combat HP pool,
 // and setting concentration. The engine already spent mana + components and
 // applied any buff effect to the save.
 
-// ProcessPlayerCast resolves the player casting spellID at the (single) monster.
+// ProcessPlayerCast resolves the player casting spellID at the targeted monster.
 // Does NOT run the monster's response — the caller ends the turn like any action.
-func ProcessPlayerCast(db *sql.DB, cs *types.CombatSession, save *types.SaveFile, spellID string, advancement []types.AdvancementEntry) ([]string, error) {
+// targetID picks the monster ("" = current target); it only becomes the current
+// target once the cast succeeds.
+func ProcessPlayerCast(db *sql.DB, cs *types.CombatSession, save *types.SaveFile, spellID, targetID string, advancement []types.AdvancementEntry) ([]string, error) {
 	if cs.Phase != "active" {
 		return nil, fmt.Errorf("cannot cast: combat phase is %q", cs.Phase)
 	}
 	if len(cs.Party) == 0 {
 		return nil, fmt.Errorf("no player in combat")
 	}
-	if len(cs.Monsters) == 0 || !cs.Monsters[0].IsAlive {
-		return nil, fmt.Errorf("no living target to cast at")
+	monster, err := resolveTarget(cs, targetID)
+	if err != nil {
+		return nil, err
 	}
 	state := &cs.Party[0].CombatState
-	if IsIncapacitated(state.Conditions) {
-		return nil, fmt.Errorf("you are incapacitated and can't cast")
+	if conds := playerConditions(cs, save); IsIncapacitated(conds) {
+		return nil, fmt.Errorf("you are %s and can't cast", incapacitatingConditionName(conds))
 	}
 
 	// Action economy — validated up front, before the engine spends any cost.
@@ -56,8 +60,14 @@ func ProcessPlayerCast(db *sql.DB, cs *types.CombatSession, save *types.SaveFile
 	default:
 		return nil, fmt.Errorf("%s takes too long to cast in combat", spellID)
 	}
+	spell, _ := gamedata.LoadSpellByID(db, spellID)
+	if err := validateSpellPosition(cs, monster, spell); err != nil {
+		return nil, err
+	}
+	if err := checkFightConcentration(cs, spell, spellID); err != nil {
+		return nil, err
+	}
 
-	monster := &cs.Monsters[0]
 	level := character.GetLevelFromXP(save.Experience, advancement)
 
 	deps := spells.Deps{
@@ -79,6 +89,7 @@ func ProcessPlayerCast(db *sql.DB, cs *types.CombatSession, save *types.SaveFile
 	if err != nil {
 		return nil, err
 	}
+	cs.TargetID = monster.InstanceID
 
 	log := res.Log
 
@@ -101,6 +112,12 @@ func ProcessPlayerCast(db *sql.DB, cs *types.CombatSession, save *types.SaveFile
 		log = append(log, applySpellCondition(spellID, res, monster)...)
 	}
 
+	// Area spells catch every other enemy within reach, each saving for half.
+	caught := castAreaSpell(cs, save, spell, res, deps, monster, level, advancement)
+	for _, m := range caught {
+		log = append(log, m.log...)
+	}
+
 	// Concentration — a maintained buff/control the player must now hold.
 	if res.Concentration {
 		cs.Concentration = &types.ConcentrationState{
@@ -118,12 +135,93 @@ func ProcessPlayerCast(db *sql.DB, cs *types.CombatSession, save *types.SaveFile
 	}
 
 	if !monster.IsAlive {
-		log = append(log, handleMonsterKill(cs, monster, save, advancement)...)
+		log = append(log, handleMonsterKill(db, cs, monster, save, advancement)...)
+	}
+	for _, m := range caught {
+		if !m.monster.IsAlive {
+			log = append(log, handleMonsterKill(db, cs, m.monster, save, advancement)...)
+		}
 	}
 
 	return log, nil
 }
 
+// validateSpellPosition returns an error if the player's position rules out the
+// cast: an enemy-targeted spell needs its target within the spell's reach, and
+// a concentration spell can't be started with an enemy in melee range.
+func validateSpellPosition(cs *types.CombatSession, target *types.MonsterInstance, spell map[string]interface{}) error {
+	name, _ := spell["name"].(string)
+	if spells.TargetsEnemy(spell) && target != nil {
+		if reach := spellReach(spell); rangeTo(cs, target) > reach {
+			return fmt.Errorf("target is beyond %s's range (%d) — move closer", name, reach)
+		}
+	}
+	if concentration, _ := spell["concentration"].(bool); concentration && enemyInMelee(cs) {
+		return fmt.Errorf("you can't concentrate on %s with an enemy in melee range — step away first", name)
+	}
+	return nil
+}
+
+// spellReach returns the furthest combat Range a spell reaches: its long range,
+// else its normal range. Touch spells (range 0) reach as far as a melee weapon.
+func spellReach(spell map[string]interface{}) int {
+	reach := max(parseRangeInt(spell["range"]), parseRangeInt(spell["range_long"]))
+	if reach == 0 {
+		return 1
+	}
+	return reach
+}
+
+// enemyInMelee reports whether any living monster is close enough to hit the
+// player in melee (Range 0–1).
+func enemyInMelee(cs *types.CombatSession) bool {
+	for i := range cs.Monsters {
+		if cs.Monsters[i].IsAlive && rangeTo(cs, &cs.Monsters[i]) <= 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// areaHit is one extra monster an area spell caught, with its log lines.
+type areaHit struct {
+	monster *types.MonsterInstance
+	log     []string
+}
+
+// castAreaSpell resolves an area_effect save spell against every living monster
+// other than primary within the spell's reach: each rolls its own save against
+// the cast's DC and takes the damage, half on a success, plus the spell's
+// condition on a failure. Kills are left to the caller. Returns nil for spells
+// that aren't area saves.
+func castAreaSpell(cs *types.CombatSession, save *types.SaveFile, spell map[string]interface{}, res *spells.CastResult, deps spells.Deps, primary *types.MonsterInstance, level int, advancement []types.AdvancementEntry) []areaHit {
+	if res.Shape != "save" || !hasTag(spell["tags"], "area_effect") {
+		return nil
+	}
+	reach := spellReach(spell)
+	var hits []areaHit
+	for i := range cs.Monsters {
+		m := &cs.Monsters[i]
+		if m == primary || !m.IsAlive || rangeTo(cs, m) > reach {
+			continue
+		}
+		dmg, made, log := spells.AreaSave(deps, spell, res.SaveDC, m)
+		if dmg > 0 {
+			applyDamageToMonster(m, dmg)
+			if xp := awardDamageXP(cs, m, dmg, save.TimeOfDay, level, advancement); xp > 0 {
+				log = append(log, fmt.Sprintf("  +%d XP", xp))
+			}
+		}
+		if m.IsAlive {
+			caught := *res
+			caught.SaveMade = made
+			log = append(log, applySpellCondition(res.SpellID, &caught, m)...)
+		}
+		hits = append(hits, areaHit{monster: m, log: log})
+	}
+	return hits
+}
+
 // spellConditionRider maps a control spell to the condition it imposes. saveStat
 // != "" means the condition re-saves each turn on that stat (entangle's STR check
 // to break free); "" means it lasts its full duration with no re-save (faerie-fire
@@ -197,11 +295,16 @@ func applyHealToPlayer(cs *types.CombatSession, heal int) {
 }
 
 // checkConcentrationOnDamage runs a Constitution save (DC = max(10, ½ damage))
-// to keep a maintained spell after the player takes damage. On a failure the
+// to keep the player's concentration after they take damage — a spell cast this
+// fight, or a concentration effect they came in holding. On a failure the
 // linked ActiveEffect is dropped and concentration clears. Returns log lines.
 // Lives here (not combat.go) so the effects import stays local to the cast path.
 func checkConcentrationOnDamage(cs *types.CombatSession, save *types.SaveFile, dmg int) []string {
-	if cs == nil || cs.Concentration == nil || dmg <= 0 {
+	if cs == nil || save == nil || dmg <= 0 {
+		return nil
+	}
+	name := concentrationName(cs, save)
+	if name == "" {
 		return nil
 	}
 	dc := 10
@@ -218,15 +321,46 @@ func checkConcentrationOnDamage(cs *types.CombatSession, save *types.SaveFile, d
 	}
 	if roll+conMod >= dc {
 		return []string{fmt.Sprintf("  You hold concentration on %s (CON save %d%s vs DC %d%s).",
-			cs.Concentration.SpellName, roll, formatModifier(conMod), dc, advNote)}
+			name, roll, formatModifier(conMod), dc, advNote)}
 	}
-	name := cs.Concentration.SpellName
-	if cs.Concentration.EffectID != "" {
+	dropConcentration(cs, save)
+	return []string{fmt.Sprintf("  ✘ You lose concentration on %s! (CON save %d%s vs DC %d)",
+		name, roll, formatModifier(conMod), dc)}
+}
+
+// concentrationName names what the player is concentrating on: the spell cast
+// this fight, else the concentration effect on their save. "" when neither.
+func concentrationName(cs *types.CombatSession, save *types.SaveFile) string {
+	if cs.Concentration != nil {
+		return cs.Concentration.SpellName
+	}
+	if held := effects.ConcentratingOn(save); held != "" {
+		return effects.EffectName(held)
+	}
+	return ""
+}
+
+// checkFightConcentration refuses a concentration spell while the fight is
+// holding a different one. The engine only checks the effect on the save, which
+// not every concentration spell leaves behind; re-casting the held spell renews it.
+func checkFightConcentration(cs *types.CombatSession, spell map[string]interface{}, spellID string) error {
+	if concentration, _ := spell["concentration"].(bool); !concentration {
+		return nil
+	}
+	if held := cs.Concentration; held != nil && held.SpellID != spellID {
+		return fmt.Errorf("you are already concentrating on %s — end it first", held.SpellName)
+	}
+	return nil
+}
+
+// dropConcentration ends the player's concentration, removing the effect it
+// held up.
+func dropConcentration(cs *types.CombatSession, save *types.SaveFile) {
+	if cs.Concentration != nil && cs.Concentration.EffectID != "" {
 		effects.RemoveEffect(save, cs.Concentration.EffectID)
 	}
+	effects.EndConcentration(save)
 	cs.Concentration = nil
-	return []string{fmt.Sprintf("  ✘ You lose concentration on %s! (CON save %d%s vs DC %d)",
-		name, roll, formatModifier(conMod), dc)}
 }
 
 // ─── Combat consumables (M4 Phase C) ─────────────────────────────────────────
@@ -236,8 +370,14 @@ func checkConcentrationOnDamage(cs *types.CombatSession, save *types.SaveFile, d
 // stashed in a pouch/sack container occupying one (the equipped backpack itself
 // isn't reachable in a fight). Healing and mana route through the shared item
 // effect path, bridged onto the combat HP pool so the heal lands on the live
-// combatant rather than the resting save HP. Uses the player's action.
+// combatant rather than the resting save HP. Uses the player's action — or, for
+// an item tagged "bonus-action", the bonus action while it's free. While the
+// player is making death saves only a "revive" item can be used (see
+// processReviveItem).
 func ProcessPlayerUseItem(db *sql.DB, cs *types.CombatSession, save *types.SaveFile, itemID string) ([]string, error) {
+	if cs.Phase == "death_saves" {
+		return processReviveItem(db, cs, save, itemID)
+	}
 	if cs.Phase != "active" {
 		return nil, fmt.Errorf("cannot use an item: combat phase is %q", cs.Phase)
 	}
@@ -245,7 +385,7 @@ func ProcessPlayerUseItem(db *sql.DB, cs *types.CombatSession, save *types.SaveF
 		return nil, fmt.Errorf("no player in combat")
 	}
 	state := &cs.Party[0].CombatState
-	if state.ActionUsed {
+	if state.ActionUsed && state.BonusActionUsed {
 		return nil, fmt.Errorf("action already used this turn")
 	}
 
@@ -260,12 +400,19 @@ func ProcessPlayerUseItem(db *sql.DB, cs *types.CombatSession, save *types.SaveF
 
 	// A spell scroll casts the spell it carries (bypassing prepared/known/components).
 	if spellID, _ := item["spell_id"].(string); spellID != "" {
+		if state.ActionUsed {
+			return nil, fmt.Errorf("action already used this turn")
+		}
 		return processScrollUse(db, cs, save, itemID, spellID, name)
 	}
 
 	if !hasTag(item["tags"], "consumable") {
 		return nil, fmt.Errorf("%s can't be used in combat", name)
 	}
+	useBonus := hasTag(item["tags"], "bonus-action") && !state.BonusActionUsed
+	if !useBonus && state.ActionUsed {
+		return nil, fmt.Errorf("action already used this turn")
+	}
 
 	// Locate a stack of the item within reach (loose general slot or a general-
 	// slot container). Nil means the player isn't carrying one where they can grab
@@ -286,7 +433,11 @@ func ProcessPlayerUseItem(db *sql.DB, cs *types.CombatSession, save *types.SaveF
 
 	// Consume one from the located stack.
 	decrementSlotStack(slot)
-	state.ActionUsed = true
+	if useBonus {
+		state.BonusActionUsed = true
+	} else {
+		state.ActionUsed = true
+	}
 
 	line := fmt.Sprintf("  You use %s.", name)
 	if len(msgs) > 0 {
@@ -295,6 +446,93 @@ func ProcessPlayerUseItem(db *sql.DB, cs *types.CombatSession, save *types.SaveF
 	return []string{line}, nil
 }
 
+// processReviveItem uses a consumable tagged "revive" while the player is making
+// death saves: in place of the roll it heals them off 0 HP, clears the death save
+// counters and puts the fight back in the active phase. Waking opens a fresh turn
+// for the player, the revive itself taking its action (or bonus action).
+func processReviveItem(db *sql.DB, cs *types.CombatSession, save *types.SaveFile, itemID string) ([]string, error) {
+	if len(cs.Party) == 0 {
+		return nil, fmt.Errorf("no player in combat")
+	}
+	item, err := gamedata.LoadItemByID(db, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("unknown item %q", itemID)
+	}
+	name := itemID
+	if n, _ := item["name"].(string); n != "" {
+		name = n
+	}
+	if !hasTag(item["tags"], "revive") {
+		return nil, fmt.Errorf("%s can't be used while dying", name)
+	}
+	slot := findReachableConsumable(save.Inventory, itemID)
+	if slot == nil {
+		return nil, fmt.Errorf("no %s within reach", name)
+	}
+
+	resetPlayerTurnState(cs, save)
+	state := &cs.Party[0].CombatState
+
+	// Same HP bridge as a conscious use, healing up from 0.
+	prevHP := save.HP
+	save.HP = 0
+	msgs := gaminventory.ApplyItemEffects(save, itemID)
+	hp := max(save.HP, 1)
+	save.HP = prevHP
+
+	decrementSlotStack(slot)
+	regainConsciousness(state, cs, hp)
+	if hasTag(item["tags"], "bonus-action") {
+		state.BonusActionUsed = true
+	} else {
+		state.ActionUsed = true
+	}
+
+	line := fmt.Sprintf("  You use %s and regain consciousness with %d HP.", name, hp)
+	if len(msgs) > 0 {
+		line = fmt.Sprintf("  You use %s — %s. You regain consciousness with %d HP.", name, strings.Join(msgs, ", "), hp)
+	}
+	return []string{line}, nil
+}
+
+// QuickItemAvailable reports whether the player still has a consumable tagged
+// "bonus-action" within reach and their bonus action free to use it — a reason
+// not to end their turn for them.
+func QuickItemAvailable(db *sql.DB, cs *types.CombatSession, save *types.SaveFile) bool {
+	if len(cs.Party) == 0 || cs.Party[0].CombatState.BonusActionUsed {
+		return false
+	}
+	gen, _ := save.Inventory["general_slots"].([]interface{})
+	seen := make(map[string]bool)
+	quick := func(raw interface{}) bool {
+		slot, ok := raw.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		id, _ := slot["item"].(string)
+		if id == "" || seen[id] || slotQty(slot, "quantity") <= 0 {
+			return false
+		}
+		seen[id] = true
+		item, err := gamedata.LoadItemByID(db, id)
+		return err == nil && hasTag(item["tags"], "consumable") && hasTag(item["tags"], "bonus-action")
+	}
+	for _, raw := range gen {
+		if quick(raw) {
+			return true
+		}
+		if slot, ok := raw.(map[string]interface{}); ok {
+			contents, _ := slot["contents"].([]interface{})
+			for _, inner := range contents {
+				if quick(inner) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 // processScrollUse resolves a spell scroll in combat: it casts the scroll's spell
 // at the monster (bypassing prepared/known/components; mana still applies), applies
 // the same consequences as a normal cast, then consumes one scroll. Uses the action.
@@ -304,10 +542,18 @@ func processScrollUse(db *sql.DB, cs *types.CombatSession, save *types.SaveFile,
 	if slot == nil {
 		return nil, fmt.Errorf("no %s within reach", itemName)
 	}
-	monster := &cs.Monsters[0]
+	monster := TargetMonster(cs)
 	adv, _ := character.LoadAdvancement(db)
 	level := character.GetLevelFromXP(save.Experience, adv)
 
+	spell, _ := gamedata.LoadSpellByID(db, spellID)
+	if err := validateSpellPosition(cs, monster, spell); err != nil {
+		return nil, err
+	}
+	if err := checkFightConcentration(cs, spell, spellID); err != nil {
+		return nil, err
+	}
+
 	deps := spells.Deps{RollD20: RollD20, RollDice: RollDice, ResolveMonsterDamage: ResolveDamageToMonster}
 	res, err := spells.CastFromScroll(db, deps, save, spellID, level, monster)
 	if err != nil {
@@ -327,6 +573,10 @@ func processScrollUse(db *sql.DB, cs *types.CombatSession, save *types.SaveFile,
 	if monster.IsAlive {
 		log = append(log, applySpellCondition(res.SpellID, res, monster)...)
 	}
+	caught := castAreaSpell(cs, save, spell, res, deps, monster, level, adv)
+	for _, m := range caught {
+		log = append(log, m.log...)
+	}
 	if res.Concentration {
 		cs.Concentration = &types.ConcentrationState{SpellID: res.SpellID, SpellName: res.SpellName, EffectID: res.EffectID}
 	}
@@ -335,7 +585,12 @@ func processScrollUse(db *sql.DB, cs *types.CombatSession, save *types.SaveFile,
 	state.ActionUsed = true
 
 	if !monster.IsAlive {
-		log = append(log, handleMonsterKill(cs, monster, save, adv)...)
+		log = append(log, handleMonsterKill(db, cs, monster, save, adv)...)
+	}
+	for _, m := range caught {
+		if !m.monster.IsAlive {
+			log = append(log, handleMonsterKill(db, cs, m.monster, save, adv)...)
+		}
 	}
 	return log, nil
 }
@@ -0,0 +1,102 @@
+package combat
+
+import (
+	"strings"
+	"testing"
+
+	"pubkey-quest/cmd/server/game/spells"
+	"pubkey-quest/types"
+)
+
+func TestSpellRangeAndConcentration(t *testing.T) {
+	// wolf-a shares the player's cell (range 0), wolf-b sits at range 3.
+	cs := twoMonsterSession(types.Position{X: 1, Y: 3}, types.Position{X: 4, Y: 3})
+	boltAt := func(r string) map[string]interface{} {
+		return map[string]interface{}{"name": "Bolt", "spell_attack": "ranged", "range": r, "range_long": r}
+	}
+
+	if err := validateSpellPosition(cs, &cs.Monsters[1], boltAt("4")); err != nil {
+		t.Errorf("range 4 spell at range 3: unexpected error %v", err)
+	}
+	if err := validateSpellPosition(cs, &cs.Monsters[1], boltAt("2")); err == nil {
+		t.Error("range 2 spell at a target at range 3 should be rejected")
+	}
+	if err := validateSpellPosition(cs, &cs.Monsters[0], boltAt("0")); err != nil {
+		t.Errorf("touch spell on an adjacent target: unexpected error %v", err)
+	}
+	if err := validateSpellPosition(cs, &cs.Monsters[1], boltAt("0")); err == nil {
+		t.Error("touch spell at range 3 should be rejected")
+	}
+	// A spell on the caster doesn't care where the enemies are.
+	if err := validateSpellPosition(cs, &cs.Monsters[1], map[string]interface{}{"name": "Shield", "effect": "+5 AC"}); err != nil {
+		t.Errorf("self buff: unexpected error %v", err)
+	}
+
+	hold := map[string]interface{}{"name": "Hold", "save_type": "wisdom", "range": "4", "concentration": true}
+	if err := validateSpellPosition(cs, &cs.Monsters[1], hold); err == nil || !strings.Contains(err.Error(), "melee") {
+		t.Errorf("concentration spell with an enemy in melee: err = %v", err)
+	}
+	cs.Monsters[0].IsAlive = false
+	if err := validateSpellPosition(cs, &cs.Monsters[1], hold); err != nil {
+		t.Errorf("concentration spell with no enemy in melee: unexpected error %v", err)
+	}
+}
+
+func TestAreaSpellSavesForHalf(t *testing.T) {
+	// Both wolves within reach; a third out past the spell's range.
+	cs := twoMonsterSession(types.Position{X: 2, Y: 3}, types.Position{X: 3, Y: 3})
+	far := cs.Monsters[1]
+	far.InstanceID, far.Pos = "wolf-c", types.Position{X: 7, Y: 3}
+	cs.Monsters = append(cs.Monsters, far)
+
+	spell := map[string]interface{}{
+		"name": "Blast", "save_type": "dexterity", "damage": "2d6", "damage_type": "fire",
+		"range": "2", "range_long": "2", "tags": []interface{}{"combat", "area_effect"},
+	}
+	deps := spells.Deps{
+		RollD20:              func() int { return 20 }, // every save is made
+		ResolveMonsterDamage: func(string, int, string, bool, *types.MonsterInstance) int { return 10 },
+	}
+	res := &spells.CastResult{SpellID: "blast", Shape: "save", SaveDC: 13}
+	save := &types.SaveFile{}
+
+	hits := castAreaSpell(cs, save, spell, res, deps, &cs.Monsters[0], 1, nil)
+	if len(hits) != 1 || hits[0].monster != &cs.Monsters[1] {
+		t.Fatalf("area hits = %+v, want only wolf-b", hits)
+	}
+	if got := cs.Monsters[1].CurrentHP; got != 45 {
+		t.Errorf("wolf-b HP = %d, want 45 (half of 10 on a made save)", got)
+	}
+	if cs.Monsters[0].CurrentHP != 50 || cs.Monsters[2].CurrentHP != 50 {
+		t.Error("the primary target and the out-of-range wolf should be left to the caller / untouched")
+	}
+
+	// A single-target save spell catches nobody else.
+	delete(spell, "tags")
+	if hits := castAreaSpell(cs, save, spell, res, deps, &cs.Monsters[0], 1, nil); hits != nil {
+		t.Errorf("single-target spell hit %+v", hits)
+	}
+}
+
+func TestDamageBreaksConcentration(t *testing.T) {
+	cs := twoMonsterSession(types.Position{X: 1, Y: 3}, types.Position{X: 4, Y: 3})
+	cs.Party[0].CombatState.CurrentHP, cs.Party[0].CombatState.MaxHP = 200, 200
+	save := &types.SaveFile{Stats: statMap(10, 10, 10, 10, 10, 10)}
+
+	// 120 damage sets the CON save at DC 60: no roll keeps it.
+	cs.Concentration = &types.ConcentrationState{SpellID: "bless", SpellName: "Bless"}
+	log := applyDamageToPlayer(cs, save, 120, "")
+	if cs.Concentration != nil {
+		t.Error("a failed concentration save should drop the spell")
+	}
+	if !strings.Contains(strings.Join(log, "\n"), "lose concentration on Bless") {
+		t.Errorf("log = %v, want the lost concentration reported", log)
+	}
+
+	// Falling unconscious drops it with no save.
+	cs.Concentration = &types.ConcentrationState{SpellID: "bless", SpellName: "Bless"}
+	applyDamageToPlayer(cs, save, 500, "")
+	if cs.Phase != "death_saves" || cs.Concentration != nil {
+		t.Errorf("phase = %q, concentration = %+v; want death saves and no concentration", cs.Phase, cs.Concentration)
+	}
+}
@@ -3,6 +3,7 @@ package combat
 import (
 	"database/sql"
 	"fmt"
+	"math"
 	"sort"
 	"strings"
 
@@ -11,6 +12,7 @@ import (
 	"pubkey-quest/cmd/server/game/effects"
 	"pubkey-quest/cmd/server/game/encounter"
 	"pubkey-quest/cmd/server/game/events"
+	"pubkey-quest/cmd/server/game/gametime"
 	gaminventory "pubkey-quest/cmd/server/game/inventory"
 	"pubkey-quest/types"
 )
@@ -44,9 +46,108 @@ func chebyshev(a, b types.Position) int {
 	return dy
 }
 
-// currentRange returns the current combat range (Chebyshev distance between player and monster).
+// currentRange returns the range to the player's current target (see TargetMonster).
 func currentRange(cs *types.CombatSession) int {
-	return chebyshev(cs.PlayerPos, cs.MonsterPos)
+	m := TargetMonster(cs)
+	if m == nil {
+		return 0
+	}
+	return rangeTo(cs, m)
+}
+
+// rangeTo returns the Chebyshev distance between the player and a specific monster.
+func rangeTo(cs *types.CombatSession, m *types.MonsterInstance) int {
+	return chebyshev(cs.PlayerPos, m.Pos)
+}
+
+// TargetMonster returns the monster the player is targeting: the living monster
+// matching cs.TargetID, else the first living monster. Falls back to the first
+// monster (dead or not) so range/position reporting still has a reference point.
+// Returns nil only when the session has no monsters.
+func TargetMonster(cs *types.CombatSession) *types.MonsterInstance {
+	if len(cs.Monsters) == 0 {
+		return nil
+	}
+	if cs.TargetID != "" {
+		for i := range cs.Monsters {
+			if cs.Monsters[i].InstanceID == cs.TargetID && cs.Monsters[i].IsAlive {
+				return &cs.Monsters[i]
+			}
+		}
+	}
+	for i := range cs.Monsters {
+		if cs.Monsters[i].IsAlive {
+			return &cs.Monsters[i]
+		}
+	}
+	return &cs.Monsters[0]
+}
+
+// SelectTarget sets the player's target to the living monster with the given instance ID.
+func SelectTarget(cs *types.CombatSession, instanceID string) error {
+	m, err := resolveTarget(cs, instanceID)
+	if err != nil {
+		return err
+	}
+	cs.TargetID = m.InstanceID
+	return nil
+}
+
+// resolveTarget looks up the living monster an action is aimed at without
+// changing cs.TargetID: instanceID "" means the current target. Actions only
+// commit the new target once they have passed their own validation.
+func resolveTarget(cs *types.CombatSession, instanceID string) (*types.MonsterInstance, error) {
+	if instanceID == "" {
+		m := TargetMonster(cs)
+		if m == nil || !m.IsAlive {
+			return nil, fmt.Errorf("no living target")
+		}
+		return m, nil
+	}
+	m := monsterByID(cs, instanceID)
+	if m == nil {
+		return nil, fmt.Errorf("no monster %q in this combat", instanceID)
+	}
+	if !m.IsAlive {
+		return nil, fmt.Errorf("%s is already down", m.Name)
+	}
+	return m, nil
+}
+
+// monsterAt returns the living monster occupying pos, or nil.
+func monsterAt(cs *types.CombatSession, pos types.Position) *types.MonsterInstance {
+	for i := range cs.Monsters {
+		if cs.Monsters[i].IsAlive && cs.Monsters[i].Pos == pos {
+			return &cs.Monsters[i]
+		}
+	}
+	return nil
+}
+
+// nearestLivingMonster returns the living monster closest to the player, or nil.
+func nearestLivingMonster(cs *types.CombatSession) *types.MonsterInstance {
+	var nearest *types.MonsterInstance
+	for i := range cs.Monsters {
+		m := &cs.Monsters[i]
+		if !m.IsAlive {
+			continue
+		}
+		if nearest == nil || rangeTo(cs, m) < rangeTo(cs, nearest) {
+			nearest = m
+		}
+	}
+	return nearest
+}
+
+// livingMonsters counts the monsters still fighting.
+func livingMonsters(cs *types.CombatSession) int {
+	n := 0
+	for i := range cs.Monsters {
+		if cs.Monsters[i].IsAlive {
+			n++
+		}
+	}
+	return n
 }
 
 // ChebyshevExported is the exported version of chebyshev for use by the API layer.
@@ -73,51 +174,107 @@ func playerMovementBudget(race string) int {
 
 // ─── StartCombat ─────────────────────────────────────────────────────────────
 
-// StartCombat initialises a new CombatSession for a single-monster encounter.
-// The session lives in server memory only — it is never written to the save file.
-func StartCombat(db *sql.DB, save *types.SaveFile, npub, monsterID, environmentID string, advancement []types.AdvancementEntry) (*types.CombatSession, error) {
-	monsterData, err := LoadMonsterByID(db, monsterID)
-	if err != nil {
-		return nil, fmt.Errorf("StartCombat: %w", err)
+// MaxEncounterMonsters caps how many monsters one encounter can field — a
+// single column of the grid.
+const MaxEncounterMonsters = 6
+
+// StartCombat initialises a new CombatSession against one or more monsters
+// (a goblin pack lists "goblin" once per goblin). Every monster rolls its own
+// initiative. The session lives in server memory only — it is never written to
+// the save file.
+func StartCombat(db *sql.DB, save *types.SaveFile, npub string, monsterIDs []string, environmentID string, advancement []types.AdvancementEntry) (*types.CombatSession, error) {
+	if len(monsterIDs) == 0 {
+		return nil, fmt.Errorf("StartCombat: no monsters")
+	}
+	if len(monsterIDs) > MaxEncounterMonsters {
+		return nil, fmt.Errorf("StartCombat: %d monsters, at most %d per encounter", len(monsterIDs), MaxEncounterMonsters)
+	}
+	monsters := make([]*types.MonsterData, 0, len(monsterIDs))
+	groupCR := 0.0
+	for _, monsterID := range monsterIDs {
+		monsterData, err := LoadMonsterByID(db, monsterID)
+		if err != nil {
+			return nil, fmt.Errorf("StartCombat: %w", err)
+		}
+		monsters = append(monsters, monsterData)
+		groupCR += monsterData.ChallengeRating
 	}
 
-	cs := initCombatSession(npub, save, monsterData, environmentID)
+	ambient := AmbientLight(db, environmentID, save.TimeOfDay)
+	cs := initCombatSession(npub, save, monsters, environmentID, LightLevel(ambient, HasLightSource(db, save)))
+	cs.AmbientLight = ambient
+	cs.Night = gametime.IsNight(save.TimeOfDay)
 
 	level := character.GetLevelFromXP(save.Experience, advancement)
 	// Seed the martial class resource pool (Rage/Stamina/Ki/Cunning) for the fight.
 	InitResourcePool(&cs.Party[0].CombatState, save.Class, level, save.Stats)
+	// Class/level crit range (a fighter critting on 19–20); effects widen it per attack.
+	cs.Party[0].CombatState.CritRange = LoadCritRules(db).ClassCritRange(save.Class, level)
+	refreshArmorProficiency(db, cs, save)
+	refreshDamageDefenses(db, cs, save)
 	// Rate the fight against the player's level band (M5 §22 difficulty guardrail).
-	cs.Difficulty = encounter.Difficulty(monsterData.ChallengeRating, level)
+	// A group rates by its combined CR: four CR 1/4 goblins fight like a CR 1.
+	cs.Difficulty = encounter.Difficulty(groupCR, level)
 
 	effStats := effectiveStats(save)
-	playerDEXMod := StatMod(GetStatFromMap(effStats, "dexterity"))
-	monsterDEXMod := StatMod(monsterData.Stats.Dexterity)
-	playerInit := rollInitiative(playerDEXMod)
-	monsterInit := rollInitiative(monsterDEXMod)
-
 	playerDEX := GetStatFromMap(effStats, "dexterity")
-	cs.Initiative = buildInitiativeOrder(npub, playerDEX, playerInit.Total, monsterData.Stats.Dexterity, monsterInit.Total, cs.Monsters[0])
-
-	cs.Log = append(cs.Log,
-		fmt.Sprintf("⚔️  Combat begins! %s appears at range %d.", cs.Monsters[0].Name, currentRange(cs)),
-	)
+	playerInit := rollInitiative(StatMod(playerDEX))
+	entries := []types.InitiativeEntry{{ID: npub, Type: "player", Initiative: playerInit.Total, DEXScore: playerDEX}}
+	monsterInits := make([]initRoll, len(cs.Monsters))
+	for i := range cs.Monsters {
+		m := &cs.Monsters[i]
+		monsterInits[i] = rollInitiative(StatMod(m.Data.Stats.Dexterity))
+		m.Initiative = monsterInits[i].Total
+		entries = append(entries, types.InitiativeEntry{ID: m.InstanceID, Type: "monster", Initiative: m.Initiative, DEXScore: m.Data.Stats.Dexterity})
+	}
+	cs.Initiative = buildInitiativeOrder(entries)
+
+	foes := cs.Monsters[0].Name
+	if len(cs.Monsters) == 1 {
+		cs.Log = append(cs.Log,
+			fmt.Sprintf("⚔️  Combat begins! %s appears at range %d.", foes, currentRange(cs)),
+		)
+	} else {
+		foes = "The group"
+		names := make([]string, len(cs.Monsters))
+		for i, m := range cs.Monsters {
+			names[i] = m.Name
+		}
+		cs.Log = append(cs.Log,
+			fmt.Sprintf("⚔️  Combat begins! %s appear — the nearest at range %d.", strings.Join(names, ", "), currentRange(cs)),
+		)
+	}
+	switch {
+	case cs.LightLevel == LightDark:
+		cs.Log = append(cs.Log, "  🌑 It's too dark to see far — ranged attacks have disadvantage.")
+	case cs.AmbientLight == LightDark:
+		cs.Log = append(cs.Log, "  🔥 Your light holds back the darkness.")
+	}
+	if cs.Night && cs.LightLevel != LightDark {
+		cs.Log = append(cs.Log, "  🌙 Night has fallen — ranged attacks have disadvantage.")
+	}
+	if cs.Party[0].CombatState.ArmorHampered {
+		cs.Log = append(cs.Log, "  ⚠️ Your armor is unfamiliar — it hampers your attacks.")
+	}
 	switch cs.Difficulty {
 	case "deadly":
-		cs.Log = append(cs.Log, fmt.Sprintf("  ⚠️ %s looks deadly — you may want to flee.", cs.Monsters[0].Name))
+		cs.Log = append(cs.Log, fmt.Sprintf("  ⚠️ %s looks deadly — you may want to flee.", foes))
 	case "tough":
-		cs.Log = append(cs.Log, fmt.Sprintf("  ⚠️ %s looks like a tough fight.", cs.Monsters[0].Name))
+		cs.Log = append(cs.Log, fmt.Sprintf("  ⚠️ %s looks like a tough fight.", foes))
 	}
 	cs.Log = append(cs.Log,
 		"⚡ Rolling initiative…",
 		fmt.Sprintf("  You rolled %d%s", playerInit.Face, formatModifier(playerInit.Mod)),
-		fmt.Sprintf("  %s rolled %d%s", cs.Monsters[0].Name, monsterInit.Face, formatModifier(monsterInit.Mod)),
 	)
+	for i, m := range cs.Monsters {
+		cs.Log = append(cs.Log, fmt.Sprintf("  %s rolled %d%s", m.Name, monsterInits[i].Face, formatModifier(monsterInits[i].Mod)))
+	}
 
 	if monsterHasFirstTurn(cs) {
-		cs.Log = append(cs.Log, fmt.Sprintf("⚡ %s goes first!", cs.Monsters[0].Name))
+		cs.Log = append(cs.Log, fmt.Sprintf("⚡ %s goes first!", monsterByID(cs, cs.Initiative[0].ID).Name))
 		// Capture the spawn position so the frontend can animate the opening
 		// step from where the monster appeared, not from where it ended up.
-		spawnPos := cs.MonsterPos
+		spawnPos := cs.Monsters[0].Pos
 		cs.MonsterSpawnPos = &spawnPos
 		cs.Log = append(cs.Log, execMonsterOpeningTurn(db, cs, save)...)
 	} else {
@@ -127,26 +284,65 @@ func StartCombat(db *sql.DB, save *types.SaveFile, npub, monsterID, environmentI
 	return cs, nil
 }
 
-// initCombatSession constructs the initial CombatSession with one player and one monster.
-func initCombatSession(npub string, save *types.SaveFile, monster *types.MonsterData, environmentID string) *types.CombatSession {
+// initCombatSession constructs the initial CombatSession with one player and
+// the encounter's monsters. In darkness the monsters aren't seen until they're
+// close.
+func initCombatSession(npub string, save *types.SaveFile, monsters []*types.MonsterData, environmentID, light string) *types.CombatSession {
 	sr := startingRange(environmentID)
+	if light == LightDark && sr > darkStartingRange {
+		sr = darkStartingRange
+	}
 	monsterX := 1 + sr
 	if monsterX > combatGridWidth-2 {
 		monsterX = combatGridWidth - 2
 	}
 	return &types.CombatSession{
 		Party:         []types.PartyCombatant{newPlayerCombatant(npub, save)},
-		Monsters:      []types.MonsterInstance{newMonsterInstance(monster)},
+		Monsters:      placeMonsters(monsters, monsterX),
 		Round:         1,
 		GridWidth:     combatGridWidth,
 		GridHeight:    combatGridHeight,
 		PlayerPos:     types.Position{X: 1, Y: combatGridHeight / 2},
-		MonsterPos:    types.Position{X: monsterX, Y: combatGridHeight / 2},
 		EnvironmentID: environmentID,
+		LightLevel:    light,
 		Phase:         "active",
 	}
 }
 
+// placeMonsters builds the encounter's monsters in a column at x, the first on
+// the player's row and the rest fanning out above and below it (spilling into
+// the next column when one is full). Repeated monsters are numbered — "Goblin 1",
+// "Goblin 2" with instance IDs "goblin-1", "goblin-2" — so each can be targeted.
+func placeMonsters(monsters []*types.MonsterData, x int) []types.MonsterInstance {
+	counts := make(map[string]int, len(monsters))
+	for _, data := range monsters {
+		counts[data.ID]++
+	}
+	seen := make(map[string]int, len(monsters))
+	out := make([]types.MonsterInstance, 0, len(monsters))
+	mid := combatGridHeight / 2
+	for i, data := range monsters {
+		m := newMonsterInstance(data)
+		if counts[data.ID] > 1 {
+			seen[data.ID]++
+			m.InstanceID = fmt.Sprintf("%s-%d", data.ID, seen[data.ID])
+			m.Name = fmt.Sprintf("%s %d", data.Name, seen[data.ID])
+		}
+		col, row := i/combatGridHeight, i%combatGridHeight
+		offset := (row + 1) / 2 // 0, 1, 1, 2, 2, 3, 3
+		if row%2 == 1 {
+			offset = -offset
+		}
+		mx := x + col
+		if mx > combatGridWidth-1 {
+			mx = combatGridWidth - 1
+		}
+		m.Pos = types.Position{X: mx, Y: mid + offset}
+		out = append(out, m)
+	}
+	return out
+}
+
 // newPlayerCombatant snapshots the player's current HP into combat state.
 func newPlayerCombatant(npub string, save *types.SaveFile) types.PartyCombatant {
 	return types.PartyCombatant{
@@ -201,13 +397,10 @@ func rollInitiative(mod int) initRoll {
 	return initRoll{Face: f, Mod: mod, Total: f + mod}
 }
 
-// buildInitiativeOrder returns combatants sorted by initiative, with DEX as the tiebreaker.
-func buildInitiativeOrder(playerID string, playerDEX, playerInit, monsterDEX, monsterInit int, monster types.MonsterInstance) []types.InitiativeEntry {
-	entries := []types.InitiativeEntry{
-		{ID: playerID, Type: "player", Initiative: playerInit, DEXScore: playerDEX},
-		{ID: monster.InstanceID, Type: "monster", Initiative: monsterInit, DEXScore: monsterDEX},
-	}
-	sort.Slice(entries, func(i, j int) bool {
+// buildInitiativeOrder sorts combatants by initiative, with DEX as the tiebreaker.
+// Full ties keep their given order (the player is listed first).
+func buildInitiativeOrder(entries []types.InitiativeEntry) []types.InitiativeEntry {
+	sort.SliceStable(entries, func(i, j int) bool {
 		if entries[i].Initiative != entries[j].Initiative {
 			return entries[i].Initiative > entries[j].Initiative
 		}
@@ -235,27 +428,45 @@ func monsterHasFirstTurn(cs *types.CombatSession) bool {
 	return len(cs.Initiative) > 0 && cs.Initiative[0].Type == "monster"
 }
 
-// execMonsterOpeningTurn runs the monster's turn when it wins initiative at combat start.
-// The player hasn't chosen a stance yet, so they get a reflex save to potentially dodge.
+// execMonsterOpeningTurn runs the turns of every monster that beat the player on
+// initiative at combat start, walking the turn queue up to the player. The player
+// hasn't chosen a stance yet, so they get a reflex save against each attack to
+// potentially dodge.
 func execMonsterOpeningTurn(db *sql.DB, cs *types.CombatSession, save *types.SaveFile) []string {
 	playerAC := computePlayerAC(db, save)
 	dexMod := StatMod(GetStatFromMap(effectiveStats(save), "dexterity"))
-	dmg, log := ExecuteMonsterTurn(cs, &cs.Monsters[0], playerAC, true, dexMod, save)
-	if dmg > 0 {
-		log = append(log, applyDamageToPlayer(cs, dmg)...)
-	}
+	var log []string
+	runTurnsUntilPlayer(cs, "active", func(monster *types.MonsterInstance) {
+		damage, turnLog := ExecuteMonsterTurn(cs, monster, playerAC, true, dexMod, save)
+		log = append(log, turnLog...)
+		log = append(log, applyMonsterDamage(cs, save, damage)...)
+	})
 	return log
 }
 
+// monsterByID returns the monster with the given instance ID, or nil.
+func monsterByID(cs *types.CombatSession, instanceID string) *types.MonsterInstance {
+	for i := range cs.Monsters {
+		if cs.Monsters[i].InstanceID == instanceID {
+			return &cs.Monsters[i]
+		}
+	}
+	return nil
+}
+
 // ─── ProcessPlayerMove ───────────────────────────────────────────────────────
 
 // ProcessPlayerMove moves the player to the target grid cell.
 // Can be called any time during the player's turn while movement budget remains.
 // Does NOT trigger the monster's response — the player must call ProcessEndTurn.
 //
-// If the player leaves the monster's melee reach without having used Disengage,
-// the monster's reaction fires as an opportunity attack.
-func ProcessPlayerMove(db *sql.DB, cs *types.CombatSession, save *types.SaveFile, targetX, targetY int) ([]string, error) {
+// Range is reported relative to the current target. Every living monster whose
+// melee reach the player leaves without having used Disengage gets its reaction
+// as an opportunity attack.
+//
+// targetID optionally retargets the player (see resolveTarget); range in the log
+// is then measured to that monster.
+func ProcessPlayerMove(db *sql.DB, cs *types.CombatSession, save *types.SaveFile, targetID string, targetX, targetY int) ([]string, error) {
 	if cs.Phase != "active" {
 		return nil, fmt.Errorf("cannot move: combat phase is %q", cs.Phase)
 	}
@@ -267,8 +478,8 @@ func ProcessPlayerMove(db *sql.DB, cs *types.CombatSession, save *types.SaveFile
 		return nil, fmt.Errorf("target (%d,%d) is outside the grid", targetX, targetY)
 	}
 	target := types.Position{X: targetX, Y: targetY}
-	if target == cs.MonsterPos {
-		return nil, fmt.Errorf("cannot move into the monster's space")
+	if m := monsterAt(cs, target); m != nil {
+		return nil, fmt.Errorf("cannot move into %s's space", m.Name)
 	}
 
 	state := &cs.Party[0].CombatState
@@ -280,11 +491,26 @@ func ProcessPlayerMove(db *sql.DB, cs *types.CombatSession, save *types.SaveFile
 	if dist > remaining {
 		return nil, fmt.Errorf("not enough movement — need %d cells, have %d remaining", dist, remaining)
 	}
+	if targetID != "" {
+		focus, err := resolveTarget(cs, targetID)
+		if err != nil {
+			return nil, err
+		}
+		cs.TargetID = focus.InstanceID
+	}
 
 	prevRange := currentRange(cs)
+	prevRanges := make([]int, len(cs.Monsters))
+	for i := range cs.Monsters {
+		prevRanges[i] = rangeTo(cs, &cs.Monsters[i])
+	}
+	from := cs.PlayerPos
 	cs.PlayerPos = target
 	state.MovementSpent += dist
 	newRange := currentRange(cs)
+	emitMove(cs, playerActor, from, target, newRange)
+	aimLost := state.Aiming
+	state.Aiming = false
 
 	var dir string
 	switch {
@@ -297,12 +523,15 @@ func ProcessPlayerMove(db *sql.DB, cs *types.CombatSession, save *types.SaveFile
 	}
 	log := []string{fmt.Sprintf("  You move %s. (range: %d, movement: %d/%d)",
 		dir, newRange, state.MovementSpent, state.MovementBudget)}
+	if aimLost {
+		log = append(log, "  Moving spoils your aim.")
+	}
 
-	// Opportunity attack: player left the monster's melee reach
-	if len(cs.Monsters) > 0 {
-		monster := &cs.Monsters[0]
+	// Opportunity attacks: player left a monster's melee reach
+	for i := range cs.Monsters {
+		monster := &cs.Monsters[i]
 		monsterReach := MonsterMeleeReach(monster)
-		if monsterReach > 0 && prevRange <= monsterReach && newRange > monsterReach &&
+		if monsterReach > 0 && prevRanges[i] <= monsterReach && rangeTo(cs, monster) > monsterReach &&
 			!state.Disengaged && !monster.ReactionUsed && monster.IsAlive {
 			log = append(log, executeMonsterOA(cs, monster, save, db)...)
 		}
@@ -337,6 +566,7 @@ func executeMonsterOA(cs *types.CombatSession, monster *types.MonsterInstance, s
 		),
 		outcomeLine(result),
 	}
+	emitAttack(cs, monster.InstanceID, playerActor, result, rangeTo(cs, monster))
 	if !result.IsHit {
 		return log
 	}
@@ -346,7 +576,8 @@ func executeMonsterOA(cs *types.CombatSession, monster *types.MonsterInstance, s
 		crit = " CRITICAL HIT!"
 	}
 	log = append(log, fmt.Sprintf("  %s deals %d %s damage.%s", monster.Name, dmg, action.Hit.Type, crit))
-	log = append(log, applyDamageToPlayer(cs, dmg)...)
+	emitDamage(cs, monster.InstanceID, playerActor, dmg, action.Hit.Type)
+	log = append(log, applyDamageToPlayer(cs, save, dmg, action.Hit.Type)...)
 	return log
 }
 
@@ -370,6 +601,43 @@ func ProcessPlayerDisengage(cs *types.CombatSession) ([]string, error) {
 	return []string{"  You disengage — your movement no longer provokes opportunity attacks."}, nil
 }
 
+// ─── ProcessPlayerAim ────────────────────────────────────────────────────────
+
+// ProcessPlayerAim uses the player's bonus action to steady a ranged weapon.
+// Trades the turn's movement for accuracy: it requires that the player hasn't
+// moved yet, burns the remaining movement, and sets Aiming so the next ranged
+// attack rolls with advantage. Aim carries into later turns while the player
+// stays put; moving, being hit, or making any attack (melee included) clears it.
+func ProcessPlayerAim(db *sql.DB, cs *types.CombatSession, save *types.SaveFile) ([]string, error) {
+	if cs.Phase != "active" {
+		return nil, fmt.Errorf("cannot aim: combat phase is %q", cs.Phase)
+	}
+	if len(cs.Party) == 0 {
+		return nil, fmt.Errorf("no player in combat")
+	}
+	state := &cs.Party[0].CombatState
+	if state.Aiming {
+		return nil, fmt.Errorf("already aiming")
+	}
+	if state.BonusActionUsed {
+		return nil, fmt.Errorf("bonus action already used this turn")
+	}
+	if state.MovementSpent > 0 {
+		return nil, fmt.Errorf("can't aim after moving this turn")
+	}
+	item, isUnarmed, err := loadWeaponItem(db, save.Inventory, "mainHand")
+	if err != nil || isUnarmed || item == nil {
+		return nil, fmt.Errorf("aiming needs a ranged weapon in your main hand")
+	}
+	if weaponType, _ := item["type"].(string); !IsRangedAction(weaponType) {
+		return nil, fmt.Errorf("aiming needs a ranged weapon in your main hand")
+	}
+	state.BonusActionUsed = true
+	state.MovementSpent = state.MovementBudget
+	state.Aiming = true
+	return []string{"  You plant your feet and take careful aim."}, nil
+}
+
 // ─── ProcessEndTurn ──────────────────────────────────────────────────────────
 
 // ProcessEndTurn finalises the player's turn and runs the monster's response.
@@ -417,25 +685,25 @@ func ProcessPlayerHold(cs *types.CombatSession) ([]string, error) {
 // ─── ProcessPlayerFlee ───────────────────────────────────────────────────────
 
 // ProcessPlayerFlee attempts to escape combat.
-// Requires range ≥ 3. Uses the player's full action.
-// On success: phase → "loot" (empty loot — XP already accumulated). Combat ends.
-// On failure: returns log, caller should prompt player to End Turn.
-func ProcessPlayerFlee(cs *types.CombatSession, save *types.SaveFile) ([]string, error) {
+// Requires range ≥ 3 to the nearest living monster, which is the one giving chase.
+// Uses the player's full action.
+// On success: phase → "fled". Fleeing forfeits the fight — no XP and no loot.
+// On failure: the turn is lost and the monsters get a free response turn.
+func ProcessPlayerFlee(db *sql.DB, cs *types.CombatSession, save *types.SaveFile) ([]string, error) {
 	if cs.Phase != "active" {
 		return nil, fmt.Errorf("cannot flee: combat phase is %q", cs.Phase)
 	}
-	if currentRange(cs) < 3 {
-		return nil, fmt.Errorf("too close to flee — retreat to range 3 or more first")
-	}
-	if len(cs.Monsters) == 0 || !cs.Monsters[0].IsAlive {
+	monster := nearestLivingMonster(cs)
+	if monster == nil {
 		return nil, fmt.Errorf("no living enemy to flee from")
 	}
+	if rangeTo(cs, monster) < 3 {
+		return nil, fmt.Errorf("too close to flee — retreat to range 3 or more first")
+	}
 	if len(cs.Party) == 0 {
 		return nil, fmt.Errorf("no player in combat")
 	}
 
-	monster := &cs.Monsters[0]
-
 	fleeStats := effectiveStats(save)
 	playerAth := athleticsScore(
 		GetStatFromMap(fleeStats, "strength"),
@@ -451,7 +719,7 @@ func ProcessPlayerFlee(cs *types.CombatSession, save *types.SaveFile) ([]string,
 	speedAdv := playerAth - monsterAth
 	speedMod := speedAdv * 0.03
 
-	baseChance := float64(currentRange(cs)-2) * 0.25 // range 3=25%, 4=50%, 5=75%, 6→capped
+	baseChance := float64(rangeTo(cs, monster)-2) * 0.25 // range 3=25%, 4=50%, 5=75%, 6→capped
 	if baseChance > 0.90 {
 		baseChance = 0.90
 	}
@@ -477,17 +745,19 @@ func ProcessPlayerFlee(cs *types.CombatSession, save *types.SaveFile) ([]string,
 	)}
 
 	if roll <= pct {
-		// Escape successful — end combat without a kill (XP already in cs.XPEarnedThisFight)
-		cs.Phase = "loot"
+		// Escape successful — the fight is abandoned, along with its XP and loot.
+		cs.Phase = "fled"
 		cs.LootRolled = nil
+		cs.XPEarnedThisFight = 0
 		log = append(log, "  You manage to put enough distance between you and the enemy to escape!")
 		return log, nil
 	}
 
-	// Escape failed — player's action is spent; monster responds when player ends turn
+	// Escape failed — the attempt costs the turn and the monsters get a free response.
 	state := &cs.Party[0].CombatState
 	state.ActionUsed = true
 	log = append(log, fmt.Sprintf("  %s cuts off your escape! You're still in combat.", monster.Name))
+	log = append(log, runMonsterResponseTurn(db, cs, save)...)
 	return log, nil
 }
 
@@ -505,7 +775,9 @@ func athleticsScore(str, con, dex int) float64 {
 //
 // hand: "main" (default) or "off" for two-weapon bonus attack.
 // thrown: true to treat a melee weapon with the "thrown" tag as a ranged attack.
-func ProcessPlayerAttack(db *sql.DB, cs *types.CombatSession, save *types.SaveFile, weaponSlot string, hand string, thrown bool, advancement []types.AdvancementEntry) ([]string, error) {
+// targetID: monster instance to attack ("" = current target). The target only
+// becomes the current one once the attack passes validation.
+func ProcessPlayerAttack(db *sql.DB, cs *types.CombatSession, save *types.SaveFile, targetID string, weaponSlot string, hand string, thrown bool, advancement []types.AdvancementEntry) ([]string, error) {
 	if cs.Phase != "active" {
 		return nil, fmt.Errorf("cannot attack: combat phase is %q", cs.Phase)
 	}
@@ -515,8 +787,9 @@ func ProcessPlayerAttack(db *sql.DB, cs *types.CombatSession, save *types.SaveFi
 
 	var log []string
 	state := &cs.Party[0].CombatState
-	if IsIncapacitated(state.Conditions) {
-		return nil, fmt.Errorf("you are incapacitated and can't act")
+	conds := playerConditions(cs, save)
+	if IsIncapacitated(conds) {
+		return nil, fmt.Errorf("you are %s and can't act", incapacitatingConditionName(conds))
 	}
 	isOffHand := hand == "off"
 
@@ -534,8 +807,13 @@ func ProcessPlayerAttack(db *sql.DB, cs *types.CombatSession, save *types.SaveFi
 		return nil, err
 	}
 
-	// Validate that the attack can reach the target at the current range
-	if err := validateAttackRange(cs, item, isUnarmed, thrown); err != nil {
+	monster, err := resolveTarget(cs, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate that the attack can reach the chosen target at its range
+	if err := validateAttackRange(cs, monster, item, isUnarmed, thrown); err != nil {
 		return nil, err
 	}
 
@@ -558,17 +836,34 @@ func ProcessPlayerAttack(db *sql.DB, cs *types.CombatSession, save *types.SaveFi
 		}
 	}
 
-	monster := &cs.Monsters[0]
+	// The attack is going ahead — it now defines the player's current target.
+	cs.TargetID = monster.InstanceID
 	level := character.GetLevelFromXP(save.Experience, advancement)
 
 	attackBonus := resolveAttackBonus(item, effectiveStats(save), save.Class, level, isUnarmed, thrown)
-	advantage := resolveAttackAdvantage(cs, item, isUnarmed, save.Race, thrown)
+	refreshLight(db, cs, save)
+	refreshArmorProficiency(db, cs, save)
+	advantage := resolveAttackAdvantage(cs, monster, item, isUnarmed, save.Race, thrown)
+	// Armor the class isn't trained in hampers every attack, armed or not.
+	if state.ArmorHampered {
+		advantage--
+	}
 	// Conditions: the player's own conditions (poisoned/prone/…) impose disadvantage;
 	// the target monster's (restrained/blinded/outlined/…) grant advantage.
-	advantage += ConditionAttackAdvantage(state.Conditions, monster.Conditions)
-	result := ResolveAttackRoll(attackBonus, monster.ArmorClass, advantage)
+	advantage += ConditionAttackAdvantage(conds, monster.Conditions)
+	result := ResolveAttackRollWithCritRange(attackBonus, monster.ArmorClass, advantage, playerCritRange(cs, save))
+	// Any attack spends a readied aim — it only helped if this one was ranged
+	// (resolveAttackAdvantage); a melee swing just wastes it.
+	if state.Aiming {
+		state.Aiming = false
+		if weaponType, _ := item["type"].(string); item != nil && (IsRangedAction(weaponType) || thrown) {
+			log = append(log, "  🎯 Your steady aim pays off.")
+		}
+	}
 
 	log = append(log, formatAttackRoll(save.D, item, isUnarmed, result), outcomeLine(result))
+	cs.LastAttack = attackPresentation(item, isUnarmed, thrown, isOffHand, monster, result)
+	emitAttack(cs, playerActor, monster.InstanceID, result, rangeTo(cs, monster))
 
 	if !result.IsHit {
 		if isOffHand {
@@ -599,6 +894,8 @@ func ProcessPlayerAttack(db *sql.DB, cs *types.CombatSession, save *types.SaveFi
 	log = append(log, riderLog...)
 
 	applyDamageToMonster(monster, dmg)
+	cs.LastAttack.Damage = dmg
+	emitDamage(cs, playerActor, monster.InstanceID, dmg, cs.LastAttack.DamageType)
 
 	xp := awardDamageXP(cs, monster, dmg, save.TimeOfDay, level, advancement)
 	if xp > 0 {
@@ -612,7 +909,7 @@ func ProcessPlayerAttack(db *sql.DB, cs *types.CombatSession, save *types.SaveFi
 	}
 
 	if !monster.IsAlive {
-		return append(log, handleMonsterKill(cs, monster, save, advancement)...), nil
+		return append(log, handleMonsterKill(db, cs, monster, save, advancement)...), nil
 	}
 
 	return log, nil
@@ -674,8 +971,8 @@ func resolveAttackBonus(item map[string]interface{}, stats map[string]interface{
 }
 
 // resolveAttackAdvantage returns >0 (advantage), <0 (disadvantage), or 0 (normal).
-// Phase 2: ranged-at-melee-range, long-range, heavy weapon + small race.
-func resolveAttackAdvantage(cs *types.CombatSession, item map[string]interface{}, isUnarmed bool, race string, thrown bool) int {
+// Phase 2: ranged-at-melee-range, long-range, ranged in darkness, heavy weapon + small race.
+func resolveAttackAdvantage(cs *types.CombatSession, target *types.MonsterInstance, item map[string]interface{}, isUnarmed bool, race string, thrown bool) int {
 	if isUnarmed || item == nil {
 		return 0
 	}
@@ -685,7 +982,7 @@ func resolveAttackAdvantage(cs *types.CombatSession, item map[string]interface{}
 	actingAsRanged := IsRangedAction(weaponType) || thrown
 
 	if actingAsRanged {
-		r := currentRange(cs)
+		r := rangeTo(cs, target)
 		// Disadvantage when firing at melee range
 		if r == 0 {
 			advantage--
@@ -695,6 +992,14 @@ func resolveAttackAdvantage(cs *types.CombatSession, item map[string]interface{}
 		if r > normalRange {
 			advantage--
 		}
+		// Readied aim (ProcessPlayerAim) steadies the shot.
+		if len(cs.Party) > 0 && cs.Party[0].CombatState.Aiming {
+			advantage++
+		}
+		// Shooting into darkness, or at anything after dark (see light.go)
+		if cs.LightLevel == LightDark || cs.Night {
+			advantage--
+		}
 	}
 
 	// Heavy weapons impose disadvantage for small races
@@ -707,9 +1012,9 @@ func resolveAttackAdvantage(cs *types.CombatSession, item map[string]interface{}
 	return advantage
 }
 
-// validateAttackRange returns an error if the current combat range prevents this attack.
-func validateAttackRange(cs *types.CombatSession, item map[string]interface{}, isUnarmed, thrown bool) error {
-	r := currentRange(cs)
+// validateAttackRange returns an error if the target's range prevents this attack.
+func validateAttackRange(cs *types.CombatSession, target *types.MonsterInstance, item map[string]interface{}, isUnarmed, thrown bool) error {
+	r := rangeTo(cs, target)
 	if isUnarmed || item == nil {
 		if r > 0 {
 			return fmt.Errorf("enemy is out of melee range — move closer or use a ranged weapon")
@@ -764,6 +1069,9 @@ func validateTwoWeaponFighting(db *sql.DB, save *types.SaveFile, cs *types.Comba
 		return fmt.Errorf("no weapon in off hand for two-weapon fighting")
 	}
 
+	if hasTag(mainItem["tags"], "two-handed") || hasTag(offItem["tags"], "two-handed") {
+		return fmt.Errorf("a two-handed weapon leaves no hand free for two-weapon fighting")
+	}
 	if !hasTag(mainItem["tags"], "light") {
 		return fmt.Errorf("main hand weapon must be light for two-weapon fighting")
 	}
@@ -776,8 +1084,8 @@ func validateTwoWeaponFighting(db *sql.DB, save *types.SaveFile, cs *types.Comba
 	return nil
 }
 
-// consumeAmmo removes one unit of ammo from the ammo gear slot and increments
-// the session's ammo-used counter. Returns an error if no ammo is equipped.
+// consumeAmmo removes one unit of ammo from the ammo gear slot and records it
+// on the session's ammo-used counters. Returns an error if no ammo is equipped.
 func consumeAmmo(save *types.SaveFile, cs *types.CombatSession, weapon map[string]interface{}) error {
 	gearSlots, ok := save.Inventory["gear_slots"].(map[string]interface{})
 	if !ok {
@@ -797,8 +1105,8 @@ func consumeAmmo(save *types.SaveFile, cs *types.CombatSession, weapon map[strin
 		// A quiver (or any container) in the ammo slot: draw one round from its
 		// contents rather than consuming the quiver itself.
 		if contents, ok := slotMap["contents"].([]interface{}); ok {
-			if consumeFromAmmoContents(contents, weapon) {
-				cs.AmmoUsedThisCombat++
+			if ammoID := consumeFromAmmoContents(contents, weapon); ammoID != "" {
+				recordAmmoUsed(cs, ammoID)
 				return nil
 			}
 			continue // container present but has no usable ammo inside
@@ -813,7 +1121,7 @@ func consumeAmmo(save *types.SaveFile, cs *types.CombatSession, weapon map[strin
 				slotMap["quantity"] = qty - 1
 				gearSlots[key] = slotMap
 			}
-			cs.AmmoUsedThisCombat++
+			recordAmmoUsed(cs, itemID)
 			return nil
 		}
 	}
@@ -826,8 +1134,9 @@ func errNoAmmo() error {
 
 // consumeFromAmmoContents removes one round from a container's contents: a first
 // pass prefers ammo matching the weapon, a second pass takes any ammo so a
-// mismatched-but-present round still fires. Returns false if the container is empty.
-func consumeFromAmmoContents(contents []interface{}, weapon map[string]interface{}) bool {
+// mismatched-but-present round still fires. Returns the round's item id, or ""
+// if the container is empty.
+func consumeFromAmmoContents(contents []interface{}, weapon map[string]interface{}) string {
 	for _, matchOnly := range []bool{true, false} {
 		for _, c := range contents {
 			slotMap, ok := c.(map[string]interface{})
@@ -851,10 +1160,19 @@ func consumeFromAmmoContents(contents []interface{}, weapon map[string]interface
 			} else {
 				slotMap["quantity"] = qty - 1
 			}
-			return true
+			return id
 		}
 	}
-	return false
+	return ""
+}
+
+// recordAmmoUsed counts one round of ammoID fired this combat.
+func recordAmmoUsed(cs *types.CombatSession, ammoID string) {
+	cs.AmmoUsedThisCombat++
+	if cs.AmmoUsedByItem == nil {
+		cs.AmmoUsedByItem = make(map[string]int)
+	}
+	cs.AmmoUsedByItem[ammoID]++
 }
 
 // ammoMatchesWeapon loosely matches an ammo item id against the weapon's
@@ -948,6 +1266,37 @@ func formatAttackRoll(playerName string, item map[string]interface{}, isUnarmed
 		playerName, weapon, result.Roll, formatModifier(result.Modifier))
 }
 
+// attackPresentation builds the animation hint for a resolved player attack.
+// Damage is filled in by the caller once it's rolled.
+func attackPresentation(item map[string]interface{}, isUnarmed, thrown, offHand bool, target *types.MonsterInstance, result AttackResult) *types.AttackPresentation {
+	p := &types.AttackPresentation{
+		WeaponCategory: "unarmed",
+		DamageType:     "bludgeoning",
+		Thrown:         thrown,
+		OffHand:        offHand,
+		TargetID:       target.InstanceID,
+		Hit:            result.IsHit,
+		Crit:           result.IsCrit,
+	}
+	if isUnarmed || item == nil {
+		p.Thrown = false
+		return p
+	}
+	p.WeaponID, _ = item["id"].(string)
+	p.DamageType = WeaponDamageType(item)
+	weaponType, _ := item["type"].(string)
+	switch lower := strings.ToLower(weaponType); {
+	case strings.Contains(lower, "martial"):
+		p.WeaponCategory = "martial"
+	case strings.Contains(lower, "simple"):
+		p.WeaponCategory = "simple"
+	default:
+		p.WeaponCategory = "improvised"
+	}
+	p.Ranged = IsRangedAction(weaponType) && !thrown
+	return p
+}
+
 // outcomeLine renders the hit/miss/crit verdict as its own log entry.
 func outcomeLine(r AttackResult) string {
 	switch {
@@ -1030,64 +1379,113 @@ func awardDamageXP(cs *types.CombatSession, monster *types.MonsterInstance, dmg,
 	return xp
 }
 
-// handleMonsterKill processes monster death: rolls loot and checks for a level-up.
-func handleMonsterKill(cs *types.CombatSession, monster *types.MonsterInstance, save *types.SaveFile, advancement []types.AdvancementEntry) []string {
+// handleMonsterKill processes monster death: rolls loot (rare tiers weighted up
+// at night and by the player's luck, each drop tagged with its rarity) onto the
+// fight's pile, logs the drops, and checks for a level-up. The fight only ends
+// once the last monster falls.
+func handleMonsterKill(db *sql.DB, cs *types.CombatSession, monster *types.MonsterInstance, save *types.SaveFile, advancement []types.AdvancementEntry) []string {
 	log := []string{fmt.Sprintf("  %s is defeated!", monster.Name)}
+	emitEvent(cs, types.CombatEvent{Type: "death", Target: monster.InstanceID})
 
 	// Feed the kill to the event recorder so "slay" quest objectives advance.
 	// No-op until a consumer is subscribed at startup.
 	events.Record(save, events.MonsterKilled, monster.Data.ID, 1)
 
-	cs.LootRolled = RollLoot(monster.Data.LootTable)
-	cs.Phase = "loot"
+	killLevel := character.GetLevelFromXP(save.Experience, advancement)
+	luck := LootLuck(save, killLevel)
+	loot := RollLootScaled(monster.Data.LootTable, NightMultiplier(save.TimeOfDay)*luck)
+	annotateLootRarity(db, loot)
+	cs.LootRolled = append(cs.LootRolled, loot...)
+	if len(loot) > 0 {
+		emitEvent(cs, types.CombatEvent{Type: "loot", Target: monster.InstanceID, Loot: loot})
+	}
+	if line := lootLogLine(db, loot); line != "" {
+		if bonus := int(math.Round((luck - 1) * 100)); bonus > 0 {
+			line += fmt.Sprintf(" (luck +%d%% on rare finds)", bonus)
+		}
+		log = append(log, "  🎁 Drops: "+line)
+	}
 
 	// Kill bonus: flat XP for the kill itself (set on tougher monsters, and on
 	// POI/dungeon steps via the node walker in M3), on top of the proportional
 	// damage XP accrued during the fight.
-	killLevel := character.GetLevelFromXP(save.Experience, advancement)
 	if bonus := character.BonusXP(killLevel, KillBonusXP(&monster.Data), advancement); bonus > 0 {
 		cs.XPEarnedThisFight += bonus
 		log = append(log, fmt.Sprintf("  +%d bonus XP for slaying %s!", bonus, monster.Name))
 	}
 
-	if character.WillLevelUp(save.Experience, cs.XPEarnedThisFight, advancement) {
+	if character.WillLevelUp(save.Experience, cs.XPEarnedThisFight, advancement) && !cs.LevelUpPending {
 		cs.LevelUpPending = true
 		log = append(log, "  Level up!")
+		emitEvent(cs, types.CombatEvent{Type: "level_up", Target: playerActor})
 	}
 
+	if left := livingMonsters(cs); left > 0 {
+		log = append(log, fmt.Sprintf("  %d %s still standing.", left, pluralFoes(left)))
+		return log
+	}
+	cs.Phase = "loot"
 	log = append(log, fmt.Sprintf("  Victory! +%d XP this fight.", cs.XPEarnedThisFight))
 	return log
 }
 
-// runMonsterResponseTurn runs the monster's turn (called by ProcessEndTurn).
-// If the player held position this turn and the monster advances into melee reach,
-// the player's readied counter-attack fires before the monster can swing.
-// Resets player turn state so the next round starts fresh.
+func pluralFoes(n int) string {
+	if n == 1 {
+		return "foe"
+	}
+	return "foes"
+}
+
+// runMonsterResponseTurn ends the player's turn and runs the monsters' turns in
+// initiative order until the player is up again (called by ProcessEndTurn).
+// If the player held position this turn and a monster advances into melee reach,
+// the player's readied counter-attack fires before that monster can swing.
+// Resets player turn state so the next turn starts fresh.
 func runMonsterResponseTurn(db *sql.DB, cs *types.CombatSession, save *types.SaveFile) []string {
-	if cs.Phase != "active" || len(cs.Monsters) == 0 || !cs.Monsters[0].IsAlive {
+	if cs.Phase != "active" || livingMonsters(cs) == 0 {
 		resetPlayerTurnState(cs, save)
 		return nil
 	}
 
-	monster := &cs.Monsters[0]
-	decision := DecideMonsterAction(cs, monster)
 	playerAC := computePlayerAC(db, save)
+	refreshDamageDefenses(db, cs, save)
 
 	var log []string
 
 	// End of the player's turn: their conditions save-to-end / count down before
-	// the monster acts. A condition the monster imposes later this turn persists to
+	// the monsters act. A condition a monster imposes later this turn persists to
 	// the player's next turn (it lands after this tick).
 	if len(cs.Party) > 0 {
 		log = append(log, TickCreatureConditions("You", &cs.Party[0].CombatState.Conditions,
-			func(stat string) int { return playerSaveTotal(save, stat) })...)
+			func(stat string) int { return playerSaveTotal(cs, save, stat) })...)
+		log = append(log, tickEffectConditionSaves(cs, save)...)
 		// End of your turn: regen the class resource and count down rage.
 		log = append(log, tickPlayerAbilities(&cs.Party[0].CombatState)...)
 	}
 
+	passTurn(cs, "active", func(monster *types.MonsterInstance) {
+		log = append(log, runSingleMonsterTurn(db, cs, save, monster, playerAC)...)
+	})
+	if len(cs.Party) > 0 {
+		cs.Party[0].CombatState.HeldPosition = false
+	}
+
+	// A new round begins: damage/healing over time lands before the player acts.
+	log = append(log, tickRoundEffects(db, cs, save)...)
+
+	resetPlayerTurnState(cs, save)
+	return log
+}
+
+// runSingleMonsterTurn runs one monster's move, action, and end-of-turn condition tick.
+func runSingleMonsterTurn(db *sql.DB, cs *types.CombatSession, save *types.SaveFile, monster *types.MonsterInstance, playerAC int) []string {
+	// Spent specials roll to recharge at the start of the monster's turn.
+	log := RechargeMonsterActions(monster)
+	decision := DecideMonsterAction(cs, monster)
+
 	// Monster starting adjacent and trying to retreat? Use Disengage (consumes
 	// its action, but avoids the player's OA).
-	if decision.Action == "retreat" && currentRange(cs) <= getPlayerMeleeReach(db, save) {
+	if decision.Action == "retreat" && rangeTo(cs, monster) <= getPlayerMeleeReach(db, save) {
 		monster.Disengaged = true
 		log = append(log, fmt.Sprintf("  %s disengages and breaks off.", monster.Name))
 	}
@@ -1104,45 +1502,39 @@ func runMonsterResponseTurn(db *sql.DB, cs *types.CombatSession, save *types.Sav
 
 	// Apply monster movement
 	log = append(log, ApplyMonsterMove(cs, monster, decision, playerReach, oaTrigger)...)
+	if !monster.IsAlive {
+		return log
+	}
 
-	// If player held position and monster just stepped into melee reach,
-	// the readied counter-attack fires before the monster strikes.
+	// If player held position and this monster just stepped into melee reach,
+	// the readied counter-attack fires before it strikes. The stance is spent
+	// on the first monster that triggers it.
 	if len(cs.Party) > 0 && cs.Party[0].CombatState.HeldPosition && decision.Move == -1 {
-		playerReach := getPlayerMeleeReach(db, save)
-		if currentRange(cs) <= playerReach {
+		if rangeTo(cs, monster) <= playerReach {
 			log = append(log, fmt.Sprintf("  Your readied stance pays off — you strike as %s steps in!", monster.Name))
 			counterLog, killed := executeReadiedAttack(db, cs, save, monster)
 			log = append(log, counterLog...)
 			cs.Party[0].CombatState.HeldPosition = false
 			if killed {
-				resetPlayerTurnState(cs, save)
 				return log
 			}
 		}
 	}
-	if len(cs.Party) > 0 {
-		cs.Party[0].CombatState.HeldPosition = false
-	}
 
 	// Re-pick the attack based on the actual post-move range (the monster may have
 	// closed enough to make a reach check succeed, or moved out of its original band).
 	decision = RefreshAttackDecision(cs, monster, decision)
 
 	// Monster takes its action (no reflex save — player already chose their stance)
-	dmg, actionLog := ApplyMonsterAction(cs, monster, decision, playerAC, false, 0, save)
+	damage, actionLog := ApplyMonsterAction(cs, monster, decision, playerAC, false, 0, save)
 	log = append(log, actionLog...)
-	if dmg > 0 {
-		log = append(log, applyDamageToPlayer(cs, dmg)...)
-		log = append(log, checkConcentrationOnDamage(cs, save, dmg)...)
-	}
+	log = append(log, applyMonsterDamage(cs, save, damage)...)
 
 	// End of the monster's turn: it rolls saves to shake off conditions
 	// (restrained/stunned/…) and timed conditions count down and expire — D&D
 	// resolves both at the end of the afflicted creature's turn.
 	log = append(log, TickCreatureConditions(monster.Name, &monster.Conditions,
 		func(stat string) int { return monsterSaveTotal(monster, stat) })...)
-
-	resetPlayerTurnState(cs, save)
 	return log
 }
 
@@ -1178,7 +1570,7 @@ func executePlayerOA(cs *types.CombatSession, save *types.SaveFile, db *sql.DB,
 
 	level := character.GetLevelFromXP(save.Experience, advancement)
 	attackBonus := resolveAttackBonus(item, effectiveStats(save), save.Class, level, isUnarmed, false)
-	result := ResolveAttackRoll(attackBonus, monster.ArmorClass, 0)
+	result := ResolveAttackRollWithCritRange(attackBonus, monster.ArmorClass, 0, playerCritRange(cs, save))
 
 	weaponName := "Unarmed Strike"
 	if !isUnarmed && item != nil {
@@ -1193,6 +1585,7 @@ func executePlayerOA(cs *types.CombatSession, save *types.SaveFile, db *sql.DB,
 		),
 		outcomeLine(result),
 	}
+	emitAttack(cs, playerActor, monster.InstanceID, result, rangeTo(cs, monster))
 	if !result.IsHit {
 		return log
 	}
@@ -1200,6 +1593,8 @@ func executePlayerOA(cs *types.CombatSession, save *types.SaveFile, db *sql.DB,
 	dmg := resolvePlayerDamage(item, effectiveStats(save), monster, isUnarmed, offhandEmpty, result.IsCrit, false)
 	log = append(log, formatDamage(item, isUnarmed, dmg, result.IsCrit))
 	applyDamageToMonster(monster, dmg)
+	cs.LastAttack.Damage = dmg
+	emitDamage(cs, playerActor, monster.InstanceID, dmg, playerDamageType(item, isUnarmed))
 
 	xp := awardDamageXP(cs, monster, dmg, save.TimeOfDay, level, advancement)
 	if xp > 0 {
@@ -1207,17 +1602,17 @@ func executePlayerOA(cs *types.CombatSession, save *types.SaveFile, db *sql.DB,
 	}
 
 	if !monster.IsAlive {
-		log = append(log, handleMonsterKill(cs, monster, save, advancement)...)
+		log = append(log, handleMonsterKill(db, cs, monster, save, advancement)...)
 	}
 	return log
 }
 
 // resetPlayerTurnState resets per-turn flags so the next round starts fresh.
-// Also resets the monster's per-turn reaction/disengage so both sides start clean.
+// Also resets every monster's per-turn reaction/disengage so both sides start clean.
 func resetPlayerTurnState(cs *types.CombatSession, save *types.SaveFile) {
-	if len(cs.Monsters) > 0 {
-		cs.Monsters[0].ReactionUsed = false
-		cs.Monsters[0].Disengaged = false
+	for i := range cs.Monsters {
+		cs.Monsters[i].ReactionUsed = false
+		cs.Monsters[i].Disengaged = false
 	}
 	if len(cs.Party) == 0 {
 		return
@@ -1225,16 +1620,19 @@ func resetPlayerTurnState(cs *types.CombatSession, save *types.SaveFile) {
 	state := &cs.Party[0].CombatState
 	state.ActionUsed = false
 	state.BonusActionUsed = false
+	state.InteractionUsed = false
 	state.MovementSpent = 0
 	state.MovementBudget = playerMovementBudget(save.Race)
 	state.Dodging = false
 	state.HeldPosition = false
 	state.ReactionUsed = false
 	state.Disengaged = false
-	// Extra actions and a readied-but-unused sneak attack don't carry over.
-	// (Rage persists — it has its own duration countdown in tickPlayerAbilities.)
+	// Extra actions and a readied-but-unused sneak attack or power strike don't
+	// carry over. (Rage persists — it has its own duration countdown in
+	// tickPlayerAbilities.)
 	state.ExtraActions = 0
 	state.PendingSneakDice = ""
+	state.PendingDamagePct = 0
 }
 
 // PlayerMeleeReachForSave is an exported helper for the API layer to report the
@@ -1271,9 +1669,10 @@ func executeReadiedAttack(db *sql.DB, cs *types.CombatSession, save *types.SaveF
 
 	level := character.GetLevelFromXP(save.Experience, advancement)
 	attackBonus := resolveAttackBonus(item, effectiveStats(save), save.Class, level, isUnarmed, false)
-	result := ResolveAttackRoll(attackBonus, monster.ArmorClass, 1) // Advantage: player was ready
+	result := ResolveAttackRollWithCritRange(attackBonus, monster.ArmorClass, 1, playerCritRange(cs, save)) // Advantage: player was ready
 
 	log := []string{formatAttackRoll(save.D, item, isUnarmed, result), outcomeLine(result)}
+	emitAttack(cs, playerActor, monster.InstanceID, result, rangeTo(cs, monster))
 	if !result.IsHit {
 		return log, false
 	}
@@ -1282,6 +1681,8 @@ func executeReadiedAttack(db *sql.DB, cs *types.CombatSession, save *types.SaveF
 	dmg := resolvePlayerDamage(item, effectiveStats(save), monster, isUnarmed, offhandEmpty, result.IsCrit, false)
 	log = append(log, formatDamage(item, isUnarmed, dmg, result.IsCrit))
 	applyDamageToMonster(monster, dmg)
+	cs.LastAttack.Damage = dmg
+	emitDamage(cs, playerActor, monster.InstanceID, dmg, playerDamageType(item, isUnarmed))
 
 	xp := awardDamageXP(cs, monster, dmg, save.TimeOfDay, level, advancement)
 	if xp > 0 {
@@ -1289,7 +1690,7 @@ func executeReadiedAttack(db *sql.DB, cs *types.CombatSession, save *types.SaveF
 	}
 
 	if !monster.IsAlive {
-		log = append(log, handleMonsterKill(cs, monster, save, advancement)...)
+		log = append(log, handleMonsterKill(db, cs, monster, save, advancement)...)
 		return log, true
 	}
 	return log, false
@@ -1301,14 +1702,21 @@ func computePlayerAC(db *sql.DB, save *types.SaveFile) int {
 }
 
 // applyDamageToPlayer deducts HP and transitions to death_saves if HP reaches zero.
-// Returns any resulting log lines (e.g., the player going unconscious).
-func applyDamageToPlayer(cs *types.CombatSession, dmg int) []string {
+// damageType ("fire", "" when untyped) is checked against the player's
+// resistances and immunities first. Damage that lands makes the player save to
+// keep their concentration, and falling unconscious drops it outright. Returns
+// any resulting log lines (e.g., the player going unconscious).
+func applyDamageToPlayer(cs *types.CombatSession, save *types.SaveFile, dmg int, damageType string) []string {
 	if len(cs.Party) == 0 {
 		return nil
 	}
 	state := &cs.Party[0].CombatState
 
 	var log []string
+	if resisted, line := resistDamage(state, dmg, damageType); line != "" {
+		dmg = resisted
+		log = append(log, line)
+	}
 	// Rage soaks a slice of incoming damage.
 	if state.RageResistPct > 0 && dmg > 0 {
 		if reduced := dmg * state.RageResistPct / 100; reduced > 0 {
@@ -1320,15 +1728,40 @@ func applyDamageToPlayer(cs *types.CombatSession, dmg int) []string {
 	if dmg > 0 && state.Resource != nil {
 		regenResource(state.Resource, state.Resource.PerHitTaken)
 	}
+	// A hit breaks the player's aim.
+	if dmg > 0 && state.Aiming {
+		state.Aiming = false
+		log = append(log, "  The blow throws off your aim.")
+	}
 
 	state.CurrentHP -= dmg
 	if state.CurrentHP <= 0 {
 		state.CurrentHP = 0
 		state.IsUnconscious = true
 		cs.Phase = "death_saves"
-		return []string{"  You fall unconscious. Make death saving throws."}
+		log = append(log, "  You fall unconscious. Make death saving throws.")
+		if save != nil {
+			if name := concentrationName(cs, save); name != "" {
+				dropConcentration(cs, save)
+				log = append(log, fmt.Sprintf("  ✘ You lose concentration on %s.", name))
+			}
+		}
+		return log
 	}
-	return nil
+	return append(log, checkConcentrationOnDamage(cs, save, dmg)...)
+}
+
+// applyMonsterDamage applies the hits of a monster's turn in order, each one
+// checking concentration. Hits still pending once the player drops are lost.
+func applyMonsterDamage(cs *types.CombatSession, save *types.SaveFile, damage []MonsterDamage) []string {
+	var log []string
+	for _, hit := range damage {
+		if cs.Phase != "active" {
+			break
+		}
+		log = append(log, applyDamageToPlayer(cs, save, hit.Amount, hit.Type)...)
+	}
+	return log
 }
 
 // addDeathSaveFailures adds N failures and transitions to defeat when total reaches 3.
@@ -1375,12 +1808,19 @@ func resolveDeathSaveRoll(state *types.PlayerCombatState, cs *types.CombatSessio
 
 // reviveFromDeathSave handles a natural 20: player regains 1 HP and consciousness.
 func reviveFromDeathSave(state *types.PlayerCombatState, cs *types.CombatSession) string {
-	state.CurrentHP = 1
+	regainConsciousness(state, cs, 1)
+	return "  Natural 20! You regain consciousness with 1 HP."
+}
+
+// regainConsciousness brings a dying player back up with hp, clearing the death
+// save counters and returning the fight to the active phase.
+func regainConsciousness(state *types.PlayerCombatState, cs *types.CombatSession, hp int) {
+	state.CurrentHP = hp
 	state.IsUnconscious = false
+	state.IsStable = false
 	state.DeathSaveSuccesses = 0
 	state.DeathSaveFailures = 0
 	cs.Phase = "active"
-	return "  Natural 20! You regain consciousness with 1 HP."
 }
 
 // twoDeathSaveFailures handles a natural 1: counts as two failures.
@@ -1412,38 +1852,57 @@ func oneDeathSaveFailure(state *types.PlayerCombatState, cs *types.CombatSession
 	return fmt.Sprintf("  Failure. (%d/3 failures)", state.DeathSaveFailures)
 }
 
-// runMonsterDeathSaveTurn runs the monster's attack against an unconscious player.
-// Hits apply death save failures rather than HP damage.
+// runMonsterDeathSaveTurn runs every living monster's attack against an unconscious
+// player, in initiative order. Hits apply death save failures rather than HP damage.
+// The player is only left alone when every remaining monster decides to break off.
 func runMonsterDeathSaveTurn(cs *types.CombatSession, save *types.SaveFile) []string {
-	if len(cs.Monsters) == 0 || !cs.Monsters[0].IsAlive {
+	decisions := make(map[string]MonsterDecision)
+	var lastLeaving string
+	allLeaving := true
+	for i := range cs.Monsters {
+		monster := &cs.Monsters[i]
+		if !monster.IsAlive {
+			continue
+		}
+		d := DecideMonsterAction(cs, monster)
+		decisions[monster.InstanceID] = d
+		lastLeaving = monster.Name
+		if d.Action != "retreat" && d.Action != "escape" {
+			allLeaving = false
+		}
+	}
+	if len(decisions) == 0 {
 		return nil
 	}
-	monster := &cs.Monsters[0]
-	decision := DecideMonsterAction(cs, monster)
-
-	if decision.Action == "retreat" || decision.Action == "escape" {
+	if allLeaving {
 		cs.Phase = "loot"
 		cs.LootRolled = nil
-		return []string{fmt.Sprintf("  %s disengages and slips away. You are safe.", monster.Name)}
-	}
-	if decision.Action != "attack" {
-		return nil
+		if len(decisions) == 1 {
+			return []string{fmt.Sprintf("  %s disengages and slips away. You are safe.", lastLeaving)}
+		}
+		return []string{"  Your foes disengage and slip away. You are safe."}
 	}
 
-	action := monster.Data.Actions[decision.ActionIndex]
-	isMeleeAtContact := action.Type == "melee_attack" && currentRange(cs) == 0
 	playerAC := 10 + StatMod(GetStatFromMap(effectiveStats(save), "dexterity"))
-	result := resolveDeathSaveAttack(action, playerAC, isMeleeAtContact)
-
-	log := []string{
-		fmt.Sprintf("  %s attacks: rolled %d%s",
-			monster.Name, result.Roll, formatModifier(action.AttackBonus)),
-		outcomeLine(result),
-	}
-
-	if result.IsHit {
-		log = append(log, applyDeathSaveHit(cs, result.IsCrit || isMeleeAtContact))
-	}
+	var log []string
+	passTurn(cs, "death_saves", func(monster *types.MonsterInstance) {
+		decision, ok := decisions[monster.InstanceID]
+		if !ok || decision.Action != "attack" {
+			return
+		}
+		action := monster.Data.Actions[decision.ActionIndex]
+		isMeleeAtContact := action.Type == "melee_attack" && rangeTo(cs, monster) == 0
+		result := resolveDeathSaveAttack(action, playerAC, isMeleeAtContact)
+
+		log = append(log,
+			fmt.Sprintf("  %s attacks: rolled %d%s",
+				monster.Name, result.Roll, formatModifier(action.AttackBonus)),
+			outcomeLine(result),
+		)
+		if result.IsHit {
+			log = append(log, applyDeathSaveHit(cs, result.IsCrit || isMeleeAtContact))
+		}
+	})
 	return log
 }
 
@@ -0,0 +1,47 @@
+package combat
+
+import "pubkey-quest/types"
+
+// The combat event stream mirrors the log prose with typed entries (see
+// types.CombatEvent). Events pile up on the session as the engine resolves a
+// request and the API layer drains them into the response.
+
+// playerActor is the Actor/Target of an event for the player.
+const playerActor = "player"
+
+// emitEvent records one typed combat event for the next response.
+func emitEvent(cs *types.CombatSession, ev types.CombatEvent) {
+	cs.Events = append(cs.Events, ev)
+}
+
+// emitAttack records an attack roll at rng and whether it hit.
+func emitAttack(cs *types.CombatSession, actor, target string, result AttackResult, rng int) {
+	emitEvent(cs, types.CombatEvent{Type: "attack", Actor: actor, Target: target,
+		Roll: result.Roll, Total: result.Total, Range: rng})
+	outcome := types.CombatEvent{Type: "miss", Actor: actor, Target: target, Crit: result.IsCritMiss}
+	if result.IsHit {
+		outcome.Type, outcome.Crit = "hit", result.IsCrit
+	}
+	emitEvent(cs, outcome)
+}
+
+// emitDamage records damage dealt by actor to target.
+func emitDamage(cs *types.CombatSession, actor, target string, amount int, damageType string) {
+	emitEvent(cs, types.CombatEvent{Type: "damage", Actor: actor, Target: target,
+		Damage: amount, DamageType: damageType})
+}
+
+// emitMove records a move from one cell to another, ending rng from the player
+// (or, for the player, from their target).
+func emitMove(cs *types.CombatSession, actor string, from, to types.Position, rng int) {
+	emitEvent(cs, types.CombatEvent{Type: "move", Actor: actor, From: &from, To: &to, Range: rng})
+}
+
+// playerDamageType is the damage type of the player's weapon, bludgeoning for
+// an unarmed strike.
+func playerDamageType(item map[string]interface{}, isUnarmed bool) string {
+	if isUnarmed || item == nil {
+		return "bludgeoning"
+	}
+	return WeaponDamageType(item)
+}
@@ -0,0 +1,67 @@
+package combat
+
+import (
+	"testing"
+
+	"pubkey-quest/types"
+)
+
+func eventTypes(evs []types.CombatEvent) []string {
+	out := make([]string, 0, len(evs))
+	for _, ev := range evs {
+		out = append(out, ev.Type)
+	}
+	return out
+}
+
+// A monster's turn reports its move and each attack as typed events that match
+// what the log says and the damage it returns.
+func TestMonsterTurnEmitsEvents(t *testing.T) {
+	save := &types.SaveFile{Race: "human", Stats: statMap(10, 10, 10, 10, 10, 10)}
+	for i := 0; i < 20; i++ {
+		cs := twoMonsterSession(types.Position{X: 5, Y: 3}, types.Position{X: 8, Y: 6})
+		wolf := &cs.Monsters[0]
+		wolf.Data.Actions[0].AttackBonus = 30
+
+		damage, _ := ExecuteMonsterTurn(cs, wolf, 10, false, 0, save)
+		evs := cs.Events
+		if len(evs) < 3 || evs[0].Type != "move" || evs[1].Type != "attack" {
+			t.Fatalf("want move, attack, outcome…; got %v", eventTypes(evs))
+		}
+		move := evs[0]
+		if move.Actor != "wolf-a" || *move.From != (types.Position{X: 5, Y: 3}) || *move.To != wolf.Pos || move.Range != rangeTo(cs, wolf) {
+			t.Errorf("move event doesn't match the step taken: %+v", move)
+		}
+		attack := evs[1]
+		if attack.Actor != "wolf-a" || attack.Target != "player" || attack.Roll < 1 || attack.Total != attack.Roll+30 {
+			t.Errorf("attack event %+v", attack)
+		}
+		if evs[2].Type == "miss" {
+			continue // a natural 1; try again
+		}
+		if len(evs) != 4 || evs[2].Type != "hit" || evs[3].Type != "damage" {
+			t.Fatalf("a hit should be followed by its damage, got %v", eventTypes(evs))
+		}
+		if len(damage) != 1 || evs[3].Damage != damage[0].Amount || evs[3].DamageType != "piercing" {
+			t.Errorf("damage event %+v doesn't match the hit %+v", evs[3], damage)
+		}
+		return
+	}
+	t.Fatal("a +30 attack never hit in 20 turns")
+}
+
+// A kill reports the death, the loot it dropped, and a level-up once earned.
+func TestKillEmitsDeathAndLoot(t *testing.T) {
+	cs := twoMonsterSession(types.Position{X: 2, Y: 3}, types.Position{X: 5, Y: 3})
+	save := &types.SaveFile{Stats: statMap(10, 10, 10, 10, 10, 10)}
+	cs.Monsters[0].Data.LootTable = types.LootTable{Guaranteed: []types.LootGuaranteed{{Item: "wolf-pelt", Quantity: [2]int{1, 1}}}}
+	cs.Monsters[0].IsAlive = false
+
+	handleMonsterKill(nil, cs, &cs.Monsters[0], save, nil)
+	if got := eventTypes(cs.Events); len(got) != 2 || got[0] != "death" || got[1] != "loot" {
+		t.Fatalf("want death, loot; got %v", got)
+	}
+	if cs.Events[0].Target != "wolf-a" || len(cs.Events[1].Loot) != 1 || cs.Events[1].Loot[0].Item != "wolf-pelt" {
+		t.Errorf("kill events %+v", cs.Events)
+	}
+}
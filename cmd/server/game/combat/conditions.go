@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"pubkey-quest/cmd/server/game/character"
+	"pubkey-quest/cmd/server/game/effects"
 	"pubkey-quest/types"
 )
 
@@ -40,6 +41,7 @@ var conditionRegistry = map[string]ConditionSpec{
 	"unconscious": {AttacksAgainstAdvantage: true, Incapacitated: true, Speed0: true}, // sleep etc.; wakes on the save-to-end
 	"outlined":    {AttacksAgainstAdvantage: true}, // faerie-fire: attackers see you clearly
 	"charmed":     {}, // no roll modifier; the "won't attack the charmer" rule is enforced in ApplyMonsterAction
+	"burning":     {}, // no roll modifier; its Damage dice tick each round (dot.go)
 }
 
 func specFor(name string) ConditionSpec { return conditionRegistry[strings.ToLower(name)] }
@@ -173,6 +175,56 @@ func TickCreatureConditions(who string, conds *[]types.CombatCondition, saveRoll
 	return log
 }
 
+// playerConditions returns the player's combat conditions plus any imposed by
+// their active effects (a "condition" in the effect JSON), so a poison potion or
+// a curse counts the same as a monster's rider. Effect conditions last as long
+// as the effect: they carry no round count or save of their own here.
+func playerConditions(cs *types.CombatSession, save *types.SaveFile) []types.CombatCondition {
+	var conds []types.CombatCondition
+	if len(cs.Party) > 0 {
+		conds = append(conds, cs.Party[0].CombatState.Conditions...)
+	}
+	for _, name := range effects.ActiveConditions(save) {
+		if !HasCondition(conds, name) {
+			conds = append(conds, types.CombatCondition{Name: name})
+		}
+	}
+	return conds
+}
+
+// tickEffectConditionSaves gives the player, at the end of their turn, a saving
+// throw against each active effect whose condition has a saving_throw; a success
+// ends the effect outright. Returns log lines for each attempt.
+func tickEffectConditionSaves(cs *types.CombatSession, save *types.SaveFile) []string {
+	if save == nil {
+		return nil
+	}
+	var log, shaken []string
+	seen := map[string]bool{}
+	for _, ae := range save.ActiveEffects {
+		if ae.DelayRemaining > 0 || seen[ae.EffectID] {
+			continue
+		}
+		seen[ae.EffectID] = true
+		data, err := effects.LoadEffectData(ae.EffectID)
+		if err != nil || data.Condition == "" || data.SavingThrow == nil || data.SavingThrow.DC <= 0 {
+			continue
+		}
+		st := data.SavingThrow
+		total := playerSaveTotal(cs, save, st.Stat)
+		if total >= st.DC {
+			log = append(log, fmt.Sprintf("  You shake off %s (%s save %d vs DC %d).", strings.ToLower(data.Condition), st.Stat, total, st.DC))
+			shaken = append(shaken, ae.EffectID)
+		} else {
+			log = append(log, fmt.Sprintf("  You're still %s (%s save %d vs DC %d).", strings.ToLower(data.Condition), st.Stat, total, st.DC))
+		}
+	}
+	for _, id := range shaken {
+		effects.RemoveEffect(save, id)
+	}
+	return log
+}
+
 // monsterSaveTotal rolls a monster's saving throw for a stat: d20 + the listed
 // saving_throws bonus, else the raw ability modifier.
 func monsterSaveTotal(m *types.MonsterInstance, stat string) int {
@@ -184,9 +236,15 @@ func monsterSaveTotal(m *types.MonsterInstance, stat string) int {
 }
 
 // playerSaveTotal rolls a player's saving throw: d20 + the ability modifier for
-// the given stat.
-func playerSaveTotal(save *types.SaveFile, stat string) int {
-	return RollD20() + character.AbilityMod(character.AbilityScore(effectiveStats(save), stat))
+// the given stat, with disadvantage on STR/DEX saves in unfamiliar armor.
+func playerSaveTotal(cs *types.CombatSession, save *types.SaveFile, stat string) int {
+	var roll int
+	if armorHampersSave(cs, stat) {
+		roll, _, _ = RollDisadvantage()
+	} else {
+		roll = RollD20()
+	}
+	return roll + character.AbilityMod(character.AbilityScore(effectiveStats(save), stat))
 }
 
 // monsterEffectCondition maps a monster hit-special `effect` string to a combat
@@ -200,6 +258,9 @@ var monsterEffectCondition = map[string]string{
 	"blinded":       "blinded",
 	"frightened":    "frightened",
 	"stunned":       "stunned",
+	"charmed":       "charmed",
+	// Luring Song (harpy): only the charm is modelled, not the pull toward the singer.
+	"charmed_and_move_toward": "charmed",
 }
 
 // applyMonsterConditionRider resolves a monster attack's on-hit rider from its
@@ -234,7 +295,7 @@ func applyMonsterConditionRider(cs *types.CombatSession, save *types.SaveFile, a
 		dc = 11
 	}
 
-	total := playerSaveTotal(save, stat)
+	total := playerSaveTotal(cs, save, stat)
 	if total >= dc {
 		return []string{fmt.Sprintf("  You resist %s (%s save %d vs DC %d).", cond, stat, total, dc)}
 	}
@@ -0,0 +1,195 @@
+package combat
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"pubkey-quest/cmd/server/game/effects"
+	"pubkey-quest/types"
+)
+
+// ─── Critical hits ───────────────────────────────────────────────────────────
+//
+// How a crit hurts and how often it lands are tunable from
+// combat_system.critical_hits in game-data/systems/combat.json:
+//
+//   - damage_rule: "double_dice" rolls twice the dice; "max_plus_roll" takes the
+//     dice at their maximum and adds one normal roll on top. Applies to every
+//     crit — player weapons, spells and monster attacks alike (see RollDice).
+//   - crit_range: the lowest natural d20 that crits (20 by default).
+//   - class_crit_ranges: classes that crit on a wider range from a given level
+//     (a fighter critting on 19–20).
+//   - min_crit_range: the floor no class or effect can push the range below.
+//
+// Effects widen the range further through a "crit_range" stat modifier: each
+// point lowers the natural roll needed by one.
+//
+// The server reads the block once, on the first attack, and keeps it: the
+// codex edits the systems table from another process, so a change to
+// critical_hits takes effect after the game server restarts.
+
+const (
+	CritDoubleDice  = "double_dice"
+	CritMaxPlusRoll = "max_plus_roll"
+)
+
+// CritRules is the parsed critical_hits block.
+type CritRules struct {
+	DamageRule      string           `json:"damage_rule"`
+	CritRange       int              `json:"crit_range"`
+	MinCritRange    int              `json:"min_crit_range"`
+	ClassCritRanges []ClassCritRange `json:"class_crit_ranges"`
+}
+
+// ClassCritRange widens a class's crit range once it reaches MinLevel.
+type ClassCritRange struct {
+	Class     string `json:"class"`
+	MinLevel  int    `json:"min_level"`
+	CritRange int    `json:"crit_range"`
+}
+
+// DefaultCritRules is the 5e rule set used when combat.json has no (or an
+// invalid) critical_hits block.
+func DefaultCritRules() CritRules {
+	return CritRules{DamageRule: CritDoubleDice, CritRange: 20, MinCritRange: 18}
+}
+
+var (
+	critRulesMu     sync.RWMutex
+	critRules       = DefaultCritRules()
+	critRulesLoaded bool
+)
+
+// currentCritRules returns the active crit rules.
+func currentCritRules() CritRules {
+	critRulesMu.RLock()
+	defer critRulesMu.RUnlock()
+	return critRules
+}
+
+// SetCritRules replaces the active crit rules (tests).
+func SetCritRules(rules CritRules) {
+	critRulesMu.Lock()
+	critRules = rules
+	critRulesLoaded = true
+	critRulesMu.Unlock()
+}
+
+// LoadCritRules reads combat_system.critical_hits from the systems table once
+// and caches it for the life of the process (edits need a server restart).
+// Missing config keeps the defaults; invalid config is logged and ignored so a
+// bad edit can't break combat.
+func LoadCritRules(db *sql.DB) CritRules {
+	critRulesMu.RLock()
+	loaded := critRulesLoaded
+	critRulesMu.RUnlock()
+	if loaded || db == nil {
+		return currentCritRules()
+	}
+
+	rules := DefaultCritRules()
+	var propsJSON string
+	if err := db.QueryRow("SELECT properties FROM systems WHERE id = 'combat'").Scan(&propsJSON); err == nil {
+		var config struct {
+			CombatSystem struct {
+				CriticalHits json.RawMessage `json:"critical_hits"`
+			} `json:"combat_system"`
+		}
+		if err := json.Unmarshal([]byte(propsJSON), &config); err != nil {
+			log.Printf("⚠️ Failed to parse combat system config: %v", err)
+		} else if len(config.CombatSystem.CriticalHits) > 0 {
+			// Omitted keys keep their defaults.
+			configured := DefaultCritRules()
+			if err := json.Unmarshal(config.CombatSystem.CriticalHits, &configured); err != nil {
+				log.Printf("⚠️ Failed to parse critical_hits config, using defaults: %v", err)
+			} else if err := ValidateCritRules(configured); err != nil {
+				log.Printf("⚠️ Invalid critical_hits config, using defaults: %v", err)
+			} else {
+				rules = configured
+			}
+		}
+	}
+	SetCritRules(rules)
+	return rules
+}
+
+// ValidateCritRules checks a critical_hits block: a known damage rule, crit
+// ranges on the d20 (2–20, since a natural 1 always misses), and class ranges
+// no wider than the floor.
+func ValidateCritRules(rules CritRules) error {
+	if rules.DamageRule != CritDoubleDice && rules.DamageRule != CritMaxPlusRoll {
+		return fmt.Errorf("damage_rule %q must be %q or %q", rules.DamageRule, CritDoubleDice, CritMaxPlusRoll)
+	}
+	if rules.CritRange < 2 || rules.CritRange > 20 {
+		return fmt.Errorf("crit_range %d must be between 2 and 20", rules.CritRange)
+	}
+	if rules.MinCritRange < 2 || rules.MinCritRange > rules.CritRange {
+		return fmt.Errorf("min_crit_range %d must be between 2 and crit_range (%d)", rules.MinCritRange, rules.CritRange)
+	}
+	for i, c := range rules.ClassCritRanges {
+		if c.Class == "" {
+			return fmt.Errorf("class_crit_ranges[%d]: class is required", i)
+		}
+		if c.MinLevel < 1 {
+			return fmt.Errorf("class_crit_ranges[%d] (%s): min_level %d must be at least 1", i, c.Class, c.MinLevel)
+		}
+		if c.CritRange < rules.MinCritRange || c.CritRange > 20 {
+			return fmt.Errorf("class_crit_ranges[%d] (%s): crit_range %d must be between min_crit_range (%d) and 20",
+				i, c.Class, c.CritRange, rules.MinCritRange)
+		}
+	}
+	return nil
+}
+
+// ClassCritRange returns the lowest natural roll that crits for a class at a
+// level, before effects.
+func (r CritRules) ClassCritRange(class string, level int) int {
+	critRange := r.CritRange
+	for _, c := range r.ClassCritRanges {
+		if strings.EqualFold(c.Class, class) && level >= c.MinLevel && c.CritRange < critRange {
+			critRange = c.CritRange
+		}
+	}
+	return critRange
+}
+
+// playerCritRange is the natural roll the player needs to crit right now: the
+// class range seeded at combat start, widened by any "crit_range" effect
+// modifiers, never below the configured floor.
+func playerCritRange(cs *types.CombatSession, save *types.SaveFile) int {
+	rules := currentCritRules()
+	critRange := rules.CritRange
+	if len(cs.Party) > 0 && cs.Party[0].CombatState.CritRange > 0 {
+		critRange = cs.Party[0].CombatState.CritRange
+	}
+	if save != nil {
+		critRange -= effects.GetActiveCombatModifiers(save)["crit_range"]
+	}
+	if critRange < rules.MinCritRange {
+		critRange = rules.MinCritRange
+	}
+	if critRange > 20 {
+		critRange = 20
+	}
+	return critRange
+}
+
+// rollCritDice rolls count dice of the given sides under the active crit damage
+// rule. roll supplies one die (so Elemental Adept can floor its dice).
+func rollCritDice(count, sides int, roll func(sides int) int) int {
+	total := 0
+	switch currentCritRules().DamageRule {
+	case CritMaxPlusRoll:
+		total = count * sides
+	default: // double_dice
+		count *= 2
+	}
+	for i := 0; i < count; i++ {
+		total += roll(sides)
+	}
+	return total
+}
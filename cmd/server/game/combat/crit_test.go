@@ -0,0 +1,91 @@
+package combat
+
+import (
+	"testing"
+
+	"pubkey-quest/types"
+)
+
+func useCritRules(t *testing.T, rules CritRules) {
+	t.Helper()
+	SetCritRules(rules)
+	t.Cleanup(func() { SetCritRules(DefaultCritRules()) })
+}
+
+func TestCritDamageRules(t *testing.T) {
+	rules := DefaultCritRules()
+	useCritRules(t, rules)
+	for i := 0; i < 200; i++ {
+		if dmg := RollDice("1d6", true); dmg < 2 || dmg > 12 {
+			t.Fatalf("double_dice 1d6 crit = %d, want 2–12", dmg)
+		}
+	}
+
+	rules.DamageRule = CritMaxPlusRoll
+	useCritRules(t, rules)
+	for i := 0; i < 200; i++ {
+		if dmg := RollDice("1d6", true); dmg < 7 || dmg > 12 {
+			t.Fatalf("max_plus_roll 1d6 crit = %d, want 7–12 (6 + 1d6)", dmg)
+		}
+		if dmg := rollDiceMinTwo("1d6", true); dmg < 8 {
+			t.Fatalf("max_plus_roll elemental adept crit = %d, want ≥ 8", dmg)
+		}
+	}
+	if dmg := RollDice("2d6", false); dmg > 12 {
+		t.Errorf("non-crit 2d6 = %d", dmg)
+	}
+}
+
+func TestExpandedCritRange(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		r := ResolveAttackRollWithCritRange(0, 30, 0, 2)
+		if r.Roll == 1 && (r.IsCrit || r.IsHit) {
+			t.Fatal("a natural 1 must miss even with crit range 2")
+		}
+		if r.Roll >= 2 && !r.IsCrit {
+			t.Fatalf("natural %d should crit with crit range 2", r.Roll)
+		}
+	}
+
+	rules := DefaultCritRules()
+	rules.ClassCritRanges = []ClassCritRange{{Class: "fighter", MinLevel: 3, CritRange: 19}}
+	useCritRules(t, rules)
+	if got := rules.ClassCritRange("Fighter", 3); got != 19 {
+		t.Errorf("level 3 fighter crit range = %d, want 19", got)
+	}
+	if got := rules.ClassCritRange("fighter", 2); got != 20 {
+		t.Errorf("level 2 fighter crit range = %d, want 20", got)
+	}
+	if got := rules.ClassCritRange("wizard", 10); got != 20 {
+		t.Errorf("wizard crit range = %d, want 20", got)
+	}
+
+	cs := &types.CombatSession{Party: []types.PartyCombatant{{}}}
+	if got := playerCritRange(cs, nil); got != 20 {
+		t.Errorf("unset combat crit range = %d, want 20", got)
+	}
+	cs.Party[0].CombatState.CritRange = 10
+	if got := playerCritRange(cs, nil); got != rules.MinCritRange {
+		t.Errorf("crit range below the floor = %d, want %d", got, rules.MinCritRange)
+	}
+}
+
+func TestValidateCritRules(t *testing.T) {
+	if err := ValidateCritRules(DefaultCritRules()); err != nil {
+		t.Errorf("default rules invalid: %v", err)
+	}
+	bad := []CritRules{
+		{DamageRule: "triple", CritRange: 20, MinCritRange: 18},
+		{DamageRule: CritDoubleDice, CritRange: 21, MinCritRange: 18},
+		{DamageRule: CritDoubleDice, CritRange: 19, MinCritRange: 20},
+		{DamageRule: CritDoubleDice, CritRange: 20, MinCritRange: 18,
+			ClassCritRanges: []ClassCritRange{{Class: "fighter", MinLevel: 3, CritRange: 15}}},
+		{DamageRule: CritDoubleDice, CritRange: 20, MinCritRange: 18,
+			ClassCritRanges: []ClassCritRange{{Class: "fighter", MinLevel: 0, CritRange: 19}}},
+	}
+	for i, r := range bad {
+		if err := ValidateCritRules(r); err == nil {
+			t.Errorf("case %d: %+v should be rejected", i, r)
+		}
+	}
+}
@@ -209,26 +209,30 @@ func resolveElementalAdeptDamage(diceExpr string, modifier int, damageType strin
 }
 
 // rollDiceMinTwo rolls a dice expression with every die counting at least 2
-// (Elemental Adept). Doubles the dice count on a crit, mirroring RollDice.
+// (Elemental Adept). Crits follow the configured crit rule, mirroring RollDice.
 func rollDiceMinTwo(diceExpr string, isCrit bool) int {
 	count, sides, err := ParseDice(diceExpr)
 	if err != nil {
 		return 2
 	}
 	if isCrit {
-		count *= 2
+		return rollCritDice(count, sides, rollDieMinTwo)
 	}
 	total := 0
 	for i := 0; i < count; i++ {
-		if r := RollD(sides); r < 2 {
-			total += 2
-		} else {
-			total += r
-		}
+		total += rollDieMinTwo(sides)
 	}
 	return total
 }
 
+// rollDieMinTwo rolls one die, counting a 1 as a 2.
+func rollDieMinTwo(sides int) int {
+	if r := RollD(sides); r >= 2 {
+		return r
+	}
+	return 2
+}
+
 // ResolveDamageToPlayer rolls damage dealt to the player (minimum 1).
 func ResolveDamageToPlayer(diceExpr string, modifier int, isCrit bool) int {
 	raw := RollDice(diceExpr, isCrit) + modifier
@@ -46,8 +46,9 @@ func ParseDice(dice string) (int, int, error) {
 	return count, sides, nil
 }
 
-// ParseVersatileDice returns the two-handed dice string from a versatile weapon.
-// Returns the original string if not versatile.
+// ParseVersatileDice splits a legacy "1d8,1d10" damage string into its one- and
+// two-handed dice (items now put the latter in damage_versatile). Returns the
+// original string for both if it isn't split.
 func ParseVersatileDice(damage string) (oneHand, twoHand string) {
 	parts := strings.SplitN(damage, ",", 2)
 	if len(parts) == 2 {
@@ -57,7 +58,8 @@ func ParseVersatileDice(damage string) (oneHand, twoHand string) {
 }
 
 // RollDice rolls the given dice expression and returns the total.
-// On a critical hit, the dice count is doubled.
+// On a critical hit, the dice follow the configured crit damage rule (see
+// CritRules): doubled by default, or maximized plus one normal roll.
 func RollDice(diceExpr string, isCrit bool) int {
 	count, sides, err := ParseDice(diceExpr)
 	if err != nil {
@@ -65,7 +67,7 @@ func RollDice(diceExpr string, isCrit bool) int {
 	}
 
 	if isCrit {
-		count *= 2
+		return rollCritDice(count, sides, RollD)
 	}
 
 	total := 0
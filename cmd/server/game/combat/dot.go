@@ -0,0 +1,141 @@
+package combat
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"pubkey-quest/cmd/server/game/character"
+	"pubkey-quest/cmd/server/game/effects"
+	"pubkey-quest/types"
+)
+
+// ─── Damage over time ────────────────────────────────────────────────────────
+//
+// Overworld effects tick on game minutes, but a combat round doesn't advance the
+// clock, so a poison picked up mid-fight would otherwise sit idle until combat
+// ends. tickRoundEffects runs at the start of every round (after the monsters
+// have acted, before the player's next turn) and resolves two sources:
+//
+//   - the player's periodic hp effects (poison, regeneration, …): each round
+//     adds its six seconds to the effect's tick accumulator, and the modifier's
+//     value lands once a whole tick_interval has built up, the same clock the
+//     overworld ticks on (so starving's 4-hour tick never fires mid-fight);
+//   - DoT conditions on any combatant — a CombatCondition with Damage dice
+//     ("burning" 1d6 fire) rolls that damage every round it's active.
+//
+// DoT damage can kill a monster (normal kill handling) or drop the player into
+// death saves, exactly like a hit.
+
+// tickRoundEffects applies one round of damage/healing over time. Returns the
+// log lines; a no-op once combat has left the "active" phase.
+func tickRoundEffects(db *sql.DB, cs *types.CombatSession, save *types.SaveFile) []string {
+	if cs.Phase != "active" {
+		return nil
+	}
+	var log []string
+
+	if len(cs.Party) > 0 {
+		log = append(log, tickPlayerPeriodicEffects(cs, save)...)
+		for _, c := range cs.Party[0].CombatState.Conditions {
+			if cs.Phase != "active" {
+				break
+			}
+			if dmg := rollConditionDamage(c); dmg > 0 {
+				log = append(log, fmt.Sprintf("  🔥 %s: you take %d %sdamage.", conditionLabel(c), dmg, damageTypeLabel(c)))
+				log = append(log, applyDamageToPlayer(cs, save, dmg, c.DamageType)...)
+			}
+		}
+	}
+
+	var advancement []types.AdvancementEntry
+	for i := range cs.Monsters {
+		if cs.Phase != "active" {
+			break
+		}
+		m := &cs.Monsters[i]
+		if !m.IsAlive {
+			continue
+		}
+		for _, c := range m.Conditions {
+			dmg := rollConditionDamage(c)
+			if dmg <= 0 {
+				continue
+			}
+			log = append(log, fmt.Sprintf("  🔥 %s takes %d %sdamage from %s.", m.Name, dmg, damageTypeLabel(c), conditionLabel(c)))
+			applyDamageToMonster(m, dmg)
+			if !m.IsAlive {
+				if advancement == nil && db != nil {
+					advancement, _ = character.LoadAdvancement(db)
+				}
+				log = append(log, handleMonsterKill(db, cs, m, save, advancement)...)
+				break
+			}
+		}
+	}
+	return log
+}
+
+// roundMinutes is the game time one combat round covers (six seconds).
+const roundMinutes = 0.1
+
+// tickPlayerPeriodicEffects advances the player's periodic hp modifiers by one
+// round and applies every tick that comes due: negative values damage the
+// combat HP pool, positive ones heal it. Effects still in their delay haven't
+// started yet and are skipped.
+func tickPlayerPeriodicEffects(cs *types.CombatSession, save *types.SaveFile) []string {
+	if save == nil || len(save.ActiveEffects) == 0 {
+		return nil
+	}
+	var log []string
+	// Damage can end a concentration effect and shrink ActiveEffects, so the
+	// length is re-read and ticks are counted before any of them land.
+	for i := 0; i < len(save.ActiveEffects); i++ {
+		if cs.Phase != "active" {
+			break
+		}
+		ae := &save.ActiveEffects[i]
+		if ae.DelayRemaining > 0 {
+			continue
+		}
+		stat, value, tickInterval, name, err := effects.GetEffectTemplate(ae.EffectID, ae.EffectIndex)
+		if err != nil || stat != "hp" || tickInterval <= 0 || value == 0 {
+			continue
+		}
+		ae.TickAccumulator += roundMinutes
+		ticks := 0
+		for ae.TickAccumulator >= float64(tickInterval) {
+			ae.TickAccumulator -= float64(tickInterval)
+			ticks++
+		}
+		for ; ticks > 0 && cs.Phase == "active"; ticks-- {
+			if value < 0 {
+				log = append(log, fmt.Sprintf("  ☠️ %s: you take %d damage.", name, -value))
+				log = append(log, applyDamageToPlayer(cs, save, -value, "")...)
+			} else {
+				applyHealToPlayer(cs, value)
+				log = append(log, fmt.Sprintf("  💚 %s: you recover %d HP.", name, value))
+			}
+		}
+	}
+	return log
+}
+
+// rollConditionDamage rolls a condition's per-round damage, 0 if it has none.
+func rollConditionDamage(c types.CombatCondition) int {
+	if c.Damage == "" {
+		return 0
+	}
+	return RollDice(c.Damage, false)
+}
+
+func conditionLabel(c types.CombatCondition) string {
+	return strings.ToLower(c.Name)
+}
+
+func damageTypeLabel(c types.CombatCondition) string {
+	if c.DamageType == "" {
+		return ""
+	}
+	return c.DamageType + " "
+}
@@ -0,0 +1,51 @@
+package combat
+
+import (
+	"testing"
+
+	"pubkey-quest/types"
+)
+
+func TestBurningTicksEachRoundAndCanKill(t *testing.T) {
+	cs := twoMonsterSession(types.Position{X: 5, Y: 3}, types.Position{X: 6, Y: 3})
+	save := &types.SaveFile{Race: "human", Stats: statMap(10, 10, 10, 10, 10, 10)}
+	// "Nd1" dice roll exactly N, keeping the tick deterministic.
+	ApplyCondition(&cs.Monsters[0].Conditions, types.CombatCondition{
+		Name: "burning", DurationRounds: 3, Damage: "4d1", DamageType: "fire",
+	})
+
+	tickRoundEffects(nil, cs, save)
+	if cs.Monsters[0].CurrentHP != 46 {
+		t.Errorf("burning wolf HP = %d, want 46", cs.Monsters[0].CurrentHP)
+	}
+	if cs.Monsters[1].CurrentHP != 50 {
+		t.Errorf("unafflicted wolf took damage: HP %d", cs.Monsters[1].CurrentHP)
+	}
+
+	cs.Monsters[0].CurrentHP = 3
+	tickRoundEffects(nil, cs, save)
+	if cs.Monsters[0].IsAlive {
+		t.Error("burning damage should kill a wolf at 3 HP")
+	}
+}
+
+func TestPlayerDoTDropsToDeathSaves(t *testing.T) {
+	cs := twoMonsterSession(types.Position{X: 5, Y: 3}, types.Position{X: 6, Y: 3})
+	save := &types.SaveFile{Race: "human", Stats: statMap(10, 10, 10, 10, 10, 10)}
+	cs.Party[0].CombatState.CurrentHP = 2
+	ApplyCondition(&cs.Party[0].CombatState.Conditions, types.CombatCondition{
+		Name: "burning", DurationRounds: 2, Damage: "3d1", DamageType: "fire",
+	})
+
+	log := tickRoundEffects(nil, cs, save)
+	if cs.Phase != "death_saves" || !cs.Party[0].CombatState.IsUnconscious {
+		t.Errorf("phase = %q after lethal DoT, want death_saves; log %v", cs.Phase, log)
+	}
+
+	// Once combat has left the active phase nothing else ticks.
+	cs.Monsters[0].Conditions = []types.CombatCondition{{Name: "burning", Damage: "5d1"}}
+	tickRoundEffects(nil, cs, save)
+	if cs.Monsters[0].CurrentHP != 50 {
+		t.Error("DoT should not tick outside the active phase")
+	}
+}
@@ -0,0 +1,99 @@
+package combat
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	gamedata "pubkey-quest/cmd/server/api/data"
+	gaminventory "pubkey-quest/cmd/server/game/inventory"
+	"pubkey-quest/types"
+)
+
+// ProcessPlayerEquip swaps a weapon from a general slot into the player's hands
+// mid-fight — drawing the sword once the wolf has closed on the archer. The first
+// swap each turn is the free object interaction; another one costs the action.
+// The equip itself goes through the inventory rules (two-handed weapons, slot
+// checks), and whatever was in hand goes back where the new weapon came from.
+// equipSlot may be "" to let the inventory pick the hand.
+func ProcessPlayerEquip(db *sql.DB, cs *types.CombatSession, save *types.SaveFile, itemID, equipSlot string) ([]string, error) {
+	if cs.Phase != "active" {
+		return nil, fmt.Errorf("cannot swap weapons: combat phase is %q", cs.Phase)
+	}
+	if len(cs.Party) == 0 {
+		return nil, fmt.Errorf("no player in combat")
+	}
+	state := &cs.Party[0].CombatState
+	free := !state.InteractionUsed
+	if !free && state.ActionUsed {
+		return nil, fmt.Errorf("no free interaction or action left this turn")
+	}
+
+	item, err := gamedata.LoadItemByID(db, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("unknown item %q", itemID)
+	}
+	name := itemID
+	if n, _ := item["name"].(string); n != "" {
+		name = n
+	}
+	if !isWeaponItem(item) {
+		return nil, fmt.Errorf("%s isn't a weapon", name)
+	}
+
+	// Only a loose general slot is within reach — the backpack stays shut mid-fight.
+	fromSlot := reachableWeaponSlot(save.Inventory, itemID)
+	if fromSlot < 0 {
+		return nil, fmt.Errorf("no %s within reach", name)
+	}
+	params := map[string]interface{}{
+		"item_id":        itemID,
+		"from_slot":      fromSlot,
+		"from_slot_type": "general",
+	}
+	if equipSlot != "" {
+		params["equipment_slot"] = equipSlot
+	}
+	resp, err := gaminventory.HandleEquipItemAction(save, params)
+	if err != nil {
+		return nil, err
+	}
+	if resp != nil && !resp.Success {
+		return nil, errors.New(resp.Error)
+	}
+
+	if free {
+		state.InteractionUsed = true
+	} else {
+		state.ActionUsed = true
+	}
+	// Whatever left the hand may have been a torch or carried a resistance.
+	refreshLight(db, cs, save)
+	refreshArmorProficiency(db, cs, save)
+	refreshDamageDefenses(db, cs, save)
+
+	cost := "free"
+	if !free {
+		cost = "action"
+	}
+	return []string{fmt.Sprintf("You ready your %s (%s).", name, cost)}, nil
+}
+
+// reachableWeaponSlot returns the index of the general slot holding itemID, or
+// -1 when none does.
+func reachableWeaponSlot(inv map[string]interface{}, itemID string) int {
+	gen, ok := inv["general_slots"].([]interface{})
+	if !ok {
+		return -1
+	}
+	for i, raw := range gen {
+		slot, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if id, _ := slot["item"].(string); id == itemID && slotQty(slot, "quantity") > 0 {
+			return i
+		}
+	}
+	return -1
+}
@@ -69,10 +69,11 @@ func BuildEquippedStats(db *sql.DB, save *types.SaveFile, level int) EquippedSta
 	}
 
 	// Off hand: only a weapon there is an attack line (a shield contributes AC,
-	// already counted). Two-weapon fighting adds no ability mod to damage.
+	// already counted; a two-handed weapon is the main hand's, held in both).
+	// Two-weapon fighting adds no ability mod to damage.
 	if id := gaminventory.GetEquippedItemID(inv, "offhand"); id != "" {
 		if item, err := gamedata.LoadItemByID(db, id); err == nil {
-			if dmg, _ := item["damage"].(string); dmg != "" && !isRangedWeapon(item) {
+			if dmg, _ := item["damage"].(string); dmg != "" && !isRangedWeapon(item) && !hasTag(item["tags"], "two-handed") {
 				es.OffHand = &WeaponLine{
 					ItemID:      id,
 					AttackBonus: WeaponAttackBonus(item, stats, save.Class, level),
@@ -0,0 +1,64 @@
+package combat
+
+import (
+	"strings"
+	"testing"
+
+	"pubkey-quest/types"
+)
+
+// A successful flee ends the fight in "fled" with its XP and loot forfeited; a
+// failed one costs the turn and the monsters answer straight away.
+func TestFleeOutcomes(t *testing.T) {
+	var fled, caught int
+	for i := 0; i < 200 && (fled == 0 || caught == 0); i++ {
+		// Range 4 from the nearest wolf with evenly matched athletics: ~50%.
+		cs := twoMonsterSession(types.Position{X: 5, Y: 3}, types.Position{X: 6, Y: 3})
+		for m := range cs.Monsters {
+			cs.Monsters[m].Data.Stats = types.MonsterStats{Strength: 10, Dexterity: 10, Constitution: 10}
+		}
+		cs.XPEarnedThisFight = 25
+		cs.LootRolled = []types.LootDrop{{Item: "wolf-pelt", Quantity: 1}}
+		save := &types.SaveFile{Race: "human", Stats: statMap(10, 10, 10, 10, 10, 10)}
+
+		log, err := ProcessPlayerFlee(nil, cs, save)
+		if err != nil {
+			t.Fatalf("flee at range 4: %v", err)
+		}
+		joined := strings.Join(log, "\n")
+
+		if cs.Phase == "fled" {
+			fled++
+			if cs.XPEarnedThisFight != 0 || cs.LootRolled != nil {
+				t.Errorf("fled fight kept XP %d / loot %+v", cs.XPEarnedThisFight, cs.LootRolled)
+			}
+			if !strings.Contains(joined, "escape!") {
+				t.Errorf("success log should say so: %q", joined)
+			}
+			if got := encounterOutcome(cs); got != "escaped" {
+				t.Errorf("fled encounter outcome = %q, want escaped", got)
+			}
+			continue
+		}
+
+		caught++
+		if !strings.Contains(joined, "cuts off your escape") {
+			t.Errorf("failure log should say so: %q", joined)
+		}
+		if cs.Party[0].CombatState.ActionUsed {
+			t.Error("after the monsters' free turn a fresh player turn should begin")
+		}
+		if cs.Monsters[0].Pos.X >= 5 {
+			t.Errorf("the nearest wolf should have closed in on its free turn, still at %+v", cs.Monsters[0].Pos)
+		}
+	}
+	if fled == 0 || caught == 0 {
+		t.Errorf("expected both outcomes over 200 attempts: fled %d, caught %d", fled, caught)
+	}
+
+	// Too close to run.
+	cs := twoMonsterSession(types.Position{X: 2, Y: 3}, types.Position{X: 6, Y: 3})
+	if _, err := ProcessPlayerFlee(nil, cs, &types.SaveFile{Stats: statMap(10, 10, 10, 10, 10, 10)}); err == nil {
+		t.Error("fleeing from an adjacent monster should be refused")
+	}
+}
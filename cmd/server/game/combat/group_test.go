@@ -0,0 +1,69 @@
+package combat
+
+import (
+	"testing"
+
+	"pubkey-quest/types"
+)
+
+// Killing one monster of a pack keeps the fight going and banks its loot; the
+// fight only ends in "loot" when the last one falls.
+func TestKillEndsFightOnlyWhenAllDown(t *testing.T) {
+	cs := twoMonsterSession(types.Position{X: 2, Y: 3}, types.Position{X: 5, Y: 3})
+	save := &types.SaveFile{Stats: statMap(10, 10, 10, 10, 10, 10)}
+	for i := range cs.Monsters {
+		cs.Monsters[i].Data.LootTable = types.LootTable{Guaranteed: []types.LootGuaranteed{{Item: "wolf-pelt", Quantity: [2]int{1, 1}}}}
+	}
+
+	cs.Monsters[0].IsAlive = false
+	handleMonsterKill(nil, cs, &cs.Monsters[0], save, nil)
+	if cs.Phase != "active" {
+		t.Fatalf("one wolf still standing: phase = %q, want active", cs.Phase)
+	}
+	if len(cs.LootRolled) != 1 {
+		t.Fatalf("first kill's loot should be banked, got %+v", cs.LootRolled)
+	}
+
+	cs.Monsters[1].IsAlive = false
+	handleMonsterKill(nil, cs, &cs.Monsters[1], save, nil)
+	if cs.Phase != "loot" {
+		t.Fatalf("every wolf down: phase = %q, want loot", cs.Phase)
+	}
+	if len(cs.LootRolled) != 2 {
+		t.Errorf("both kills' loot should be kept, got %+v", cs.LootRolled)
+	}
+}
+
+// A monster that escapes leaves the fight without ending it for the rest.
+func TestEscapeLeavesPackFighting(t *testing.T) {
+	cs := twoMonsterSession(types.Position{X: 8, Y: 3}, types.Position{X: 5, Y: 3})
+	save := &types.SaveFile{Stats: statMap(10, 10, 10, 10, 10, 10)}
+
+	ApplyMonsterAction(cs, &cs.Monsters[0], MonsterDecision{Action: "escape"}, 10, false, 0, save)
+	if cs.Phase != "active" || cs.Monsters[0].IsAlive || !cs.Monsters[0].Escaped {
+		t.Fatalf("after one escape: phase %q, monster %+v", cs.Phase, cs.Monsters[0])
+	}
+	ApplyMonsterAction(cs, &cs.Monsters[1], MonsterDecision{Action: "escape"}, 10, false, 0, save)
+	if cs.Phase != "loot" {
+		t.Errorf("both gone: phase = %q, want loot", cs.Phase)
+	}
+	if got := encounterOutcome(cs); got != "escaped" {
+		t.Errorf("outcome = %q, want escaped", got)
+	}
+}
+
+func TestPlaceMonstersFansOut(t *testing.T) {
+	goblin := &types.MonsterData{ID: "goblin", Name: "Goblin"}
+	placed := placeMonsters([]*types.MonsterData{goblin, goblin, goblin}, 4)
+	wantY := []int{3, 2, 4}
+	for i, m := range placed {
+		if m.Pos.X != 4 || m.Pos.Y != wantY[i] {
+			t.Errorf("monster %d at %+v, want (4,%d)", i, m.Pos, wantY[i])
+		}
+	}
+
+	solo := placeMonsters([]*types.MonsterData{goblin}, 4)
+	if solo[0].InstanceID != "goblin" || solo[0].Name != "Goblin" {
+		t.Errorf("a lone monster keeps its plain ID and name, got %s %q", solo[0].InstanceID, solo[0].Name)
+	}
+}
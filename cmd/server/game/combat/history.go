@@ -0,0 +1,71 @@
+package combat
+
+import "pubkey-quest/types"
+
+// MaxEncounterHistory caps SaveFile.CombatHistory; the oldest records are
+// dropped first.
+const MaxEncounterHistory = 50
+
+// RecordEncounter appends a compact record of a finished fight to the save's
+// encounter history. Call it once the combat has reached a terminal phase and
+// before the outcome is applied, so Location/Day/Minute are where the fight
+// happened rather than where a defeat respawns the player.
+//
+// Outcome is "defeat" for a lost fight, "victory" when every monster died, and
+// "escaped" when the fight ended with a monster still standing (the player fled
+// or the monster slipped away). Loot is only recorded for victories.
+func RecordEncounter(save *types.SaveFile, cs *types.CombatSession) types.EncounterRecord {
+	record := types.EncounterRecord{
+		Outcome:     encounterOutcome(cs),
+		Rounds:      cs.Round,
+		XP:          cs.XPEarnedThisFight,
+		Environment: cs.EnvironmentID,
+		Location:    save.Location,
+		Day:         save.CurrentDay,
+		Minute:      save.TimeOfDay,
+	}
+	for _, m := range cs.Monsters {
+		record.MonsterIDs = append(record.MonsterIDs, m.TemplateID)
+	}
+	if record.Outcome == "victory" && len(cs.LootRolled) > 0 {
+		record.Loot = append([]types.LootDrop(nil), cs.LootRolled...)
+	}
+
+	save.CombatHistory = append(save.CombatHistory, record)
+	if over := len(save.CombatHistory) - MaxEncounterHistory; over > 0 {
+		save.CombatHistory = append([]types.EncounterRecord(nil), save.CombatHistory[over:]...)
+	}
+	return record
+}
+
+// MaxRecentFights caps the fights (with full logs) kept on the session.
+const MaxRecentFights = 10
+
+// RememberFight adds a finished fight's record and combat log to recent,
+// dropping the oldest past MaxRecentFights, and returns the new list. The log
+// is copied so the combat session can be dropped.
+func RememberFight(recent []types.FightLog, record types.EncounterRecord, log []string) []types.FightLog {
+	recent = append(recent, types.FightLog{
+		Encounter: record,
+		Log:       append([]string{}, log...),
+	})
+	if over := len(recent) - MaxRecentFights; over > 0 {
+		recent = append([]types.FightLog(nil), recent[over:]...)
+	}
+	return recent
+}
+
+func encounterOutcome(cs *types.CombatSession) string {
+	switch cs.Phase {
+	case "defeat":
+		return "defeat"
+	case "fled":
+		return "escaped"
+	}
+	for _, m := range cs.Monsters {
+		if m.IsAlive || m.Escaped {
+			return "escaped"
+		}
+	}
+	return "victory"
+}
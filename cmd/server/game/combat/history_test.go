@@ -0,0 +1,82 @@
+package combat
+
+import (
+	"testing"
+
+	"pubkey-quest/types"
+)
+
+func TestRecordEncounterOutcomes(t *testing.T) {
+	save := &types.SaveFile{Location: "darkwood-forest", CurrentDay: 3, TimeOfDay: 600}
+
+	cs := twoMonsterSession(types.Position{X: 5, Y: 3}, types.Position{X: 6, Y: 3})
+	cs.Monsters[0].TemplateID, cs.Monsters[1].TemplateID = "wolf", "wolf"
+	cs.Round = 4
+	cs.XPEarnedThisFight = 50
+	cs.LootRolled = []types.LootDrop{{Item: "wolf-pelt", Quantity: 1}}
+	cs.Monsters[0].IsAlive = false
+	cs.Phase = "loot"
+
+	rec := RecordEncounter(save, cs)
+	if rec.Outcome != "escaped" {
+		t.Errorf("one wolf alive: outcome = %q, want escaped", rec.Outcome)
+	}
+	if rec.Loot != nil {
+		t.Errorf("escaped fight recorded loot %+v", rec.Loot)
+	}
+
+	cs.Monsters[1].IsAlive = false
+	rec = RecordEncounter(save, cs)
+	if rec.Outcome != "victory" || rec.Rounds != 4 || rec.XP != 50 || len(rec.Loot) != 1 {
+		t.Errorf("victory record = %+v", rec)
+	}
+	if len(rec.MonsterIDs) != 2 || rec.MonsterIDs[0] != "wolf" {
+		t.Errorf("monster ids = %v, want [wolf wolf]", rec.MonsterIDs)
+	}
+	if rec.Location != "darkwood-forest" || rec.Day != 3 || rec.Minute != 600 {
+		t.Errorf("record place/time = %s day %d min %d", rec.Location, rec.Day, rec.Minute)
+	}
+
+	cs.Phase = "defeat"
+	if rec = RecordEncounter(save, cs); rec.Outcome != "defeat" || rec.Loot != nil {
+		t.Errorf("defeat record = %+v", rec)
+	}
+	if len(save.CombatHistory) != 3 {
+		t.Errorf("history has %d records, want 3", len(save.CombatHistory))
+	}
+}
+
+func TestRecordEncounterCapsHistory(t *testing.T) {
+	save := &types.SaveFile{}
+	cs := twoMonsterSession(types.Position{X: 5, Y: 3}, types.Position{X: 6, Y: 3})
+	cs.Phase = "loot"
+	for i := 1; i <= MaxEncounterHistory+5; i++ {
+		cs.Round = i
+		RecordEncounter(save, cs)
+	}
+	if len(save.CombatHistory) != MaxEncounterHistory {
+		t.Fatalf("history length = %d, want %d", len(save.CombatHistory), MaxEncounterHistory)
+	}
+	if first := save.CombatHistory[0].Rounds; first != 6 {
+		t.Errorf("oldest kept record is round %d, want 6 (first five dropped)", first)
+	}
+}
+
+func TestRememberFightKeepsLogsCapped(t *testing.T) {
+	var recent []types.FightLog
+	log := []string{"Round 1"}
+	for i := 1; i <= MaxRecentFights+3; i++ {
+		recent = RememberFight(recent, types.EncounterRecord{Rounds: i}, log)
+	}
+	if len(recent) != MaxRecentFights {
+		t.Fatalf("recent fights = %d, want %d", len(recent), MaxRecentFights)
+	}
+	if first := recent[0].Encounter.Rounds; first != 4 {
+		t.Errorf("oldest kept fight is round %d, want 4", first)
+	}
+
+	log[0] = "changed"
+	if got := recent[len(recent)-1].Log[0]; got != "Round 1" {
+		t.Errorf("kept log aliases the combat log: %q", got)
+	}
+}
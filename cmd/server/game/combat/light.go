@@ -0,0 +1,130 @@
+package combat
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	gamedata "pubkey-quest/cmd/server/api/data"
+	"pubkey-quest/cmd/server/game/effects"
+	"pubkey-quest/cmd/server/game/gametime"
+	gaminventory "pubkey-quest/cmd/server/game/inventory"
+	"pubkey-quest/types"
+)
+
+// ─── Light ───────────────────────────────────────────────────────────────────
+//
+// A fight's light level comes from where and when it happens:
+//
+//   - cities are lit: bright by day, dim at night (lamps, windows);
+//   - environments start from their location "light" field (bright — open sky —
+//     when unset), and night drops them one step: bright → dim → dark.
+//
+// A light source lifts darkness to dim: a lit torch or candle (used items that
+// apply an effect with a positive "light" modifier), the Revealing Light spell
+// (same effect path), or a lantern equipped in the offhand (any equipped item
+// tagged "light-source").
+//
+// In darkness ranged attacks have disadvantage and monsters are only spotted
+// once they're close, so fights start at short range. Night alone is enough to
+// spoil a shot: after dark every ranged attack has disadvantage, lit or not — a
+// torch shows you your feet, not the archer across the clearing.
+
+const (
+	LightBright = "bright"
+	LightDim    = "dim"
+	LightDark   = "dark"
+)
+
+// darkStartingRange caps the opening range of a fight in the dark.
+const darkStartingRange = 1
+
+// ValidLightLevel reports whether level is one of the three light levels.
+func ValidLightLevel(level string) bool {
+	switch level {
+	case LightBright, LightDim, LightDark:
+		return true
+	}
+	return false
+}
+
+// AmbientLightFor returns the light at a location of locationType ("city",
+// "environment") whose configured "light" field is configured, by day or by
+// night, before any light source the player carries.
+func AmbientLightFor(locationType, configured string, night bool) string {
+	if locationType == "city" {
+		if night {
+			return LightDim
+		}
+		return LightBright
+	}
+	if !ValidLightLevel(configured) {
+		configured = LightBright
+	}
+	if !night {
+		return configured
+	}
+	switch configured {
+	case LightBright:
+		return LightDim
+	default:
+		return LightDark
+	}
+}
+
+// AmbientLight looks up a location's type and configured light and returns its
+// ambient light at timeOfDay (minutes since midnight). Unknown locations are
+// treated as open ground.
+func AmbientLight(db *sql.DB, locationID string, timeOfDay int) string {
+	locationType, configured := "", ""
+	if db != nil && locationID != "" {
+		var propsJSON string
+		if err := db.QueryRow("SELECT COALESCE(location_type,''), COALESCE(properties,'') FROM locations WHERE id = ?",
+			locationID).Scan(&locationType, &propsJSON); err == nil {
+			var props struct {
+				Light string `json:"light"`
+			}
+			if json.Unmarshal([]byte(propsJSON), &props) == nil {
+				configured = props.Light
+			}
+		}
+	}
+	return AmbientLightFor(locationType, configured, gametime.IsNight(timeOfDay))
+}
+
+// HasLightSource reports whether the player is carrying light: an active effect
+// with a positive "light" modifier, or a light-source item in the offhand.
+func HasLightSource(db *sql.DB, save *types.SaveFile) bool {
+	if save == nil {
+		return false
+	}
+	if effects.GetActiveCombatModifiers(save)["light"] > 0 {
+		return true
+	}
+	if db == nil {
+		return false
+	}
+	itemID := gaminventory.GetEquippedItemID(save.Inventory, "offhand")
+	if itemID == "" {
+		return false
+	}
+	item, err := gamedata.LoadItemByID(db, itemID)
+	return err == nil && hasTag(item["tags"], "light-source")
+}
+
+// LightLevel is the light the player actually fights in: the ambient light,
+// with darkness lifted to dim when they carry a light source.
+func LightLevel(ambient string, lit bool) string {
+	if ambient == LightDark && lit {
+		return LightDim
+	}
+	return ambient
+}
+
+// refreshLight recomputes cs.LightLevel from the fight's ambient light and the
+// player's current light sources, so a torch lit mid-fight counts.
+func refreshLight(db *sql.DB, cs *types.CombatSession, save *types.SaveFile) {
+	if cs.AmbientLight == "" {
+		return
+	}
+	cs.LightLevel = LightLevel(cs.AmbientLight, HasLightSource(db, save))
+}
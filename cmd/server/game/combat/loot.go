@@ -1,12 +1,46 @@
 package combat
 
 import (
+	"database/sql"
+	"fmt"
+	"math"
+	"strings"
+
+	gamedata "pubkey-quest/cmd/server/api/data"
+	"pubkey-quest/cmd/server/game/effects"
 	"pubkey-quest/types"
 )
 
+// rarityRank orders item rarities from most to least common. Loot tier names
+// use the same scale (plus "very_rare", which sits between rare and legendary).
+var rarityRank = map[string]int{
+	"common":    0,
+	"uncommon":  1,
+	"rare":      2,
+	"very_rare": 3,
+	"legendary": 4,
+	"mythical":  5,
+	"mythic":    5,
+}
+
+// RarityRank returns a rarity's position on the common→mythical scale and
+// whether it's a known rarity.
+func RarityRank(rarity string) (int, bool) {
+	rank, ok := rarityRank[rarity]
+	return rank, ok
+}
+
 // RollLoot executes all rolls on a monster's loot table and returns the resulting drops.
 // Guaranteed items always appear. Tier rolls use weighted random selection.
 func RollLoot(table types.LootTable) []types.LootDrop {
+	return RollLootScaled(table, 1.0)
+}
+
+// RollLootScaled is RollLoot with the weight of every tier rated rare or better
+// multiplied by bonus — the night multiplier, so fights in the dark are more
+// likely to turn up the good stuff. A bonus of 1 (or less) leaves the table as
+// authored.
+func RollLootScaled(table types.LootTable, bonus float64) []types.LootDrop {
 	var drops []types.LootDrop
 
 	// Always add guaranteed drops
@@ -23,8 +57,9 @@ func RollLoot(table types.LootTable) []types.LootDrop {
 		rolls = 1
 	}
 
+	tiers := scaleRareTiers(table.Tiers, bonus)
 	for i := 0; i < rolls; i++ {
-		drop := rollOneTier(table.Tiers)
+		drop := rollOneTier(tiers)
 		if drop != nil {
 			drops = appendOrStack(drops, *drop)
 		}
@@ -33,6 +68,131 @@ func RollLoot(table types.LootTable) []types.LootDrop {
 	return drops
 }
 
+// LootLuck returns the player's loot luck as a multiplier on the weight of
+// rare-or-better loot tiers, stacked on the night bonus: +2% per level past
+// 1st, and +10% per point of "luck" from active effects (a fortune buff). 1.0
+// leaves the table as authored.
+func LootLuck(save *types.SaveFile, level int) float64 {
+	luck := 1.0
+	if level > 1 {
+		luck += 0.02 * float64(level-1)
+	}
+	if save != nil {
+		if points := effects.GetActiveCombatModifiers(save)["luck"]; points > 0 {
+			luck += 0.1 * float64(points)
+		}
+	}
+	return luck
+}
+
+// lootLogLine describes a kill's drops for the combat log ("2× Wolf Pelt, 1×
+// Fang"), using item names where the items table has them. Empty for no drops.
+func lootLogLine(db *sql.DB, drops []types.LootDrop) string {
+	if len(drops) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(drops))
+	for _, drop := range drops {
+		name := drop.Item
+		if db != nil {
+			var itemName sql.NullString
+			if err := db.QueryRow("SELECT name FROM items WHERE id = ?", drop.Item).Scan(&itemName); err == nil && itemName.String != "" {
+				name = itemName.String
+			}
+		}
+		parts = append(parts, fmt.Sprintf("%d× %s", drop.Quantity, name))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// scaleRareTiers returns tiers with the weight of each rare-or-better tier
+// multiplied by bonus. The table itself is left untouched.
+func scaleRareTiers(tiers []types.LootTier, bonus float64) []types.LootTier {
+	if bonus <= 1 {
+		return tiers
+	}
+	rare := rarityRank["rare"]
+	scaled := make([]types.LootTier, len(tiers))
+	copy(scaled, tiers)
+	for i := range scaled {
+		if rank, ok := rarityRank[scaled[i].Name]; ok && rank >= rare {
+			scaled[i].Weight = int(math.Round(float64(scaled[i].Weight) * bonus))
+		}
+	}
+	return scaled
+}
+
+// annotateLootRarity fills in each drop's Rarity from the items table so the
+// loot screen can highlight rare finds. Unknown items (or no db) stay blank.
+func annotateLootRarity(db *sql.DB, drops []types.LootDrop) {
+	if db == nil {
+		return
+	}
+	for i := range drops {
+		var rarity sql.NullString
+		if err := db.QueryRow("SELECT rarity FROM items WHERE id = ?", drops[i].Item).Scan(&rarity); err == nil {
+			drops[i].Rarity = rarity.String
+		}
+	}
+}
+
+// ValidateLootFilter checks a loot filter's settings: a known rarity floor and
+// a non-negative value floor.
+func ValidateLootFilter(filter types.LootFilter) error {
+	if filter.MinRarity != "" {
+		if _, ok := rarityRank[filter.MinRarity]; !ok {
+			return fmt.Errorf("unknown rarity %q", filter.MinRarity)
+		}
+	}
+	if filter.MinValue < 0 {
+		return fmt.Errorf("min_value can't be negative")
+	}
+	return nil
+}
+
+// FilterLoot splits drops by the save's loot filter into what's picked up and
+// what's left behind. A nil filter keeps everything. Currency is exempt from
+// the rarity and value floors (see types.LootFilter).
+func FilterLoot(db *sql.DB, filter *types.LootFilter, drops []types.LootDrop) (keep, skip []types.LootDrop) {
+	if filter == nil {
+		return drops, nil
+	}
+	minRank, hasMinRarity := rarityRank[filter.MinRarity]
+	for _, drop := range drops {
+		if filter.ManualPickup || containsString(filter.Ignore, drop.Item) {
+			skip = append(skip, drop)
+			continue
+		}
+		if !hasMinRarity && filter.MinValue <= 0 {
+			keep = append(keep, drop)
+			continue
+		}
+
+		var item map[string]interface{}
+		if db != nil {
+			item, _ = gamedata.LoadItemByID(db, drop.Item)
+		}
+		if itemType, _ := item["type"].(string); itemType == "currency" || hasTag(item["tags"], "currency") {
+			keep = append(keep, drop)
+			continue
+		}
+		rarity := drop.Rarity
+		if rarity == "" {
+			rarity, _ = item["rarity"].(string)
+		}
+		if rank, ok := rarityRank[rarity]; hasMinRarity && ok && rank < minRank {
+			skip = append(skip, drop)
+			continue
+		}
+		if value, ok := item["value"].(float64); filter.MinValue > 0 && ok && int(value) < filter.MinValue {
+			skip = append(skip, drop)
+			continue
+		}
+		keep = append(keep, drop)
+	}
+	return keep, skip
+}
+
 // rollOneTier picks a tier by weight, then picks an entry within that tier by weight.
 // Returns nil if the result is "nothing".
 func rollOneTier(tiers []types.LootTier) *types.LootDrop {
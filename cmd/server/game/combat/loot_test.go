@@ -0,0 +1,40 @@
+package combat
+
+import (
+	"testing"
+
+	"pubkey-quest/types"
+)
+
+func TestScaleRareTiersBoostsOnlyRareTiers(t *testing.T) {
+	tiers := []types.LootTier{
+		{Name: "common", Weight: 80},
+		{Name: "rare", Weight: 16},
+		{Name: "very_rare", Weight: 4},
+	}
+
+	if got := scaleRareTiers(tiers, 1.0); &got[0] != &tiers[0] {
+		t.Error("a bonus of 1 should return the table as authored")
+	}
+
+	scaled := scaleRareTiers(tiers, 1.25)
+	want := []int{80, 20, 5}
+	for i, w := range want {
+		if scaled[i].Weight != w {
+			t.Errorf("%s weight = %d, want %d", scaled[i].Name, scaled[i].Weight, w)
+		}
+	}
+	if tiers[1].Weight != 16 {
+		t.Errorf("scaling modified the monster's table: rare weight %d", tiers[1].Weight)
+	}
+}
+
+func TestLootLogLine(t *testing.T) {
+	if got := lootLogLine(nil, nil); got != "" {
+		t.Errorf("no drops should log nothing, got %q", got)
+	}
+	drops := []types.LootDrop{{Item: "wolf-pelt", Quantity: 2}, {Item: "fang", Quantity: 1}}
+	if got := lootLogLine(nil, drops); got != "2× wolf-pelt, 1× fang" {
+		t.Errorf("lootLogLine = %q", got)
+	}
+}
@@ -0,0 +1,179 @@
+package combat
+
+import (
+	"strings"
+	"testing"
+
+	"pubkey-quest/types"
+)
+
+func intPtr(v int) *int { return &v }
+
+func TestAttackSequence(t *testing.T) {
+	bite := types.MonsterAction{Name: "Bite", Type: "melee_attack", Hit: types.MonsterHit{Dice: "1d6"}}
+	claw := types.MonsterAction{Name: "Claw", Type: "melee_attack", Hit: types.MonsterHit{Dice: "2d6"}}
+	spike := types.MonsterAction{Name: "Tail Spike", Type: "ranged_attack", Range: intPtr(4), RangeLong: intPtr(5),
+		Hit: types.MonsterHit{Dice: "1d8"}}
+	multi := types.MonsterAction{Name: "Multiattack", Type: "multiattack", Attacks: 3,
+		Sequence: []string{"Bite", "Claw", "Claw"}}
+	manticore := []types.MonsterAction{multi, bite, claw, spike}
+
+	names := func(actions []types.MonsterAction, seq []int) string {
+		var out []string
+		for _, i := range seq {
+			out = append(out, actions[i].Name)
+		}
+		return strings.Join(out, ",")
+	}
+	cases := []struct {
+		name    string
+		actions []types.MonsterAction
+		rng     int
+		want    string
+	}{
+		{"no multiattack", []types.MonsterAction{bite, claw}, 1, "Bite"},
+		{"sequence in melee", manticore, 1, "Bite,Claw,Claw"},
+		// Out of reach of bite and claw, every slot falls back to the chosen spike.
+		{"sequence at range", manticore, 4, "Tail Spike,Tail Spike,Tail Spike"},
+		{"no sequence cycles usable attacks", []types.MonsterAction{
+			{Name: "Multiattack", Type: "multiattack", Attacks: 3}, bite, claw}, 1, "Bite,Claw,Bite"},
+	}
+	for _, c := range cases {
+		chosen := selectBestAction(c.actions, c.rng)
+		if got := names(c.actions, attackSequence(c.actions, chosen, c.rng)); got != c.want {
+			t.Errorf("%s: sequence = %s, want %s", c.name, got, c.want)
+		}
+	}
+}
+
+func TestMultiattackMakesEveryAttack(t *testing.T) {
+	cs := twoMonsterSession(types.Position{X: 2, Y: 3}, types.Position{X: 6, Y: 3})
+	troll := &cs.Monsters[0]
+	troll.Data.Actions = []types.MonsterAction{
+		{Name: "Multiattack", Type: "multiattack", Attacks: 3, Sequence: []string{"Bite", "Claw", "Claw"}},
+		{Name: "Bite", Type: "melee_attack", AttackBonus: 7, Hit: types.MonsterHit{Dice: "1d6", Mod: 4, Type: "piercing"}},
+		{Name: "Claw", Type: "melee_attack", AttackBonus: 7, Hit: types.MonsterHit{Dice: "2d6", Mod: 4, Type: "slashing"}},
+	}
+	save := &types.SaveFile{Race: "human", Stats: statMap(10, 10, 10, 10, 10, 10)}
+
+	decision := RefreshAttackDecision(cs, troll, MonsterDecision{Action: "none"})
+	damage, log := ApplyMonsterAction(cs, troll, decision, 10, false, 0, save)
+	joined := strings.Join(log, "\n")
+	if strings.Count(joined, "attacks with Bite") != 1 || strings.Count(joined, "attacks with Claw") != 2 {
+		t.Errorf("expected one bite and two claws:\n%s", joined)
+	}
+	for _, hit := range damage {
+		if hit.Amount < 5 || (hit.Type != "piercing" && hit.Type != "slashing") {
+			t.Errorf("unexpected hit %+v", hit)
+		}
+	}
+}
+
+func TestMonsterSpecialActions(t *testing.T) {
+	wail := types.MonsterAction{Name: "Wail", Type: "special", Recharge: "once_per_combat",
+		Mechanic: &types.MonsterMechanic{Type: "area_save", Ability: "constitution", DC: 30, Range: 4,
+			OnFail: "drop_to_zero", OnSuccessDice: "3d6", OnSuccessMod: 3, OnSuccessType: "psychic"}}
+	visage := types.MonsterAction{Name: "Horrifying Visage", Type: "special", Recharge: "none",
+		Mechanic: &types.MonsterMechanic{Type: "area_save", Ability: "wisdom", DC: 30, Range: 4,
+			Effect: "frightened", Duration: "1_minute"}}
+	touch := types.MonsterAction{Name: "Corrupting Touch", Type: "melee_attack", AttackBonus: 4,
+		Hit: types.MonsterHit{Dice: "3d6", Type: "necrotic"}}
+	save := &types.SaveFile{Race: "human", Stats: statMap(10, 10, 10, 10, 10, 10)}
+
+	// A failed save against the frightening visage frightens the player, and the
+	// banshee holds it back while the fear lasts.
+	cs := twoMonsterSession(types.Position{X: 4, Y: 3}, types.Position{X: 6, Y: 3})
+	banshee := &cs.Monsters[0]
+	banshee.Data.Actions = []types.MonsterAction{touch, visage}
+	decision := RefreshAttackDecision(cs, banshee, MonsterDecision{Action: "none"})
+	if decision.Action != "special" || decision.ActionIndex != 1 {
+		t.Fatalf("in range of its visage the banshee should use it, got %+v", decision)
+	}
+	ApplyMonsterAction(cs, banshee, decision, 10, false, 0, save)
+	if !HasCondition(cs.Party[0].CombatState.Conditions, "frightened") {
+		t.Fatal("failing the DC 30 wisdom save should frighten the player")
+	}
+	if idx := selectSpecialAction(cs, banshee, rangeTo(cs, banshee)); idx >= 0 {
+		t.Error("the visage shouldn't be used again while the player is frightened")
+	}
+
+	// Saving against it makes the player immune for the rest of the fight.
+	cs.Party[0].CombatState.Conditions = nil
+	banshee.Data.Actions[1].Mechanic.DC = 1
+	ApplyMonsterAction(cs, banshee, MonsterDecision{Action: "special", ActionIndex: 1}, 10, false, 0, save)
+	if !actionSpent(banshee, "Horrifying Visage") {
+		t.Error("a successful save should spend the visage for the fight")
+	}
+
+	// A failed save against the wail drops the player to 0, and it's spent for good.
+	cs = twoMonsterSession(types.Position{X: 4, Y: 3}, types.Position{X: 6, Y: 3})
+	banshee = &cs.Monsters[0]
+	banshee.Data.Actions = []types.MonsterAction{touch, wail}
+	damage, _ := ApplyMonsterAction(cs, banshee, MonsterDecision{Action: "special", ActionIndex: 1}, 10, false, 0, save)
+	state := cs.Party[0].CombatState
+	if len(damage) != 0 || state.CurrentHP != 0 || !state.IsUnconscious || cs.Phase != "death_saves" {
+		t.Errorf("a failed wail should drop the player to 0: damage %+v, state %+v, phase %s", damage, state, cs.Phase)
+	}
+	for i := 0; i < 20; i++ {
+		RechargeMonsterActions(banshee)
+	}
+	if !actionSpent(banshee, "Wail") {
+		t.Error("a once-per-combat special never recharges")
+	}
+
+	// Succeeding takes the on-success damage instead.
+	cs = twoMonsterSession(types.Position{X: 4, Y: 3}, types.Position{X: 6, Y: 3})
+	banshee = &cs.Monsters[0]
+	wail.Mechanic.DC = 1
+	banshee.Data.Actions = []types.MonsterAction{touch, wail}
+	damage, _ = ApplyMonsterAction(cs, banshee, MonsterDecision{Action: "special", ActionIndex: 1}, 10, false, 0, save)
+	if len(damage) != 1 || damage[0].Type != "psychic" || damage[0].Amount < 6 || damage[0].Amount > 21 {
+		t.Errorf("a resisted wail should deal 3d6+3 psychic, got %+v", damage)
+	}
+
+	// A downed player is attacked, not targeted with a special.
+	cs = twoMonsterSession(types.Position{X: 1, Y: 3}, types.Position{X: 6, Y: 3})
+	cs.Party[0].CombatState.IsUnconscious = true
+	cs.Monsters[0].Data.Actions = []types.MonsterAction{touch, visage}
+	if d := DecideMonsterAction(cs, &cs.Monsters[0]); d.Action != "attack" {
+		t.Errorf("against an unconscious player the banshee should attack, got %+v", d)
+	}
+
+	// Out of range, the banshee falls back to moving and attacking.
+	cs = twoMonsterSession(types.Position{X: 8, Y: 3}, types.Position{X: 9, Y: 3})
+	cs.Monsters[0].Data.Actions = []types.MonsterAction{touch, visage}
+	if idx := selectSpecialAction(cs, &cs.Monsters[0], rangeTo(cs, &cs.Monsters[0])); idx >= 0 {
+		t.Errorf("range %d is beyond the visage's 4, got special %d", rangeTo(cs, &cs.Monsters[0]), idx)
+	}
+}
+
+func TestRechargeMonsterActions(t *testing.T) {
+	for _, c := range []struct {
+		recharge string
+		want     int
+		ok       bool
+	}{
+		{"recharge_5_6", 5, true}, {"recharge_6", 6, true}, {"recharge_1_6", 0, false},
+		{"once_per_combat", 0, false}, {"none", 0, false},
+	} {
+		if got, ok := rechargeThreshold(c.recharge); got != c.want || ok != c.ok {
+			t.Errorf("rechargeThreshold(%q) = %d, %v; want %d, %v", c.recharge, got, ok, c.want, c.ok)
+		}
+	}
+
+	breath := types.MonsterAction{Name: "Fire Breath", Type: "special", Recharge: "recharge_5_6"}
+	drake := &types.MonsterInstance{Name: "Drake", Data: types.MonsterData{Actions: []types.MonsterAction{breath}}}
+	spendAction(drake, "Fire Breath")
+	recharged := false
+	for i := 0; i < 100 && !recharged; i++ {
+		if log := RechargeMonsterActions(drake); len(log) > 0 {
+			recharged = true
+			if actionSpent(drake, "Fire Breath") {
+				t.Error("a recharged breath should be ready again")
+			}
+		}
+	}
+	if !recharged {
+		t.Error("a recharge 5–6 special should recharge within 100 turns")
+	}
+}
@@ -0,0 +1,33 @@
+package combat
+
+import (
+	"testing"
+
+	"pubkey-quest/types"
+)
+
+// After dark every ranged attack has disadvantage, even by torchlight; melee
+// swings don't care.
+func TestNightRangedDisadvantage(t *testing.T) {
+	// wolf-a at range 2, inside the bow's normal range.
+	cs := twoMonsterSession(types.Position{X: 3, Y: 3}, types.Position{X: 5, Y: 3})
+	bow := map[string]interface{}{"type": "Simple Ranged Weapon", "range": 8.0}
+	sword := map[string]interface{}{"type": "Martial Melee Weapon"}
+
+	cs.LightLevel = LightDim
+	if got := resolveAttackAdvantage(cs, &cs.Monsters[0], bow, false, "human", false); got != 0 {
+		t.Errorf("bow by day in dim light: advantage %d, want 0", got)
+	}
+
+	cs.Night = true
+	if got := resolveAttackAdvantage(cs, &cs.Monsters[0], bow, false, "human", false); got != -1 {
+		t.Errorf("bow at night: advantage %d, want -1", got)
+	}
+	cs.LightLevel = LightDark
+	if got := resolveAttackAdvantage(cs, &cs.Monsters[0], bow, false, "human", false); got != -1 {
+		t.Errorf("bow at night in darkness: advantage %d, want a single -1", got)
+	}
+	if got := resolveAttackAdvantage(cs, &cs.Monsters[0], sword, false, "human", false); got != 0 {
+		t.Errorf("sword at night: advantage %d, want 0", got)
+	}
+}
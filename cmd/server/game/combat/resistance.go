@@ -0,0 +1,139 @@
+package combat
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	gamedata "pubkey-quest/cmd/server/api/data"
+	"pubkey-quest/cmd/server/game/effects"
+	gaminventory "pubkey-quest/cmd/server/game/inventory"
+	"pubkey-quest/types"
+)
+
+// ─── Player damage resistance ────────────────────────────────────────────────
+//
+// The player resists or shrugs off damage types the same way monsters do:
+// resistance halves a hit of that type, immunity negates it. Both come from
+//
+//   - active effects — a "damage_resistance" or "damage_immunity" modifier with a
+//     positive value and a damage_type (a fire-resistance potion);
+//   - equipped gear — "damage_resistances" / "damage_immunities" lists on the
+//     item, the same fields monsters use (a ring of fire resistance).
+//
+// They're collected onto the player's combat state at the start of the fight
+// and before the monsters act, and applyDamageToPlayer consults them for every
+// typed hit: monster attacks (their hit type) and damage-over-time conditions.
+
+// Effect modifier stats that grant a defense against a damage type.
+const (
+	StatDamageResistance = "damage_resistance"
+	StatDamageImmunity   = "damage_immunity"
+)
+
+// ValidDamageType reports whether t is a known damage type (types.DamageTypes),
+// in any case.
+func ValidDamageType(t string) bool {
+	return types.IsDamageType(strings.ToLower(t))
+}
+
+// PlayerDamageDefenses returns the damage types the player resists and is
+// immune to from active effects and equipped gear, sorted and deduplicated.
+func PlayerDamageDefenses(db *sql.DB, save *types.SaveFile) (resistances, immunities []string) {
+	if save == nil {
+		return nil, nil
+	}
+	resist := map[string]bool{}
+	immune := map[string]bool{}
+
+	for _, ae := range save.ActiveEffects {
+		if ae.DelayRemaining > 0 {
+			continue
+		}
+		data, err := effects.LoadEffectData(ae.EffectID)
+		if err != nil || ae.EffectIndex < 0 || ae.EffectIndex >= len(data.Modifiers) {
+			continue
+		}
+		mod := data.Modifiers[ae.EffectIndex]
+		if mod.Value <= 0 || !ValidDamageType(mod.DamageType) {
+			continue
+		}
+		switch mod.Stat {
+		case StatDamageResistance:
+			resist[strings.ToLower(mod.DamageType)] = true
+		case StatDamageImmunity:
+			immune[strings.ToLower(mod.DamageType)] = true
+		}
+	}
+
+	if db != nil {
+		gearSlots, _ := save.Inventory["gear_slots"].(map[string]interface{})
+		for slot := range gearSlots {
+			itemID := gaminventory.GetEquippedItemID(save.Inventory, slot)
+			if itemID == "" {
+				continue
+			}
+			item, err := gamedata.LoadItemByID(db, itemID)
+			if err != nil {
+				continue
+			}
+			addDamageTypes(resist, item["damage_resistances"])
+			addDamageTypes(immune, item["damage_immunities"])
+		}
+	}
+
+	return sortedKeys(resist), sortedKeys(immune)
+}
+
+// addDamageTypes adds the known damage types in a JSON string list to set.
+func addDamageTypes(set map[string]bool, raw interface{}) {
+	list, _ := raw.([]interface{})
+	for _, v := range list {
+		if t, ok := v.(string); ok && ValidDamageType(t) {
+			set[strings.ToLower(t)] = true
+		}
+	}
+}
+
+func sortedKeys(set map[string]bool) []string {
+	if len(set) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// refreshDamageDefenses records the player's current resistances and
+// immunities on their combat state, so a potion drunk or ring put on mid-fight
+// counts from the next monster turn.
+func refreshDamageDefenses(db *sql.DB, cs *types.CombatSession, save *types.SaveFile) {
+	if len(cs.Party) == 0 {
+		return
+	}
+	state := &cs.Party[0].CombatState
+	state.DamageResistances, state.DamageImmunities = PlayerDamageDefenses(db, save)
+}
+
+// resistDamage applies the player's immunity or resistance to a hit of
+// damageType. Returns the damage left and a log line when it changed.
+func resistDamage(state *types.PlayerCombatState, dmg int, damageType string) (int, string) {
+	if dmg <= 0 || damageType == "" {
+		return dmg, ""
+	}
+	for _, t := range state.DamageImmunities {
+		if strings.EqualFold(t, damageType) {
+			return 0, fmt.Sprintf("  🛡️ You're immune to %s — the %d damage has no effect.", strings.ToLower(damageType), dmg)
+		}
+	}
+	for _, t := range state.DamageResistances {
+		if strings.EqualFold(t, damageType) {
+			return dmg / 2, fmt.Sprintf("  🛡️ You resist %s: %d damage becomes %d.", strings.ToLower(damageType), dmg, dmg/2)
+		}
+	}
+	return dmg, ""
+}
@@ -0,0 +1,63 @@
+package combat
+
+import (
+	"strings"
+	"testing"
+
+	"pubkey-quest/types"
+)
+
+func TestPlayerResistanceHalvesMatchingDoT(t *testing.T) {
+	cs := twoMonsterSession(types.Position{X: 5, Y: 3}, types.Position{X: 6, Y: 3})
+	save := &types.SaveFile{Race: "human", Stats: statMap(10, 10, 10, 10, 10, 10)}
+	state := &cs.Party[0].CombatState
+	state.CurrentHP = 20
+	state.DamageResistances = []string{"fire"}
+	ApplyCondition(&state.Conditions, types.CombatCondition{
+		Name: "burning", DurationRounds: 3, Damage: "5d1", DamageType: "fire",
+	})
+
+	tickRoundEffects(nil, cs, save)
+	if state.CurrentHP != 18 {
+		t.Errorf("HP = %d after 5 fire damage with fire resistance, want 18", state.CurrentHP)
+	}
+
+	// An untyped or differently-typed hit isn't reduced.
+	applyDamageToPlayer(cs, nil, 5, "cold")
+	applyDamageToPlayer(cs, nil, 3, "")
+	if state.CurrentHP != 10 {
+		t.Errorf("HP = %d after unresisted hits, want 10", state.CurrentHP)
+	}
+}
+
+func TestPlayerImmunityNegatesDamage(t *testing.T) {
+	cs := twoMonsterSession(types.Position{X: 5, Y: 3}, types.Position{X: 6, Y: 3})
+	state := &cs.Party[0].CombatState
+	state.CurrentHP = 4
+	state.DamageImmunities = []string{"poison"}
+	state.DamageResistances = []string{"poison"} // immunity wins
+
+	log := applyDamageToPlayer(cs, nil, 12, "Poison")
+	if state.CurrentHP != 4 || state.IsUnconscious {
+		t.Errorf("immune player took damage: HP %d, unconscious %v", state.CurrentHP, state.IsUnconscious)
+	}
+	if len(log) == 0 {
+		t.Error("immunity should be reported in the combat log")
+	}
+}
+
+// Combat knows every damage type in the shared registry, in any case, and
+// nothing else.
+func TestValidDamageTypeUsesRegistry(t *testing.T) {
+	for _, dt := range types.DamageTypes {
+		if !ValidDamageType(dt) || !ValidDamageType(strings.ToUpper(dt)) {
+			t.Errorf("ValidDamageType(%q) = false", dt)
+		}
+	}
+	if ValidDamageType("slash") {
+		t.Error(`"slash" is not a damage type`)
+	}
+	if got := WeaponDamageType(map[string]interface{}{}); !types.IsDamageType(got) {
+		t.Errorf("default weapon damage type %q isn't in the registry", got)
+	}
+}
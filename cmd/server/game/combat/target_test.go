@@ -0,0 +1,113 @@
+package combat
+
+import (
+	"strings"
+	"testing"
+
+	"pubkey-quest/types"
+)
+
+// twoMonsterSession puts the player at (1,3) with one monster per given cell.
+func twoMonsterSession(posA, posB types.Position) *types.CombatSession {
+	bite := types.MonsterAction{Name: "Bite", Type: "melee_attack", AttackBonus: 4,
+		Hit: types.MonsterHit{Dice: "1d4", Type: "piercing"}}
+	mk := func(id string, pos types.Position) types.MonsterInstance {
+		return types.MonsterInstance{
+			InstanceID: id, Name: id, CurrentHP: 50, MaxHP: 50, ArmorClass: 10,
+			IsAlive: true, Pos: pos,
+			Data: types.MonsterData{Actions: []types.MonsterAction{bite}},
+		}
+	}
+	return &types.CombatSession{
+		Party: []types.PartyCombatant{{Type: "player", CombatState: types.PlayerCombatState{
+			CurrentHP: 20, MaxHP: 20, MovementBudget: 6,
+		}}},
+		Monsters:   []types.MonsterInstance{mk("wolf-a", posA), mk("wolf-b", posB)},
+		GridWidth:  combatGridWidth,
+		GridHeight: combatGridHeight,
+		PlayerPos:  types.Position{X: 1, Y: 3},
+		Phase:      "active",
+	}
+}
+
+func TestAttackRangeIsPerTarget(t *testing.T) {
+	// wolf-a shares the player's cell (range 0), wolf-b sits at range 2.
+	cs := twoMonsterSession(types.Position{X: 1, Y: 3}, types.Position{X: 3, Y: 3})
+	save := &types.SaveFile{Race: "human", Stats: statMap(14, 12, 12, 10, 10, 10)}
+
+	if err := validateAttackRange(cs, &cs.Monsters[0], nil, true, false); err != nil {
+		t.Errorf("unarmed vs adjacent target: unexpected error %v", err)
+	}
+	if err := validateAttackRange(cs, &cs.Monsters[1], nil, true, false); err == nil {
+		t.Error("unarmed vs target at range 2 should be rejected")
+	}
+
+	// A rejected attack must not switch the player's target.
+	if _, err := ProcessPlayerAttack(nil, cs, save, "wolf-b", "unarmed", "main", false, nil); err == nil {
+		t.Fatal("attacking the far wolf unarmed should fail")
+	}
+	if cs.TargetID != "" {
+		t.Errorf("failed attack changed TargetID to %q", cs.TargetID)
+	}
+
+	if _, err := ProcessPlayerAttack(nil, cs, save, "wolf-a", "unarmed", "main", false, nil); err != nil {
+		t.Fatalf("attacking the adjacent wolf: %v", err)
+	}
+	if cs.TargetID != "wolf-a" {
+		t.Errorf("TargetID = %q, want wolf-a", cs.TargetID)
+	}
+	if cs.Monsters[1].CurrentHP != 50 {
+		t.Error("the far wolf should be untouched")
+	}
+}
+
+func TestSelectTargetRejectsDeadAndUnknown(t *testing.T) {
+	cs := twoMonsterSession(types.Position{X: 2, Y: 3}, types.Position{X: 4, Y: 3})
+	cs.Monsters[1].IsAlive = false
+
+	if err := SelectTarget(cs, "wolf-b"); err == nil {
+		t.Error("targeting a dead monster should fail")
+	}
+	if err := SelectTarget(cs, "dragon"); err == nil {
+		t.Error("targeting an unknown instance should fail")
+	}
+	if cs.TargetID != "" {
+		t.Errorf("failed selections changed TargetID to %q", cs.TargetID)
+	}
+	if err := SelectTarget(cs, "wolf-a"); err != nil || cs.TargetID != "wolf-a" {
+		t.Errorf("SelectTarget(wolf-a) = %v, TargetID %q", err, cs.TargetID)
+	}
+	// A dead target falls back to the first living monster.
+	cs.TargetID = "wolf-b"
+	if m := TargetMonster(cs); m == nil || m.InstanceID != "wolf-a" {
+		t.Errorf("TargetMonster with dead TargetID = %+v, want wolf-a", m)
+	}
+}
+
+func TestMoveAwayFromTwoMonstersProvokesTwoOAs(t *testing.T) {
+	// Both wolves are within reach 1 of the player at (1,3).
+	cs := twoMonsterSession(types.Position{X: 2, Y: 3}, types.Position{X: 2, Y: 2})
+	save := &types.SaveFile{Race: "human", Stats: statMap(10, 10, 10, 10, 10, 10)}
+
+	log, err := ProcessPlayerMove(nil, cs, save, "", 0, 4)
+	if err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	oas := 0
+	for _, line := range log {
+		if strings.Contains(line, "opportunity attack") {
+			oas++
+		}
+	}
+	if oas != 2 {
+		t.Errorf("got %d opportunity attacks, want 2; log: %v", oas, log)
+	}
+	if !cs.Monsters[0].ReactionUsed || !cs.Monsters[1].ReactionUsed {
+		t.Error("both wolves should have spent their reaction")
+	}
+
+	// Moving onto a monster's cell is blocked.
+	if _, err := ProcessPlayerMove(nil, cs, save, "", 2, 3); err == nil {
+		t.Error("moving into a monster's space should fail")
+	}
+}
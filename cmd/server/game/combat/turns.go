@@ -0,0 +1,108 @@
+package combat
+
+import (
+	"math"
+
+	"pubkey-quest/types"
+)
+
+// The turn queue walks cs.Initiative one combatant at a time. CurrentTurnIndex
+// is whose turn it is, and Round counts full passes through the order: it
+// advances when the queue wraps back to the top, not on every player action. A
+// monster that beat the player on initiative acts before them each round, one
+// that lost acts after them.
+
+// currentTurn returns the initiative entry whose turn it is.
+func currentTurn(cs *types.CombatSession) (types.InitiativeEntry, bool) {
+	if cs.CurrentTurnIndex < 0 || cs.CurrentTurnIndex >= len(cs.Initiative) {
+		return types.InitiativeEntry{}, false
+	}
+	return cs.Initiative[cs.CurrentTurnIndex], true
+}
+
+// advanceTurn passes the turn to the next entry in initiative order, starting a
+// new round when the queue wraps to the top.
+func advanceTurn(cs *types.CombatSession) {
+	if len(cs.Initiative) == 0 {
+		return
+	}
+	cs.CurrentTurnIndex++
+	if cs.CurrentTurnIndex >= len(cs.Initiative) {
+		cs.CurrentTurnIndex = 0
+		cs.Round++
+	}
+}
+
+// syncInitiative makes sure every combatant has a place in the queue. A monster
+// that joins mid-fight rolls initiative and slots in by its roll, without
+// re-sorting anyone already placed, and the turn stays with whoever holds it.
+// A session built without the player's entry gets one leading the order.
+func syncInitiative(cs *types.CombatSession) {
+	placed := make(map[string]bool, len(cs.Initiative))
+	hasPlayer := false
+	for _, entry := range cs.Initiative {
+		if entry.Type == "player" {
+			hasPlayer = true
+		} else {
+			placed[entry.ID] = true
+		}
+	}
+	if !hasPlayer && len(cs.Party) > 0 {
+		lead := types.InitiativeEntry{ID: cs.Party[0].ID, Type: "player", Initiative: math.MaxInt}
+		cs.Initiative = append([]types.InitiativeEntry{lead}, cs.Initiative...)
+		if len(cs.Initiative) > 1 {
+			cs.CurrentTurnIndex++
+		}
+	}
+	for i := range cs.Monsters {
+		m := &cs.Monsters[i]
+		if placed[m.InstanceID] || !m.IsAlive {
+			continue
+		}
+		m.Initiative = rollInitiative(StatMod(m.Data.Stats.Dexterity)).Total
+		entry := types.InitiativeEntry{ID: m.InstanceID, Type: "monster", Initiative: m.Initiative, DEXScore: m.Data.Stats.Dexterity}
+		// Slot in after everyone who beat (or tied) the roll.
+		at := len(cs.Initiative)
+		for j, other := range cs.Initiative {
+			if other.Initiative < entry.Initiative {
+				at = j
+				break
+			}
+		}
+		cs.Initiative = append(cs.Initiative, types.InitiativeEntry{})
+		copy(cs.Initiative[at+1:], cs.Initiative[at:])
+		cs.Initiative[at] = entry
+		if at <= cs.CurrentTurnIndex && len(cs.Initiative) > 1 {
+			cs.CurrentTurnIndex++
+		}
+		placed[m.InstanceID] = true
+	}
+}
+
+// runTurnsUntilPlayer walks the queue from the current entry to the player's,
+// running each living monster's turn with act on the way. Once the fight leaves
+// phase, the monsters still to come forfeit their turns but the queue still
+// moves on, so the player is always next up afterwards.
+func runTurnsUntilPlayer(cs *types.CombatSession, phase string, act func(*types.MonsterInstance)) {
+	syncInitiative(cs)
+	for range cs.Initiative {
+		entry, ok := currentTurn(cs)
+		if !ok || entry.Type == "player" {
+			return
+		}
+		if m := monsterByID(cs, entry.ID); m != nil && m.IsAlive && cs.Phase == phase {
+			act(m)
+		}
+		advanceTurn(cs)
+	}
+}
+
+// passTurn ends the player's turn and runs the monsters' turns until the
+// player's comes around again (see runTurnsUntilPlayer).
+func passTurn(cs *types.CombatSession, phase string, act func(*types.MonsterInstance)) {
+	syncInitiative(cs)
+	if entry, ok := currentTurn(cs); ok && entry.Type == "player" {
+		advanceTurn(cs)
+	}
+	runTurnsUntilPlayer(cs, phase, act)
+}
@@ -0,0 +1,110 @@
+package combat
+
+import (
+	"strings"
+	"testing"
+
+	"pubkey-quest/types"
+)
+
+// queueSession is a fight with a fast wolf ahead of the player and a slow one
+// behind, on the player's turn in round 1.
+func queueSession() *types.CombatSession {
+	cs := twoMonsterSession(types.Position{X: 5, Y: 3}, types.Position{X: 6, Y: 3})
+	cs.Party[0].ID = "npub1player"
+	cs.Round = 1
+	cs.Initiative = []types.InitiativeEntry{
+		{ID: "wolf-a", Type: "monster", Initiative: 18},
+		{ID: "npub1player", Type: "player", Initiative: 12},
+		{ID: "wolf-b", Type: "monster", Initiative: 4},
+	}
+	cs.CurrentTurnIndex = 1
+	return cs
+}
+
+func TestPassTurnFollowsInitiative(t *testing.T) {
+	cs := queueSession()
+	var acted []string
+	record := func(m *types.MonsterInstance) { acted = append(acted, m.InstanceID) }
+
+	// The slower wolf acts to close out round 1, the faster one opens round 2,
+	// then it's the player's turn again.
+	passTurn(cs, "active", record)
+	if got := strings.Join(acted, ","); got != "wolf-b,wolf-a" {
+		t.Errorf("turn order = %s, want wolf-b,wolf-a", got)
+	}
+	if cs.Round != 2 || cs.CurrentTurnIndex != 1 {
+		t.Errorf("after one pass: round %d, turn %d; want round 2, the player's turn (1)", cs.Round, cs.CurrentTurnIndex)
+	}
+
+	// A dead monster's turn is skipped.
+	acted = nil
+	cs.Monsters[1].IsAlive = false
+	passTurn(cs, "active", record)
+	if got := strings.Join(acted, ","); got != "wolf-a" || cs.Round != 3 {
+		t.Errorf("with wolf-b dead: acted %s in round %d, want wolf-a in round 3", got, cs.Round)
+	}
+
+	// Once the fight leaves the phase the rest forfeit, but the turn still
+	// comes back around to the player.
+	acted = nil
+	cs.Monsters[1].IsAlive = true
+	passTurn(cs, "active", func(m *types.MonsterInstance) {
+		record(m)
+		cs.Phase = "death_saves"
+	})
+	if got := strings.Join(acted, ","); got != "wolf-b" {
+		t.Errorf("only the first monster should act before the player drops, got %s", got)
+	}
+	if entry, _ := currentTurn(cs); entry.Type != "player" {
+		t.Errorf("the queue should end on the player, got %+v", entry)
+	}
+}
+
+func TestOpeningTurnsStopAtThePlayer(t *testing.T) {
+	cs := queueSession()
+	cs.CurrentTurnIndex = 0
+	var acted []string
+	runTurnsUntilPlayer(cs, "active", func(m *types.MonsterInstance) { acted = append(acted, m.InstanceID) })
+	if got := strings.Join(acted, ","); got != "wolf-a" || cs.Round != 1 || cs.CurrentTurnIndex != 1 {
+		t.Errorf("opening: acted %s, round %d, turn %d; want wolf-a, round 1, turn 1", got, cs.Round, cs.CurrentTurnIndex)
+	}
+}
+
+func TestJoiningMonsterTakesItsPlace(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		cs := queueSession()
+		cs.Monsters = append(cs.Monsters, types.MonsterInstance{
+			InstanceID: "wolf-c", Name: "wolf-c", CurrentHP: 10, MaxHP: 10, IsAlive: true,
+			Data: types.MonsterData{Stats: types.MonsterStats{Dexterity: 14}},
+		})
+		syncInitiative(cs)
+
+		if len(cs.Initiative) != 4 {
+			t.Fatalf("the newcomer should join the queue once: %+v", cs.Initiative)
+		}
+		if entry, _ := currentTurn(cs); entry.Type != "player" {
+			t.Fatalf("it should still be the player's turn, got %+v", entry)
+		}
+		for j := 1; j < len(cs.Initiative); j++ {
+			if cs.Initiative[j].Initiative > cs.Initiative[j-1].Initiative {
+				t.Fatalf("queue out of order after the newcomer rolled: %+v", cs.Initiative)
+			}
+		}
+	}
+}
+
+// Rounds count full passes through the order, not player actions.
+func TestEndTurnAdvancesRound(t *testing.T) {
+	cs := twoMonsterSession(types.Position{X: 7, Y: 3}, types.Position{X: 8, Y: 3})
+	cs.Round = 1
+	save := &types.SaveFile{Race: "human", Stats: statMap(10, 10, 10, 10, 10, 10)}
+	for i := 0; i < 2; i++ {
+		if _, err := ProcessEndTurn(nil, cs, save); err != nil {
+			t.Fatalf("end turn: %v", err)
+		}
+	}
+	if cs.Round != 3 {
+		t.Errorf("two ended turns should reach round 3, got %d", cs.Round)
+	}
+}
@@ -0,0 +1,36 @@
+package combat
+
+import "testing"
+
+// Versatile weapons use their larger dice only with a free offhand; a
+// two-handed weapon always does, since it holds both hands.
+func TestWeaponDamageDiceTwoHanded(t *testing.T) {
+	tags := func(t ...string) []interface{} {
+		out := make([]interface{}, len(t))
+		for i, s := range t {
+			out[i] = s
+		}
+		return out
+	}
+	cases := []struct {
+		name         string
+		item         map[string]interface{}
+		offhandEmpty bool
+		want         string
+	}{
+		{"versatile, offhand free", map[string]interface{}{"damage": "1d8", "damage_versatile": "1d10", "tags": tags("weapon", "versatile")}, true, "1d10"},
+		{"versatile, offhand held", map[string]interface{}{"damage": "1d8", "damage_versatile": "1d10", "tags": tags("weapon", "versatile")}, false, "1d8"},
+		{"versatile, hyphen key", map[string]interface{}{"damage": "1d6", "damage-versatile": "1d8", "tags": tags("weapon", "versatile")}, true, "1d8"},
+		{"versatile, legacy split dice", map[string]interface{}{"damage": "1d8, 1d10", "tags": tags("weapon", "versatile")}, true, "1d10"},
+		{"versatile dice without the tag", map[string]interface{}{"damage": "1d8", "damage_versatile": "1d10", "tags": tags("weapon")}, true, "1d8"},
+		{"two-handed with versatile dice", map[string]interface{}{"damage": "1d10", "damage_versatile": "1d12", "tags": tags("weapon", "two-handed")}, false, "1d12"},
+		{"two-handed with split dice", map[string]interface{}{"damage": "1d8, 1d12", "tags": tags("weapon", "two-handed")}, false, "1d12"},
+		{"two-handed", map[string]interface{}{"damage": "2d6", "tags": tags("weapon", "two-handed", "heavy")}, false, "2d6"},
+		{"one-handed", map[string]interface{}{"damage": "1d6", "tags": tags("weapon")}, true, "1d6"},
+	}
+	for _, c := range cases {
+		if got := WeaponDamageDice(c.item, c.offhandEmpty); got != c.want {
+			t.Errorf("%s: WeaponDamageDice = %q, want %q", c.name, got, c.want)
+		}
+	}
+}
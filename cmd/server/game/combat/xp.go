@@ -1,6 +1,7 @@
 package combat
 
 import (
+	"pubkey-quest/cmd/server/game/gametime"
 	"pubkey-quest/types"
 )
 
@@ -37,15 +38,9 @@ func KillBonusXP(monster *types.MonsterData) int {
 	return monster.KillBonusXP
 }
 
-// IsNight returns true if the current in-game time falls in the night window (23:00–05:00).
-// timeOfDay is minutes since midnight (0–1439).
-func IsNight(timeOfDay int) bool {
-	return timeOfDay >= 1380 || timeOfDay < 300 // 23:00–04:59
-}
-
-// NightMultiplier returns 1.25 at night, 1.0 otherwise.
+// NightMultiplier returns 1.25 at night (gametime.IsNight), 1.0 otherwise.
 func NightMultiplier(timeOfDay int) float64 {
-	if IsNight(timeOfDay) {
+	if gametime.IsNight(timeOfDay) {
 		return 1.25
 	}
 	return 1.0
@@ -0,0 +1,58 @@
+package effects
+
+import (
+	"fmt"
+
+	"pubkey-quest/types"
+)
+
+// Concentration — an effect flagged "concentration" is held by the player's
+// focus. Only one can be up at a time (SaveFile.ConcentrationEffect): starting
+// a second is refused until the first is ended, and a failed Constitution save
+// after taking combat damage breaks it. Removing the effect by any route ends
+// the concentration with it.
+
+// ConcentratingOn returns the ID of the effect the player is concentrating on,
+// or "" when none. A pointer left behind by an effect that has since expired
+// counts as none.
+func ConcentratingOn(state *types.SaveFile) string {
+	if state == nil || state.ConcentrationEffect == "" {
+		return ""
+	}
+	if !HasActiveEffect(state, state.ConcentrationEffect) {
+		return ""
+	}
+	return state.ConcentrationEffect
+}
+
+// CanConcentrate returns an error if the player is already concentrating on an
+// effect other than effectID. Renewing the effect already held is allowed.
+func CanConcentrate(state *types.SaveFile, effectID string) error {
+	held := ConcentratingOn(state)
+	if held == "" || held == effectID {
+		return nil
+	}
+	return fmt.Errorf("you are already concentrating on %s — end it first", EffectName(held))
+}
+
+// EndConcentration drops the effect the player is concentrating on and
+// returns its display name, or "" if they weren't concentrating.
+func EndConcentration(state *types.SaveFile) string {
+	held := ConcentratingOn(state)
+	if state != nil {
+		state.ConcentrationEffect = ""
+	}
+	if held == "" {
+		return ""
+	}
+	RemoveEffect(state, held)
+	return EffectName(held)
+}
+
+// EffectName returns an effect's display name, falling back to its ID.
+func EffectName(effectID string) string {
+	if data, err := LoadEffectData(effectID); err == nil && data.Name != "" {
+		return data.Name
+	}
+	return effectID
+}
@@ -0,0 +1,62 @@
+package effects
+
+import (
+	"strings"
+
+	"pubkey-quest/types"
+)
+
+// An effect can impose a combat condition ("condition" in its JSON) for as long
+// as it's active — a poison that leaves you poisoned, a curse that frightens.
+// Combat folds these in with the conditions it tracks itself, so the same
+// advantage/disadvantage and lost-turn rules apply whichever way one arrived.
+
+// ConditionEffectIndex is the EffectIndex of the entry that keeps a
+// condition-only effect (one with no tracked modifiers) active.
+const ConditionEffectIndex = -1
+
+// HasCondition reports whether an active, started effect imposes the
+// (case-insensitive) condition.
+func HasCondition(state *types.SaveFile, condition string) bool {
+	for _, c := range ActiveConditions(state) {
+		if strings.EqualFold(c, condition) {
+			return true
+		}
+	}
+	return false
+}
+
+// ActiveConditions returns the conditions imposed by the player's active
+// effects, lowercased and without duplicates. Effects still in their delay
+// haven't taken hold yet and are skipped.
+func ActiveConditions(state *types.SaveFile) []string {
+	if state == nil {
+		return nil
+	}
+	var conditions []string
+	seen := map[string]bool{}
+	for _, ae := range state.ActiveEffects {
+		if ae.DelayRemaining > 0 || seen[ae.EffectID] {
+			continue
+		}
+		seen[ae.EffectID] = true
+		data, err := LoadEffectData(ae.EffectID)
+		if err != nil || data.Condition == "" {
+			continue
+		}
+		condition := strings.ToLower(data.Condition)
+		if !containsString(conditions, condition) {
+			conditions = append(conditions, condition)
+		}
+	}
+	return conditions
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
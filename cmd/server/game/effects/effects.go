@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"math"
+	"sort"
 	"strings"
 
 	"pubkey-quest/cmd/server/db"
@@ -116,6 +117,24 @@ func applySkillScaling(tickInterval int, scaling *types.SkillScaling, stats map[
 	return int(math.Floor(float64(tickInterval) * multiplier))
 }
 
+// applyEncumbranceFatigue shortens the fatigue-accumulation tick interval by the
+// encumbrance tier's fatigue rate: a heavy load tires you faster. Other effects
+// pass through.
+func applyEncumbranceFatigue(effectID string, interval int, state *types.SaveFile) int {
+	if effectID != "fatigue-accumulation" || interval <= 0 {
+		return interval
+	}
+	rate := gameutil.GetEncumbranceTier(state).FatigueRate
+	if rate <= 0 || rate == 1 {
+		return interval
+	}
+	scaled := int(math.Round(float64(interval) / rate))
+	if scaled < 1 {
+		scaled = 1
+	}
+	return scaled
+}
+
 // ApplyEffect applies an effect to the character (from game-data/effects/{effectID}.json)
 func ApplyEffect(state *types.SaveFile, effectID string) error {
 	_, err := ApplyEffectWithMessage(state, effectID)
@@ -129,12 +148,21 @@ func ApplyEffectWithMessage(state *types.SaveFile, effectID string) (*types.Effe
 	if err != nil {
 		return nil, fmt.Errorf("failed to load effect %s: %v", effectID, err)
 	}
+	if effectData.Concentration {
+		if err := CanConcentrate(state, effectID); err != nil {
+			return nil, err
+		}
+	}
 
 	// Initialize active_effects if nil
 	if state.ActiveEffects == nil {
 		state.ActiveEffects = []types.ActiveEffect{}
 	}
 
+	// Mutually exclusive effects: the new one displaces any it conflicts with.
+	displaced := removeConflictingEffects(state, effectData)
+	tracked := len(state.ActiveEffects)
+
 	// Calculate total duration from removal config
 	duration := 0.0
 	if effectData.Removal.Type == "timed" || effectData.Removal.Type == "hybrid" {
@@ -207,6 +235,22 @@ func ApplyEffectWithMessage(state *types.SaveFile, effectID string) (*types.Effe
 		}
 	}
 
+	// A condition needs an active entry to last; give a condition-only effect
+	// (nothing above was tracked) a marker entry that carries its duration.
+	if effectData.Condition != "" && len(state.ActiveEffects) == tracked {
+		state.ActiveEffects = append(state.ActiveEffects, types.ActiveEffect{
+			EffectID:          effectID,
+			EffectIndex:       ConditionEffectIndex,
+			DurationRemaining: duration,
+			TotalDuration:     duration,
+			AppliedAt:         state.TimeOfDay,
+		})
+	}
+
+	if effectData.Concentration {
+		state.ConcentrationEffect = effectID
+	}
+
 	// Return effect message (convert visible to silent for backward compatibility)
 	effectMsg := &types.EffectMessage{
 		Message:  effectData.Message,
@@ -214,10 +258,27 @@ func ApplyEffectWithMessage(state *types.SaveFile, effectID string) (*types.Effe
 		Category: effectData.Category,
 		Silent:   !effectData.Visible,
 	}
+	if len(displaced) > 0 && effectMsg.Message != "" {
+		effectMsg.Message += fmt.Sprintf(" (%s ended.)", strings.Join(displaced, ", "))
+	}
 
 	return effectMsg, nil
 }
 
+// removeConflictingEffects removes the active effects effectData declares as
+// conflicting and returns their display names.
+func removeConflictingEffects(state *types.SaveFile, effectData *types.EffectData) []string {
+	var names []string
+	for _, conflictID := range effectData.Conflicts {
+		if conflictID == effectData.ID || !HasActiveEffect(state, conflictID) {
+			continue
+		}
+		RemoveEffect(state, conflictID)
+		names = append(names, EffectName(conflictID))
+	}
+	return names
+}
+
 // ApplyImmediateEffect applies an instant effect (no duration)
 // Note: This function modifies fatigue/hunger but does NOT call status update functions
 // to avoid circular dependencies. The caller is responsible for updating penalty effects.
@@ -267,7 +328,10 @@ func GetEffectTemplate(effectID string, effectIndex int) (stat string, value int
 		return "", 0, 0, "", fmt.Errorf("failed to load effect %s: %v", effectID, err)
 	}
 
-	if effectIndex >= len(effectData.Modifiers) {
+	if effectIndex == ConditionEffectIndex {
+		return "", 0, 0, effectData.Name, nil
+	}
+	if effectIndex < 0 || effectIndex >= len(effectData.Modifiers) {
 		return "", 0, 0, effectData.Name, fmt.Errorf("invalid modifier index %d for effect %s", effectIndex, effectID)
 	}
 
@@ -340,6 +404,7 @@ func TickEffects(state *types.SaveFile, minutesElapsed int, accrueFatigue bool)
 			if scaling := GetEffectSkillScaling(activeEffect.EffectID); scaling != nil {
 				tickInterval = applySkillScaling(tickInterval, scaling, state.Stats)
 			}
+			tickInterval = applyEncumbranceFatigue(activeEffect.EffectID, tickInterval, state)
 
 			// Freeze fatigue while waiting / resting: skip its tick so the accumulator
 			// doesn't advance and no fatigue is applied (time still flows for everything
@@ -402,6 +467,43 @@ func TickEffects(state *types.SaveFile, minutesElapsed int, accrueFatigue bool)
 	return messages
 }
 
+// TimedEffectDurations maps each timed (duration > 0) effect ID to its
+// shortest remaining duration. Permanent and scoped effects are left out.
+func TimedEffectDurations(active []types.ActiveEffect) map[string]float64 {
+	durations := map[string]float64{}
+	for _, ae := range active {
+		if ae.DurationRemaining <= 0 {
+			continue
+		}
+		if d, ok := durations[ae.EffectID]; !ok || ae.DurationRemaining < d {
+			durations[ae.EffectID] = ae.DurationRemaining
+		}
+	}
+	return durations
+}
+
+// ExpiredEffectIDs compares a TimedEffectDurations snapshot with the effects
+// still active after elapsed minutes and returns, sorted, the IDs that are gone
+// because their clock ran out. Effects removed early (cured, dispelled) had
+// time left and aren't reported.
+func ExpiredEffectIDs(before map[string]float64, after []types.ActiveEffect, elapsed int) []string {
+	if elapsed <= 0 || len(before) == 0 {
+		return nil
+	}
+	still := map[string]bool{}
+	for _, ae := range after {
+		still[ae.EffectID] = true
+	}
+	var expired []string
+	for effectID, remaining := range before {
+		if !still[effectID] && remaining <= float64(elapsed) {
+			expired = append(expired, effectID)
+		}
+	}
+	sort.Strings(expired)
+	return expired
+}
+
 // TickDownEffectDurations reduces duration_remaining for all timed effects
 // Used during sleep and other time jumps to properly expire buffs/debuffs
 func TickDownEffectDurations(state *types.SaveFile, minutes int) {
@@ -434,6 +536,33 @@ func TickDownEffectDurations(state *types.SaveFile, minutes int) {
 
 // GetActiveStatModifiers calculates total stat modifiers from all active effects
 func GetActiveStatModifiers(state *types.SaveFile) map[string]int {
+	return sumActiveModifiers(state, func(stat string) bool {
+		switch stat {
+		case "strength", "dexterity", "constitution", "intelligence", "wisdom", "charisma":
+			return true
+		}
+		return false
+	})
+}
+
+// combatModifierStats are the effect stats only combat reads — the "combat"
+// category of effect_types in systems/effects.json.
+var combatModifierStats = map[string]bool{
+	"crit_range": true,
+	"light":      true,
+	"luck":       true,
+}
+
+// GetActiveCombatModifiers totals the combat-only modifiers (crit_range, light, luck, …)
+// from all active effects. They're kept apart from GetActiveStatModifiers so
+// they never leak into the ability scores EffectiveStats builds.
+func GetActiveCombatModifiers(state *types.SaveFile) map[string]int {
+	return sumActiveModifiers(state, func(stat string) bool { return combatModifierStats[stat] })
+}
+
+// sumActiveModifiers totals the modifiers of every started active effect whose
+// stat passes include.
+func sumActiveModifiers(state *types.SaveFile, include func(stat string) bool) map[string]int {
 	modifiers := make(map[string]int)
 
 	if state.ActiveEffects == nil {
@@ -453,9 +582,8 @@ func GetActiveStatModifiers(state *types.SaveFile) map[string]int {
 			continue
 		}
 
-		// Only apply stat modifiers (not instant effects like hp/mana)
-		switch stat {
-		case "strength", "dexterity", "constitution", "intelligence", "wisdom", "charisma":
+		// Only apply the requested modifiers (not instant effects like hp/mana)
+		if include(stat) {
 			modifiers[stat] += value
 		}
 	}
@@ -627,6 +755,9 @@ func RemoveEffect(state *types.SaveFile, effectID string) {
 		}
 	}
 	state.ActiveEffects = remaining
+	if state.ConcentrationEffect == effectID {
+		state.ConcentrationEffect = ""
+	}
 }
 
 // RemoveEffectsByAction clears every active effect whose removal is
@@ -716,6 +847,7 @@ func EnrichActiveEffects(activeEffects []types.ActiveEffect, state *types.SaveFi
 					if effectData.SkillScaling != nil {
 						interval = applySkillScaling(interval, effectData.SkillScaling, state.Stats)
 					}
+					interval = applyEncumbranceFatigue(ae.EffectID, interval, state)
 					ee.TickInterval = float64(interval)
 				}
 			}
@@ -802,18 +934,7 @@ func EvaluateCondition(condition string, state *types.SaveFile) bool {
 			actualValue = (state.Mana * 100) / state.MaxMana
 		}
 	case "weight_percent":
-		totalWeight := gameutil.CalculateTotalWeight(state)
-		capacity := gameutil.CalculateWeightCapacity(state)
-		if capacity > 0 {
-			// Use ceiling to round up (100.1% = 101%, not 100%)
-			percentage := (totalWeight / capacity) * 100
-			actualValue = int(percentage)
-			if percentage > float64(actualValue) {
-				actualValue++ // Round up if there's any decimal
-			}
-		} else {
-			actualValue = 0
-		}
+		actualValue = gameutil.WeightPercent(state)
 	default:
 		log.Printf("⚠️ Unknown condition stat: %s", stat)
 		return false
@@ -0,0 +1,105 @@
+package effects
+
+import (
+	"log"
+
+	"pubkey-quest/types"
+)
+
+// Scoped effects are declared on a place — an environment's or a building's
+// "effects" list in the location JSON — and last only while the player is
+// there (a cave's darkness, a grove's calm). They're tagged with a Source so
+// leaving removes exactly what entering applied, and nothing else.
+
+// EnvironmentSource is the Source tag for effects applied by an environment.
+func EnvironmentSource(envID string) string {
+	return "environment:" + envID
+}
+
+// BuildingSource is the Source tag for effects applied by a building.
+func BuildingSource(locationID, buildingID string) string {
+	return "building:" + locationID + "/" + buildingID
+}
+
+// ApplyScopedEffects applies each effect and tags its entries with source.
+// Effects already active from the same source are skipped, so re-entering
+// (or a reload that replays entry) doesn't stack them. Returns the messages
+// of the effects that were applied.
+func ApplyScopedEffects(state *types.SaveFile, source string, effectIDs []string) []types.EffectMessage {
+	var messages []types.EffectMessage
+	for _, effectID := range effectIDs {
+		if hasScopedEffect(state, source, effectID) {
+			continue
+		}
+		before := len(state.ActiveEffects)
+		msg, err := ApplyEffectWithMessage(state, effectID)
+		if err != nil {
+			log.Printf("⚠️ Failed to apply %s effect %s: %v", source, effectID, err)
+			continue
+		}
+		for i := before; i < len(state.ActiveEffects); i++ {
+			state.ActiveEffects[i].Source = source
+		}
+		if msg != nil && msg.Message != "" {
+			messages = append(messages, *msg)
+		}
+		log.Printf("🌫️ Applied %s effect: %s", source, effectID)
+	}
+	return messages
+}
+
+// RemoveScopedEffects removes every active effect applied by source and
+// returns the display names of what was removed.
+func RemoveScopedEffects(state *types.SaveFile, source string) []string {
+	return removeScopedWhere(state, func(s string) bool { return s == source })
+}
+
+// ClearStaleScopedEffects removes scoped effects whose place the player is no
+// longer in. Used after jumps that skip the normal exit paths (death, moves,
+// arrival) so nothing lingers from a place already left.
+func ClearStaleScopedEffects(state *types.SaveFile) []string {
+	envSource := EnvironmentSource(state.Location)
+	buildingSource := ""
+	if state.Building != "" {
+		buildingSource = BuildingSource(state.Location, state.Building)
+	}
+	return removeScopedWhere(state, func(s string) bool {
+		return s != "" && s != envSource && s != buildingSource
+	})
+}
+
+func hasScopedEffect(state *types.SaveFile, source, effectID string) bool {
+	for _, ae := range state.ActiveEffects {
+		if ae.Source == source && ae.EffectID == effectID {
+			return true
+		}
+	}
+	return false
+}
+
+func removeScopedWhere(state *types.SaveFile, match func(source string) bool) []string {
+	if len(state.ActiveEffects) == 0 {
+		return nil
+	}
+	var remaining []types.ActiveEffect
+	var names []string
+	seen := map[string]bool{}
+	for _, ae := range state.ActiveEffects {
+		if ae.Source == "" || !match(ae.Source) {
+			remaining = append(remaining, ae)
+			continue
+		}
+		key := ae.Source + "|" + ae.EffectID
+		if !seen[key] {
+			seen[key] = true
+			name := ae.EffectID
+			if data, err := LoadEffectData(ae.EffectID); err == nil && data != nil {
+				name = data.Name
+			}
+			names = append(names, name)
+			log.Printf("🌤️ Removed %s effect: %s", ae.Source, ae.EffectID)
+		}
+	}
+	state.ActiveEffects = remaining
+	return names
+}
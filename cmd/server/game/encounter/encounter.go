@@ -8,13 +8,40 @@ package encounter
 
 import "math/rand"
 
-// Candidate is a monster eligible to be rolled for a biome encounter.
+// Candidate is a monster eligible to be rolled for a biome encounter. Weight
+// is its share of the pick among the eligible candidates (an environment's
+// authored "encounters" table); zero counts as 1, so a plain biome pool is an
+// even pick.
 type Candidate struct {
-	ID   string
-	Name string
-	CR   float64
+	ID     string
+	Name   string
+	CR     float64
+	Weight int
 }
 
+// dangerByDifficulty scales the encounter chance by an environment's
+// travel_difficulty — its danger rating. Unknown or unset ratings count as
+// "moderate".
+var dangerByDifficulty = map[string]float64{
+	"easy":      0.5,
+	"moderate":  1,
+	"hard":      1.5,
+	"very_hard": 2,
+}
+
+// Danger is the encounter-chance multiplier for an environment's
+// travel_difficulty.
+func Danger(travelDifficulty string) float64 {
+	if d, ok := dangerByDifficulty[travelDifficulty]; ok {
+		return d
+	}
+	return 1
+}
+
+// NightDanger multiplies the encounter chance while travelling at night
+// (gametime.IsNight): the road is more dangerous after dark.
+const NightDanger = 1.5
+
 // Difficulty tunables — alpha-rough on purpose. The roadmap defers full scaling
 // math to beta, so these are meant to be adjusted by feel after playtesting;
 // they should season travel, not dominate it.
@@ -111,11 +138,22 @@ func TickChance(minutesElapsed int) float64 {
 // a row (the biggest driver of "same fight over and over"). With only one
 // eligible monster it's ignored — there's nothing else to pick.
 func Roll(candidates []Candidate, level, minutesElapsed int, rng *rand.Rand, avoidID string) (Candidate, bool) {
+	return RollWithDanger(candidates, level, minutesElapsed, 1, rng, avoidID)
+}
+
+// RollWithDanger is Roll with the tick's chance scaled by the environment's
+// danger (see Danger), still capped at maxTickChance, and the monster picked by
+// candidate Weight.
+func RollWithDanger(candidates []Candidate, level, minutesElapsed int, danger float64, rng *rand.Rand, avoidID string) (Candidate, bool) {
 	eligible := Eligible(candidates, level)
 	if len(eligible) == 0 {
 		return Candidate{}, false
 	}
-	if rng.Float64() >= TickChance(minutesElapsed) {
+	chance := TickChance(minutesElapsed) * danger
+	if chance > maxTickChance {
+		chance = maxTickChance
+	}
+	if rng.Float64() >= chance {
 		return Candidate{}, false
 	}
 	if avoidID != "" && len(eligible) > 1 {
@@ -129,5 +167,27 @@ func Roll(candidates []Candidate, level, minutesElapsed int, rng *rand.Rand, avo
 			eligible = fresh
 		}
 	}
-	return eligible[rng.Intn(len(eligible))], true
+	return pickWeighted(eligible, rng), true
+}
+
+// pickWeighted picks a candidate with probability proportional to its Weight
+// (zero or negative counts as 1).
+func pickWeighted(candidates []Candidate, rng *rand.Rand) Candidate {
+	weight := func(c Candidate) int {
+		if c.Weight <= 0 {
+			return 1
+		}
+		return c.Weight
+	}
+	total := 0
+	for _, c := range candidates {
+		total += weight(c)
+	}
+	n := rng.Intn(total)
+	for _, c := range candidates {
+		if n -= weight(c); n < 0 {
+			return c
+		}
+	}
+	return candidates[len(candidates)-1]
 }
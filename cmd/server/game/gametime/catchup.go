@@ -0,0 +1,62 @@
+package gametime
+
+import (
+	"pubkey-quest/cmd/server/utils"
+	"pubkey-quest/types"
+)
+
+// Catch-up — a time sync after the client was backgrounded can claim hours at
+// once. Run through AdvanceTime as one jump, the effects see a single tick:
+// hunger drops several levels before starvation starts, penalties update only
+// at the end, and fatigue accrues at the rate it had when the jump began. So
+// the elapsed time is simulated in tick-interval steps, each one a normal
+// AdvanceTime, and the total is capped so a stale client can't buy a loop of
+// days.
+
+const (
+	// DefaultTickIntervalMinutes is the catch-up step when unconfigured.
+	DefaultTickIntervalMinutes = 15
+	// DefaultMaxCatchUpMinutes caps one sync at a game day when unconfigured.
+	DefaultMaxCatchUpMinutes = 1440
+)
+
+// TickInterval returns the configured catch-up step in game minutes.
+func TickInterval() int {
+	if n := utils.AppConfig.Game.TickIntervalMinutes; n > 0 {
+		return n
+	}
+	return DefaultTickIntervalMinutes
+}
+
+// MaxCatchUp returns the configured cap on the game minutes one sync simulates.
+func MaxCatchUp() int {
+	if n := utils.AppConfig.Game.MaxCatchUpMinutes; n > 0 {
+		return n
+	}
+	return DefaultMaxCatchUpMinutes
+}
+
+// AdvanceTimeInTicks advances state by minutes (at most maxMinutes of them) in
+// steps of interval minutes, the last step taking the remainder. It returns the
+// messages of every step, the number of steps run, and the minutes advanced.
+func AdvanceTimeInTicks(state *types.SaveFile, minutes, interval, maxMinutes int, accrueFatigue bool) ([]types.EffectMessage, int, int) {
+	if interval <= 0 {
+		interval = DefaultTickIntervalMinutes
+	}
+	if maxMinutes > 0 && minutes > maxMinutes {
+		minutes = maxMinutes
+	}
+
+	var messages []types.EffectMessage
+	ticks, advanced := 0, 0
+	for advanced < minutes {
+		step := interval
+		if minutes-advanced < step {
+			step = minutes - advanced
+		}
+		messages = append(messages, AdvanceTime(state, step, accrueFatigue)...)
+		advanced += step
+		ticks++
+	}
+	return messages, ticks, advanced
+}
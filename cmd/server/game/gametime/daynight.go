@@ -0,0 +1,18 @@
+package gametime
+
+// Day and night — the one definition of night every system shares: combat XP
+// and loot bonuses, the fall-off in light (and with it ranged accuracy), the
+// higher encounter rate on the road, and NPCs who keep daytime hours.
+
+const (
+	// NightStart is when night falls, in minutes since midnight (23:00).
+	NightStart = 23 * 60
+	// NightEnd is when night lifts (05:00).
+	NightEnd = 5 * 60
+)
+
+// IsNight reports whether timeOfDay (minutes since midnight, 0–1439) falls in
+// the night window, 23:00–04:59.
+func IsNight(timeOfDay int) bool {
+	return timeOfDay >= NightStart || timeOfDay < NightEnd
+}
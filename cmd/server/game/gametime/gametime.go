@@ -3,12 +3,12 @@ package gametime
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"pubkey-quest/cmd/server/db"
 	"pubkey-quest/cmd/server/game/building"
 	"pubkey-quest/cmd/server/game/effects"
 	"pubkey-quest/cmd/server/game/gameutil"
-	"pubkey-quest/cmd/server/game/npc"
 	"pubkey-quest/cmd/server/game/status"
 	"pubkey-quest/types"
 )
@@ -23,6 +23,13 @@ type TimeSessionProvider interface {
 	UpdateNPCsAtLocation(npcIDs []string, hour int)
 	GetBookedShows() []map[string]interface{}
 	UpdateSnapshotAndCalculateDeltaProvider() types.DeltaProvider
+	GetTickClock() *TickClock
+}
+
+// MissedShowChecker is implemented by sessions that track booked shows; the
+// time tick hands them the new time so any show the player missed is penalized.
+type MissedShowChecker interface {
+	CheckMissedShows(state *types.SaveFile, currentTime, currentDay int)
 }
 
 // IdleResetSessionProvider defines session interface for idle timer reset
@@ -110,21 +117,43 @@ func HandleUpdateTimeAction(state *types.SaveFile, params map[string]interface{}
 		minutesElapsed = (1440 - oldTime) + newTime + ((newDay - oldDay - 1) * 1440)
 	}
 
+	// Ambient time runs at the configured scale, whatever the client's clock
+	// claims: a tick only moves the save as far as real time allows (scale.go).
+	if session != nil && minutesElapsed > 0 {
+		minutesElapsed = session.GetTickClock().Allow(minutesElapsed, time.Now().UnixMilli(), TimeScale())
+	}
+
 	// Only process if time actually advanced
+	var tickMessages []string
+	ticks := 0
 	if minutesElapsed > 0 {
-		// Use AdvanceTime to properly process effects. Ambient time accrues fatigue
-		// normally, EXCEPT while stopped to rest mid-travel — then the clock keeps
-		// moving (hunger, arrival timers) but fatigue is frozen.
-		AdvanceTime(state, minutesElapsed, !state.TravelStopped)
+		// Advance in tick-interval steps so a long catch-up (a backgrounded
+		// client) fires periodic effects as they would have played out
+		// (catchup.go). Ambient time accrues fatigue normally, EXCEPT while
+		// stopped to rest mid-travel — then the clock keeps moving (hunger,
+		// arrival timers) but fatigue is frozen. Periodic effects (starvation's
+		// HP loss) fire here, and their messages go back to the client.
+		messages, n, advanced := AdvanceTimeInTicks(state, minutesElapsed, TickInterval(), MaxCatchUp(), !state.TravelStopped)
+		if advanced < minutesElapsed {
+			log.Printf("⏩ Time sync capped: simulated %d of %d minutes", advanced, minutesElapsed)
+		}
+		ticks = n
+		for _, msg := range messages {
+			if !msg.Silent && msg.Message != "" {
+				tickMessages = append(tickMessages, msg.Message)
+			}
+		}
 	}
+	// The save's clock is authoritative from here on (the tick may have been
+	// paced short of what the client asked for).
+	newTime, newDay = state.TimeOfDay, state.CurrentDay
 
 	// Check for missed shows (session-only data) and apply penalty
 	if session != nil {
 		bookedShows := session.GetBookedShows()
 		if len(bookedShows) > 0 {
-			// Use npc package's CheckMissedShows if session implements the required interface
-			if showSession, ok := session.(npc.SessionProvider); ok {
-				npc.CheckMissedShows(state, showSession, newTime, newDay)
+			if showSession, ok := session.(MissedShowChecker); ok {
+				showSession.CheckMissedShows(state, newTime, newDay)
 			}
 		}
 	}
@@ -182,6 +211,8 @@ func HandleUpdateTimeAction(state *types.SaveFile, params map[string]interface{}
 					"hp":             state.HP,
 					"active_effects": effects.EnrichActiveEffects(state.ActiveEffects, state),
 					"auto_pause":     autoPause,
+					"tick_messages":  tickMessages,
+					"ticks":          ticks,
 				},
 			}, nil
 		}
@@ -199,13 +230,18 @@ func HandleUpdateTimeAction(state *types.SaveFile, params map[string]interface{}
 			"hp":             state.HP,
 			"active_effects": effects.EnrichActiveEffects(state.ActiveEffects, state),
 			"auto_pause":     autoPause,
+			"tick_messages":  tickMessages,
+			"ticks":          ticks,
 		},
 	}, nil
 }
 
 // HandleWaitAction waits for a specified amount of time
-// Accepts either "minutes" (15-360) or "hours" (1-6) for backwards compatibility
-func HandleWaitAction(state *types.SaveFile, params map[string]interface{}, session TimeSessionProvider, npcIdsFunc func(string, string, string, string, int) []string) (*types.GameActionResponse, error) {
+// Accepts either "minutes" (15-360) or "hours" (1-6) for backwards compatibility.
+// Time passes in RestStepMinutes steps; the wait stops early when the player
+// takes damage, starts starving, or interrupt returns a reason, and only the
+// time actually waited counts.
+func HandleWaitAction(state *types.SaveFile, params map[string]interface{}, session TimeSessionProvider, npcIdsFunc func(string, string, string, string, int) []string, interrupt WaitInterrupt) (*types.GameActionResponse, error) {
 	var minutesToAdvance int
 
 	// Check for minutes first (more granular), fall back to hours
@@ -239,53 +275,44 @@ func HandleWaitAction(state *types.SaveFile, params map[string]interface{}, sess
 
 	// Advance time and process all effects. Waiting does NOT accrue fatigue — you're
 	// deliberately resting — but hunger, durations, and starvation still progress.
-	timeMessages := AdvanceTime(state, minutesToAdvance, false)
+	var (
+		waited       int
+		interrupted  string
+		timeMessages []types.EffectMessage
+	)
+	for waited < minutesToAdvance && interrupted == "" {
+		step := RestStepMinutes
+		if minutesToAdvance-waited < step {
+			step = minutesToAdvance - waited
+		}
+		hpBefore, hungerBefore := state.HP, state.Hunger
+		timeMessages = append(timeMessages, AdvanceTime(state, step, false)...)
+		waited += step
+
+		// A last-step hurt doesn't cut the wait short, but an encounter can
+		// still find you in it.
+		over := waited == minutesToAdvance
+		switch {
+		case !over && state.HP < hpBefore:
+			interrupted = "pain pulls you to your feet"
+		case !over && state.Hunger == 0 && hungerBefore > 0:
+			interrupted = "hunger won't let you sit any longer"
+		case interrupt != nil:
+			interrupted = interrupt(state, step)
+		}
+	}
 
 	// Waiting is light rest: recover HP/mana proportional to the time waited (a
 	// full ~8h restores all; percentage-of-Max so it scales with level).
-	hpGain, manaGain := status.RestoreVitalsForRest(state, minutesToAdvance)
+	hpGain, manaGain := status.RestoreVitalsForRest(state, waited)
 
 	// Update building states and NPCs after time jump
-	if session != nil {
-		database := db.GetDB()
-		if database != nil {
-			newTime := state.TimeOfDay
-			currentHour := newTime / 60
-
-			// Refresh building states
-			buildingStates, err := building.GetAllBuildingStatesForDistrict(
-				database,
-				state.Location,
-				state.District,
-				newTime,
-			)
-			if err == nil && len(buildingStates) > 0 {
-				session.UpdateBuildingStates(buildingStates, newTime)
-			}
-
-			// Refresh NPCs
-			npcIDs := npcIdsFunc(
-				state.Location,
-				state.District,
-				state.Building,
-				state.Room,
-				newTime,
-			)
-			session.UpdateNPCsAtLocation(npcIDs, currentHour)
-		}
-	}
+	refreshWorldAfterTimeJump(state, session, npcIdsFunc)
 
 	// Format message based on wait duration
-	var message string
-	hours := minutesToAdvance / 60
-	mins := minutesToAdvance % 60
-	if hours > 0 && mins > 0 {
-		message = fmt.Sprintf("You waited %d hour%s and %d minute%s.",
-			hours, gameutil.Pluralize(hours), mins, gameutil.Pluralize(mins))
-	} else if hours > 0 {
-		message = fmt.Sprintf("You waited %d hour%s.", hours, gameutil.Pluralize(hours))
-	} else {
-		message = fmt.Sprintf("You waited %d minute%s.", mins, gameutil.Pluralize(mins))
+	message := fmt.Sprintf("You waited %s.", formatDuration(waited))
+	if interrupted != "" {
+		message = fmt.Sprintf("Your wait is cut short after %s — %s.", formatDuration(waited), interrupted)
 	}
 
 	// Waiting doesn't change fatigue anymore (it's frozen), so only surface hunger.
@@ -310,7 +337,7 @@ func HandleWaitAction(state *types.SaveFile, params map[string]interface{}, sess
 		}
 	}
 
-	log.Printf("⏱️ Waited %d minutes - Time: %d, Fatigue: %d (frozen), Hunger: %d→%d", minutesToAdvance, state.TimeOfDay, state.Fatigue, oldHunger, state.Hunger)
+	log.Printf("⏱️ Waited %d/%d minutes - Time: %d, Fatigue: %d (frozen), Hunger: %d→%d (interrupted: %q)", waited, minutesToAdvance, state.TimeOfDay, state.Fatigue, oldHunger, state.Hunger, interrupted)
 
 	// Calculate delta for UI updates
 	var deltaMap map[string]interface{}
@@ -327,18 +354,69 @@ func HandleWaitAction(state *types.SaveFile, params map[string]interface{}, sess
 		Color:   "yellow",
 		Delta:   deltaMap,
 		Data: map[string]interface{}{
-			"time_of_day": state.TimeOfDay,
-			"current_day": state.CurrentDay,
-			"fatigue":     state.Fatigue,
-			"hunger":      state.Hunger,
-			"hp":          state.HP,
-			"max_hp":      state.MaxHP,
-			"mana":        state.Mana,
-			"max_mana":    state.MaxMana,
+			"time_of_day":    state.TimeOfDay,
+			"current_day":    state.CurrentDay,
+			"fatigue":        state.Fatigue,
+			"hunger":         state.Hunger,
+			"hp":             state.HP,
+			"max_hp":         state.MaxHP,
+			"mana":           state.Mana,
+			"max_mana":       state.MaxMana,
+			"minutes_waited": waited,
+			"interrupted":    interrupted != "",
 		},
 	}, nil
 }
 
+// refreshWorldAfterTimeJump refreshes building open/closed states and the NPCs
+// at the player's location after time skips ahead (waiting, resting).
+func refreshWorldAfterTimeJump(state *types.SaveFile, session TimeSessionProvider, npcIdsFunc func(string, string, string, string, int) []string) {
+	if session == nil {
+		return
+	}
+	database := db.GetDB()
+	if database == nil {
+		return
+	}
+	newTime := state.TimeOfDay
+	currentHour := newTime / 60
+
+	// Refresh building states
+	buildingStates, err := building.GetAllBuildingStatesForDistrict(
+		database,
+		state.Location,
+		state.District,
+		newTime,
+	)
+	if err == nil && len(buildingStates) > 0 {
+		session.UpdateBuildingStates(buildingStates, newTime)
+	}
+
+	// Refresh NPCs
+	npcIDs := npcIdsFunc(
+		state.Location,
+		state.District,
+		state.Building,
+		state.Room,
+		newTime,
+	)
+	session.UpdateNPCsAtLocation(npcIDs, currentHour)
+}
+
+// formatDuration renders minutes as "2 hours and 15 minutes", "1 hour" or
+// "45 minutes".
+func formatDuration(minutes int) string {
+	hours := minutes / 60
+	mins := minutes % 60
+	if hours > 0 && mins > 0 {
+		return fmt.Sprintf("%d hour%s and %d minute%s",
+			hours, gameutil.Pluralize(hours), mins, gameutil.Pluralize(mins))
+	} else if hours > 0 {
+		return fmt.Sprintf("%d hour%s", hours, gameutil.Pluralize(hours))
+	}
+	return fmt.Sprintf("%d minute%s", mins, gameutil.Pluralize(mins))
+}
+
 // HandleResetIdleTimerAction resets the auto-pause idle timer
 // Called when the play button is pressed to prevent immediate re-triggering of auto-pause
 func HandleResetIdleTimerAction(session IdleResetSessionProvider, currentUnixTime int64) (*types.GameActionResponse, error) {
@@ -374,6 +452,15 @@ func AdvanceTime(state *types.SaveFile, minutes int, accrueFatigue bool) []types
 		state.TimeOfDay = state.TimeOfDay % 1440
 	}
 
+	// Time awake builds fatigue: make sure the accumulation ticker is running
+	// (its rate is the tick_interval in fatigue-accumulation.json) in case
+	// something lowered fatigue from the max without restarting it.
+	if accrueFatigue {
+		if err := status.EnsureFatigueAccumulation(state); err != nil {
+			log.Printf("⚠️ Failed to ensure fatigue accumulation: %v", err)
+		}
+	}
+
 	// Tick active effects (includes fatigue/hunger accumulation effects)
 	messages := effects.TickEffects(state, minutes, accrueFatigue)
 
@@ -0,0 +1,123 @@
+package gametime
+
+import (
+	"fmt"
+	"log"
+	"math"
+
+	"pubkey-quest/cmd/server/game/status"
+	"pubkey-quest/types"
+)
+
+// RestStepMinutes is how much time passes between the checks that can cut a
+// rest, wait or night's sleep short.
+const RestStepMinutes = 15
+
+// WaitInterrupt is checked after each step of a wait or a night's sleep with
+// the step's length in minutes. A non-empty reason ends it there; the api layer
+// passes one that rolls encounters while the player is out in the wild.
+type WaitInterrupt func(state *types.SaveFile, minutes int) string
+
+// HandleRestAction rests for "minutes" of game time (15 up to a full rest, in
+// 15-minute steps). Time passes through AdvanceTime with fatigue frozen, and HP
+// and mana come back in proportion to the time rested, scaled by
+// status.RestRecoveryFactor — so a hungry rest heals less and a starving one
+// not at all. The rest is interrupted if the player takes damage (starvation,
+// poison) or starts starving, and only the time actually rested counts.
+func HandleRestAction(state *types.SaveFile, params map[string]interface{}, session TimeSessionProvider, npcIdsFunc func(string, string, string, string, int) []string) (*types.GameActionResponse, error) {
+	minutesFloat, ok := params["minutes"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("minutes parameter is required")
+	}
+	minutes := int(minutesFloat)
+	if minutes < RestStepMinutes || minutes > status.FullRestMinutes {
+		return &types.GameActionResponse{
+			Success: false,
+			Message: fmt.Sprintf("You can only rest between %d minutes and %d hours", RestStepMinutes, status.FullRestMinutes/60),
+			Color:   "red",
+		}, nil
+	}
+
+	var (
+		rested      int
+		restedFull  float64 // minutes of rest at full recovery, after hunger/fatigue scaling
+		interrupted string
+		tickLines   []string
+	)
+	for rested < minutes && interrupted == "" {
+		step := RestStepMinutes
+		if minutes-rested < step {
+			step = minutes - rested
+		}
+		factor := status.RestRecoveryFactor(state)
+		hpBefore, hungerBefore := state.HP, state.Hunger
+
+		for _, msg := range AdvanceTime(state, step, false) {
+			if !msg.Silent && msg.Message != "" {
+				tickLines = append(tickLines, msg.Message)
+			}
+		}
+		rested += step
+		restedFull += float64(step) * factor
+
+		switch {
+		case rested == minutes:
+			// The rest is over; a last-step hurt doesn't cut it short.
+		case state.HP < hpBefore:
+			interrupted = "pain jolts you awake"
+		case state.Hunger == 0 && hungerBefore > 0:
+			interrupted = "hunger gnaws you awake"
+		}
+	}
+
+	hpGain, manaGain := status.RestoreVitalsForRest(state, int(math.Round(restedFull)))
+	refreshWorldAfterTimeJump(state, session, npcIdsFunc)
+
+	var message string
+	if interrupted != "" {
+		message = fmt.Sprintf("Your rest is cut short after %s — %s.", formatDuration(rested), interrupted)
+	} else {
+		message = fmt.Sprintf("You rest for %s.", formatDuration(rested))
+	}
+	switch {
+	case hpGain > 0 || manaGain > 0:
+		message += fmt.Sprintf(" You recover %d HP and %d mana.", hpGain, manaGain)
+	case state.Hunger == 0:
+		message += " You're too hungry to recover anything."
+	}
+	for _, line := range tickLines {
+		message += "\n\n" + line
+	}
+
+	log.Printf("🛌 Rested %d/%d minutes → +%d HP +%d mana (interrupted: %q)", rested, minutes, hpGain, manaGain, interrupted)
+
+	var deltaMap map[string]interface{}
+	if session != nil {
+		if delta := session.UpdateSnapshotAndCalculateDeltaProvider(); delta != nil {
+			deltaMap = delta.ToMap()
+		}
+	}
+
+	color := "green"
+	if interrupted != "" {
+		color = "yellow"
+	}
+	return &types.GameActionResponse{
+		Success: true,
+		Message: message,
+		Color:   color,
+		Delta:   deltaMap,
+		Data: map[string]interface{}{
+			"time_of_day":    state.TimeOfDay,
+			"current_day":    state.CurrentDay,
+			"hp":             state.HP,
+			"max_hp":         state.MaxHP,
+			"mana":           state.Mana,
+			"max_mana":       state.MaxMana,
+			"minutes_rested": rested,
+			"hp_recovered":   hpGain,
+			"mana_recovered": manaGain,
+			"interrupted":    interrupted != "",
+		},
+	}, nil
+}
@@ -0,0 +1,75 @@
+package gametime
+
+import (
+	"pubkey-quest/cmd/server/utils"
+)
+
+// Time scale — how fast ambient game time runs against the real clock.
+//
+// The client's smooth clock advances the displayed time and reports it on each
+// update_time tick; every simulation system (effects, hunger/fatigue
+// accumulation, travel progress, rentals, shows) then runs off the game minutes
+// that tick moved the save forward. The scale is configured once, as the real
+// minutes a game day takes (game.day_length_minutes in config.yml), served to
+// the client for its clock, and enforced here: a tick may only move time as far
+// as the real time since the previous tick allows.
+
+// DefaultDayLengthMinutes is the real length of a game day when unconfigured:
+// 10 real minutes, i.e. 144× real time.
+const DefaultDayLengthMinutes = 10.0
+
+// maxTickBudget caps how many game minutes of unclaimed allowance a session can
+// bank, so a paused clock doesn't save up an hour to spend at once, while still
+// absorbing late or bunched-up ticks.
+const maxTickBudget = 30.0
+
+// tickSlack lets a tick spend up to half a minute it hasn't quite earned yet,
+// so timer jitter around the nominal tick interval doesn't hold a minute back.
+const tickSlack = 0.5
+
+// TimeScaleFor returns the game minutes that pass per real minute for a game
+// day lasting dayLengthMinutes real minutes. Non-positive lengths fall back to
+// DefaultDayLengthMinutes.
+func TimeScaleFor(dayLengthMinutes float64) float64 {
+	if dayLengthMinutes <= 0 {
+		dayLengthMinutes = DefaultDayLengthMinutes
+	}
+	return 1440 / dayLengthMinutes
+}
+
+// TimeScale returns the configured game minutes per real minute.
+func TimeScale() float64 {
+	return TimeScaleFor(utils.AppConfig.Game.DayLengthMinutes)
+}
+
+// TickClock paces one session's ambient time. Each tick earns the session the
+// game minutes the real time since the previous tick is worth; a tick may
+// spend up to what has been earned.
+type TickClock struct {
+	lastRealMs int64   // real time of the previous tick (unix ms); 0 before the first
+	budget     float64 // game minutes earned but not yet spent
+}
+
+// Allow returns how many of the claimed game minutes may pass on a tick at
+// nowMs (unix ms) under scale game minutes per real minute, and spends them.
+// The first tick of a session is trusted — there's nothing to measure against.
+func (c *TickClock) Allow(claimed int, nowMs int64, scale float64) int {
+	if c.lastRealMs == 0 || nowMs < c.lastRealMs {
+		c.lastRealMs = nowMs
+		return claimed
+	}
+	c.budget += float64(nowMs-c.lastRealMs) / 60000 * scale
+	if c.budget > maxTickBudget {
+		c.budget = maxTickBudget
+	}
+	c.lastRealMs = nowMs
+
+	allowed := claimed
+	if earned := int(c.budget + tickSlack); allowed > earned {
+		allowed = earned
+	}
+	if allowed > 0 {
+		c.budget -= float64(allowed)
+	}
+	return allowed
+}
@@ -0,0 +1,85 @@
+package gameutil
+
+import (
+	"encoding/json"
+
+	"pubkey-quest/cmd/server/db"
+	"pubkey-quest/types"
+)
+
+// EncumbranceTier is one band of carried weight, as a percentage of capacity,
+// and what carrying that much does to travel and fatigue. The bands match the
+// weight_percent checks on the encumbrance-* effects, which carry the stat
+// penalties; the tiers add the movement side.
+type EncumbranceTier struct {
+	Level       string  `json:"level"`
+	MaxPercent  int     `json:"max_percent,omitempty"` // inclusive upper bound; 0 on the last tier
+	TravelSpeed float64 `json:"travel_speed"`          // travel progress multiplier (0 = can't travel)
+	FatigueRate float64 `json:"fatigue_rate"`          // fatigue accrual multiplier
+}
+
+// defaultEncumbranceTiers are used when encumbrance_system.tiers isn't set.
+var defaultEncumbranceTiers = []EncumbranceTier{
+	{Level: "light", MaxPercent: 34, TravelSpeed: 1.1, FatigueRate: 1},
+	{Level: "normal", MaxPercent: 100, TravelSpeed: 1, FatigueRate: 1},
+	{Level: "overweight", MaxPercent: 150, TravelSpeed: 0.9, FatigueRate: 1.25},
+	{Level: "encumbered", MaxPercent: 200, TravelSpeed: 0.75, FatigueRate: 1.5},
+	{Level: "overloaded", TravelSpeed: 0, FatigueRate: 2},
+}
+
+// EncumbranceTiers returns the tiers from encumbrance_system.tiers, lightest
+// first, or the defaults when the config doesn't list them.
+func EncumbranceTiers() []EncumbranceTier {
+	database := db.GetDB()
+	if database == nil {
+		return defaultEncumbranceTiers
+	}
+	var configJSON string
+	if err := database.QueryRow("SELECT properties FROM systems WHERE id = 'encumbrance'").Scan(&configJSON); err != nil {
+		return defaultEncumbranceTiers
+	}
+	var config struct {
+		EncumbranceSystem struct {
+			Tiers []EncumbranceTier `json:"tiers"`
+		} `json:"encumbrance_system"`
+	}
+	if err := json.Unmarshal([]byte(configJSON), &config); err != nil || len(config.EncumbranceSystem.Tiers) == 0 {
+		return defaultEncumbranceTiers
+	}
+	return config.EncumbranceSystem.Tiers
+}
+
+// WeightPercent is the carried weight as a whole percentage of capacity,
+// rounded up (100.1% counts as 101%) — the weight_percent the encumbrance
+// effects check against. 0 when there's no capacity.
+func WeightPercent(state *types.SaveFile) int {
+	capacity := CalculateWeightCapacity(state)
+	if capacity <= 0 {
+		return 0
+	}
+	percentage := CalculateTotalWeight(state) / capacity * 100
+	percent := int(percentage)
+	if percentage > float64(percent) {
+		percent++
+	}
+	return percent
+}
+
+// TierForPercent returns the first of tiers whose MaxPercent covers percent,
+// or the last tier past them all.
+func TierForPercent(tiers []EncumbranceTier, percent int) EncumbranceTier {
+	for _, tier := range tiers {
+		if tier.MaxPercent > 0 && percent <= tier.MaxPercent {
+			return tier
+		}
+	}
+	if len(tiers) == 0 {
+		return EncumbranceTier{Level: "normal", TravelSpeed: 1, FatigueRate: 1}
+	}
+	return tiers[len(tiers)-1]
+}
+
+// GetEncumbranceTier returns the tier the player's carried weight puts them in.
+func GetEncumbranceTier(state *types.SaveFile) EncumbranceTier {
+	return TierForPercent(EncumbranceTiers(), WeightPercent(state))
+}
@@ -145,6 +145,29 @@ func DeductGold(state *types.SaveFile, amount int) bool {
 	return remaining == 0
 }
 
+// SpendGold pays amount out of the player's gold pieces if they have that much
+// and reports whether it did. Short of the full amount nothing is taken, so gold
+// never goes below zero (DeductGold on its own would empty the purse first).
+func SpendGold(state *types.SaveFile, amount int) bool {
+	if amount <= 0 {
+		return true
+	}
+	if GetGoldQuantity(state) < amount {
+		return false
+	}
+	return DeductGold(state, amount)
+}
+
+// AddGold puts amount gold pieces into the player's inventory, onto an existing
+// gold stack first, and reports whether there was room. A non-positive amount
+// adds nothing.
+func AddGold(state *types.SaveFile, amount int) bool {
+	if amount <= 0 {
+		return true
+	}
+	return AddGoldToInventory(state.Inventory, amount) == nil
+}
+
 // PlayerHasItem checks if player has an item in inventory
 func PlayerHasItem(state *types.SaveFile, itemID string) bool {
 	// Check general slots
@@ -176,6 +199,81 @@ func PlayerHasItem(state *types.SaveFile, itemID string) bool {
 	return false
 }
 
+// CountItem returns how many of itemID the player carries across the general
+// slots and the backpack.
+func CountItem(state *types.SaveFile, itemID string) int {
+	total := 0
+	for _, slotMap := range carriedSlots(state) {
+		if item, ok := slotMap["item"].(string); ok && item == itemID {
+			total += slotQuantity(slotMap)
+		}
+	}
+	return total
+}
+
+// TakeItem removes quantity of itemID from the general slots, then the backpack,
+// and reports whether it did. Short of the full quantity nothing is taken.
+func TakeItem(state *types.SaveFile, itemID string, quantity int) bool {
+	if quantity <= 0 {
+		return true
+	}
+	if CountItem(state, itemID) < quantity {
+		return false
+	}
+	remaining := quantity
+	for _, slotMap := range carriedSlots(state) {
+		if remaining <= 0 {
+			break
+		}
+		if item, ok := slotMap["item"].(string); !ok || item != itemID {
+			continue
+		}
+		have := slotQuantity(slotMap)
+		if have > remaining {
+			slotMap["quantity"] = have - remaining
+			remaining = 0
+			continue
+		}
+		remaining -= have
+		slotMap["item"] = nil
+		slotMap["quantity"] = 0
+	}
+	return true
+}
+
+// carriedSlots returns the general slots followed by the backpack's slots.
+func carriedSlots(state *types.SaveFile) []map[string]interface{} {
+	var out []map[string]interface{}
+	collect := func(slots []interface{}) {
+		for _, slotData := range slots {
+			if slotMap, ok := slotData.(map[string]interface{}); ok {
+				out = append(out, slotMap)
+			}
+		}
+	}
+	generalSlots, _ := state.Inventory["general_slots"].([]interface{})
+	collect(generalSlots)
+	if gearSlots, ok := state.Inventory["gear_slots"].(map[string]interface{}); ok {
+		if bag, ok := gearSlots["bag"].(map[string]interface{}); ok {
+			contents, _ := bag["contents"].([]interface{})
+			collect(contents)
+		}
+	}
+	return out
+}
+
+// slotQuantity reads a slot's quantity, which is float64 after JSON decoding and
+// int after an in-Go edit.
+func slotQuantity(slotMap map[string]interface{}) int {
+	switch v := slotMap["quantity"].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	}
+	return 0
+}
+
 // AddGoldToInventory adds gold to the player's inventory
 func AddGoldToInventory(inventory map[string]interface{}, goldAmount int) error {
 	log.Printf("💰 Adding %dg to inventory", goldAmount)
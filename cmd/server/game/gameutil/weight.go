@@ -176,3 +176,52 @@ func getBaseWeightMultiplier() float64 {
 	}
 	return 5.0
 }
+
+// WeightPolicy returns how a source of new items ("pickup", "loot") handles
+// going over the weight capacity: "block" refuses what won't fit, "allow" takes
+// it and leaves the rest to the encumbrance penalties. Read from
+// encumbrance_system.weight_enforcement; pickups block and loot is allowed when
+// the config doesn't say.
+func WeightPolicy(source string) string {
+	policy := "block"
+	if source == "loot" {
+		policy = "allow"
+	}
+	database := db.GetDB()
+	if database == nil {
+		return policy
+	}
+	var configJSON string
+	if err := database.QueryRow("SELECT properties FROM systems WHERE id = 'encumbrance'").Scan(&configJSON); err != nil {
+		return policy
+	}
+	var config map[string]interface{}
+	if err := json.Unmarshal([]byte(configJSON), &config); err != nil {
+		return policy
+	}
+	if enc, ok := config["encumbrance_system"].(map[string]interface{}); ok {
+		if we, ok := enc["weight_enforcement"].(map[string]interface{}); ok {
+			if p, ok := we[source].(string); ok && (p == "block" || p == "allow") {
+				return p
+			}
+		}
+	}
+	return policy
+}
+
+// CarryableQuantity returns how many of quantity × itemID fit under the weight
+// capacity on top of what the player already carries. Weightless items always fit.
+func CarryableQuantity(state *types.SaveFile, itemID string, quantity int) int {
+	weight := GetItemWeight(itemID)
+	if weight <= 0 || quantity <= 0 {
+		return quantity
+	}
+	room := CalculateWeightCapacity(state) - CalculateTotalWeight(state)
+	if room <= 0 {
+		return 0
+	}
+	if fit := int(room / weight); fit < quantity {
+		return fit
+	}
+	return quantity
+}
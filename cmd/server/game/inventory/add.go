@@ -19,7 +19,7 @@ func AddItemToInventory(save *types.SaveFile, itemID string, quantity int) (int,
 	// Get item data to check max stack size
 	itemData, err := db.GetItemByID(itemID)
 	if err != nil {
-		return 0, fmt.Errorf("item not found: %s", itemID)
+		return 0, types.NewActionError(types.ErrCodeItemNotFound, "item not found: %s", itemID)
 	}
 
 	// Parse stack size from properties JSON
@@ -49,6 +49,17 @@ func AddItemToInventory(save *types.SaveFile, itemID string, quantity int) (int,
 	bag, _ := gearSlots["bag"].(map[string]interface{})
 	backpackSlots, _ := bag["contents"].([]interface{})
 
+	// Slot counts are dynamic: the general count comes from systems data and the
+	// backpack holds as many slots as the equipped bag's container_slots. Slots
+	// past the cap (a save from a bigger bag or an older count) can be emptied
+	// but never filled, so every step below stops at it.
+	generalCap := GeneralSlotCount()
+	generalSlots = padSlots(generalSlots, generalCap)
+	backpackCap := BackpackSlotCount(inventory)
+	if backpackSlots != nil {
+		backpackSlots = padSlots(backpackSlots, backpackCap)
+	}
+
 	remaining := quantity
 	totalAdded := 0
 
@@ -58,7 +69,7 @@ func AddItemToInventory(save *types.SaveFile, itemID string, quantity int) (int,
 	if backpackSlots != nil {
 		log.Printf("🔍 Checking backpack for existing %s stacks...", itemID)
 		for i, slotData := range backpackSlots {
-			if remaining <= 0 {
+			if remaining <= 0 || i >= backpackCap {
 				break
 			}
 
@@ -93,7 +104,7 @@ func AddItemToInventory(save *types.SaveFile, itemID string, quantity int) (int,
 
 	// STEP 2: Try to stack with existing items in general slots
 	for i, slotData := range generalSlots {
-		if remaining <= 0 {
+		if remaining <= 0 || i >= generalCap {
 			break
 		}
 
@@ -121,7 +132,7 @@ func AddItemToInventory(save *types.SaveFile, itemID string, quantity int) (int,
 
 	// STEP 3: Fill empty backpack slots
 	for i, slotData := range backpackSlots {
-		if remaining <= 0 {
+		if remaining <= 0 || i >= backpackCap {
 			break
 		}
 
@@ -147,7 +158,7 @@ func AddItemToInventory(save *types.SaveFile, itemID string, quantity int) (int,
 
 	// STEP 4: Fill empty general slots
 	for i, slotData := range generalSlots {
-		if remaining <= 0 {
+		if remaining <= 0 || i >= generalCap {
 			break
 		}
 
@@ -182,7 +193,7 @@ func AddItemToInventory(save *types.SaveFile, itemID string, quantity int) (int,
 	if remaining > 0 {
 		log.Printf("⚠️ Inventory full - added %d/%d items (%d couldn't fit)", totalAdded, quantity, remaining)
 		if totalAdded == 0 {
-			return 0, fmt.Errorf("no room in inventory")
+			return 0, types.NewActionError(types.ErrCodeInventoryFull, "no room in inventory")
 		}
 		return totalAdded, nil // Partial success
 	}
@@ -0,0 +1,108 @@
+package inventory
+
+import (
+	"encoding/json"
+
+	"pubkey-quest/cmd/server/db"
+	"pubkey-quest/types"
+)
+
+// ConsolidateStacks merges fragmented stacks of the same item across the
+// general slots and the backpack, topping up the earliest stack first (general
+// before backpack, in slot order) up to the item's max stack, and empties the
+// slots that drain. Quantities are only moved, never created or dropped.
+// Slots carrying anything besides item/quantity/slot (a quiver's contents) are
+// left alone. Returns the number of slots freed.
+func ConsolidateStacks(save *types.SaveFile) int {
+	if save == nil || save.Inventory == nil {
+		return 0
+	}
+
+	var slots []map[string]interface{}
+	collect := func(list []interface{}) {
+		for _, entry := range list {
+			if slotMap, ok := entry.(map[string]interface{}); ok && plainStack(slotMap) {
+				slots = append(slots, slotMap)
+			}
+		}
+	}
+	generalSlots, _ := save.Inventory["general_slots"].([]interface{})
+	collect(generalSlots)
+	gearSlots, _ := save.Inventory["gear_slots"].(map[string]interface{})
+	bag, _ := gearSlots["bag"].(map[string]interface{})
+	backpackSlots, _ := bag["contents"].([]interface{})
+	collect(backpackSlots)
+
+	freed := 0
+	limits := map[string]int{}
+	open := map[string][]map[string]interface{}{} // item ID → earlier stacks with room
+	for _, slotMap := range slots {
+		itemID, _ := slotMap["item"].(string)
+		qty := slotQuantity(slotMap)
+		if itemID == "" || qty <= 0 {
+			continue
+		}
+		limit, known := limits[itemID]
+		if !known {
+			limit = stackLimit(itemID)
+			limits[itemID] = limit
+		}
+		if limit <= 1 {
+			continue
+		}
+
+		for len(open[itemID]) > 0 && qty > 0 {
+			target := open[itemID][0]
+			have := slotQuantity(target)
+			move := limit - have
+			if move > qty {
+				move = qty
+			}
+			target["quantity"] = have + move
+			qty -= move
+			if have+move >= limit {
+				open[itemID] = open[itemID][1:]
+			}
+		}
+
+		if qty == 0 {
+			slotMap["item"] = nil
+			slotMap["quantity"] = 0
+			freed++
+			continue
+		}
+		slotMap["quantity"] = qty
+		if qty < limit {
+			open[itemID] = append(open[itemID], slotMap)
+		}
+	}
+	return freed
+}
+
+// plainStack reports whether a slot holds nothing but an item stack, so its
+// quantity can be moved without losing anything attached to it.
+func plainStack(slotMap map[string]interface{}) bool {
+	for key := range slotMap {
+		switch key {
+		case "item", "quantity", "slot":
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// stackLimit returns the item's max stack, 1 when it doesn't stack or is unknown.
+func stackLimit(itemID string) int {
+	itemData, err := db.GetItemByID(itemID)
+	if err != nil || itemData.Properties == "" {
+		return 1
+	}
+	var properties map[string]interface{}
+	if err := json.Unmarshal([]byte(itemData.Properties), &properties); err == nil {
+		if val, ok := properties["stack"].(float64); ok && val > 1 {
+			return int(val)
+		}
+	}
+	return 1
+}
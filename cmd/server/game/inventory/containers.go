@@ -106,6 +106,10 @@ func HandleAddToContainerAction(state *types.SaveFile, params map[string]interfa
 	if !isContainer {
 		return nil, fmt.Errorf("item is not a container")
 	}
+	containerName, _ := properties["name"].(string)
+	if containerName == "" {
+		containerName = containerID
+	}
 
 	// Get container slots limit
 	containerSlots := 10 // default
@@ -156,7 +160,7 @@ func HandleAddToContainerAction(state *types.SaveFile, params map[string]interfa
 	}
 
 	if usedSlots >= containerSlots {
-		return nil, fmt.Errorf("container is full")
+		return containerRejection(types.ErrCodeContainerFull, fmt.Sprintf("The %s is full (%d/%d slots)", containerName, usedSlots, containerSlots)), nil
 	}
 
 	// Get item properties to check if it's a container
@@ -175,7 +179,7 @@ func HandleAddToContainerAction(state *types.SaveFile, params map[string]interfa
 	if itemTags, ok := itemProperties["tags"].([]interface{}); ok {
 		for _, tag := range itemTags {
 			if tagStr, ok := tag.(string); ok && tagStr == "container" {
-				return nil, fmt.Errorf("containers cannot be stored inside other containers")
+				return containerRejection(types.ErrCodeItemNotAllowed, "Containers cannot be stored inside other containers"), nil
 			}
 		}
 	}
@@ -213,7 +217,12 @@ func HandleAddToContainerAction(state *types.SaveFile, params map[string]interfa
 		}
 
 		if !itemAllowed {
-			return nil, fmt.Errorf("this item cannot be stored in this container (requires %s)", strings.Join(allowedTypes, ", "))
+			itemName, _ := itemProperties["name"].(string)
+			if itemName == "" {
+				itemName = itemID
+			}
+			return containerRejection(types.ErrCodeItemNotAllowed, fmt.Sprintf("The %s only holds %s — %s doesn't qualify",
+				containerName, strings.Join(allowedTypes, ", "), itemName)), nil
 		}
 	}
 
@@ -324,6 +333,17 @@ func HandleAddToContainerAction(state *types.SaveFile, params map[string]interfa
 	}, nil
 }
 
+// containerRejection is the red failure response for an add the container's
+// rules refuse (full, wrong type, or a container inside a container).
+func containerRejection(code, message string) *types.GameActionResponse {
+	return &types.GameActionResponse{
+		Success:   false,
+		Error:     message,
+		Color:     "red",
+		ErrorCode: code,
+	}
+}
+
 // HandleRemoveFromContainerAction removes an item from a container back to inventory
 func HandleRemoveFromContainerAction(state *types.SaveFile, params map[string]interface{}) (*types.GameActionResponse, error) {
 	// Extract parameters
@@ -493,7 +513,7 @@ func HandleRemoveFromContainerAction(state *types.SaveFile, params map[string]in
 	}
 
 	if !emptySlotFound {
-		return nil, fmt.Errorf("no room to take that out — free a general slot or equip a bag first")
+		return nil, types.NewActionError(types.ErrCodeInventoryFull, "no room to take that out — free a general slot or equip a bag first")
 	}
 
 	// Remove item from container
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
 
 	"pubkey-quest/cmd/server/db"
 	"pubkey-quest/cmd/server/game/effects"
@@ -29,6 +30,91 @@ func GetEquippedItemID(inventory map[string]interface{}, slot string) string {
 	return itemID
 }
 
+// equipSlotsByGearSlot maps an item's gear_slot to the gear_slots keys it may
+// be equipped into.
+var equipSlotsByGearSlot = map[string][]string{
+	"hands":    {"mainhand", "offhand"},
+	"mainhand": {"mainhand"},
+	"offhand":  {"offhand"},
+	"head":     {"head"},
+	"chest":    {"chest"},
+	"legs":     {"legs"},
+	"gloves":   {"gloves"},
+	"boots":    {"boots"},
+	"neck":     {"neck"},
+	"ring":     {"ring1", "ring2"},
+	"ammo":     {"ammo"},
+	"bag":      {"bag"},
+}
+
+// EquipSlotsFor returns the gear slots an item with the given gear_slot may be
+// equipped into, or nil if the gear_slot isn't one an item can be worn from.
+func EquipSlotsFor(gearSlot string) []string {
+	return append([]string(nil), equipSlotsByGearSlot[gearSlot]...)
+}
+
+// GearSlotLayout returns, for every item gear_slot, the gear_slots keys that can
+// hold it — two for rings, so a player wears at most two.
+func GearSlotLayout() map[string][]string {
+	layout := make(map[string][]string, len(equipSlotsByGearSlot))
+	for gearSlot := range equipSlotsByGearSlot {
+		layout[gearSlot] = EquipSlotsFor(gearSlot)
+	}
+	return layout
+}
+
+// SlotsFullError refuses an equip because the slots the item needs are taken.
+// Occupied maps each blocking slot to the item in it, so the player can be
+// told what to take off.
+type SlotsFullError struct {
+	Message  string
+	Occupied map[string]string
+}
+
+func (e *SlotsFullError) Error() string {
+	return e.Message
+}
+
+// FreeEquipSlot picks the first empty slot an item with the given gear_slot
+// can go in. When every one of them is taken it returns a *SlotsFullError
+// rather than choosing one to swap out — the player says which, by naming the
+// slot.
+func FreeEquipSlot(inventory map[string]interface{}, gearSlot string) (string, error) {
+	slots := equipSlotsByGearSlot[gearSlot]
+	occupied := map[string]string{}
+	for _, slot := range slots {
+		itemID := GetEquippedItemID(inventory, slot)
+		if itemID == "" {
+			return slot, nil
+		}
+		occupied[slot] = itemID
+	}
+
+	held := make([]string, 0, len(slots))
+	for _, slot := range slots {
+		held = append(held, fmt.Sprintf("%s: %s", slot, occupied[slot]))
+	}
+	return "", &SlotsFullError{
+		Message:  fmt.Sprintf("All %d %s slots are full (%s) - take one off first", len(slots), gearSlot, strings.Join(held, ", ")),
+		Occupied: occupied,
+	}
+}
+
+// validateEquipSlot rejects equipping an item into a slot its gear_slot doesn't
+// permit (a helmet into the mainhand).
+func validateEquipSlot(itemID, gearSlot, equipSlot string) error {
+	allowed := equipSlotsByGearSlot[gearSlot]
+	if len(allowed) == 0 {
+		return fmt.Errorf("item '%s' can't be equipped (gear_slot '%s')", itemID, gearSlot)
+	}
+	for _, slot := range allowed {
+		if slot == equipSlot {
+			return nil
+		}
+	}
+	return fmt.Errorf("item '%s' can't be equipped in the %s slot (fits: %s)", itemID, equipSlot, strings.Join(allowed, ", "))
+}
+
 // HandleEquipItemAction equips an item from inventory to an equipment slot
 func HandleEquipItemAction(state *types.SaveFile, params map[string]interface{}) (*types.GameActionResponse, error) {
 	itemID, ok := params["item_id"].(string)
@@ -64,39 +150,40 @@ func HandleEquipItemAction(state *types.SaveFile, params map[string]interface{})
 		state.Inventory["gear_slots"] = gearSlots
 	}
 
-	// If no equipment slot specified, determine from item properties
-	if equipSlot == "" {
-		database := db.GetDB()
-		if database == nil {
-			return nil, fmt.Errorf("database not available")
-		}
+	// Look up the item: its gear_slot decides where it may go
+	database := db.GetDB()
+	if database == nil {
+		return nil, fmt.Errorf("database not available")
+	}
 
-		var propertiesJSON string
-		var tagsJSON string
-		var itemType string
-		err := database.QueryRow("SELECT properties, tags, item_type FROM items WHERE id = ?", itemID).Scan(&propertiesJSON, &tagsJSON, &itemType)
-		if err != nil {
-			return nil, fmt.Errorf("item '%s' not found in database: %v", itemID, err)
-		}
+	var propertiesJSON string
+	var tagsJSON string
+	var itemType string
+	err := database.QueryRow("SELECT properties, tags, item_type FROM items WHERE id = ?", itemID).Scan(&propertiesJSON, &tagsJSON, &itemType)
+	if err != nil {
+		return nil, fmt.Errorf("item '%s' not found in database: %v", itemID, err)
+	}
 
-		var properties map[string]interface{}
-		if err := json.Unmarshal([]byte(propertiesJSON), &properties); err != nil {
-			return nil, fmt.Errorf("failed to parse item properties")
-		}
+	var properties map[string]interface{}
+	if err := json.Unmarshal([]byte(propertiesJSON), &properties); err != nil {
+		return nil, fmt.Errorf("failed to parse item properties")
+	}
+	gearSlotProp, _ := properties["gear_slot"].(string)
 
-		// Check for two-handed
-		var tags []interface{}
-		if err := json.Unmarshal([]byte(tagsJSON), &tags); err == nil {
-			for _, tag := range tags {
-				if tagStr, ok := tag.(string); ok && tagStr == "two-handed" {
-					isTwoHanded = true
-					break
-				}
+	// Check for two-handed
+	var tags []interface{}
+	if err := json.Unmarshal([]byte(tagsJSON), &tags); err == nil {
+		for _, tag := range tags {
+			if tagStr, ok := tag.(string); ok && tagStr == "two-handed" {
+				isTwoHanded = true
+				break
 			}
 		}
+	}
 
-		// Determine equipment slot
-		if gearSlotProp, ok := properties["gear_slot"].(string); ok {
+	// If no equipment slot specified, determine from item properties
+	if equipSlot == "" {
+		if gearSlotProp != "" {
 			switch gearSlotProp {
 			case "hands":
 				if itemType == "Shield" {
@@ -127,22 +214,12 @@ func HandleEquipItemAction(state *types.SaveFile, params map[string]interface{})
 						equipSlot = "mainhand"
 					}
 				}
-			case "armor", "body":
-				equipSlot = "armor"
-			case "neck", "necklace":
-				equipSlot = "necklace"
-			case "finger", "ring":
-				equipSlot = "ring"
-			case "ammunition", "ammo":
-				equipSlot = "ammo"
-			case "clothes", "clothing":
-				equipSlot = "clothes"
-			case "bag", "backpack":
-				equipSlot = "bag"
-			case "mainhand":
-				equipSlot = "mainhand"
-			case "offhand":
-				equipSlot = "offhand"
+			case "ring":
+				free, err := FreeEquipSlot(state.Inventory, gearSlotProp)
+				if err != nil {
+					return nil, err
+				}
+				equipSlot = free
 			default:
 				equipSlot = gearSlotProp
 			}
@@ -151,10 +228,29 @@ func HandleEquipItemAction(state *types.SaveFile, params map[string]interface{})
 		}
 	}
 
+	// Whatever slot was asked for, the item's gear_slot has to allow it
+	if err := validateEquipSlot(itemID, gearSlotProp, equipSlot); err != nil {
+		return nil, err
+	}
+
+	// A two-handed weapon needs the offhand free. The only thing allowed there is
+	// the other half of a two-handed weapon already held, which is swapped out.
+	if isTwoHanded {
+		mainID := GetEquippedItemID(state.Inventory, "mainhand")
+		if offID := GetEquippedItemID(state.Inventory, "offhand"); offID != "" && !(offID == mainID && isTwoHandedWeapon(offID)) {
+			return nil, &SlotsFullError{
+				Message:  fmt.Sprintf("'%s' needs both hands - unequip the %s from your offhand first", itemID, offID),
+				Occupied: map[string]string{"offhand": offID},
+			}
+		}
+	}
+
 	log.Printf("⚔️ Equipping to slot: %s (two-handed: %v)", equipSlot, isTwoHanded)
 
-	// Handle two-handed weapons - unequip both hands
+	// Handle two-handed weapons - swap out the mainhand (the offhand is empty or
+	// holds the same two-handed weapon, cleared when the new one takes both hands)
 	var itemsToUnequip []map[string]interface{}
+	pairedHand := ""
 	if isTwoHanded {
 		if rightMap, ok := gearSlots["mainhand"].(map[string]interface{}); ok {
 			if rightMap["item"] != nil && rightMap["item"] != "" {
@@ -165,15 +261,6 @@ func HandleEquipItemAction(state *types.SaveFile, params map[string]interface{})
 				})
 			}
 		}
-		if leftMap, ok := gearSlots["offhand"].(map[string]interface{}); ok {
-			if leftMap["item"] != nil && leftMap["item"] != "" {
-				itemsToUnequip = append(itemsToUnequip, map[string]interface{}{
-					"item":     leftMap["item"],
-					"quantity": leftMap["quantity"],
-					"from":     "offhand",
-				})
-			}
-		}
 	} else {
 		if existing := gearSlots[equipSlot]; existing != nil {
 			if existingMap, ok := existing.(map[string]interface{}); ok {
@@ -183,43 +270,21 @@ func HandleEquipItemAction(state *types.SaveFile, params map[string]interface{})
 					itemsToUnequip = append(itemsToUnequip, map[string]interface{}{
 						"item":     existingItemID,
 						"quantity": existingMap["quantity"],
+						"contents": existingMap["contents"],
 						"from":     equipSlot,
 					})
 
-					// Check if existing item is two-handed
-					log.Printf("🔍 Checking if existing item '%s' is two-handed", existingItemID)
-					database := db.GetDB()
-					if database != nil {
-						var tagsJSON string
-						err := database.QueryRow("SELECT tags FROM items WHERE id = ?", existingItemID).Scan(&tagsJSON)
-						if err == nil {
-							var tags []interface{}
-							if err := json.Unmarshal([]byte(tagsJSON), &tags); err == nil {
-								for _, tag := range tags {
-									if tagStr, ok := tag.(string); ok && tagStr == "two-handed" {
-										var otherSlot string
-										switch equipSlot {
-										case "mainhand":
-											otherSlot = "offhand"
-										case "offhand":
-											otherSlot = "mainhand"
-										}
-
-										if otherSlot != "" {
-											if otherHand, ok := gearSlots[otherSlot].(map[string]interface{}); ok {
-												if otherHand["item"] == existingItemID {
-													log.Printf("🗡️ Existing two-handed weapon detected - also clearing %s", otherSlot)
-													gearSlots[otherSlot] = map[string]interface{}{
-														"item":     nil,
-														"quantity": 0,
-													}
-												}
-											}
-										}
-										break
-									}
-								}
-							}
+					// A two-handed weapon being replaced lets go of the other hand
+					// too — cleared below, once nothing can refuse the equip.
+					if isTwoHandedWeapon(existingItemID) {
+						switch equipSlot {
+						case "mainhand":
+							pairedHand = "offhand"
+						case "offhand":
+							pairedHand = "mainhand"
+						}
+						if GetEquippedItemID(state.Inventory, pairedHand) != existingItemID {
+							pairedHand = ""
 						}
 					}
 				}
@@ -245,7 +310,7 @@ func HandleEquipItemAction(state *types.SaveFile, params map[string]interface{})
 
 		itemMap, ok := sourceInventory[fromSlot].(map[string]interface{})
 		if !ok || itemMap["item"] != itemID {
-			return nil, fmt.Errorf("item not found in specified slot")
+			return nil, types.NewActionError(types.ErrCodeItemNotFound, "item not found in specified slot")
 		}
 		itemData = itemMap
 		sourceArrayIndex = fromSlot
@@ -279,12 +344,29 @@ func HandleEquipItemAction(state *types.SaveFile, params map[string]interface{})
 			}
 		}
 		if !found {
-			return nil, fmt.Errorf("item not found in specified slot")
+			return nil, types.NewActionError(types.ErrCodeItemNotFound, "item not found in specified slot")
 		}
 	} else {
 		return nil, fmt.Errorf("invalid source slot type")
 	}
 
+	// Swap: the displaced item takes the slot the new one came from. Check it
+	// may live there before anything moves, so a refused swap changes nothing.
+	if len(itemsToUnequip) > 0 && fromSlotType == "inventory" {
+		displaced, _ := itemsToUnequip[0]["item"].(string)
+		if refusal := checkBackpackAllowed(displaced); refusal != nil {
+			return refusal, nil
+		}
+	}
+
+	if pairedHand != "" {
+		log.Printf("🗡️ Existing two-handed weapon detected - also clearing %s", pairedHand)
+		gearSlots[pairedHand] = map[string]interface{}{
+			"item":     nil,
+			"quantity": 0,
+		}
+	}
+
 	// Add unequipped items back to inventory (swapped items)
 	for i, unequipData := range itemsToUnequip {
 		var targetSlot int
@@ -312,7 +394,8 @@ func HandleEquipItemAction(state *types.SaveFile, params map[string]interface{})
 					if ok {
 						backpackContents, ok := bag["contents"].([]interface{})
 						if ok {
-							for j := 0; j < 20; j++ {
+							backpackCap := BackpackSlotCount(state.Inventory)
+							for j := 0; j < backpackCap; j++ {
 								if j >= len(backpackContents) {
 									backpackContents = append(backpackContents, map[string]interface{}{
 										"item":     nil,
@@ -335,7 +418,8 @@ func HandleEquipItemAction(state *types.SaveFile, params map[string]interface{})
 				} else {
 					generalSlots, ok := state.Inventory["general_slots"].([]interface{})
 					if ok {
-						for j := 0; j < 4; j++ {
+						generalCap := GeneralSlotCount()
+						for j := 0; j < generalCap; j++ {
 							if j >= len(generalSlots) {
 								generalSlots = append(generalSlots, map[string]interface{}{
 									"item":     nil,
@@ -357,16 +441,20 @@ func HandleEquipItemAction(state *types.SaveFile, params map[string]interface{})
 				}
 
 				if !foundEmpty {
-					return nil, fmt.Errorf("inventory is full, cannot unequip both items")
+					return nil, types.NewActionError(types.ErrCodeInventoryFull, "inventory is full, cannot unequip both items")
 				}
 			}
 		}
 
-		targetInventory[targetSlot] = map[string]interface{}{
+		displacedItem := map[string]interface{}{
 			"item":     unequipData["item"],
 			"quantity": unequipData["quantity"],
 			"slot":     targetSlot,
 		}
+		if contents, ok := unequipData["contents"].([]interface{}); ok {
+			displacedItem["contents"] = contents
+		}
+		targetInventory[targetSlot] = displacedItem
 
 		slotName := unequipData["from"].(string)
 		gearSlots[slotName] = map[string]interface{}{
@@ -401,7 +489,7 @@ func HandleEquipItemAction(state *types.SaveFile, params map[string]interface{})
 			equippedItem["contents"] = contents
 			log.Printf("📦 Preserving container contents on equip (%d slots)", len(contents))
 		} else if equipSlot == "bag" {
-			equippedItem["contents"] = make([]interface{}, 0, 20)
+			equippedItem["contents"] = make([]interface{}, 0, ContainerSlotCount(itemID))
 			log.Printf("📦 Initializing empty bag contents")
 		}
 
@@ -432,7 +520,6 @@ func HandleEquipItemAction(state *types.SaveFile, params map[string]interface{})
 	}
 
 	// Apply effects_when_worn
-	database := db.GetDB()
 	if database != nil {
 		var propertiesJSON string
 		err := database.QueryRow("SELECT properties FROM items WHERE id = ?", itemID).Scan(&propertiesJSON)
@@ -521,16 +608,39 @@ func HandleUnequipItemAction(state *types.SaveFile, params map[string]interface{
 	emptySlotIndex := -1
 	emptySlotType := ""
 
-	if equipSlot == "bag" || itemIsContainer {
+	// Dropped onto a particular slot (to_slot/to_slot_type): land there if it's
+	// empty and may hold the item, else fall back to the first open slot.
+	if toSlotType, _ := params["to_slot_type"].(string); toSlotType == "general" || toSlotType == "inventory" {
+		toSlot := -1
+		if ts, ok := params["to_slot"].(float64); ok {
+			toSlot = int(ts)
+		} else if ts, ok := params["to_slot"].(int); ok {
+			toSlot = ts
+		}
+		containerToBackpack := toSlotType == "inventory" && (equipSlot == "bag" || itemIsContainer)
+		if toSlot >= 0 && !containerToBackpack {
+			if slots, capacity, err := playerSlots(state, toSlotType); err == nil && toSlot < capacity {
+				if slotMap, ok := slots[toSlot].(map[string]interface{}); slots[toSlot] == nil || ok && (slotMap["item"] == nil || slotMap["item"] == "") {
+					emptySlotIndex = toSlot
+					emptySlotType = toSlotType
+				}
+			}
+		}
+	}
+
+	if emptySlotIndex != -1 {
+		log.Printf("🎯 Unequipping %s to requested %s slot %d", itemID, emptySlotType, emptySlotIndex)
+	} else if equipSlot == "bag" || itemIsContainer {
 		log.Printf("🎒 Unequipping container %s → general slots only", itemID)
 
 		generalSlots, ok := state.Inventory["general_slots"].([]interface{})
 		if !ok {
-			generalSlots = make([]interface{}, 0, 4)
+			generalSlots = make([]interface{}, 0, GeneralSlotCount())
 			state.Inventory["general_slots"] = generalSlots
 		}
 
-		for i := 0; i < 4; i++ {
+		generalCap := GeneralSlotCount()
+		for i := 0; i < generalCap; i++ {
 			if i >= len(generalSlots) {
 				generalSlots = append(generalSlots, map[string]interface{}{
 					"item":     nil,
@@ -557,9 +667,10 @@ func HandleUnequipItemAction(state *types.SaveFile, params map[string]interface{
 
 		if emptySlotIndex == -1 {
 			return &types.GameActionResponse{
-				Success: false,
-				Error:   "There isn't enough room in your general slots to take this off",
-				Color:   "red",
+				Success:   false,
+				Error:     "There isn't enough room in your general slots to take this off",
+				ErrorCode: types.ErrCodeInventoryFull,
+				Color:     "red",
 			}, nil
 		}
 
@@ -576,7 +687,8 @@ func HandleUnequipItemAction(state *types.SaveFile, params map[string]interface{
 			bag["contents"] = backpackContents
 		}
 
-		for i := 0; i < 20; i++ {
+		backpackCap := BackpackSlotCount(state.Inventory)
+		for i := 0; i < backpackCap; i++ {
 			if i >= len(backpackContents) {
 				backpackContents = append(backpackContents, map[string]interface{}{
 					"item":     nil,
@@ -605,11 +717,12 @@ func HandleUnequipItemAction(state *types.SaveFile, params map[string]interface{
 	if emptySlotIndex == -1 && equipSlot != "bag" && !itemIsContainer {
 		generalSlots, ok := state.Inventory["general_slots"].([]interface{})
 		if !ok {
-			generalSlots = make([]interface{}, 0, 4)
+			generalSlots = make([]interface{}, 0, GeneralSlotCount())
 			state.Inventory["general_slots"] = generalSlots
 		}
 
-		for i := 0; i < 4; i++ {
+		generalCap := GeneralSlotCount()
+		for i := 0; i < generalCap; i++ {
 			if i >= len(generalSlots) {
 				generalSlots = append(generalSlots, map[string]interface{}{
 					"item":     nil,
@@ -636,7 +749,7 @@ func HandleUnequipItemAction(state *types.SaveFile, params map[string]interface{
 	}
 
 	if emptySlotIndex == -1 {
-		return nil, fmt.Errorf("your inventory is full")
+		return nil, types.NewActionError(types.ErrCodeInventoryFull, "your inventory is full")
 	}
 
 	newItem := map[string]interface{}{
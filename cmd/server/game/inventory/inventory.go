@@ -171,7 +171,7 @@ func HandleUseItemAction(state *types.SaveFile, params map[string]interface{}) (
 	}
 
 	if !itemFound {
-		return nil, fmt.Errorf("item not found: %s", itemID)
+		return nil, types.NewActionError(types.ErrCodeItemNotFound, "item not found: %s", itemID)
 	}
 
 	effectMsg := "Used item"
@@ -416,7 +416,7 @@ func HandleDropItemAction(state *types.SaveFile, params map[string]interface{})
 	}
 
 	if !itemFound {
-		return nil, 0, fmt.Errorf("item not found: %s", itemID)
+		return nil, 0, types.NewActionError(types.ErrCodeItemNotFound, "item not found: %s", itemID)
 	}
 
 	return &types.GameActionResponse{
@@ -513,7 +513,7 @@ func HandleRemoveFromInventoryAction(state *types.SaveFile, params map[string]in
 	}
 
 	if !itemFound {
-		return nil, fmt.Errorf("item not found: %s at slot %d", itemID, int(fromSlot))
+		return nil, types.NewActionError(types.ErrCodeItemNotFound, "item not found: %s at slot %d", itemID, int(fromSlot))
 	}
 
 	return &types.GameActionResponse{
@@ -545,23 +545,25 @@ func HandleMoveItemAction(state *types.SaveFile, params map[string]interface{})
 	if (fromSlotType == "vault" || toSlotType == "vault") && vaultBuilding == "" {
 		vaultBuilding = state.Building
 	}
+	// A vault is only reachable from inside its own building; naming another
+	// town's bank doesn't reach into it.
+	if (fromSlotType == "vault" || toSlotType == "vault") && (state.Building == "" || vaultBuilding != state.Building) {
+		return &types.GameActionResponse{
+			Success:   false,
+			Error:     "You can only use a vault from inside its building",
+			ErrorCode: types.ErrCodeNotInBuilding,
+			Color:     "red",
+		}, nil
+	}
 
 	// Get from slots
 	switch fromSlotType {
-	case "general":
-		generalSlots, ok := state.Inventory["general_slots"].([]interface{})
-		if !ok {
-			return nil, fmt.Errorf("invalid general slots")
-		}
-		fromSlots = generalSlots
-	case "inventory":
-		gearSlots, _ := state.Inventory["gear_slots"].(map[string]interface{})
-		bag, _ := gearSlots["bag"].(map[string]interface{})
-		contents, ok := bag["contents"].([]interface{})
-		if !ok {
-			return nil, fmt.Errorf("invalid backpack")
+	case "general", "inventory":
+		slots, _, err := playerSlots(state, fromSlotType)
+		if err != nil {
+			return nil, err
 		}
-		fromSlots = contents
+		fromSlots = slots
 	case "vault":
 		vaultData := vault.GetVaultForLocation(state, vaultBuilding)
 		if vaultData == nil {
@@ -576,20 +578,15 @@ func HandleMoveItemAction(state *types.SaveFile, params map[string]interface{})
 
 	// Get to slots
 	switch toSlotType {
-	case "general":
-		generalSlots, ok := state.Inventory["general_slots"].([]interface{})
-		if !ok {
-			return nil, fmt.Errorf("invalid general slots")
+	case "general", "inventory":
+		slots, capacity, err := playerSlots(state, toSlotType)
+		if err != nil {
+			return nil, err
 		}
-		toSlots = generalSlots
-	case "inventory":
-		gearSlots, _ := state.Inventory["gear_slots"].(map[string]interface{})
-		bag, _ := gearSlots["bag"].(map[string]interface{})
-		contents, ok := bag["contents"].([]interface{})
-		if !ok {
-			return nil, fmt.Errorf("invalid backpack")
+		if err := checkDestinationSlot(toSlotType, toSlot, capacity); err != nil {
+			return nil, err
 		}
-		toSlots = contents
+		toSlots = slots
 	case "vault":
 		vaultData := vault.GetVaultForLocation(state, vaultBuilding)
 		if vaultData == nil {
@@ -599,108 +596,45 @@ func HandleMoveItemAction(state *types.SaveFile, params map[string]interface{})
 		if !ok {
 			return nil, fmt.Errorf("invalid vault slots")
 		}
+		if toSlot < 0 || toSlot >= len(slots) {
+			if fromSlotType != "vault" && vault.FreeSlots(vaultData) == 0 {
+				return &types.GameActionResponse{
+					Success:   false,
+					Error:     fmt.Sprintf("Your vault is full (%d slots)", len(slots)),
+					ErrorCode: types.ErrCodeVaultFull,
+					Color:     "red",
+				}, nil
+			}
+			return nil, fmt.Errorf("slot %d is beyond your vault (%d slots)", toSlot, len(slots))
+		}
 		toSlots = slots
 	}
 
 	// CRITICAL VALIDATION: Containers cannot go into backpack
 	if toSlotType == "inventory" {
-		log.Printf("🔍 VALIDATION CHECK: Is '%s' a container? (destination: backpack)", itemID)
-
-		database := db.GetDB()
-		if database == nil {
-			log.Printf("❌ CRITICAL: Database not available")
-			return &types.GameActionResponse{
-				Success: false,
-				Error:   "System error: Cannot validate item restrictions",
-				Color:   "red",
-			}, nil
-		}
-
-		// Query tags from database
-		var tagsJSON string
-		err := database.QueryRow("SELECT tags FROM items WHERE id = ?", itemID).Scan(&tagsJSON)
-		if err != nil {
-			log.Printf("❌ CRITICAL: Failed to query tags for %s: %v", itemID, err)
-			return &types.GameActionResponse{
-				Success: false,
-				Error:   fmt.Sprintf("System error: Cannot find item %s", itemID),
-				Color:   "red",
-			}, nil
-		}
-
-		log.Printf("📦 Raw tags JSON from database for '%s': %s", itemID, tagsJSON)
-
-		var tags []interface{}
-		if err := json.Unmarshal([]byte(tagsJSON), &tags); err != nil {
-			log.Printf("❌ CRITICAL: Failed to parse tags JSON for %s: %v", itemID, err)
-			return &types.GameActionResponse{
-				Success: false,
-				Error:   "System error: Invalid item data format",
-				Color:   "red",
-			}, nil
-		}
-
-		log.Printf("📦 Parsed tags array for '%s': %v", itemID, tags)
-
-		// Check each tag
-		for _, tag := range tags {
-			if tagStr, ok := tag.(string); ok {
-				log.Printf("   🏷️ Found tag: '%s'", tagStr)
-				if tagStr == "container" {
-					log.Printf("❌ BLOCKED: '%s' has 'container' tag - CANNOT go in backpack!", itemID)
-					return &types.GameActionResponse{
-						Success: false,
-						Error:   "Containers cannot be stored in the backpack",
-						Color:   "red",
-					}, nil
-				}
-			}
+		if refusal := checkBackpackAllowed(itemID); refusal != nil {
+			return refusal, nil
 		}
-
-		log.Printf("✅ VALIDATION PASSED: '%s' is NOT a container - allowing move to backpack", itemID)
 	}
 
 	// ADDITIONAL VALIDATION: Check displaced item in swap scenarios
-	log.Printf("🔍 Checking swap validation: fromSlotType=%s, toSlotType=%s", fromSlotType, toSlotType)
 	if fromSlotType == "inventory" && toSlotType != "inventory" {
-		log.Printf("🔍 Condition met: dragging FROM backpack TO %s", toSlotType)
 		// Check if we're swapping (destination slot is not empty)
 		if toSlots != nil && toSlot < len(toSlots) {
-			log.Printf("🔍 Checking destination slot %d (toSlots length: %d)", toSlot, len(toSlots))
 			if destSlot, ok := toSlots[toSlot].(map[string]interface{}); ok {
-				log.Printf("🔍 Destination slot data: %+v", destSlot)
 				if destItem, ok := destSlot["item"].(string); ok && destItem != "" {
-					// There's an item in the destination - this is a swap
-					log.Printf("🔍 SWAP VALIDATION: Checking if displaced item '%s' is a container (would go to backpack)", destItem)
-
-					database := db.GetDB()
-					if database != nil {
-						var tagsJSON string
-						err := database.QueryRow("SELECT tags FROM items WHERE id = ?", destItem).Scan(&tagsJSON)
-						if err == nil {
-							var tags []interface{}
-							if err := json.Unmarshal([]byte(tagsJSON), &tags); err == nil {
-								for _, tag := range tags {
-									if tagStr, ok := tag.(string); ok && tagStr == "container" {
-										log.Printf("❌ BLOCKED: Displaced item '%s' is a container and cannot go in backpack via swap!", destItem)
-										return &types.GameActionResponse{
-											Success: false,
-											Error:   "Containers cannot be stored in the backpack",
-											Color:   "red",
-										}, nil
-									}
-								}
-							}
-						}
+					// There's an item in the destination - it would go to the backpack
+					if refusal := checkBackpackAllowed(destItem); refusal != nil {
+						return refusal, nil
 					}
-					log.Printf("✅ Swap validated: Displaced item '%s' is not a container", destItem)
 				}
 			}
 		}
 	}
 
 	// Swap items
-	if fromSlots != nil && toSlots != nil && fromSlot >= 0 && toSlot >= 0 {
+	if fromSlots != nil && toSlots != nil && fromSlot >= 0 && toSlot >= 0 &&
+		fromSlot < len(fromSlots) && toSlot < len(toSlots) {
 		if fromSlotType == toSlotType {
 			// Same array, just swap within it
 			fromSlots[fromSlot], fromSlots[toSlot] = fromSlots[toSlot], fromSlots[fromSlot]
@@ -736,7 +670,7 @@ func HandleMoveItemAction(state *types.SaveFile, params map[string]interface{})
 		log.Printf("🏦 Vault involved: from=%s, to=%s, building=%s", fromSlotType, toSlotType, vaultBuilding)
 		vaultData := vault.GetVaultForLocation(state, vaultBuilding)
 		if vaultData != nil {
-			delta["vault_data"] = vaultData
+			delta["vault_data"] = vault.VaultDelta(vaultData)
 			log.Printf("✅ Returning updated vault data with %d slots", len(vaultData["slots"].([]interface{})))
 		} else {
 			log.Printf("⚠️ Vault not found for building: %s", vaultBuilding)
@@ -754,6 +688,55 @@ func HandleMoveItemAction(state *types.SaveFile, params map[string]interface{})
 	return response, nil
 }
 
+// checkBackpackAllowed refuses itemID a place in the backpack when it is a
+// container (a backpack can't hold another container) or its tags can't be
+// checked. It returns the refusal to send back, or nil if the item may go in.
+// Moves and equip swaps both check whatever is headed for the backpack.
+func checkBackpackAllowed(itemID string) *types.GameActionResponse {
+	database := db.GetDB()
+	if database == nil {
+		log.Printf("❌ CRITICAL: Database not available")
+		return &types.GameActionResponse{
+			Success: false,
+			Error:   "System error: Cannot validate item restrictions",
+			Color:   "red",
+		}
+	}
+
+	var tagsJSON string
+	if err := database.QueryRow("SELECT tags FROM items WHERE id = ?", itemID).Scan(&tagsJSON); err != nil {
+		log.Printf("❌ CRITICAL: Failed to query tags for %s: %v", itemID, err)
+		return &types.GameActionResponse{
+			Success: false,
+			Error:   fmt.Sprintf("System error: Cannot find item %s", itemID),
+			Color:   "red",
+		}
+	}
+
+	var tags []interface{}
+	if err := json.Unmarshal([]byte(tagsJSON), &tags); err != nil {
+		log.Printf("❌ CRITICAL: Failed to parse tags JSON for %s: %v", itemID, err)
+		return &types.GameActionResponse{
+			Success: false,
+			Error:   "System error: Invalid item data format",
+			Color:   "red",
+		}
+	}
+
+	for _, tag := range tags {
+		if tagStr, ok := tag.(string); ok && tagStr == "container" {
+			log.Printf("❌ BLOCKED: '%s' has 'container' tag - CANNOT go in backpack!", itemID)
+			return &types.GameActionResponse{
+				Success:   false,
+				Error:     "Containers cannot be stored in the backpack",
+				ErrorCode: types.ErrCodeItemNotAllowed,
+				Color:     "red",
+			}
+		}
+	}
+	return nil
+}
+
 // HandleStackItemAction stacks items together
 func HandleStackItemAction(state *types.SaveFile, params map[string]interface{}) (*types.GameActionResponse, error) {
 	itemID, _ := params["item_id"].(string)
@@ -765,20 +748,12 @@ func HandleStackItemAction(state *types.SaveFile, params map[string]interface{})
 	// Get source slots
 	var fromSlots []interface{}
 	switch fromSlotType {
-	case "general":
-		generalSlots, ok := state.Inventory["general_slots"].([]interface{})
-		if !ok {
-			return nil, fmt.Errorf("invalid general slots")
-		}
-		fromSlots = generalSlots
-	case "inventory":
-		gearSlots, _ := state.Inventory["gear_slots"].(map[string]interface{})
-		bag, _ := gearSlots["bag"].(map[string]interface{})
-		contents, ok := bag["contents"].([]interface{})
-		if !ok {
-			return nil, fmt.Errorf("invalid backpack")
+	case "general", "inventory":
+		slots, _, err := playerSlots(state, fromSlotType)
+		if err != nil {
+			return nil, err
 		}
-		fromSlots = contents
+		fromSlots = slots
 	default:
 		return nil, fmt.Errorf("invalid source slot type: %s", fromSlotType)
 	}
@@ -786,24 +761,23 @@ func HandleStackItemAction(state *types.SaveFile, params map[string]interface{})
 	// Get destination slots
 	var toSlots []interface{}
 	switch toSlotType {
-	case "general":
-		generalSlots, ok := state.Inventory["general_slots"].([]interface{})
-		if !ok {
-			return nil, fmt.Errorf("invalid general slots")
+	case "general", "inventory":
+		slots, capacity, err := playerSlots(state, toSlotType)
+		if err != nil {
+			return nil, err
 		}
-		toSlots = generalSlots
-	case "inventory":
-		gearSlots, _ := state.Inventory["gear_slots"].(map[string]interface{})
-		bag, _ := gearSlots["bag"].(map[string]interface{})
-		contents, ok := bag["contents"].([]interface{})
-		if !ok {
-			return nil, fmt.Errorf("invalid backpack")
+		if err := checkDestinationSlot(toSlotType, toSlot, capacity); err != nil {
+			return nil, err
 		}
-		toSlots = contents
+		toSlots = slots
 	default:
 		return nil, fmt.Errorf("invalid destination slot type: %s", toSlotType)
 	}
 
+	if fromSlot < 0 || fromSlot >= len(fromSlots) {
+		return nil, fmt.Errorf("invalid from slot: %d", fromSlot)
+	}
+
 	// Get source and destination items
 	fromSlotMap, ok := fromSlots[fromSlot].(map[string]interface{})
 	if !ok {
@@ -823,7 +797,7 @@ func HandleStackItemAction(state *types.SaveFile, params map[string]interface{})
 	// Get item data to check max stack
 	itemData, err := db.GetItemByID(itemID)
 	if err != nil {
-		return nil, fmt.Errorf("item not found: %s", itemID)
+		return nil, types.NewActionError(types.ErrCodeItemNotFound, "item not found: %s", itemID)
 	}
 
 	// Parse max stack from properties
@@ -910,20 +884,12 @@ func HandleSplitItemAction(state *types.SaveFile, params map[string]interface{})
 	// Get source slot
 	var fromSlots []interface{}
 	switch fromSlotType {
-	case "general":
-		generalSlots, ok := state.Inventory["general_slots"].([]interface{})
-		if !ok {
-			return nil, fmt.Errorf("invalid general slots")
-		}
-		fromSlots = generalSlots
-	case "inventory":
-		gearSlots, _ := state.Inventory["gear_slots"].(map[string]interface{})
-		bag, _ := gearSlots["bag"].(map[string]interface{})
-		contents, ok := bag["contents"].([]interface{})
-		if !ok {
-			return nil, fmt.Errorf("invalid backpack")
+	case "general", "inventory":
+		slots, _, err := playerSlots(state, fromSlotType)
+		if err != nil {
+			return nil, err
 		}
-		fromSlots = contents
+		fromSlots = slots
 	default:
 		return nil, fmt.Errorf("invalid source slot type: %s", fromSlotType)
 	}
@@ -931,20 +897,15 @@ func HandleSplitItemAction(state *types.SaveFile, params map[string]interface{})
 	// Get destination slot
 	var toSlots []interface{}
 	switch toSlotType {
-	case "general":
-		generalSlots, ok := state.Inventory["general_slots"].([]interface{})
-		if !ok {
-			return nil, fmt.Errorf("invalid general slots")
+	case "general", "inventory":
+		slots, capacity, err := playerSlots(state, toSlotType)
+		if err != nil {
+			return nil, err
 		}
-		toSlots = generalSlots
-	case "inventory":
-		gearSlots, _ := state.Inventory["gear_slots"].(map[string]interface{})
-		bag, _ := gearSlots["bag"].(map[string]interface{})
-		contents, ok := bag["contents"].([]interface{})
-		if !ok {
-			return nil, fmt.Errorf("invalid backpack")
+		if err := checkDestinationSlot(toSlotType, toSlot, capacity); err != nil {
+			return nil, err
 		}
-		toSlots = contents
+		toSlots = slots
 	default:
 		return nil, fmt.Errorf("invalid destination slot type: %s", toSlotType)
 	}
@@ -1022,10 +983,25 @@ func HandleAddItemAction(state *types.SaveFile, params map[string]interface{}) (
 
 	log.Printf("➕ Adding %dx %s to inventory", quantity, itemID)
 
+	// enforce_weight refuses an item that would take the player over their
+	// carrying capacity. It is off by default: the shop hands staged sells back
+	// through this action and must never strand them. Overweight adds are
+	// penalised by the encumbrance update that follows the action.
+	if enforceWeight, _ := params["enforce_weight"].(bool); enforceWeight &&
+		status.CarryableQuantity(state, itemID, quantity) < quantity {
+		return &types.GameActionResponse{
+			Success: false,
+			Error: fmt.Sprintf("%dx %s is too heavy to carry (%.0f/%.0f lbs)", quantity, itemID,
+				status.CalculateTotalWeight(state), status.CalculateWeightCapacity(state)),
+			Color:     "red",
+			ErrorCode: types.ErrCodeTooHeavy,
+		}, nil
+	}
+
 	// Try general slots first
-	generalSlots, ok := state.Inventory["general_slots"].([]interface{})
-	if ok {
-		for i, slotData := range generalSlots {
+	generalSlots, generalCap, err := playerSlots(state, "general")
+	if err == nil {
+		for i, slotData := range generalSlots[:generalCap] {
 			slotMap, ok := slotData.(map[string]interface{})
 			if !ok {
 				continue
@@ -1046,35 +1022,29 @@ func HandleAddItemAction(state *types.SaveFile, params map[string]interface{}) (
 		}
 	}
 
-	// Try backpack if general slots are full
-	gearSlots, ok := state.Inventory["gear_slots"].(map[string]interface{})
-	if ok {
-		bag, ok := gearSlots["bag"].(map[string]interface{})
-		if ok {
-			backpack, ok := bag["contents"].([]interface{})
-			if ok {
-				for i, slotData := range backpack {
-					slotMap, ok := slotData.(map[string]interface{})
-					if !ok {
-						continue
-					}
+	// Try backpack if general slots are full — only as many slots as the bag holds
+	backpack, backpackCap, err := playerSlots(state, "inventory")
+	if err == nil {
+		for i, slotData := range backpack[:backpackCap] {
+			slotMap, ok := slotData.(map[string]interface{})
+			if !ok {
+				continue
+			}
 
-					// Check if slot is empty
-					if slotMap["item"] == nil || slotMap["item"] == "" {
-						// Add item to this slot (ensure quantity is int)
-						slotMap["item"] = itemID
-						slotMap["quantity"] = int(quantity)
-						log.Printf("✅ Added %dx %s to backpack[%d] (type: %T)", quantity, itemID, i, slotMap["quantity"])
-
-						return &types.GameActionResponse{
-							Success: true,
-							Message: fmt.Sprintf("Added %dx %s", quantity, itemID),
-						}, nil
-					}
-				}
+			// Check if slot is empty
+			if slotMap["item"] == nil || slotMap["item"] == "" {
+				// Add item to this slot (ensure quantity is int)
+				slotMap["item"] = itemID
+				slotMap["quantity"] = int(quantity)
+				log.Printf("✅ Added %dx %s to backpack[%d] (type: %T)", quantity, itemID, i, slotMap["quantity"])
+
+				return &types.GameActionResponse{
+					Success: true,
+					Message: fmt.Sprintf("Added %dx %s", quantity, itemID),
+				}, nil
 			}
 		}
 	}
 
-	return nil, fmt.Errorf("inventory is full")
+	return nil, types.NewActionError(types.ErrCodeInventoryFull, "inventory is full")
 }
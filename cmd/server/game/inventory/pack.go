@@ -0,0 +1,155 @@
+package inventory
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"pubkey-quest/cmd/server/db"
+	"pubkey-quest/types"
+)
+
+// PackGrant is one content line of an opened pack: how many were added to the
+// inventory and how many didn't fit (the caller decides where overflow goes).
+type PackGrant struct {
+	Item     string `json:"item"`
+	Quantity int    `json:"quantity"`
+	Overflow int    `json:"overflow,omitempty"`
+}
+
+// packContents reads a pack item's [item_id, quantity] contents. Returns an
+// error if the item isn't tagged "pack".
+func packContents(itemID string) ([][2]interface{}, error) {
+	itemData, err := db.GetItemByID(itemID)
+	if err != nil {
+		return nil, fmt.Errorf("item '%s' not found", itemID)
+	}
+	var tags []string
+	_ = json.Unmarshal([]byte(itemData.Tags), &tags)
+	isPack := false
+	for _, tag := range tags {
+		if tag == "pack" {
+			isPack = true
+			break
+		}
+	}
+	if !isPack {
+		return nil, fmt.Errorf("item '%s' is not a pack", itemID)
+	}
+
+	var properties struct {
+		Contents [][2]interface{} `json:"contents"`
+	}
+	if err := json.Unmarshal([]byte(itemData.Properties), &properties); err != nil {
+		return nil, fmt.Errorf("failed to parse pack contents: %v", err)
+	}
+	return properties.Contents, nil
+}
+
+// takeOneFromSlots removes one itemID from the first matching slot (or only the
+// given slot when slot >= 0). Reports whether one was found.
+func takeOneFromSlots(slots []interface{}, itemID string, slot int) bool {
+	for i, slotData := range slots {
+		slotMap, ok := slotData.(map[string]interface{})
+		if !ok || slotMap["item"] != itemID || (slot >= 0 && i != slot) {
+			continue
+		}
+		if qty := slotQuantity(slotMap); qty > 1 {
+			slotMap["quantity"] = qty - 1
+		} else {
+			slotMap["item"] = nil
+			slotMap["quantity"] = 0
+		}
+		return true
+	}
+	return false
+}
+
+// HandleOpenPackAction opens a pack: removes one from the inventory and adds each
+// of its [item_id, quantity] contents with AddItemToInventory (which stacks onto
+// existing piles before taking empty slots). Anything that doesn't fit is
+// reported as Overflow on its grant rather than failing the whole open — the
+// pack is consumed either way. Params: item_id, optional from_slot +
+// from_slot_type ("general" / "inventory") to pick a specific pack.
+func HandleOpenPackAction(state *types.SaveFile, params map[string]interface{}) (*types.GameActionResponse, []PackGrant, error) {
+	itemID, ok := params["item_id"].(string)
+	if !ok || itemID == "" {
+		return nil, nil, fmt.Errorf("missing or invalid item_id parameter")
+	}
+
+	contents, err := packContents(itemID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	slot := -1
+	if s, ok := params["from_slot"].(float64); ok {
+		slot = int(s)
+	}
+	slotType, _ := params["from_slot_type"].(string)
+
+	// Take the pack out first so its slot is free for the contents.
+	found := false
+	if slotType == "" || slotType == "general" {
+		if generalSlots, ok := state.Inventory["general_slots"].([]interface{}); ok {
+			found = takeOneFromSlots(generalSlots, itemID, slot)
+		}
+	}
+	if !found && (slotType == "" || slotType == "inventory") {
+		if backpack, _, err := playerSlots(state, "inventory"); err == nil {
+			found = takeOneFromSlots(backpack, itemID, slot)
+		}
+	}
+	if !found {
+		return &types.GameActionResponse{
+			Success: false,
+			Message: "You don't have that pack",
+			Color:   "red",
+		}, nil, nil
+	}
+
+	var grants []PackGrant
+	var received, left []string
+	for _, entry := range contents {
+		contentID, _ := entry[0].(string)
+		qtyVal, _ := entry[1].(float64)
+		quantity := int(qtyVal)
+		if contentID == "" || quantity <= 0 {
+			continue
+		}
+		added, addErr := AddItemToInventory(state, contentID, quantity)
+		if addErr != nil {
+			log.Printf("⚠️ open_pack: failed to add %dx %s: %v", quantity, contentID, addErr)
+		}
+		grant := PackGrant{Item: contentID, Quantity: added, Overflow: quantity - added}
+		grants = append(grants, grant)
+		if grant.Quantity > 0 {
+			received = append(received, fmt.Sprintf("%dx %s", grant.Quantity, contentID))
+		}
+		if grant.Overflow > 0 {
+			left = append(left, fmt.Sprintf("%dx %s", grant.Overflow, contentID))
+		}
+	}
+
+	log.Printf("📦 Opened pack %s: %v", itemID, grants)
+
+	// Report what actually went in, not what the pack held.
+	message := fmt.Sprintf("Opened %s", itemID)
+	if len(received) > 0 {
+		message += ": " + strings.Join(received, ", ")
+	}
+	color := "green"
+	if len(left) > 0 {
+		message += fmt.Sprintf(" (no room for %s)", strings.Join(left, ", "))
+		color = "yellow"
+	}
+	return &types.GameActionResponse{
+		Success: true,
+		Message: message,
+		Color:   color,
+		Data: map[string]interface{}{
+			"granted": grants,
+		},
+	}, grants, nil
+}
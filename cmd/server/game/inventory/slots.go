@@ -0,0 +1,138 @@
+package inventory
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"pubkey-quest/cmd/server/db"
+	"pubkey-quest/types"
+)
+
+const (
+	defaultGeneralSlots = 4  // systems/inventory.json general_inventory.count fallback
+	defaultBagSlots     = 20 // bag with no container_slots (legacy data)
+)
+
+var (
+	generalSlotsMu     sync.RWMutex
+	generalSlots       = defaultGeneralSlots
+	generalSlotsLoaded bool
+)
+
+// GeneralSlotCount returns the number of general inventory slots every character
+// has, from slots.general_inventory.count in systems/inventory.json. It's read
+// from the systems table once and cached, since every item add asks for it;
+// edits need a server restart.
+func GeneralSlotCount() int {
+	generalSlotsMu.RLock()
+	loaded, count := generalSlotsLoaded, generalSlots
+	generalSlotsMu.RUnlock()
+	if loaded {
+		return count
+	}
+
+	database := db.GetDB()
+	if database == nil {
+		return defaultGeneralSlots // not cached: the database may come up later
+	}
+	count = defaultGeneralSlots
+	var configJSON string
+	if err := database.QueryRow("SELECT properties FROM systems WHERE id = 'inventory'").Scan(&configJSON); err == nil {
+		var config map[string]interface{}
+		if err := json.Unmarshal([]byte(configJSON), &config); err == nil {
+			if sys, ok := config["inventory_system"].(map[string]interface{}); ok {
+				if slots, ok := sys["slots"].(map[string]interface{}); ok {
+					if general, ok := slots["general_inventory"].(map[string]interface{}); ok {
+						if n, ok := general["count"].(float64); ok && n > 0 {
+							count = int(n)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	generalSlotsMu.Lock()
+	generalSlots, generalSlotsLoaded = count, true
+	generalSlotsMu.Unlock()
+	return count
+}
+
+// ContainerSlotCount returns an item's container_slots, or defaultBagSlots when
+// the item exists but doesn't declare one. Unknown items hold nothing.
+func ContainerSlotCount(itemID string) int {
+	if itemID == "" {
+		return 0
+	}
+	itemData, err := db.GetItemByID(itemID)
+	if err != nil {
+		return 0
+	}
+	var properties map[string]interface{}
+	if err := json.Unmarshal([]byte(itemData.Properties), &properties); err == nil {
+		if val, ok := properties["container_slots"].(float64); ok && val > 0 {
+			return int(val)
+		}
+	}
+	return defaultBagSlots
+}
+
+// BackpackSlotCount returns how many backpack slots the equipped bag provides.
+// No bag means no backpack space — equipping a bigger bag grants more.
+func BackpackSlotCount(inventory map[string]interface{}) int {
+	return ContainerSlotCount(GetEquippedItemID(inventory, "bag"))
+}
+
+// padSlots extends slots with empty entries until it holds n slots.
+func padSlots(slots []interface{}, n int) []interface{} {
+	for i := len(slots); i < n; i++ {
+		slots = append(slots, map[string]interface{}{
+			"item":     nil,
+			"quantity": 0,
+			"slot":     i,
+		})
+	}
+	return slots
+}
+
+// playerSlots returns the "general" or "inventory" (backpack) slot array padded
+// out to its current capacity, written back into the save, along with that
+// capacity. A bag swapped for a smaller one can leave the array longer than the
+// capacity; those trailing slots can still be emptied but not filled.
+func playerSlots(state *types.SaveFile, slotType string) ([]interface{}, int, error) {
+	switch slotType {
+	case "general":
+		generalSlots, ok := state.Inventory["general_slots"].([]interface{})
+		if !ok {
+			return nil, 0, fmt.Errorf("invalid general slots")
+		}
+		capacity := GeneralSlotCount()
+		generalSlots = padSlots(generalSlots, capacity)
+		state.Inventory["general_slots"] = generalSlots
+		return generalSlots, capacity, nil
+	case "inventory":
+		gearSlots, _ := state.Inventory["gear_slots"].(map[string]interface{})
+		bag, _ := gearSlots["bag"].(map[string]interface{})
+		contents, ok := bag["contents"].([]interface{})
+		if !ok {
+			return nil, 0, fmt.Errorf("invalid backpack")
+		}
+		capacity := BackpackSlotCount(state.Inventory)
+		contents = padSlots(contents, capacity)
+		bag["contents"] = contents
+		return contents, capacity, nil
+	}
+	return nil, 0, fmt.Errorf("invalid slot type: %s", slotType)
+}
+
+// checkDestinationSlot rejects placing into a slot outside the container's capacity.
+func checkDestinationSlot(slotType string, slot, capacity int) error {
+	if slot < 0 || slot >= capacity {
+		if slotType == "inventory" {
+			return fmt.Errorf("slot %d is beyond your bag's capacity (%d slots)", slot, capacity)
+		}
+		return fmt.Errorf("slot %d is beyond your general slots (%d)", slot, capacity)
+	}
+	return nil
+}
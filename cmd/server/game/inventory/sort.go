@@ -0,0 +1,100 @@
+package inventory
+
+import (
+	"fmt"
+	"sort"
+
+	"pubkey-quest/cmd/server/db"
+	"pubkey-quest/types"
+)
+
+// HandleSortInventoryAction tidies the general slots and the backpack: stacks of
+// the same item are merged up to their max stack (ConsolidateStacks), occupied
+// slots are packed to the front of their own list, and with "sort": true each
+// list is ordered by item type then name. Items never cross between the general
+// slots and the backpack, so a container can't end up in the backpack, and the
+// equipped gear slots are left untouched. The reorganized slots reach the client
+// through the session's inventory delta.
+func HandleSortInventoryAction(state *types.SaveFile, params map[string]interface{}) (*types.GameActionResponse, error) {
+	if state == nil || state.Inventory == nil {
+		return nil, fmt.Errorf("no inventory")
+	}
+	sortItems, _ := params["sort"].(bool)
+
+	freed := ConsolidateStacks(state)
+
+	generalSlots, _, err := playerSlots(state, "general")
+	if err != nil {
+		return nil, err
+	}
+	compactSlots(generalSlots, sortItems)
+	// No bag, no backpack to tidy.
+	if backpackSlots, _, err := playerSlots(state, "inventory"); err == nil {
+		compactSlots(backpackSlots, sortItems)
+	}
+
+	message := "Inventory tidied"
+	if sortItems {
+		message = "Inventory sorted"
+	}
+	if freed > 0 {
+		message += fmt.Sprintf(" (%d stack(s) merged)", freed)
+	}
+	return &types.GameActionResponse{
+		Success: true,
+		Message: message,
+		Color:   "green",
+	}, nil
+}
+
+// compactSlots moves the occupied slots of one list to the front, in their
+// current order or (sortItems) by item type then name, and renumbers every slot.
+// Whole slot maps move, so anything attached to a slot (a quiver's contents)
+// travels with it.
+func compactSlots(slots []interface{}, sortItems bool) {
+	var filled []map[string]interface{}
+	for _, entry := range slots {
+		if slotMap, ok := entry.(map[string]interface{}); ok {
+			if itemID, _ := slotMap["item"].(string); itemID != "" {
+				filled = append(filled, slotMap)
+			}
+		}
+	}
+
+	if sortItems {
+		type sortKey struct{ itemType, name string }
+		keys := map[string]sortKey{}
+		keyOf := func(slotMap map[string]interface{}) sortKey {
+			itemID, _ := slotMap["item"].(string)
+			if key, ok := keys[itemID]; ok {
+				return key
+			}
+			key := sortKey{name: itemID}
+			if itemData, err := db.GetItemByID(itemID); err == nil {
+				key = sortKey{itemType: itemData.Type, name: itemData.Name}
+			}
+			keys[itemID] = key
+			return key
+		}
+		sort.SliceStable(filled, func(i, j int) bool {
+			a, b := keyOf(filled[i]), keyOf(filled[j])
+			if a.itemType != b.itemType {
+				return a.itemType < b.itemType
+			}
+			return a.name < b.name
+		})
+	}
+
+	for i := range slots {
+		if i < len(filled) {
+			filled[i]["slot"] = i
+			slots[i] = filled[i]
+			continue
+		}
+		slots[i] = map[string]interface{}{
+			"item":     nil,
+			"quantity": 0,
+			"slot":     i,
+		}
+	}
+}
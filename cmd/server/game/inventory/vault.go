@@ -0,0 +1,285 @@
+package inventory
+
+import (
+	"fmt"
+	"log"
+
+	"pubkey-quest/cmd/server/game/vault"
+	"pubkey-quest/types"
+)
+
+// Vault deposit/withdraw — the single-click moves between the player's
+// inventory and the vault of the building they're in. The server picks the
+// slot on the other side and hands off to HandleMoveItemAction, so both stores
+// are validated and updated exactly as a drag would.
+
+// HandleVaultDepositAction moves the item in from_slot/from_slot_type
+// (general or inventory) into the first open slot of the building's vault.
+func HandleVaultDepositAction(state *types.SaveFile, params map[string]interface{}) (*types.GameActionResponse, error) {
+	fromSlotType, _ := params["from_slot_type"].(string)
+	fromSlotF, ok := params["from_slot"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("missing from_slot")
+	}
+	fromSlot := int(fromSlotF)
+	if fromSlotType != "general" && fromSlotType != "inventory" {
+		return nil, fmt.Errorf("invalid from_slot_type: %s", fromSlotType)
+	}
+
+	vaultData := vault.GetVaultForLocation(state, state.Building)
+	if vaultData == nil {
+		return &types.GameActionResponse{
+			Success:   false,
+			Error:     "You have no vault here",
+			ErrorCode: types.ErrCodeNoVault,
+			Color:     "red",
+		}, nil
+	}
+
+	slots, _, err := playerSlots(state, fromSlotType)
+	if err != nil {
+		return nil, err
+	}
+	itemID, err := slotItemAt(slots, fromSlot, params)
+	if err != nil {
+		return nil, err
+	}
+
+	vaultSlots, ok := vaultData["slots"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid vault slots")
+	}
+	toSlot := firstEmptySlot(vaultSlots, len(vaultSlots))
+	if toSlot < 0 {
+		return &types.GameActionResponse{
+			Success:   false,
+			Error:     fmt.Sprintf("Your vault is full (%d slots)", len(vaultSlots)),
+			ErrorCode: types.ErrCodeVaultFull,
+			Color:     "red",
+		}, nil
+	}
+
+	resp, err := HandleMoveItemAction(state, map[string]interface{}{
+		"item_id":        itemID,
+		"from_slot":      float64(fromSlot),
+		"from_slot_type": fromSlotType,
+		"to_slot":        float64(toSlot),
+		"to_slot_type":   "vault",
+		"vault_building": state.Building,
+	})
+	if err != nil || resp == nil || !resp.Success {
+		return resp, err
+	}
+	log.Printf("🏦 Deposited %s into vault[%d] at %s", itemID, toSlot, state.Building)
+	resp.Message = "Item stored in vault"
+	resp.Color = "green"
+	return resp, nil
+}
+
+// HandleVaultWithdrawAction moves the item in vault slot from_slot into the
+// first open backpack slot, or the first open general slot when the backpack
+// is full or can't hold it (containers).
+func HandleVaultWithdrawAction(state *types.SaveFile, params map[string]interface{}) (*types.GameActionResponse, error) {
+	fromSlotF, ok := params["from_slot"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("missing from_slot")
+	}
+	fromSlot := int(fromSlotF)
+
+	vaultData := vault.GetVaultForLocation(state, state.Building)
+	if vaultData == nil {
+		return &types.GameActionResponse{
+			Success:   false,
+			Error:     "You have no vault here",
+			ErrorCode: types.ErrCodeNoVault,
+			Color:     "red",
+		}, nil
+	}
+	vaultSlots, ok := vaultData["slots"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid vault slots")
+	}
+	itemID, err := slotItemAt(vaultSlots, fromSlot, params)
+	if err != nil {
+		return nil, err
+	}
+
+	toSlotType, toSlot := "", -1
+	if checkBackpackAllowed(itemID) == nil {
+		backpack, capacity, err := playerSlots(state, "inventory")
+		if err == nil {
+			toSlotType, toSlot = "inventory", firstEmptySlot(backpack, capacity)
+		}
+	}
+	if toSlot < 0 {
+		general, capacity, err := playerSlots(state, "general")
+		if err != nil {
+			return nil, err
+		}
+		toSlotType, toSlot = "general", firstEmptySlot(general, capacity)
+	}
+	if toSlot < 0 {
+		return &types.GameActionResponse{
+			Success:   false,
+			Error:     "No free inventory space",
+			ErrorCode: types.ErrCodeInventoryFull,
+			Color:     "red",
+		}, nil
+	}
+
+	resp, err := HandleMoveItemAction(state, map[string]interface{}{
+		"item_id":        itemID,
+		"from_slot":      float64(fromSlot),
+		"from_slot_type": "vault",
+		"to_slot":        float64(toSlot),
+		"to_slot_type":   toSlotType,
+		"vault_building": state.Building,
+	})
+	if err != nil || resp == nil || !resp.Success {
+		return resp, err
+	}
+	log.Printf("🏦 Withdrew %s from vault[%d] to %s[%d]", itemID, fromSlot, toSlotType, toSlot)
+	resp.Message = "Item withdrawn from vault"
+	resp.Color = "green"
+	return resp, nil
+}
+
+// slotItemAt returns the item in slots[index], checked against the optional
+// item_id param so a stale click can't move whatever is there now.
+func slotItemAt(slots []interface{}, index int, params map[string]interface{}) (string, error) {
+	if index < 0 || index >= len(slots) {
+		return "", fmt.Errorf("invalid slot: %d", index)
+	}
+	slot, _ := slots[index].(map[string]interface{})
+	itemID, _ := slot["item"].(string)
+	if itemID == "" {
+		return "", fmt.Errorf("slot %d is empty", index)
+	}
+	if want, _ := params["item_id"].(string); want != "" && want != itemID {
+		return "", fmt.Errorf("slot %d holds %s, not %s", index, itemID, want)
+	}
+	return itemID, nil
+}
+
+// firstEmptySlot returns the index of the first empty slot among the first
+// capacity slots, or -1 if they're all taken.
+func firstEmptySlot(slots []interface{}, capacity int) int {
+	for i := 0; i < capacity && i < len(slots); i++ {
+		slot, ok := slots[i].(map[string]interface{})
+		if !ok {
+			return i
+		}
+		if itemID, _ := slot["item"].(string); itemID == "" {
+			return i
+		}
+	}
+	return -1
+}
+
+// VaultTransfer is one stack in a bulk vault transfer summary.
+type VaultTransfer struct {
+	Item     string `json:"item"`
+	Quantity int    `json:"quantity"`
+}
+
+// HandleVaultStoreAllAction deposits every stack in the backpack (or, with
+// from_slot_type "general", the general slots) into the vault until it's full.
+// Data lists what moved and what didn't fit.
+func HandleVaultStoreAllAction(state *types.SaveFile, params map[string]interface{}) (*types.GameActionResponse, error) {
+	fromSlotType, _ := params["from_slot_type"].(string)
+	if fromSlotType == "" {
+		fromSlotType = "inventory"
+	}
+	if fromSlotType != "general" && fromSlotType != "inventory" {
+		return nil, fmt.Errorf("invalid from_slot_type: %s", fromSlotType)
+	}
+	if vault.GetVaultForLocation(state, state.Building) == nil {
+		return &types.GameActionResponse{
+			Success:   false,
+			Error:     "You have no vault here",
+			ErrorCode: types.ErrCodeNoVault,
+			Color:     "red",
+		}, nil
+	}
+
+	slots, _, err := playerSlots(state, fromSlotType)
+	if err != nil {
+		return nil, err
+	}
+	return bulkVaultTransfer(state, "Stored", slots, func(i int) (*types.GameActionResponse, error) {
+		return HandleVaultDepositAction(state, map[string]interface{}{
+			"from_slot":      float64(i),
+			"from_slot_type": fromSlotType,
+		})
+	})
+}
+
+// HandleVaultTakeAllAction withdraws every stack in the vault, each to the
+// first open backpack slot then general slot, until the player has no room.
+// Data lists what moved and what didn't fit.
+func HandleVaultTakeAllAction(state *types.SaveFile, _ map[string]interface{}) (*types.GameActionResponse, error) {
+	vaultData := vault.GetVaultForLocation(state, state.Building)
+	if vaultData == nil {
+		return &types.GameActionResponse{
+			Success:   false,
+			Error:     "You have no vault here",
+			ErrorCode: types.ErrCodeNoVault,
+			Color:     "red",
+		}, nil
+	}
+	slots, ok := vaultData["slots"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid vault slots")
+	}
+	return bulkVaultTransfer(state, "Took", slots, func(i int) (*types.GameActionResponse, error) {
+		return HandleVaultWithdrawAction(state, map[string]interface{}{"from_slot": float64(i)})
+	})
+}
+
+// bulkVaultTransfer runs move on every occupied slot of source, in order, and
+// sums up what moved and what was refused. A slot that fails to move is left
+// where it was; the rest still go.
+func bulkVaultTransfer(state *types.SaveFile, verb string, source []interface{}, move func(int) (*types.GameActionResponse, error)) (*types.GameActionResponse, error) {
+	moved, left := []VaultTransfer{}, []VaultTransfer{}
+	for i := range source {
+		slot, _ := source[i].(map[string]interface{})
+		itemID, _ := slot["item"].(string)
+		if itemID == "" {
+			continue
+		}
+		stack := VaultTransfer{Item: itemID, Quantity: slotQuantity(slot)}
+		resp, err := move(i)
+		if err != nil || resp == nil || !resp.Success {
+			if err != nil {
+				log.Printf("⚠️ Vault bulk transfer skipped %s: %v", itemID, err)
+			}
+			left = append(left, stack)
+			continue
+		}
+		moved = append(moved, stack)
+	}
+
+	message := fmt.Sprintf("%s %d item(s)", verb, len(moved))
+	color := "green"
+	if len(left) > 0 {
+		message += fmt.Sprintf("; %d didn't fit", len(left))
+		color = "yellow"
+	}
+	if len(moved) == 0 && len(left) == 0 {
+		message, color = "Nothing to move", "yellow"
+	}
+	log.Printf("🏦 Vault bulk transfer at %s: %d moved, %d left", state.Building, len(moved), len(left))
+
+	return &types.GameActionResponse{
+		Success: len(moved) > 0 || len(left) == 0,
+		Message: message,
+		Color:   color,
+		Delta: map[string]interface{}{
+			"vault_data": vault.VaultDelta(vault.GetVaultForLocation(state, state.Building)),
+		},
+		Data: map[string]interface{}{
+			"moved": moved,
+			"left":  left,
+		},
+	}, nil
+}
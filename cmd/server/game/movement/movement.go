@@ -7,6 +7,7 @@ import (
 
 	"pubkey-quest/cmd/server/db"
 	"pubkey-quest/cmd/server/game/building"
+	"pubkey-quest/cmd/server/game/effects"
 	"pubkey-quest/cmd/server/game/events"
 	"pubkey-quest/cmd/server/game/gameutil"
 	"pubkey-quest/types"
@@ -26,6 +27,7 @@ func HandleMoveAction(state *types.SaveFile, params map[string]interface{}) (*ty
 	state.Location = location
 	state.District = district
 	state.Building = building
+	effects.ClearStaleScopedEffects(state)
 
 	// Add to discovered locations if not already there
 	if !slices.Contains(state.LocationsDiscovered, location) {
@@ -97,12 +99,22 @@ func HandleEnterBuildingAction(state *types.SaveFile, params map[string]interfac
 		state.Room = defaultRoom
 	}
 
+	// Apply whatever the building itself imposes while inside (a smoky tavern,
+	// a temple's calm). Removed again on exit.
+	var applied []types.EffectMessage
+	if effectIDs, effErr := building.GetBuildingEffects(database, state.Location, buildingID); effErr == nil && len(effectIDs) > 0 {
+		applied = effects.ApplyScopedEffects(state, effects.BuildingSource(state.Location, buildingID), effectIDs)
+	}
+
 	log.Printf("🏛️ Entered building: %s (room: %q)", buildingID, state.Room)
 
 	return &types.GameActionResponse{
 		Success: true,
 		Message: "Entered building",
 		Color:   "blue",
+		Data: map[string]interface{}{
+			"effects_applied": applied,
+		},
 	}, nil
 }
 
@@ -145,6 +157,12 @@ func HandleMoveToRoomAction(state *types.SaveFile, params map[string]interface{}
 
 // HandleExitBuildingAction exits a building
 func HandleExitBuildingAction(state *types.SaveFile, _ map[string]interface{}) (*types.GameActionResponse, error) {
+	// Building effects end at the door.
+	var removed []string
+	if state.Building != "" {
+		removed = effects.RemoveScopedEffects(state, effects.BuildingSource(state.Location, state.Building))
+	}
+
 	// Update state to remove building (back outdoors)
 	state.Building = ""
 	state.Room = ""
@@ -161,5 +179,8 @@ func HandleExitBuildingAction(state *types.SaveFile, _ map[string]interface{}) (
 		Success: true,
 		Message: message,
 		Color:   "blue",
+		Data: map[string]interface{}{
+			"effects_removed": removed,
+		},
 	}, nil
 }
@@ -148,8 +148,17 @@ type SessionProvider interface {
 	SetRentedRooms(rooms []map[string]interface{})
 }
 
+// QuestActions starts and hands in quests for the "start_quest" and
+// "turn_in_quest" dialogue actions. The API layer supplies it: accepting a quest
+// needs the requirement context and handing one in pays rewards from the
+// advancement table.
+type QuestActions interface {
+	StartQuest(state *types.SaveFile, questID string) error
+	TurnInQuest(state *types.SaveFile, questID string) error
+}
+
 // HandleNPCDialogueChoiceActionWithSession processes dialogue with session access
-func HandleNPCDialogueChoiceActionWithSession(state *types.SaveFile, params map[string]interface{}, session SessionProvider) (*types.GameActionResponse, error) {
+func HandleNPCDialogueChoiceActionWithSession(state *types.SaveFile, params map[string]interface{}, session SessionProvider, quests QuestActions) (*types.GameActionResponse, error) {
 	npcID, _ := params["npc_id"].(string)
 	choice, _ := params["choice"].(string)
 
@@ -197,15 +206,9 @@ func HandleNPCDialogueChoiceActionWithSession(state *types.SaveFile, params map[
 	switch action {
 	case "register_storage":
 		cost, _ := choiceNode["cost"].(float64)
-		goldAmount := gameutil.GetGoldQuantity(state)
-		if goldAmount >= int(cost) {
-			if gameutil.DeductGold(state, int(cost)) {
-				vault.RegisterVault(state, state.Building)
-				actionResult, _ = choiceNode["success"].(string)
-			} else {
-				log.Printf("⚠️ Failed to deduct gold even though we had enough")
-				actionResult, _ = choiceNode["failure"].(string)
-			}
+		if gameutil.SpendGold(state, int(cost)) {
+			vault.RegisterVault(state, state.Building)
+			actionResult, _ = choiceNode["success"].(string)
 		} else {
 			actionResult, _ = choiceNode["failure"].(string)
 		}
@@ -241,7 +244,7 @@ func HandleNPCDialogueChoiceActionWithSession(state *types.SaveFile, params map[
 			Message: responseText,
 			Color:   "yellow",
 			Delta: map[string]interface{}{
-				"open_vault": vaultData,
+				"open_vault": vault.VaultDelta(vaultData),
 				"npc_dialogue": map[string]interface{}{
 					"action": "close",
 				},
@@ -279,6 +282,25 @@ func HandleNPCDialogueChoiceActionWithSession(state *types.SaveFile, params map[
 			},
 		}, nil
 
+	case "start_quest", "turn_in_quest":
+		// The node's "quest" names the quest; "success"/"failure" are the lines
+		// shown, falling back to the reason it failed.
+		questID, _ := choiceNode["quest"].(string)
+		err := fmt.Errorf("quests are not available here")
+		if quests != nil {
+			if action == "start_quest" {
+				err = quests.StartQuest(state, questID)
+			} else {
+				err = quests.TurnInQuest(state, questID)
+			}
+		}
+		if err == nil {
+			log.Printf("📜 %s: %s %s", npcID, action, questID)
+			actionResult, _ = choiceNode["success"].(string)
+		} else if actionResult, _ = choiceNode["failure"].(string); actionResult == "" {
+			actionResult = err.Error()
+		}
+
 	case "book_show":
 		return handleBookShowDialogue(state, session, npcID, npcData, choiceNode, responseText)
 
@@ -291,8 +291,8 @@ func HandlePlayShowAction(state *types.SaveFile, session PerformShowSessionProvi
 	totalGold := baseGold + (charismaMod2 * charismaBonus)
 
 	// Add gold to inventory (always get paid)
-	if err := gameutil.AddGoldToInventory(state.Inventory, totalGold); err != nil {
-		log.Printf("⚠️ Failed to add gold to inventory: %v", err)
+	if !gameutil.AddGold(state, totalGold) {
+		log.Printf("⚠️ Failed to add gold to inventory: no room")
 	}
 
 	// Only award XP on successful performance
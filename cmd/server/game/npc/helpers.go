@@ -3,7 +3,9 @@ package npc
 import (
 	"slices"
 
+	"pubkey-quest/cmd/server/db"
 	"pubkey-quest/cmd/server/game/gameutil"
+	"pubkey-quest/cmd/server/game/quest"
 	"pubkey-quest/cmd/server/game/vault"
 	"pubkey-quest/types"
 )
@@ -56,5 +58,25 @@ func CheckDialogueRequirements(state *types.SaveFile, requirements map[string]in
 		}
 	}
 
+	// Quest gates name a quest id: in progress, finished, never begun, or with
+	// its current stage ready to hand in.
+	if questID, ok := requirements["quest_active"].(string); ok && !quest.IsActive(state, questID) {
+		return false
+	}
+	if questID, ok := requirements["quest_completed"].(string); ok && !quest.IsCompleted(state, questID) {
+		return false
+	}
+	if questID, ok := requirements["quest_not_started"].(string); ok {
+		if quest.IsActive(state, questID) || quest.IsCompleted(state, questID) {
+			return false
+		}
+	}
+	if questID, ok := requirements["quest_ready"].(string); ok {
+		q, err := db.GetQuestByID(questID)
+		if err != nil || q == nil || quest.ReadyToTurnIn(state, q) != nil {
+			return false
+		}
+	}
+
 	return true
 }
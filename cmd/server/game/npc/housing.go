@@ -8,6 +8,7 @@ import (
 	"pubkey-quest/cmd/server/db"
 	"pubkey-quest/cmd/server/game/building"
 	"pubkey-quest/cmd/server/game/effects"
+	"pubkey-quest/cmd/server/game/gametime"
 	"pubkey-quest/cmd/server/game/gameutil"
 	"pubkey-quest/cmd/server/game/status"
 	"pubkey-quest/types"
@@ -33,17 +34,13 @@ func HandleRentRoomAction(state *types.SaveFile, _ SessionProvider, params map[s
 		return &types.GameActionResponse{Success: false, Message: "You already have a room rented here.", Color: "yellow"}, nil
 	}
 
-	goldAmount := gameutil.GetGoldQuantity(state)
-	if goldAmount < cost {
+	if !gameutil.SpendGold(state, cost) {
 		return &types.GameActionResponse{
 			Success: false,
-			Message: fmt.Sprintf("You need %d gold to rent a room. You have %d gold.", cost, goldAmount),
+			Message: fmt.Sprintf("You need %d gold to rent a room. You have %d gold.", cost, gameutil.GetGoldQuantity(state)),
 			Color:   "red",
 		}, nil
 	}
-	if !gameutil.DeductGold(state, cost) {
-		return &types.GameActionResponse{Success: false, Message: "Failed to deduct gold for room rental", Color: "red"}, nil
-	}
 
 	// Rental holds through the end of the next day.
 	expiresDay := state.CurrentDay + 1
@@ -86,7 +83,7 @@ func CanSleepNow(timeOfDay int) bool {
 // for the building, standing in the rented-state room (when the building has
 // rooms), and a time after 9 PM. The rental persists until it expires — sleeping
 // doesn't consume it.
-func HandleSleepAction(state *types.SaveFile, session SleepSessionProvider, npcIdsFunc func(string, string, string, string, int) []string) (*types.GameActionResponse, error) {
+func HandleSleepAction(state *types.SaveFile, session SleepSessionProvider, npcIdsFunc func(string, string, string, string, int) []string, interrupt gametime.WaitInterrupt) (*types.GameActionResponse, error) {
 	buildingID := state.Building
 	if buildingID == "" {
 		log.Printf("😴 sleep rejected: not in a building (location=%s room=%q)", state.Location, state.Room)
@@ -122,7 +119,7 @@ func HandleSleepAction(state *types.SaveFile, session SleepSessionProvider, npcI
 	}
 
 	// Inn/tavern: a real bed and breakfast in the fee — best comfort, hunger restored.
-	return applySleep(state, comfortInn, true, session, npcIdsFunc)
+	return applySleep(state, comfortInn, true, session, npcIdsFunc, interrupt)
 }
 
 // comfort tiers for sleeping — bed quality drives how much fatigue a night restores.
@@ -136,7 +133,7 @@ const (
 // environment (not a town). A bedroll makes for a decent camp; without one it's a
 // rough sleep that recovers less fatigue. No breakfast out here, so hunger isn't
 // restored — you wake a little hungrier. Same night-time gate as an inn.
-func HandleWildernessSleepAction(state *types.SaveFile, session SleepSessionProvider, npcIdsFunc func(string, string, string, string, int) []string) (*types.GameActionResponse, error) {
+func HandleWildernessSleepAction(state *types.SaveFile, session SleepSessionProvider, npcIdsFunc func(string, string, string, string, int) []string, interrupt gametime.WaitInterrupt) (*types.GameActionResponse, error) {
 	if state.Building != "" {
 		return &types.GameActionResponse{Success: false, Message: "You're indoors — rent a room to sleep here.", Color: "yellow"}, nil
 	}
@@ -151,25 +148,45 @@ func HandleWildernessSleepAction(state *types.SaveFile, session SleepSessionProv
 	if gameutil.PlayerHasItem(state, "bedroll") {
 		comfort = comfortBedroll
 	}
-	return applySleep(state, comfort, false, session, npcIdsFunc)
+	return applySleep(state, comfort, false, session, npcIdsFunc, interrupt)
 }
 
 // applySleep is the shared sleep resolution: sleep until the 6 AM wake time,
 // restore HP/mana in proportion to how long was slept (so it scales with level),
 // restore fatigue scaled by bed comfort × bedtime × hours, optionally restore
-// hunger (paid lodging only), then advance time and refresh the world.
-func applySleep(state *types.SaveFile, comfort float64, restoreHunger bool, session SleepSessionProvider, npcIdsFunc func(string, string, string, string, int) []string) (*types.GameActionResponse, error) {
+// hunger (paid lodging only), then advance time and refresh the world. The
+// night passes in gametime.RestStepMinutes steps; when interrupt returns a
+// reason the player wakes there, and only the time actually slept counts.
+func applySleep(state *types.SaveFile, comfort float64, restoreHunger bool, session SleepSessionProvider, npcIdsFunc func(string, string, string, string, int) []string, interrupt gametime.WaitInterrupt) (*types.GameActionResponse, error) {
 	oldTime := state.TimeOfDay
 
 	// Sleep until 6 AM (next day if already past it).
-	var minutesSlept int
+	var night int
 	if oldTime >= sleepWakeTime {
-		minutesSlept = (1440 - oldTime) + sleepWakeTime
-		state.CurrentDay++
+		night = (1440 - oldTime) + sleepWakeTime
 	} else {
-		minutesSlept = sleepWakeTime - oldTime
+		night = sleepWakeTime - oldTime
+	}
+
+	// Duration-based buffs/debuffs expire over the time slept.
+	var minutesSlept int
+	var woken string
+	for minutesSlept < night && woken == "" {
+		step := gametime.RestStepMinutes
+		if night-minutesSlept < step {
+			step = night - minutesSlept
+		}
+		state.TimeOfDay += step
+		if state.TimeOfDay >= 1440 {
+			state.TimeOfDay -= 1440
+			state.CurrentDay++
+		}
+		effects.TickDownEffectDurations(state, step)
+		minutesSlept += step
+		if interrupt != nil {
+			woken = interrupt(state, step)
+		}
 	}
-	state.TimeOfDay = sleepWakeTime
 
 	// Fraction of a full night actually slept (0..1) — drives restore amounts.
 	frac := float64(minutesSlept) / float64(status.FullRestMinutes)
@@ -183,9 +200,6 @@ func applySleep(state *types.SaveFile, comfort float64, restoreHunger bool, sess
 		bedtime = 0.7
 	}
 
-	// Duration-based buffs/debuffs expire over the time slept.
-	effects.TickDownEffectDurations(state, minutesSlept)
-
 	// HP/mana — proportional to time slept, scaled to Max (so it scales with level).
 	hpGain, manaGain := status.RestoreVitalsForRest(state, minutesSlept)
 
@@ -195,12 +209,14 @@ func applySleep(state *types.SaveFile, comfort float64, restoreHunger bool, sess
 	if state.Fatigue < 0 {
 		state.Fatigue = 0
 	}
+	// Waking starts a fresh day awake: accumulation restarts (it stops at max
+	// fatigue) from an empty accumulator, and the tired penalties follow.
+	status.HandleFatigueChange(state)
 	status.ResetFatigueAccumulator(state)
-	status.UpdateFatiguePenaltyEffects(state)
 
-	// Hunger — only paid lodging includes breakfast. Out in the wild the night's
-	// calorie burn leaves you a step hungrier.
-	if restoreHunger {
+	// Hunger — only paid lodging includes breakfast, and only if you sleep
+	// through to it. Otherwise the night's calorie burn leaves you a step hungrier.
+	if restoreHunger && woken == "" {
 		state.Hunger = 2 // Satisfied
 		status.ResetHungerAccumulator(state)
 		status.UpdateHungerPenaltyEffects(state)
@@ -210,8 +226,8 @@ func applySleep(state *types.SaveFile, comfort float64, restoreHunger bool, sess
 		status.UpdateHungerPenaltyEffects(state)
 		status.EnsureHungerAccumulation(state)
 	}
-	log.Printf("😴 slept %dm (comfort=%.2f bedtime=%.2f) → +%d HP +%d mana, fatigue=%d hunger=%d",
-		minutesSlept, comfort, bedtime, hpGain, manaGain, state.Fatigue, state.Hunger)
+	log.Printf("😴 slept %d/%dm (comfort=%.2f bedtime=%.2f) → +%d HP +%d mana, fatigue=%d hunger=%d (woken: %q)",
+		minutesSlept, night, comfort, bedtime, hpGain, manaGain, state.Fatigue, state.Hunger, woken)
 
 	// Refresh building states + NPCs after the time jump.
 	if database := db.GetDB(); database != nil {
@@ -226,7 +242,11 @@ func applySleep(state *types.SaveFile, comfort float64, restoreHunger bool, sess
 	delta := session.UpdateSnapshotAndCalculateDeltaProvider()
 
 	msg := fmt.Sprintf("You sleep %dh and wake at 6 AM", minutesSlept/60)
+	color := "green"
 	switch {
+	case woken != "":
+		msg = fmt.Sprintf("Your sleep is cut short after %dh %02dm — %s", minutesSlept/60, minutesSlept%60, woken)
+		color = "yellow"
 	case comfort >= comfortInn:
 		msg += ", warm and rested."
 	case comfort >= comfortBedroll:
@@ -237,22 +257,27 @@ func applySleep(state *types.SaveFile, comfort float64, restoreHunger bool, sess
 	if hpGain > 0 || manaGain > 0 {
 		msg += fmt.Sprintf(" (+%d HP, +%d mana)", hpGain, manaGain)
 	}
+	if woken != "" {
+		msg += "."
+	}
 
 	return &types.GameActionResponse{
 		Success: true,
 		Message: msg,
-		Color:   "green",
+		Color:   color,
 		Delta:   delta.ToMap(),
 		Data: map[string]interface{}{
-			"time_of_day": state.TimeOfDay,
-			"current_day": state.CurrentDay,
-			"fatigue":     state.Fatigue,
-			"hunger":      state.Hunger,
-			"hp":          state.HP,
-			"max_hp":      state.MaxHP,
-			"mana":        state.Mana,
-			"max_mana":    state.MaxMana,
-			"rentals":     state.Rentals,
+			"time_of_day":   state.TimeOfDay,
+			"current_day":   state.CurrentDay,
+			"fatigue":       state.Fatigue,
+			"hunger":        state.Hunger,
+			"hp":            state.HP,
+			"max_hp":        state.MaxHP,
+			"mana":          state.Mana,
+			"max_mana":      state.MaxMana,
+			"rentals":       state.Rentals,
+			"minutes_slept": minutesSlept,
+			"interrupted":   woken != "",
 		},
 	}, nil
 }
@@ -270,79 +295,3 @@ func isEnvironmentLocation(location string) bool {
 	}
 	return lt == "environment"
 }
-
-// HandleRestAction rests to restore HP/Mana (sleep in rented room)
-func HandleRestAction(state *types.SaveFile, _ map[string]interface{}) (*types.GameActionResponse, error) {
-	// Restore HP and Mana
-	state.HP = state.MaxHP
-	state.Mana = state.MaxMana
-
-	// Calculate sleep duration
-	// If it's before 6 AM, sleep until 6 AM
-	// If it's after 6 AM, sleep until 6 AM the next day
-	sleepMinutes := 0
-	targetTime := 6 * 60 // 6:00 AM
-
-	if state.TimeOfDay < targetTime {
-		// Sleep until 6 AM today
-		sleepMinutes = targetTime - state.TimeOfDay
-	} else {
-		// Sleep until 6 AM tomorrow
-		sleepMinutes = (1440 - state.TimeOfDay) + targetTime
-	}
-
-	// Clear fatigue and re-evaluate effects
-	state.Fatigue = 0
-	status.HandleFatigueChange(state)
-
-	// Advance time manually (can't call gametime.AdvanceTime due to circular dependency)
-	oldTime := state.TimeOfDay
-	state.TimeOfDay += sleepMinutes
-	if state.TimeOfDay >= 1440 {
-		daysAdvanced := state.TimeOfDay / 1440
-		state.CurrentDay += daysAdvanced
-		state.TimeOfDay = state.TimeOfDay % 1440
-	}
-
-	// Tick active effects (includes fatigue/hunger accumulation effects). Sleeping
-	// is rest — no fatigue accrual (it's zeroed on wake anyway); hunger still ticks.
-	timeMessages := effects.TickEffects(state, sleepMinutes, false)
-
-	// Update penalty effects based on current fatigue/hunger levels
-	fatigueMsg, _ := status.UpdateFatiguePenaltyEffects(state)
-	hungerMsg, _ := status.UpdateHungerPenaltyEffects(state)
-
-	// Build response with time messages
-	response := &types.GameActionResponse{
-		Success: true,
-		Message: fmt.Sprintf("You rest and sleep for %.1f hours. You wake up at 6:00 AM feeling refreshed.", float64(sleepMinutes)/60.0),
-		State:   state,
-	}
-
-	// Add effect messages to response data
-	effectMessages := []types.EffectMessage{}
-
-	// Append effect messages from time advancement
-	for _, msg := range timeMessages {
-		effectMessages = append(effectMessages, msg)
-	}
-
-	// Add any new penalty effect messages
-	if fatigueMsg != nil && !fatigueMsg.Silent {
-		effectMessages = append(effectMessages, *fatigueMsg)
-	}
-	if hungerMsg != nil && !hungerMsg.Silent {
-		effectMessages = append(effectMessages, *hungerMsg)
-	}
-
-	// Add to response data if we have messages
-	if len(effectMessages) > 0 {
-		response.Data = map[string]interface{}{
-			"effect_messages": effectMessages,
-		}
-	}
-
-	log.Printf("🛌 Player rested from %d to %d minutes (%.1f hours)", oldTime, state.TimeOfDay, float64(sleepMinutes)/60.0)
-
-	return response, nil
-}
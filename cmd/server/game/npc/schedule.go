@@ -1,6 +1,7 @@
 package npc
 
 import (
+	"pubkey-quest/cmd/server/game/gametime"
 	"pubkey-quest/types"
 	"strings"
 )
@@ -52,6 +53,18 @@ func DetermineLocationType(locationID string) string {
 
 // ResolveNPCSchedule returns current schedule state for an NPC
 func ResolveNPCSchedule(npc *types.NPCData, timeOfDay int) *types.NPCScheduleInfo {
+	if npc.DayOnly && gametime.IsNight(timeOfDay%1440) {
+		// Gone for the night — not at any location, not available
+		return &types.NPCScheduleInfo{
+			CurrentSlot:       nil,
+			IsAvailable:       false,
+			Location:          "",
+			State:             "away",
+			AvailableDialogue: []string{},
+			AvailableActions:  []string{},
+		}
+	}
+
 	currentSlot := GetCurrentScheduleSlot(npc.Schedule, timeOfDay)
 
 	if currentSlot == nil {
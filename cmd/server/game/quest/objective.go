@@ -51,7 +51,8 @@ func advanceObjectives(save *types.SaveFile, ev events.Event, lookup QuestLookup
 			changed = true
 		}
 
-		if changed && stageComplete(stage, qp.ObjectiveCounts) {
+		// A turn-in stage waits for the player to hand it in (see TurnIn).
+		if changed && !stage.TurnIn && stageComplete(stage, qp.ObjectiveCounts) {
 			completeStage(save, qp, quest, advancement)
 		}
 	}
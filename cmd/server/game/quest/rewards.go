@@ -21,9 +21,7 @@ func GrantReward(save *types.SaveFile, reward *types.POIReward, advancement []ty
 	if reward.XP > 0 {
 		character.GrantXP(save, reward.XP, advancement)
 	}
-	if reward.Gold > 0 {
-		_ = gameutil.AddGoldToInventory(save.Inventory, reward.Gold)
-	}
+	gameutil.AddGold(save, reward.Gold)
 	for _, item := range reward.Items {
 		qty := item.Quantity
 		if qty <= 0 {
@@ -0,0 +1,67 @@
+package quest
+
+import (
+	"fmt"
+
+	"pubkey-quest/cmd/server/game/gameutil"
+	"pubkey-quest/types"
+)
+
+// ReadyToTurnIn reports why the quest's current stage can't be handed in yet, or
+// nil when it can: the quest is active, the stage is a turn-in stage, the player
+// carries every fetch objective's items, and every other objective is done.
+func ReadyToTurnIn(save *types.SaveFile, q *types.QuestData) error {
+	qp := activeProgress(save, q.ID)
+	if qp == nil {
+		return fmt.Errorf("quest not active: %s", q.ID)
+	}
+	if qp.Stage >= len(q.Stages) || !q.Stages[qp.Stage].TurnIn {
+		return fmt.Errorf("nothing to hand in for %q yet", q.Name)
+	}
+	for j, obj := range q.Stages[qp.Stage].Objectives {
+		need := objectiveTarget(obj)
+		if obj.Type == types.ObjectiveFetch {
+			if have := gameutil.CountItem(save, obj.Target); have < need {
+				return fmt.Errorf("you still need %d %s (have %d)", need, obj.Target, have)
+			}
+			continue
+		}
+		if j >= len(qp.ObjectiveCounts) || qp.ObjectiveCounts[j] < need {
+			return fmt.Errorf("not done yet: %s", obj.Description)
+		}
+	}
+	return nil
+}
+
+// TurnIn hands in the current stage of an active quest: once ReadyToTurnIn
+// passes, the fetch objectives' items are taken, the stage's reward is paid,
+// and the quest advances (or moves to the completed list after its last stage).
+// Nothing changes on error.
+func TurnIn(save *types.SaveFile, questID string, lookup QuestLookup, advancement []types.AdvancementEntry) error {
+	q, err := lookup(questID)
+	if err != nil || q == nil {
+		return fmt.Errorf("quest not found: %s", questID)
+	}
+	if err := ReadyToTurnIn(save, q); err != nil {
+		return err
+	}
+	qp := activeProgress(save, questID)
+	for _, obj := range q.Stages[qp.Stage].Objectives {
+		if obj.Type == types.ObjectiveFetch {
+			gameutil.TakeItem(save, obj.Target, objectiveTarget(obj))
+		}
+	}
+	completeStage(save, qp, q, advancement)
+	pruneCompleted(save, lookup)
+	return nil
+}
+
+// activeProgress returns the progress record of an in-progress quest, or nil.
+func activeProgress(save *types.SaveFile, questID string) *types.QuestProgress {
+	for i := range save.QuestsActive {
+		if save.QuestsActive[i].QuestID == questID {
+			return &save.QuestsActive[i]
+		}
+	}
+	return nil
+}
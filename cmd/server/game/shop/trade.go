@@ -0,0 +1,210 @@
+package shop
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"pubkey-quest/cmd/server/db"
+	"pubkey-quest/cmd/server/game/effects"
+	"pubkey-quest/cmd/server/game/gameutil"
+	"pubkey-quest/cmd/server/game/inventory"
+	"pubkey-quest/types"
+)
+
+// Trade is the outcome of a buy or sell: how many items changed hands and the
+// gold that paid for them.
+type Trade struct {
+	ItemID   string
+	ItemName string
+	Quantity int
+	Gold     int
+}
+
+// SellSource says where the item being sold sits: a general or backpack
+// ("inventory") slot by index, or an equipped gear slot by name ("equipment").
+type SellSource struct {
+	SlotType string
+	Slot     int
+	GearSlot string
+}
+
+// Buy moves up to quantity of itemID from the merchant into the player's
+// inventory at the shop's buy price, given the merchant's current stock of it.
+// The whole order must be affordable; if only part of it fits in the inventory,
+// only that part is bought and paid for. Nothing changes on error.
+func Buy(save *types.SaveFile, shopConfig types.ShopConfig, itemID string, quantity, stock int) (*Trade, error) {
+	if quantity <= 0 {
+		return nil, fmt.Errorf("quantity must be positive")
+	}
+	stocked := false
+	for _, invItem := range shopConfig.Inventory {
+		if invItem.ItemID == itemID {
+			stocked = true
+			break
+		}
+	}
+	if !stocked {
+		return nil, types.NewActionError(types.ErrCodeItemNotFound, "item not in shop inventory")
+	}
+	if stock < quantity {
+		return nil, types.NewActionError(types.ErrCodeOutOfStock, "not enough stock (available: %d)", stock)
+	}
+
+	item, err := db.GetItemByID(itemID)
+	if err != nil {
+		return nil, types.NewActionError(types.ErrCodeItemNotFound, "item not found: %s", itemID)
+	}
+	price := CalculateBuyPrice(item.Value, shopConfig, charismaOf(save))
+	if gold := gameutil.GetGoldQuantity(save); gold < price*quantity {
+		return nil, types.NewActionError(types.ErrCodeInsufficientGold, "not enough gold (need %d, have %d)", price*quantity, gold)
+	}
+
+	added, err := inventory.AddItemToInventory(save, itemID, quantity)
+	if added == 0 {
+		if err == nil {
+			err = types.NewActionError(types.ErrCodeInventoryFull, "no room in inventory")
+		}
+		return nil, err
+	}
+	if !gameutil.SpendGold(save, price*added) {
+		return nil, fmt.Errorf("failed to deduct gold")
+	}
+	return &Trade{ItemID: itemID, ItemName: item.Name, Quantity: added, Gold: price * added}, nil
+}
+
+// Sell takes quantity of itemID out of the given slot and pays the shop's sell
+// price for it, as long as the merchant buys it and can afford it. Equipped gear
+// can be sold straight off the body, but a container that still holds items
+// can't be sold — empty it first. Nothing changes on error.
+func Sell(save *types.SaveFile, shopConfig types.ShopConfig, itemID string, quantity int, from SellSource, merchantGold int) (*Trade, error) {
+	if !shopConfig.BuysItems {
+		return nil, fmt.Errorf("this merchant doesn't buy items")
+	}
+	if quantity <= 0 {
+		return nil, fmt.Errorf("quantity must be positive")
+	}
+	// Specialty shops only buy items they stock
+	if shopConfig.ShopType == "specialty" {
+		stocked := false
+		for _, invItem := range shopConfig.Inventory {
+			if invItem.ItemID == itemID {
+				stocked = true
+				break
+			}
+		}
+		if !stocked {
+			return nil, fmt.Errorf("this specialty shop doesn't buy that type of item")
+		}
+	}
+
+	slotMap, err := sellSlot(save, from)
+	if err != nil {
+		return nil, err
+	}
+	if slotMap["item"] != itemID {
+		return nil, fmt.Errorf("item mismatch: expected %s, found %v", itemID, slotMap["item"])
+	}
+	if contents, ok := slotMap["contents"].([]interface{}); ok {
+		for _, entry := range contents {
+			if inner, ok := entry.(map[string]interface{}); ok {
+				if id, _ := inner["item"].(string); id != "" {
+					return nil, fmt.Errorf("empty the %s before selling it", itemID)
+				}
+			}
+		}
+	}
+	have := inventory.GetSlotQuantity(slotMap)
+	if have < quantity {
+		return nil, fmt.Errorf("not enough items: have %d, trying to sell %d", have, quantity)
+	}
+
+	item, err := db.GetItemByID(itemID)
+	if err != nil {
+		return nil, types.NewActionError(types.ErrCodeItemNotFound, "item not found: %s", itemID)
+	}
+	total := CalculateSellPrice(item.Value, shopConfig, charismaOf(save)) * quantity
+	if merchantGold < total {
+		return nil, types.NewActionError(types.ErrCodeInsufficientGold, "merchant doesn't have enough gold (needs %d, has %d)", total, merchantGold)
+	}
+
+	before := map[string]interface{}{}
+	for k, v := range slotMap {
+		before[k] = v
+	}
+	if have == quantity {
+		slotMap["item"] = nil
+		slotMap["quantity"] = 0
+		delete(slotMap, "contents")
+	} else {
+		slotMap["quantity"] = have - quantity
+	}
+	if !gameutil.AddGold(save, total) {
+		for k := range slotMap {
+			delete(slotMap, k)
+		}
+		for k, v := range before {
+			slotMap[k] = v
+		}
+		return nil, types.NewActionError(types.ErrCodeInventoryFull, "no room for the gold")
+	}
+
+	// Gear sold off the body takes its worn effects with it, as unequipping does.
+	if from.SlotType == "equipment" && have == quantity {
+		var properties map[string]interface{}
+		if err := json.Unmarshal([]byte(item.Properties), &properties); err == nil {
+			if worn, ok := properties["effects_when_worn"].([]interface{}); ok {
+				for _, effectID := range worn {
+					if id, ok := effectID.(string); ok {
+						effects.RemoveEffect(save, id)
+					}
+				}
+			}
+		}
+	}
+	return &Trade{ItemID: itemID, ItemName: item.Name, Quantity: quantity, Gold: total}, nil
+}
+
+// sellSlot returns the slot map the item is sold from.
+func sellSlot(save *types.SaveFile, from SellSource) (map[string]interface{}, error) {
+	if save.Inventory == nil {
+		return nil, fmt.Errorf("no inventory")
+	}
+	gearSlots, _ := save.Inventory["gear_slots"].(map[string]interface{})
+
+	var slots []interface{}
+	switch from.SlotType {
+	case "equipment":
+		slotMap, ok := gearSlots[from.GearSlot].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("nothing equipped in %s", from.GearSlot)
+		}
+		return slotMap, nil
+	case "general", "":
+		slots, _ = save.Inventory["general_slots"].([]interface{})
+	case "inventory":
+		bag, _ := gearSlots["bag"].(map[string]interface{})
+		slots, _ = bag["contents"].([]interface{})
+	default:
+		return nil, fmt.Errorf("invalid slot type: %s", from.SlotType)
+	}
+	if from.Slot < 0 || from.Slot >= len(slots) {
+		return nil, fmt.Errorf("invalid slot index: %d", from.Slot)
+	}
+	slotMap, ok := slots[from.Slot].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid slot data at index %d", from.Slot)
+	}
+	return slotMap, nil
+}
+
+// charismaOf returns the player's charisma with active effects folded in
+// (effects.EffectiveStats), the score shop prices key off; 10 when it's missing.
+func charismaOf(save *types.SaveFile) int {
+	switch cha := effects.EffectiveStats(save)["charisma"].(type) {
+	case float64:
+		return int(cha)
+	case int:
+		return cha
+	}
+	return 10
+}
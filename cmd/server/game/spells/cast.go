@@ -37,7 +37,7 @@ import (
 type Deps struct {
 	// RollD20 returns a raw d20 (1–20).
 	RollD20 func() int
-	// RollDice rolls a dice expression like "1d10" (crit doubles the dice count).
+	// RollDice rolls a dice expression like "1d10" (crit applies the crit damage rule).
 	RollDice func(expr string, crit bool) int
 	// ResolveMonsterDamage rolls damage and applies the monster's
 	// resistances/immunities/vulnerabilities, returning final damage (min 1 on a
@@ -114,9 +114,18 @@ func resolveCast(deps Deps, save *types.SaveFile, spell map[string]interface{},
 		}
 	}
 
+	// Only one concentration at a time: a second has to wait until the first is
+	// ended (re-casting the one already held just renews it). In a fight the
+	// combat package also checks the spell the fight is holding.
+	if boolField(spell, "concentration") {
+		ownEffect, _ := spellEffect(spellID)
+		if err := effects.CanConcentrate(save, ownEffect); err != nil {
+			return nil, err
+		}
+	}
+
 	shape := spellShape(spell)
-	needsTarget := shape == "attack" || shape == "auto" || shape == "save"
-	if needsTarget && target == nil {
+	if TargetsEnemy(spell) && target == nil {
 		return nil, fmt.Errorf("%s can only be cast on an enemy in combat", name)
 	}
 
@@ -194,18 +203,11 @@ func resolveCast(deps Deps, save *types.SaveFile, spell map[string]interface{},
 		res.SaveMade = made
 		res.Log = append(res.Log, fmt.Sprintf("  You cast %s (DC %d). %s rolls %d%s %s save.",
 			name, dc, target.Name, roll, mod(saveBonus), saveType))
-		dice := stringField(spell, "damage")
-		if dice != "" {
-			dtype := stringField(spell, "damage_type")
-			full := deps.ResolveMonsterDamage(dice, 0, dtype, false, target)
-			if made {
-				res.Damage = full / 2
-				res.Log = append(res.Log, fmt.Sprintf("  ✔ %s resists — %d %s damage (half).", target.Name, res.Damage, dtype))
-			} else {
-				res.Damage = full
-				res.Log = append(res.Log, fmt.Sprintf("  ✘ %s fails — %d %s damage.", target.Name, res.Damage, dtype))
-			}
-			res.DamageType = dtype
+		if stringField(spell, "damage") != "" {
+			dmg, line := saveDamage(deps, spell, made, target)
+			res.Damage = dmg
+			res.DamageType = stringField(spell, "damage_type")
+			res.Log = append(res.Log, line)
 		} else {
 			// Pure control/condition save (entangle, faerie-fire): the save
 			// resolves, but the condition itself lands in the M5 conditions pass.
@@ -225,13 +227,16 @@ func resolveCast(deps Deps, save *types.SaveFile, spell map[string]interface{},
 		res.Log = append(res.Log, fmt.Sprintf("  You cast %s, mending %d HP.", name, heal))
 
 	case "buff":
+		res.Log = append(res.Log, castNarrative(name, spell))
 		if effectID, ok := spellEffect(spellID); ok {
-			if err := effects.ApplyEffect(save, effectID); err == nil {
+			if msg, err := effects.ApplyEffectWithMessage(save, effectID); err == nil {
 				res.EffectID = effectID
 				res.Concentration = boolField(spell, "concentration")
+				if msg != nil && !msg.Silent && msg.Message != "" {
+					res.Log = append(res.Log, "  "+msg.Message)
+				}
 			}
 		}
-		res.Log = append(res.Log, castNarrative(name, spell))
 
 	default: // "utility"
 		res.Log = append(res.Log, castNarrative(name, spell))
@@ -240,6 +245,34 @@ func resolveCast(deps Deps, save *types.SaveFile, spell map[string]interface{},
 	return res, nil
 }
 
+// AreaSave resolves a save spell against one more creature caught in its area:
+// the creature rolls its own save against dc and takes the spell's damage, half
+// on a success. Returns the damage to apply, whether the save was made, and the
+// log lines. The caster's costs were paid by the Cast that hit the first target.
+func AreaSave(deps Deps, spell map[string]interface{}, dc int, target *types.MonsterInstance) (int, bool, []string) {
+	saveType := strings.ToLower(stringField(spell, "save_type"))
+	roll := deps.RollD20()
+	saveBonus := monsterSaveBonus(target, saveType)
+	made := roll+saveBonus >= dc
+	log := []string{fmt.Sprintf("  %s rolls %d%s %s save.", target.Name, roll, mod(saveBonus), saveType)}
+	if stringField(spell, "damage") == "" {
+		return 0, made, log
+	}
+	dmg, line := saveDamage(deps, spell, made, target)
+	return dmg, made, append(log, line)
+}
+
+// saveDamage rolls a save spell's damage against target, halved when the save
+// was made, and the log line reporting it.
+func saveDamage(deps Deps, spell map[string]interface{}, made bool, target *types.MonsterInstance) (int, string) {
+	dtype := stringField(spell, "damage_type")
+	full := deps.ResolveMonsterDamage(stringField(spell, "damage"), 0, dtype, false, target)
+	if made {
+		return full / 2, fmt.Sprintf("  ✔ %s resists — %d %s damage (half).", target.Name, full/2, dtype)
+	}
+	return full, fmt.Sprintf("  ✘ %s fails — %d %s damage.", target.Name, full, dtype)
+}
+
 // castNarrative returns a flavour line for buff/utility spells, preferring the
 // spell's `effect` prose and falling back to a plain "You cast X."
 func castNarrative(name string, spell map[string]interface{}) string {
@@ -270,6 +303,16 @@ func spellShape(spell map[string]interface{}) string {
 	return "utility"
 }
 
+// TargetsEnemy reports whether spell resolves against an enemy (the attack,
+// auto-hit and save shapes) rather than the caster.
+func TargetsEnemy(spell map[string]interface{}) bool {
+	switch spellShape(spell) {
+	case "attack", "auto", "save":
+		return true
+	}
+	return false
+}
+
 // spellEffect maps a spell id to an ActiveEffect def id, for buffs that have a
 // matching effect in game-data/effects. Unmapped buffs resolve as narrative
 // (no mechanical effect yet) — new effect defs (bless, mage-armor, …) extend
@@ -277,6 +320,7 @@ func spellShape(spell map[string]interface{}) string {
 var spellEffectByID = map[string]string{
 	"bless":       "blessed",
 	"haste":       "haste",
+	"light":       "revealing-light",
 	"regenerate":  "regeneration",
 }
 
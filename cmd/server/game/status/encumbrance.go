@@ -27,29 +27,25 @@ func GetItemWeightRecursive(slot map[string]interface{}) float64 {
 	return gameutil.GetItemWeightRecursive(slot)
 }
 
-// GetEncumbranceLevel returns the encumbrance category based on weight percentage
-func GetEncumbranceLevel(state *types.SaveFile) string {
-	totalWeight := CalculateTotalWeight(state)
-	capacity := CalculateWeightCapacity(state)
+// WeightPolicy delegates to the canonical gameutil implementation
+func WeightPolicy(source string) string {
+	return gameutil.WeightPolicy(source)
+}
 
-	if capacity <= 0 {
-		return "normal"
-	}
+// CarryableQuantity delegates to the canonical gameutil implementation
+func CarryableQuantity(state *types.SaveFile, itemID string, quantity int) int {
+	return gameutil.CarryableQuantity(state, itemID, quantity)
+}
 
-	percentage := (totalWeight / capacity) * 100
+// GetEncumbranceTier delegates to the canonical gameutil implementation
+func GetEncumbranceTier(state *types.SaveFile) gameutil.EncumbranceTier {
+	return gameutil.GetEncumbranceTier(state)
+}
 
-	switch {
-	case percentage <= 50:
-		return "light"
-	case percentage <= 100:
-		return "normal"
-	case percentage <= 150:
-		return "overweight"
-	case percentage <= 200:
-		return "encumbered"
-	default:
-		return "overloaded"
-	}
+// GetEncumbranceLevel returns the encumbrance tier's level (light, normal,
+// overweight, encumbered, overloaded) for the player's carried weight
+func GetEncumbranceLevel(state *types.SaveFile) string {
+	return gameutil.GetEncumbranceTier(state).Level
 }
 
 // UpdateEncumbrancePenaltyEffects applies appropriate penalty effects based on encumbrance level
@@ -37,3 +37,27 @@ func RestoreVitalsForRest(save *types.SaveFile, minutes int) (int, int) {
 	}
 	return save.HP - hpBefore, save.Mana - manaBefore
 }
+
+// Resting recovers less on an empty stomach or a tired body.
+const (
+	restHungryFactor      = 0.5  // Hungry (hunger 1) halves recovery; starving (0) stops it
+	restFatiguePenaltyPer = 0.05 // each point of fatigue takes 5% off
+)
+
+// RestRecoveryFactor scales how much a rest restores (1 = the full
+// RestoreVitalsForRest rate): a starving body has nothing to heal with, a hungry
+// one heals at half rate, and fatigue takes a little more off per point.
+func RestRecoveryFactor(save *types.SaveFile) float64 {
+	if save == nil || save.Hunger <= 0 {
+		return 0
+	}
+	factor := 1.0
+	if save.Hunger == 1 {
+		factor = restHungryFactor
+	}
+	factor *= 1 - restFatiguePenaltyPer*float64(save.Fatigue)
+	if factor < 0 {
+		return 0
+	}
+	return factor
+}
@@ -9,7 +9,9 @@ import (
 	"strings"
 
 	"pubkey-quest/cmd/server/db"
+	"pubkey-quest/cmd/server/game/effects"
 	"pubkey-quest/cmd/server/game/events"
+	"pubkey-quest/cmd/server/game/gameutil"
 	"pubkey-quest/types"
 )
 
@@ -22,6 +24,7 @@ type EnvironmentData struct {
 	Connects         []string `json:"connects"`
 	TravelTime       int      `json:"travel_time"` // Total minutes to traverse
 	TravelDifficulty string   `json:"travel_difficulty"`
+	Effects          []string `json:"effects,omitempty"` // Effect IDs active while traveling here, removed on leaving
 }
 
 // TravelEndpoints holds parsed origin and destination info
@@ -42,6 +45,7 @@ type TravelUpdate struct {
 	DestDistrict    string
 	NewlyDiscovered bool
 	MusicUnlocked   []string
+	EffectsRemoved  []string // Names of environment effects that ended on arrival
 	TravelProgress  float64
 }
 
@@ -304,6 +308,11 @@ func HandleStartTravel(state *types.SaveFile, params map[string]interface{}) (*t
 	state.TravelStopped = false
 	state.Building = ""
 
+	// Leaving the city drops anything its buildings applied; the environment's
+	// own effects take hold for the length of the journey.
+	effects.ClearStaleScopedEffects(state)
+	envEffects := effects.ApplyScopedEffects(state, effects.EnvironmentSource(envID), env.Effects)
+
 	// Unlock music track for this environment (if not already unlocked)
 	musicUnlocked := checkMusicUnlocks(state, envID)
 
@@ -334,6 +343,7 @@ func HandleStartTravel(state *types.SaveFile, params map[string]interface{}) (*t
 			"travel_progress":  0.0,
 			"music_unlocked":   musicUnlocked,
 			"newly_discovered": envDiscovered,
+			"effects_applied":  envEffects,
 		},
 	}, nil
 }
@@ -457,9 +467,11 @@ func MaybeAdvanceTravelProgress(state *types.SaveFile, minutesElapsed int) *Trav
 		}
 	}
 
-	// Advance progress proportionally (Athletics skill scaling applied)
+	// Advance progress proportionally (Athletics skill scaling applied), slowed
+	// by a heavy load — an overloaded player doesn't move at all.
 	progressIncrement := float64(minutesElapsed) / float64(env.TravelTime)
 	progressIncrement *= getTravelSpeedMultiplier(state.Stats)
+	progressIncrement *= gameutil.GetEncumbranceTier(state).TravelSpeed
 	state.TravelProgress += progressIncrement
 
 	// Check for arrival
@@ -492,6 +504,9 @@ func processArrival(state *types.SaveFile, env *EnvironmentData) *TravelUpdate {
 	state.TravelStopped = false
 	state.Building = ""
 
+	// Environment effects end at the city gate.
+	removedEffects := effects.RemoveScopedEffects(state, effects.EnvironmentSource(env.ID))
+
 	// Check if city is newly discovered
 	newlyDiscovered := false
 	if !slices.Contains(state.LocationsDiscovered, destCity) {
@@ -515,6 +530,7 @@ func processArrival(state *types.SaveFile, env *EnvironmentData) *TravelUpdate {
 		DestDistrict:    destDistrict,
 		NewlyDiscovered: newlyDiscovered,
 		MusicUnlocked:   musicUnlocked,
+		EffectsRemoved:  removedEffects,
 		TravelProgress:  0,
 	}
 }
@@ -3,28 +3,46 @@ package vault
 import (
 	"log"
 
+	"pubkey-quest/cmd/server/db"
+	"pubkey-quest/cmd/server/game/building"
 	"pubkey-quest/types"
 )
 
+// DefaultVaultSlots is the size of a vault in a building with no entry in
+// VaultSlotsByBuildingType.
+const DefaultVaultSlots = 40
+
+// VaultSlotsByBuildingType sets how many slots a newly registered vault gets,
+// keyed by the building's type (see building.GetBuildingType). A vault keeps
+// the size it was registered with.
+var VaultSlotsByBuildingType = map[string]int{
+	"bank":  60,
+	"vault": 50,
+}
+
+// VaultSlotCount returns the slot count for a vault registered at buildingID
+// in locationID.
+func VaultSlotCount(locationID, buildingID string) int {
+	database := db.GetDB()
+	if database == nil {
+		return DefaultVaultSlots
+	}
+	buildingType, err := building.GetBuildingType(database, locationID, buildingID)
+	if err != nil {
+		return DefaultVaultSlots
+	}
+	if slots, ok := VaultSlotsByBuildingType[buildingType]; ok && slots > 0 {
+		return slots
+	}
+	return DefaultVaultSlots
+}
+
 // IsVaultRegistered checks if a vault is registered at the specified building
 func IsVaultRegistered(state *types.SaveFile, buildingID string) bool {
 	if state.Vaults == nil {
 		return false
 	}
-	for _, vault := range state.Vaults {
-		// Check new format (building field)
-		if building, ok := vault["building"].(string); ok {
-			if building == buildingID {
-				return true
-			}
-		} else if location, ok := vault["location"].(string); ok {
-			// Check old format (location field) - match if we're at that location
-			if location == state.Location {
-				return true
-			}
-		}
-	}
-	return false
+	return GetVaultForLocation(state, buildingID) != nil
 }
 
 // RegisterVault registers a new vault at the specified building
@@ -40,9 +58,12 @@ func RegisterVault(state *types.SaveFile, buildingID string) {
 		}
 	}
 
-	// Create new vault with 40 empty slots
-	slots := make([]map[string]interface{}, 40)
-	for i := range 40 {
+	// Create new vault with empty slots, sized for the building. The slots are
+	// []interface{} like a vault loaded from a save, so moves work on them
+	// before the next reload.
+	count := VaultSlotCount(state.Location, buildingID)
+	slots := make([]interface{}, count)
+	for i := range count {
 		slots[i] = map[string]interface{}{
 			"slot":     i,
 			"item":     nil,
@@ -56,22 +77,31 @@ func RegisterVault(state *types.SaveFile, buildingID string) {
 	}
 
 	state.Vaults = append(state.Vaults, vault)
-	log.Printf("✅ Registered vault at %s", buildingID)
+	log.Printf("✅ Registered vault at %s (%d slots)", buildingID, count)
 }
 
-// GetVaultForLocation returns the vault for the specified building
+// GetVaultForLocation returns the vault for the specified building. Each
+// building has its own vault: what's deposited in one bank isn't in another.
 func GetVaultForLocation(state *types.SaveFile, buildingID string) map[string]interface{} {
-	if state.Vaults == nil {
+	if state.Vaults == nil || buildingID == "" {
 		return nil
 	}
 
 	for _, vault := range state.Vaults {
-		// Check new format (building field)
 		if building, ok := vault["building"].(string); ok && building == buildingID {
 			return vault
 		}
-		// Check old format (location field) - return if we're at that location
+	}
+
+	// Old saves keyed vaults by location. The first building in that location
+	// to open one takes it over, so it isn't shared with the rest of the town.
+	for _, vault := range state.Vaults {
+		if _, ok := vault["building"].(string); ok {
+			continue
+		}
 		if location, ok := vault["location"].(string); ok && location == state.Location {
+			vault["building"] = buildingID
+			log.Printf("🏦 Moved legacy vault at %s to building %s", location, buildingID)
 			return vault
 		}
 	}
@@ -79,24 +109,34 @@ func GetVaultForLocation(state *types.SaveFile, buildingID string) map[string]in
 	return nil
 }
 
-// HandleVaultDepositAction deposits items into vault (uses existing move_item action for vault transfers)
-func HandleVaultDepositAction(_ *types.SaveFile, _ map[string]interface{}) (*types.GameActionResponse, error) {
-	// Vaults work like containers - use the container system
-	// This is handled by frontend calling move_item or add_to_container with vault as destination
-	return &types.GameActionResponse{
-		Success: true,
-		Message: "Item deposited to vault",
-	}, nil
+// FreeSlots counts the empty slots in a vault.
+func FreeSlots(vault map[string]interface{}) int {
+	slots, _ := vault["slots"].([]interface{})
+	free := 0
+	for _, s := range slots {
+		slot, ok := s.(map[string]interface{})
+		if !ok {
+			free++
+			continue
+		}
+		if item, _ := slot["item"].(string); item == "" {
+			free++
+		}
+	}
+	return free
 }
 
-// HandleVaultWithdrawAction withdraws items from vault (uses existing move_item action for vault transfers)
-func HandleVaultWithdrawAction(_ *types.SaveFile, _ map[string]interface{}) (*types.GameActionResponse, error) {
-	// Vaults work like containers - use the container system
-	// This is handled by frontend calling move_item or remove_from_container with vault as source
-	return &types.GameActionResponse{
-		Success: true,
-		Message: "Item withdrawn from vault",
-	}, nil
+// VaultDelta is the vault as sent to the client: its slots plus how many it
+// holds and how many are free.
+func VaultDelta(vault map[string]interface{}) map[string]interface{} {
+	delta := make(map[string]interface{}, len(vault)+2)
+	for k, v := range vault {
+		delta[k] = v
+	}
+	slots, _ := vault["slots"].([]interface{})
+	delta["capacity"] = len(slots)
+	delta["free_slots"] = FreeSlots(vault)
+	return delta
 }
 
 // HandleRegisterVaultAction registers a vault (called after payment)
@@ -104,9 +144,10 @@ func HandleRegisterVaultAction(state *types.SaveFile, _ map[string]interface{})
 	buildingID := state.Building
 	if buildingID == "" {
 		return &types.GameActionResponse{
-			Success: false,
-			Error:   "not in a building",
-			Color:   "red",
+			Success:   false,
+			Error:     "not in a building",
+			ErrorCode: types.ErrCodeNotInBuilding,
+			Color:     "red",
 		}, nil
 	}
 
@@ -124,18 +165,20 @@ func HandleOpenVaultAction(state *types.SaveFile, _ map[string]interface{}) (*ty
 	buildingID := state.Building
 	if buildingID == "" {
 		return &types.GameActionResponse{
-			Success: false,
-			Error:   "not in a building",
-			Color:   "red",
+			Success:   false,
+			Error:     "not in a building",
+			ErrorCode: types.ErrCodeNotInBuilding,
+			Color:     "red",
 		}, nil
 	}
 
 	vault := GetVaultForLocation(state, buildingID)
 	if vault == nil {
 		return &types.GameActionResponse{
-			Success: false,
-			Error:   "no vault registered at this location",
-			Color:   "red",
+			Success:   false,
+			Error:     "no vault registered at this location",
+			ErrorCode: types.ErrCodeNoVault,
+			Color:     "red",
 		}, nil
 	}
 
@@ -143,7 +186,7 @@ func HandleOpenVaultAction(state *types.SaveFile, _ map[string]interface{}) (*ty
 		Success: true,
 		Message: "Vault opened",
 		Delta: map[string]interface{}{
-			"vault": vault,
+			"vault": VaultDelta(vault),
 		},
 	}, nil
 }
@@ -6,6 +6,7 @@ import (
 	"log"
 	"net/http"
 	"pubkey-quest/cmd/server/auth"
+	"pubkey-quest/cmd/server/game/gametime"
 	"pubkey-quest/cmd/server/utils"
 
 	"github.com/0ceanslim/grain/client/core/tools"
@@ -25,6 +26,7 @@ func GameHandler(w http.ResponseWriter, r *http.Request) {
 			"SaveID":    saveID,
 			"NewGame":   newGame == "true",
 			"DebugMode": utils.AppConfig.Server.DebugMode,
+			"TimeScale": gametime.TimeScale(),
 		},
 	}
 
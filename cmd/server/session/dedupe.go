@@ -0,0 +1,58 @@
+package session
+
+// Action dedupe — a client on a flaky connection may re-send a game action it
+// never saw the answer to. Actions that carry an action_id are remembered along
+// with the response they produced, so a repeat gets that response back instead
+// of drinking a second potion or depositing the gold twice. Session-only: the
+// window is dropped with the session.
+//
+// The replay check and the record only dedupe if nothing runs in between, so a
+// handler holds LockActions from the check until the response is recorded.
+
+// AppliedActionWindow is how many recent action ids a session remembers.
+const AppliedActionWindow = 32
+
+// AppliedAction is one remembered action: its client-chosen id and the encoded
+// response that was sent for it.
+type AppliedAction struct {
+	ID       string
+	Response []byte
+}
+
+// LockActions takes the session's action lock. Handlers that change the session
+// hold it across the replay check, the action and RecordAppliedAction, so
+// concurrent requests for one save run one at a time.
+func (s *GameSession) LockActions() {
+	s.actionMu.Lock()
+}
+
+// UnlockActions releases the lock taken by LockActions.
+func (s *GameSession) UnlockActions() {
+	s.actionMu.Unlock()
+}
+
+// AppliedResponse returns the response recorded for actionID, if that action
+// has already been applied in this session. An empty id is never a duplicate.
+func (s *GameSession) AppliedResponse(actionID string) ([]byte, bool) {
+	if actionID == "" {
+		return nil, false
+	}
+	for _, applied := range s.AppliedActions {
+		if applied.ID == actionID {
+			return applied.Response, true
+		}
+	}
+	return nil, false
+}
+
+// RecordAppliedAction remembers the response sent for actionID, dropping the
+// oldest entry once the window is full.
+func (s *GameSession) RecordAppliedAction(actionID string, response []byte) {
+	if actionID == "" {
+		return
+	}
+	s.AppliedActions = append(s.AppliedActions, AppliedAction{ID: actionID, Response: response})
+	if over := len(s.AppliedActions) - AppliedActionWindow; over > 0 {
+		s.AppliedActions = append([]AppliedAction(nil), s.AppliedActions[over:]...)
+	}
+}
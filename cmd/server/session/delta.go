@@ -120,10 +120,8 @@ func CreateSnapshot(save *types.SaveFile, npcs []string, buildings map[string]bo
 	// Copy inventory general slots
 	if save.Inventory != nil {
 		if generalSlots, ok := save.Inventory["general_slots"].([]interface{}); ok {
+			snapshot.GeneralSlots = make([]InventorySlotSnapshot, len(generalSlots))
 			for i, slot := range generalSlots {
-				if i >= 4 {
-					break
-				}
 				if slotMap, ok := slot.(map[string]interface{}); ok {
 					if itemID, ok := slotMap["item"].(string); ok {
 						snapshot.GeneralSlots[i].ItemID = itemID
@@ -139,10 +137,8 @@ func CreateSnapshot(save *types.SaveFile, npcs []string, buildings map[string]bo
 		if gearSlots, ok := save.Inventory["gear_slots"].(map[string]interface{}); ok {
 			if bag, ok := gearSlots["bag"].(map[string]interface{}); ok {
 				if contents, ok := bag["contents"].([]interface{}); ok {
+					snapshot.BackpackSlots = make([]InventorySlotSnapshot, len(contents))
 					for i, slot := range contents {
-						if i >= 20 {
-							break
-						}
 						if slotMap, ok := slot.(map[string]interface{}); ok {
 							if itemID, ok := slotMap["item"].(string); ok {
 								snapshot.BackpackSlots[i].ItemID = itemID
@@ -261,9 +257,9 @@ func CalculateDelta(old, new *SessionSnapshot) *Delta {
 
 	// Inventory general slots delta
 	generalChanges := make(map[int]InventorySlotDelta)
-	for i := 0; i < 4; i++ {
-		oldSlot := old.GeneralSlots[i]
-		newSlot := new.GeneralSlots[i]
+	for i := 0; i < maxLen(len(old.GeneralSlots), len(new.GeneralSlots)); i++ {
+		oldSlot := slotAt(old.GeneralSlots, i)
+		newSlot := slotAt(new.GeneralSlots, i)
 
 		if oldSlot.ItemID != newSlot.ItemID || oldSlot.Quantity != newSlot.Quantity {
 			slotDelta := InventorySlotDelta{}
@@ -285,9 +281,9 @@ func CalculateDelta(old, new *SessionSnapshot) *Delta {
 
 	// Backpack slots delta
 	backpackChanges := make(map[int]InventorySlotDelta)
-	for i := 0; i < 20; i++ {
-		oldSlot := old.BackpackSlots[i]
-		newSlot := new.BackpackSlots[i]
+	for i := 0; i < maxLen(len(old.BackpackSlots), len(new.BackpackSlots)); i++ {
+		oldSlot := slotAt(old.BackpackSlots, i)
+		newSlot := slotAt(new.BackpackSlots, i)
 
 		if oldSlot.ItemID != newSlot.ItemID || oldSlot.Quantity != newSlot.Quantity {
 			slotDelta := InventorySlotDelta{}
@@ -571,3 +567,19 @@ func (d *Delta) ToMap() map[string]any {
 
 	return result
 }
+
+// slotAt returns slots[i], or an empty slot when the slice is shorter — slot
+// counts vary with the equipped bag, so two snapshots can differ in length.
+func slotAt(slots []InventorySlotSnapshot, i int) InventorySlotSnapshot {
+	if i < len(slots) {
+		return slots[i]
+	}
+	return InventorySlotSnapshot{}
+}
+
+func maxLen(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -96,10 +96,24 @@ func RecoverJournaledSave(npub, saveID, diskSavePath string) *types.SaveFile {
 
 // JournalAllSessions snapshots every active session for crash recovery.
 func JournalAllSessions() {
-	for _, sess := range GetSessionManager().GetAllSessions() {
-		if err := WriteJournal(sess); err != nil {
-			log.Printf("⚠️ Failed to journal session %s:%s: %v", sess.Npub, sess.SaveID, err)
-		}
+	sm := GetSessionManager()
+	for key, sess := range sm.GetAllSessions() {
+		sm.journalIfLoaded(key, sess)
+	}
+}
+
+// journalIfLoaded writes sess's journal if it is still the session loaded under
+// key. The check and the write hold the manager's read lock, so a session
+// unloaded during the pass (a deleted save, a clean quit) doesn't get its
+// journal written back after the unload removed it.
+func (sm *SessionManager) journalIfLoaded(key string, sess *GameSession) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	if sm.sessions[key] != sess {
+		return
+	}
+	if err := WriteJournal(sess); err != nil {
+		log.Printf("⚠️ Failed to journal session %s:%s: %v", sess.Npub, sess.SaveID, err)
 	}
 }
 
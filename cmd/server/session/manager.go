@@ -1,6 +1,7 @@
 package session
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"maps"
@@ -11,8 +12,8 @@ import (
 	"pubkey-quest/cmd/server/db"
 	"pubkey-quest/cmd/server/game/building"
 	"pubkey-quest/cmd/server/game/character"
+	"pubkey-quest/cmd/server/game/inventory"
 	"pubkey-quest/cmd/server/game/status"
-	"pubkey-quest/cmd/server/world"
 	"pubkey-quest/types"
 )
 
@@ -73,7 +74,7 @@ func getBuildingStatesWrapper(location, district string, timeOfDay int) (map[str
 
 // loadAndHydrateSave loads a save from disk and recomputes its derived fields
 // (MaxHP/MaxMana from class + level + stats) so leveling is reflected on every
-// load. Advancement comes from the DB; if it's unavailable the raw save is
+// load, and merges fragmented inventory stacks. Advancement comes from the DB; if it's unavailable the raw save is
 // returned and derived fields keep their last-persisted values.
 func loadAndHydrateSave(npub, saveID string) (*types.SaveFile, error) {
 	save, err := LoadSaveByID(npub, saveID)
@@ -92,6 +93,10 @@ func loadAndHydrateSave(npub, saveID string) (*types.SaveFile, error) {
 		} else {
 			log.Printf("⚠️ Hydrate skipped — advancement load failed: %v", advErr)
 		}
+		// Tidy stacks fragmented by past moves/splits (needs item stack sizes).
+		if freed := inventory.ConsolidateStacks(save); freed > 0 {
+			log.Printf("📦 Consolidated stacks in %s:%s, freeing %d slot(s)", npub, saveID, freed)
+		}
 	}
 	return save, nil
 }
@@ -153,7 +158,6 @@ func (sm *SessionManager) LoadSession(npub, saveID string, loadSave func(string,
 		LastActionTime:     currentTimestamp(),
 		LastActionGameTime: saveData.TimeOfDay,
 		BuildingStates:     make(map[string]bool),
-		Ground:             world.NewGroundStore(),
 	}
 
 	// Initialize building states and NPCs for current location
@@ -204,6 +208,12 @@ func (sm *SessionManager) ReloadSession(npub, saveID string, loadSave func(strin
 		}
 	}
 
+	// The disk copy is usually behind the session it replaces; keep counting up
+	// from the discarded revision so a tab still holding it sees a conflict.
+	if old, exists := sm.sessions[key]; exists && old.SaveData.Revision >= saveData.Revision {
+		saveData.Revision = old.SaveData.Revision + 1
+	}
+
 	// Create/overwrite session in memory
 	session := &GameSession{
 		Npub:               npub,
@@ -214,7 +224,6 @@ func (sm *SessionManager) ReloadSession(npub, saveID string, loadSave func(strin
 		LastActionTime:     currentTimestamp(),
 		LastActionGameTime: saveData.TimeOfDay,
 		BuildingStates:     make(map[string]bool),
-		Ground:             world.NewGroundStore(),
 	}
 
 	// Initialize building states and NPCs for current location
@@ -261,7 +270,14 @@ func (sm *SessionManager) GetSession(npub, saveID string) (*GameSession, error)
 	return session, nil
 }
 
-// UpdateSession updates the in-memory game state
+// ErrRevisionConflict is returned by UpdateSession when the save data is based
+// on an older revision than the session holds — the game was changed elsewhere
+// (another tab or device) in the meantime.
+var ErrRevisionConflict = errors.New("save data is out of date: the game was changed elsewhere")
+
+// UpdateSession updates the in-memory game state. saveData must carry the
+// session's current Revision; the stored copy moves on to the next one. Stale
+// data is refused with ErrRevisionConflict instead of overwriting newer progress.
 func (sm *SessionManager) UpdateSession(npub, saveID string, saveData types.SaveFile) error {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
@@ -272,8 +288,12 @@ func (sm *SessionManager) UpdateSession(npub, saveID string, saveData types.Save
 	if !exists {
 		return fmt.Errorf("session not found in memory: %s", key)
 	}
+	if saveData.Revision != session.SaveData.Revision {
+		return ErrRevisionConflict
+	}
 
 	// Update the save data
+	saveData.Revision++
 	session.SaveData = saveData
 	session.UpdatedAt = currentTimestamp()
 	return nil
@@ -0,0 +1,109 @@
+package session
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+
+	"pubkey-quest/cmd/server/db"
+	"pubkey-quest/cmd/server/game/gameutil"
+	"pubkey-quest/types"
+)
+
+// Save policy — when a deliberate save is allowed.
+//
+// "anywhere" is the classic behaviour: the player may commit a save wherever
+// they stand. "safe-only" raises the stakes by restricting deliberate saves to
+// the places listed in safe_places ("city" for anywhere inside a town,
+// "rented_room" for a building the player holds an active rental on), so a
+// bad fight in the wilds can't be saved past. Crash-recovery journaling is
+// unaffected either way — it protects against a dead server, not a dead hero.
+
+const (
+	SavePolicyAnywhere = "anywhere"
+	SavePolicySafeOnly = "safe-only"
+)
+
+// SavePolicy is the save_system block of systems/saving.json.
+type SavePolicy struct {
+	Policy     string   `json:"policy"`      // SavePolicyAnywhere or SavePolicySafeOnly
+	SafePlaces []string `json:"safe_places"` // "city", "rented_room"
+}
+
+// DefaultSavePolicy allows saving anywhere.
+func DefaultSavePolicy() SavePolicy {
+	return SavePolicy{Policy: SavePolicyAnywhere, SafePlaces: []string{"city", "rented_room"}}
+}
+
+// LoadSavePolicy reads the save policy from the systems table, falling back to
+// DefaultSavePolicy when it's missing or unreadable.
+func LoadSavePolicy(database *sql.DB) SavePolicy {
+	policy := DefaultSavePolicy()
+	if database == nil {
+		return policy
+	}
+	var propsJSON string
+	if err := database.QueryRow("SELECT properties FROM systems WHERE id = 'saving'").Scan(&propsJSON); err != nil {
+		return policy
+	}
+	var config struct {
+		SaveSystem SavePolicy `json:"save_system"`
+	}
+	config.SaveSystem = policy
+	if err := json.Unmarshal([]byte(propsJSON), &config); err != nil {
+		log.Printf("⚠️ Failed to parse save policy config: %v", err)
+		return policy
+	}
+	return config.SaveSystem
+}
+
+// BlockedReason returns why a deliberate save isn't allowed where the player
+// is standing, or "" if it is. locationType is the locations.location_type of
+// save.Location ("city", "environment"). Unknown policies behave as "anywhere".
+func (p SavePolicy) BlockedReason(save *types.SaveFile, locationType string) string {
+	if p.Policy != SavePolicySafeOnly {
+		return ""
+	}
+	for _, place := range p.SafePlaces {
+		switch place {
+		case "city":
+			if locationType == "city" {
+				return ""
+			}
+		case "rented_room":
+			if save.Building != "" && gameutil.HasActiveRental(save, save.Building) {
+				return ""
+			}
+		}
+	}
+	if locationType != "city" {
+		return "You can only save somewhere safe. Find a town before saving."
+	}
+	return "You can only save in a room you've rented. Rent a room at an inn to save."
+}
+
+// SaveBlockedReason checks the configured save policy against the player's
+// current location. Returns "" when the save may go ahead.
+func SaveBlockedReason(save *types.SaveFile) string {
+	database := db.GetDB()
+	policy := LoadSavePolicy(database)
+	if policy.Policy != SavePolicySafeOnly {
+		return ""
+	}
+	locationType := ""
+	if database != nil {
+		_ = database.QueryRow("SELECT COALESCE(location_type,'') FROM locations WHERE id = ?", save.Location).Scan(&locationType)
+	}
+	return policy.BlockedReason(save, locationType)
+}
+
+// CommitSave writes the session's state to its save file and drops the crash
+// journal — the deliberate save is now the authoritative state. Policy checks
+// are the caller's job.
+func CommitSave(npub, saveID string, save *types.SaveFile) error {
+	if err := WriteSaveFile(GetSavePath(npub, saveID), save); err != nil {
+		return err
+	}
+	RemoveJournal(npub, saveID)
+	return nil
+}
@@ -73,6 +73,20 @@ func GetSavePath(npub, saveID string) string {
 	return fmt.Sprintf("%s/%s/%s.json", SavesDirectory, npub, saveID)
 }
 
+// DeleteSave removes a save for good: its file, its crash journal and its
+// session in memory. A hardcore death ends the run with it. The file goes
+// first: if it can't be removed the session and journal are left as they were.
+// The journal is removed after the unload, so the journal loop (which only
+// writes loaded sessions) can't put it back.
+func DeleteSave(npub, saveID string) error {
+	if err := os.Remove(GetSavePath(npub, saveID)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	GetSessionManager().UnloadSession(npub, saveID)
+	RemoveJournal(npub, saveID)
+	return nil
+}
+
 // EnsureSaveDirectory ensures the saves directory exists for a user
 func EnsureSaveDirectory(npub string) error {
 	userSavesDir := filepath.Join(SavesDirectory, npub)
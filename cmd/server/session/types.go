@@ -1,8 +1,11 @@
 package session
 
 import (
+	"sync"
+
+	"pubkey-quest/cmd/server/game/gametime"
+	"pubkey-quest/cmd/server/game/npc"
 	"pubkey-quest/cmd/server/game/poi"
-	"pubkey-quest/cmd/server/world"
 	"pubkey-quest/types"
 )
 
@@ -28,10 +31,6 @@ type GameSession struct {
 	// POI sets ActiveCombat too and stashes the resume node here (poi.Session).
 	ActivePOI *poi.Session `json:"-"`
 
-	// Ground holds items dropped on the ground per location — server-authoritative,
-	// memory-only. Drop moves an item here; pickup takes it back. Nil-safe.
-	Ground *world.GroundStore `json:"-"`
-
 	// Travel-encounter cooldown: the absolute in-game minute (day*1440 +
 	// time_of_day) of the last biome encounter, so they can't fire back-to-back.
 	// Session-only.
@@ -51,6 +50,24 @@ type GameSession struct {
 	// Each entry tracks a spell being prepared for a specific slot.
 	PrepQueue []types.SpellPrepTask `json:"-"`
 
+	// The last few finished fights with their full combat logs, newest last
+	// (see combat.RememberFight). Session-only: the save keeps the compact
+	// CombatHistory.
+	RecentFights []types.FightLog `json:"-"`
+
+	// Recently applied action ids and their responses, so a re-sent action is
+	// answered from here instead of running twice (see dedupe.go). Session-only.
+	AppliedActions []AppliedAction `json:"-"`
+
+	// actionMu serializes the requests that change this session (see
+	// LockActions), so two retries of one action can't both get past the
+	// replay check.
+	actionMu sync.Mutex
+
+	// Ambient-time pacing for update_time ticks (game time can't outrun the
+	// configured time scale). Session-only.
+	TickClock gametime.TickClock `json:"-"`
+
 	// Auto-pause tracking: tracks time since last player action
 	LastActionTime     int64 `json:"-"` // Real-time timestamp of last player action
 	LastActionGameTime int   `json:"-"` // In-game time (TimeOfDay) of last player action
@@ -86,11 +103,11 @@ type SessionSnapshot struct {
 	NPCs      []string
 	Buildings map[string]bool // building_id -> isOpen
 
-	// Inventory (general slots - 4 slots)
-	GeneralSlots [4]InventorySlotSnapshot
+	// Inventory (general slots — count comes from systems/inventory.json)
+	GeneralSlots []InventorySlotSnapshot
 
-	// Backpack (20 slots)
-	BackpackSlots [20]InventorySlotSnapshot
+	// Backpack (sized by the equipped bag's container_slots)
+	BackpackSlots []InventorySlotSnapshot
 
 	// Equipment
 	EquipmentSlots map[string]string // slot_name -> item_id
@@ -134,6 +151,11 @@ func (s *GameSession) SetRentedRooms(rooms []map[string]interface{}) {
 	s.RentedRooms = rooms
 }
 
+// CheckMissedShows penalizes booked shows the player missed as of the given time
+func (s *GameSession) CheckMissedShows(state *types.SaveFile, currentTime, currentDay int) {
+	npc.CheckMissedShows(state, s, currentTime, currentDay)
+}
+
 // GetPerformedShows returns the session's performed shows
 func (s *GameSession) GetPerformedShows() []string {
 	return s.PerformedShows
@@ -149,6 +171,11 @@ func (s *GameSession) GetLastActionGameTime() int {
 	return s.LastActionGameTime
 }
 
+// GetTickClock returns the session's ambient-time pacing clock
+func (s *GameSession) GetTickClock() *gametime.TickClock {
+	return &s.TickClock
+}
+
 // SetLastActionTime sets the real-time timestamp of last player action
 func (s *GameSession) SetLastActionTime(unixTime int64) {
 	s.LastActionTime = unixTime
@@ -29,10 +29,27 @@ type ReportConfig struct {
 	AccessIssueNumber int    `yaml:"access_issue_number"` // Pinned issue that collects access requests
 }
 
+// GameConfig holds deployment-level gameplay tuning.
+type GameConfig struct {
+	DayLengthMinutes    float64     `yaml:"day_length_minutes"`    // Real minutes per in-game day (0 = default 10, i.e. 144× real time)
+	TickIntervalMinutes int         `yaml:"tick_interval_minutes"` // Game minutes per simulation step when a time sync catches up (0 = default 15)
+	MaxCatchUpMinutes   int         `yaml:"max_catchup_minutes"`   // Most game minutes one time sync will simulate (0 = default 1440, one day)
+	Death               DeathConfig `yaml:"death"`                 // What dying costs (unset = keep the 3 most valuable items)
+}
+
+// DeathConfig sets the death penalty. Unset fields keep the standard penalty:
+// the 3 most valuable items survive and everything else, gold included, is lost.
+type DeathConfig struct {
+	Mode      string `yaml:"mode"`       // "standard" (default) or "hardcore" (the save is deleted on death)
+	KeepItems *int   `yaml:"keep_items"` // Most valuable items kept (unset = 3, -1 = keep everything)
+	LoseGold  *bool  `yaml:"lose_gold"`  // false keeps all gold on top of the kept items (unset = true)
+}
+
 // Config holds the full application configuration
 type Config struct {
 	Server ServerConfig `yaml:"server"`
 	Report ReportConfig `yaml:"report"`
+	Game   GameConfig   `yaml:"game"`
 }
 
 // Global variable to hold the config after loading
@@ -1,9 +1,9 @@
-// Package world manages transient server-side world state that persists across
-// player sessions but is NOT saved to player save files.
+// Package world manages server-side world state that persists across player
+// sessions but is NOT saved to player save files — ground items excepted.
 //
 // This package handles:
 //   - Merchant inventories and gold (per-player, resets on restock timers)
-//   - Ground items / dropped items (future)
+//   - Ground items / dropped items (kept in the save, so drops survive a reload)
 //   - World events and temporary state (future)
 //   - Any game state that should be server-authoritative and session-scoped
 //
@@ -15,9 +15,9 @@
 //
 // Current files:
 //   - merchant.go: MerchantStateManager for per-player merchant inventories/gold
+//   - ground.go: Dropped items per location, stored in SaveFile.GroundItems
 //
 // Future additions:
-//   - ground.go: Dropped items per location
 //   - events.go: Temporary world events
 //   - manager.go: Unified world state manager
 package world
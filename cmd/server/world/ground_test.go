@@ -6,18 +6,17 @@ import (
 	"pubkey-quest/types"
 )
 
-func TestGroundStoreAddTakeList(t *testing.T) {
-	g := NewGroundStore()
-	const key = "kingdom|center||"
+func TestGroundDropTakeList(t *testing.T) {
+	s := &types.SaveFile{Location: "kingdom", District: "center"}
 
 	// Drops of the same item stack into one pile.
-	g.Add(key, "longsword", 1)
-	g.Add(key, "rations", 3)
-	g.Add(key, "rations", 2)
+	DropOnGround(s, "longsword", 1)
+	DropOnGround(s, "rations", 3)
+	DropOnGround(s, "rations", 2)
 
-	list := g.List(key)
+	list := GroundHere(s)
 	if len(list) != 2 {
-		t.Fatalf("expected 2 piles, got %d: %+v", len(list), list)
+		t.Fatalf("expected 2 stacks, got %d: %+v", len(list), list)
 	}
 	var rations int
 	for _, d := range list {
@@ -29,29 +28,44 @@ func TestGroundStoreAddTakeList(t *testing.T) {
 		t.Errorf("rations should stack to 5, got %d", rations)
 	}
 
+	// The pile lives in the save, under the spot's key.
+	if len(s.GroundItems["kingdom|center||"]) != 2 {
+		t.Errorf("ground items should be stored on the save, got %+v", s.GroundItems)
+	}
+
 	// Take part of a stack — the remainder stays.
-	if got := g.Take(key, "rations", 2); got != 2 {
+	if got := TakeFromGround(s, "rations", 2); got != 2 {
 		t.Errorf("take 2 rations: got %d, want 2", got)
 	}
-	// Take more than remains — clamps to what's there and clears the pile.
-	if got := g.Take(key, "rations", 99); got != 3 {
+	// Take more than remains — clamps to what's there and clears the stack.
+	if got := TakeFromGround(s, "rations", 99); got != 3 {
 		t.Errorf("take remaining rations: got %d, want 3", got)
 	}
-	// The empty pile is gone.
-	for _, d := range g.List(key) {
+	for _, d := range GroundHere(s) {
 		if d.Item == "rations" {
-			t.Errorf("rations pile should be removed once emptied, got %+v", d)
+			t.Errorf("rations stack should be removed once emptied, got %+v", d)
 		}
 	}
 
 	// Taking something not on the ground yields 0 (pickup will reject).
-	if got := g.Take(key, "not-here", 1); got != 0 {
+	if got := TakeFromGround(s, "not-here", 1); got != 0 {
 		t.Errorf("take absent item: got %d, want 0", got)
 	}
 
-	// Different location keys are isolated.
-	if len(g.List("other|place||")) != 0 {
-		t.Error("a different location should have no ground items")
+	// Other spots are isolated.
+	s.District = "market"
+	if len(GroundHere(s)) != 0 {
+		t.Error("a different spot should have no ground items")
+	}
+	if got := TakeFromGround(s, "longsword", 1); got != 0 {
+		t.Errorf("longsword was dropped elsewhere: took %d", got)
+	}
+
+	// An emptied pile leaves no key behind.
+	s.District = "center"
+	TakeFromGround(s, "longsword", 1)
+	if len(s.GroundItems) != 0 {
+		t.Errorf("empty piles should be removed, got %+v", s.GroundItems)
 	}
 }
 
@@ -65,13 +79,15 @@ func TestGroundKey(t *testing.T) {
 	}
 }
 
-func TestGroundStoreNilSafe(t *testing.T) {
-	var g *GroundStore
-	g.Add("k", "x", 1)             // must not panic
-	if g.Take("k", "x", 1) != 0 {  // nil store takes nothing
-		t.Error("nil store Take should return 0")
-	}
-	if g.List("k") != nil {
-		t.Error("nil store List should return nil")
+func TestGroundNilSafe(t *testing.T) {
+	DropOnGround(nil, "x", 1) // must not panic
+	if TakeFromGround(nil, "x", 1) != 0 {
+		t.Error("nil save Take should return 0")
+	}
+	if GroundHere(nil) != nil {
+		t.Error("nil save GroundHere should return nil")
+	}
+	if TakeFromGround(&types.SaveFile{}, "x", 1) != 0 {
+		t.Error("a save with no ground items should take nothing")
 	}
 }
@@ -129,8 +129,9 @@ export async function getItemStats(itemName) {
         statsHTML += `<div class="space-y-1 mb-3">`;
 
         if (props.damage) {
+            const versatile = props.damage_versatile || props["damage-versatile"];
             statsHTML += `<div class="text-gray-300 text-sm">⚔️ Damage: ${props.damage
-                } ${props.damage_type || props["damage-type"] || ""}</div>`;
+                }${versatile ? ` (${versatile} two-handed)` : ""} ${props.damage_type || props["damage-type"] || ""}</div>`;
         }
 
         if (props.ac) {
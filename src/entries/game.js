@@ -37,6 +37,7 @@ import { updateTimeDisplay } from '../ui/timeDisplay.js';
 import { updateCharacterDisplay } from '../ui/characterDisplay.js';
 import { displayCurrentLocation } from '../ui/locationDisplay.js';
 import { setTextSpeed, getTextSpeed } from '../ui/sceneSpeech.js';
+import { applyLootFilterForm } from '../ui/lootFilter.js';
 import { updateSpellsDisplay } from '../ui/spellsDisplay.js';
 import { updateAllDisplays } from '../ui/displayCoordinator.js';
 import { showMessage, showActionText, addGameLog } from '../ui/messaging.js';
@@ -91,11 +92,14 @@ window.doMoveToCell     = combatSystem.doMoveToCell;
 window.doStep           = combatSystem.doStep;
 window.doHoldPosition   = combatSystem.doHoldPosition;
 window.doDisengage      = combatSystem.doDisengage;
+window.doAim            = combatSystem.doAim;
 window.doStubAction     = combatSystem.doStubAction;
 window.doCastSpell        = combatSystem.doCastSpell;
 window.doUseCombatItem    = combatSystem.doUseCombatItem;
 window.openCombatSpellMenu = combatSystem.openCombatSpellMenu;
 window.openCombatItemMenu  = combatSystem.openCombatItemMenu;
+window.doEquipCombatWeapon   = combatSystem.doEquipCombatWeapon;
+window.openCombatWeaponMenu  = combatSystem.openCombatWeaponMenu;
 window.doUseAbility        = combatSystem.doUseAbility;
 window.openCombatAbilityMenu = combatSystem.openCombatAbilityMenu;
 window.doFlee           = combatSystem.doFlee;
@@ -123,6 +127,7 @@ if (document.readyState === 'loading') {
 // Text-speed preference (Settings → Text): expose the setter for the tab's
 // onchange, and reflect the saved value in the dropdown on load.
 window.setTextSpeed = setTextSpeed;
+window.applyLootFilter = applyLootFilterForm;
 function initTextSpeedSelect() {
     const sel = document.getElementById('text-speed-select');
     if (sel) sel.value = getTextSpeed();
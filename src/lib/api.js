@@ -9,12 +9,30 @@
 
 import { logger } from './logger.js';
 import { API_BASE_URL } from '../config/constants.js';
+import { eventBus } from './events.js';
+
+/**
+ * A reasonably unique id for one game action (the server's dedupe key).
+ * @returns {string}
+ */
+function newActionID() {
+    if (globalThis.crypto?.randomUUID) {
+        return globalThis.crypto.randomUUID();
+    }
+    return `${Date.now().toString(36)}-${Math.random().toString(36).slice(2, 10)}`;
+}
 
 class GameAPI {
     constructor() {
         this.npub = null;
         this.saveID = null;
         this.initialized = false;
+        // Save revision from the last state the server sent; echoed with every
+        // action so the server can refuse one made against stale state.
+        this.revision = 0;
+        // Actions go out one at a time, each carrying the revision the previous
+        // one produced (a tick racing a click would otherwise look stale).
+        this.queue = Promise.resolve();
     }
 
     /**
@@ -45,7 +63,48 @@ class GameAPI {
      * @param {Object} params - Action parameters
      * @returns {Promise<Object>} Action result with updated state
      */
-    async sendAction(actionType, params = {}) {
+    sendAction(actionType, params = {}) {
+        const run = this.queue.then(() => this.postAction(actionType, params));
+        this.queue = run.catch(() => {});
+        return run;
+    }
+
+    /**
+     * POST to one of the endpoints outside /game/action that change the save
+     * (combat, POI, shop, quests, spells, progression). Queued behind the
+     * actions and stamped with the current revision like them; the revision
+     * in the reply is kept for the next request.
+     * @param {string} url - Endpoint URL
+     * @param {Object} body - Request body (npub/save_id included by the caller)
+     * @returns {Promise<Response>} The raw response, for the caller to read
+     */
+    postChange(url, body) {
+        const run = this.queue.then(async () => {
+            const response = await fetch(url, {
+                method: 'POST',
+                headers: { 'Content-Type': 'application/json' },
+                body: JSON.stringify({ ...body, revision: this.revision })
+            });
+            const result = await response.clone().json().catch(() => null);
+            if (typeof result?.revision === 'number') {
+                this.revision = result.revision;
+            }
+            if (result?.error_code === 'REVISION_CONFLICT' && typeof window !== 'undefined') {
+                window.showMessage?.(result.message, 'error');
+            }
+            return response;
+        });
+        this.queue = run.catch(() => {});
+        return run;
+    }
+
+    /**
+     * Post one game action (sendAction queues these)
+     * @param {string} actionType - Type of action
+     * @param {Object} params - Action parameters
+     * @returns {Promise<Object>} Action result with updated state
+     */
+    async postAction(actionType, params) {
         this.ensureInitialized();
 
         logger.debug(`Sending action: ${actionType}`, params);
@@ -59,6 +118,10 @@ class GameAPI {
                 body: JSON.stringify({
                     npub: this.npub,
                     save_id: this.saveID,
+                    // One id per action: if the request is re-sent, the server
+                    // replays its first answer instead of applying it twice.
+                    action_id: newActionID(),
+                    revision: this.revision,
                     action: {
                         type: actionType,
                         params: params
@@ -66,12 +129,27 @@ class GameAPI {
                 })
             });
 
+            if (response.status === 409) {
+                // The game moved on in another tab or device.
+                const conflict = await response.json().catch(() => ({}));
+                const error = new Error(conflict.message || 'Your game is open elsewhere.');
+                error.conflict = true;
+                if (typeof window !== 'undefined') {
+                    window.showMessage?.(error.message, 'error');
+                }
+                throw error;
+            }
+
             if (!response.ok) {
                 throw new Error(`Action failed: ${response.status}`);
             }
 
             const result = await response.json();
 
+            if (result.state) {
+                this.revision = result.state.revision ?? 0;
+            }
+
             if (!result.success) {
                 // Surface the server's human-readable reason. Handlers put the
                 // explanation in `message` (e.g. "Head to your rented room to
@@ -81,12 +159,24 @@ class GameAPI {
 
             logger.info(`Action completed: ${actionType}`, result.message);
 
+            // Typed events (effect_expired, arrived, level_up, encounter, …) in
+            // the order they happened — one stream for toasts and the log.
+            for (const ev of result.events ?? []) {
+                eventBus.emit('game:event', ev);
+            }
+
             // Any action can award XP; surface a level-up moment generically so
             // every XP source (shows, quests, …) shows it without bespoke wiring.
             if (result.data?.level_up?.leveled && typeof window !== 'undefined') {
                 window.showLevelUpModal?.(result.data.level_up);
             }
 
+            // A hardcore death deletes the save: back to the save list.
+            if (result.data?.death?.permadeath && typeof window !== 'undefined') {
+                window.showMessage?.(result.message, 'error');
+                setTimeout(() => window.location.href = '/saves', 3000);
+            }
+
             // Return the updated state
             return result;
 
@@ -118,6 +208,7 @@ class GameAPI {
                 throw new Error('Failed to fetch state');
             }
 
+            this.revision = result.state.revision ?? 0;
             return result.state;
 
         } catch (error) {
@@ -126,6 +217,33 @@ class GameAPI {
         }
     }
 
+    /**
+     * Fetch the character sheet: derived level, ability scores and modifiers,
+     * AC, HP/mana, carry weight, proficiencies and equipped gear
+     * @returns {Promise<Object>} Character sheet
+     */
+    async getCharacterSheet() {
+        this.ensureInitialized();
+
+        try {
+            const response = await fetch(
+                `${API_BASE_URL}/game/sheet?npub=${this.npub}&save_id=${this.saveID}`
+            );
+
+            const result = await response.json();
+
+            if (!response.ok || !result.success) {
+                throw new Error(result.error || `Failed to fetch character sheet: ${response.status}`);
+            }
+
+            return result.sheet;
+
+        } catch (error) {
+            logger.error('Failed to fetch character sheet:', error);
+            throw error;
+        }
+    }
+
     /**
      * Save game to disk (manual save)
      * @returns {Promise<boolean>} Success status
@@ -154,7 +272,10 @@ class GameAPI {
             const result = await response.json();
 
             if (!result.success) {
-                throw new Error(result.message || 'Save failed');
+                // A "safe-only" save policy refusal carries the reason to show.
+                const error = new Error(result.message || 'Save failed');
+                error.blocked = true;
+                throw error;
             }
 
             logger.info('Game saved to disk');
@@ -214,11 +335,8 @@ class GameAPI {
     async spendAbilityPoint(ability) {
         this.ensureInitialized();
 
-        const response = await fetch(`${API_BASE_URL}/progression/spend-point`, {
-            method: 'POST',
-            headers: { 'Content-Type': 'application/json' },
-            body: JSON.stringify({ npub: this.npub, save_id: this.saveID, ability })
-        });
+        const response = await this.postChange(`${API_BASE_URL}/progression/spend-point`,
+            { npub: this.npub, save_id: this.saveID, ability });
         if (!response.ok) {
             // The endpoint returns a plain-text reason (e.g. "no unspent ability points").
             let reason = `spend failed: ${response.status}`;
@@ -228,6 +346,24 @@ class GameAPI {
         return await response.json();
     }
 
+    /**
+     * Apply any pending level-up (spell slots, ability unlocks). Idempotent: at an
+     * already-applied level gains.applied is false.
+     * @returns {Promise<Object>} { gains: {applied, from_level, level, hp_gained, mana_gained, spell_slots, abilities}, unspent }
+     */
+    async applyLevelUp() {
+        this.ensureInitialized();
+
+        const response = await this.postChange(`${API_BASE_URL}/progression/level-up`,
+            { npub: this.npub, save_id: this.saveID });
+        if (!response.ok) {
+            let reason = `level-up failed: ${response.status}`;
+            try { reason = (await response.text()).trim() || reason; } catch { /* ignore */ }
+            throw new Error(reason);
+        }
+        return await response.json();
+    }
+
     /**
      * List feats + this character's feat slots.
      * @returns {Promise<Object>} { slots_available, eligible_levels, feats: [{id,name,description,stat_grant,hp_per_level,effects,taken,choice}] }
@@ -251,11 +387,8 @@ class GameAPI {
      */
     async chooseFeat(featId, choice = '') {
         this.ensureInitialized();
-        const response = await fetch(`${API_BASE_URL}/progression/choose-feat`, {
-            method: 'POST',
-            headers: { 'Content-Type': 'application/json' },
-            body: JSON.stringify({ npub: this.npub, save_id: this.saveID, feat_id: featId, choice })
-        });
+        const response = await this.postChange(`${API_BASE_URL}/progression/choose-feat`,
+            { npub: this.npub, save_id: this.saveID, feat_id: featId, choice });
         if (!response.ok) {
             let reason = `choose-feat failed: ${response.status}`;
             try { reason = (await response.text()).trim() || reason; } catch { /* ignore */ }
@@ -274,11 +407,8 @@ class GameAPI {
      */
     async prepareSpell(spellId, slotLevel, slotIndex) {
         this.ensureInitialized();
-        const response = await fetch(`${API_BASE_URL}/spells/prepare`, {
-            method: 'POST',
-            headers: { 'Content-Type': 'application/json' },
-            body: JSON.stringify({ npub: this.npub, save_id: this.saveID, spell_id: spellId, slot_level: slotLevel, slot_index: slotIndex })
-        });
+        const response = await this.postChange(`${API_BASE_URL}/spells/prepare`,
+            { npub: this.npub, save_id: this.saveID, spell_id: spellId, slot_level: slotLevel, slot_index: slotIndex });
         if (!response.ok) {
             let reason = `prepare failed: ${response.status}`;
             try { reason = (await response.text()).trim() || reason; } catch { /* ignore */ }
@@ -298,11 +428,8 @@ class GameAPI {
     /** Clear a slot (and cancel any in-progress prep for it). */
     async unslotSpell(slotLevel, slotIndex) {
         this.ensureInitialized();
-        const response = await fetch(`${API_BASE_URL}/spells/unslot`, {
-            method: 'POST',
-            headers: { 'Content-Type': 'application/json' },
-            body: JSON.stringify({ npub: this.npub, save_id: this.saveID, slot_level: slotLevel, slot_index: slotIndex })
-        });
+        const response = await this.postChange(`${API_BASE_URL}/spells/unslot`,
+            { npub: this.npub, save_id: this.saveID, slot_level: slotLevel, slot_index: slotIndex });
         if (!response.ok) {
             let reason = `unslot failed: ${response.status}`;
             try { reason = (await response.text()).trim() || reason; } catch { /* ignore */ }
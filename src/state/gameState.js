@@ -164,6 +164,8 @@ export function transformSaveDataToUIState(saveData) {
             alignment: saveData.alignment,
             level: saveData.level || 1,
             experience: saveData.experience || 0,
+            xp_to_next_level: saveData.xp_to_next_level || 0,
+            proficiency_bonus: saveData.proficiency_bonus || 2,
             hp: saveData.hp,
             max_hp: saveData.max_hp,
             mana: saveData.mana,
@@ -188,7 +190,11 @@ export function transformSaveDataToUIState(saveData) {
             // Include pre-calculated values from backend (NOT persisted)
             total_weight: saveData.total_weight,
             weight_capacity: saveData.weight_capacity,
-            equipped_stats: saveData.equipped_stats
+            encumbrance: saveData.encumbrance,
+            equipped_stats: saveData.equipped_stats,
+            gear_slot_layout: saveData.gear_slot_layout || {},
+            learned: saveData.learned,
+            loot_filter: saveData.loot_filter || null
         },
         location: {
             current: locationId,
@@ -11,6 +11,7 @@ import { eventBus }   from '../lib/events.js';
 import { gameAPI }    from '../lib/api.js';
 import { smoothClock } from './smoothClock.js';
 import { restoreActionButtonsLayout, displayCurrentLocation } from '../ui/locationDisplay.js';
+import { rarityStyle } from '../ui/characterDisplay.js';
 
 // ─── Range descriptions ────────────────────────────────────────────────────────
 const RANGE_LABELS = {
@@ -45,11 +46,7 @@ const _hide     = id  => $id(id)?.classList.add('hidden');
 const _setText  = (id, t) => { const e = $id(id); if (e) e.textContent = t; };
 
 function combatPost(endpoint, body) {
-    return fetch(endpoint, {
-        method: 'POST',
-        headers: { 'Content-Type': 'application/json' },
-        body: JSON.stringify(body),
-    });
+    return gameAPI.postChange(endpoint, body);
 }
 
 // ─── Public API ───────────────────────────────────────────────────────────────
@@ -140,8 +137,10 @@ export function renderCombatState(cs) {
     if (monster) {
 
         const img = $id('combat-monster-img');
-        if (img && monster.instance_id) {
-            const newSrc = `/res/img/monsters/${monster.instance_id}.png`;
+        // Art is per monster type; instance IDs are numbered in a pack ("goblin-2").
+        const artID = monster.template_id || monster.instance_id;
+        if (img && artID) {
+            const newSrc = `/res/img/monsters/${artID}.png`;
             if (img.src !== newSrc) {
                 img.src = newSrc;
                 img.onerror = () => { img.src = '/res/img/monsters/unknown.png'; img.onerror = null; };
@@ -303,6 +302,25 @@ export async function doHoldPosition() {
     }
 }
 
+/** Aim — costs Bonus Action and the turn's movement; advantage on the next ranged attack. */
+export async function doAim() {
+    const npub = getNpub(), saveID = getSaveID();
+    if (!npub || !saveID) return;
+    try {
+        const resp = await combatPost('/api/combat/aim', { npub, save_id: saveID });
+        const cs   = await resp.json();
+        if (!resp.ok || !cs.success) {
+            _logError(cs.error ?? `HTTP ${resp.status}`);
+            if (_lastState) _renderCombatButtons(_lastState);
+            return;
+        }
+        renderCombatState(cs);
+    } catch (err) {
+        logger.error('doAim error:', err);
+        _logError('Network error — could not process aim.');
+    }
+}
+
 /** Move the player one cell in the given direction (D-pad press). */
 export async function doStep(dx, dy) {
     if (!_lastState?.player_pos) return;
@@ -475,9 +493,14 @@ export function openCombatSpellMenu() {
     _openCombatChooser(`✨ Cast a spell — ${mana}/${maxMana}◆ mana`, entries);
 }
 
-/** Open the combat item menu: reachable consumables (loose + in general-slot pouches). */
+/**
+ * Open the combat item menu: reachable consumables (loose + in general-slot pouches).
+ * A "bonus-action" item can still be used once the action is spent.
+ */
 export function openCombatItemMenu() {
     const inv = window.getGameStateSync?.()?.character?.inventory ?? {};
+    const actionUsed = _lastState?.action_used ?? false;
+    const bonusUsed  = _lastState?.bonus_action_used ?? false;
     const gen = inv.general_slots ?? [];
     const counts = new Map();
 
@@ -499,11 +522,13 @@ export function openCombatItemMenu() {
     for (const [id, qty] of counts) {
         if (qty <= 0) continue;
         const item = window.getItemById?.(id);
+        const quick = (item?.tags ?? []).map(t => String(t).toLowerCase()).includes('bonus-action');
+        const usable = quick ? !(actionUsed && bonusUsed) : !actionUsed;
         entries.push({
             label: item?.name ?? id,
-            meta:  `×${qty}`,
-            disabled: false,
-            tip: item?.description ?? '',
+            meta:  quick ? `×${qty} · bonus action` : `×${qty}`,
+            disabled: !usable,
+            tip: usable ? (item?.description ?? '') : '⚠ Action used this turn',
             onClick: () => window.doUseCombatItem(id),
         });
     }
@@ -515,6 +540,61 @@ export function openCombatItemMenu() {
     _openCombatChooser('🧪 Use an item', entries);
 }
 
+// ─── Weapon swap ───────────────────────────────────────────────────────────────
+
+/** Swap a weapon from a general slot into hand. Free once per turn, then the action. */
+export async function doEquipCombatWeapon(itemId) {
+    const npub = getNpub(), saveID = getSaveID();
+    if (!npub || !saveID) return;
+    _closeCombatChooser();
+    try {
+        const resp = await combatPost('/api/combat/equip', { npub, save_id: saveID, item_id: itemId });
+        const cs = await resp.json();
+        if (!resp.ok || !cs.success) {
+            _logError(cs.error ?? `HTTP ${resp.status}`);
+            if (_lastState) _renderCombatButtons(_lastState);
+            return;
+        }
+        // The hands changed server-side — pull the inventory so the attack
+        // buttons name the new weapon.
+        if (window.refreshGameState) await window.refreshGameState();
+        renderCombatState(cs);
+    } catch (err) {
+        logger.error('doEquipCombatWeapon error:', err);
+        _logError('Network error — could not swap weapons.');
+    }
+}
+
+/** List the weapons in loose general slots (the backpack is out of reach mid-fight). */
+export function openCombatWeaponMenu() {
+    const inv = window.getGameStateSync?.()?.character?.inventory ?? {};
+    const free = !(_lastState?.interaction_used ?? false);
+    const actionUsed = _lastState?.action_used ?? false;
+
+    const entries = [];
+    const seen = new Set();
+    for (const slot of (inv.general_slots ?? [])) {
+        const id = slot?.item;
+        if (!id || seen.has(id) || !_isWeaponItem(id)) continue;
+        seen.add(id);
+        const item = window.getItemById?.(id);
+        const usable = free || !actionUsed;
+        entries.push({
+            label: item?.name ?? id,
+            meta:  free ? 'free' : 'action',
+            disabled: !usable,
+            tip: usable ? (item?.description ?? '') : '⚠ Already swapped and action used this turn',
+            onClick: () => window.doEquipCombatWeapon(id),
+        });
+    }
+
+    if (entries.length === 0) {
+        _logInfo('No weapons within reach — stow one in a general slot before the fight.');
+        return;
+    }
+    _openCombatChooser('🗡 Swap weapon', entries);
+}
+
 // ─── Class abilities (M5 Slice 3) ──────────────────────────────────────────────
 
 // Abilities wired into the combat engine (must mirror abilityMechanics in the Go
@@ -522,7 +602,7 @@ export function openCombatItemMenu() {
 // rather than surface a button the server would reject.
 const _COMBAT_ABILITIES = new Set([
     'enter-rage', 'intimidating-roar',      // barbarian
-    'second-wind', 'action-surge',          // fighter
+    'second-wind', 'action-surge', 'power-strike', // fighter
     'flurry-of-blows', 'patient-defense',   // monk
     'sneak-attack', 'shadow-step',          // rogue
 ]);
@@ -558,6 +638,7 @@ export async function openCombatAbilityMenu() {
     const cls   = String(ch.class ?? '').toLowerCase();
     const level = ch.level ?? 1;
     const used  = new Set(_lastState?.player?.abilities_used ?? []);
+    const cooldowns = _lastState?.player?.ability_cooldowns ?? {};
 
     let list = [];
     try {
@@ -579,14 +660,16 @@ export async function openCombatAbilityMenu() {
         if (!_COMBAT_ABILITIES.has(a.id) || !a.is_unlocked) continue;
         const cost = a.current_tier?.override_cost ?? a.resource_cost ?? 0;
         const spent = used.has(a.id);
+        const cooling = cooldowns[a.id] ?? 0;
         const affordable = pool.current >= cost;
-        const disabled = spent || !affordable;
+        const disabled = spent || cooling > 0 || !affordable;
         let tip = a.current_tier?.summary ?? a.description ?? '';
         if (spent) tip = 'Already used this fight';
+        else if (cooling > 0) tip = `On cooldown — ${cooling} more round${cooling === 1 ? '' : 's'}`;
         else if (!affordable) tip = `Not enough ${pool.label} — need ${cost}, have ${pool.current}`;
         entries.push({
             label: a.name ?? a.id,
-            meta:  spent ? '✓ used' : `${cost} ${pool.label}`,
+            meta:  spent ? '✓ used' : cooling > 0 ? `⏳ ${cooling}` : `${cost} ${pool.label}`,
             disabled,
             tip,
             onClick: () => window.doUseAbility(a.id),
@@ -726,8 +809,13 @@ export async function endCombat() {
             return;
         }
         window.showMessage?.(result.message,
-            result.outcome === 'victory' ? 'success' : 'error');
+            result.outcome === 'victory' ? 'success' : result.outcome === 'fled' ? 'info' : 'error');
         exitCombatMode();
+        if (result.permadeath) {
+            // Hardcore: the save was deleted with the character.
+            setTimeout(() => window.location.href = '/saves', 3000);
+            return;
+        }
         if (window.refreshGameState) await window.refreshGameState();
         if (result.level_up?.leveled) window.showLevelUpModal?.(result.level_up);
 
@@ -822,7 +910,7 @@ function _classifyDelay(line) {
                                                                          return DELAY_KILL;
     if (/deals\s+\d+\s+\w+\s+damage|You deal\s+\d+/i.test(L))            return DELAY_DAMAGE;
     if (/^\s*\+\d+\s*XP/i.test(L))                                       return DELAY_XP;
-    if (/moves\s+(toward|away)|You move|disengage|braces? yourself|readying/i.test(L))
+    if (/moves\s+(toward|away)|You move|disengage|braces? yourself|readying|careful aim/i.test(L))
                                                                          return DELAY_NARRATIVE;
     return DELAY_DEFAULT;
 }
@@ -1205,6 +1293,7 @@ function _showPhasePanel(cs) {
             break;
         case 'loot':
         case 'victory':
+        case 'fled':
             _show('loot-panel');
             _renderLootPanel(cs);
             break;
@@ -1272,14 +1361,36 @@ function _renderDeathSaveBar(cs) {
         cursor:pointer;background:#7f1d1d;
         border-top:2px solid #dc2626;border-left:2px solid #dc2626;
         border-right:2px solid #450a0a;border-bottom:2px solid #450a0a;`;
+    // A revive item within reach can be used in place of the roll.
+    const reviveID = _reachableReviveItem();
+    const reviveBtn = reviveID
+        ? `<button style="${_B('color:#fdba74;margin-top:3px;')}" onclick="window.doUseCombatItem('${reviveID}')"
+                title="Use it instead of rolling — heals you off 0 HP and back into the fight">🔥 ${window.getItemById?.(reviveID)?.name ?? reviveID}</button>`
+        : '';
     npcEl.innerHTML = `
         <h3 style="color:#9ca3af;font-size:8px;font-weight:bold;text-transform:uppercase;margin-bottom:2px;">Action</h3>
         <button style="${rollBtnStyle}" onclick="window.rollDeathSave()" title="Roll a d20 — 10+ is a success, nat 20 revives, nat 1 = two failures">
             🎲 Roll Death Save
         </button>
+        ${reviveBtn}
     `;
 }
 
+/** First consumable tagged 'revive' in a general slot or general-slot pouch, or null. */
+function _reachableReviveItem() {
+    const inv = window.getGameStateSync?.()?.character?.inventory ?? {};
+    const isRevive = (slot) => {
+        if (!slot?.item || (slot.quantity ?? 0) <= 0) return false;
+        const tags = (window.getItemById?.(slot.item)?.tags ?? []).map(t => String(t).toLowerCase());
+        return tags.includes('revive');
+    };
+    for (const slot of (inv.general_slots ?? [])) {
+        if (isRevive(slot)) return slot.item;
+        for (const c of (slot?.contents ?? [])) if (isRevive(c)) return c.item;
+    }
+    return null;
+}
+
 function _renderLootPanel(cs) {
     const xpEl = $id('combat-xp-earned');
     if (xpEl) {
@@ -1302,6 +1413,14 @@ function _renderLootPanel(cs) {
         img.onerror = () => { img.style.display = 'none'; img.onerror = null; };
         const lbl = document.createElement('span');
         lbl.textContent = `${drop.item} ×${drop.quantity}`;
+        // Anything above common gets its rarity color; legendary+ also glows.
+        if (drop.rarity && drop.rarity !== 'common') {
+            const { color, glow } = rarityStyle(drop.rarity);
+            lbl.style.color = color;
+            lbl.style.fontWeight = 'bold';
+            if (glow) lbl.style.textShadow = `0 0 4px ${color}`;
+            lbl.title = drop.rarity;
+        }
         row.appendChild(img);
         row.appendChild(lbl);
         listEl.appendChild(row);
@@ -1481,6 +1600,16 @@ function _renderCombatButtons(cs) {
     else if (rangedBlocked) attackBtn = _B_GRAYED(mainLabel, 'No ammo');
     else attackBtn = `<button style="${_B()}" onclick="window.doAttack('main',false)">${mainLabel}</button>`;
 
+    // Aim: ranged only, before moving; stays lit while the aim is held.
+    let aimBtn = '';
+    if (isRanged) {
+        if (cs.aiming)          aimBtn = _B_GRAYED('🎯 Aiming', 'Next ranged attack has advantage — moving or being hit loses it');
+        else if (bonusUsed)     aimBtn = _B_GRAYED('🎯 Aim (bonus)', 'Bonus action used');
+        else if (spent > 0)     aimBtn = _B_GRAYED('🎯 Aim (bonus)', "Can't aim after moving");
+        else aimBtn = `<button style="${_B('color:#fde68a;')}" onclick="window.doAim()"
+                    title="Spend your bonus action and movement to steady your next shot (advantage)">🎯 Aim (bonus)</button>`;
+    }
+
     let bonusBtn = '';
     if (bonusAvail) {
         const offName = _equippedWeaponName('offHand') || 'Off Hand';
@@ -1493,15 +1622,20 @@ function _renderCombatButtons(cs) {
         <h3 style="color:#fbbf24;font-size:8px;font-weight:bold;text-transform:uppercase;margin-bottom:2px;">Action</h3>
         <div style="display:flex;flex-direction:column;gap:2px;overflow:hidden;">
             ${attackBtn}
+            ${aimBtn}
             ${bonusBtn}
             ${actionUsed
                 ? _B_GRAYED('✨ Cast Spell', 'Action used this turn')
                 : `<button style="${_B('color:#c4b5fd;')}" onclick="window.openCombatSpellMenu()"
                     title="Cast a prepared spell">✨ Cast Spell</button>`}
-            ${actionUsed
-                ? _B_GRAYED('🧪 Use Item', 'Action used this turn')
+            ${actionUsed && bonusUsed
+                ? _B_GRAYED('🧪 Use Item', 'Action and bonus action used this turn')
                 : `<button style="${_B('color:#fca5a5;')}" onclick="window.openCombatItemMenu()"
                     title="Use a consumable (potion, food)">🧪 Use Item</button>`}
+            ${actionUsed && (cs.interaction_used ?? false)
+                ? _B_GRAYED('🗡 Swap Weapon', 'Already swapped and action used this turn')
+                : `<button style="${_B('color:#d1d5db;')}" onclick="window.openCombatWeaponMenu()"
+                    title="Draw a weapon from a general slot (free once per turn, then your action)">🗡 Swap Weapon</button>`}
             ${_abilityButton(cs)}
         </div>`;
 
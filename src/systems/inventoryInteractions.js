@@ -612,6 +612,11 @@ export function getItemActions(itemData, isEquipped) {
             actions.push({ action: 'use', label: 'Use' });
         }
 
+        // Packs unpack into their contents
+        if (itemData.tags && itemData.tags.includes('pack')) {
+            actions.push({ action: 'unpack', label: 'Unpack' });
+        }
+
         // Add split action for stackable items (quantity > 1)
         if (itemData.quantity && itemData.quantity > 1) {
             actions.push({ action: 'split', label: 'Split' });
@@ -794,6 +799,7 @@ export async function performAction(action, itemId, fromSlot, toSlotOrType, from
             'equip': 'equip_item',
             'unequip': 'unequip_item',
             'use': 'use_item',
+            'unpack': 'open_pack',
             'drop': 'drop_item',
             'move': 'move_item',
             'stack': 'stack_item',
@@ -1440,163 +1446,81 @@ function hideItemTooltip() {
 
 /**
  * Store item from inventory into vault (when vault is open)
+ * The server puts it in the first open vault slot.
  * Uses surgical updates to avoid rebuilding the scene
  */
 export async function storeInVault(itemId, fromSlot, fromSlotType) {
-    // Get vault building ID from the vault overlay
-    const vaultSlots = document.querySelectorAll('[data-vault-slot]');
-    if (vaultSlots.length === 0) return;
-
-    // May be empty for some vaults (legacy location-format); the backend
-    // resolves it from the player's current building, so don't bail here.
-    const buildingId = vaultSlots[0].getAttribute('data-vault-building') || '';
-
-    // Find first free vault slot
-    let freeVaultSlot = null;
-    for (let i = 0; i < vaultSlots.length; i++) {
-        const slot = vaultSlots[i];
-        const slotItemId = slot.getAttribute('data-item-id');
-        if (!slotItemId) {
-            freeVaultSlot = parseInt(slot.getAttribute('data-vault-slot'));
-            break;
-        }
-    }
-
-    if (freeVaultSlot === null) {
-        if (showMessage) showMessage('Vault is full', 'error');
-        return;
-    }
-
-    // Perform move action
-    try {
-        const result = await gameAPI.sendAction('move_item', {
-            from_slot: fromSlot,
-            from_slot_type: fromSlotType,
-            to_slot: freeVaultSlot,
-            to_slot_type: 'vault',
-            vault_building: buildingId
-        });
-
-        if (result.success) {
-            showMessage('Item stored in vault', 'success');
-
-            // Log the full response for debugging
-            logger.debug('Vault store response:', JSON.stringify(result, null, 2));
-
-            // Apply delta for surgical inventory updates (no full refresh)
-            if (result.delta) {
-                logger.debug('Applying delta:', Object.keys(result.delta));
-                deltaApplier.applyDelta(result.delta);
-            }
-
-            // Silent refresh to update local cache without triggering location rebuild
-            await refreshGameState(true);
-
-            // Update character display (for gold changes etc)
-            await updateCharacterDisplay();
-
-            // Show updated vault directly (use imported function)
-            const vaultData = result.delta?.vault_data;
-            logger.debug('Vault data from delta:', vaultData ? 'present' : 'missing');
-            if (vaultData) {
-                logger.debug('Updating vault UI after store, slots:', vaultData.slots?.length || 0);
-                showVaultUI(vaultData);
-            } else {
-                logger.warn('No vault_data in response delta - vault UI will not update');
-            }
-        }
-    } catch (error) {
-        logger.error('Error storing in vault:', error);
-        showMessage('Failed to store item', 'error');
-    }
+    await vaultTransfer('vault_deposit', {
+        item_id: itemId,
+        from_slot: fromSlot,
+        from_slot_type: fromSlotType
+    }, 'Item stored in vault', 'Failed to store item');
 }
 
 /**
  * Withdraw item from vault to inventory (when vault is open)
- * Priority: backpack slots first, then general slots
+ * The server picks the slot: backpack first, then general slots
  * Uses surgical updates to avoid rebuilding the scene
  */
 export async function withdrawFromVault(itemId, vaultSlot) {
-    const state = getGameStateSync();
-    let targetSlot = null;
-    let targetSlotType = null;
-
-    // First try to find free backpack slot
-    if (state.character?.inventory?.gear_slots?.bag?.contents) {
-        const backpackContents = state.character.inventory.gear_slots.bag.contents;
-        for (let i = 0; i < 20; i++) {
-            const existingItem = backpackContents.find(item => item.slot === i);
-            if (!existingItem || !existingItem.item) {
-                targetSlot = i;
-                targetSlotType = 'inventory'; // Backend expects 'inventory' for backpack
-                break;
-            }
-        }
-    }
-
-    // If no backpack slot, try general slots
-    if (targetSlot === null && state.character?.inventory?.general_slots) {
-        for (let i = 0; i < state.character.inventory.general_slots.length; i++) {
-            const slot = state.character.inventory.general_slots[i];
-            if (!slot.item) {
-                targetSlot = i;
-                targetSlotType = 'general'; // Backend expects 'general' not 'general_slots'
-                break;
-            }
-        }
-    }
+    await vaultTransfer('vault_withdraw', {
+        item_id: itemId,
+        from_slot: vaultSlot
+    }, 'Item withdrawn from vault', 'Failed to withdraw item');
+}
 
-    if (targetSlot === null) {
-        if (showMessage) showMessage('No free inventory space', 'error');
-        return;
-    }
+/**
+ * Store every stack in the backpack in the vault, until it's full
+ */
+export async function storeAllInVault() {
+    await vaultTransfer('vault_store_all', { from_slot_type: 'inventory' }, 'Stored your bag in the vault', 'Failed to store items');
+}
 
-    // Get building ID from vault
-    const vaultSlots = document.querySelectorAll('[data-vault-slot]');
-    const buildingId = vaultSlots[0]?.getAttribute('data-vault-building');
+/**
+ * Take every stack out of the vault, until the inventory is full
+ */
+export async function takeAllFromVault() {
+    await vaultTransfer('vault_take_all', {}, 'Took everything from the vault', 'Failed to withdraw items');
+}
 
-    // Perform move action
+/**
+ * Send a vault deposit/withdraw and refresh the inventory and vault UI from
+ * the response delta.
+ */
+async function vaultTransfer(action, params, successMessage, failureMessage) {
     try {
-        const result = await gameAPI.sendAction('move_item', {
-            item_id: itemId,
-            from_slot: vaultSlot,
-            from_slot_type: 'vault',
-            to_slot: targetSlot,
-            to_slot_type: targetSlotType,
-            vault_building: buildingId
-        });
+        const result = await gameAPI.sendAction(action, params);
 
-        if (result.success) {
-            showMessage('Item withdrawn from vault', 'success');
-
-            // Log the full response for debugging
-            logger.debug('Vault withdraw response:', JSON.stringify(result, null, 2));
-
-            // Apply delta for surgical inventory updates (no full refresh)
-            if (result.delta) {
-                logger.debug('Applying delta:', Object.keys(result.delta));
-                deltaApplier.applyDelta(result.delta);
-            }
+        if (!result.success) {
+            showMessage(result.error || result.message || failureMessage, 'error');
+            return;
+        }
+        // Bulk transfers come back yellow when some stacks didn't fit
+        showMessage(result.message || successMessage, result.color === 'yellow' ? 'warning' : 'success');
+        logger.debug(`${action} response:`, JSON.stringify(result, null, 2));
+
+        // Apply delta for surgical inventory updates (no full refresh)
+        if (result.delta) {
+            logger.debug('Applying delta:', Object.keys(result.delta));
+            deltaApplier.applyDelta(result.delta);
+        }
 
-            // Silent refresh to update local cache without triggering location rebuild
-            await refreshGameState(true);
+        // Silent refresh to update local cache without triggering location rebuild
+        await refreshGameState(true);
 
-            // Update character display (for gold changes etc)
-            await updateCharacterDisplay();
+        // Update character display (for gold changes etc)
+        await updateCharacterDisplay();
 
-            // Show updated vault directly (use imported function)
-            const vaultData = result.delta?.vault_data;
-            logger.debug('Vault data from delta:', vaultData ? 'present' : 'missing');
-            if (vaultData) {
-                logger.debug('Updating vault UI after withdrawal, slots:', vaultData.slots?.length || 0);
-                showVaultUI(vaultData);
-            } else {
-                logger.warn('No vault_data in response delta - vault UI will not update');
-            }
+        // Show updated vault directly (use imported function)
+        const vaultData = result.delta?.vault_data;
+        if (vaultData) {
+            showVaultUI(vaultData);
+        } else {
+            logger.warn('No vault_data in response delta - vault UI will not update');
         }
     } catch (error) {
-        logger.error('Error withdrawing from vault:', error);
-        showMessage('Failed to withdraw item', 'error');
+        logger.error(`Error in ${action}:`, error);
+        showMessage(failureMessage, 'error');
     }
 }
 
@@ -91,9 +91,16 @@ export async function confirmSaveGame() {
     const status = document.getElementById('save-modal-status');
     if (btn) { btn.disabled = true; btn.textContent = 'Saving…'; }
 
+    // Capture a save-policy refusal so the modal can say why instead of "try again".
+    let refusal = '';
+    const report = (msg, type) => {
+        if (type === 'warning') refusal = msg;
+        window.showMessage?.(msg, type);
+    };
+
     let ok = false;
     try {
-        ok = await saveGameToLocal(window.showMessage);
+        ok = await saveGameToLocal(report);
     } catch (error) {
         logger.error('Save ritual failed:', error);
         ok = false;
@@ -112,7 +119,7 @@ export async function confirmSaveGame() {
         setTimeout(closeSaveModal, 900);
     } else {
         if (status) {
-            status.textContent = '❌ Save failed — try again';
+            status.textContent = refusal ? `🚫 ${refusal}` : '❌ Save failed — try again';
             status.className = 'mb-2 font-bold text-red-400';
             status.classList.remove('hidden');
         }
@@ -41,6 +41,12 @@ export async function saveGameToLocal(showMessage) {
         return true;
 
     } catch (error) {
+        if (error.blocked) {
+            // Save policy refusal (not somewhere safe) — not a failure to report as one.
+            logger.info('Save refused:', error.message);
+            if (messageFunc) messageFunc(error.message, 'warning');
+            return false;
+        }
         logger.error('Save failed:', error);
         if (messageFunc) messageFunc('Failed to save game: ' + error.message, 'error');
         return false;
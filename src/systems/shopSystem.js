@@ -509,17 +509,13 @@ async function buyItemNow(itemID, name, quantity, price, maxStock) {
     }
 
     try {
-        const response = await fetch('/api/shop/buy', {
-            method: 'POST',
-            headers: { 'Content-Type': 'application/json' },
-            body: JSON.stringify({
-                npub: npub,
-                save_id: saveID,
-                merchant_id: currentMerchantID,
-                item_id: itemID,
-                quantity: quantity,
-                action: 'buy'
-            })
+        const response = await gameAPI.postChange('/api/shop/buy', {
+            npub: npub,
+            save_id: saveID,
+            merchant_id: currentMerchantID,
+            item_id: itemID,
+            quantity: quantity,
+            action: 'buy'
         });
 
         const result = await response.json();
@@ -740,17 +736,13 @@ async function confirmSellTransaction() {
     // Process each staged item
     for (const item of sellStaging) {
         try {
-            const response = await fetch('/api/shop/sell', {
-                method: 'POST',
-                headers: { 'Content-Type': 'application/json' },
-                body: JSON.stringify({
-                    npub: npub,
-                    save_id: saveID,
-                    merchant_id: currentMerchantID,
-                    item_id: item.itemID,
-                    quantity: item.quantity,
-                    action: 'sell'
-                })
+            const response = await gameAPI.postChange('/api/shop/sell', {
+                npub: npub,
+                save_id: saveID,
+                merchant_id: currentMerchantID,
+                item_id: item.itemID,
+                quantity: item.quantity,
+                action: 'sell'
             });
 
             const result = await response.json();
@@ -312,9 +312,9 @@ async function routeDrop(src, tgt) {
     if (tgt.surface === 'vault') { await vaultMove(src, tgt.index, tgt.buildingId, 'vault'); return; }
     if (src.surface === 'vault') { await vaultMove(src, tgt.index, src.buildingId, tgt.surface); return; }
 
-    // Unequip by dropping equipment onto an inventory slot.
+    // Unequip by dropping equipment onto an inventory slot (it lands there if free).
     if (src.surface === 'equipment') {
-        await performAction('unequip', src.itemId, src.slotName, undefined, 'equipment', undefined, showMessage, showVaultUI, showActionText);
+        await performAction('unequip', src.itemId, src.slotName, tgt.index, 'equipment', tgt.surface, showMessage, showVaultUI, showActionText);
         return;
     }
 
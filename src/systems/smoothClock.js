@@ -15,7 +15,9 @@
 import { logger } from '../lib/logger.js';
 
 // Constants
-const TIME_MULTIPLIER = 144; // 144x real-time speed
+// Game minutes per real minute, served by the page from the server's config
+// (game.day_length_minutes). 144x real-time speed when unset.
+export const TIME_MULTIPLIER = window.GAME_TIME_SCALE > 0 ? window.GAME_TIME_SCALE : 144;
 const MINUTES_PER_DAY = 1440; // 24 hours * 60 minutes
 
 class SmoothClock {
@@ -1,20 +1,22 @@
 /**
  * TickManager - Game tick orchestration system
  *
- * Manages the 417ms tick timer that syncs game state with the backend.
+ * Manages the tick timer (417ms at default speed) that syncs game state with the backend.
  * Each tick:
  * 1. Sends current time to backend
  * 2. Backend processes effects and returns delta
  * 3. Delta is applied to DOM via deltaApplier
  * 4. SmoothClock syncs to authoritative backend time
  *
- * Tick rate: 417ms (~2.4 ticks/second)
- * This corresponds to 1 in-game minute at 144x speed:
+ * Tick rate: one in-game minute of real time at the configured time scale,
+ * 417ms (~2.4 ticks/second) at the default 144x speed:
  *   60 seconds / 144 = 0.417 seconds = 417ms
+ * Very fast scales are floored at MIN_TICK_INTERVAL_MS (a tick then carries
+ * several minutes); the server paces ambient time to the same scale.
  */
 
 import { logger } from '../lib/logger.js';
-import { smoothClock } from './smoothClock.js';
+import { smoothClock, TIME_MULTIPLIER } from './smoothClock.js';
 import { deltaApplier } from './deltaApplier.js';
 import { gameAPI } from '../lib/api.js';
 import { eventBus } from '../lib/events.js';
@@ -22,7 +24,16 @@ import { effectsDisplay } from '../ui/effectsDisplay.js';
 import { refreshGameState, setGroundItems } from '../state/gameState.js';
 
 // Constants
-const TICK_INTERVAL_MS = 417; // 1 in-game minute at 144x speed
+const MIN_TICK_INTERVAL_MS = 250;
+const TICK_INTERVAL_MS = Math.max(MIN_TICK_INTERVAL_MS, Math.round(60000 / TIME_MULTIPLIER)); // 1 in-game minute
+
+// Expiring effects have no other surface — toast them from the action event
+// stream. Arrivals, level-ups and encounters keep their dedicated handling.
+eventBus.on('game:event', (ev) => {
+    if (ev?.type === 'effect_expired' && ev.message) {
+        window.showMessage?.(`⏱️ ${ev.message}`, 'info');
+    }
+});
 
 class TickManager {
     constructor() {
@@ -68,7 +79,7 @@ class TickManager {
     }
 
     /**
-     * Main tick function - called every 417ms
+     * Main tick function - called every TICK_INTERVAL_MS
      * Always sends update_time. Backend handles travel progress automatically.
      */
     async tick() {
@@ -153,6 +164,11 @@ class TickManager {
                 return;
             }
 
+            // Periodic effects that fired this tick (starvation draining HP, …).
+            if (data && Array.isArray(data.tick_messages)) {
+                data.tick_messages.forEach((msg) => window.showMessage?.(msg, 'warning'));
+            }
+
             if (data) {
                 this.updateLocalState(data);
             }
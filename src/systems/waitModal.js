@@ -5,6 +5,7 @@
 
 import { logger } from '../lib/logger.js';
 import { gameAPI } from '../lib/api.js';
+import { eventBus } from '../lib/events.js';
 import { getGameStateSync, refreshGameState } from '../state/gameState.js';
 import { updateAllDisplays } from '../ui/displayCoordinator.js';
 import { showMessage } from '../ui/messaging.js';
@@ -204,6 +205,7 @@ export async function confirmWait() {
 
             showMessage(result.message || `You waited ${formatDuration(currentWaitMinutes)}.`, 'warning');
             closeWaitModal();
+            handleRestEncounter(result.data);
 
             // NOTE: Don't call displayCurrentLocation() here!
             // The delta system already updated buildings/NPCs surgically.
@@ -234,9 +236,10 @@ export async function confirmSleep() {
         if (result.data && result.data.time_of_day !== undefined) {
             smoothClock.syncFromBackend(result.data.time_of_day, result.data.current_day || 1, true);
         }
-        showMessage(result.message || 'You sleep until dawn.', 'success');
+        showMessage(result.message || 'You sleep until dawn.', result.data?.interrupted ? 'warning' : 'success');
         closeWaitModal();
         await refreshGameState(true);
+        handleRestEncounter(result.data);
     } catch (error) {
         // A rejected sleep (wrong place / too early) surfaces its reason here; keep
         // the modal open so the player can wait or move instead.
@@ -245,6 +248,23 @@ export async function confirmSleep() {
     }
 }
 
+/**
+ * A wait or a night's sleep out in the wild can be cut short by an encounter
+ * the server started partway through — hand off to the combat UI or the
+ * exploration overlay, as a travel tick does (tickManager).
+ */
+function handleRestEncounter(data) {
+    if (data?.combat_started && data.combat) {
+        logger.info('⚔️ Rest interrupted — entering combat');
+        eventBus.emit('combat:started', data.combat);
+    } else if (data?.encounter_started && data.poi_step) {
+        logger.info('✨ Rest interrupted — opening exploration overlay');
+        import('../ui/poiExplore.js')
+            .then((m) => m.openFromStep(data.poi_step))
+            .catch((e) => logger.error('encounter overlay open failed:', e));
+    }
+}
+
 // Export functions to window for onclick handlers
 if (typeof window !== 'undefined') {
     window.openWaitModal = openWaitModal;
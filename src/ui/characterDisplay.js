@@ -35,6 +35,18 @@ const RARITY_COLORS = {
 };
 const GLOW_RARITIES = new Set(['legendary', 'mythic']);
 
+/**
+ * Rarity palette lookup shared with the combat loot screen. Data uses both
+ * "mythical" and "mythic"; unknown rarities read as common.
+ * @param {string} rarity - Item rarity
+ * @returns {{color: string, glow: boolean}}
+ */
+export function rarityStyle(rarity) {
+    let key = String(rarity || 'common').toLowerCase();
+    if (key === 'mythical') key = 'mythic';
+    return { color: RARITY_COLORS[key] || RARITY_COLORS.common, glow: GLOW_RARITIES.has(key) };
+}
+
 // Cached {itemId → rarity} map, built once from the static #all-items blob so the
 // inventory render doesn't re-parse the whole item list per slot.
 let _rarityMap = null;
@@ -139,6 +151,40 @@ export async function calculateAndDisplayWeight(character) {
     return 0;
 }
 
+// Weight readout colour per encumbrance tier (encumbrance.json ui_integration).
+const ENCUMBRANCE_COLORS = {
+    light: '#4ade80',
+    normal: '#ffffff',
+    overweight: '#facc15',
+    encumbered: '#fb923c',
+    overloaded: '#f87171'
+};
+
+/**
+ * Colour the weight readout by encumbrance tier and explain what the tier does
+ * to travel and fatigue in its tooltip.
+ * @param {Object} [encumbrance] - { level, travel_speed, fatigue_rate } from the server
+ */
+function updateEncumbranceDisplay(encumbrance) {
+    const el = document.getElementById('weight-display');
+    if (!el || !encumbrance?.level) return;
+
+    el.style.color = ENCUMBRANCE_COLORS[encumbrance.level] || '';
+    const name = encumbrance.level.charAt(0).toUpperCase() + encumbrance.level.slice(1);
+    let tip = name;
+    if (encumbrance.travel_speed === 0) {
+        tip += ' — too heavy to travel, drop something';
+    } else if (encumbrance.travel_speed < 1) {
+        tip += ` — travel ${Math.round((1 - encumbrance.travel_speed) * 100)}% slower`;
+    } else if (encumbrance.travel_speed > 1) {
+        tip += ` — travel ${Math.round((encumbrance.travel_speed - 1) * 100)}% faster`;
+    }
+    if (encumbrance.fatigue_rate > 1) {
+        tip += `, tire ${Math.round((encumbrance.fatigue_rate - 1) * 100)}% faster`;
+    }
+    el.title = tip;
+}
+
 /**
  * Update the resource bar (mana/stamina/rage/ki/cunning) based on character class
  * @param {Object} character - Character data
@@ -219,6 +265,31 @@ function showLevelUpModal(lvl) {
     modal.classList.remove('hidden');
     // Surface an "allocate ability points" prompt if any are now unspent.
     window.refreshLevelUpPointsPrompt?.();
+    applyLevelUpUnlocks();
+}
+
+/**
+ * Apply the level-up server-side and list what it unlocked (new spell slots and
+ * class abilities) in the modal. Safe to call more than once per level.
+ */
+async function applyLevelUpUnlocks() {
+    const row = document.getElementById('level-up-unlocks');
+    if (!row) return;
+    row.classList.add('hidden');
+    try {
+        const { gains } = await gameAPI.applyLevelUp();
+        const lines = [];
+        for (const [slotLevel, count] of Object.entries(gains?.spell_slots || {})) {
+            const label = slotLevel === 'cantrips' ? 'cantrip' : slotLevel.replace('level_', 'level ');
+            lines.push(`+${count} ${label} spell slot${count > 1 ? 's' : ''}`);
+        }
+        for (const name of gains?.abilities || []) lines.push(`New ability: ${name}`);
+        if (lines.length === 0) return;
+        row.innerHTML = lines.map(l => `<div>${l}</div>`).join('');
+        row.classList.remove('hidden');
+    } catch (err) {
+        logger.warn('Level-up apply failed:', err);
+    }
 }
 
 function closeLevelUpModal() {
@@ -371,6 +442,7 @@ export async function updateCharacterDisplay() {
             if (maxWeightEl) maxWeightEl.textContent = Math.round(maxCapacity);
         });
     }
+    updateEncumbranceDisplay(character.encumbrance);
 
     // Update detailed stats tab (if visible)
     updateStatsTab(character);
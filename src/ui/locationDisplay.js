@@ -1157,11 +1157,8 @@ export function showNPCDialogue(dialogueData, npcMessage) {
  */
 async function acceptOfferedQuest(questId, npcId) {
     try {
-        const resp = await fetch(`${API_BASE_URL}/quests/accept`, {
-            method: 'POST',
-            headers: { 'Content-Type': 'application/json' },
-            body: JSON.stringify({ npub: gameAPI.npub, save_id: gameAPI.saveID, quest_id: questId }),
-        });
+        const resp = await gameAPI.postChange(`${API_BASE_URL}/quests/accept`,
+            { npub: gameAPI.npub, save_id: gameAPI.saveID, quest_id: questId });
         const json = await resp.json();
         if (!resp.ok || !json.success) {
             showMessage(json.error ?? 'Could not accept the quest', 'error');
@@ -1550,24 +1547,50 @@ export function showVaultUI(vaultData) {
     title.style.fontSize = '12px';
     title.textContent = '🏦 Vault Storage';
 
+    const slots = vaultData.slots || [];
+    const capacity = vaultData.capacity || slots.length || 40;
+    const freeSlots = vaultData.free_slots ?? slots.filter(s => !s?.item).length;
+    const usage = document.createElement('span');
+    usage.className = freeSlots === 0 ? 'text-red-400' : 'text-gray-400';
+    usage.style.fontSize = '8px';
+    usage.textContent = `${capacity - freeSlots}/${capacity} slots used`;
+    title.appendChild(document.createTextNode(' '));
+    title.appendChild(usage);
+
     const closeButton = document.createElement('button');
     closeButton.className = 'text-white px-2 py-1 font-bold';
     closeButton.style.cssText = 'background: #dc2626; border-top: 2px solid #ef4444; border-left: 2px solid #ef4444; border-right: 2px solid #991b1b; border-bottom: 2px solid #991b1b; font-size: 10px;';
     closeButton.textContent = 'Close';
     closeButton.addEventListener('click', closeVaultUI);
 
+    // Bulk transfer buttons (same chunky style as Close)
+    const bulkButton = (label, handlerName) => {
+        const button = document.createElement('button');
+        button.className = 'text-white px-2 py-1 font-bold';
+        button.style.cssText = 'background: #4b5563; border-top: 2px solid #6b7280; border-left: 2px solid #6b7280; border-right: 2px solid #1f2937; border-bottom: 2px solid #1f2937; font-size: 10px;';
+        button.textContent = label;
+        button.addEventListener('click', () => {
+            import('../systems/inventoryInteractions.js').then(module => module[handlerName]());
+        });
+        return button;
+    };
+    const buttons = document.createElement('div');
+    buttons.className = 'flex gap-1';
+    buttons.appendChild(bulkButton('Store bag', 'storeAllInVault'));
+    buttons.appendChild(bulkButton('Take all', 'takeAllFromVault'));
+    buttons.appendChild(closeButton);
+
     header.appendChild(title);
-    header.appendChild(closeButton);
+    header.appendChild(buttons);
     vaultContainer.appendChild(header);
 
-    // Vault slots grid (40 slots in 8x5 grid)
+    // Vault slots grid (8 per row, as many rows as the vault holds)
     const slotsGrid = document.createElement('div');
     slotsGrid.className = 'grid grid-cols-8 gap-1 flex-1';
     slotsGrid.id = 'vault-slots-grid';
     slotsGrid.style.gridAutoRows = '1fr';
 
-    const slots = vaultData.slots || [];
-    for (let i = 0; i < 40; i++) {
+    for (let i = 0; i < capacity; i++) {
         const slotData = slots[i] || { slot: i, item: null, quantity: 0 };
         const slotElement = createVaultSlot(slotData, i, vaultData.building || vaultData.location);
         slotsGrid.appendChild(slotElement);
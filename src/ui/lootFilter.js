@@ -0,0 +1,49 @@
+/**
+ * Loot Filter Settings
+ *
+ * Settings → Loot: the per-save loot filter the server consults when a fight's
+ * loot is collected. Rejected drops are left on the ground, not lost.
+ *
+ * @module ui/lootFilter
+ */
+
+import { logger } from '../lib/logger.js';
+import { gameAPI } from '../lib/api.js';
+import { eventBus } from '../lib/events.js';
+import { getGameStateSync } from '../state/gameState.js';
+
+/** Fill the Settings → Loot controls from the save's current filter. */
+export function syncLootFilterForm() {
+    const filter = getGameStateSync()?.character?.loot_filter || {};
+    const auto = document.getElementById('loot-auto-pickup');
+    const rarity = document.getElementById('loot-min-rarity');
+    const value = document.getElementById('loot-min-value');
+    if (auto) auto.checked = !filter.manual_pickup;
+    if (rarity) rarity.value = filter.min_rarity || '';
+    if (value) value.value = filter.min_value || 0;
+}
+
+/** Send the Settings → Loot controls to the server as the save's loot filter. */
+export async function applyLootFilterForm() {
+    const filter = getGameStateSync()?.character?.loot_filter || {};
+    const params = {
+        manual_pickup: !document.getElementById('loot-auto-pickup')?.checked,
+        min_rarity: document.getElementById('loot-min-rarity')?.value || '',
+        min_value: Math.max(0, parseInt(document.getElementById('loot-min-value')?.value, 10) || 0),
+        ignore: filter.ignore || [] // edited elsewhere; keep it as is
+    };
+    try {
+        const result = await gameAPI.sendAction('set_loot_filter', params);
+        if (!result?.success) {
+            window.showMessage?.(result?.error || 'Could not update the loot filter.', 'error');
+            return;
+        }
+        const state = getGameStateSync();
+        if (state?.character) state.character.loot_filter = result.data?.loot_filter || null;
+        window.showMessage?.(result.message, 'info');
+    } catch (err) {
+        logger.error('Loot filter update failed:', err);
+    }
+}
+
+eventBus.on('gameStateLoaded', syncLootFilterForm);
@@ -52,11 +52,7 @@ export function getEnvPOIs() {
 }
 
 async function poiPost(path, body) {
-    const resp = await fetch(`${API_BASE_URL}${path}`, {
-        method: 'POST',
-        headers: { 'Content-Type': 'application/json' },
-        body: JSON.stringify({ npub: gameAPI.npub, save_id: gameAPI.saveID, ...body }),
-    });
+    const resp = await gameAPI.postChange(`${API_BASE_URL}${path}`, { npub: gameAPI.npub, save_id: gameAPI.saveID, ...body });
     return resp.json();
 }
 
@@ -25,6 +25,7 @@ func setupCharacterTestServer(t *testing.T) *helpers.TestServer {
 	ts.Mux.HandleFunc("/api/introductions", character.IntroductionsHandler)
 	ts.Mux.HandleFunc("/api/starting-gear", character.StartingGearHandler)
 	ts.Mux.HandleFunc("/api/character", character.CharacterHandler)
+	ts.Mux.HandleFunc("/api/character/generate", character.GenerateSeededHandler)
 	ts.Mux.HandleFunc("/api/character/create-save", character.CreateCharacterHandler)
 
 	return ts
@@ -49,6 +50,42 @@ func TestWeightsHandler(t *testing.T) {
 	}
 }
 
+func TestGenerateSeededHandler(t *testing.T) {
+	ts := setupCharacterTestServer(t)
+	defer ts.Close()
+	defer db.Close()
+
+	// The same seed rolls the same character every time.
+	first := helpers.AssertJSON(t, ts.GET(t, "/api/character/generate?seed=share-my-roll"))
+	second := helpers.AssertJSON(t, ts.GET(t, "/api/character/generate?seed=share-my-roll"))
+	if first["seed"] != "share-my-roll" {
+		t.Errorf("seed = %v, want share-my-roll", first["seed"])
+	}
+	c1, _ := first["character"].(map[string]interface{})
+	c2, _ := second["character"].(map[string]interface{})
+	if c1 == nil || c1["race"] == "" || c1["class"] == "" {
+		t.Fatalf("expected a generated character, got %v", first)
+	}
+	for _, field := range []string{"race", "class", "background", "alignment"} {
+		if c1[field] != c2[field] {
+			t.Errorf("%s differs between identical seeds: %v vs %v", field, c1[field], c2[field])
+		}
+	}
+
+	// No seed: a fresh one comes back so the roll can be reproduced.
+	fresh := helpers.AssertJSON(t, ts.GET(t, "/api/character/generate"))
+	seed, _ := fresh["seed"].(string)
+	if seed == "" {
+		t.Fatal("expected a generated seed when none is given")
+	}
+	again := helpers.AssertJSON(t, ts.GET(t, "/api/character/generate?seed="+seed))
+	cf, _ := fresh["character"].(map[string]interface{})
+	ca, _ := again["character"].(map[string]interface{})
+	if cf["race"] != ca["race"] || cf["class"] != ca["class"] {
+		t.Errorf("rerolling with the returned seed gave %v, want %v", ca, cf)
+	}
+}
+
 func TestIntroductionsHandler(t *testing.T) {
 	ts := setupCharacterTestServer(t)
 	defer ts.Close()
@@ -0,0 +1,155 @@
+package api_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"pubkey-quest/cmd/server/api/game"
+	"pubkey-quest/cmd/server/db"
+	"pubkey-quest/cmd/server/game/gameutil"
+	"pubkey-quest/cmd/server/session"
+	"pubkey-quest/cmd/server/utils"
+	"pubkey-quest/tests/helpers"
+	"pubkey-quest/types"
+)
+
+// withDeathConfig sets game.death for one test.
+func withDeathConfig(t *testing.T, cfg utils.DeathConfig) {
+	t.Helper()
+	old := utils.AppConfig.Game.Death
+	utils.AppConfig.Game.Death = cfg
+	t.Cleanup(func() { utils.AppConfig.Game.Death = old })
+}
+
+func TestLoadDeathPenalty(t *testing.T) {
+	withDeathConfig(t, utils.DeathConfig{})
+	if p := game.LoadDeathPenalty(); p.Hardcore || p.KeepItems != game.DefaultDeathKeepItems || !p.LoseGold {
+		t.Errorf("unset config = %+v, want the standard keep-3 penalty", p)
+	}
+
+	keepAll, keepGold := -5, false
+	withDeathConfig(t, utils.DeathConfig{Mode: "hardcore", KeepItems: &keepAll, LoseGold: &keepGold})
+	if p := game.LoadDeathPenalty(); !p.Hardcore || p.KeepItems != -1 || p.LoseGold {
+		t.Errorf("configured = %+v, want hardcore, keep everything, keep gold", p)
+	}
+}
+
+func deathSession(npub, saveID string) *session.GameSession {
+	return &session.GameSession{
+		Npub:   npub,
+		SaveID: saveID,
+		SaveData: types.SaveFile{
+			HP: 0, MaxHP: 12, Mana: 0, MaxMana: 4,
+			Location:            "darkwood-forest",
+			LocationsDiscovered: []string{"kingdom"},
+			Inventory: map[string]interface{}{
+				"general_slots": []interface{}{
+					map[string]interface{}{"item": "gold-piece", "quantity": float64(40), "slot": float64(0)},
+					map[string]interface{}{"item": "dagger", "quantity": float64(1), "slot": float64(1)},
+				},
+				"gear_slots": map[string]interface{}{},
+			},
+		},
+	}
+}
+
+// "Lose gold only": everything stays where it is except the gold.
+func TestApplyDeathKeepEverythingButGold(t *testing.T) {
+	keepAll, loseGold := -1, true
+	withDeathConfig(t, utils.DeathConfig{KeepItems: &keepAll, LoseGold: &loseGold})
+
+	sess := deathSession("npub1test", "save_gold")
+	kept, permadeath := game.ApplyDeath(sess)
+	if permadeath || kept != nil {
+		t.Fatalf("ApplyDeath = %v, %v; want nothing stripped and no permadeath", kept, permadeath)
+	}
+	save := &sess.SaveData
+	if gold := gameutil.GetGoldQuantity(save); gold != 0 {
+		t.Errorf("gold after death = %d, want 0", gold)
+	}
+	if !gameutil.PlayerHasItem(save, "dagger") {
+		t.Error("the dagger should have been kept in place")
+	}
+	if save.HP != save.MaxHP || save.Location != "kingdom" {
+		t.Errorf("after death HP %d/%d at %s, want full HP at kingdom", save.HP, save.MaxHP, save.Location)
+	}
+}
+
+// Hardcore deletes the save file and ends the run.
+func TestApplyDeathHardcoreDeletesSave(t *testing.T) {
+	t.Chdir(t.TempDir())
+	withDeathConfig(t, utils.DeathConfig{Mode: "hardcore"})
+
+	sess := deathSession("npub1hardcore", "save_hc")
+	path := session.GetSavePath(sess.Npub, sess.SaveID)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := session.WriteSaveFile(path, &sess.SaveData); err != nil {
+		t.Fatal(err)
+	}
+
+	kept, permadeath := game.ApplyDeath(sess)
+	if !permadeath || kept != nil {
+		t.Fatalf("ApplyDeath = %v, %v; want permadeath", kept, permadeath)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("save file still there after a hardcore death (stat err %v)", err)
+	}
+}
+
+// A death sends the player to their race's starting city, not to whichever
+// town they discovered first.
+func TestDeathReturnsToRacialStartingCity(t *testing.T) {
+	helpers.SetupTestEnvironment(t)
+	if err := db.InitDatabase(); err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+	withDeathConfig(t, utils.DeathConfig{})
+
+	sess := deathSession("npub1test", "save_dwarf")
+	sess.SaveData.Race = "Dwarf"
+	if _, permadeath := game.ApplyDeath(sess); permadeath {
+		t.Fatal("standard death ended the run")
+	}
+	if got := sess.SaveData.Location; got != "ironpeak" {
+		t.Errorf("dwarf respawned at %q, want ironpeak (first discovered was kingdom)", got)
+	}
+}
+
+// A hardcore death whose save can't be deleted doesn't end the run: the session
+// stays loaded and the standard penalty applies instead.
+func TestApplyDeathHardcoreFallsBackWhenDeleteFails(t *testing.T) {
+	t.Chdir(t.TempDir())
+	keepAll := -1
+	withDeathConfig(t, utils.DeathConfig{Mode: "hardcore", KeepItems: &keepAll})
+
+	npub, saveID := "npub1hcfail", "save_hcfail"
+	// A non-empty directory where the save file should be: os.Remove fails.
+	path := session.GetSavePath(npub, saveID)
+	if err := os.MkdirAll(filepath.Join(path, "stuck"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	load := func(string, string) (*types.SaveFile, error) {
+		save := deathSession(npub, saveID).SaveData
+		return &save, nil
+	}
+	sm := session.GetSessionManager()
+	sess, err := sm.SessionManager.LoadSession(npub, saveID, load, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("LoadSession: %v", err)
+	}
+	defer sm.UnloadSession(npub, saveID)
+
+	if _, permadeath := game.ApplyDeath(sess); permadeath {
+		t.Fatal("ApplyDeath reported permadeath although the save wasn't deleted")
+	}
+	if _, err := sm.GetSession(npub, saveID); err != nil {
+		t.Errorf("session unloaded after a failed delete: %v", err)
+	}
+	save := &sess.SaveData
+	if save.HP != save.MaxHP || save.Location != "kingdom" {
+		t.Errorf("after death HP %d/%d at %s, want the standard penalty (full HP at kingdom)", save.HP, save.MaxHP, save.Location)
+	}
+}
@@ -0,0 +1,94 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	"pubkey-quest/cmd/server/db"
+	"pubkey-quest/cmd/server/session"
+	"pubkey-quest/types"
+)
+
+// Retries of one action_id sent at the same time apply it once: the rest wait
+// for the first and get its response replayed.
+func TestConcurrentRetriesApplyOnce(t *testing.T) {
+	ts := setupGameTestServer(t)
+	defer ts.Close()
+	defer db.Close()
+
+	npub, saveID := "npub1dedupe", "save_dedupe"
+	load := func(string, string) (*types.SaveFile, error) {
+		general := make([]interface{}, 4)
+		for i := range general {
+			general[i] = map[string]interface{}{"item": nil, "quantity": float64(0), "slot": float64(i)}
+		}
+		return &types.SaveFile{
+			HP: 10, MaxHP: 10, Location: "kingdom", Revision: 3,
+			Stats: map[string]interface{}{
+				"strength": 10, "dexterity": 10, "constitution": 10,
+				"intelligence": 10, "wisdom": 10, "charisma": 10,
+			},
+			Inventory: map[string]interface{}{
+				"general_slots": general,
+				"gear_slots":    map[string]interface{}{},
+			},
+		}, nil
+	}
+	sm := session.GetSessionManager()
+	sess, err := sm.SessionManager.LoadSession(npub, saveID, load, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("LoadSession: %v", err)
+	}
+	defer sm.UnloadSession(npub, saveID)
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"npub": npub, "save_id": saveID, "action_id": "add-arrow-1", "revision": 3,
+		"action": map[string]interface{}{
+			"type":   "add_item",
+			"params": map[string]interface{}{"item_id": "arrows", "quantity": 1},
+		},
+	})
+
+	const retries = 8
+	var wg sync.WaitGroup
+	statuses := make([]int, retries)
+	for i := 0; i < retries; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := http.Post(ts.URL+"/api/game/action", "application/json", bytes.NewReader(body))
+			if err != nil {
+				return
+			}
+			resp.Body.Close()
+			statuses[i] = resp.StatusCode
+		}(i)
+	}
+	wg.Wait()
+
+	for i, status := range statuses {
+		if status != http.StatusOK {
+			t.Errorf("retry %d: status %d, want 200 (applied or replayed)", i, status)
+		}
+	}
+	arrows := 0
+	for _, s := range sess.SaveData.Inventory["general_slots"].([]interface{}) {
+		if slot, _ := s.(map[string]interface{}); slot["item"] == "arrows" {
+			switch q := slot["quantity"].(type) {
+			case float64:
+				arrows += int(q)
+			case int:
+				arrows += q
+			}
+		}
+	}
+	if arrows != 1 {
+		t.Errorf("%d arrows after %d concurrent retries of one action, want 1", arrows, retries)
+	}
+	if sess.SaveData.Revision != 4 {
+		t.Errorf("revision = %d, want 4 (one applied action)", sess.SaveData.Revision)
+	}
+}
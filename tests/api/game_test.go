@@ -6,7 +6,9 @@ import (
 
 	"pubkey-quest/cmd/server/api/game"
 	"pubkey-quest/cmd/server/db"
+	"pubkey-quest/cmd/server/game/character"
 	"pubkey-quest/tests/helpers"
+	"pubkey-quest/types"
 )
 
 func setupGameTestServer(t *testing.T) *helpers.TestServer {
@@ -29,6 +31,7 @@ func setupGameTestServer(t *testing.T) *helpers.TestServer {
 	ts.Mux.HandleFunc("/api/session/cleanup", game.CleanupSessionHandler)
 	ts.Mux.HandleFunc("/api/game/action", game.GameActionHandler)
 	ts.Mux.HandleFunc("/api/game/state", game.GetGameStateHandler)
+	ts.Mux.HandleFunc("/api/game/sheet", game.CharacterSheetHandler)
 	ts.Mux.HandleFunc("/api/shop/", game.ShopHandler)
 
 	return ts
@@ -219,6 +222,75 @@ func TestGetGameStateHandler(t *testing.T) {
 	})
 }
 
+func TestCharacterSheetHandler(t *testing.T) {
+	ts := setupGameTestServer(t)
+	defer ts.Close()
+	defer db.Close()
+
+	t.Run("requires GET method", func(t *testing.T) {
+		resp := ts.POST(t, "/api/game/sheet", nil)
+		helpers.AssertStatus(t, resp, http.StatusMethodNotAllowed)
+	})
+
+	t.Run("requires npub and save_id", func(t *testing.T) {
+		resp := ts.GET(t, "/api/game/sheet")
+		helpers.AssertStatus(t, resp, http.StatusBadRequest)
+	})
+
+	t.Run("fails for non-existent session", func(t *testing.T) {
+		resp := ts.GET(t, "/api/game/sheet?npub="+helpers.MockNpub+"&save_id=nonexistent_save")
+		helpers.AssertStatus(t, resp, http.StatusNotFound)
+	})
+}
+
+// The sheet derives level, modifiers and AC from the save and resolves
+// equipped item names, listing weapons first.
+func TestBuildCharacterSheet(t *testing.T) {
+	helpers.SetupTestEnvironment(t)
+	if err := db.InitDatabase(); err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer db.Close()
+
+	adv, err := character.LoadAdvancement(db.GetDB())
+	if err != nil {
+		t.Fatalf("load advancement: %v", err)
+	}
+	save := &types.SaveFile{
+		Race: "Human", Class: "Fighter", Experience: 300, HP: 18, MaxHP: 20,
+		Stats: map[string]interface{}{
+			"strength": 16.0, "dexterity": 14.0, "constitution": 14.0,
+			"intelligence": 8.0, "wisdom": 10.0, "charisma": 9.0,
+		},
+		Inventory: map[string]interface{}{
+			"gear_slots": map[string]interface{}{
+				"head":     map[string]interface{}{"item": nil, "quantity": 0},
+				"mainhand": map[string]interface{}{"item": "longsword", "quantity": 1.0},
+			},
+		},
+	}
+
+	sheet := game.BuildCharacterSheet(db.GetDB(), save, adv)
+	if want := character.GetLevelFromXP(300, adv); sheet.Level != want || sheet.ProficiencyBonus != character.ProficiencyBonus(want) {
+		t.Errorf("level %d / proficiency %d, want level %d", sheet.Level, sheet.ProficiencyBonus, want)
+	}
+	if len(sheet.Abilities) != 6 || sheet.Abilities[0].Ability != "strength" || sheet.Abilities[0].Modifier != 3 {
+		t.Errorf("abilities = %+v", sheet.Abilities)
+	}
+	if cha := sheet.Abilities[5]; cha.Modifier != -1 {
+		t.Errorf("CHA 9 modifier = %d, want -1", cha.Modifier)
+	}
+	if sheet.ArmorClass != 12 {
+		t.Errorf("unarmored AC with DEX 14 = %d, want 12", sheet.ArmorClass)
+	}
+	if len(sheet.Equipment) != 1 || sheet.Equipment[0].Name != "Longsword" {
+		t.Errorf("equipment = %+v, want just the longsword", sheet.Equipment)
+	}
+	if len(sheet.WeaponProficiencies) == 0 {
+		t.Error("a fighter should list weapon proficiencies")
+	}
+}
+
 func TestShopHandler(t *testing.T) {
 	ts := setupGameTestServer(t)
 	defer ts.Close()
@@ -0,0 +1,118 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"pubkey-quest/cmd/server/api/game"
+	"pubkey-quest/cmd/server/db"
+	"pubkey-quest/cmd/server/session"
+	"pubkey-quest/types"
+)
+
+// postJSON posts body to the test server and decodes the JSON reply.
+func postJSON(t *testing.T, url string, body map[string]interface{}) (int, map[string]interface{}) {
+	t.Helper()
+	raw, _ := json.Marshal(body)
+	resp, err := http.Post(url, "application/json", bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("POST %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+	var out map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&out)
+	return resp.StatusCode, out
+}
+
+// A shop purchase bumps the save revision and reports it, so the client's next
+// action isn't refused as stale; a request against the old revision is.
+func TestShopBuyReturnsNewRevision(t *testing.T) {
+	ts := setupGameTestServer(t)
+	defer ts.Close()
+	defer db.Close()
+
+	npub, saveID := "npub1revision", "save_revision"
+	load := func(string, string) (*types.SaveFile, error) {
+		general := make([]interface{}, 4)
+		for i := range general {
+			general[i] = map[string]interface{}{"item": nil, "quantity": float64(0), "slot": float64(i)}
+		}
+		general[0] = map[string]interface{}{"item": "gold-piece", "quantity": float64(100), "slot": float64(0)}
+		return &types.SaveFile{
+			HP: 10, MaxHP: 10, Location: "goldenhaven", Revision: 7,
+			Stats: map[string]interface{}{
+				"strength": 10, "dexterity": 10, "constitution": 10,
+				"intelligence": 10, "wisdom": 10, "charisma": 10,
+			},
+			Inventory: map[string]interface{}{
+				"general_slots": general,
+				"gear_slots":    map[string]interface{}{},
+			},
+		}, nil
+	}
+	sm := session.GetSessionManager()
+	sess, err := sm.SessionManager.LoadSession(npub, saveID, load, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("LoadSession: %v", err)
+	}
+	defer sm.UnloadSession(npub, saveID)
+
+	buy := map[string]interface{}{
+		"npub": npub, "save_id": saveID, "merchant_id": "goldenhaven-barkeep",
+		"item_id": "beer", "quantity": 1, "action": "buy", "revision": 7,
+	}
+	status, out := postJSON(t, ts.URL+"/api/shop/buy", buy)
+	if status != http.StatusOK || out["success"] != true {
+		t.Fatalf("buy: status %d, body %v", status, out)
+	}
+	if out["revision"] != float64(8) || sess.SaveData.Revision != 8 {
+		t.Errorf("buy reported revision %v, session at %d; want 8", out["revision"], sess.SaveData.Revision)
+	}
+
+	// The same purchase again at the revision it was first made against.
+	status, _ = postJSON(t, ts.URL+"/api/shop/buy", buy)
+	if status != http.StatusConflict {
+		t.Errorf("stale buy: status %d, want 409", status)
+	}
+	if sess.SaveData.Revision != 8 {
+		t.Errorf("stale buy moved the revision to %d", sess.SaveData.Revision)
+	}
+}
+
+// The combat and POI endpoints refuse a stale revision like /game/action.
+func TestCombatAndPOIRefuseStaleRevision(t *testing.T) {
+	ts := setupGameTestServer(t)
+	defer ts.Close()
+	defer db.Close()
+	ts.Mux.HandleFunc("/api/combat/start", game.StartCombatHandler)
+	ts.Mux.HandleFunc("/api/poi/advance", game.POIAdvanceHandler)
+
+	npub, saveID := "npub1stale", "save_stale"
+	load := func(string, string) (*types.SaveFile, error) {
+		return &types.SaveFile{HP: 10, MaxHP: 10, Location: "kingdom", Revision: 5}, nil
+	}
+	sm := session.GetSessionManager()
+	sess, err := sm.SessionManager.LoadSession(npub, saveID, load, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("LoadSession: %v", err)
+	}
+	defer sm.UnloadSession(npub, saveID)
+
+	for path, body := range map[string]map[string]interface{}{
+		"/api/combat/start": {"npub": npub, "save_id": saveID, "monster_id": "goblin", "revision": 4},
+		"/api/poi/advance":  {"npub": npub, "save_id": saveID, "next": "n2", "revision": 4},
+	} {
+		status, out := postJSON(t, ts.URL+path, body)
+		if status != http.StatusConflict {
+			t.Errorf("%s: status %d, want 409", path, status)
+		}
+		if out["error_code"] != types.ErrCodeRevisionConflict {
+			t.Errorf("%s: error_code %v, want %s", path, out["error_code"], types.ErrCodeRevisionConflict)
+		}
+	}
+	if sess.ActiveCombat != nil {
+		t.Error("a stale /combat/start still started a fight")
+	}
+}
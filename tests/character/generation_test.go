@@ -0,0 +1,60 @@
+package character_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/server/game/character"
+	"pubkey-quest/types"
+)
+
+func testWeights() *types.WeightData {
+	return &types.WeightData{
+		Races:                    []string{"Human", "Elf"},
+		RaceWeights:              []int{60, 40},
+		ClassWeightsByRace:       map[string]map[string]int{"Human": {"Fighter": 1}, "Elf": {"Wizard": 1}},
+		BackgroundWeightsByClass: map[string]map[string]int{"Fighter": {"Soldier": 1}, "Wizard": {"Sage": 1}},
+		Alignments:               []string{"Neutral"},
+		AlignmentWeights:         []int{1},
+	}
+}
+
+func TestGenerateCharacterFromSeedIsDeterministic(t *testing.T) {
+	w := testWeights()
+	a := character.GenerateCharacterFromSeed("abc", w)
+	b := character.GenerateCharacterFromSeed("abc", w)
+	if a.Race != b.Race || a.Class != b.Class || a.Background != b.Background {
+		t.Errorf("same seed rolled %+v and %+v", a, b)
+	}
+	for _, stat := range character.StatNames {
+		if a.Stats[stat] != b.Stats[stat] {
+			t.Errorf("%s differs: %d vs %d", stat, a.Stats[stat], b.Stats[stat])
+		}
+	}
+	if len(character.SeedKey("abc")) != 64 {
+		t.Errorf("SeedKey should be a 32-byte hex key, got %q", character.SeedKey("abc"))
+	}
+}
+
+func TestValidateWeights(t *testing.T) {
+	if err := character.ValidateWeights(testWeights()); err != nil {
+		t.Fatalf("valid weights rejected: %v", err)
+	}
+
+	mismatched := testWeights()
+	mismatched.RaceWeights = []int{100}
+	if err := character.ValidateWeights(mismatched); err == nil {
+		t.Error("race/weight length mismatch should fail")
+	}
+
+	noClasses := testWeights()
+	delete(noClasses.ClassWeightsByRace, "Elf")
+	if err := character.ValidateWeights(noClasses); err == nil {
+		t.Error("a race without class weights should fail")
+	}
+
+	noBackgrounds := testWeights()
+	delete(noBackgrounds.BackgroundWeightsByClass, "Wizard")
+	if err := character.ValidateWeights(noBackgrounds); err == nil {
+		t.Error("a class without background weights should fail")
+	}
+}
@@ -0,0 +1,59 @@
+package character_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/server/game/character"
+	"pubkey-quest/types"
+)
+
+func TestBuildLearnedContent(t *testing.T) {
+	catalog := character.LearnedCatalog{
+		Spells: map[string]character.LearnedEntry{
+			"fire-bolt": {ID: "fire-bolt", Name: "Fire Bolt", Level: 0, Detail: "evocation"},
+			"shield":    {ID: "shield", Name: "Shield", Level: 1, Detail: "abjuration"},
+		},
+		Locations: map[string]character.LearnedEntry{
+			"kingdom": {ID: "kingdom", Name: "The Kingdom", Detail: "city"},
+		},
+		Music: map[string]character.LearnedEntry{
+			"A Just King": {ID: "A Just King", Name: "A Just King", Detail: "/res/aud/A Just King.mp3"},
+		},
+		ClassAbilities: []character.LearnedEntry{
+			{ID: "second-wind", Name: "Second Wind", Level: 1},
+			{ID: "action-surge", Name: "Action Surge", Level: 2},
+			{ID: "indomitable", Name: "Indomitable", Level: 9},
+		},
+	}
+	save := &types.SaveFile{
+		KnownSpells:         []string{"shield", "fire-bolt", "shield", "mystery-spell"},
+		LocationsDiscovered: []string{"kingdom"},
+		MusicTracksUnlocked: []string{"A Just King"},
+	}
+
+	got := character.BuildLearnedContent(save, 2, catalog)
+
+	if len(got.Spells) != 3 {
+		t.Fatalf("spells = %+v, want shield, fire-bolt, mystery-spell (deduplicated)", got.Spells)
+	}
+	if got.Spells[0].Name != "Shield" || got.Spells[0].Level != 1 || got.Spells[0].Detail != "abjuration" {
+		t.Errorf("shield entry = %+v", got.Spells[0])
+	}
+	if got.Spells[2].Name != "mystery-spell" {
+		t.Errorf("uncatalogued spell should fall back to its ID, got %+v", got.Spells[2])
+	}
+	if len(got.Abilities) != 2 || got.Abilities[1].ID != "action-surge" {
+		t.Errorf("level 2 abilities = %+v, want second-wind + action-surge", got.Abilities)
+	}
+	if len(got.Locations) != 1 || got.Locations[0].Name != "The Kingdom" {
+		t.Errorf("locations = %+v", got.Locations)
+	}
+	if len(got.Music) != 1 || got.Music[0].Detail == "" {
+		t.Errorf("music = %+v", got.Music)
+	}
+
+	empty := character.BuildLearnedContent(&types.SaveFile{}, 1, character.LearnedCatalog{})
+	if empty.Spells == nil || empty.Abilities == nil || empty.Locations == nil || empty.Music == nil {
+		t.Error("empty sections should encode as [] rather than null")
+	}
+}
@@ -0,0 +1,74 @@
+package character_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/server/game/character"
+	"pubkey-quest/types"
+)
+
+// Applying a level-up grows the spell slots to the new level's counts (keeping
+// prepared spells), reports the abilities unlocked on the way, and does nothing
+// the second time.
+func TestApplyLevelUp(t *testing.T) {
+	adv := []types.AdvancementEntry{
+		{ExperiencePoints: 0, Level: 1, XPMultiplier: 1.0},
+		{ExperiencePoints: 250, Level: 2, XPMultiplier: 1.0},
+		{ExperiencePoints: 650, Level: 3, XPMultiplier: 1.0},
+	}
+	slotTable := map[int]map[string]int{
+		1: {"cantrips": 3, "level_1": 2},
+		2: {"cantrips": 3, "level_1": 3},
+		3: {"cantrips": 3, "level_1": 4, "level_2": 2},
+	}
+	abilities := []character.GuideAbilityUnlock{
+		{Name: "Arcane Recovery", UnlockLevel: 1},
+		{Name: "Sculpt Spells", UnlockLevel: 2},
+		{Name: "Empowered Evocation", UnlockLevel: 3},
+		{Name: "Overchannel", UnlockLevel: 10},
+	}
+	save := &types.SaveFile{
+		Class: "Wizard",
+		Stats: map[string]interface{}{"constitution": float64(14), "intelligence": float64(16)},
+		SpellSlots: map[string]interface{}{
+			"cantrips": []interface{}{},
+			"level_1": []interface{}{
+				map[string]interface{}{"slot": float64(0), "spell": "magic-missile", "quantity": float64(1)},
+				map[string]interface{}{"slot": float64(1), "spell": nil, "quantity": float64(0)},
+			},
+		},
+	}
+
+	if g := character.ApplyLevelUp(save, adv, slotTable, abilities); g.Applied {
+		t.Fatalf("no XP, no level-up to apply: %+v", g)
+	}
+
+	save.Experience = 700 // level 3, two levels at once
+	g := character.ApplyLevelUp(save, adv, slotTable, abilities)
+	if !g.Applied || g.FromLevel != 1 || g.Level != 3 {
+		t.Fatalf("want 1→3 applied, got %+v", g)
+	}
+	if g.SpellSlots["level_1"] != 2 || g.SpellSlots["level_2"] != 2 || g.SpellSlots["cantrips"] != 3 {
+		t.Errorf("slots added = %v, want level_1 +2, level_2 +2, cantrips +3", g.SpellSlots)
+	}
+	level1 := save.SpellSlots["level_1"].([]interface{})
+	if len(level1) != 4 || level1[0].(map[string]interface{})["spell"] != "magic-missile" {
+		t.Errorf("level_1 slots should grow to 4 keeping the prepared spell, got %v", level1)
+	}
+	if len(g.Abilities) != 2 || g.Abilities[0] != "Sculpt Spells" || g.Abilities[1] != "Empowered Evocation" {
+		t.Errorf("abilities = %v, want the level 2 and 3 unlocks", g.Abilities)
+	}
+	if g.HPGained != 12 || save.MaxHP != 20 { // wizard d6 CON14: +6 per level
+		t.Errorf("HP gained %d (max %d), want +12 to 20", g.HPGained, save.MaxHP)
+	}
+	if save.LevelApplied != 3 {
+		t.Errorf("LevelApplied = %d, want 3", save.LevelApplied)
+	}
+
+	if again := character.ApplyLevelUp(save, adv, slotTable, abilities); again.Applied || again.SpellSlots != nil {
+		t.Errorf("applying twice at the same level must do nothing, got %+v", again)
+	}
+	if len(save.SpellSlots["level_1"].([]interface{})) != 4 {
+		t.Error("a repeat apply must not add slots")
+	}
+}
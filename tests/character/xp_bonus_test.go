@@ -55,6 +55,23 @@ func TestBonusXP(t *testing.T) {
 	}
 }
 
+// XPToNextLevel counts down to the next threshold and stops at the top level.
+func TestXPToNextLevel(t *testing.T) {
+	adv := testAdvancement() // a level every 100 XP
+	cases := []struct{ xp, want int }{
+		{0, 100},
+		{199, 1},
+		{200, 100},
+		{450, 50},
+		{xpForLevel(20), 0},
+	}
+	for _, c := range cases {
+		if got := character.XPToNextLevel(c.xp, adv); got != c.want {
+			t.Errorf("XPToNextLevel(%d) = %d, want %d", c.xp, got, c.want)
+		}
+	}
+}
+
 // The guide surfaces the multiplier so the player can see the bonus per level.
 func TestBuildLevelGuide_XPMultiplier(t *testing.T) {
 	adv := testAdvancement()
@@ -0,0 +1,63 @@
+package codex_test
+
+import (
+	"strings"
+	"testing"
+
+	"pubkey-quest/cmd/codex/validation"
+	"pubkey-quest/tests/helpers"
+)
+
+func TestCheckAbility(t *testing.T) {
+	effects := map[string]bool{"rage": true}
+	clean := map[string]interface{}{
+		"id": "enter-rage", "name": "Enter Rage", "class": "barbarian",
+		"unlock_level": float64(1), "resource_cost": float64(50),
+		"scaling_tiers": []interface{}{
+			map[string]interface{}{"effects_applied": []interface{}{"rage"}},
+		},
+	}
+	if issues := validation.CheckAbility(clean, "barbarian", effects); len(issues) != 0 {
+		t.Fatalf("clean ability flagged: %+v", issues)
+	}
+
+	broken := map[string]interface{}{
+		"id": "enter-rage", "class": "fighter",
+		"unlock_level": float64(25), "resource_cost": float64(-1),
+		"scaling_tiers": []interface{}{
+			map[string]interface{}{"effects_applied": []interface{}{"rage", "enter-rage-t2"}},
+		},
+	}
+	issues := validation.CheckAbility(broken, "barbarian", effects)
+	want := []string{"name", "class", "unlock_level", "resource_cost", "scaling_tiers.effects_applied"}
+	if len(issues) != len(want) {
+		t.Fatalf("want %d issues, got %+v", len(want), issues)
+	}
+	for i, field := range want {
+		if issues[i].Field != field {
+			t.Errorf("issue %d: want field %q, got %+v", i, field, issues[i])
+		}
+	}
+	last := issues[len(issues)-1]
+	if last.Type != "warning" || !strings.Contains(last.Message, "enter-rage-t2") || strings.Contains(last.Message, "rage,") {
+		t.Errorf("missing effect should be a warning naming only the unknown id: %+v", last)
+	}
+
+	if issues := validation.CheckAbility(map[string]interface{}{"id": "x", "name": "X", "class": "monk"}, "monk", effects); len(issues) != 1 || issues[0].Field != "unlock_level" {
+		t.Errorf("unlock_level is required, got %+v", issues)
+	}
+}
+
+// The shipped abilities carry no errors.
+func TestValidateAbilitiesShippedData(t *testing.T) {
+	helpers.SetupTestEnvironment(t)
+	issues, _, err := validation.ValidateAbilities()
+	if err != nil {
+		t.Fatalf("ValidateAbilities: %v", err)
+	}
+	for _, issue := range issues {
+		if issue.Type == "error" {
+			t.Errorf("%s: %s", issue.File, issue.Message)
+		}
+	}
+}
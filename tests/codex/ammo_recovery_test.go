@@ -0,0 +1,40 @@
+package codex_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"pubkey-quest/cmd/codex/validation"
+)
+
+func TestCheckAmmoRecovery(t *testing.T) {
+	cases := []struct {
+		name  string
+		block string
+		field string // expected issue field; "" = clean
+	}{
+		{"full", `{"victory_rate":0.5,"flee_rate":0.25,"drop_unrecovered":true}`, ""},
+		{"defaults", `{}`, ""},
+		{"rate above one", `{"victory_rate":2}`, "ammo_recovery.victory_rate"},
+		{"negative flee", `{"flee_rate":-0.5}`, "ammo_recovery.flee_rate"},
+		{"string rate", `{"flee_rate":"half"}`, "ammo_recovery.flee_rate"},
+		{"drop not bool", `{"drop_unrecovered":"yes"}`, "ammo_recovery.drop_unrecovered"},
+		{"not an object", `[0.5]`, "ammo_recovery"},
+	}
+	for _, c := range cases {
+		var block interface{}
+		if err := json.Unmarshal([]byte(c.block), &block); err != nil {
+			t.Fatalf("%s: bad fixture: %v", c.name, err)
+		}
+		issues := validation.CheckAmmoRecovery(block)
+		if c.field == "" {
+			if len(issues) > 0 {
+				t.Errorf("%s: unexpected issues %+v", c.name, issues)
+			}
+			continue
+		}
+		if len(issues) != 1 || issues[0].Type != "error" || issues[0].Field != c.field {
+			t.Errorf("%s: want one error on %s, got %+v", c.name, c.field, issues)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package codex_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"pubkey-quest/cmd/codex/itemeditor"
+)
+
+func postTagEdit(t *testing.T, handler http.HandlerFunc, req itemeditor.TagEditRequest) *httptest.ResponseRecorder {
+	t.Helper()
+	body, err := json.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodPost, "/api/refactor/tags", bytes.NewReader(body)))
+	return rec
+}
+
+// A bulk edit previews the tags each matching file gains and loses, then
+// rewrites only those files, keeping properties the Item struct doesn't model.
+func TestBulkTagEdit(t *testing.T) {
+	t.Chdir(t.TempDir())
+	itemsDir := filepath.Join("game-data", "items")
+	if err := os.MkdirAll(itemsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"greatsword.json": `{"id": "greatsword", "name": "Greatsword", "type": "Martial Melee Weapons", "rarity": "common", "tags": ["weapon", "heavy"]}`,
+		"maul.json":       `{"id": "maul", "name": "Maul", "type": "Martial Melee Weapons", "rarity": "common", "tags": ["weapon", "heavy", "two-handed"]}`,
+		"longsword.json":  `{"id": "longsword", "name": "Longsword", "type": "Martial Melee Weapons", "rarity": "common", "damage_versatile": "1d10", "tags": ["weapon", "versatile"]}`,
+	}
+	for name, body := range files {
+		if err := os.WriteFile(filepath.Join(itemsDir, name), []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	editor := itemeditor.New()
+	if err := editor.LoadItems(); err != nil {
+		t.Fatal(err)
+	}
+
+	req := itemeditor.TagEditRequest{
+		Filter:     itemeditor.ItemFilter{Tag: "heavy"},
+		AddTags:    []string{"two-handed"},
+		RemoveTags: []string{"heavy"},
+	}
+	rec := postTagEdit(t, editor.HandleTagEditPreview, req)
+	var preview itemeditor.TagEditPreview
+	if err := json.Unmarshal(rec.Body.Bytes(), &preview); err != nil {
+		t.Fatalf("preview: %v (%s)", err, rec.Body.String())
+	}
+	if preview.Matched != 2 || len(preview.Changes) != 2 {
+		t.Fatalf("preview = %+v, want 2 matched and changed", preview)
+	}
+	if c := preview.Changes[1]; c.File != "maul.json" || len(c.Added) != 0 || len(c.Removed) != 1 {
+		t.Errorf("maul already has two-handed, so it should only lose heavy: %+v", c)
+	}
+
+	if rec := postTagEdit(t, editor.HandleTagEditApply, req); rec.Code != http.StatusOK {
+		t.Fatalf("apply: %d %s", rec.Code, rec.Body.String())
+	}
+	if got := editor.Items["greatsword"].Tags; strings.Join(got, ",") != "weapon,two-handed" {
+		t.Errorf("greatsword tags = %v, want weapon,two-handed", got)
+	}
+	if got := editor.Items["maul"].Tags; strings.Join(got, ",") != "weapon,two-handed" {
+		t.Errorf("maul tags = %v, want weapon,two-handed", got)
+	}
+
+	// A file outside the filter is left byte-for-byte alone.
+	untouched, _ := os.ReadFile(filepath.Join(itemsDir, "longsword.json"))
+	if string(untouched) != files["longsword.json"] {
+		t.Errorf("longsword.json was rewritten: %s", untouched)
+	}
+
+	// Fields the editor's Item struct doesn't know survive a rewrite.
+	req = itemeditor.TagEditRequest{Filter: itemeditor.ItemFilter{Tag: "versatile"}, AddTags: []string{"finesse"}}
+	postTagEdit(t, editor.HandleTagEditApply, req)
+	rewritten, _ := os.ReadFile(filepath.Join(itemsDir, "longsword.json"))
+	if !strings.Contains(string(rewritten), `"damage_versatile": "1d10"`) || !strings.Contains(string(rewritten), `"finesse"`) {
+		t.Errorf("longsword.json after tagging = %s", rewritten)
+	}
+
+	for _, bad := range []itemeditor.TagEditRequest{
+		{AddTags: []string{"two-handed"}},
+		{Filter: itemeditor.ItemFilter{Tag: "weapon"}},
+		{Filter: itemeditor.ItemFilter{Tag: "weapon"}, AddTags: []string{"x"}, RemoveTags: []string{"x"}},
+	} {
+		if rec := postTagEdit(t, editor.HandleTagEditPreview, bad); rec.Code != http.StatusBadRequest {
+			t.Errorf("%+v: status %d, want 400", bad, rec.Code)
+		}
+	}
+}
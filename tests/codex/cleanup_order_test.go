@@ -0,0 +1,50 @@
+package codex_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"pubkey-quest/cmd/codex/validation"
+)
+
+// Cleanup writes the standard properties in their set order and any others
+// after them alphabetically, the same way every time.
+func TestOrderItemProperties(t *testing.T) {
+	item := map[string]interface{}{
+		"zeta":        1,
+		"tags":        []interface{}{"light"},
+		"name":        "Torch",
+		"alpha":       "a & b",
+		"id":          "torch",
+		"mystery":     true,
+		"description": "Burns for an hour.",
+	}
+
+	first, err := json.Marshal(validation.OrderItemProperties(item))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"id":"torch","name":"Torch","description":"Burns for an hour.","tags":["light"],"alpha":"a \u0026 b","mystery":true,"zeta":1}`
+	if string(first) != want {
+		t.Errorf("got  %s\nwant %s", first, want)
+	}
+
+	for range 20 {
+		again, _ := json.Marshal(validation.OrderItemProperties(item))
+		if string(again) != string(first) {
+			t.Fatalf("output changed between runs:\n%s\n%s", first, again)
+		}
+	}
+
+	// An encoder that doesn't escape HTML keeps "&" as written.
+	var out strings.Builder
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(validation.OrderItemProperties(item)); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), `"a & b"`) {
+		t.Errorf("unescaped encode = %s, want the & left alone", out.String())
+	}
+}
@@ -0,0 +1,81 @@
+package codex_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"pubkey-quest/cmd/codex/validation"
+)
+
+// Safe-mode cleanup renames properties and drops null fields, but leaves
+// guessed defaults (like a gear_slot for untagged equipment) for a person and
+// reports them as skipped.
+func TestCleanupSafeMode(t *testing.T) {
+	t.Chdir(t.TempDir())
+	dir := filepath.Join("game-data", "items")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "widget.json")
+	original := `{"id": "widget", "Name": "Widget", "description": "A widget.", "rarity": "common", "value": 1, "weight": 1, "stack": 1, "type": "Adventuring Gear", "image": "", "tags": ["equipment"], "notes": [], "heal": null}`
+	if err := os.WriteFile(path, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := validation.CleanupAllItems(false, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.FilesModified != 1 || result.RunID == "" {
+		t.Fatalf("safe cleanup = %+v, want one file modified under a backup run", result)
+	}
+
+	data, _ := os.ReadFile(path)
+	var item map[string]interface{}
+	if err := json.Unmarshal(data, &item); err != nil {
+		t.Fatal(err)
+	}
+	if item["name"] != "Widget" {
+		t.Errorf("name = %v, want the 'Name' property renamed", item["name"])
+	}
+	if _, ok := item["Name"]; ok {
+		t.Error("the misnamed property is still there")
+	}
+	if _, ok := item["heal"]; ok {
+		t.Error("the null heal field wasn't removed")
+	}
+	if _, ok := item["gear_slot"]; ok {
+		t.Errorf("safe mode guessed gear_slot = %v", item["gear_slot"])
+	}
+
+	manual := false
+	for _, change := range result.Changes {
+		if strings.Contains(change.Message, "MANUAL FIX REQUIRED") {
+			t.Errorf("safe mode applied a manual fix: %s", change.Message)
+		}
+	}
+	for _, change := range result.Skipped {
+		if change.Field == "gear_slot" && strings.Contains(change.Message, "MANUAL FIX REQUIRED") {
+			manual = true
+		}
+	}
+	if !manual {
+		t.Errorf("skipped = %+v, want the gear_slot guess listed", result.Skipped)
+	}
+
+	// A normal run then makes the guesses safe mode left alone.
+	full, err := validation.CleanupAllItems(false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(full.Skipped) != 0 {
+		t.Errorf("a normal run skipped %+v", full.Skipped)
+	}
+	data, _ = os.ReadFile(path)
+	if !strings.Contains(string(data), `"gear_slot"`) {
+		t.Errorf("normal cleanup didn't add gear_slot:\n%s", data)
+	}
+}
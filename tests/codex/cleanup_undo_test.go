@@ -0,0 +1,62 @@
+package codex_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"pubkey-quest/cmd/codex/validation"
+)
+
+// A cleanup run backs up every file it overwrites, and undoing it puts the
+// original bytes back and uses up the run.
+func TestUndoCleanup(t *testing.T) {
+	t.Chdir(t.TempDir())
+	effectsDir := filepath.Join("game-data", "effects")
+	if err := os.MkdirAll(effectsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	legacy := filepath.Join(effectsDir, "blessed.json")
+	original := []byte(`{"id": "blessed", "name": "Blessed", "icon": "✨", "effects": [{"type": "strength", "value": 1, "duration": 60}]}`)
+	if err := os.WriteFile(legacy, original, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := validation.RestoreLastCleanup(); !errors.Is(err, validation.ErrNoCleanupRun) {
+		t.Fatalf("undo before any cleanup = %v, want ErrNoCleanupRun", err)
+	}
+
+	preview, err := validation.CleanupEffects(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preview.RunID != "" {
+		t.Errorf("a dry run shouldn't leave a backup, got run %q", preview.RunID)
+	}
+
+	result, err := validation.CleanupEffects(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.FilesModified != 1 || result.RunID == "" {
+		t.Fatalf("cleanup = %+v, want one file modified under a backup run", result)
+	}
+	if cleaned, _ := os.ReadFile(legacy); string(cleaned) == string(original) {
+		t.Fatal("cleanup didn't change the legacy effect")
+	}
+
+	restored, err := validation.RestoreLastCleanup()
+	if err != nil {
+		t.Fatalf("undo: %v", err)
+	}
+	if restored.RunID != result.RunID || len(restored.Files) != 1 || restored.Files[0] != legacy {
+		t.Errorf("undo = %+v, want %s restored from run %s", restored, legacy, result.RunID)
+	}
+	if got, _ := os.ReadFile(legacy); string(got) != string(original) {
+		t.Errorf("after undo the file is %s, want the original back", got)
+	}
+	if _, err := validation.RestoreLastCleanup(); !errors.Is(err, validation.ErrNoCleanupRun) {
+		t.Errorf("second undo = %v, want ErrNoCleanupRun", err)
+	}
+}
@@ -0,0 +1,67 @@
+package codex_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"pubkey-quest/cmd/codex/validation"
+)
+
+func TestConsumableEffectChances(t *testing.T) {
+	cases := []struct {
+		name    string
+		effects string
+		want    []string // substrings of expected "type: message" issues; none = clean
+	}{
+		{"default chance", `[{"apply_effect":"drunk"}]`, nil},
+		{"coin flip", `[{"apply_effect":"drunk","chance":50}]`, nil},
+		{"zero chance", `[{"apply_effect":"drunk","chance":0}]`, []string{"error: Effect 'drunk' has chance 0"}},
+		{"long shots", `[{"apply_effect":"drunk","chance":20},{"apply_effect":"blessed","chance":10}]`,
+			[]string{"warning: Only a 28% chance"}},
+		{"long shot plus inline", `[{"apply_effect":"drunk","chance":5},{"type":"hp","value":5}]`, nil},
+		{"chance on inline", `[{"type":"hp","value":5,"chance":50}]`,
+			[]string{"warning: 'chance' only applies to named 'apply_effect'"}},
+		{"non-numeric chance", `[{"apply_effect":"drunk","chance":"half"}]`, []string{"error: 'chance' must be a number"}},
+		{"fractional chance", `[{"apply_effect":"drunk","chance":0.5}]`, []string{"error: Effect 'drunk' has chance 0.5"}},
+		{"part percent", `[{"apply_effect":"drunk","chance":50.5}]`, []string{"error: chance 50.5 must be a whole percentage"}},
+		{"unset beside rolled", `[{"apply_effect":"drunk","chance":50},{"apply_effect":"blessed"}]`,
+			[]string{"warning: Effect 'blessed' has no chance alongside rolled effects"}},
+	}
+	for _, c := range cases {
+		var item map[string]interface{}
+		if err := json.Unmarshal([]byte(`{"effects":`+c.effects+`}`), &item); err != nil {
+			t.Fatalf("%s: bad fixture: %v", c.name, err)
+		}
+		issues := validation.CheckConsumableEffectChances(item)
+		if len(issues) != len(c.want) {
+			t.Errorf("%s: got %d issues %+v, want %d", c.name, len(issues), issues, len(c.want))
+			continue
+		}
+		for i, want := range c.want {
+			got := issues[i].Type + ": " + issues[i].Message
+			if !strings.Contains(got, want) {
+				t.Errorf("%s: issue %q, want containing %q", c.name, got, want)
+			}
+		}
+	}
+}
+
+func TestItemEffectReferences(t *testing.T) {
+	valid := map[string]bool{"drunk": true, "torchlight": true}
+	var item map[string]interface{}
+	fixture := `{"effects":[{"apply_effect":"drunk","chance":50},{"type":"hp","value":5},{"apply_effect":"drunkk"},{"apply_effect":7}]}`
+	if err := json.Unmarshal([]byte(fixture), &item); err != nil {
+		t.Fatalf("bad fixture: %v", err)
+	}
+	issues := validation.CheckItemEffectReferences(item, valid)
+	if len(issues) != 2 {
+		t.Fatalf("want 2 dangling references, got %+v", issues)
+	}
+	if issues[0].Field != "effects[2].apply_effect" || !strings.Contains(issues[0].Message, "'drunkk'") {
+		t.Errorf("typo'd effect issue: %+v", issues[0])
+	}
+	if issues[1].Field != "effects[3].apply_effect" || issues[1].Type != "error" {
+		t.Errorf("non-string effect issue: %+v", issues[1])
+	}
+}
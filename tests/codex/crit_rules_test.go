@@ -0,0 +1,50 @@
+package codex_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"pubkey-quest/cmd/codex/validation"
+)
+
+func TestCheckCritRules(t *testing.T) {
+	cases := []struct {
+		name  string
+		crit  string
+		field string // expected issue field; "" = clean
+	}{
+		{"defaults", `{"damage_rule":"double_dice"}`, ""},
+		{"expanded", `{"damage_rule":"max_plus_roll","crit_range":20,"min_crit_range":18,
+			"class_crit_ranges":[{"class":"fighter","min_level":3,"crit_range":19}]}`, ""},
+		{"unknown rule", `{"damage_rule":"triple_dice"}`, "critical_hits.damage_rule"},
+		{"range off the die", `{"damage_rule":"double_dice","crit_range":25}`, "critical_hits.crit_range"},
+		{"floor above range", `{"damage_rule":"double_dice","crit_range":19,"min_crit_range":20}`, "critical_hits.min_crit_range"},
+		{"class below floor", `{"damage_rule":"double_dice","class_crit_ranges":[{"class":"rogue","min_level":1,"crit_range":15}]}`,
+			"critical_hits.class_crit_ranges[0].crit_range"},
+		{"class missing", `{"damage_rule":"double_dice","class_crit_ranges":[{"min_level":1,"crit_range":19}]}`,
+			"critical_hits.class_crit_ranges[0].class"},
+	}
+	for _, c := range cases {
+		var crit map[string]interface{}
+		if err := json.Unmarshal([]byte(c.crit), &crit); err != nil {
+			t.Fatalf("%s: bad fixture: %v", c.name, err)
+		}
+		issues := validation.CheckCritRules(crit)
+		if c.field == "" {
+			if len(issues) > 0 {
+				t.Errorf("%s: unexpected issues %+v", c.name, issues)
+			}
+			continue
+		}
+		found := false
+		for _, is := range issues {
+			if is.Type == "error" && strings.EqualFold(is.Field, c.field) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s: want an error on %s, got %+v", c.name, c.field, issues)
+		}
+	}
+}
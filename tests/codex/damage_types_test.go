@@ -0,0 +1,88 @@
+package codex_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"pubkey-quest/cmd/codex/validation"
+	"pubkey-quest/types"
+)
+
+func TestCheckItemDamageDefenses(t *testing.T) {
+	ok := map[string]interface{}{"damage_resistances": []interface{}{"fire", "cold"}}
+	if issues := validation.CheckItemDamageDefenses(ok); len(issues) != 0 {
+		t.Errorf("valid resistances flagged: %+v", issues)
+	}
+
+	bad := map[string]interface{}{
+		"damage_resistances": []interface{}{"fire", "lava"},
+		"damage_immunities":  "poison",
+	}
+	issues := validation.CheckItemDamageDefenses(bad)
+	if len(issues) != 2 {
+		t.Fatalf("want 2 issues (unknown type, non-list), got %+v", issues)
+	}
+	if issues[0].Field != "damage_resistances[1]" || issues[1].Field != "damage_immunities" {
+		t.Errorf("unexpected fields: %q, %q", issues[0].Field, issues[1].Field)
+	}
+}
+
+// A damaging monster attack has to name its damage type, since player
+// resistances key off it.
+func TestCheckMonsterAttacksRequiresDamageType(t *testing.T) {
+	actions := []types.MonsterAction{
+		{Name: "Bite", Type: "melee_attack", AttackBonus: 4, Hit: types.MonsterHit{Dice: "1d6", Type: "piercing"}},
+		{Name: "Claw", Type: "melee_attack", AttackBonus: 4, Hit: types.MonsterHit{Dice: "1d4", Type: ""}},
+	}
+	issues := validation.CheckMonsterAttacks(actions)
+	if len(issues) != 1 || issues[0].Field != "actions[1].hit.type" {
+		t.Errorf("want one hit.type issue on actions[1], got %+v", issues)
+	}
+}
+
+// A misspelt monster resistance never matches the damage it should reduce.
+func TestCheckMonsterDamageModifiers(t *testing.T) {
+	ok := map[string]interface{}{
+		"damage_resistances":     []interface{}{"bludgeoning", "piercing", "slashing"},
+		"damage_immunities":      []interface{}{"poison"},
+		"damage_vulnerabilities": []interface{}{},
+	}
+	if issues := validation.CheckMonsterDamageModifiers(ok); len(issues) != 0 {
+		t.Errorf("valid modifiers flagged: %+v", issues)
+	}
+
+	bad := map[string]interface{}{
+		"damage_resistances":     []interface{}{"slash", "lava"},
+		"damage_vulnerabilities": []interface{}{"Fire"},
+		"immunities":             []interface{}{"poison"},
+	}
+	issues := validation.CheckMonsterDamageModifiers(bad)
+	want := map[string]string{
+		"immunities":                "error",
+		"damage_resistances[0]":     "error",
+		"damage_resistances[1]":     "error",
+		"damage_vulnerabilities[0]": "warning",
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("want %d issues, got %+v", len(want), issues)
+	}
+	for _, issue := range issues {
+		if want[issue.Field] != issue.Type {
+			t.Errorf("%s: got a %s, want %q", issue.Field, issue.Type, want[issue.Field])
+		}
+	}
+	if !strings.Contains(issues[1].Message, "'slashing'") {
+		t.Errorf("'slash' should suggest slashing: %q", issues[1].Message)
+	}
+}
+
+// The exported schemas offer exactly the shared registry's damage types.
+func TestSchemaDamageTypesMatchRegistry(t *testing.T) {
+	schema := validation.ItemJSONSchema()
+	props := schema["properties"].(map[string]interface{})
+	items := props["damage_resistances"].(map[string]interface{})["items"].(map[string]interface{})
+	if got := items["enum"].([]string); !slices.Equal(got, types.DamageTypes) {
+		t.Errorf("schema damage types = %v, want %v", got, types.DamageTypes)
+	}
+}
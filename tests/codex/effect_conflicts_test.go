@@ -0,0 +1,91 @@
+package codex_test
+
+import (
+	"strings"
+	"testing"
+
+	"pubkey-quest/cmd/codex/validation"
+)
+
+func TestCheckEffectConflicts(t *testing.T) {
+	clean := map[string][]string{
+		"blessed": {"cursed"},
+		"cursed":  {"blessed"},
+		"rested":  nil,
+	}
+	if issues := validation.CheckEffectConflicts(clean); len(issues) != 0 {
+		t.Fatalf("symmetric table should pass, got %+v", issues)
+	}
+
+	broken := map[string][]string{
+		"blessed": {"cursed", "hexed"}, // cursed doesn't list it back; hexed doesn't exist
+		"cursed":  nil,
+		"rested":  {"rested"},
+	}
+	issues := validation.CheckEffectConflicts(broken)
+	want := []string{"one-sided", "does not exist", "itself"}
+	if len(issues) != len(want) {
+		t.Fatalf("want %d issues, got %+v", len(want), issues)
+	}
+	for i, fragment := range want {
+		if !strings.Contains(issues[i].Message, fragment) {
+			t.Errorf("issue %d: want %q in %q", i, fragment, issues[i].Message)
+		}
+		if issues[i].Field == "" || issues[i].Category != "effects" {
+			t.Errorf("issue %d missing field/category: %+v", i, issues[i])
+		}
+	}
+}
+
+func TestCheckEffectCondition(t *testing.T) {
+	cases := []struct {
+		name   string
+		effect map[string]interface{}
+		want   []string // fields with issues, in order
+	}{
+		{"no condition", map[string]interface{}{"id": "rested"}, nil},
+		{"known condition", map[string]interface{}{"condition": "stunned"}, nil},
+		{"with save", map[string]interface{}{"condition": "poisoned", "saving_throw": map[string]interface{}{"stat": "constitution", "dc": 12.0}}, nil},
+		{"unknown condition", map[string]interface{}{"condition": "sleepy"}, []string{"condition"}},
+		{"save without condition", map[string]interface{}{"saving_throw": map[string]interface{}{"stat": "wisdom", "dc": 10.0}}, []string{"saving_throw"}},
+		{"bad save", map[string]interface{}{"condition": "frightened", "saving_throw": map[string]interface{}{"stat": "luck", "dc": 0.5}}, []string{"saving_throw.stat", "saving_throw.dc"}},
+	}
+	for _, c := range cases {
+		issues := validation.CheckEffectCondition(c.effect)
+		if len(issues) != len(c.want) {
+			t.Errorf("%s: want issues on %v, got %+v", c.name, c.want, issues)
+			continue
+		}
+		for i, field := range c.want {
+			if issues[i].Field != field || issues[i].Type != "error" {
+				t.Errorf("%s: issue %d = %+v, want an error on %s", c.name, i, issues[i], field)
+			}
+		}
+	}
+}
+
+func TestCheckEffectConcentration(t *testing.T) {
+	cases := []struct {
+		name   string
+		effect map[string]interface{}
+		errors int
+	}{
+		{"no flag", map[string]interface{}{"source_type": "system_ticker"}, 0},
+		{"applied", map[string]interface{}{"source_type": "applied", "concentration": true}, 0},
+		{"off on a ticker", map[string]interface{}{"source_type": "system_ticker", "concentration": false}, 0},
+		{"on a status", map[string]interface{}{"source_type": "system_status", "concentration": true}, 1},
+		{"not a boolean", map[string]interface{}{"source_type": "applied", "concentration": "yes"}, 1},
+	}
+	for _, c := range cases {
+		issues := validation.CheckEffectConcentration(c.effect)
+		if len(issues) != c.errors {
+			t.Errorf("%s: want %d issues, got %+v", c.name, c.errors, issues)
+			continue
+		}
+		for _, issue := range issues {
+			if issue.Field != "concentration" || issue.Type != "error" {
+				t.Errorf("%s: issue = %+v, want an error on concentration", c.name, issue)
+			}
+		}
+	}
+}
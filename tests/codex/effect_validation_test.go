@@ -0,0 +1,64 @@
+package codex_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"pubkey-quest/cmd/codex/systemseditor"
+)
+
+// validateRaw runs the systems editor's save-time validation on an effect body
+// exactly as a client would send it.
+func validateRaw(t *testing.T, body string) error {
+	t.Helper()
+	var effect systemseditor.Effect
+	var raw map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &effect); err != nil {
+		t.Fatalf("bad fixture: %v", err)
+	}
+	if err := json.Unmarshal([]byte(body), &raw); err != nil {
+		t.Fatalf("bad fixture: %v", err)
+	}
+	ed := systemseditor.NewEditor(nil)
+	ed.EffectTypes.EffectTypes = map[string]systemseditor.EffectTypeDefinition{
+		"hp": {ID: "hp"},
+	}
+	return ed.ValidateEffect(effect, raw)
+}
+
+func TestValidateEffectSourceTypeRules(t *testing.T) {
+	const base = `"id":"test-effect","name":"Test","modifiers":[{"stat":"hp","value":1,"type":"instant"}]`
+	const check = `"system_check":{"stat":"hunger","operator":"==","value":0}`
+
+	cases := []struct {
+		name    string
+		body    string
+		wantErr string // "" = valid
+	}{
+		{"ticker hidden", `{` + base + `,"source_type":"system_ticker","visible":false}`, ""},
+		{"ticker visible", `{` + base + `,"source_type":"system_ticker","visible":true}`, "system_ticker effects must have visible=false"},
+		{"status visible", `{` + base + `,"source_type":"system_status","visible":true,` + check + `}`, ""},
+		{"status hidden", `{` + base + `,"source_type":"system_status","visible":false,` + check + `}`, "system_status effects must have visible=true"},
+		{"applied visible", `{` + base + `,"source_type":"applied","visible":true}`, ""},
+		{"applied hidden", `{` + base + `,"source_type":"applied","visible":false}`, "applied effects must have visible=true"},
+		{"visible omitted", `{` + base + `,"source_type":"system_ticker"}`, "Required field 'visible' is missing"},
+		{"status without check", `{` + base + `,"source_type":"system_status","visible":true}`, "system_status effects must have 'system_check' defined"},
+		{"status unknown stat", `{` + base + `,"source_type":"system_status","visible":true,"system_check":{"stat":"mood","operator":"==","value":0}}`, "Invalid stat 'mood'"},
+		{"status bad operator", `{` + base + `,"source_type":"system_status","visible":true,"system_check":{"stat":"hunger","operator":"~","value":0}}`, "Invalid operator '~'"},
+		{"status value out of range", `{` + base + `,"source_type":"system_status","visible":true,"system_check":{"stat":"hunger","operator":"==","value":7}}`, "hunger value must be 0-3"},
+		{"unknown source_type", `{` + base + `,"source_type":"passive","visible":true}`, "Invalid source_type 'passive'"},
+	}
+	for _, c := range cases {
+		err := validateRaw(t, c.body)
+		if c.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", c.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+			t.Errorf("%s: got %v, want error containing %q", c.name, err, c.wantErr)
+		}
+	}
+}
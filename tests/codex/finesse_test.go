@@ -0,0 +1,25 @@
+package codex_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/codex/validation"
+)
+
+func TestCheckFinesseWeapon(t *testing.T) {
+	cases := []struct {
+		name   string
+		tags   []string
+		issues int
+	}{
+		{"light finesse weapon", []string{"weapon", "finesse", "light"}, 0},
+		{"not finesse", []string{"weapon", "heavy"}, 0},
+		{"heavy finesse", []string{"weapon", "finesse", "heavy"}, 1},
+		{"finesse non-weapon", []string{"equipment", "finesse"}, 1},
+	}
+	for _, c := range cases {
+		if got := validation.CheckFinesseWeapon(c.tags); len(got) != c.issues {
+			t.Errorf("%s: got %d issues, want %d: %+v", c.name, len(got), c.issues, got)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package codex_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"pubkey-quest/cmd/codex/itemeditor"
+
+	"github.com/gorilla/mux"
+)
+
+// Pending candidates are addressed by index: HandleGetImage previews one and
+// HandleAcceptImage promotes it, backing up the live sprite.
+func TestAcceptImageCandidateByIndex(t *testing.T) {
+	t.Chdir(t.TempDir())
+	itemsDir := filepath.Join("www", "res", "img", "items")
+	candDir := filepath.Join(itemsDir, "_candidates", "torch")
+	if err := os.MkdirAll(candDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, body := range map[string]string{"a_bitforge_1.png": "first", "a_bitforge_2.png": "second"} {
+		if err := os.WriteFile(filepath.Join(candDir, name), []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(itemsDir, "torch.png"), []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	editor := itemeditor.New()
+	editor.Items["torch"] = &itemeditor.Item{ID: "torch", Name: "Torch"}
+	vars := map[string]string{"filename": "torch"}
+
+	rec := httptest.NewRecorder()
+	editor.HandleGetImage(rec, mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/api/items/torch/image?candidate=1", nil), vars))
+	var preview struct {
+		Name      string `json:"name"`
+		ImageData string `json:"imageData"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&preview); err != nil || preview.Name != "a_bitforge_2.png" {
+		t.Fatalf("preview candidate 1: %q (%v)", preview.Name, err)
+	}
+
+	accept := func(body string) int {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/items/torch/accept-image", bytes.NewBufferString(body))
+		editor.HandleAcceptImage(rec, mux.SetURLVars(req, vars))
+		return rec.Code
+	}
+	if code := accept(`{"candidate": 5}`); code != http.StatusNotFound {
+		t.Errorf("out-of-range candidate: status %d, want 404", code)
+	}
+	if code := accept(`{"candidate": 1}`); code != http.StatusOK {
+		t.Fatalf("accept candidate 1: status %d", code)
+	}
+	if live, _ := os.ReadFile(filepath.Join(itemsDir, "torch.png")); string(live) != "second" {
+		t.Errorf("live sprite = %q, want candidate 1", live)
+	}
+	if backups, _ := os.ReadDir(filepath.Join(itemsDir, "_history", "torch")); len(backups) != 1 {
+		t.Errorf("expected the old sprite backed up, got %d history files", len(backups))
+	}
+}
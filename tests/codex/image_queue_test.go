@@ -0,0 +1,84 @@
+package codex_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"pubkey-quest/cmd/codex/pixellab"
+)
+
+// waitForJob polls until job id settles as done or failed.
+func waitForJob(t *testing.T, q *pixellab.Queue, id string) pixellab.Job {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if job, _ := q.Get(id); job.Status == pixellab.JobDone || job.Status == pixellab.JobFailed {
+			return job
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("job %s never finished", id)
+	return pixellab.Job{}
+}
+
+// Jobs run in the background no more than two at a time, report their result,
+// and a failed job can be retried until it goes through.
+func TestGenerationQueue(t *testing.T) {
+	q := pixellab.NewQueue(2)
+
+	var running, peak atomic.Int32
+	release := make(chan struct{})
+	var ids []string
+	for range 5 {
+		job, err := q.Enqueue("torch", func() (interface{}, error) {
+			n := running.Add(1)
+			for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+			}
+			<-release
+			running.Add(-1)
+			return "ok", nil
+		})
+		if err != nil {
+			t.Fatalf("enqueue: %v", err)
+		}
+		if job.Status != pixellab.JobPending {
+			t.Errorf("a new job should be pending, got %s", job.Status)
+		}
+		ids = append(ids, job.ID)
+	}
+	close(release)
+	for _, id := range ids {
+		if job := waitForJob(t, q, id); job.Status != pixellab.JobDone || job.Result != "ok" {
+			t.Errorf("job %s = %+v, want done with its result", id, job)
+		}
+	}
+	if peak.Load() > 2 {
+		t.Errorf("%d jobs ran at once, want at most 2", peak.Load())
+	}
+	if latest, ok := q.Latest("torch"); !ok || latest.ID != ids[len(ids)-1] {
+		t.Errorf("latest torch job = %+v, want %s", latest, ids[len(ids)-1])
+	}
+
+	attempts := 0
+	job, _ := q.Enqueue("shield", func() (interface{}, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, errors.New("rate limited")
+		}
+		return "second time lucky", nil
+	})
+	if job = waitForJob(t, q, job.ID); job.Status != pixellab.JobFailed || job.Error != "rate limited" {
+		t.Fatalf("first attempt = %+v, want failed with the error", job)
+	}
+	if _, err := q.Retry(ids[0]); err == nil {
+		t.Error("a finished job shouldn't be retryable")
+	}
+	if _, err := q.Retry(job.ID); err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if job = waitForJob(t, q, job.ID); job.Status != pixellab.JobDone || job.Attempts != 2 || job.Error != "" {
+		t.Errorf("after retry = %+v, want done on the second attempt", job)
+	}
+}
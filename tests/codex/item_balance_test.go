@@ -0,0 +1,39 @@
+package codex_test
+
+import (
+	"fmt"
+	"testing"
+
+	"pubkey-quest/cmd/codex/itemeditor"
+)
+
+// A dagger priced like a greatsword stands out from the other simple weapons;
+// a typical one doesn't, and neither does a type where prices are all alike.
+func TestBalanceReportFlagsOutliers(t *testing.T) {
+	items := map[string]*itemeditor.Item{}
+	for i := 0; i < 8; i++ {
+		items[fmt.Sprintf("club-%d", i)] = &itemeditor.Item{Name: "Club", Type: "Simple Melee Weapons", Value: 10 + i, Weight: 2, Damage: "1d4"}
+	}
+	items["dagger"] = &itemeditor.Item{Name: "Dagger", Type: "Simple Melee Weapons", Value: 5000, Weight: 2, Damage: "1d4"}
+	items["maul"] = &itemeditor.Item{Name: "Maul", Type: "Simple Melee Weapons", Value: 12, Weight: 2, Damage: "2d6"}
+	for i := 0; i < 6; i++ {
+		items[fmt.Sprintf("rope-%d", i)] = &itemeditor.Item{Name: "Rope", Type: "Adventuring Gear", Value: 100, Weight: 1}
+	}
+
+	report := itemeditor.BuildBalanceReport(items, 2)
+	weapons := report.Types["Simple Melee Weapons"]
+	if len(weapons) != 2 {
+		t.Fatalf("flagged %+v, want the dagger's price and the maul's damage", weapons)
+	}
+	for _, o := range weapons {
+		switch {
+		case o.File == "dagger.json" && o.Field == "value" && o.Deviation > 2:
+		case o.File == "maul.json" && o.Field == "damage" && o.Amount == 7 && o.Deviation > 2:
+		default:
+			t.Errorf("unexpected outlier %+v", o)
+		}
+	}
+	if _, ok := report.Types["Adventuring Gear"]; ok {
+		t.Error("identical rope shouldn't be flagged")
+	}
+}
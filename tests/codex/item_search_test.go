@@ -0,0 +1,73 @@
+package codex_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"pubkey-quest/cmd/codex/itemeditor"
+)
+
+func searchItems(t *testing.T, editor *itemeditor.Editor, query string) (*httptest.ResponseRecorder, itemeditor.ItemListResponse) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	editor.HandleGetItems(rec, httptest.NewRequest(http.MethodGet, "/api/items"+query, nil))
+	var resp itemeditor.ItemListResponse
+	if rec.Code == http.StatusOK && query != "" {
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("%s: decoding response: %v", query, err)
+		}
+	}
+	return rec, resp
+}
+
+// The listing filters by type, tag, rarity and name/description text, pages the
+// matches in filename order, and still returns the whole catalog without params.
+func TestItemListingFilters(t *testing.T) {
+	editor := itemeditor.New()
+	editor.Items["dagger"] = &itemeditor.Item{Name: "Dagger", Type: "Simple Weapons", Rarity: "common", Tags: []string{"finesse"}}
+	editor.Items["rapier"] = &itemeditor.Item{Name: "Rapier", Type: "Martial Weapons", Rarity: "common", Tags: []string{"finesse"}}
+	editor.Items["flame-tongue"] = &itemeditor.Item{Name: "Flame Tongue", Type: "Martial Weapons", Rarity: "rare", Description: "A blade wreathed in fire."}
+	editor.Items["healing"] = &itemeditor.Item{Name: "Potion of Healing", Type: "Potion", Rarity: "common", Tags: []string{"consumable"}}
+
+	rec, _ := searchItems(t, editor, "")
+	var all map[string]*itemeditor.Item
+	if err := json.Unmarshal(rec.Body.Bytes(), &all); err != nil || len(all) != 4 {
+		t.Fatalf("unfiltered listing = %d items (err %v), want the plain 4-item map", len(all), err)
+	}
+
+	for _, c := range []struct {
+		query string
+		want  []string
+	}{
+		{"?type=martial%20weapons", []string{"flame-tongue", "rapier"}},
+		{"?tag=finesse", []string{"dagger", "rapier"}},
+		{"?rarity=rare", []string{"flame-tongue"}},
+		{"?q=FIRE", []string{"flame-tongue"}},
+		{"?q=potion&tag=consumable", []string{"healing"}},
+		{"?tag=finesse&rarity=rare", nil},
+	} {
+		_, resp := searchItems(t, editor, c.query)
+		if resp.Total != len(c.want) || len(resp.Items) != len(c.want) {
+			t.Errorf("%s: total %d, %d items; want %d", c.query, resp.Total, len(resp.Items), len(c.want))
+			continue
+		}
+		for _, id := range c.want {
+			if resp.Items[id] == nil {
+				t.Errorf("%s: missing %s", c.query, id)
+			}
+		}
+	}
+
+	_, resp := searchItems(t, editor, "?limit=2&offset=1")
+	if resp.Total != 4 || len(resp.Items) != 2 || resp.Items["flame-tongue"] == nil || resp.Items["healing"] == nil {
+		t.Errorf("page 2 of 2 by filename = %v (total %d), want flame-tongue and healing of 4", resp.Items, resp.Total)
+	}
+	if _, resp := searchItems(t, editor, "?offset=10"); resp.Total != 4 || len(resp.Items) != 0 {
+		t.Errorf("offset past the end: %d items of %d, want 0 of 4", len(resp.Items), resp.Total)
+	}
+	if rec, _ := searchItems(t, editor, "?limit=lots"); rec.Code != http.StatusBadRequest {
+		t.Errorf("a bad limit should be a 400, got %d", rec.Code)
+	}
+}
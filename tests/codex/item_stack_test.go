@@ -0,0 +1,36 @@
+package codex_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/codex/validation"
+)
+
+func TestCheckItemStack(t *testing.T) {
+	cases := []struct {
+		name  string
+		item  map[string]interface{}
+		tags  []string
+		issue string // expected issue type, "" for none
+	}{
+		{"sword", map[string]interface{}{"stack": 1.0, "gear_slot": "mainhand"}, []string{"weapon", "equipment"}, ""},
+		{"stacking sword", map[string]interface{}{"stack": 2.0, "gear_slot": "mainhand"}, []string{"weapon", "equipment"}, "error"},
+		{"arrows", map[string]interface{}{"stack": 25.0, "gear_slot": "ammo"}, []string{"equipment", "ammunition"}, ""},
+		{"darts", map[string]interface{}{"stack": 10.0, "gear_slot": "hands"}, []string{"weapon", "equipment", "thrown"}, ""},
+		{"rations", map[string]interface{}{"stack": 10.0}, []string{"consumable"}, ""},
+		{"lone potion", map[string]interface{}{"stack": 1.0}, []string{"consumable"}, "warning"},
+		{"no stack", map[string]interface{}{}, []string{"equipment", "consumable"}, ""},
+	}
+	for _, c := range cases {
+		got := validation.CheckItemStack(c.item, c.tags)
+		if c.issue == "" {
+			if len(got) != 0 {
+				t.Errorf("%s: unexpected issues %+v", c.name, got)
+			}
+			continue
+		}
+		if len(got) != 1 || got[0].Type != c.issue || got[0].Field != "stack" {
+			t.Errorf("%s: issues = %+v, want one %s on stack", c.name, got, c.issue)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package codex_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"pubkey-quest/cmd/codex/validation"
+)
+
+// validateItem writes item as game-data/items/widget.json and returns the
+// fields the validator raised errors on.
+func validateItem(t *testing.T, item map[string]interface{}) []string {
+	t.Helper()
+	dir := filepath.Join("game-data", "items")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(item)
+	if err := os.WriteFile(filepath.Join(dir, "widget.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := validation.ValidateOneItem("widget")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields []string
+	for _, issue := range issues {
+		if issue.Type == "error" {
+			fields = append(fields, issue.Field)
+		}
+	}
+	return fields
+}
+
+// The item schema's required fields and tag requirements are the ones the
+// validator enforces: dropping any of them from a file is a validation error.
+func TestItemJSONSchemaMatchesValidator(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	var schema struct {
+		Required []string `json:"required"`
+		AllOf    []struct {
+			If struct {
+				Properties struct {
+					Tags struct {
+						Contains struct {
+							Const string `json:"const"`
+						} `json:"contains"`
+					} `json:"tags"`
+				} `json:"properties"`
+			} `json:"if"`
+			Then struct {
+				Required []string `json:"required"`
+			} `json:"then"`
+		} `json:"allOf"`
+	}
+	data, err := json.Marshal(validation.ItemJSONSchema())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatal(err)
+	}
+
+	item := func(tags ...string) map[string]interface{} {
+		return map[string]interface{}{
+			"id": "widget", "name": "Widget", "description": "A widget.", "rarity": "common",
+			"value": 1, "weight": 1, "stack": 1, "type": "Adventuring Gear", "image": "",
+			"tags": tags, "notes": []string{},
+		}
+	}
+	if errs := validateItem(t, item()); len(errs) != 0 {
+		t.Fatalf("baseline item has errors on %v", errs)
+	}
+
+	if len(schema.Required) == 0 {
+		t.Fatal("schema has no required fields")
+	}
+	for _, field := range schema.Required {
+		missing := item()
+		delete(missing, field)
+		if errs := validateItem(t, missing); !slices.Contains(errs, field) {
+			t.Errorf("schema requires %q but the validator accepts an item without it (errors on %v)", field, errs)
+		}
+	}
+
+	tagRules := 0
+	for _, rule := range schema.AllOf {
+		tag := rule.If.Properties.Tags.Contains.Const
+		if tag == "" {
+			continue
+		}
+		tagRules++
+		errs := validateItem(t, item(tag))
+		for _, field := range rule.Then.Required {
+			if !slices.Contains(errs, field) {
+				t.Errorf("schema says %q items need %q but the validator doesn't (errors on %v)", tag, field, errs)
+			}
+		}
+	}
+	if tagRules == 0 {
+		t.Error("schema has no tag requirements")
+	}
+}
+
+// The effect schema limits modifier stats to the registry's effect types.
+func TestEffectJSONSchema(t *testing.T) {
+	registry := []byte(`{"effect_types": {"strength": {"id": "strength", "category": "stat"}, "hp": {"id": "hp", "category": "resource"}}}`)
+	schema, err := validation.EffectJSONSchema(registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc struct {
+		Schema     string   `json:"$schema"`
+		Required   []string `json:"required"`
+		Properties struct {
+			Category struct {
+				Enum []string `json:"enum"`
+			} `json:"category"`
+			Modifiers struct {
+				Items struct {
+					Properties struct {
+						Stat struct {
+							Enum []string `json:"enum"`
+						} `json:"stat"`
+					} `json:"properties"`
+				} `json:"items"`
+			} `json:"modifiers"`
+		} `json:"properties"`
+	}
+	data, _ := json.Marshal(schema)
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.Schema == "" {
+		t.Error("schema doesn't declare its dialect")
+	}
+	for _, field := range []string{"id", "name", "description", "category", "removal", "visible", "source_type"} {
+		if !slices.Contains(doc.Required, field) {
+			t.Errorf("required = %v, missing %q", doc.Required, field)
+		}
+	}
+	if !slices.Equal(doc.Properties.Category.Enum, []string{"buff", "debuff", "status"}) {
+		t.Errorf("category enum = %v", doc.Properties.Category.Enum)
+	}
+	if stats := doc.Properties.Modifiers.Items.Properties.Stat.Enum; !slices.Equal(stats, []string{"hp", "strength"}) {
+		t.Errorf("modifier stats = %v, want the registry's [hp strength]", stats)
+	}
+
+	if _, err := validation.EffectJSONSchema([]byte("not json")); err == nil {
+		t.Error("a broken registry should be an error")
+	}
+}
@@ -0,0 +1,32 @@
+package codex_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/codex/validation"
+)
+
+func TestCheckLightSource(t *testing.T) {
+	lightEffects := map[string]bool{"torchlight": true}
+	lit := []interface{}{map[string]interface{}{"apply_effect": "torchlight"}}
+	cases := []struct {
+		name   string
+		item   map[string]interface{}
+		tags   []string
+		issues int
+	}{
+		{"torch", map[string]interface{}{"effects": lit}, []string{"light-source", "consumable"}, 0},
+		{"lantern", map[string]interface{}{"gear_slot": "offhand"}, []string{"light-source", "equipment"}, 0},
+		{"not a light", map[string]interface{}{}, []string{"consumable"}, 0},
+		{"no way to light it", map[string]interface{}{}, []string{"light-source"}, 1},
+		{"effect gives no light", map[string]interface{}{"effects": []interface{}{map[string]interface{}{"apply_effect": "drunk"}}},
+			[]string{"light-source", "consumable"}, 1},
+		{"can't be used", map[string]interface{}{"effects": lit}, []string{"light-source"}, 1},
+		{"worn on the head", map[string]interface{}{"gear_slot": "head"}, []string{"light-source", "equipment"}, 1},
+	}
+	for _, c := range cases {
+		if got := validation.CheckLightSource(c.item, c.tags, lightEffects); len(got) != c.issues {
+			t.Errorf("%s: got %d issues, want %d: %+v", c.name, len(got), c.issues, got)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package codex_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/codex/validation"
+)
+
+func TestCheckLocationEncounters(t *testing.T) {
+	monsters := map[string]bool{"wolf": true, "bandit": true}
+	entry := func(monster string, weight interface{}) map[string]interface{} {
+		e := map[string]interface{}{"monster": monster}
+		if weight != nil {
+			e["weight"] = weight
+		}
+		return e
+	}
+	cases := []struct {
+		name     string
+		location map[string]interface{}
+		want     []string // fields with issues, in order
+	}{
+		{"no table", map[string]interface{}{"type": "environment", "travel_difficulty": "hard"}, nil},
+		{"valid table", map[string]interface{}{"type": "environment", "encounters": []interface{}{entry("wolf", 3.0), entry("bandit", nil)}}, nil},
+		{"bad difficulty", map[string]interface{}{"type": "environment", "travel_difficulty": "deadly"}, []string{"travel_difficulty"}},
+		{"not an environment", map[string]interface{}{"type": "city", "encounters": []interface{}{entry("wolf", 1.0)}}, []string{"encounters"}},
+		{"not an array", map[string]interface{}{"type": "environment", "encounters": "wolf"}, []string{"encounters"}},
+		{"bad entries", map[string]interface{}{"type": "environment", "encounters": []interface{}{
+			entry("dragon", 1.0), entry("wolf", 0.0), entry("bandit", 1.5), "wolf",
+		}}, []string{"encounters[0].monster", "encounters[1].weight", "encounters[2].weight", "encounters[3]"}},
+	}
+	for _, c := range cases {
+		issues := validation.CheckLocationEncounters(c.location, monsters)
+		if len(issues) != len(c.want) {
+			t.Errorf("%s: want issues on %v, got %+v", c.name, c.want, issues)
+			continue
+		}
+		for i, field := range c.want {
+			if issues[i].Field != field || issues[i].Type != "error" || issues[i].Category != "locations" {
+				t.Errorf("%s: issue %d = %+v, want a locations error on %s", c.name, i, issues[i], field)
+			}
+		}
+	}
+}
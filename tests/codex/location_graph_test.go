@@ -0,0 +1,44 @@
+package codex_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/codex/validation"
+)
+
+func TestCheckLocationGraph(t *testing.T) {
+	city := map[string]interface{}{
+		"id": "kingdom",
+		"districts": map[string]interface{}{
+			"center": map[string]interface{}{"id": "kingdom-center", "connections": map[string]interface{}{
+				"east": "kingdom-market", "north": "northern-road",
+			}},
+			"market": map[string]interface{}{"id": "kingdom-market", "connections": map[string]interface{}{
+				"west": "kingdom-center", "south": "sunken-docks",
+			}},
+		},
+	}
+	road := map[string]interface{}{"id": "northern-road", "type": "environment", "connects": []interface{}{"kingdom-center", "kingdom-market"}}
+
+	issues := validation.CheckLocationGraph(map[string]map[string]interface{}{"kingdom.json": city, "northern-road.json": road})
+	want := []struct{ typ, file, field string }{
+		{"error", "kingdom.json", "districts.market.connections.south"},
+		{"warning", "northern-road.json", "connects[1]"},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("want %d issues, got %+v", len(want), issues)
+	}
+	for i, w := range want {
+		got := issues[i]
+		if got.Type != w.typ || got.File != w.file || got.Field != w.field || got.Category != "locations" {
+			t.Errorf("issue %d = %+v, want %s on %s %s", i, got, w.typ, w.file, w.field)
+		}
+	}
+
+	// Once the market links back to the road and the dead end is gone, the graph is clean.
+	market := city["districts"].(map[string]interface{})["market"].(map[string]interface{})
+	market["connections"] = map[string]interface{}{"west": "kingdom-center", "north": "northern-road"}
+	if issues := validation.CheckLocationGraph(map[string]map[string]interface{}{"kingdom.json": city, "northern-road.json": road}); len(issues) != 0 {
+		t.Errorf("consistent graph should pass, got %+v", issues)
+	}
+}
@@ -0,0 +1,31 @@
+package codex_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/codex/validation"
+	"pubkey-quest/types"
+)
+
+func TestCheckLootRarities(t *testing.T) {
+	rarities := map[string]string{"rat-tail": "common", "moonblade": "legendary", "amulet": "rare"}
+	table := types.LootTable{Rolls: 1, Tiers: []types.LootTier{
+		{Name: "common", Weight: 90, Entries: []types.LootEntry{
+			{Item: "rat-tail", Weight: 1},
+			{Item: "moonblade", Weight: 1}, // legendary in a common tier
+		}},
+		{Name: "very_rare", Weight: 10, Entries: []types.LootEntry{
+			{Item: "amulet", Weight: 1},
+			{Item: "nothing", Weight: 1},
+		}},
+		{Name: "junk", Weight: 1, Entries: []types.LootEntry{{Item: "moonblade", Weight: 1}}},
+	}}
+
+	issues := validation.CheckLootRarities("wolf.json", table, rarities)
+	if len(issues) != 1 {
+		t.Fatalf("got %d issues, want 1: %+v", len(issues), issues)
+	}
+	if issues[0].Field != "loot_table.tiers[0].entries[1].item" || issues[0].Type != "warning" {
+		t.Errorf("issue = %+v, want a warning on the moonblade entry", issues[0])
+	}
+}
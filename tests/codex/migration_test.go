@@ -0,0 +1,109 @@
+package codex_test
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"pubkey-quest/cmd/codex/migration"
+
+	_ "modernc.org/sqlite"
+)
+
+// migrationTree moves into an empty game-data tree with every directory the
+// migration walks, and returns a func that writes an item file into it.
+func migrationTree(t *testing.T) func(id, name string) {
+	t.Chdir(t.TempDir())
+	for _, dir := range []string{
+		"items", "magic/spells", "monsters", "locations/cities", "locations/environments", "locations/poi-draft",
+		"npcs", "effects", "systems/abilities", "systems/feats", "systems/encounters-draft", "quests-drafts",
+	} {
+		if err := os.MkdirAll(filepath.Join("game-data", dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return func(id, name string) {
+		t.Helper()
+		body := `{"name": "` + name + `", "type": "Adventuring Gear"}`
+		if err := os.WriteFile(filepath.Join("game-data", "items", id+".json"), []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// itemNames reads the items table back as id → name.
+func itemNames(t *testing.T, dbPath string) map[string]string {
+	t.Helper()
+	database, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+	rows, err := database.Query(`SELECT id, name FROM items ORDER BY id`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	got := map[string]string{}
+	for rows.Next() {
+		var id, name string
+		if err := rows.Scan(&id, &name); err != nil {
+			t.Fatal(err)
+		}
+		got[id] = name
+	}
+	return got
+}
+
+// An incremental migration rewrites only the files that changed, adds new ones
+// and drops rows whose file was deleted.
+func TestMigrateIncremental(t *testing.T) {
+	writeItem := migrationTree(t)
+	writeItem("rope", "Rope")
+	writeItem("torch", "Torch")
+
+	dbPath := filepath.Join("www", "game.db")
+	if err := migration.Migrate(dbPath, nil); err != nil {
+		t.Fatalf("full migration: %v", err)
+	}
+
+	writeItem("rope", "Silk Rope")
+	writeItem("lantern", "Lantern")
+	os.Remove(filepath.Join("game-data", "items", "torch.json"))
+	if err := migration.MigrateIncremental(dbPath, nil); err != nil {
+		t.Fatalf("incremental migration: %v", err)
+	}
+
+	got := itemNames(t, dbPath)
+	want := map[string]string{"lantern": "Lantern", "rope": "Silk Rope"}
+	if len(got) != len(want) || got["lantern"] != want["lantern"] || got["rope"] != want["rope"] {
+		t.Errorf("items after incremental migration = %v, want %v", got, want)
+	}
+}
+
+// A category that fails part way is rolled back, keeping the rows it had
+// instead of leaving the table half-migrated.
+func TestMigrateRollsBackFailedCategory(t *testing.T) {
+	writeItem := migrationTree(t)
+	writeItem("rope", "Rope")
+	writeItem("torch", "Torch")
+
+	dbPath := filepath.Join("www", "game.db")
+	if err := migration.Migrate(dbPath, nil); err != nil {
+		t.Fatalf("full migration: %v", err)
+	}
+
+	writeItem("rope", "Silk Rope")
+	if err := os.WriteFile(filepath.Join("game-data", "items", "broken.json"), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := migration.Migrate(dbPath, nil); err == nil {
+		t.Fatal("a malformed item file should fail the migration")
+	}
+
+	got := itemNames(t, dbPath)
+	if len(got) != 2 || got["rope"] != "Rope" || got["torch"] != "Torch" {
+		t.Errorf("items after the failed migration = %v, want the previous rope and torch", got)
+	}
+}
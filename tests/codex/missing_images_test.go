@@ -0,0 +1,77 @@
+package codex_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"pubkey-quest/cmd/codex/itemeditor"
+	"pubkey-quest/cmd/codex/pixellab"
+)
+
+// Items without a sprite get a generation job each; items that have one are
+// left alone, and items with nothing to prompt from or a job already waiting
+// are reported as skipped.
+func TestGenerateMissingImages(t *testing.T) {
+	t.Chdir(t.TempDir())
+	itemsDir := filepath.Join("www", "res", "img", "items")
+	if err := os.MkdirAll(itemsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(itemsDir, "torch.png"), []byte("png"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// PixelLab stand-in that holds every call until the test is done.
+	release := make(chan struct{})
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer api.Close()
+	defer close(release)
+
+	editor := itemeditor.New()
+	editor.PixelLabClient = pixellab.NewClient("test-key")
+	editor.PixelLabClient.BaseURL = api.URL
+	editor.ImageJobs = pixellab.NewQueue(1)
+	editor.Items["torch"] = &itemeditor.Item{ID: "torch", Name: "Torch"}
+	editor.Items["shield"] = &itemeditor.Item{ID: "shield", Name: "Shield", Rarity: "common"}
+	editor.Items["mystery"] = &itemeditor.Item{ID: "mystery"}
+
+	generateMissing := func() (queued, skipped []map[string]string) {
+		rec := httptest.NewRecorder()
+		editor.HandleGenerateMissingImages(rec, httptest.NewRequest(http.MethodPost, "/api/items/generate-missing-images", nil))
+		if rec.Code != http.StatusAccepted {
+			t.Fatalf("status %d: %s", rec.Code, rec.Body.String())
+		}
+		var resp struct {
+			Queued  []map[string]string `json:"queued"`
+			Skipped []map[string]string `json:"skipped"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp.Queued, resp.Skipped
+	}
+
+	queued, skipped := generateMissing()
+	if len(queued) != 1 || queued[0]["file"] != "shield" || queued[0]["jobId"] == "" {
+		t.Errorf("queued = %v, want only shield with a job", queued)
+	}
+	if len(skipped) != 1 || skipped[0]["file"] != "mystery" {
+		t.Errorf("skipped = %v, want mystery (nothing to prompt from)", skipped)
+	}
+	if job, ok := editor.ImageJobs.Latest("shield"); !ok || job.ID != queued[0]["jobId"] {
+		t.Errorf("shield's job isn't in the queue: %+v", job)
+	}
+
+	// Asking again while shield's job is still waiting doesn't queue it twice.
+	queued, skipped = generateMissing()
+	if len(queued) != 0 || len(skipped) != 2 {
+		t.Errorf("second pass queued %v, skipped %v; want nothing queued, shield and mystery skipped", queued, skipped)
+	}
+}
@@ -0,0 +1,62 @@
+package codex_test
+
+import (
+	"strings"
+	"testing"
+
+	"pubkey-quest/cmd/codex/validation"
+	"pubkey-quest/types"
+)
+
+func TestCheckMonsterActionBlock(t *testing.T) {
+	bite := types.MonsterAction{Name: "Bite", Type: "melee_attack", AttackBonus: 4, Hit: types.MonsterHit{Dice: "1d6", Type: "piercing"}}
+	claw := types.MonsterAction{Name: "Claw", Type: "melee_attack", AttackBonus: 4, Hit: types.MonsterHit{Dice: "2d6", Type: "slashing"}}
+	wail := func(mech types.MonsterMechanic) types.MonsterAction {
+		mech.Type = "area_save"
+		return types.MonsterAction{Name: "Wail", Type: "special", Recharge: "once_per_combat", Mechanic: &mech}
+	}
+	good := types.MonsterMechanic{Ability: "constitution", DC: 13, Range: 4, OnFail: "drop_to_zero",
+		OnSuccessDice: "3d6", OnSuccessMod: 3, OnSuccessType: "psychic"}
+
+	cases := []struct {
+		name    string
+		actions []types.MonsterAction
+		issues  []string // expected "type:field"
+	}{
+		{"plain attacks", []types.MonsterAction{bite, claw}, nil},
+		{"multiattack with sequence", []types.MonsterAction{
+			{Name: "Multiattack", Type: "multiattack", Attacks: 3, Sequence: []string{"Bite", "Claw", "Claw"}}, bite, claw}, nil},
+		{"multiattack of one", []types.MonsterAction{{Name: "Multiattack", Type: "multiattack", Attacks: 1}, bite},
+			[]string{"error:actions[0].attacks"}},
+		{"two multiattacks", []types.MonsterAction{
+			{Name: "Multiattack", Type: "multiattack", Attacks: 2}, {Name: "Multiattack", Type: "multiattack", Attacks: 2}, bite},
+			[]string{"error:actions[1]"}},
+		{"sequence too short and unknown", []types.MonsterAction{
+			{Name: "Multiattack", Type: "multiattack", Attacks: 3, Sequence: []string{"Bite", "Tail"}}, bite},
+			[]string{"error:actions[0].sequence", "error:actions[0].sequence[1]"}},
+		{"good special", []types.MonsterAction{bite, wail(good)}, nil},
+		{"bad recharge", []types.MonsterAction{bite, {Name: "Breath", Type: "special", Recharge: "recharge_9_6",
+			Mechanic: &types.MonsterMechanic{Type: "area_save", Ability: "dexterity", DC: 13, Range: 3,
+				Dice: "4d6", DamageType: "fire"}}},
+			[]string{"error:actions[1].recharge"}},
+		{"special without mechanic", []types.MonsterAction{bite, {Name: "Roar", Type: "special", Recharge: "none"}},
+			[]string{"warning:actions[1].mechanic"}},
+		{"broken mechanic", []types.MonsterAction{bite, wail(types.MonsterMechanic{Ability: "luck", Range: 0,
+			OnFail: "explode", OnSuccessDice: "lots", OnSuccessType: "psychic"})},
+			[]string{"error:actions[1].mechanic.ability", "error:actions[1].mechanic.dc", "error:actions[1].mechanic.range",
+				"error:actions[1].mechanic.on_fail", "error:actions[1].mechanic.on_success_dice"}},
+		{"does nothing on a failure", []types.MonsterAction{bite, wail(types.MonsterMechanic{Ability: "wisdom", DC: 12, Range: 2})},
+			[]string{"error:actions[1].mechanic"}},
+		{"unknown effect", []types.MonsterAction{bite, wail(types.MonsterMechanic{Ability: "wisdom", DC: 12, Range: 2, Effect: "polymorphed"})},
+			[]string{"warning:actions[1].mechanic.effect"}},
+	}
+	for _, c := range cases {
+		var got []string
+		for _, issue := range validation.CheckMonsterActionBlock(c.actions) {
+			got = append(got, issue.Type+":"+issue.Field)
+		}
+		if strings.Join(got, ",") != strings.Join(c.issues, ",") {
+			t.Errorf("%s: issues = %v, want %v", c.name, got, c.issues)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package codex_test
+
+import (
+	"strings"
+	"testing"
+
+	"pubkey-quest/cmd/codex/validation"
+	"pubkey-quest/types"
+)
+
+func TestCheckMonsterAttacks(t *testing.T) {
+	bite := types.MonsterAction{Name: "Bite", Type: "melee_attack", AttackBonus: 4, Hit: types.MonsterHit{Dice: "2d4", Type: "piercing"}}
+	web := types.MonsterAction{Name: "Web", Type: "ranged_attack", AttackBonus: 5,
+		Hit: types.MonsterHit{Dice: "0", Type: "none", Special: &types.MonsterHitSpecial{Type: "save", Effect: "restrained"}}}
+	multi := types.MonsterAction{Name: "Multiattack", Type: "multiattack"}
+
+	cases := []struct {
+		name    string
+		actions []types.MonsterAction
+		fields  []string // expected issue fields
+	}{
+		{"one good attack", []types.MonsterAction{bite}, nil},
+		{"rider attack alongside a real one", []types.MonsterAction{bite, web}, nil},
+		{"no actions", nil, []string{"actions"}},
+		{"only multiattack and a rider", []types.MonsterAction{multi, web}, []string{"actions"}},
+		{"bad dice", []types.MonsterAction{{Name: "Slam", Type: "melee_attack", AttackBonus: 3, Hit: types.MonsterHit{Dice: "d6"}}},
+			[]string{"actions[0].hit.dice", "actions"}},
+		{"absurd to-hit", []types.MonsterAction{{Name: "Slam", Type: "melee_attack", AttackBonus: 40, Hit: types.MonsterHit{Dice: "1d6"}}},
+			[]string{"actions[0].attack_bonus", "actions"}},
+	}
+	for _, c := range cases {
+		issues := validation.CheckMonsterAttacks(c.actions)
+		var got []string
+		for _, issue := range issues {
+			if issue.Type != "error" {
+				t.Errorf("%s: %q should be an error, got %s", c.name, issue.Message, issue.Type)
+			}
+			got = append(got, issue.Field)
+		}
+		if strings.Join(got, ",") != strings.Join(c.fields, ",") {
+			t.Errorf("%s: issue fields = %v, want %v", c.name, got, c.fields)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package codex_test
+
+import (
+	"strings"
+	"testing"
+
+	"pubkey-quest/cmd/codex/validation"
+)
+
+func TestCheckMonsterStatBlock(t *testing.T) {
+	items := map[string]bool{"wolf-pelt": true}
+	stats := func(str interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"strength": str, "dexterity": 15.0, "constitution": 12.0,
+			"intelligence": 3.0, "wisdom": 12.0, "charisma": 6.0,
+		}
+	}
+	loot := func(item string, rolls float64) map[string]interface{} {
+		return map[string]interface{}{
+			"guaranteed": []interface{}{},
+			"rolls":      rolls,
+			"tiers": []interface{}{map[string]interface{}{
+				"name": "common", "weight": 1.0,
+				"entries": []interface{}{map[string]interface{}{"item": item, "weight": 1.0}},
+			}},
+		}
+	}
+	monster := func(edit func(m map[string]interface{})) map[string]interface{} {
+		m := map[string]interface{}{
+			"challenge_rating": 0.25, "armor_class": 13.0, "hp_dice": "2d8+2",
+			"stats": stats(12.0), "loot_table": loot("wolf-pelt", 1),
+		}
+		if edit != nil {
+			edit(m)
+		}
+		return m
+	}
+
+	cases := []struct {
+		name    string
+		monster map[string]interface{}
+		want    []string // "type:field" of each issue, in order
+	}{
+		{"sound wolf", monster(nil), nil},
+		{"negative CR", monster(func(m map[string]interface{}) { m["challenge_rating"] = -1.0 }), []string{"error:challenge_rating"}},
+		{"CR as text", monster(func(m map[string]interface{}) { m["challenge_rating"] = "1/4" }), []string{"error:challenge_rating"}},
+		{"fractional AC", monster(func(m map[string]interface{}) { m["armor_class"] = 12.5 }), []string{"error:armor_class"}},
+		{"zero AC", monster(func(m map[string]interface{}) { m["armor_class"] = 0.0 }), []string{"error:armor_class"}},
+		{"huge AC", monster(func(m map[string]interface{}) { m["armor_class"] = 35.0 }), []string{"warning:armor_class"}},
+		{"bad hp dice", monster(func(m map[string]interface{}) { m["hp_dice"] = "2x8" }), []string{"error:hp_dice"}},
+		{"score out of range", monster(func(m map[string]interface{}) { m["stats"] = stats(31.0) }), []string{"error:stats.strength"}},
+		{"score not a number", monster(func(m map[string]interface{}) { m["stats"] = stats("12") }), []string{"error:stats.strength"}},
+		{"unknown loot item", monster(func(m map[string]interface{}) { m["loot_table"] = loot("dragon-scale", 1) }),
+			[]string{"error:loot_table.tiers[0].entries[0].item"}},
+		{"no rolls, no loot", monster(func(m map[string]interface{}) { m["loot_table"] = loot("wolf-pelt", 0) }), []string{"warning:loot_table"}},
+	}
+	for _, c := range cases {
+		var got []string
+		for _, issue := range validation.CheckMonsterStatBlock(c.monster, items) {
+			got = append(got, issue.Type+":"+issue.Field)
+		}
+		if strings.Join(got, ",") != strings.Join(c.want, ",") {
+			t.Errorf("%s: issues = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package codex_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"pubkey-quest/cmd/codex/validation"
+)
+
+func TestCheckNPCSchedule(t *testing.T) {
+	dir := t.TempDir()
+	city := `{"id": "town", "districts": {"center": {"id": "town-center", "buildings": [
+		{"id": "inn", "rooms": [{"id": "common_room"}, {"id": "cellar"}]},
+		{"id": "keeper_home"}
+	]}}}`
+	if err := os.WriteFile(filepath.Join(dir, "town.json"), []byte(city), 0644); err != nil {
+		t.Fatal(err)
+	}
+	places := validation.LoadSchedulePlaces(dir)
+
+	check := func(schedule string) (errs, warns []string) {
+		t.Helper()
+		var v interface{}
+		if err := json.Unmarshal([]byte(schedule), &v); err != nil {
+			t.Fatal(err)
+		}
+		for _, issue := range validation.CheckNPCSchedule(v, places) {
+			if issue.Type == "error" {
+				errs = append(errs, issue.Field+": "+issue.Message)
+			} else {
+				warns = append(warns, issue.Message)
+			}
+		}
+		return errs, warns
+	}
+
+	// A full day, wrapping midnight, across a building, a room and a district.
+	errs, warns := check(`[
+		{"start": 1320, "end": 360, "location": "keeper_home"},
+		{"start": 360, "end": 420, "location": "town-center"},
+		{"start": 420, "end": 1320, "location": "inn", "room": "cellar"}
+	]`)
+	if len(errs) != 0 || len(warns) != 0 {
+		t.Errorf("valid schedule: errors %v, warnings %v", errs, warns)
+	}
+
+	errs, _ = check(`[
+		{"start": 0, "end": 720, "location": "inn"},
+		{"start": 600, "end": 1440, "location": "inn"}
+	]`)
+	if len(errs) != 1 {
+		t.Errorf("overlapping slots: errors %v, want one", errs)
+	}
+
+	errs, _ = check(`[
+		{"start": 0, "end": 1440, "location": "castle"},
+		{"start": 0, "end": 0, "location": "inn", "room": "attic"}
+	]`)
+	if len(errs) != 3 {
+		t.Errorf("unknown location, empty slot and unknown room: errors %v, want three", errs)
+	}
+
+	errs, warns = check(`[{"start": 480, "end": 1200, "location": "inn"}]`)
+	if len(errs) != 0 || len(warns) != 2 {
+		t.Errorf("daytime-only schedule: errors %v, warnings %v, want the two uncovered spans", errs, warns)
+	}
+}
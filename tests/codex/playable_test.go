@@ -0,0 +1,68 @@
+package codex_test
+
+import (
+	"strings"
+	"testing"
+
+	"pubkey-quest/cmd/codex/validation"
+	"pubkey-quest/types"
+)
+
+func TestCheckPlayableRegistry(t *testing.T) {
+	clean := &types.PlayableRegistry{
+		Races:   []types.PlayableOption{{ID: "human", Name: "Human"}, {ID: "half-elf", Name: "Half-Elf"}},
+		Classes: []types.PlayableOption{{ID: "fighter", Name: "Fighter"}},
+	}
+	if issues := validation.CheckPlayableRegistry(clean); len(issues) != 0 {
+		t.Fatalf("clean registry should pass, got %+v", issues)
+	}
+
+	broken := &types.PlayableRegistry{
+		Races: []types.PlayableOption{
+			{ID: "human", Name: "Human"},
+			{ID: "human", Name: "Humanoid"}, // duplicate id
+			{ID: "Elf", Name: "Elf"},        // not lowercase
+			{ID: "orc"},                     // no name
+		},
+	}
+	issues := validation.CheckPlayableRegistry(broken)
+	want := []string{"Duplicate id", "lowercase", "Missing required field: name", "at least one"}
+	if len(issues) != len(want) {
+		t.Fatalf("want %d issues, got %+v", len(want), issues)
+	}
+	for i, fragment := range want {
+		if !strings.Contains(issues[i].Message, fragment) {
+			t.Errorf("issue %d: want %q in %q", i, fragment, issues[i].Message)
+		}
+		if issues[i].Category != "playable" {
+			t.Errorf("issue %d has category %q", i, issues[i].Category)
+		}
+	}
+}
+
+// References resolve by id or display name; unknown ones are flagged, and a
+// complete table must cover every registered option.
+func TestCheckPlayableReferences(t *testing.T) {
+	classes := []types.PlayableOption{{ID: "fighter", Name: "Fighter"}, {ID: "wizard", Name: "Wizard"}}
+
+	refs := map[string]string{"[0].class": "Fighter", "[1].class": "wizard"}
+	if issues := validation.CheckPlayableReferences("starting-gear.json", "class", classes, refs, true); len(issues) != 0 {
+		t.Fatalf("covered table should pass, got %+v", issues)
+	}
+
+	refs = map[string]string{"[0].class": "Fighter", "[1].class": "Artificer"}
+	issues := validation.CheckPlayableReferences("starting-gear.json", "class", classes, refs, true)
+	if len(issues) != 2 {
+		t.Fatalf("want 2 issues, got %+v", issues)
+	}
+	if !strings.Contains(issues[0].Message, "'Artificer' is not a playable class") || issues[0].Field != "[1].class" {
+		t.Errorf("unknown class issue: %+v", issues[0])
+	}
+	if !strings.Contains(issues[1].Message, "Wizard") {
+		t.Errorf("missing class issue: %+v", issues[1])
+	}
+
+	if issues := validation.CheckPlayableReferences("starting-spells.json", "class", classes, map[string]string{"wizard": "wizard"}, false); len(issues) != 0 {
+		t.Errorf("partial table should pass when not complete, got %+v", issues)
+	}
+}
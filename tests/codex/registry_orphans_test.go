@@ -0,0 +1,57 @@
+package codex_test
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"pubkey-quest/cmd/codex/validation"
+	"pubkey-quest/tests/helpers"
+)
+
+func TestRegistryEditReportsOrphanedEffects(t *testing.T) {
+	helpers.SetupTestEnvironment(t)
+
+	data, err := os.ReadFile("game-data/systems/effects.json")
+	if err != nil {
+		t.Fatalf("read registry: %v", err)
+	}
+
+	issues, err := validation.ValidateEffectsForRegistry(data)
+	if err != nil {
+		t.Fatalf("validate against current registry: %v", err)
+	}
+	if broken := validation.UnknownStatIssues(issues); len(broken) > 0 {
+		t.Fatalf("current registry already orphans effects: %+v", broken)
+	}
+
+	// Drop "wisdom" — every effect modifying it is now orphaned.
+	var registry map[string]interface{}
+	if err := json.Unmarshal(data, &registry); err != nil {
+		t.Fatalf("parse registry: %v", err)
+	}
+	delete(registry["effect_types"].(map[string]interface{}), "wisdom")
+	edited, _ := json.Marshal(registry)
+
+	issues, err = validation.ValidateEffectsForRegistry(edited)
+	if err != nil {
+		t.Fatalf("validate against edited registry: %v", err)
+	}
+	broken := validation.UnknownStatIssues(issues)
+	if len(broken) == 0 {
+		t.Fatal("removing 'wisdom' should orphan at least one effect (forest-gloom)")
+	}
+	found := false
+	for _, issue := range broken {
+		if !strings.Contains(issue.Message, "'wisdom'") {
+			t.Errorf("unexpected orphan issue: %+v", issue)
+		}
+		if issue.File == "forest-gloom.json" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("forest-gloom.json not reported; got %+v", broken)
+	}
+}
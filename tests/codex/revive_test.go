@@ -0,0 +1,39 @@
+package codex_test
+
+import (
+	"strings"
+	"testing"
+
+	"pubkey-quest/cmd/codex/validation"
+)
+
+func TestCheckReviveItem(t *testing.T) {
+	hp := func(v float64) []interface{} {
+		return []interface{}{map[string]interface{}{"type": "hp", "value": v}}
+	}
+	revive := []string{"consumable", "revive"}
+	cases := []struct {
+		name   string
+		item   map[string]interface{}
+		tags   []string
+		issues []string // expected fields
+	}{
+		{"heal roll", map[string]interface{}{"heal": "2d8"}, revive, nil},
+		{"hp effect", map[string]interface{}{"effects": hp(5)}, revive, nil},
+		{"not a revive", map[string]interface{}{}, []string{"consumable"}, nil},
+		{"heals nothing", map[string]interface{}{}, revive, []string{"heal"}},
+		{"can roll 0", map[string]interface{}{"heal": "1d4 - 1"}, revive, []string{"heal"}},
+		{"unparseable heal", map[string]interface{}{"heal": "lots"}, revive, []string{"heal"}},
+		{"harmful hp effect", map[string]interface{}{"effects": hp(-3)}, revive, []string{"effects[0].value"}},
+		{"not consumable", map[string]interface{}{"heal": "2d8"}, []string{"revive"}, []string{"tags"}},
+	}
+	for _, c := range cases {
+		var got []string
+		for _, issue := range validation.CheckReviveItem(c.item, c.tags) {
+			got = append(got, issue.Field)
+		}
+		if strings.Join(got, ",") != strings.Join(c.issues, ",") {
+			t.Errorf("%s: issue fields = %v, want %v", c.name, got, c.issues)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package codex_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/codex/validation"
+	"pubkey-quest/tests/helpers"
+	"pubkey-quest/types"
+)
+
+func TestCheckSpellClasses(t *testing.T) {
+	classes := []types.PlayableOption{{ID: "wizard", Name: "Wizard"}, {ID: "cleric", Name: "Cleric"}}
+
+	spell := map[string]interface{}{"classes": []interface{}{"wizard", "Cleric"}}
+	if issues := validation.CheckSpellClasses(spell, classes); len(issues) != 0 {
+		t.Fatalf("playable classes flagged: %+v", issues)
+	}
+
+	spell = map[string]interface{}{"classes": []interface{}{"wizard", "artificer", 3}}
+	issues := validation.CheckSpellClasses(spell, classes)
+	if len(issues) != 2 {
+		t.Fatalf("want 2 issues, got %+v", issues)
+	}
+	if issues[0].Field != "classes[1]" || issues[0].Type != "warning" {
+		t.Errorf("unplayable class issue: %+v", issues[0])
+	}
+	if issues[1].Field != "classes[2]" || issues[1].Type != "error" {
+		t.Errorf("non-string class issue: %+v", issues[1])
+	}
+
+	// Without a registry only the shape is checked.
+	if issues := validation.CheckSpellClasses(map[string]interface{}{"classes": []interface{}{"artificer"}}, nil); len(issues) != 0 {
+		t.Errorf("nil registry should skip the playable check, got %+v", issues)
+	}
+}
+
+func TestCheckSpellRolls(t *testing.T) {
+	for _, roll := range []string{"1d6", "3d4+3", "1d8 + 3", "2d6-1", "5"} {
+		if issues := validation.CheckSpellRolls(map[string]interface{}{"damage": roll}); len(issues) != 0 {
+			t.Errorf("%q should parse, got %+v", roll, issues)
+		}
+	}
+	if issues := validation.CheckSpellRolls(map[string]interface{}{"damage": nil, "heal": nil}); len(issues) != 0 {
+		t.Errorf("null rolls are allowed, got %+v", issues)
+	}
+	for _, roll := range []interface{}{"d6", "2d", "1d6+x", "lots", 6} {
+		issues := validation.CheckSpellRolls(map[string]interface{}{"heal": roll})
+		if len(issues) != 1 || issues[0].Field != "heal" {
+			t.Errorf("%v should be rejected, got %+v", roll, issues)
+		}
+	}
+}
+
+// The shipped spells carry no errors.
+func TestValidateSpellsShippedData(t *testing.T) {
+	helpers.SetupTestEnvironment(t)
+	issues, _, err := validation.ValidateSpells()
+	if err != nil {
+		t.Fatalf("ValidateSpells: %v", err)
+	}
+	for _, issue := range issues {
+		if issue.Type == "error" {
+			t.Errorf("%s: %s", issue.File, issue.Message)
+		}
+	}
+}
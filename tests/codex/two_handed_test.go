@@ -0,0 +1,31 @@
+package codex_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/codex/validation"
+)
+
+func TestCheckTwoHandedWeapon(t *testing.T) {
+	cases := []struct {
+		name   string
+		tags   []string
+		issues int
+	}{
+		{"two-handed heavy", []string{"weapon", "two-handed", "heavy"}, 0},
+		{"light one-handed", []string{"weapon", "light"}, 0},
+		{"two-handed and light", []string{"weapon", "two-handed", "light"}, 1},
+	}
+	for _, c := range cases {
+		got := validation.CheckTwoHandedWeapon(c.tags)
+		if len(got) != c.issues {
+			t.Errorf("%s: got %d issues, want %d: %+v", c.name, len(got), c.issues, got)
+			continue
+		}
+		for _, issue := range got {
+			if issue.Type != "error" || issue.Field != "tags" {
+				t.Errorf("%s: issue = %+v, want an error on tags", c.name, issue)
+			}
+		}
+	}
+}
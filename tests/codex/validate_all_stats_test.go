@@ -0,0 +1,62 @@
+package codex_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"pubkey-quest/cmd/codex/validation"
+	"pubkey-quest/tests/helpers"
+)
+
+// TotalFiles counts the files the validators scan, not the issues they raise.
+func TestValidateAllCountsFiles(t *testing.T) {
+	helpers.SetupTestEnvironment(t)
+
+	spellFiles, _ := filepath.Glob("game-data/magic/spells/*.json")
+	_, files, err := validation.ValidateSpells()
+	if err != nil {
+		t.Fatalf("ValidateSpells: %v", err)
+	}
+	if files != len(spellFiles) || files == 0 {
+		t.Errorf("ValidateSpells scanned %d files, want %d", files, len(spellFiles))
+	}
+
+	result, err := validation.ValidateAll()
+	if err != nil {
+		t.Fatalf("ValidateAll: %v", err)
+	}
+	_, itemFiles, _ := validation.ValidateItems()
+	_, monsterFiles, _ := validation.ValidateMonsters()
+	if result.Stats.TotalFiles < files+itemFiles+monsterFiles {
+		t.Errorf("TotalFiles %d is less than the spell, item and monster files alone (%d)",
+			result.Stats.TotalFiles, files+itemFiles+monsterFiles)
+	}
+	counted := result.Stats.ErrorCount + result.Stats.WarningCount + result.Stats.InfoCount
+	if counted != len(result.Issues) {
+		t.Errorf("severity counts %d should add up to the %d issues", counted, len(result.Issues))
+	}
+}
+
+// A single category runs only its own validator.
+func TestValidateCategory(t *testing.T) {
+	helpers.SetupTestEnvironment(t)
+
+	result, err := validation.ValidateCategory("spells")
+	if err != nil {
+		t.Fatalf("ValidateCategory(spells): %v", err)
+	}
+	_, files, _ := validation.ValidateSpells()
+	if result.Stats.TotalFiles != files {
+		t.Errorf("spells category scanned %d files, want %d", result.Stats.TotalFiles, files)
+	}
+	for _, issue := range result.Issues {
+		if issue.Category != "spells" {
+			t.Errorf("issue from another category: %+v", issue)
+		}
+	}
+
+	if _, err := validation.ValidateCategory("dragons"); !errors.Is(err, validation.ErrUnknownCategory) {
+		t.Errorf("unknown category should return ErrUnknownCategory, got %v", err)
+	}
+}
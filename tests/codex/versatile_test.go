@@ -0,0 +1,43 @@
+package codex_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/codex/validation"
+)
+
+func TestCheckVersatileWeapon(t *testing.T) {
+	melee := func(fields map[string]interface{}) map[string]interface{} {
+		item := map[string]interface{}{"type": "Martial Melee Weapons", "damage": "1d8"}
+		for k, v := range fields {
+			item[k] = v
+		}
+		return item
+	}
+	cases := []struct {
+		name string
+		item map[string]interface{}
+		tags []string
+		want []string // "type:field" per issue, in order
+	}{
+		{"versatile weapon", melee(map[string]interface{}{"damage_versatile": "1d10"}), []string{"weapon", "versatile"}, nil},
+		{"plain weapon", melee(nil), []string{"weapon"}, nil},
+		{"tag without dice", melee(nil), []string{"weapon", "versatile"}, []string{"warning:damage_versatile"}},
+		{"legacy split dice", melee(map[string]interface{}{"damage": "1d8,1d10"}), []string{"weapon", "versatile"}, []string{"warning:damage"}},
+		{"dice without tag", melee(map[string]interface{}{"damage_versatile": "1d10"}), []string{"weapon"}, []string{"error:damage_versatile"}},
+		{"ranged weapon", map[string]interface{}{"type": "Simple Ranged Weapons", "damage": "1d6", "damage_versatile": "1d8"}, []string{"weapon", "versatile"}, []string{"error:damage_versatile"}},
+		{"bad dice", melee(map[string]interface{}{"damage-versatile": "big"}), []string{"weapon", "versatile"}, []string{"error:damage-versatile"}},
+	}
+	for _, c := range cases {
+		issues := validation.CheckVersatileWeapon(c.item, c.tags)
+		if len(issues) != len(c.want) {
+			t.Errorf("%s: want %v, got %+v", c.name, c.want, issues)
+			continue
+		}
+		for i, w := range c.want {
+			if got := issues[i].Type + ":" + issues[i].Field; got != w {
+				t.Errorf("%s: issue %d = %s (%s), want %s", c.name, i, got, issues[i].Message, w)
+			}
+		}
+	}
+}
@@ -6,6 +6,7 @@
 package combat_test
 
 import (
+	"strings"
 	"testing"
 
 	"pubkey-quest/cmd/server/db"
@@ -59,7 +60,7 @@ func TestStartCombatWiresResourceAndDifficulty(t *testing.T) {
 		t.Fatalf("load advancement: %v", err)
 	}
 	save := fighterSave()
-	cs, err := combat.StartCombat(db.GetDB(), save, "npub_test", "wolf", "forest", adv)
+	cs, err := combat.StartCombat(db.GetDB(), save, "npub_test", []string{"wolf"}, "forest", adv)
 	if err != nil {
 		t.Fatalf("StartCombat: %v", err)
 	}
@@ -114,3 +115,34 @@ func TestAbilityRejectsWrongClass(t *testing.T) {
 		t.Error("a fighter should not be able to Enter Rage")
 	}
 }
+
+// Power Strike readies a damage multiplier, then sits on its round cooldown:
+// a second use is refused with the rounds left.
+func TestPowerStrikeCooldown(t *testing.T) {
+	combatSetup(t)
+	adv, _ := character.LoadAdvancement(db.GetDB())
+	save := fighterSave()
+	for _, a := range adv {
+		if a.Level == 3 {
+			save.Experience = a.ExperiencePoints
+		}
+	}
+	cs := activeFightWithStamina()
+
+	if _, err := combat.ProcessPlayerAbility(db.GetDB(), cs, save, "power-strike", adv); err != nil {
+		t.Fatalf("power-strike: %v", err)
+	}
+	st := &cs.Party[0].CombatState
+	if st.PendingDamagePct != 150 {
+		t.Errorf("tier 1 Power Strike should ready 150%% damage, got %d", st.PendingDamagePct)
+	}
+	if st.AbilityCooldowns["power-strike"] != 2 {
+		t.Fatalf("expected a 2-round cooldown, got %+v", st.AbilityCooldowns)
+	}
+
+	st.BonusActionUsed = false
+	_, err := combat.ProcessPlayerAbility(db.GetDB(), cs, save, "power-strike", adv)
+	if err == nil || !strings.Contains(err.Error(), "2 more round") {
+		t.Errorf("reuse should report the rounds left, got %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package combat_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/server/db"
+	"pubkey-quest/cmd/server/game/combat"
+	"pubkey-quest/types"
+)
+
+func archerFight(weapon string) (*types.CombatSession, *types.SaveFile) {
+	save := fighterSave()
+	save.Inventory = map[string]interface{}{
+		"gear_slots": map[string]interface{}{
+			"mainhand": map[string]interface{}{"item": weapon, "quantity": 1},
+		},
+	}
+	cs := &types.CombatSession{
+		Phase:      "active",
+		GridWidth:  9,
+		GridHeight: 7,
+		PlayerPos:  types.Position{X: 1, Y: 3},
+		Party: []types.PartyCombatant{{
+			Type:        "player",
+			CombatState: types.PlayerCombatState{CurrentHP: 20, MaxHP: 20, MovementBudget: 6},
+		}},
+		Monsters: []types.MonsterInstance{{Name: "Wolf", InstanceID: "wolf", IsAlive: true,
+			CurrentHP: 5, MaxHP: 5, Pos: types.Position{X: 7, Y: 3}}},
+	}
+	return cs, save
+}
+
+// Aim spends the bonus action and the turn's movement; moving afterwards loses it.
+func TestAimTradesMovementAndBreaksOnMove(t *testing.T) {
+	combatSetup(t)
+	cs, save := archerFight("shortbow")
+
+	if _, err := combat.ProcessPlayerAim(db.GetDB(), cs, save); err != nil {
+		t.Fatalf("aim with a shortbow: %v", err)
+	}
+	st := &cs.Party[0].CombatState
+	if !st.Aiming || !st.BonusActionUsed || st.MovementSpent != st.MovementBudget {
+		t.Fatalf("after aim: aiming=%v bonus=%v movement %d/%d", st.Aiming, st.BonusActionUsed, st.MovementSpent, st.MovementBudget)
+	}
+
+	// Next turn: still aiming until the player moves.
+	st.BonusActionUsed, st.MovementSpent = false, 0
+	if _, err := combat.ProcessPlayerAim(db.GetDB(), cs, save); err == nil {
+		t.Error("aiming twice should be rejected")
+	}
+	if _, err := combat.ProcessPlayerMove(db.GetDB(), cs, save, "", 2, 3); err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	if st.Aiming {
+		t.Error("moving should clear the aim")
+	}
+	if _, err := combat.ProcessPlayerAim(db.GetDB(), cs, save); err == nil {
+		t.Error("aiming after moving this turn should be rejected")
+	}
+}
+
+func TestAimNeedsRangedWeapon(t *testing.T) {
+	combatSetup(t)
+	cs, save := archerFight("longsword")
+	if _, err := combat.ProcessPlayerAim(db.GetDB(), cs, save); err == nil {
+		t.Error("aiming a longsword should be rejected")
+	}
+	if cs.Party[0].CombatState.BonusActionUsed {
+		t.Error("a rejected aim must not spend the bonus action")
+	}
+}
@@ -0,0 +1,69 @@
+package combat_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/server/db"
+	"pubkey-quest/cmd/server/game/combat"
+)
+
+// The class proficiency tables must only name real weapons and known armor
+// categories, and cover exactly the playable classes.
+func TestProficiencyTablesMatchItems(t *testing.T) {
+	combatSetup(t)
+	for _, problem := range combat.CheckProficiencyTables(db.GetDB()) {
+		t.Error(problem)
+	}
+}
+
+// A wizard in plate keeps its AC but is hampered; a fighter in the same plate
+// isn't. Hampering costs attacks their edge.
+func TestNonProficientArmorHampers(t *testing.T) {
+	combatSetup(t)
+	cs, save := archerFight("longsword")
+	cs.Monsters[0].Pos.X = 2 // adjacent
+	gear := save.Inventory["gear_slots"].(map[string]interface{})
+	gear["chest"] = map[string]interface{}{"item": "plate-cuirass", "quantity": 1}
+	gear["offhand"] = map[string]interface{}{"item": "shield", "quantity": 1}
+
+	if got := combat.NonProficientArmor(db.GetDB(), save); len(got) != 0 {
+		t.Errorf("fighter: non-proficient armor %v, want none", got)
+	}
+
+	save.Class = "Wizard"
+	got := combat.NonProficientArmor(db.GetDB(), save)
+	if len(got) != 2 || got[0] != "plate-cuirass" || got[1] != "shield" {
+		t.Errorf("wizard: non-proficient armor %v, want [plate-cuirass shield]", got)
+	}
+	if ac := combat.CalculatePlayerAC(db.GetDB(), save.Inventory, save.Stats); ac < 13 {
+		t.Errorf("wizard in plate and shield: AC %d, want the armor's full AC", ac)
+	}
+
+	if _, err := combat.ProcessPlayerAttack(db.GetDB(), cs, save, "", "mainhand", "main", false, nil); err != nil {
+		t.Fatalf("attack: %v", err)
+	}
+	if !cs.Party[0].CombatState.ArmorHampered {
+		t.Error("attacking in unfamiliar armor should mark the player hampered")
+	}
+}
+
+func TestArmorCategory(t *testing.T) {
+	cases := []struct {
+		item map[string]interface{}
+		want string
+	}{
+		{map[string]interface{}{"type": "Light Armor"}, combat.ArmorLight},
+		{map[string]interface{}{"type": "Medium Armor"}, combat.ArmorMedium},
+		{map[string]interface{}{"type": "Heavy Armor"}, combat.ArmorHeavy},
+		{map[string]interface{}{"type": "Heavy Armor", "tags": []interface{}{"equipment", "shield"}}, combat.ArmorShields},
+		{map[string]interface{}{"type": "Martial Melee Weapons"}, ""},
+	}
+	for _, c := range cases {
+		if got := combat.ArmorCategory(c.item); got != c.want {
+			t.Errorf("ArmorCategory(%v) = %q, want %q", c.item, got, c.want)
+		}
+	}
+	if !combat.IsProficientWithArmor("Wizard", "") || combat.IsProficientWithArmor("Wizard", combat.ArmorLight) {
+		t.Error("wizard: needs no training for non-armor, and has none for light armor")
+	}
+}
@@ -3,7 +3,10 @@ package combat_test
 import (
 	"testing"
 
+	gamedata "pubkey-quest/cmd/server/api/data"
+	"pubkey-quest/cmd/server/db"
 	"pubkey-quest/cmd/server/game/combat"
+	"pubkey-quest/types"
 )
 
 // Proficiency bonus must scale correctly across the alpha level band (and
@@ -20,3 +23,67 @@ func TestProficiencyScaling(t *testing.T) {
 		}
 	}
 }
+
+// A finesse weapon attacks and hits with the better of STR and DEX: the same
+// dagger rides a rogue's DEX and a fighter's STR. Both are proficient with
+// simple weapons, so level 1 adds +2.
+func TestFinesseUsesBetterOfStrAndDex(t *testing.T) {
+	combatSetup(t)
+	dagger, err := gamedata.LoadItemByID(db.GetDB(), "dagger")
+	if err != nil {
+		t.Fatalf("load dagger: %v", err)
+	}
+
+	rogue := map[string]interface{}{"strength": float64(8), "dexterity": float64(18)} // -1 / +4
+	if got := combat.WeaponAbility(dagger, rogue); got != "dexterity" {
+		t.Errorf("rogue dagger ability = %s, want dexterity", got)
+	}
+	if got := combat.WeaponAttackBonus(dagger, rogue, "Rogue", 1); got != 6 {
+		t.Errorf("rogue dagger attack bonus = %d, want 6 (DEX +4, prof +2)", got)
+	}
+	if got := combat.WeaponDamageBonus(dagger, rogue); got != 4 {
+		t.Errorf("rogue dagger damage bonus = %d, want 4", got)
+	}
+
+	fighter := map[string]interface{}{"strength": float64(16), "dexterity": float64(12)} // +3 / +1
+	if got := combat.WeaponAbility(dagger, fighter); got != "strength" {
+		t.Errorf("fighter dagger ability = %s, want strength", got)
+	}
+	if got := combat.WeaponAttackBonus(dagger, fighter, "Fighter", 1); got != 5 {
+		t.Errorf("fighter dagger attack bonus = %d, want 5 (STR +3, prof +2)", got)
+	}
+	if got := combat.WeaponDamageBonus(dagger, fighter); got != 3 {
+		t.Errorf("fighter dagger damage bonus = %d, want 3", got)
+	}
+
+	// A non-finesse melee weapon ignores DEX entirely.
+	longsword, err := gamedata.LoadItemByID(db.GetDB(), "longsword")
+	if err != nil {
+		t.Fatalf("load longsword: %v", err)
+	}
+	if got := combat.WeaponDamageBonus(longsword, rogue); got != -1 {
+		t.Errorf("rogue longsword damage bonus = %d, want -1 (STR)", got)
+	}
+}
+
+// An attack reports how to animate it: weapon category and damage type from the
+// item, melee vs ranged, and the damage dealt.
+func TestAttackPresentation(t *testing.T) {
+	combatSetup(t)
+	cs, save := archerFight("longsword")
+	cs.Monsters[0].Pos = types.Position{X: 2, Y: 3} // adjacent
+
+	if _, err := combat.ProcessPlayerAttack(db.GetDB(), cs, save, "", "mainhand", "main", false, nil); err != nil {
+		t.Fatalf("attack: %v", err)
+	}
+	p := cs.LastAttack
+	if p == nil {
+		t.Fatal("attack left no presentation hint")
+	}
+	if p.WeaponID != "longsword" || p.WeaponCategory != "martial" || p.DamageType != "slashing" || p.Ranged || p.Thrown {
+		t.Errorf("presentation = %+v", p)
+	}
+	if p.TargetID != "wolf" || p.Hit != (p.Damage > 0) {
+		t.Errorf("target %q, hit %v with %d damage", p.TargetID, p.Hit, p.Damage)
+	}
+}
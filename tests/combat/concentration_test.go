@@ -0,0 +1,53 @@
+package combat_test
+
+import (
+	"strings"
+	"testing"
+
+	"pubkey-quest/cmd/server/db"
+	"pubkey-quest/cmd/server/game/combat"
+	"pubkey-quest/types"
+)
+
+// A second concentration spell in the same fight is refused while the fight is
+// holding the first one — even one that left no effect on the save — and goes
+// through once the first is let go. Re-casting the held spell just renews it.
+func TestSecondConcentrationCastInFight(t *testing.T) {
+	combatSetup(t)
+	save := fighterSave()
+	save.Class, save.Mana, save.MaxMana = "Cleric", 10, 10
+	save.Stats["wisdom"] = 16
+	save.KnownSpells = []string{"shield-of-faith"}
+	save.SpellSlots = map[string]interface{}{
+		"level_1": []interface{}{
+			map[string]interface{}{"slot": float64(0), "spell": "shield-of-faith", "quantity": float64(0)},
+		},
+	}
+	cs := activeFightWithStamina()
+	cs.PlayerPos, cs.Monsters[0].Pos = types.Position{X: 1, Y: 3}, types.Position{X: 5, Y: 3} // out of melee
+	cs.Concentration = &types.ConcentrationState{SpellID: "hex", SpellName: "Hex"}
+
+	_, err := combat.ProcessPlayerCast(db.GetDB(), cs, save, "shield-of-faith", "", nil)
+	if err == nil || !strings.Contains(err.Error(), "already concentrating on Hex") {
+		t.Fatalf("second concentration cast: err = %v, want it refused", err)
+	}
+	if save.Mana != 10 || cs.Party[0].CombatState.BonusActionUsed {
+		t.Errorf("refused cast spent mana %d / bonus action %v", 10-save.Mana, cs.Party[0].CombatState.BonusActionUsed)
+	}
+	if cs.Concentration.SpellID != "hex" {
+		t.Errorf("concentration = %+v, want Hex still held", cs.Concentration)
+	}
+
+	// Let go of Hex and the cast goes through.
+	cs.Concentration = nil
+	if _, err := combat.ProcessPlayerCast(db.GetDB(), cs, save, "shield-of-faith", "", nil); err != nil {
+		t.Fatalf("cast with no concentration held: %v", err)
+	}
+
+	// Next turn, re-casting the spell already held is allowed.
+	cs.Party[0].CombatState.BonusActionUsed = false
+	cs.Concentration = &types.ConcentrationState{SpellID: "shield-of-faith", SpellName: "Shield of Faith"}
+	if _, err := combat.ProcessPlayerCast(db.GetDB(), cs, save, "shield-of-faith", "", nil); err != nil {
+		t.Errorf("re-casting the held spell: %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package combat_test
+
+import (
+	"strings"
+	"testing"
+
+	"pubkey-quest/cmd/server/db"
+	"pubkey-quest/cmd/server/game/combat"
+	"pubkey-quest/cmd/server/game/effects"
+)
+
+// The poison effect imposes the poisoned condition while it lasts, and its
+// saving throw lets the player throw it off at the end of a combat turn.
+func TestPoisonEffectImposesConditionWithSave(t *testing.T) {
+	combatSetup(t)
+	save := fighterSave()
+
+	if effects.HasCondition(save, "poisoned") {
+		t.Fatal("a fresh save shouldn't be poisoned")
+	}
+	if err := effects.ApplyEffect(save, "poison"); err != nil {
+		t.Fatalf("apply poison: %v", err)
+	}
+	if !effects.HasCondition(save, "Poisoned") {
+		t.Fatalf("poison should impose poisoned, active conditions %v", effects.ActiveConditions(save))
+	}
+
+	// Poisoned hampers attacks but doesn't cost the turn.
+	cs := activeFightWithStamina()
+	if _, err := combat.ProcessPlayerAttack(db.GetDB(), cs, save, "", "mainhand", "main", false, nil); err != nil && strings.Contains(err.Error(), "can't act") {
+		t.Errorf("poisoned shouldn't stop an attack: %v", err)
+	}
+
+	// CON 40 (+15) always beats the DC 12 save.
+	save.Stats["constitution"] = 40
+	cs = activeFightWithStamina()
+	log, err := combat.ProcessEndTurn(db.GetDB(), cs, save)
+	if err != nil {
+		t.Fatalf("end turn: %v", err)
+	}
+	if !strings.Contains(strings.Join(log, "\n"), "You shake off poisoned") {
+		t.Errorf("end-of-turn log should record the save, got %q", log)
+	}
+	if effects.HasActiveEffect(save, "poison") || effects.HasCondition(save, "poisoned") {
+		t.Errorf("a successful save should end the effect, active %+v", save.ActiveEffects)
+	}
+}
@@ -0,0 +1,71 @@
+package combat_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/server/db"
+	"pubkey-quest/cmd/server/game/combat"
+	"pubkey-quest/cmd/server/game/inventory"
+)
+
+// An archer caught in melee draws a sword for free, swapping back costs the
+// action, and a third swap that turn is refused. Weapons in the backpack and
+// things that aren't weapons can't be drawn.
+func TestEquipWeaponInCombat(t *testing.T) {
+	combatSetup(t)
+	save := fighterSave()
+	save.Inventory = map[string]interface{}{
+		"general_slots": []interface{}{
+			map[string]interface{}{"slot": 0, "item": "longsword", "quantity": 1},
+			map[string]interface{}{"slot": 1, "item": "rations", "quantity": 1},
+			map[string]interface{}{"slot": 2, "item": nil, "quantity": 0},
+		},
+		"gear_slots": map[string]interface{}{
+			"mainhand": map[string]interface{}{"item": "longbow", "quantity": 1},
+			"offhand":  map[string]interface{}{"item": "longbow", "quantity": 1},
+			"bag": map[string]interface{}{"item": "backpack", "quantity": 1, "contents": []interface{}{
+				map[string]interface{}{"slot": 0, "item": "dagger", "quantity": 1},
+			}},
+		},
+	}
+	cs := activeFightWithStamina()
+	state := &cs.Party[0].CombatState
+	d := db.GetDB()
+
+	if _, err := combat.ProcessPlayerEquip(d, cs, save, "longsword", ""); err != nil {
+		t.Fatalf("drawing the sword: %v", err)
+	}
+	if got := inventory.GetEquippedItemID(save.Inventory, "mainhand"); got != "longsword" {
+		t.Errorf("mainhand = %q, want longsword", got)
+	}
+	if got := inventory.GetEquippedItemID(save.Inventory, "offhand"); got != "" {
+		t.Errorf("the bow's offhand half should be cleared, offhand = %q", got)
+	}
+	if !state.InteractionUsed || state.ActionUsed {
+		t.Errorf("the first swap should be the free interaction: interaction %v, action %v", state.InteractionUsed, state.ActionUsed)
+	}
+
+	if _, err := combat.ProcessPlayerEquip(d, cs, save, "longbow", ""); err != nil {
+		t.Fatalf("swapping back to the bow: %v", err)
+	}
+	if got := inventory.GetEquippedItemID(save.Inventory, "mainhand"); got != "longbow" || !state.ActionUsed {
+		t.Errorf("the second swap should take the action: mainhand %q, action %v", got, state.ActionUsed)
+	}
+	if _, err := combat.ProcessPlayerEquip(d, cs, save, "longsword", ""); err == nil {
+		t.Error("a third swap with the interaction and action spent should be refused")
+	}
+
+	state.InteractionUsed, state.ActionUsed = false, false
+	for _, c := range []struct{ item, why string }{
+		{"dagger", "it's in the backpack"},
+		{"rations", "it isn't a weapon"},
+		{"greatsword", "none is carried"},
+	} {
+		if _, err := combat.ProcessPlayerEquip(d, cs, save, c.item, ""); err == nil {
+			t.Errorf("equipping %s should be refused: %s", c.item, c.why)
+		}
+	}
+	if state.InteractionUsed {
+		t.Error("a refused swap shouldn't spend the interaction")
+	}
+}
@@ -0,0 +1,65 @@
+package combat_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/server/db"
+	"pubkey-quest/cmd/server/game/character"
+	"pubkey-quest/cmd/server/game/combat"
+)
+
+// A pack fields one numbered, separately placed monster per ID, and every one
+// of them rolls into the initiative order.
+func TestStartCombatWithPack(t *testing.T) {
+	combatSetup(t)
+	adv, err := character.LoadAdvancement(db.GetDB())
+	if err != nil {
+		t.Fatalf("load advancement: %v", err)
+	}
+	save := fighterSave()
+	save.HP, save.MaxHP = 200, 200 // survive any opening volley
+
+	cs, err := combat.StartCombat(db.GetDB(), save, "npub_test", []string{"goblin", "goblin", "wolf"}, "forest", adv)
+	if err != nil {
+		t.Fatalf("StartCombat: %v", err)
+	}
+	if len(cs.Monsters) != 3 {
+		t.Fatalf("want 3 monsters, got %d", len(cs.Monsters))
+	}
+	wantIDs := []string{"goblin-1", "goblin-2", "wolf"}
+	wantNames := []string{"Goblin 1", "Goblin 2", "Wolf"}
+	cells := map[[2]int]bool{}
+	for i, m := range cs.Monsters {
+		if m.InstanceID != wantIDs[i] || m.Name != wantNames[i] {
+			t.Errorf("monster %d: %s %q, want %s %q", i, m.InstanceID, m.Name, wantIDs[i], wantNames[i])
+		}
+		cells[[2]int{m.Pos.X, m.Pos.Y}] = true
+	}
+	if len(cells) != 3 {
+		t.Errorf("monsters should start on separate cells: %+v", cs.Monsters)
+	}
+
+	if len(cs.Initiative) != 4 {
+		t.Fatalf("initiative should list the player and 3 monsters, got %+v", cs.Initiative)
+	}
+	seen := map[string]bool{}
+	for _, entry := range cs.Initiative {
+		seen[entry.ID] = true
+	}
+	for _, id := range append(wantIDs, "npub_test") {
+		if !seen[id] {
+			t.Errorf("%s missing from initiative %+v", id, cs.Initiative)
+		}
+	}
+
+	if _, err := combat.StartCombat(db.GetDB(), save, "npub_test", nil, "forest", adv); err == nil {
+		t.Error("an encounter needs at least one monster")
+	}
+	tooMany := make([]string, combat.MaxEncounterMonsters+1)
+	for i := range tooMany {
+		tooMany[i] = "goblin"
+	}
+	if _, err := combat.StartCombat(db.GetDB(), save, "npub_test", tooMany, "forest", adv); err == nil {
+		t.Error("an encounter over the monster cap should be refused")
+	}
+}
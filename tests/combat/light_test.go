@@ -0,0 +1,64 @@
+package combat_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/server/db"
+	"pubkey-quest/cmd/server/game/combat"
+	"pubkey-quest/cmd/server/game/effects"
+)
+
+// Cities stay lit at night; the wilds lose a step, so a dim forest goes dark.
+func TestAmbientLight(t *testing.T) {
+	cases := []struct {
+		locationType, configured string
+		night                    bool
+		want                     string
+	}{
+		{"city", "", false, combat.LightBright},
+		{"city", "", true, combat.LightDim},
+		{"environment", "", false, combat.LightBright},
+		{"environment", "", true, combat.LightDim},
+		{"environment", "dim", false, combat.LightDim},
+		{"environment", "dim", true, combat.LightDark},
+		{"environment", "dark", false, combat.LightDark},
+		{"environment", "pitch", false, combat.LightBright},
+	}
+	for _, c := range cases {
+		if got := combat.AmbientLightFor(c.locationType, c.configured, c.night); got != c.want {
+			t.Errorf("%s light=%q night=%v: got %s, want %s", c.locationType, c.configured, c.night, got, c.want)
+		}
+	}
+	if got := combat.LightLevel(combat.LightDark, true); got != combat.LightDim {
+		t.Errorf("a light source in darkness should give dim light, got %s", got)
+	}
+	if got := combat.LightLevel(combat.LightBright, false); got != combat.LightBright {
+		t.Errorf("daylight without a light source = %s", got)
+	}
+}
+
+// A lit torch (timed light effect) or a lantern in the offhand both count.
+func TestHasLightSource(t *testing.T) {
+	combatSetup(t)
+	save := fighterSave()
+	if combat.HasLightSource(db.GetDB(), save) {
+		t.Fatal("an empty-handed fighter shouldn't have light")
+	}
+
+	if err := effects.ApplyEffect(save, "torchlight"); err != nil {
+		t.Fatalf("apply torchlight: %v", err)
+	}
+	if !combat.HasLightSource(db.GetDB(), save) {
+		t.Error("a lit torch should count as a light source")
+	}
+
+	save = fighterSave()
+	save.Inventory = map[string]interface{}{
+		"gear_slots": map[string]interface{}{
+			"offhand": map[string]interface{}{"item": "lantern-hooded", "quantity": 1},
+		},
+	}
+	if !combat.HasLightSource(db.GetDB(), save) {
+		t.Error("an equipped lantern should count as a light source")
+	}
+}
@@ -0,0 +1,68 @@
+package combat_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/server/db"
+	"pubkey-quest/cmd/server/game/combat"
+	"pubkey-quest/types"
+)
+
+func lootItems(drops []types.LootDrop) []string {
+	ids := make([]string, 0, len(drops))
+	for _, d := range drops {
+		ids = append(ids, d.Item)
+	}
+	return ids
+}
+
+// The loot filter leaves behind what the player turned down: below the rarity
+// or value floor, on the ignore list, or everything with auto-pickup off.
+// Currency clears the floors.
+func TestFilterLoot(t *testing.T) {
+	combatSetup(t)
+	drops := []types.LootDrop{
+		{Item: "gold-piece", Quantity: 12},
+		{Item: "torch", Quantity: 1},        // common, 1
+		{Item: "longsword", Quantity: 1},    // common, 1500
+		{Item: "iron-filings", Quantity: 2}, // uncommon
+	}
+
+	cases := []struct {
+		name     string
+		filter   *types.LootFilter
+		wantKeep []string
+	}{
+		{"no filter", nil, []string{"gold-piece", "torch", "longsword", "iron-filings"}},
+		{"min rarity", &types.LootFilter{MinRarity: "uncommon"}, []string{"gold-piece", "iron-filings"}},
+		{"min value", &types.LootFilter{MinValue: 100}, []string{"gold-piece", "longsword"}},
+		{"ignore list", &types.LootFilter{Ignore: []string{"torch", "gold-piece"}}, []string{"longsword", "iron-filings"}},
+		{"manual pickup", &types.LootFilter{ManualPickup: true}, []string{}},
+	}
+	for _, c := range cases {
+		keep, skip := combat.FilterLoot(db.GetDB(), c.filter, drops)
+		got := lootItems(keep)
+		if len(got) != len(c.wantKeep) || len(keep)+len(skip) != len(drops) {
+			t.Errorf("%s: kept %v, skipped %v; want kept %v", c.name, got, lootItems(skip), c.wantKeep)
+			continue
+		}
+		for i := range got {
+			if got[i] != c.wantKeep[i] {
+				t.Errorf("%s: kept %v, want %v", c.name, got, c.wantKeep)
+				break
+			}
+		}
+	}
+}
+
+func TestValidateLootFilter(t *testing.T) {
+	if err := combat.ValidateLootFilter(types.LootFilter{MinRarity: "rare", MinValue: 50}); err != nil {
+		t.Errorf("valid filter rejected: %v", err)
+	}
+	if err := combat.ValidateLootFilter(types.LootFilter{MinRarity: "shiny"}); err == nil {
+		t.Error("unknown rarity accepted")
+	}
+	if err := combat.ValidateLootFilter(types.LootFilter{MinValue: -1}); err == nil {
+		t.Error("negative min value accepted")
+	}
+}
@@ -0,0 +1,34 @@
+package combat_test
+
+import (
+	"math"
+	"testing"
+
+	"pubkey-quest/cmd/server/game/combat"
+	"pubkey-quest/cmd/server/game/effects"
+	"pubkey-quest/types"
+)
+
+// Loot luck grows with level and with a fortune buff.
+func TestLootLuck(t *testing.T) {
+	combatSetup(t)
+	save := fighterSave()
+
+	if got := combat.LootLuck(save, 1); got != 1.0 {
+		t.Errorf("a level 1 character without buffs should have no luck, got %v", got)
+	}
+	if got := combat.LootLuck(save, 11); math.Abs(got-1.2) > 1e-9 {
+		t.Errorf("level 11 luck = %v, want 1.2", got)
+	}
+
+	if _, err := effects.ApplyEffectWithMessage(save, "fortune"); err != nil {
+		t.Fatalf("apply fortune: %v", err)
+	}
+	if got := combat.LootLuck(save, 1); math.Abs(got-1.3) > 1e-9 {
+		t.Errorf("fortune (+3 luck) = %v, want 1.3", got)
+	}
+
+	if got := combat.LootLuck(&types.SaveFile{}, 0); got != 1.0 {
+		t.Errorf("no level and no effects should leave luck at 1, got %v", got)
+	}
+}
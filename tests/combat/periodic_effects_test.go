@@ -0,0 +1,39 @@
+package combat_test
+
+import (
+	"strings"
+	"testing"
+
+	"pubkey-quest/cmd/server/db"
+	"pubkey-quest/cmd/server/game/combat"
+	"pubkey-quest/cmd/server/game/effects"
+)
+
+// Periodic hp effects tick on game time in combat too: a round is six seconds,
+// so starving (1 HP every 4 hours) doesn't drain anything over a fight, while
+// the round time still builds up toward its next tick.
+func TestStarvingDoesNotTickEachRound(t *testing.T) {
+	combatSetup(t)
+	save := fighterSave()
+	if err := effects.ApplyEffect(save, "starving"); err != nil {
+		t.Fatalf("apply starving: %v", err)
+	}
+
+	cs := activeFightWithStamina()
+	for round := 0; round < 20 && cs.Phase == "active"; round++ {
+		log, err := combat.ProcessEndTurn(db.GetDB(), cs, save)
+		if err != nil {
+			t.Fatalf("end turn: %v", err)
+		}
+		if joined := strings.Join(log, "\n"); strings.Contains(joined, "Starving:") {
+			t.Fatalf("round %d: starving ticked mid-fight:\n%s", round+1, joined)
+		}
+	}
+
+	for _, ae := range save.ActiveEffects {
+		if ae.EffectID == "starving" && ae.TickAccumulator > 0 {
+			return
+		}
+	}
+	t.Errorf("combat rounds should count toward starving's next tick, active %+v", save.ActiveEffects)
+}
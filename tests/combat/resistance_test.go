@@ -0,0 +1,31 @@
+package combat_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/server/db"
+	"pubkey-quest/cmd/server/game/combat"
+	"pubkey-quest/cmd/server/game/effects"
+)
+
+// Drinking a fire-resistance potion gives the player fire resistance; the
+// defenses come from the effect's damage_resistance modifier.
+func TestPlayerDamageDefensesFromEffects(t *testing.T) {
+	combatSetup(t)
+	save := fighterSave()
+
+	if resist, immune := combat.PlayerDamageDefenses(db.GetDB(), save); len(resist)+len(immune) != 0 {
+		t.Fatalf("no effects should mean no defenses, got %v / %v", resist, immune)
+	}
+
+	if _, err := effects.ApplyEffectWithMessage(save, "fire-resistance"); err != nil {
+		t.Fatalf("apply fire-resistance: %v", err)
+	}
+	resist, immune := combat.PlayerDamageDefenses(db.GetDB(), save)
+	if len(resist) != 1 || resist[0] != "fire" {
+		t.Errorf("resistances = %v, want [fire]", resist)
+	}
+	if len(immune) != 0 {
+		t.Errorf("immunities = %v, want none", immune)
+	}
+}
@@ -0,0 +1,50 @@
+package combat_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/server/db"
+	"pubkey-quest/cmd/server/game/combat"
+)
+
+// A phoenix feather used while dying takes the place of the death save: the
+// player comes back up with HP, the counters clear and the fight resumes. Other
+// consumables can't be used while dying.
+func TestReviveItemDuringDeathSaves(t *testing.T) {
+	combatSetup(t)
+	save := fighterSave()
+	save.Inventory = map[string]interface{}{
+		"general_slots": []interface{}{
+			map[string]interface{}{"slot": 0, "item": "healing", "quantity": 1},
+			map[string]interface{}{"slot": 1, "item": "phoenix-feather", "quantity": 1},
+		},
+	}
+	cs := activeFightWithStamina()
+	cs.Phase = "death_saves"
+	state := &cs.Party[0].CombatState
+	state.CurrentHP = 0
+	state.IsUnconscious = true
+	state.DeathSaveSuccesses, state.DeathSaveFailures = 1, 2
+	state.ActionUsed = true // dropped mid-turn
+	d := db.GetDB()
+
+	if _, err := combat.ProcessPlayerUseItem(d, cs, save, "healing"); err == nil {
+		t.Error("a healing potion shouldn't be usable while dying")
+	}
+	if _, err := combat.ProcessPlayerUseItem(d, cs, save, "phoenix-feather"); err != nil {
+		t.Fatalf("using the feather while dying: %v", err)
+	}
+	if cs.Phase != "active" || state.IsUnconscious || state.CurrentHP < 2 {
+		t.Errorf("the feather should revive: phase %s, unconscious %v, hp %d", cs.Phase, state.IsUnconscious, state.CurrentHP)
+	}
+	if state.DeathSaveSuccesses != 0 || state.DeathSaveFailures != 0 {
+		t.Errorf("death saves should clear, got %d/%d", state.DeathSaveSuccesses, state.DeathSaveFailures)
+	}
+	if !state.ActionUsed || state.BonusActionUsed || state.MovementBudget == 0 {
+		t.Errorf("waking should open a fresh turn with the action spent: %+v", state)
+	}
+	gen := save.Inventory["general_slots"].([]interface{})
+	if item := gen[1].(map[string]interface{})["item"]; item != nil {
+		t.Errorf("the feather should be used up, slot holds %v", item)
+	}
+}
@@ -0,0 +1,61 @@
+package combat_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/server/db"
+	"pubkey-quest/cmd/server/game/combat"
+)
+
+// A healing potion is a bonus action: it can be drunk after attacking, heals the
+// live combat HP pool, and a second one has to wait for the next turn. Items that
+// take the action, non-consumables and items not carried are refused.
+func TestUseItemInCombat(t *testing.T) {
+	combatSetup(t)
+	save := fighterSave()
+	save.Inventory = map[string]interface{}{
+		"general_slots": []interface{}{
+			map[string]interface{}{"slot": 0, "item": "healing", "quantity": 2},
+			map[string]interface{}{"slot": 1, "item": "rations", "quantity": 1},
+			map[string]interface{}{"slot": 2, "item": "longsword", "quantity": 1},
+		},
+	}
+	cs := activeFightWithStamina()
+	state := &cs.Party[0].CombatState
+	state.CurrentHP = 5
+	state.ActionUsed = true // already attacked this turn
+	d := db.GetDB()
+
+	if !combat.QuickItemAvailable(d, cs, save) {
+		t.Error("a potion in reach with the bonus action free should count as a quick item")
+	}
+	if _, err := combat.ProcessPlayerUseItem(d, cs, save, "healing"); err != nil {
+		t.Fatalf("drinking a potion after attacking: %v", err)
+	}
+	if !state.BonusActionUsed || state.CurrentHP <= 5 {
+		t.Errorf("the potion should take the bonus action and heal: bonus %v, hp %d", state.BonusActionUsed, state.CurrentHP)
+	}
+	gen := save.Inventory["general_slots"].([]interface{})
+	if q := gen[0].(map[string]interface{})["quantity"]; q != 1 {
+		t.Errorf("one potion should be used up, %v left", q)
+	}
+	if combat.QuickItemAvailable(d, cs, save) {
+		t.Error("with the bonus action spent no quick item is available")
+	}
+
+	for _, c := range []struct {
+		item                  string
+		actionUsed, bonusUsed bool
+		why                   string
+	}{
+		{"healing", true, true, "both actions are spent"},
+		{"rations", true, false, "eating takes the action"},
+		{"longsword", false, false, "a sword isn't a consumable"},
+		{"greater-healing", false, false, "none is carried"},
+	} {
+		state.ActionUsed, state.BonusActionUsed = c.actionUsed, c.bonusUsed
+		if _, err := combat.ProcessPlayerUseItem(d, cs, save, c.item); err == nil {
+			t.Errorf("using %s should be refused: %s", c.item, c.why)
+		}
+	}
+}
@@ -143,3 +143,48 @@ func TestRollAvoidsImmediateRepeat(t *testing.T) {
 		t.Error("expected the single-monster pool to fire at least once")
 	}
 }
+
+func TestDangerByTravelDifficulty(t *testing.T) {
+	if encounter.Danger("easy") >= encounter.Danger("moderate") || encounter.Danger("moderate") >= encounter.Danger("very_hard") {
+		t.Error("danger should rise with travel difficulty")
+	}
+	if encounter.Danger("") != 1 || encounter.Danger("unknown") != 1 {
+		t.Error("a missing or unknown rating should leave the chance unchanged")
+	}
+}
+
+func TestRollWithDangerScalesChance(t *testing.T) {
+	count := func(danger float64) int {
+		rng := rand.New(rand.NewSource(7))
+		fired := 0
+		for i := 0; i < 2000; i++ {
+			if _, ok := encounter.RollWithDanger(pool(), 1, 10, danger, rng, ""); ok {
+				fired++
+			}
+		}
+		return fired
+	}
+	if safe, risky := count(encounter.Danger("easy")), count(encounter.Danger("very_hard")); safe >= risky {
+		t.Errorf("a dangerous environment should fire more often: easy %d, very_hard %d", safe, risky)
+	}
+	if n := count(0); n != 0 {
+		t.Errorf("zero danger should never fire, fired %d times", n)
+	}
+}
+
+func TestRollWithDangerPicksByWeight(t *testing.T) {
+	table := []encounter.Candidate{
+		{ID: "wolf", CR: 0.25, Weight: 9},
+		{ID: "giant-rat", CR: 0.125, Weight: 1},
+	}
+	rng := rand.New(rand.NewSource(3))
+	picks := map[string]int{}
+	for i := 0; i < 5000; i++ {
+		if m, ok := encounter.RollWithDanger(table, 1, 1000, 2, rng, ""); ok {
+			picks[m.ID]++
+		}
+	}
+	if picks["giant-rat"] == 0 || picks["wolf"] < 5*picks["giant-rat"] {
+		t.Errorf("weight 9 vs 1 should favour the wolf heavily, got %v", picks)
+	}
+}
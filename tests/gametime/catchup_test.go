@@ -0,0 +1,59 @@
+package gametime_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/server/db"
+	"pubkey-quest/cmd/server/game/gametime"
+	"pubkey-quest/cmd/server/game/status"
+	"pubkey-quest/tests/helpers"
+	"pubkey-quest/types"
+)
+
+// A long sync runs in tick-interval steps, the last one taking the remainder,
+// and never simulates past the cap.
+func TestAdvanceTimeInTicks(t *testing.T) {
+	state := &types.SaveFile{TimeOfDay: 600, CurrentDay: 1}
+	_, ticks, advanced := gametime.AdvanceTimeInTicks(state, 100, 15, 1440, false)
+	if ticks != 7 || advanced != 100 || state.TimeOfDay != 700 {
+		t.Errorf("100 minutes at 15: %d ticks, %d advanced, clock %d; want 7, 100, 700", ticks, advanced, state.TimeOfDay)
+	}
+
+	state = &types.SaveFile{TimeOfDay: 600, CurrentDay: 1}
+	_, ticks, advanced = gametime.AdvanceTimeInTicks(state, 5000, 15, 1440, false)
+	if ticks != 96 || advanced != 1440 || state.CurrentDay != 2 || state.TimeOfDay != 600 {
+		t.Errorf("capped sync: %d ticks, %d advanced, day %d at %d; want 96, 1440, day 2 at 600", ticks, advanced, state.CurrentDay, state.TimeOfDay)
+	}
+
+	if _, ticks, _ := gametime.AdvanceTimeInTicks(state, 0, 15, 1440, false); ticks != 0 {
+		t.Errorf("no time, %d ticks", ticks)
+	}
+}
+
+// Stepping lets hunger run down and starvation start within the same sync; a
+// single jump only finds the player starving at its end.
+func TestCatchUpFiresStarvation(t *testing.T) {
+	helpers.SetupTestEnvironment(t)
+	if err := db.InitDatabase(); err != nil {
+		t.Fatalf("init database: %v", err)
+	}
+	newState := func() *types.SaveFile {
+		state := &types.SaveFile{HP: 50, MaxHP: 50, Hunger: 1, TimeOfDay: 600, CurrentDay: 1}
+		if err := status.EnsureHungerAccumulation(state); err != nil {
+			t.Fatalf("seed hunger accumulation: %v", err)
+		}
+		return state
+	}
+
+	jumped := newState()
+	gametime.AdvanceTime(jumped, 1440, false)
+	stepped := newState()
+	gametime.AdvanceTimeInTicks(stepped, 1440, 15, 1440, false)
+
+	if stepped.Hunger != 0 {
+		t.Fatalf("a day without food should leave the player famished, hunger %d", stepped.Hunger)
+	}
+	if stepped.HP >= jumped.HP {
+		t.Errorf("stepped catch-up should apply the starvation it ran into: HP %d stepped vs %d jumped", stepped.HP, jumped.HP)
+	}
+}
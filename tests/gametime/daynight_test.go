@@ -0,0 +1,57 @@
+package gametime_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/server/game/gametime"
+	"pubkey-quest/cmd/server/game/npc"
+	"pubkey-quest/types"
+)
+
+// Night runs 23:00 to 05:00, wrapping midnight.
+func TestIsNight(t *testing.T) {
+	cases := []struct {
+		timeOfDay int
+		want      bool
+	}{
+		{0, true},
+		{4*60 + 59, true},
+		{5 * 60, false},
+		{12 * 60, false},
+		{22*60 + 59, false},
+		{23 * 60, true},
+		{1439, true},
+	}
+	for _, c := range cases {
+		if got := gametime.IsNight(c.timeOfDay); got != c.want {
+			t.Errorf("IsNight(%d) = %v, want %v", c.timeOfDay, got, c.want)
+		}
+	}
+}
+
+// A day-only NPC leaves at night, whatever the schedule says; everyone else
+// follows their schedule.
+func TestDayOnlyNPCGoneAtNight(t *testing.T) {
+	mayor := &types.NPCData{
+		ID:      "mayor",
+		DayOnly: true,
+		Schedule: []types.NPCScheduleSlot{{
+			Start: 0, End: 1440, Location: "merchants_guild", State: "working",
+			DialogueOptions: []string{"main_menu"},
+		}},
+	}
+
+	day := npc.ResolveNPCSchedule(mayor, 12*60)
+	if !day.IsAvailable || day.Location != "merchants_guild" {
+		t.Errorf("at noon: available=%v location=%q, want at the guild", day.IsAvailable, day.Location)
+	}
+	night := npc.ResolveNPCSchedule(mayor, 23*60+30)
+	if night.IsAvailable || night.Location != "" {
+		t.Errorf("at 23:30: available=%v location=%q, want gone", night.IsAvailable, night.Location)
+	}
+
+	mayor.DayOnly = false
+	if info := npc.ResolveNPCSchedule(mayor, 23*60+30); !info.IsAvailable || info.Location != "merchants_guild" {
+		t.Errorf("without day_only at 23:30: available=%v location=%q, want the schedule", info.IsAvailable, info.Location)
+	}
+}
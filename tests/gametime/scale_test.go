@@ -0,0 +1,68 @@
+package gametime_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/server/game/gametime"
+)
+
+func TestTimeScaleFor(t *testing.T) {
+	cases := []struct {
+		dayLength float64
+		want      float64
+	}{
+		{10, 144},
+		{0, 144}, // unset → default
+		{-5, 144},
+		{1440, 1},
+		{20, 72},
+	}
+	for _, c := range cases {
+		if got := gametime.TimeScaleFor(c.dayLength); got != c.want {
+			t.Errorf("TimeScaleFor(%v) = %v, want %v", c.dayLength, got, c.want)
+		}
+	}
+}
+
+// A tick may only move time as far as the real time since the previous tick
+// is worth at the scale; a client running its clock fast is held back, and a
+// late tick catches up on what it was owed.
+func TestTickClockPacesToScale(t *testing.T) {
+	var clock gametime.TickClock
+	const scale = 144 // 1 game minute per 416.7ms
+	now := int64(1_000_000)
+
+	if got := clock.Allow(5, now, scale); got != 5 {
+		t.Fatalf("first tick: allowed %d, want the full 5 (nothing to measure against)", got)
+	}
+
+	// On pace: 1 minute claimed per ~417ms, with timer jitter either side.
+	for _, interval := range []int64{417, 405, 430, 416} {
+		now += interval
+		if got := clock.Allow(1, now, scale); got != 1 {
+			t.Errorf("on-pace tick after %dms: allowed %d, want 1", interval, got)
+		}
+	}
+
+	// Running double speed: 2 claimed per 417ms — only what's been earned passes.
+	total := 0
+	for i := 0; i < 10; i++ {
+		now += 417
+		total += clock.Allow(2, now, scale)
+	}
+	if total < 9 || total > 11 {
+		t.Errorf("double-speed client over 10 ticks: %d minutes passed, want ~10", total)
+	}
+
+	// A late tick (3s) catches up: ~7 minutes earned.
+	now += 3000
+	if got := clock.Allow(7, now, scale); got != 7 {
+		t.Errorf("late tick: allowed %d, want 7", got)
+	}
+
+	// A long pause doesn't bank more than the cap.
+	now += 60 * 60 * 1000
+	if got := clock.Allow(500, now, scale); got > 30 {
+		t.Errorf("after an hour paused: allowed %d, want at most the 30-minute bank", got)
+	}
+}
@@ -0,0 +1,81 @@
+package inventory_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/server/game/inventory"
+)
+
+// totals sums the quantity of every item across general slots and backpack.
+func totals(slotLists ...[]interface{}) map[string]int {
+	out := map[string]int{}
+	for _, slots := range slotLists {
+		for i := range slots {
+			if id := slotItem(slots, i); id != "" {
+				out[id] += slotQty(slots, i)
+			}
+		}
+	}
+	return out
+}
+
+// A fragmented inventory (arrows split three ways, candles across general and
+// backpack) is merged into the earliest stacks up to max stack, freeing the
+// drained slots without losing or creating anything. Unstackables and
+// containers are left where they are.
+func TestConsolidateStacksMergesFragments(t *testing.T) {
+	setup(t)
+	s := newSave(4, 20)
+	general(s)[0] = slot(0, "arrows", 10)
+	general(s)[1] = slot(1, "dagger", 1)
+	general(s)[2] = slot(2, "arrows", 8)
+	general(s)[3] = slot(3, "candle", 4)
+	backpack(s)[0] = slot(0, "arrows", 12) // arrows stack to 25
+	backpack(s)[1] = slot(1, "dagger", 1)
+	backpack(s)[2] = slot(2, "candle", 3) // candles stack to 10
+	backpack(s)[3] = map[string]interface{}{
+		"item": "quiver", "quantity": float64(1), "slot": float64(3),
+		"contents": []interface{}{slot(0, "arrows", 5)},
+	}
+
+	before := totals(general(s), backpack(s))
+	freed := inventory.ConsolidateStacks(s)
+	after := totals(general(s), backpack(s))
+
+	for id, qty := range before {
+		if after[id] != qty {
+			t.Errorf("%s: %d before, %d after — consolidation must be loss-free", id, qty, after[id])
+		}
+	}
+	if freed != 2 {
+		t.Errorf("freed %d slots, want 2 (general[2] arrows, backpack[2] candles)", freed)
+	}
+
+	// 30 arrows: 25 in general[0], the remaining 5 in the next arrow slot.
+	if got := slotQty(general(s), 0); got != 25 {
+		t.Errorf("general[0] arrows = %d, want 25", got)
+	}
+	if got := slotItem(general(s), 2); got != "" {
+		t.Errorf("general[2] = %q, want empty", got)
+	}
+	if got := slotQty(backpack(s), 0); got != 5 {
+		t.Errorf("backpack[0] arrows = %d, want 5", got)
+	}
+	if got := slotQty(general(s), 3); got != 7 {
+		t.Errorf("general[3] candles = %d, want 7", got)
+	}
+	if got := slotItem(backpack(s), 2); got != "" {
+		t.Errorf("backpack[2] = %q, want empty", got)
+	}
+	if slotItem(general(s), 1) != "dagger" || slotItem(backpack(s), 1) != "dagger" {
+		t.Error("unstackable daggers should stay in their own slots")
+	}
+	quiver := backpack(s)[3].(map[string]interface{})
+	if contents, _ := quiver["contents"].([]interface{}); slotQty(contents, 0) != 5 {
+		t.Error("a container's contents must not be touched")
+	}
+
+	if again := inventory.ConsolidateStacks(s); again != 0 {
+		t.Errorf("a consolidated inventory should be stable, freed %d more", again)
+	}
+}
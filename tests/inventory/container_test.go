@@ -1,6 +1,8 @@
 package inventory_test
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"pubkey-quest/cmd/server/game/inventory"
@@ -27,12 +29,18 @@ func pouchContents(s *types.SaveFile, generalIndex int) []interface{} {
 	return c
 }
 
-func addToContainer(s *types.SaveFile, itemID string, fromSlot, containerSlot int) error {
-	_, err := inventory.HandleAddToContainerAction(s, p(map[string]interface{}{
+// addToContainer moves a general-slot item into a container in the general
+// slots. A rule the container refuses comes back as a failure response, which
+// is returned as an error along with the response color.
+func addToContainer(s *types.SaveFile, itemID string, fromSlot, containerSlot int) (string, error) {
+	resp, err := inventory.HandleAddToContainerAction(s, p(map[string]interface{}{
 		"item_id": itemID, "from_slot": float64(fromSlot), "from_slot_type": "general",
 		"container_slot": float64(containerSlot), "container_slot_type": "general",
 	}))
-	return err
+	if err == nil && !resp.Success {
+		return resp.Color, errors.New(resp.Error)
+	}
+	return "", err
 }
 
 func TestAddSpellComponentToPouchThenRemove(t *testing.T) {
@@ -41,7 +49,7 @@ func TestAddSpellComponentToPouchThenRemove(t *testing.T) {
 	general(s)[0] = slot(0, "arcane-powder", 1)
 	general(s)[1] = pouchSlot(1, 0)
 
-	if err := addToContainer(s, "arcane-powder", 0, 1); err != nil {
+	if _, err := addToContainer(s, "arcane-powder", 0, 1); err != nil {
 		t.Fatalf("add to container: %v", err)
 	}
 	if got := slotItem(general(s), 0); got != "" {
@@ -147,8 +155,9 @@ func TestPouchRejectsNestedContainer(t *testing.T) {
 	general(s)[0] = pouchSlot(0, 0)
 	general(s)[1] = pouchSlot(1, 0)
 
-	if err := addToContainer(s, "component-pouch", 0, 1); err == nil {
-		t.Error("expected nesting a container inside a container to be rejected")
+	color, err := addToContainer(s, "component-pouch", 0, 1)
+	if err == nil || color != "red" {
+		t.Errorf("expected nesting a container inside a container to be rejected in red, got %v (%q)", err, color)
 	}
 }
 
@@ -159,8 +168,12 @@ func TestPouchFullRejectsAdd(t *testing.T) {
 	general(s)[0] = slot(0, "arcane-powder", 1)
 	general(s)[1] = pouchSlot(1, 4) // all 4 slots used
 
-	if err := addToContainer(s, "arcane-powder", 0, 1); err == nil {
-		t.Error("expected a full container to reject the add")
+	color, err := addToContainer(s, "arcane-powder", 0, 1)
+	if err == nil || color != "red" || !strings.Contains(err.Error(), "is full (4/4 slots)") {
+		t.Errorf("expected a full container to reject the add in red, got %v (%q)", err, color)
+	}
+	if slotItem(general(s), 0) != "arcane-powder" {
+		t.Error("a refused add must leave the item where it was")
 	}
 }
 
@@ -176,7 +189,8 @@ func TestPouchRejectsDisallowedType(t *testing.T) {
 	general(s)[0] = slot(0, "longsword", 1)
 	general(s)[1] = pouchSlot(1, 0)
 
-	if err := addToContainer(s, "longsword", 0, 1); err == nil {
-		t.Error("expected a non-component item to be rejected by the component pouch")
+	color, err := addToContainer(s, "longsword", 0, 1)
+	if err == nil || color != "red" || !strings.Contains(err.Error(), "only holds Spell Component") {
+		t.Errorf("expected a non-component item to be rejected by the component pouch in red, got %v (%q)", err, color)
 	}
 }
@@ -1,6 +1,7 @@
 package inventory_test
 
 import (
+	"errors"
 	"testing"
 
 	"pubkey-quest/cmd/server/game/inventory"
@@ -77,9 +78,9 @@ func TestEquipFromBackpack(t *testing.T) {
 	}
 }
 
-// A two-handed weapon must occupy both hands and displace whatever was in them
-// back into inventory.
-func TestEquipTwoHandedClearsBothHands(t *testing.T) {
+// A two-handed weapon occupies both hands, swapping the mainhand weapon back
+// into inventory — but it can't be wielded while the offhand holds something.
+func TestEquipTwoHandedNeedsFreeOffhand(t *testing.T) {
 	setup(t)
 	s := newSave(4, 20)
 	gs := gearSlots(s)
@@ -87,6 +88,22 @@ func TestEquipTwoHandedClearsBothHands(t *testing.T) {
 	gs["offhand"] = map[string]interface{}{"item": "shield", "quantity": float64(1)}
 	general(s)[0] = slot(0, "greatsword", 1)
 
+	_, err := inventory.HandleEquipItemAction(s, p(map[string]interface{}{
+		"item_id": "greatsword", "from_slot": float64(0), "from_slot_type": "general",
+	}))
+	if err == nil {
+		t.Fatal("a two-handed weapon should be refused while the offhand holds a shield")
+	}
+	var full *inventory.SlotsFullError
+	if !errors.As(err, &full) || full.Occupied["offhand"] != "shield" {
+		t.Errorf("refusal = %v, want a SlotsFullError naming the shield in the offhand", err)
+	}
+	if gearItem(s, "mainhand") != "dagger" || gearItem(s, "offhand") != "shield" || slotItem(general(s), 0) != "greatsword" {
+		t.Errorf("a refused equip must change nothing: mainhand %q, offhand %q, general[0] %q",
+			gearItem(s, "mainhand"), gearItem(s, "offhand"), slotItem(general(s), 0))
+	}
+
+	gs["offhand"] = map[string]interface{}{"item": nil, "quantity": float64(0)}
 	equip(t, s, "greatsword", 0, "general")
 
 	if got := gearItem(s, "mainhand"); got != "greatsword" {
@@ -95,15 +112,24 @@ func TestEquipTwoHandedClearsBothHands(t *testing.T) {
 	if got := gearItem(s, "offhand"); got != "greatsword" {
 		t.Errorf("offhand = %q, want greatsword (two-handed occupies both)", got)
 	}
-	// The displaced dagger + shield must both be back in general slots.
-	found := map[string]bool{}
+	if got := slotItem(general(s), 0); got != "dagger" {
+		t.Errorf("general[0] = %q, want the displaced dagger", got)
+	}
+
+	// Swapping one two-handed weapon for another returns the old one once.
+	general(s)[1] = slot(1, "greataxe", 1)
+	equip(t, s, "greataxe", 1, "general")
+	if gearItem(s, "mainhand") != "greataxe" || gearItem(s, "offhand") != "greataxe" {
+		t.Errorf("hands = %q/%q, want greataxe in both", gearItem(s, "mainhand"), gearItem(s, "offhand"))
+	}
+	greatswords := 0
 	for i := range general(s) {
-		if id := slotItem(general(s), i); id != "" {
-			found[id] = true
+		if slotItem(general(s), i) == "greatsword" {
+			greatswords++
 		}
 	}
-	if !found["dagger"] || !found["shield"] {
-		t.Errorf("displaced items not returned to inventory: found %v", found)
+	if greatswords != 1 {
+		t.Errorf("the swapped-out greatsword should be back in inventory once, found %d", greatswords)
 	}
 }
 
@@ -174,3 +200,142 @@ func TestUnequipReturnsToInventory(t *testing.T) {
 		t.Errorf("backpack[0] = %q, want longsword", got)
 	}
 }
+
+// An explicit equipment_slot must be one the item's gear_slot allows: armor
+// can't go in a hand, a weapon can't be worn as armor, and items with no
+// gear_slot can't be equipped at all. A rejected equip leaves the save alone.
+func TestEquipRejectsMismatchedSlot(t *testing.T) {
+	setup(t)
+	cases := []struct{ item, slot string }{
+		{"breastplate", "mainhand"},
+		{"dagger", "chest"},
+		{"shield", "head"},
+		{"bedroll", "offhand"},
+	}
+	for _, c := range cases {
+		s := newSave(4, 20)
+		general(s)[0] = slot(0, c.item, 1)
+
+		_, err := inventory.HandleEquipItemAction(s, p(map[string]interface{}{
+			"item_id": c.item, "from_slot": float64(0), "from_slot_type": "general", "equipment_slot": c.slot,
+		}))
+		if err == nil {
+			t.Errorf("equipping %s into %s should be rejected", c.item, c.slot)
+			continue
+		}
+		if got := slotItem(general(s), 0); got != c.item {
+			t.Errorf("%s should stay in general[0] after a rejected equip, got %q", c.item, got)
+		}
+		if got := gearItem(s, c.slot); got != "" {
+			t.Errorf("%s slot = %q after a rejected equip", c.slot, got)
+		}
+	}
+
+	// A permitted explicit slot still works: a one-handed weapon in the offhand.
+	s := newSave(4, 20)
+	general(s)[0] = slot(0, "dagger", 1)
+	if _, err := inventory.HandleEquipItemAction(s, p(map[string]interface{}{
+		"item_id": "dagger", "from_slot": float64(0), "from_slot_type": "general", "equipment_slot": "offhand",
+	})); err != nil {
+		t.Fatalf("dagger into offhand: %v", err)
+	}
+	if got := gearItem(s, "offhand"); got != "dagger" {
+		t.Errorf("offhand = %q, want dagger", got)
+	}
+}
+
+// Rings fill ring1 then ring2; with both worn, auto-placement refuses and says
+// what's on each finger instead of pulling one off.
+func TestFreeEquipSlotForRings(t *testing.T) {
+	s := newSave(4, 20)
+	gs := gearSlots(s)
+	gs["ring1"] = emptyGear()
+	gs["ring2"] = emptyGear()
+
+	if got, err := inventory.FreeEquipSlot(s.Inventory, "ring"); err != nil || got != "ring1" {
+		t.Errorf("no rings worn: got %q, %v; want ring1", got, err)
+	}
+	gs["ring1"] = map[string]interface{}{"item": "ring-of-protection", "quantity": float64(1)}
+	if got, err := inventory.FreeEquipSlot(s.Inventory, "ring"); err != nil || got != "ring2" {
+		t.Errorf("one ring worn: got %q, %v; want ring2", got, err)
+	}
+	gs["ring2"] = map[string]interface{}{"item": "signet-ring", "quantity": float64(1)}
+	_, err := inventory.FreeEquipSlot(s.Inventory, "ring")
+	var full *inventory.SlotsFullError
+	if !errors.As(err, &full) {
+		t.Fatalf("both rings worn: err = %v, want a SlotsFullError", err)
+	}
+	if full.Occupied["ring1"] != "ring-of-protection" || full.Occupied["ring2"] != "signet-ring" {
+		t.Errorf("occupied = %v, want both rings", full.Occupied)
+	}
+
+	if layout := inventory.GearSlotLayout(); len(layout["ring"]) != 2 || len(layout["head"]) != 1 {
+		t.Errorf("layout = %v, want two ring slots and one head slot", layout)
+	}
+}
+
+// Equipping over a worn item swaps them: the old one takes the new one's slot,
+// container contents and all. A swap that would put a container in the
+// backpack is refused outright, leaving everything where it was.
+func TestEquipSwapsIntoSourceSlot(t *testing.T) {
+	setup(t)
+	arrows := func() []interface{} {
+		return []interface{}{map[string]interface{}{"item": "arrows", "quantity": float64(12), "slot": float64(0)}}
+	}
+
+	s := newSave(4, 20)
+	gearSlots(s)["ammo"] = map[string]interface{}{"item": "quiver", "quantity": float64(1), "contents": arrows()}
+	backpack(s)[2] = slot(2, "quiver", 1)
+	resp, err := inventory.HandleEquipItemAction(s, p(map[string]interface{}{
+		"item_id": "quiver", "from_slot": float64(2), "from_slot_type": "inventory",
+	}))
+	if err != nil || resp == nil || resp.Success {
+		t.Fatalf("swapping a quiver into the backpack should be refused: resp=%+v err=%v", resp, err)
+	}
+	if slotItem(backpack(s), 2) != "quiver" || gearItem(s, "ammo") != "quiver" {
+		t.Error("a refused swap must leave both quivers in place")
+	}
+
+	s = newSave(4, 20)
+	gearSlots(s)["ammo"] = map[string]interface{}{"item": "quiver", "quantity": float64(1), "contents": arrows()}
+	general(s)[1] = slot(1, "quiver", 1)
+	equip(t, s, "quiver", 1, "general")
+	displaced, _ := general(s)[1].(map[string]interface{})
+	if displaced["item"] != "quiver" {
+		t.Fatalf("general[1] = %v, want the displaced quiver", displaced["item"])
+	}
+	if contents, _ := displaced["contents"].([]interface{}); len(contents) != 1 {
+		t.Errorf("the displaced quiver lost its arrows: %+v", displaced)
+	}
+}
+
+// Unequipping onto a chosen empty slot puts the item there; a container asked
+// into the backpack still goes to a general slot.
+func TestUnequipToRequestedSlot(t *testing.T) {
+	setup(t)
+	s := newSave(4, 20)
+	gearSlots(s)["mainhand"] = map[string]interface{}{"item": "longsword", "quantity": float64(1)}
+
+	resp, err := inventory.HandleUnequipItemAction(s, p(map[string]interface{}{
+		"equipment_slot": "mainhand", "to_slot": float64(3), "to_slot_type": "general",
+	}))
+	if err != nil || resp == nil || !resp.Success {
+		t.Fatalf("unequip: resp=%+v err=%v", resp, err)
+	}
+	if got := slotItem(general(s), 3); got != "longsword" {
+		t.Errorf("general[3] = %q, want longsword", got)
+	}
+
+	gearSlots(s)["ammo"] = map[string]interface{}{"item": "quiver", "quantity": float64(1), "contents": []interface{}{}}
+	if _, err := inventory.HandleUnequipItemAction(s, p(map[string]interface{}{
+		"equipment_slot": "ammo", "to_slot": float64(5), "to_slot_type": "inventory",
+	})); err != nil {
+		t.Fatalf("unequip quiver: %v", err)
+	}
+	if got := slotItem(backpack(s), 5); got != "" {
+		t.Errorf("backpack[5] = %q, a container can't go in the backpack", got)
+	}
+	if got := slotItem(general(s), 0); got != "quiver" {
+		t.Errorf("general[0] = %q, want the quiver in the first open general slot", got)
+	}
+}
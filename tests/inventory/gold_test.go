@@ -0,0 +1,37 @@
+package inventory_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/server/game/gameutil"
+)
+
+// Spending is all-or-nothing, so gold never goes below zero; adding stacks
+// onto the purse and ignores non-positive amounts.
+func TestSpendAndAddGold(t *testing.T) {
+	setup(t)
+	s := newSave(4, 20)
+	general(s)[0] = slot(0, "gold-piece", 30)
+	backpack(s)[0] = slot(0, "gold-piece", 20)
+
+	if gameutil.SpendGold(s, 60) {
+		t.Error("spending more than the purse holds should fail")
+	}
+	if got := gameutil.GetGoldQuantity(s); got != 50 {
+		t.Errorf("a failed spend took gold: %d left, want 50", got)
+	}
+
+	if !gameutil.SpendGold(s, 40) {
+		t.Fatal("spending 40 of 50 should succeed")
+	}
+	if got := gameutil.GetGoldQuantity(s); got != 10 {
+		t.Errorf("gold after spending 40 = %d, want 10", got)
+	}
+
+	if !gameutil.AddGold(s, 15) || !gameutil.AddGold(s, -5) {
+		t.Fatal("adding gold should succeed")
+	}
+	if got := gameutil.GetGoldQuantity(s); got != 25 {
+		t.Errorf("gold after adding 15 (and -5) = %d, want 25", got)
+	}
+}
@@ -0,0 +1,82 @@
+package inventory_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"pubkey-quest/cmd/server/game/inventory"
+)
+
+func TestOpenPackGrantsContents(t *testing.T) {
+	setup(t)
+	s := newSave(4, 20)
+	general(s)[1] = slot(1, "leather-set", 1)
+
+	resp, grants, err := inventory.HandleOpenPackAction(s, p(map[string]interface{}{
+		"item_id": "leather-set", "from_slot": float64(1), "from_slot_type": "general",
+	}))
+	if err != nil || resp == nil || !resp.Success {
+		t.Fatalf("open pack: resp=%+v err=%v", resp, err)
+	}
+	if got := slotItem(general(s), 1); got == "leather-set" {
+		t.Error("the pack should be consumed")
+	}
+	if len(grants) != 2 {
+		t.Fatalf("grants = %+v, want vest + leggings", grants)
+	}
+	for _, g := range grants {
+		if g.Quantity != 1 || g.Overflow != 0 {
+			t.Errorf("grant %+v, want 1 added and no overflow", g)
+		}
+	}
+}
+
+func TestOpenPackReportsOverflow(t *testing.T) {
+	setup(t)
+	// No bag and every general slot taken: the pack's own slot frees up for
+	// the first item, the second has nowhere to go.
+	s := newSave(4, 0)
+	gearSlots(s)["bag"] = emptyGear()
+	general(s)[0] = slot(0, "leather-set", 1)
+	for i := 1; i < 4; i++ {
+		general(s)[i] = slot(i, "longsword", 1)
+	}
+
+	resp, grants, err := inventory.HandleOpenPackAction(s, p(map[string]interface{}{"item_id": "leather-set"}))
+	if err != nil || resp == nil || !resp.Success {
+		t.Fatalf("open pack: resp=%+v err=%v", resp, err)
+	}
+	overflow := 0
+	for _, g := range grants {
+		overflow += g.Overflow
+	}
+	if overflow != 1 {
+		t.Errorf("overflow = %d, want 1; grants %+v", overflow, grants)
+	}
+
+	// The message lists what was added and names what didn't fit.
+	for _, g := range grants {
+		added := fmt.Sprintf("1x %s", g.Item)
+		inMessage := strings.Contains(resp.Message, added)
+		before, _, _ := strings.Cut(resp.Message, "no room for")
+		if g.Quantity > 0 && !strings.Contains(before, added) {
+			t.Errorf("message %q should list %s as received", resp.Message, added)
+		}
+		if g.Overflow > 0 && (!inMessage || strings.Contains(before, added)) {
+			t.Errorf("message %q should list %s only as not fitting", resp.Message, added)
+		}
+	}
+	if resp.Color != "yellow" {
+		t.Errorf("color = %q, want yellow for a partial open", resp.Color)
+	}
+}
+
+func TestOpenPackRejectsNonPack(t *testing.T) {
+	setup(t)
+	s := newSave(4, 20)
+	general(s)[0] = slot(0, "longsword", 1)
+	if _, _, err := inventory.HandleOpenPackAction(s, p(map[string]interface{}{"item_id": "longsword"})); err == nil {
+		t.Error("opening a non-pack item should fail")
+	}
+}
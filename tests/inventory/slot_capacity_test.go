@@ -0,0 +1,88 @@
+package inventory_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/server/game/inventory"
+)
+
+func TestSlotCountsComeFromDataAndBag(t *testing.T) {
+	setup(t)
+	if got := inventory.GeneralSlotCount(); got != 4 {
+		t.Errorf("GeneralSlotCount() = %d, want 4 (systems/inventory.json)", got)
+	}
+
+	s := newSave(4, 2)
+	if got := inventory.BackpackSlotCount(s.Inventory); got != 20 {
+		t.Errorf("backpack slots = %d, want 20 (backpack container_slots)", got)
+	}
+	gearSlots(s)["bag"] = emptyGear()
+	if got := inventory.BackpackSlotCount(s.Inventory); got != 0 {
+		t.Errorf("no bag: backpack slots = %d, want 0", got)
+	}
+}
+
+func TestMoveIntoBackpackRespectsBagCapacity(t *testing.T) {
+	setup(t)
+	// Backpack contents only hold 2 entries so far, but the bag has 20 slots.
+	s := newSave(4, 2)
+	general(s)[0] = slot(0, "longsword", 1)
+
+	resp, err := inventory.HandleMoveItemAction(s, p(map[string]interface{}{
+		"item_id": "longsword", "from_slot": float64(0), "to_slot": float64(15),
+		"from_slot_type": "general", "to_slot_type": "inventory",
+	}))
+	if err != nil || resp == nil || !resp.Success {
+		t.Fatalf("move into slot 15: resp=%+v err=%v", resp, err)
+	}
+	if got := slotItem(backpack(s), 15); got != "longsword" {
+		t.Errorf("backpack[15] = %q, want longsword", got)
+	}
+
+	// Slot 20 is past the backpack's 20 slots.
+	if _, err := inventory.HandleMoveItemAction(s, p(map[string]interface{}{
+		"item_id": "longsword", "from_slot": float64(15), "to_slot": float64(20),
+		"from_slot_type": "inventory", "to_slot_type": "inventory",
+	})); err == nil {
+		t.Error("moving past the bag's capacity should fail")
+	}
+}
+
+func TestAddItemFillsBagUpToCapacity(t *testing.T) {
+	setup(t)
+	// General slots full, backpack array shorter than the bag's 20 slots.
+	s := newSave(4, 0)
+	for i := 0; i < 4; i++ {
+		general(s)[i] = slot(i, "longsword", 1)
+	}
+
+	added, err := inventory.AddItemToInventory(s, "dagger", 25)
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if added != 20 {
+		t.Errorf("added %d daggers, want 20 (one per backpack slot)", added)
+	}
+	if n := len(backpack(s)); n != 20 {
+		t.Errorf("backpack has %d slots, want 20", n)
+	}
+}
+
+// Slots past the cap (a save with more general slots than the configured
+// count) can't take more items, not even onto a stack already sitting there.
+func TestAddItemIgnoresSlotsPastCapacity(t *testing.T) {
+	setup(t)
+	s := newSave(6, 0)
+	gearSlots(s)["bag"] = emptyGear()
+	for i := 0; i < 4; i++ {
+		general(s)[i] = slot(i, "longsword", 1)
+	}
+	general(s)[5] = slot(5, "arrows", 1)
+
+	if added, err := inventory.AddItemToInventory(s, "arrows", 5); err == nil || added != 0 {
+		t.Errorf("add = %d, %v; want nothing added with every slot in the cap taken", added, err)
+	}
+	if qty := general(s)[5].(map[string]interface{})["quantity"]; qty != float64(1) {
+		t.Errorf("general[5] quantity = %v, want the stack past the cap left at 1", qty)
+	}
+}
@@ -0,0 +1,66 @@
+package inventory_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/server/game/inventory"
+)
+
+// Sorting merges stacks, packs each list to the front without moving items
+// between the general slots and the backpack, and leaves equipped gear alone.
+func TestSortInventoryCompacts(t *testing.T) {
+	setup(t)
+	s := newSave(4, 20)
+	general(s)[1] = slot(1, "pouch", 1)
+	general(s)[3] = slot(3, "arrows", 10)
+	backpack(s)[1] = slot(1, "dagger", 1)
+	backpack(s)[2] = slot(2, "arrows", 8)
+	backpack(s)[3] = slot(3, "rations", 2)
+	backpack(s)[5] = slot(5, "candle", 3)
+	gearSlots(s)["mainhand"] = map[string]interface{}{"item": "shortsword", "quantity": float64(1)}
+
+	resp, err := inventory.HandleSortInventoryAction(s, map[string]interface{}{})
+	if err != nil || !resp.Success {
+		t.Fatalf("sort_inventory: %v %+v", err, resp)
+	}
+
+	wantGeneral := []string{"pouch", "arrows", "", ""}
+	for i, want := range wantGeneral {
+		if got := slotItem(general(s), i); got != want {
+			t.Errorf("general[%d] = %q, want %q", i, got, want)
+		}
+	}
+	if got := slotQty(general(s), 1); got != 18 {
+		t.Errorf("arrows should merge into one stack of 18, got %d", got)
+	}
+
+	// The pouch stays out of the backpack; the backpack keeps its order.
+	wantBackpack := []string{"dagger", "rations", "candle", ""}
+	for i, want := range wantBackpack {
+		if got := slotItem(backpack(s), i); got != want {
+			t.Errorf("backpack[%d] = %q, want %q", i, got, want)
+		}
+	}
+	for i, entry := range backpack(s) {
+		if n := entry.(map[string]interface{})["slot"]; n != i {
+			t.Errorf("backpack[%d] numbered %v", i, n)
+		}
+	}
+	if gearItem(s, "mainhand") != "shortsword" {
+		t.Error("equipped gear must be left untouched")
+	}
+
+	// With sort, each list is ordered by item type then name.
+	if _, err := inventory.HandleSortInventoryAction(s, map[string]interface{}{"sort": true}); err != nil {
+		t.Fatalf("sort_inventory with sort: %v", err)
+	}
+	wantSorted := []string{"candle", "rations", "dagger"} // Adventuring Gear, Food, Simple Melee Weapons
+	for i, want := range wantSorted {
+		if got := slotItem(backpack(s), i); got != want {
+			t.Errorf("sorted backpack[%d] = %q, want %q", i, got, want)
+		}
+	}
+	if slotItem(general(s), 0) != "pouch" || slotItem(general(s), 1) != "arrows" {
+		t.Errorf("sorted general = %q, %q; want pouch (Adventuring Gear) before arrows (Ammunition)", slotItem(general(s), 0), slotItem(general(s), 1))
+	}
+}
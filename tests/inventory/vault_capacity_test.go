@@ -0,0 +1,120 @@
+package inventory_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/server/game/inventory"
+	"pubkey-quest/cmd/server/game/vault"
+)
+
+// A new vault is sized for its building's type; unlisted types get the default.
+func TestRegisterVaultSizedByBuildingType(t *testing.T) {
+	setup(t)
+	s := newSave(4, 20)
+	s.Location = "kingdom"
+
+	vault.RegisterVault(s, "vault_of_crowns")
+	if got, want := len(vaultSlots(s, "vault_of_crowns")), vault.VaultSlotsByBuildingType["vault"]; got != want {
+		t.Errorf("vault_of_crowns has %d slots, want %d", got, want)
+	}
+
+	vault.RegisterVault(s, "stable")
+	if got := len(vaultSlots(s, "stable")); got != vault.DefaultVaultSlots {
+		t.Errorf("stable vault has %d slots, want the default %d", got, vault.DefaultVaultSlots)
+	}
+
+	s.Building = "vault_of_crowns"
+	resp, err := vault.HandleOpenVaultAction(s, nil)
+	if err != nil || resp == nil || !resp.Success {
+		t.Fatalf("open vault: resp=%+v err=%v", resp, err)
+	}
+	opened := resp.Delta["vault"].(map[string]interface{})
+	if opened["capacity"] != 50 || opened["free_slots"] != 50 {
+		t.Errorf("open_vault capacity=%v free_slots=%v, want 50/50", opened["capacity"], opened["free_slots"])
+	}
+}
+
+func TestDepositIntoFullVaultRefused(t *testing.T) {
+	setup(t)
+	s := newSave(4, 20)
+	makeVault(s, "bank", 2)
+	s.Building = "bank"
+	vaultSlots(s, "bank")[0] = slot(0, "rations", 1)
+	vaultSlots(s, "bank")[1] = slot(1, "torch", 1)
+	general(s)[0] = slot(0, "longsword", 1)
+
+	resp, err := inventory.HandleMoveItemAction(s, p(map[string]interface{}{
+		"item_id": "longsword", "from_slot": float64(0), "to_slot": float64(2),
+		"from_slot_type": "general", "to_slot_type": "vault", "vault_building": "bank",
+	}))
+	if err != nil || resp == nil || resp.Success {
+		t.Fatalf("deposit into a full vault: resp=%+v err=%v, want a refusal", resp, err)
+	}
+	if got := slotItem(general(s), 0); got != "longsword" {
+		t.Errorf("general[0] = %q, want the longsword kept", got)
+	}
+
+	// Swapping with an occupied slot doesn't take more room, so it's allowed.
+	resp, err = inventory.HandleMoveItemAction(s, p(map[string]interface{}{
+		"item_id": "longsword", "from_slot": float64(0), "to_slot": float64(1),
+		"from_slot_type": "general", "to_slot_type": "vault", "vault_building": "bank",
+	}))
+	if err != nil || resp == nil || !resp.Success {
+		t.Fatalf("swap into a full vault: resp=%+v err=%v", resp, err)
+	}
+	data := resp.Delta["vault_data"].(map[string]interface{})
+	if data["capacity"] != 2 || data["free_slots"] != 0 {
+		t.Errorf("vault_data capacity=%v free_slots=%v, want 2/0", data["capacity"], data["free_slots"])
+	}
+}
+
+// Items in one town's bank aren't reachable from another's.
+func TestVaultsIsolatedPerBuilding(t *testing.T) {
+	setup(t)
+	s := newSave(4, 20)
+	makeVault(s, "vault_of_crowns", 4)
+	makeVault(s, "stone_vault", 4)
+	general(s)[0] = slot(0, "longsword", 1)
+
+	s.Location, s.Building = "kingdom", "vault_of_crowns"
+	resp, err := inventory.HandleMoveItemAction(s, p(map[string]interface{}{
+		"item_id": "longsword", "from_slot": float64(0), "to_slot": float64(0),
+		"from_slot_type": "general", "to_slot_type": "vault", "vault_building": "vault_of_crowns",
+	}))
+	if err != nil || resp == nil || !resp.Success {
+		t.Fatalf("deposit: resp=%+v err=%v", resp, err)
+	}
+
+	s.Location, s.Building = "ironpeak", "stone_vault"
+	if got := slotItem(vault.GetVaultForLocation(s, "stone_vault")["slots"].([]interface{}), 0); got != "" {
+		t.Errorf("stone_vault[0] = %q, want the kingdom deposit not to show up here", got)
+	}
+	resp, err = inventory.HandleMoveItemAction(s, p(map[string]interface{}{
+		"item_id": "longsword", "from_slot": float64(0), "to_slot": float64(0),
+		"from_slot_type": "vault", "to_slot_type": "general", "vault_building": "vault_of_crowns",
+	}))
+	if err != nil || resp == nil || resp.Success {
+		t.Fatalf("withdraw from another town's vault: resp=%+v err=%v, want a refusal", resp, err)
+	}
+	if got := slotItem(vaultSlots(s, "vault_of_crowns"), 0); got != "longsword" {
+		t.Errorf("vault_of_crowns[0] = %q, want the longsword still there", got)
+	}
+}
+
+// A vault from an old save keyed by location belongs to the first building
+// that opens it, not to every building in town.
+func TestLegacyLocationVaultClaimedByOneBuilding(t *testing.T) {
+	s := newSave(4, 20)
+	s.Location = "kingdom"
+	s.Vaults = []map[string]interface{}{{"location": "kingdom", "slots": []interface{}{slot(0, "torch", 1)}}}
+
+	if v := vault.GetVaultForLocation(s, "vault_of_crowns"); v == nil {
+		t.Fatal("legacy vault not found from a building in its location")
+	}
+	if vault.IsVaultRegistered(s, "trading_post") {
+		t.Error("legacy vault is also visible from a second building")
+	}
+	if got := slotItem(vaultSlots(s, "vault_of_crowns"), 0); got != "torch" {
+		t.Errorf("vault_of_crowns[0] = %q, want the legacy contents", got)
+	}
+}
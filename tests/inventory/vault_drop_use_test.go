@@ -11,6 +11,7 @@ func TestVaultRoundTrip(t *testing.T) {
 	setup(t)
 	s := newSave(4, 20)
 	makeVault(s, "bank", 40)
+	s.Building = "bank"
 	general(s)[0] = slot(0, "longsword", 1)
 
 	deposit := func(fromType, toType string, from, to int) {
@@ -0,0 +1,175 @@
+package inventory_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/server/game/inventory"
+	"pubkey-quest/types"
+)
+
+// A click deposit lands in the first open vault slot, without the client
+// picking one.
+func TestVaultDepositFirstOpenSlot(t *testing.T) {
+	setup(t)
+	s := newSave(4, 20)
+	makeVault(s, "bank", 3)
+	s.Building = "bank"
+	vaultSlots(s, "bank")[0] = slot(0, "torch", 1)
+	general(s)[2] = slot(2, "longsword", 1)
+
+	resp, err := inventory.HandleVaultDepositAction(s, p(map[string]interface{}{
+		"item_id": "longsword", "from_slot": float64(2), "from_slot_type": "general",
+	}))
+	if err != nil || resp == nil || !resp.Success {
+		t.Fatalf("deposit: resp=%+v err=%v", resp, err)
+	}
+	if got := slotItem(vaultSlots(s, "bank"), 1); got != "longsword" {
+		t.Errorf("vault[1] = %q, want longsword", got)
+	}
+	if got := slotItem(general(s), 2); got != "" {
+		t.Errorf("general[2] = %q, want empty after deposit", got)
+	}
+	if data, _ := resp.Delta["vault_data"].(map[string]interface{}); data == nil || data["free_slots"] != 1 {
+		t.Errorf("vault_data = %v, want the updated vault with 1 free slot", resp.Delta["vault_data"])
+	}
+
+	// A stale click naming a different item is an error, not a wrong move.
+	general(s)[0] = slot(0, "rations", 2)
+	if _, err := inventory.HandleVaultDepositAction(s, p(map[string]interface{}{
+		"item_id": "torch", "from_slot": float64(0), "from_slot_type": "general",
+	})); err == nil {
+		t.Error("deposit naming the wrong item should fail")
+	}
+
+	vaultSlots(s, "bank")[2] = slot(2, "torch", 1)
+	resp, err = inventory.HandleVaultDepositAction(s, p(map[string]interface{}{
+		"item_id": "rations", "from_slot": float64(0), "from_slot_type": "general",
+	}))
+	if err != nil || resp == nil || resp.Success {
+		t.Fatalf("deposit into a full vault: resp=%+v err=%v, want a refusal", resp, err)
+	}
+	if resp.ErrorCode != types.ErrCodeVaultFull {
+		t.Errorf("full vault error code = %q, want %q", resp.ErrorCode, types.ErrCodeVaultFull)
+	}
+
+	s.Building = ""
+	resp, _ = inventory.HandleVaultDepositAction(s, p(map[string]interface{}{
+		"item_id": "rations", "from_slot": float64(0), "from_slot_type": "general",
+	}))
+	if resp == nil || resp.Success || resp.ErrorCode != types.ErrCodeNoVault {
+		t.Errorf("deposit outside a building: resp=%+v, want a %s refusal", resp, types.ErrCodeNoVault)
+	}
+}
+
+// A click withdraw goes to the backpack first, then general slots; containers
+// can't go in the backpack so they go straight to general.
+func TestVaultWithdrawBackpackThenGeneral(t *testing.T) {
+	setup(t)
+	s := newSave(4, 20)
+	makeVault(s, "bank", 4)
+	s.Building = "bank"
+	vaultSlots(s, "bank")[0] = slot(0, "longsword", 1)
+	vaultSlots(s, "bank")[1] = slot(1, "rations", 3)
+	vaultSlots(s, "bank")[2] = slot(2, "basket", 1)
+	backpack(s)[0] = slot(0, "torch", 1)
+
+	withdraw := func(from int, item string) {
+		t.Helper()
+		resp, err := inventory.HandleVaultWithdrawAction(s, p(map[string]interface{}{
+			"item_id": item, "from_slot": float64(from),
+		}))
+		if err != nil || resp == nil || !resp.Success {
+			t.Fatalf("withdraw %s: resp=%+v err=%v", item, resp, err)
+		}
+		if _, ok := resp.Delta["vault_data"]; !ok {
+			t.Errorf("withdraw %s: no vault_data in delta", item)
+		}
+	}
+
+	withdraw(0, "longsword")
+	if got := slotItem(backpack(s), 1); got != "longsword" {
+		t.Errorf("backpack[1] = %q, want longsword in the first open backpack slot", got)
+	}
+	if got := slotItem(vaultSlots(s, "bank"), 0); got != "" {
+		t.Errorf("vault[0] = %q, want empty after withdraw", got)
+	}
+
+	withdraw(2, "basket")
+	if got := slotItem(general(s), 0); got != "basket" {
+		t.Errorf("general[0] = %q, want the basket (containers skip the backpack)", got)
+	}
+
+	for i := range backpack(s) {
+		if slotItem(backpack(s), i) == "" {
+			backpack(s)[i] = slot(i, "torch", 1)
+		}
+	}
+	withdraw(1, "rations")
+	if got := slotItem(general(s), 1); got != "rations" || slotQty(general(s), 1) != 3 {
+		t.Errorf("general[1] = %q x%d, want 3 rations with the backpack full", got, slotQty(general(s), 1))
+	}
+}
+
+// Store-all empties the bag into the vault until it's full and reports the
+// stacks that didn't fit.
+func TestVaultStoreAllStopsWhenFull(t *testing.T) {
+	setup(t)
+	s := newSave(4, 20)
+	makeVault(s, "bank", 2)
+	s.Building = "bank"
+	backpack(s)[0] = slot(0, "torch", 1)
+	backpack(s)[3] = slot(3, "rations", 4)
+	backpack(s)[5] = slot(5, "longsword", 1)
+
+	resp, err := inventory.HandleVaultStoreAllAction(s, p(map[string]interface{}{}))
+	if err != nil || resp == nil || !resp.Success {
+		t.Fatalf("store all: resp=%+v err=%v", resp, err)
+	}
+	moved := resp.Data["moved"].([]inventory.VaultTransfer)
+	left := resp.Data["left"].([]inventory.VaultTransfer)
+	if len(moved) != 2 || moved[0].Item != "torch" || moved[1].Item != "rations" || moved[1].Quantity != 4 {
+		t.Errorf("moved = %+v, want torch and 4 rations", moved)
+	}
+	if len(left) != 1 || left[0].Item != "longsword" {
+		t.Errorf("left = %+v, want the longsword", left)
+	}
+	if got := slotItem(backpack(s), 5); got != "longsword" {
+		t.Errorf("backpack[5] = %q, want the longsword still in the bag", got)
+	}
+	if got := slotItem(vaultSlots(s, "bank"), 1); got != "rations" {
+		t.Errorf("vault[1] = %q, want rations", got)
+	}
+}
+
+// Take-all pulls everything out; a container with nowhere but the backpack to
+// go stays in the vault.
+func TestVaultTakeAllRespectsBackpackRule(t *testing.T) {
+	setup(t)
+	s := newSave(inventory.GeneralSlotCount(), 20)
+	makeVault(s, "bank", 4)
+	s.Building = "bank"
+	for i := range general(s) {
+		general(s)[i] = slot(i, "torch", 1)
+	}
+	vaultSlots(s, "bank")[0] = slot(0, "basket", 1)
+	vaultSlots(s, "bank")[2] = slot(2, "rations", 2)
+
+	resp, err := inventory.HandleVaultTakeAllAction(s, p(map[string]interface{}{}))
+	if err != nil || resp == nil || !resp.Success {
+		t.Fatalf("take all: resp=%+v err=%v", resp, err)
+	}
+	moved := resp.Data["moved"].([]inventory.VaultTransfer)
+	left := resp.Data["left"].([]inventory.VaultTransfer)
+	if len(moved) != 1 || moved[0].Item != "rations" {
+		t.Errorf("moved = %+v, want the rations", moved)
+	}
+	if len(left) != 1 || left[0].Item != "basket" {
+		t.Errorf("left = %+v, want the basket (general slots full, can't go in the bag)", left)
+	}
+	if got := slotItem(backpack(s), 0); got != "rations" {
+		t.Errorf("backpack[0] = %q, want rations", got)
+	}
+	if got := slotItem(vaultSlots(s, "bank"), 0); got != "basket" {
+		t.Errorf("vault[0] = %q, want the basket left behind", got)
+	}
+}
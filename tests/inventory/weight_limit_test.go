@@ -0,0 +1,43 @@
+package inventory_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/server/game/inventory"
+	"pubkey-quest/cmd/server/game/status"
+)
+
+// Items only fit the weight capacity left after what the player carries, and
+// add_item refuses an overweight add only when asked to enforce it.
+func TestWeightLimitedAdds(t *testing.T) {
+	setup(t)
+	s := newSave(4, 20) // STR 16 → 96 lbs; the backpack weighs 5
+
+	if got := status.CarryableQuantity(s, "barrel", 3); got != 1 {
+		t.Errorf("barrels (70 lbs) that fit = %d, want 1", got)
+	}
+	if got := status.CarryableQuantity(s, "not-an-item", 3); got != 3 {
+		t.Errorf("weightless items always fit, got %d", got)
+	}
+	if status.WeightPolicy("pickup") != "block" || status.WeightPolicy("loot") != "allow" {
+		t.Errorf("policies: pickup %q, loot %q", status.WeightPolicy("pickup"), status.WeightPolicy("loot"))
+	}
+
+	resp, err := inventory.HandleAddItemAction(s, map[string]interface{}{
+		"item_id": "barrel", "quantity": float64(2), "enforce_weight": true,
+	})
+	if err != nil || resp.Success {
+		t.Fatalf("enforced overweight add should be refused, got %+v %v", resp, err)
+	}
+	if slotItem(general(s), 0) != "" {
+		t.Error("a refused add must not place anything")
+	}
+
+	resp, err = inventory.HandleAddItemAction(s, map[string]interface{}{"item_id": "barrel", "quantity": float64(2)})
+	if err != nil || !resp.Success {
+		t.Fatalf("unenforced add should go through, got %+v %v", resp, err)
+	}
+	if got := status.CarryableQuantity(s, "barrel", 1); got != 0 {
+		t.Errorf("over capacity nothing more fits, got %d", got)
+	}
+}
@@ -0,0 +1,99 @@
+package quest_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/server/game/events"
+	"pubkey-quest/cmd/server/game/npc"
+	"pubkey-quest/cmd/server/game/quest"
+	"pubkey-quest/types"
+)
+
+func peltQuest() map[string]*types.QuestData {
+	return map[string]*types.QuestData{
+		"pelts": {
+			ID: "pelts", Name: "Pelts for the Tanner", Category: types.QuestSide,
+			Stages: []types.QuestStage{{
+				ID: "bring", TurnIn: true,
+				Objectives: []types.QuestObjective{{Type: types.ObjectiveFetch, Target: "wolf-pelt", Count: 3, Description: "Bring 3 wolf pelts"}},
+				Rewards:    &types.POIReward{Gold: 30},
+			}},
+		},
+	}
+}
+
+// A turn-in fetch stage doesn't complete on pickup; handing it in checks the
+// inventory, takes the items and pays out.
+func TestTurnInFetchQuest(t *testing.T) {
+	qs := peltQuest()
+	lookup := lookupFrom(qs)
+	save := newSave()
+	if err := quest.Accept(*qs["pelts"], save, fakeCtx{}); err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+
+	var r events.Recorder
+	r.Subscribe(quest.Consumer(lookup, nil))
+	r.Record(save, events.ItemAcquired, "wolf-pelt", 3)
+	if !quest.IsActive(save, "pelts") {
+		t.Fatal("a turn-in stage must wait for the hand-in, not complete on pickup")
+	}
+
+	if err := quest.TurnIn(save, "pelts", lookup, nil); err == nil {
+		t.Fatal("handing in without the pelts should fail")
+	}
+
+	slots := save.Inventory["general_slots"].([]interface{})
+	slots[0] = map[string]interface{}{"item": "wolf-pelt", "quantity": 2}
+	slots[1] = map[string]interface{}{"item": "wolf-pelt", "quantity": float64(2)}
+	if err := quest.ReadyToTurnIn(save, qs["pelts"]); err != nil {
+		t.Fatalf("4 pelts should be enough: %v", err)
+	}
+	if err := quest.TurnIn(save, "pelts", lookup, nil); err != nil {
+		t.Fatalf("TurnIn: %v", err)
+	}
+
+	if !quest.IsCompleted(save, "pelts") || quest.IsActive(save, "pelts") {
+		t.Errorf("quest should be completed, active=%v completed=%v", save.QuestsActive, save.QuestsCompleted)
+	}
+	pelts := 0
+	for _, s := range save.Inventory["general_slots"].([]interface{}) {
+		if slot := s.(map[string]interface{}); slot["item"] == "wolf-pelt" {
+			pelts += slot["quantity"].(int)
+		}
+	}
+	if pelts != 1 {
+		t.Errorf("3 of 4 pelts should be taken, %d left", pelts)
+	}
+	if goldOf(save) != 30 {
+		t.Errorf("reward gold = %d, want 30", goldOf(save))
+	}
+	if err := quest.TurnIn(save, "pelts", lookup, nil); err == nil {
+		t.Error("a completed quest can't be handed in again")
+	}
+}
+
+// Dialogue options can be gated on a quest's state.
+func TestDialogueQuestRequirements(t *testing.T) {
+	save := newSave()
+	save.QuestsActive = []types.QuestProgress{{QuestID: "pelts"}}
+	save.QuestsCompleted = []string{"wolf-hunt"}
+
+	cases := []struct {
+		req  map[string]interface{}
+		want bool
+	}{
+		{map[string]interface{}{"quest_active": "pelts"}, true},
+		{map[string]interface{}{"quest_active": "wolf-hunt"}, false},
+		{map[string]interface{}{"quest_completed": "wolf-hunt"}, true},
+		{map[string]interface{}{"quest_completed": "pelts"}, false},
+		{map[string]interface{}{"quest_not_started": "sequel"}, true},
+		{map[string]interface{}{"quest_not_started": "pelts"}, false},
+		{map[string]interface{}{"quest_not_started": "wolf-hunt"}, false},
+	}
+	for _, c := range cases {
+		if got := npc.CheckDialogueRequirements(save, c.req); got != c.want {
+			t.Errorf("requirements %v = %v, want %v", c.req, got, c.want)
+		}
+	}
+}
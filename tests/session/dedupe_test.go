@@ -0,0 +1,41 @@
+package session_test
+
+import (
+	"fmt"
+	"testing"
+
+	"pubkey-quest/cmd/server/session"
+)
+
+// A re-sent action id is answered from the window; once enough newer actions
+// have gone through, the oldest id is forgotten. Actions without an id are
+// never remembered.
+func TestAppliedActionWindow(t *testing.T) {
+	sess := &session.GameSession{}
+
+	sess.RecordAppliedAction("drink-1", []byte(`{"success":true}`))
+	if resp, ok := sess.AppliedResponse("drink-1"); !ok || string(resp) != `{"success":true}` {
+		t.Fatalf("repeat of drink-1 = %q, %v; want the recorded response", resp, ok)
+	}
+	if _, ok := sess.AppliedResponse("drink-2"); ok {
+		t.Error("an unseen id shouldn't count as a duplicate")
+	}
+
+	sess.RecordAppliedAction("", []byte(`{}`))
+	if _, ok := sess.AppliedResponse(""); ok || len(sess.AppliedActions) != 1 {
+		t.Errorf("an action without an id shouldn't be remembered (%d entries)", len(sess.AppliedActions))
+	}
+
+	for i := 0; i < session.AppliedActionWindow; i++ {
+		sess.RecordAppliedAction(fmt.Sprintf("move-%d", i), []byte(`{}`))
+	}
+	if _, ok := sess.AppliedResponse("drink-1"); ok {
+		t.Error("the oldest id should fall out of a full window")
+	}
+	if _, ok := sess.AppliedResponse("move-0"); !ok {
+		t.Error("move-0 is still inside the window")
+	}
+	if len(sess.AppliedActions) != session.AppliedActionWindow {
+		t.Errorf("window holds %d entries, want %d", len(sess.AppliedActions), session.AppliedActionWindow)
+	}
+}
@@ -0,0 +1,49 @@
+package session_test
+
+import (
+	"errors"
+	"testing"
+
+	"pubkey-quest/cmd/server/session"
+	"pubkey-quest/types"
+)
+
+// Each stored update moves the session to the next revision; an update built on
+// an older revision (a second tab) is refused, and a reload from disk keeps
+// counting up so the old revision stays stale.
+func TestUpdateSessionRevisions(t *testing.T) {
+	sm := session.NewSessionManager()
+	npub, saveID := "npub1test", "save_123"
+	load := func(string, string) (*types.SaveFile, error) {
+		return &types.SaveFile{HP: 10, Revision: 4}, nil
+	}
+	sess, err := sm.LoadSession(npub, saveID, load, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("LoadSession: %v", err)
+	}
+
+	tabA, tabB := sess.SaveData, sess.SaveData
+	tabA.HP = 7
+	if err := sm.UpdateSession(npub, saveID, tabA); err != nil {
+		t.Fatalf("first update: %v", err)
+	}
+	if sess.SaveData.Revision != 5 || sess.SaveData.HP != 7 {
+		t.Fatalf("after the update: revision %d, hp %d; want 5, 7", sess.SaveData.Revision, sess.SaveData.HP)
+	}
+
+	tabB.HP = 1
+	if err := sm.UpdateSession(npub, saveID, tabB); !errors.Is(err, session.ErrRevisionConflict) {
+		t.Fatalf("stale update: err = %v, want ErrRevisionConflict", err)
+	}
+	if sess.SaveData.HP != 7 {
+		t.Errorf("the stale update overwrote the session: hp %d", sess.SaveData.HP)
+	}
+
+	reloaded, err := sm.ReloadSession(npub, saveID, load, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("ReloadSession: %v", err)
+	}
+	if reloaded.SaveData.Revision != 6 {
+		t.Errorf("reloaded revision = %d, want 6 (past the discarded session's 5)", reloaded.SaveData.Revision)
+	}
+}
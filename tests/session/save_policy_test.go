@@ -0,0 +1,40 @@
+package session_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/server/session"
+	"pubkey-quest/types"
+)
+
+func TestSavePolicyBlockedReason(t *testing.T) {
+	inCity := &types.SaveFile{Location: "kingdom", CurrentDay: 3, TimeOfDay: 600}
+	wilds := &types.SaveFile{Location: "darkwood-forest", CurrentDay: 3, TimeOfDay: 600}
+	rented := &types.SaveFile{Location: "kingdom", Building: "kingdom-inn", CurrentDay: 3, TimeOfDay: 600,
+		Rentals: []types.Rental{{Building: "kingdom-inn", ExpiresDay: 4, ExpiresMin: 360}}}
+
+	anywhere := session.DefaultSavePolicy()
+	if reason := anywhere.BlockedReason(wilds, "environment"); reason != "" {
+		t.Errorf("anywhere policy refused a save in the wilds: %q", reason)
+	}
+
+	safe := session.SavePolicy{Policy: session.SavePolicySafeOnly, SafePlaces: []string{"city", "rented_room"}}
+	if reason := safe.BlockedReason(inCity, "city"); reason != "" {
+		t.Errorf("safe-only refused a save in town: %q", reason)
+	}
+	if reason := safe.BlockedReason(wilds, "environment"); reason == "" {
+		t.Error("safe-only allowed a save in the wilds")
+	}
+
+	roomOnly := session.SavePolicy{Policy: session.SavePolicySafeOnly, SafePlaces: []string{"rented_room"}}
+	if reason := roomOnly.BlockedReason(inCity, "city"); reason == "" {
+		t.Error("rented_room-only allowed a save on the street")
+	}
+	if reason := roomOnly.BlockedReason(rented, "city"); reason != "" {
+		t.Errorf("rented_room-only refused a save in the rented room: %q", reason)
+	}
+	rented.CurrentDay = 5 // rental expired
+	if reason := roomOnly.BlockedReason(rented, "city"); reason == "" {
+		t.Error("an expired rental should not count as a safe room")
+	}
+}
@@ -0,0 +1,134 @@
+package shop_test
+
+import (
+	"strings"
+	"testing"
+
+	"pubkey-quest/cmd/server/db"
+	"pubkey-quest/cmd/server/game/gameutil"
+	"pubkey-quest/cmd/server/game/shop"
+	"pubkey-quest/tests/helpers"
+	"pubkey-quest/types"
+)
+
+func setup(t *testing.T) {
+	t.Helper()
+	helpers.SetupTestEnvironment(t)
+	if err := db.InitDatabase(); err != nil {
+		t.Fatalf("init database: %v", err)
+	}
+}
+
+func slot(index int, item string, qty int) map[string]interface{} {
+	if item == "" {
+		return map[string]interface{}{"item": nil, "quantity": float64(0), "slot": float64(index)}
+	}
+	return map[string]interface{}{"item": item, "quantity": float64(qty), "slot": float64(index)}
+}
+
+// traderSave has gold in general[0], the rest of the general row empty, and an
+// equipped backpack with four empty slots.
+func traderSave(gold int) *types.SaveFile {
+	general := []interface{}{slot(0, "gold-piece", gold), slot(1, "", 0), slot(2, "", 0), slot(3, "", 0)}
+	contents := []interface{}{slot(0, "", 0), slot(1, "", 0), slot(2, "", 0), slot(3, "", 0)}
+	return &types.SaveFile{
+		Stats: map[string]interface{}{"charisma": float64(10)},
+		Inventory: map[string]interface{}{
+			"general_slots": general,
+			"gear_slots": map[string]interface{}{
+				"bag": map[string]interface{}{"item": "backpack", "quantity": float64(1), "contents": contents},
+			},
+		},
+	}
+}
+
+var generalStore = types.ShopConfig{
+	ShopType:  "general",
+	BuysItems: true,
+	Inventory: []types.ShopInventoryItem{{ItemID: "dagger", Stock: 5, MaxStock: 5}},
+}
+
+// Buying charges the shop's buy price and needs stock, gold and room.
+func TestBuy(t *testing.T) {
+	setup(t)
+	price := shop.CalculateBuyPrice(200, generalStore, 10) // a dagger is worth 200
+	s := traderSave(price * 2)
+
+	trade, err := shop.Buy(s, generalStore, "dagger", 2, 5)
+	if err != nil {
+		t.Fatalf("buy 2 daggers: %v", err)
+	}
+	if trade.Quantity != 2 || trade.Gold != price*2 {
+		t.Errorf("trade = %+v, want 2 daggers for %d", trade, price*2)
+	}
+	if gold := gameutil.GetGoldQuantity(s); gold != 0 {
+		t.Errorf("gold left = %d, want 0", gold)
+	}
+
+	for _, tc := range []struct {
+		item     string
+		qty      int
+		stock    int
+		fragment string
+		code     string
+	}{
+		{"dagger", 1, 5, "not enough gold", types.ErrCodeInsufficientGold},
+		{"dagger", 3, 2, "not enough stock", types.ErrCodeOutOfStock},
+		{"longsword", 1, 5, "not in shop inventory", types.ErrCodeItemNotFound},
+	} {
+		_, err := shop.Buy(s, generalStore, tc.item, tc.qty, tc.stock)
+		if err == nil || !strings.Contains(err.Error(), tc.fragment) {
+			t.Errorf("buy %d %s: got %v, want %q", tc.qty, tc.item, err, tc.fragment)
+		}
+		if code := types.ErrorCodeOf(err); code != tc.code {
+			t.Errorf("buy %d %s: error code %q, want %q", tc.qty, tc.item, code, tc.code)
+		}
+	}
+}
+
+// Selling pays the sell price and takes the item out of its slot; an equipped
+// container still holding items can't be sold.
+func TestSell(t *testing.T) {
+	setup(t)
+	s := traderSave(0)
+	general := s.Inventory["general_slots"].([]interface{})
+	general[1] = slot(1, "dagger", 1)
+	bag := s.Inventory["gear_slots"].(map[string]interface{})["bag"].(map[string]interface{})
+	bag["contents"].([]interface{})[0] = slot(0, "rations", 2)
+
+	fromGeneral := shop.SellSource{SlotType: "general", Slot: 1}
+	if _, err := shop.Sell(s, generalStore, "dagger", 1, fromGeneral, 0); err == nil {
+		t.Error("a merchant without the gold should refuse")
+	}
+	if general[1].(map[string]interface{})["item"] != "dagger" {
+		t.Fatal("a refused sale must leave the item in place")
+	}
+
+	price := shop.CalculateSellPrice(200, generalStore, 10)
+	trade, err := shop.Sell(s, generalStore, "dagger", 1, fromGeneral, 1000)
+	if err != nil {
+		t.Fatalf("sell dagger: %v", err)
+	}
+	if trade.Gold != price || gameutil.GetGoldQuantity(s) != price {
+		t.Errorf("trade %+v, gold %d; want %d", trade, gameutil.GetGoldQuantity(s), price)
+	}
+	if general[1].(map[string]interface{})["item"] != nil {
+		t.Error("the sold dagger should leave its slot")
+	}
+
+	fromBag := shop.SellSource{SlotType: "equipment", GearSlot: "bag"}
+	if _, err := shop.Sell(s, generalStore, "backpack", 1, fromBag, 1000); err == nil || !strings.Contains(err.Error(), "empty the backpack") {
+		t.Errorf("selling a full equipped backpack: got %v", err)
+	}
+	bag["contents"].([]interface{})[0] = slot(0, "", 0)
+	if _, err := shop.Sell(s, generalStore, "backpack", 1, fromBag, 1000); err != nil {
+		t.Fatalf("sell empty backpack: %v", err)
+	}
+	if bag["item"] != nil {
+		t.Errorf("bag slot should be empty after the sale, got %v", bag["item"])
+	}
+
+	if _, err := shop.Sell(s, types.ShopConfig{ShopType: "general"}, "gold-piece", 1, shop.SellSource{SlotType: "general"}, 1000); err == nil {
+		t.Error("a merchant that doesn't buy items should refuse")
+	}
+}
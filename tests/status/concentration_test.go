@@ -0,0 +1,53 @@
+package status_test
+
+import (
+	"strings"
+	"testing"
+
+	"pubkey-quest/cmd/server/game/effects"
+	"pubkey-quest/types"
+)
+
+// Only one concentration effect at a time: a second is refused until the first
+// is ended, and removing the held effect frees the player's focus.
+func TestConcentrationIsExclusive(t *testing.T) {
+	setup(t)
+
+	state := &types.SaveFile{Stats: baseStats()}
+	if _, err := effects.ApplyEffectWithMessage(state, "blessed"); err != nil {
+		t.Fatalf("apply blessed: %v", err)
+	}
+	if got := effects.ConcentratingOn(state); got != "blessed" {
+		t.Fatalf("concentrating on %q, want blessed", got)
+	}
+	if _, err := effects.ApplyEffectWithMessage(state, "blessed"); err != nil {
+		t.Errorf("renewing the held effect: %v", err)
+	}
+
+	_, err := effects.ApplyEffectWithMessage(state, "haste")
+	if err == nil || !strings.Contains(err.Error(), "Blessed") {
+		t.Fatalf("second concentration effect: err = %v, want a refusal naming Blessed", err)
+	}
+	if effects.HasActiveEffect(state, "haste") {
+		t.Error("a refused effect must not be applied")
+	}
+	// Effects that don't need concentration still go on.
+	if _, err := effects.ApplyEffectWithMessage(state, "performance-high"); err != nil {
+		t.Errorf("non-concentration effect: %v", err)
+	}
+
+	if name := effects.EndConcentration(state); name != "Blessed" {
+		t.Errorf("EndConcentration = %q, want Blessed", name)
+	}
+	if effects.HasActiveEffect(state, "blessed") || state.ConcentrationEffect != "" {
+		t.Errorf("ending concentration should drop the effect: %+v", state)
+	}
+	if _, err := effects.ApplyEffectWithMessage(state, "haste"); err != nil {
+		t.Fatalf("apply haste once free: %v", err)
+	}
+
+	effects.RemoveEffect(state, "haste")
+	if effects.ConcentratingOn(state) != "" || state.ConcentrationEffect != "" {
+		t.Error("removing the held effect should end the concentration")
+	}
+}
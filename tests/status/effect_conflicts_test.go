@@ -0,0 +1,44 @@
+package status_test
+
+import (
+	"strings"
+	"testing"
+
+	"pubkey-quest/cmd/server/game/effects"
+	"pubkey-quest/types"
+)
+
+// Mutually exclusive effects: applying one lifts whatever it conflicts with,
+// either way round, and leaves unrelated effects alone.
+func TestEffectConflictsDisplace(t *testing.T) {
+	setup(t)
+
+	state := &types.SaveFile{Stats: baseStats()}
+	if _, err := effects.ApplyEffectWithMessage(state, "cursed"); err != nil {
+		t.Fatalf("apply cursed: %v", err)
+	}
+	if _, err := effects.ApplyEffectWithMessage(state, "performance-high"); err != nil {
+		t.Fatalf("apply performance-high: %v", err)
+	}
+
+	msg, err := effects.ApplyEffectWithMessage(state, "blessed")
+	if err != nil {
+		t.Fatalf("apply blessed: %v", err)
+	}
+	if effects.HasActiveEffect(state, "cursed") {
+		t.Error("blessing should lift the curse")
+	}
+	if !effects.HasActiveEffect(state, "blessed") || !effects.HasActiveEffect(state, "performance-high") {
+		t.Errorf("blessed and the unrelated performance-high should be active: %+v", state.ActiveEffects)
+	}
+	if !strings.Contains(msg.Message, "Cursed") {
+		t.Errorf("message should mention the lifted curse: %q", msg.Message)
+	}
+
+	if _, err := effects.ApplyEffectWithMessage(state, "cursed"); err != nil {
+		t.Fatalf("re-apply cursed: %v", err)
+	}
+	if effects.HasActiveEffect(state, "blessed") || !effects.HasActiveEffect(state, "cursed") {
+		t.Errorf("the curse should displace the blessing in turn: %+v", state.ActiveEffects)
+	}
+}
@@ -0,0 +1,34 @@
+package status_test
+
+import (
+	"reflect"
+	"testing"
+
+	"pubkey-quest/cmd/server/game/effects"
+	"pubkey-quest/types"
+)
+
+// Only timed effects whose clock ran out count as expired: a permanent effect
+// is never tracked, and one cured early (time still left) isn't an expiry.
+func TestExpiredEffectIDs(t *testing.T) {
+	before := effects.TimedEffectDurations([]types.ActiveEffect{
+		{EffectID: "well-rested", DurationRemaining: 10},
+		{EffectID: "poisoned", DurationRemaining: 120},
+		{EffectID: "blessed", DurationRemaining: 30},
+		{EffectID: "fatigue-accumulation"}, // permanent
+	})
+	if _, tracked := before["fatigue-accumulation"]; tracked {
+		t.Error("permanent effects shouldn't be tracked")
+	}
+
+	// 30 minutes later: well-rested and blessed ran out; poisoned was cured early.
+	after := []types.ActiveEffect{{EffectID: "fatigue-accumulation"}}
+	got := effects.ExpiredEffectIDs(before, after, 30)
+	if want := []string{"blessed", "well-rested"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expired = %v, want %v", got, want)
+	}
+
+	if got := effects.ExpiredEffectIDs(before, nil, 0); got != nil {
+		t.Errorf("no time passed, but got expiries %v", got)
+	}
+}
@@ -0,0 +1,46 @@
+package status_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/server/game/gameutil"
+)
+
+// The configured tiers split at the same weight_percent values as the
+// encumbrance-* effects, and get slower and more tiring as the load grows.
+func TestEncumbranceTiers(t *testing.T) {
+	setup(t)
+	tiers := gameutil.EncumbranceTiers()
+
+	cases := []struct {
+		percent int
+		want    string
+	}{
+		{0, "light"},
+		{34, "light"},
+		{35, "normal"},
+		{100, "normal"},
+		{101, "overweight"},
+		{150, "overweight"},
+		{151, "encumbered"},
+		{200, "encumbered"},
+		{201, "overloaded"},
+		{500, "overloaded"},
+	}
+	for _, c := range cases {
+		if got := gameutil.TierForPercent(tiers, c.percent).Level; got != c.want {
+			t.Errorf("%d%% → %s, want %s", c.percent, got, c.want)
+		}
+	}
+
+	for i := 1; i < len(tiers); i++ {
+		prev, tier := tiers[i-1], tiers[i]
+		if tier.TravelSpeed > prev.TravelSpeed || tier.FatigueRate < prev.FatigueRate {
+			t.Errorf("%s (speed %v, fatigue %v) should be no easier than %s (speed %v, fatigue %v)",
+				tier.Level, tier.TravelSpeed, tier.FatigueRate, prev.Level, prev.TravelSpeed, prev.FatigueRate)
+		}
+	}
+	if last := tiers[len(tiers)-1]; last.TravelSpeed != 0 {
+		t.Errorf("an overloaded player shouldn't travel, speed %v", last.TravelSpeed)
+	}
+}
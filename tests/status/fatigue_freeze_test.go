@@ -54,3 +54,25 @@ func TestFatigueFreezeOnWaitRest(t *testing.T) {
 		t.Errorf("fatigue should accrue during normal time passing, still 0 after 600 min")
 	}
 }
+
+// Fatigue that drops below the max after accumulation stopped (a potion, a
+// night's sleep) starts building again on the next tick of awake time.
+func TestFatigueAccrualRestartsBelowMax(t *testing.T) {
+	setup(t)
+
+	state := &types.SaveFile{HP: 20, MaxHP: 20, Hunger: 2, Fatigue: 10, Stats: baseStats()}
+	gametime.AdvanceTime(state, 60, true)
+	for _, ae := range state.ActiveEffects {
+		if ae.EffectID == "fatigue-accumulation" {
+			t.Fatal("accumulation should stay off at max fatigue")
+		}
+	}
+
+	state.Fatigue = 4
+	for i := 0; i < 120; i++ {
+		gametime.AdvanceTime(state, 1, true)
+	}
+	if state.Fatigue <= 4 {
+		t.Errorf("fatigue should build again once below max, still %d after 2 hours", state.Fatigue)
+	}
+}
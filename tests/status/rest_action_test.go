@@ -0,0 +1,70 @@
+package status_test
+
+import (
+	"strings"
+	"testing"
+
+	"pubkey-quest/cmd/server/game/gametime"
+	"pubkey-quest/cmd/server/game/status"
+	"pubkey-quest/types"
+)
+
+func rest(t *testing.T, state *types.SaveFile, minutes int) *types.GameActionResponse {
+	t.Helper()
+	resp, err := gametime.HandleRestAction(state, map[string]interface{}{"minutes": float64(minutes)}, nil, nil)
+	if err != nil {
+		t.Fatalf("rest %d: %v", minutes, err)
+	}
+	return resp
+}
+
+// Resting restores vitals in proportion to the time rested, scaled down by
+// hunger and fatigue, and passes that much game time.
+func TestRestActionScalesRecovery(t *testing.T) {
+	setup(t)
+
+	fed := &types.SaveFile{HP: 0, MaxHP: 80, Mana: 0, MaxMana: 40, Hunger: 3, Stats: baseStats(), TimeOfDay: 600, CurrentDay: 1}
+	resp := rest(t, fed, 240)
+	if !resp.Success || fed.HP != 40 || fed.Mana != 20 {
+		t.Fatalf("4h well fed and rested should restore half: HP %d Mana %d (%+v)", fed.HP, fed.Mana, resp)
+	}
+	if fed.TimeOfDay != 840 {
+		t.Errorf("rest should pass 240 minutes, clock at %d", fed.TimeOfDay)
+	}
+	if !strings.Contains(resp.Message, "4 hours") || !strings.Contains(resp.Message, "40 HP and 20 mana") {
+		t.Errorf("message should report time and recovery: %q", resp.Message)
+	}
+
+	hungry := &types.SaveFile{HP: 0, MaxHP: 80, Hunger: 1, Fatigue: 4, Stats: baseStats(), TimeOfDay: 600, CurrentDay: 1}
+	if got := status.RestRecoveryFactor(hungry); got != 0.4 {
+		t.Errorf("hungry with fatigue 4 should recover at 0.5×0.8, got %v", got)
+	}
+	rest(t, hungry, 240)
+	if hungry.HP >= 40 || hungry.HP == 0 {
+		t.Errorf("a hungry, tired rest should restore less than a fed one, got HP %d", hungry.HP)
+	}
+
+	if resp := rest(t, fed, 5); resp.Success {
+		t.Error("resting under 15 minutes should be refused")
+	}
+}
+
+// Starving, a rest heals nothing and the starvation drain interrupts it.
+func TestRestInterruptedWhileStarving(t *testing.T) {
+	setup(t)
+
+	state := &types.SaveFile{HP: 10, MaxHP: 20, Hunger: 0, Stats: baseStats(), TimeOfDay: 600, CurrentDay: 1}
+	resp := rest(t, state, 480)
+	if resp.Data["interrupted"] != true {
+		t.Fatalf("starvation damage should cut the rest short: %+v", resp)
+	}
+	if rested := resp.Data["minutes_rested"].(int); rested >= 480 {
+		t.Errorf("an interrupted rest shouldn't run its full length, rested %d", rested)
+	}
+	if state.HP > 10 || resp.Data["hp_recovered"] != 0 {
+		t.Errorf("a starving rest should heal nothing: HP %d, recovered %v", state.HP, resp.Data["hp_recovered"])
+	}
+	if !strings.Contains(resp.Message, "cut short") {
+		t.Errorf("message should say the rest was cut short: %q", resp.Message)
+	}
+}
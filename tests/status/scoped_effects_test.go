@@ -0,0 +1,44 @@
+package status_test
+
+import (
+	"testing"
+
+	"pubkey-quest/cmd/server/game/effects"
+	"pubkey-quest/types"
+)
+
+// Environment-scoped effects: applied on entry tagged with their source, not
+// stacked on re-entry, and cleared — alone — once the player is elsewhere.
+func TestScopedEffectsClearOnLeaving(t *testing.T) {
+	setup(t)
+
+	state := &types.SaveFile{
+		Location:      "darkwood-forest",
+		ActiveEffects: []types.ActiveEffect{{EffectID: "blessed", DurationRemaining: 600}},
+	}
+	source := effects.EnvironmentSource("darkwood-forest")
+
+	effects.ApplyScopedEffects(state, source, []string{"forest-gloom"})
+	effects.ApplyScopedEffects(state, source, []string{"forest-gloom"})
+	if len(state.ActiveEffects) != 2 {
+		t.Fatalf("want blessed + one forest-gloom, got %+v", state.ActiveEffects)
+	}
+	if got := effects.GetActiveStatModifiers(state)["wisdom"]; got != 2-1 {
+		t.Errorf("wisdom modifier = %d, want 1 (blessed +2, gloom -1)", got)
+	}
+
+	// Still in the forest: nothing is stale.
+	if removed := effects.ClearStaleScopedEffects(state); len(removed) != 0 {
+		t.Errorf("nothing should clear while still in the forest, got %v", removed)
+	}
+
+	// Arriving in a city drops the gloom but keeps the unscoped buff.
+	state.Location = "verdant"
+	removed := effects.ClearStaleScopedEffects(state)
+	if len(removed) != 1 || removed[0] != "Forest Gloom" {
+		t.Errorf("removed = %v, want [Forest Gloom]", removed)
+	}
+	if len(state.ActiveEffects) != 1 || state.ActiveEffects[0].EffectID != "blessed" {
+		t.Errorf("only blessed should remain, got %+v", state.ActiveEffects)
+	}
+}
@@ -0,0 +1,39 @@
+package status_test
+
+import (
+	"strings"
+	"testing"
+
+	"pubkey-quest/cmd/server/db"
+	"pubkey-quest/cmd/server/game/combat"
+	"pubkey-quest/cmd/server/game/effects"
+	"pubkey-quest/cmd/server/game/spells"
+	"pubkey-quest/types"
+)
+
+// A buff spell goes through the effect pipeline: the mapped effect lands on
+// the caster, its message joins the cast log, and the mana is spent.
+func TestBuffSpellAppliesEffect(t *testing.T) {
+	setup(t)
+
+	save := &types.SaveFile{Class: "Cleric", Mana: 10, MaxMana: 10, Stats: baseStats()}
+	deps := spells.Deps{RollD20: combat.RollD20, RollDice: combat.RollDice, ResolveMonsterDamage: combat.ResolveDamageToMonster}
+
+	res, err := spells.CastFromScroll(db.GetDB(), deps, save, "bless", 1, nil)
+	if err != nil {
+		t.Fatalf("cast bless: %v", err)
+	}
+	if res.EffectID != "blessed" || !effects.HasActiveEffect(save, "blessed") {
+		t.Fatalf("bless should apply the blessed effect, got %q (active %v)", res.EffectID, save.ActiveEffects)
+	}
+	if len(res.Log) < 2 || !strings.Contains(strings.Join(res.Log, " "), "blessed by divine power") {
+		t.Errorf("cast log should carry the effect message, got %q", res.Log)
+	}
+	if save.Mana != 10-res.ManaSpent || res.ManaSpent == 0 {
+		t.Errorf("mana: spent %d, left %d", res.ManaSpent, save.Mana)
+	}
+
+	if _, err := spells.CastFromScroll(db.GetDB(), deps, save, "fire-bolt", 1, nil); err == nil {
+		t.Error("an offensive spell needs an enemy out of combat")
+	}
+}
@@ -1,6 +1,7 @@
 package status_test
 
 import (
+	"strings"
 	"testing"
 
 	"pubkey-quest/cmd/server/game/gametime"
@@ -32,3 +33,39 @@ func TestStarvationDrainsHP(t *testing.T) {
 		t.Errorf("expected exactly one starvation tick (HP 20 → 19) after 300 min, got HP %d", state.HP)
 	}
 }
+
+// The world tick (update_time) surfaces starvation's HP loss to the client as a
+// tick message, not just a silent HP change.
+func TestUpdateTimeReportsStarvationTick(t *testing.T) {
+	setup(t)
+
+	state := &types.SaveFile{
+		HP: 20, MaxHP: 20, Hunger: 0, Fatigue: 0, Stats: baseStats(), TimeOfDay: 480, CurrentDay: 1,
+	}
+	tick := func(to int) []string {
+		t.Helper()
+		resp, err := gametime.HandleUpdateTimeAction(state, map[string]interface{}{
+			"time_of_day": float64(to), "current_day": float64(1),
+		}, nil, nil)
+		if err != nil || !resp.Success {
+			t.Fatalf("update_time to %d: %v %+v", to, err, resp)
+		}
+		msgs, _ := resp.Data["tick_messages"].([]string)
+		return msgs
+	}
+
+	// First tick: the starving status takes hold, no HP lost yet.
+	tick(490)
+	if state.HP != 20 {
+		t.Fatalf("HP dropped before a full interval passed: %d", state.HP)
+	}
+
+	// A full 240-minute interval later the drain fires and is reported.
+	msgs := tick(730)
+	if state.HP != 19 {
+		t.Fatalf("expected one starvation tick (HP 20 → 19), got HP %d", state.HP)
+	}
+	if len(msgs) == 0 || !strings.Contains(strings.Join(msgs, " "), "lose 1 HP") {
+		t.Errorf("the tick should report the HP loss, got %q", msgs)
+	}
+}
@@ -0,0 +1,87 @@
+package status_test
+
+import (
+	"strings"
+	"testing"
+
+	"pubkey-quest/cmd/server/game/gametime"
+	"pubkey-quest/cmd/server/game/gameutil"
+	"pubkey-quest/cmd/server/game/npc"
+	"pubkey-quest/cmd/server/session"
+	"pubkey-quest/types"
+)
+
+// interruptAfter returns a WaitInterrupt that fires on its nth step and counts
+// the minutes it was shown.
+func interruptAfter(n int, minutes *int) gametime.WaitInterrupt {
+	steps := 0
+	return func(_ *types.SaveFile, step int) string {
+		steps++
+		*minutes += step
+		if steps == n {
+			return "wolves circle the camp"
+		}
+		return ""
+	}
+}
+
+// A wait passes in 15-minute steps and stops on the step an interrupt fires;
+// only the time actually waited passes.
+func TestWaitInterrupted(t *testing.T) {
+	setup(t)
+
+	state := &types.SaveFile{HP: 10, MaxHP: 20, Hunger: 3, Stats: baseStats(), TimeOfDay: 600, CurrentDay: 1}
+	var seen int
+	resp, err := gametime.HandleWaitAction(state, map[string]interface{}{"minutes": float64(240)}, nil, nil, interruptAfter(3, &seen))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Data["interrupted"] != true || resp.Data["minutes_waited"] != 45 {
+		t.Fatalf("wait should stop after the third step: %+v", resp.Data)
+	}
+	if state.TimeOfDay != 645 || seen != 45 {
+		t.Errorf("clock at %d, interrupt saw %d minutes; want 645 and 45", state.TimeOfDay, seen)
+	}
+	if !strings.Contains(resp.Message, "45 minutes") || !strings.Contains(resp.Message, "wolves circle the camp") {
+		t.Errorf("message should say how long and why: %q", resp.Message)
+	}
+
+	// Without an interruption the full time passes, every step checked.
+	seen = 0
+	resp, _ = gametime.HandleWaitAction(state, map[string]interface{}{"minutes": float64(60)}, nil, nil, interruptAfter(-1, &seen))
+	if resp.Data["interrupted"] != false || resp.Data["minutes_waited"] != 60 || seen != 60 {
+		t.Errorf("uninterrupted wait: %+v, interrupt saw %d minutes", resp.Data, seen)
+	}
+}
+
+// A night's sleep woken early keeps the time slept, not the full night, and
+// skips the inn's breakfast.
+func TestSleepInterrupted(t *testing.T) {
+	setup(t)
+
+	state := &types.SaveFile{
+		HP: 10, MaxHP: 20, Hunger: 1, Fatigue: 8, Stats: baseStats(),
+		TimeOfDay: 1380, CurrentDay: 1, Location: "nowhere", Building: "test_inn",
+	}
+	gameutil.AddRental(state, "test_inn", 2, 1439)
+	sess := &session.GameSession{SaveData: *state}
+	noNPCs := func(string, string, string, string, int) []string { return nil }
+
+	var seen int
+	resp, err := npc.HandleSleepAction(state, sess, noNPCs, interruptAfter(6, &seen))
+	if err != nil || !resp.Success {
+		t.Fatalf("sleep: %v %+v", err, resp)
+	}
+	if resp.Data["interrupted"] != true || resp.Data["minutes_slept"] != 90 {
+		t.Fatalf("sleep should end on the sixth step: %+v", resp.Data)
+	}
+	if state.TimeOfDay != 30 || state.CurrentDay != 2 {
+		t.Errorf("woken at day %d %d, want day 2 at 00:30", state.CurrentDay, state.TimeOfDay)
+	}
+	if state.Hunger > 1 {
+		t.Errorf("a broken night shouldn't include breakfast, hunger %d", state.Hunger)
+	}
+	if state.Fatigue == 0 {
+		t.Error("90 minutes shouldn't clear a tired player's fatigue")
+	}
+}
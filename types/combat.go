@@ -44,15 +44,45 @@ type MonsterHitSpecial struct {
 	Effect  string `json:"effect,omitempty"`
 }
 
-// MonsterAction represents an action a monster can take in combat
+// MonsterAction represents an action a monster can take in combat. Attacks
+// ("melee_attack", "ranged_attack") roll against AC and deal Hit. A
+// "multiattack" entry makes Attacks attacks a turn, in Sequence order when
+// given (names of the monster's attacks). A "special" resolves its Mechanic and
+// is limited by Recharge: "none", "once_per_combat" or "recharge_N_6".
 type MonsterAction struct {
-	Name        string     `json:"name"`
-	Type        string     `json:"type"` // "melee_attack", "ranged_attack"
-	AttackBonus int        `json:"attack_bonus"`
-	Reach       *int       `json:"reach"`
-	Range       *int       `json:"range"`
-	RangeLong   *int       `json:"range_long"`
-	Hit         MonsterHit `json:"hit"`
+	Name        string           `json:"name"`
+	Type        string           `json:"type"` // "melee_attack", "ranged_attack", "multiattack", "special"
+	AttackBonus int              `json:"attack_bonus"`
+	Reach       *int             `json:"reach"`
+	Range       *int             `json:"range"`
+	RangeLong   *int             `json:"range_long"`
+	Hit         MonsterHit       `json:"hit"`
+	Description string           `json:"description,omitempty"`
+	Attacks     int              `json:"attacks,omitempty"`
+	Sequence    []string         `json:"sequence,omitempty"`
+	Recharge    string           `json:"recharge,omitempty"`
+	Mechanic    *MonsterMechanic `json:"mechanic,omitempty"`
+}
+
+// MonsterMechanic is how a special action resolves. "area_save": the player,
+// if within Range, saves with Ability against DC. On a failure they take
+// Dice+Mod damage and/or gain the Effect condition, or drop to 0 HP when
+// OnFail is "drop_to_zero"; on a success they take the OnSuccess damage, if any.
+type MonsterMechanic struct {
+	Type          string `json:"type"`
+	Ability       string `json:"ability,omitempty"`
+	DC            int    `json:"dc,omitempty"`
+	Range         int    `json:"range,omitempty"`
+	Targets       string `json:"targets,omitempty"`
+	Effect        string `json:"effect,omitempty"`
+	Duration      string `json:"duration,omitempty"`
+	Dice          string `json:"dice,omitempty"`
+	Mod           int    `json:"mod,omitempty"`
+	DamageType    string `json:"damage_type,omitempty"`
+	OnFail        string `json:"on_fail,omitempty"`
+	OnSuccessDice string `json:"on_success_dice,omitempty"`
+	OnSuccessMod  int    `json:"on_success_mod,omitempty"`
+	OnSuccessType string `json:"on_success_type,omitempty"`
 }
 
 // MonsterSpecialAbility represents a passive or active special trait
@@ -145,6 +175,7 @@ type AdvancementEntry struct {
 type LootDrop struct {
 	Item     string `json:"item"`
 	Quantity int    `json:"quantity"`
+	Rarity   string `json:"rarity,omitempty"` // item rarity at roll time, for the loot screen
 }
 
 // CombatCondition is a condition applied to a combatant
@@ -153,6 +184,8 @@ type CombatCondition struct {
 	DurationRounds int    `json:"duration_rounds"` // -1 = permanent until removed
 	SaveDC         int    `json:"save_dc,omitempty"`
 	SaveStat       string `json:"save_stat,omitempty"`
+	Damage         string `json:"damage,omitempty"`      // Damage-over-time dice rolled each round ("1d6"); "" = none
+	DamageType     string `json:"damage_type,omitempty"` // e.g. "fire", "poison" (log only)
 }
 
 // Position is an X,Y coordinate on the combat grid.
@@ -182,6 +215,7 @@ type PlayerCombatState struct {
 	MaxHP              int               `json:"max_hp"`
 	ActionUsed         bool              `json:"action_used"`
 	BonusActionUsed    bool              `json:"bonus_action_used"`
+	InteractionUsed    bool              `json:"interaction_used"`
 	MovementBudget     int               `json:"movement_budget"` // Max cells per turn (Speed / 5)
 	MovementSpent      int               `json:"movement_spent"`  // Cells used this turn
 	HeldPosition       bool              `json:"held_position"`   // True when player didn't move this turn (readied attack)
@@ -192,16 +226,23 @@ type PlayerCombatState struct {
 	IsStable           bool              `json:"is_stable"`
 	ReactionUsed       bool              `json:"reaction_used"` // Reaction consumed this round (OA)
 	Disengaged         bool              `json:"disengaged"`    // Used Disengage action this turn — no OAs provoked
+	Aiming             bool              `json:"aiming"`        // Readied aim — advantage on the next ranged attack; lost on moving, being hit, or any attack
 	Conditions         []CombatCondition `json:"conditions"`
 
 	// Class-ability state (M5 §12) — all memory-only, initialised at combat start.
-	Resource           *ResourcePool `json:"resource,omitempty"`             // martial resource pool; nil for casters
-	RageTurnsLeft      int           `json:"rage_turns_left,omitempty"`      // barbarian rage: turns remaining (0 = not raging)
-	RageDamageBonusPct int           `json:"rage_damage_bonus_pct,omitempty"` // % bonus to weapon damage while raging
-	RageResistPct      int           `json:"rage_resist_pct,omitempty"`       // % reduction to incoming damage while raging
-	ExtraActions       int           `json:"extra_actions,omitempty"`         // granted by Action Surge / Flurry — extra attack actions this turn
-	PendingSneakDice   string        `json:"pending_sneak_dice,omitempty"`    // rogue Sneak Attack rider ("2d6") applied to the next hit
-	AbilitiesUsed      []string      `json:"abilities_used,omitempty"`        // once-per-combat abilities already spent this fight
+	Resource           *ResourcePool  `json:"resource,omitempty"`              // martial resource pool; nil for casters
+	RageTurnsLeft      int            `json:"rage_turns_left,omitempty"`       // barbarian rage: turns remaining (0 = not raging)
+	RageDamageBonusPct int            `json:"rage_damage_bonus_pct,omitempty"` // % bonus to weapon damage while raging
+	RageResistPct      int            `json:"rage_resist_pct,omitempty"`       // % reduction to incoming damage while raging
+	ExtraActions       int            `json:"extra_actions,omitempty"`         // granted by Action Surge / Flurry — extra attack actions this turn
+	PendingSneakDice   string         `json:"pending_sneak_dice,omitempty"`    // rogue Sneak Attack rider ("2d6") applied to the next hit
+	AbilitiesUsed      []string       `json:"abilities_used,omitempty"`        // once-per-combat abilities already spent this fight
+	AbilityCooldowns   map[string]int `json:"ability_cooldowns,omitempty"`     // ability id → rounds until it can be used again
+	PendingDamagePct   int            `json:"pending_damage_pct,omitempty"`    // fighter Power Strike: the next hit deals this % of its damage
+	CritRange          int            `json:"crit_range,omitempty"`            // lowest natural d20 that crits, from class/level at combat start (0 = 20)
+	ArmorHampered      bool           `json:"armor_hampered,omitempty"`        // wearing armor the class isn't trained in — disadvantage on attacks and STR/DEX saves
+	DamageResistances  []string       `json:"damage_resistances,omitempty"`    // damage types halved, from effects and gear
+	DamageImmunities   []string       `json:"damage_immunities,omitempty"`     // damage types negated, from effects and gear
 }
 
 // MonsterInstance is a live monster in the current combat encounter
@@ -217,6 +258,9 @@ type MonsterInstance struct {
 	IsAlive    bool             `json:"is_alive"`
 	ReactionUsed bool           `json:"reaction_used"` // Reaction consumed this round (OA)
 	Disengaged   bool           `json:"disengaged"`    // Monster used Disengage this turn
+	Escaped      bool           `json:"escaped,omitempty"` // Fled the battlefield (no longer alive in the fight, but not killed)
+	Pos          Position       `json:"pos"`           // Grid cell — range is measured per monster from here
+	SpentActions []string       `json:"spent_actions,omitempty"` // Specials used up until they recharge (or for the rest of the fight)
 	Data       MonsterData      `json:"data"` // Full stat block
 }
 
@@ -256,30 +300,86 @@ type CombatSession struct {
 	GridWidth          int               `json:"grid_width"`   // Always 9
 	GridHeight         int               `json:"grid_height"`  // Always 7
 	PlayerPos          Position          `json:"player_pos"`
-	MonsterPos         Position          `json:"monster_pos"`
+	TargetID           string            `json:"target_id,omitempty"` // Instance ID the player is targeting ("" = first living monster)
 	Log                []string          `json:"log"`
 	EnvironmentID      string            `json:"environment_id"`
 	IsSurprised        bool              `json:"is_surprised"`  // Player was surprised (monster acts first)
-	Phase              string            `json:"phase"`         // "active", "loot", "victory", "defeat", "death_saves"
+	Phase              string            `json:"phase"`         // "active", "loot", "victory", "fled", "defeat", "death_saves"
 	LootRolled         []LootDrop        `json:"loot_rolled,omitempty"`
 	LevelUpPending     bool              `json:"level_up_pending"`
 	XPEarnedThisFight  int               `json:"xp_earned_this_fight"`
 	AmmoUsedThisCombat int               `json:"ammo_used_this_combat"`
+	AmmoUsedByItem     map[string]int    `json:"ammo_used_by_item,omitempty"` // Rounds fired per ammo item, for recovery at the end
 
 	// Difficulty rates the fight against the player's level band at combat start
 	// ("trivial"…"deadly", see encounter.Difficulty). Surfaced so the client can
 	// warn on a fight that outclasses the player (M5 §22). Set once, memory-only.
 	Difficulty string `json:"difficulty,omitempty"`
 
+	// AmbientLight is the light where the fight happens ("bright", "dim",
+	// "dark"), fixed at combat start from the location and time of day.
+	// LightLevel is what the player actually fights in — ambient, lifted by a
+	// light source they carry — refreshed before each attack. Darkness gives
+	// ranged attacks disadvantage.
+	AmbientLight string `json:"ambient_light,omitempty"`
+	LightLevel   string `json:"light_level,omitempty"`
+	// Night is set when the fight starts after dark (gametime.IsNight); ranged
+	// attacks have disadvantage for the whole fight.
+	Night bool `json:"night,omitempty"`
+
 	// Concentration is the spell the player is currently concentrating on (buff/
 	// control). Nil when not concentrating. Taking damage triggers a CON save.
 	Concentration *ConcentrationState `json:"concentration,omitempty"`
 
 	// MonsterSpawnPos is set only on the very first response for an encounter
 	// (combat start). When the monster wins initiative and runs an opening
-	// turn before the player ever sees the board, the post-move MonsterPos
+	// turn before the player ever sees the board, the post-move monster Pos
 	// would otherwise teleport the sprite into position. Surfacing the spawn
 	// lets the frontend animate the opening step in lock-step with the
 	// "moves toward you" log line. Cleared after the start response is sent.
 	MonsterSpawnPos *Position `json:"-"`
+
+	// LastAttack describes the player's attack just resolved, for the client to
+	// animate (see AttackPresentation). Set by ProcessPlayerAttack and cleared
+	// once the attack response is sent.
+	LastAttack *AttackPresentation `json:"-"`
+
+	// Events are the typed steps resolved since the last response (see
+	// CombatEvent), reported once alongside the new log lines.
+	Events []CombatEvent `json:"-"`
+}
+
+// CombatEvent is one typed step of a combat exchange — an attack and whether it
+// hit, the damage, a move, a kill, the loot, a level-up — reported in order
+// alongside the log prose so the client can animate from the numbers instead
+// of parsing the text. Actor and Target are "player" or a monster instance ID.
+type CombatEvent struct {
+	Type       string     `json:"type"` // attack, hit, miss, damage, move, death, loot, level_up
+	Actor      string     `json:"actor,omitempty"`
+	Target     string     `json:"target,omitempty"`
+	Roll       int        `json:"roll,omitempty"`        // attack: natural d20
+	Total      int        `json:"total,omitempty"`       // attack: roll plus modifiers
+	Range      int        `json:"range"`                 // attack: range to the target; move: range to the player after it
+	Crit       bool       `json:"crit,omitempty"`        // hit / miss: a natural 20 / natural 1
+	Damage     int        `json:"damage,omitempty"`      // damage
+	DamageType string     `json:"damage_type,omitempty"` // damage
+	From       *Position  `json:"from,omitempty"`        // move
+	To         *Position  `json:"to,omitempty"`          // move
+	Loot       []LootDrop `json:"loot,omitempty"`        // loot
+}
+
+// AttackPresentation is the presentation hint for one player attack: what was
+// swung or shot and how it landed, derived from the weapon so the client can
+// pick an animation without looking the item up.
+type AttackPresentation struct {
+	WeaponID       string `json:"weapon_id,omitempty"` // "" for an unarmed strike
+	WeaponCategory string `json:"weapon_category"`     // "simple", "martial", "improvised" or "unarmed"
+	DamageType     string `json:"damage_type"`         // "slashing", "piercing", …
+	Ranged         bool   `json:"ranged"`              // fired from a ranged weapon
+	Thrown         bool   `json:"thrown"`              // a thrown melee weapon
+	OffHand        bool   `json:"off_hand,omitempty"`  // two-weapon fighting bonus attack
+	TargetID       string `json:"target_id"`
+	Hit            bool   `json:"hit"`
+	Crit           bool   `json:"crit"`
+	Damage         int    `json:"damage"`
 }
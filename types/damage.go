@@ -0,0 +1,39 @@
+package types
+
+// Damage types — the one list every system shares: weapon and monster attacks,
+// spells and damage-over-time deal them; monster and gear resistances,
+// immunities and vulnerabilities name them; the codex validator and cleanup
+// check and fill them in from here.
+const (
+	DamageAcid        = "acid"
+	DamageBludgeoning = "bludgeoning"
+	DamageCold        = "cold"
+	DamageFire        = "fire"
+	DamageForce       = "force"
+	DamageLightning   = "lightning"
+	DamageNecrotic    = "necrotic"
+	DamagePiercing    = "piercing"
+	DamagePoison      = "poison"
+	DamagePsychic     = "psychic"
+	DamageRadiant     = "radiant"
+	DamageSlashing    = "slashing"
+	DamageThunder     = "thunder"
+)
+
+// DamageTypes lists every damage type in alphabetical order.
+var DamageTypes = []string{
+	DamageAcid, DamageBludgeoning, DamageCold, DamageFire, DamageForce,
+	DamageLightning, DamageNecrotic, DamagePiercing, DamagePoison,
+	DamagePsychic, DamageRadiant, DamageSlashing, DamageThunder,
+}
+
+// IsDamageType reports whether t is a damage type as written in game data
+// (lowercase). Combat matches case-insensitively, so lowercase t first there.
+func IsDamageType(t string) bool {
+	for _, name := range DamageTypes {
+		if name == t {
+			return true
+		}
+	}
+	return false
+}
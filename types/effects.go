@@ -2,22 +2,35 @@ package types
 
 // EffectData represents the complete effect definition (from JSON files)
 type EffectData struct {
-	ID          string           `json:"id"`
-	Name        string           `json:"name"`
-	Description string           `json:"description"`
-	SourceType  string           `json:"source_type"` // "system_ticker", "system_status", "applied"
-	Category    string           `json:"category"`    // "buff", "debuff", "status"
-	Removal     RemovalCondition `json:"removal"`
+	ID           string           `json:"id"`
+	Name         string           `json:"name"`
+	Description  string           `json:"description"`
+	SourceType   string           `json:"source_type"` // "system_ticker", "system_status", "applied"
+	Category     string           `json:"category"`    // "buff", "debuff", "status"
+	Removal      RemovalCondition `json:"removal"`
 	SystemCheck  *SystemCheck     `json:"system_check,omitempty"`  // For system_status effects: when to activate
-	SkillScaling *SkillScaling   `json:"skill_scaling,omitempty"` // Optional: skill-based tick interval scaling
-	Modifiers    []Modifier      `json:"modifiers"`
-	Message     string           `json:"message,omitempty"`
-	Visible     bool             `json:"visible"`
+	SkillScaling *SkillScaling    `json:"skill_scaling,omitempty"` // Optional: skill-based tick interval scaling
+	Modifiers    []Modifier       `json:"modifiers"`
+	Conflicts    []string         `json:"conflicts,omitempty"`    // Effect IDs this one can't coexist with — applying it removes them
+	Condition    string           `json:"condition,omitempty"`    // Combat condition imposed while active ("poisoned", "stunned", …)
+	SavingThrow  *SavingThrow     `json:"saving_throw,omitempty"` // Optional: lets the player shake the condition off in combat
+	Message      string           `json:"message,omitempty"`
+	Visible      bool             `json:"visible"`
+	// Concentration marks an effect the player holds by focus: only one at a
+	// time, and combat damage can break it (see effects.ConcentratingOn).
+	Concentration bool `json:"concentration,omitempty"`
+}
+
+// SavingThrow is the save an afflicted player rolls at the end of each of their
+// combat turns to throw off an effect's condition (ending the effect early).
+type SavingThrow struct {
+	Stat string `json:"stat"` // Ability the save uses ("constitution", "wisdom", …)
+	DC   int    `json:"dc"`   // Total needed (d20 + ability modifier) to succeed
 }
 
 // RemovalCondition describes how an effect is removed
 type RemovalCondition struct {
-	Type   string `json:"type"`             // "permanent", "timed", "action", "equipment"
+	Type   string `json:"type"`             // "permanent", "timed", "action", "equipment", "environment"
 	Timer  int    `json:"timer,omitempty"`  // Duration in minutes (for timed)
 	Action string `json:"action,omitempty"` // Action ID (for action)
 }
@@ -29,6 +42,7 @@ type Modifier struct {
 	Type         string `json:"type"`                    // "instant" or "periodic"
 	Delay        int    `json:"delay,omitempty"`         // Minutes before this modifier activates
 	TickInterval int    `json:"tick_interval,omitempty"` // For periodic: minutes between applications
+	DamageType   string `json:"damage_type,omitempty"`   // For damage_resistance/damage_immunity: the damage type defended
 }
 
 // SkillScaling defines how a skill modifies an effect's tick interval
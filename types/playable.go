@@ -0,0 +1,18 @@
+package types
+
+// PlayableRegistry is the authoritative list of races and classes a character
+// can be (game-data/systems/new-character/playable.json). Every other
+// new-character table, the class abilities and the proficiency tables are
+// validated against it.
+type PlayableRegistry struct {
+	Races   []PlayableOption `json:"races"`
+	Classes []PlayableOption `json:"classes"`
+}
+
+// PlayableOption is one race or class. ID is the lowercase key used by
+// per-class data (starting spells, abilities); Name is the display form saves
+// and the capitalized tables use.
+type PlayableOption struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
@@ -34,10 +34,15 @@ type QuestObjective struct {
 // WaitMinutes >0 means the stage doesn't become active until the in-game
 // clock advances by that amount after the previous stage completes (used
 // for "come back tomorrow" pacing).
+//
+// TurnIn=true means the stage only completes when the player hands it in through
+// an NPC's "turn_in_quest" dialogue action; its fetch objectives are checked
+// against the inventory then (and the items taken) rather than on pickup.
 type QuestStage struct {
 	ID          string           `json:"id"`
 	Description string           `json:"description"`
 	WaitMinutes int              `json:"wait_minutes,omitempty"`
+	TurnIn      bool             `json:"turn_in,omitempty"`
 	Objectives  []QuestObjective `json:"objectives"`
 	Rewards     *POIReward       `json:"rewards,omitempty"`
 	UnlocksPOI  string           `json:"unlocks_poi,omitempty"`
@@ -1,5 +1,10 @@
 package types
 
+import (
+	"errors"
+	"fmt"
+)
+
 // GameActionResponse is returned after processing an action
 type GameActionResponse struct {
 	Success bool                   `json:"success"`
@@ -9,6 +14,76 @@ type GameActionResponse struct {
 	Delta   map[string]interface{} `json:"delta,omitempty"` // Only changed fields (for optimization)
 	Data    map[string]interface{} `json:"data,omitempty"`  // Additional response data
 	Error   string                 `json:"error,omitempty"`
+	Events  []GameEvent            `json:"events,omitempty"` // Noteworthy things that happened, in order
+	// ErrorCode is the machine-readable reason a failed action failed (one of
+	// the ErrCode constants), for the client to branch on or localize. Error
+	// and Message still carry the human text.
+	ErrorCode string `json:"error_code,omitempty"`
+}
+
+// Error codes carried in GameActionResponse.ErrorCode.
+const (
+	ErrCodeInventoryFull    = "INVENTORY_FULL"    // No free slot for the item
+	ErrCodeTooHeavy         = "TOO_HEAVY"         // Would put the player over their carry capacity
+	ErrCodeSlotsFull        = "SLOTS_FULL"        // Equipment slots the item needs are taken
+	ErrCodeContainerFull    = "CONTAINER_FULL"    // The container has no free slot
+	ErrCodeItemNotAllowed   = "ITEM_NOT_ALLOWED"  // The slot or container doesn't take that kind of item
+	ErrCodeItemNotFound     = "ITEM_NOT_FOUND"    // Unknown item, or not where the action says it is
+	ErrCodeInsufficientGold = "INSUFFICIENT_GOLD" // The player (or merchant) can't pay
+	ErrCodeOutOfStock       = "OUT_OF_STOCK"      // The merchant doesn't have that many
+	ErrCodeNotInBuilding    = "NOT_IN_BUILDING"   // The action needs the player inside a building
+	ErrCodeNoVault          = "NO_VAULT"          // No vault registered here
+	ErrCodeVaultFull        = "VAULT_FULL"        // The vault has no free slot
+	ErrCodeInCombat         = "IN_COMBAT"         // Not allowed during a fight
+	ErrCodeRevisionConflict = "REVISION_CONFLICT" // The action was made against a stale save revision
+)
+
+// ActionError is an action failure that carries an error code. Handlers return
+// it like any error; the action endpoint copies the code into ErrorCode.
+type ActionError struct {
+	Code    string
+	Message string
+}
+
+func (e *ActionError) Error() string {
+	return e.Message
+}
+
+// NewActionError builds an ActionError with a formatted message.
+func NewActionError(code, format string, args ...interface{}) *ActionError {
+	return &ActionError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// ErrorCodeOf returns the code of the ActionError in err's chain, or "".
+func ErrorCodeOf(err error) string {
+	var actionErr *ActionError
+	if errors.As(err, &actionErr) {
+		return actionErr.Code
+	}
+	return ""
+}
+
+// Game event types carried in GameActionResponse.Events.
+const (
+	EventEffectExpired = "effect_expired" // Data: effect_id, name
+	EventArrived       = "arrived"        // Data: city, city_name, district, newly_discovered
+	EventLevelUp       = "level_up"       // Data: old_level, new_level
+	EventEncounter     = "encounter"      // Data: kind ("combat"/"vignette"), id, name
+	EventItemBroken    = "item_broken"    // Data: item; reserved for item durability
+)
+
+// GameEvent is one typed, noteworthy occurrence during an action — an effect
+// wearing off, an arrival, a level-up — so the client has one ordered stream to
+// drive toasts and the log instead of sniffing ad-hoc Data fields.
+type GameEvent struct {
+	Type    string                 `json:"type"`
+	Message string                 `json:"message,omitempty"`
+	Data    map[string]interface{} `json:"data,omitempty"`
+}
+
+// AddEvent appends a typed event to the response's event stream.
+func (r *GameActionResponse) AddEvent(eventType, message string, data map[string]interface{}) {
+	r.Events = append(r.Events, GameEvent{Type: eventType, Message: message, Data: data})
 }
 
 // EffectMessage contains the message to display when an effect is applied
@@ -9,6 +9,7 @@ type ActiveEffect struct {
 	DelayRemaining    float64 `json:"delay_remaining"`    // Minutes remaining before effect starts
 	TickAccumulator   float64 `json:"tick_accumulator"`   // Time accumulated since last tick (for periodic effects)
 	AppliedAt         int     `json:"applied_at"`         // Time of day (minutes) when effect was applied
+	Source            string  `json:"source,omitempty"`   // Scope that applied it ("environment:<id>", "building:<location>/<id>"); cleared on leaving
 }
 
 // EnrichedEffect combines runtime state with template data for API responses
@@ -66,7 +67,28 @@ type SaveFile struct {
 	// so they never enter QuestsCompleted; this is the non-derivable runtime fact
 	// that gates "already done this period" (schema v3).
 	RepeatableQuests map[string]int `json:"repeatable_quests,omitempty"`
+	// CombatHistory is the encounter journal: one compact record per finished
+	// fight, newest last, capped at combat.MaxEncounterHistory.
+	CombatHistory []EncounterRecord `json:"combat_history,omitempty"`
+	// LootFilter is the player's loot preference: victory loot it rejects is
+	// left on the ground instead of filling the pack. Nil picks up everything.
+	LootFilter    *LootFilter `json:"loot_filter,omitempty"`
+	// LevelApplied is the highest level whose level-up rewards (new spell slots,
+	// unlock report) have been applied — see character.ApplyLevelUp. Zero on
+	// older saves, which counts as level 1.
+	LevelApplied int `json:"level_applied,omitempty"`
+	// GroundItems holds what the player has left on the ground, keyed by spot
+	// (world.GroundKey: location|district|building|room).
+	GroundItems   map[string][]GroundStack `json:"ground_items,omitempty"`
 	SchemaVersion   int             `json:"schema_version,omitempty"`   // Save schema version (see CurrentSchemaVersion)
+	// Revision goes up by one every time the session stores a change. Clients
+	// echo the revision they last saw with each action, so a second tab acting
+	// on stale state is refused instead of overwriting newer progress.
+	Revision int `json:"revision,omitempty"`
+
+	// ConcentrationEffect is the concentration effect the player is holding
+	// (see effects.ConcentratingOn); "" when none.
+	ConcentrationEffect string `json:"concentration_effect,omitempty"`
 
 	InternalID          string                   `json:"-"`                        // Not serialized, used internally for file naming
 	InternalNpub        string                   `json:"-"`                        // Not serialized, used internally for directory structure
@@ -97,6 +119,44 @@ type POIState struct {
 	Cleared    bool   `json:"cleared,omitempty"`
 }
 
+// LootFilter decides which victory loot is picked up automatically. Currency
+// ignores the rarity and value floors; the ignore list applies to everything.
+type LootFilter struct {
+	ManualPickup bool     `json:"manual_pickup,omitempty"` // auto-pickup off: leave all loot on the ground
+	MinRarity    string   `json:"min_rarity,omitempty"`    // skip drops below this rarity ("uncommon" leaves commons)
+	MinValue     int      `json:"min_value,omitempty"`     // skip drops worth less than this apiece (item "value")
+	Ignore       []string `json:"ignore,omitempty"`        // item IDs never picked up
+}
+
+// GroundStack is a stack of an item lying on the ground at one spot.
+type GroundStack struct {
+	Item     string `json:"item"`
+	Quantity int    `json:"quantity"`
+}
+
+// EncounterRecord is one finished fight in SaveFile.CombatHistory. Outcome is
+// "victory", "escaped" or "defeat"; Loot is what the monsters dropped (victories
+// only). Location/Day/Minute are where and when the fight ended.
+type EncounterRecord struct {
+	MonsterIDs  []string   `json:"monster_ids"`
+	Outcome     string     `json:"outcome"`
+	Rounds      int        `json:"rounds"`
+	XP          int        `json:"xp"`
+	Loot        []LootDrop `json:"loot,omitempty"`
+	Environment string     `json:"environment,omitempty"`
+	Location    string     `json:"location"`
+	Day         int        `json:"day"`
+	Minute      int        `json:"minute"`
+}
+
+// FightLog is a recent fight kept on the session with its full combat log, for
+// the combat journal: the same record as CombatHistory plus the round-by-round
+// log that the save doesn't keep.
+type FightLog struct {
+	Encounter EncounterRecord `json:"encounter"`
+	Log       []string        `json:"log"`
+}
+
 // Rental is a paid room the player holds until it expires (in-game day/minute).
 type Rental struct {
 	Building   string `json:"building"`
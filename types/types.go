@@ -65,6 +65,9 @@ type NPCData struct {
 	ShopConfig    map[string]interface{} `json:"shop_config,omitempty"`
 	StorageConfig map[string]interface{} `json:"storage_config,omitempty"`
 	InnConfig     map[string]interface{} `json:"inn_config,omitempty"`
+	// DayOnly NPCs keep daytime hours: at night (gametime.IsNight) they're
+	// nowhere to be found, whatever their schedule says.
+	DayOnly bool `json:"day_only,omitempty"`
 
 	// PrimaryHome is the canonical "home" location ID for an external NPC
 	// (a city, environment, or POI ID). Required for NPCs in game-data/npcs/;
@@ -131,4 +134,6 @@ type ShopTransaction struct {
 	ItemID     string `json:"item_id"`
 	Quantity   int    `json:"quantity"`
 	Action     string `json:"action"` // "buy" or "sell"
+	// Revision is the save revision the client last saw; a stale one is refused with 409.
+	Revision int `json:"revision"`
 }
\ No newline at end of file